@@ -580,6 +580,7 @@ class APIClient:
         compact: bool = False,
         source: str | None = None,
         min_score: float | None = None,
+        archived: str | None = None,
     ) -> list[dict[str, Any]]:
         """Search content using semantic similarity.
 
@@ -589,6 +590,7 @@ class APIClient:
             compact: Return compact format (excludes content and analysis)
             source: Filter by source name (case-insensitive substring match)
             min_score: Minimum relevance score override (None uses server default)
+            archived: Archived content in scope: "no", "yes", or "only" (None uses server default)
 
         Returns:
             List of content items with relevance scores
@@ -605,6 +607,8 @@ class APIClient:
                     params["source"] = source
                 if min_score is not None:
                     params["min_score"] = min_score
+                if archived is not None:
+                    params["archived"] = archived
 
                 response = client.get(
                     f"{self.base_url}/api/search",
@@ -28,6 +28,11 @@ def search(
         "--min-score",
         help="Minimum relevance score (0.0-1.0). Server default is 0.1; pass 0.0 to disable filtering.",
     ),
+    archived: str | None = typer.Option(
+        None,
+        "--archived",
+        help="Archived content in scope: no, yes, or only. Server default is yes.",
+    ),
     output_json: bool = typer.Option(False, "--json", help="Output as JSON"),
 ) -> None:
     """Search content using semantic similarity.
@@ -38,6 +43,7 @@ def search(
         source: Filter results to sources containing this substring
         compact: Return compact format for LLM consumption
         min_score: Minimum relevance score override (None uses server default)
+        archived: Archived content in scope (None uses server default)
         output_json: If True, output raw JSON instead of formatted table
     """
     try:
@@ -49,6 +55,13 @@ def search(
                 console.print("[red]✗ Error: Limit must be between 1 and 50[/red]")
             raise typer.Exit(1)
 
+        if archived is not None and archived not in ("no", "yes", "only"):
+            if not output_json:
+                console.print(
+                    "[red]✗ Error: --archived must be no, yes, or only[/red]"
+                )
+            raise typer.Exit(1)
+
         # Search content
         results = client.search(
             query,
@@ -56,6 +69,7 @@ def search(
             compact=compact,
             source=source,
             min_score=min_score,
+            archived=archived,
         )
 
         if output_json:
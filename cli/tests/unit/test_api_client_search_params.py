@@ -112,3 +112,25 @@ def test_explicit_min_score_sent_as_is() -> None:
     assert params.get("min_score") == 0.15, (
         f"min_score=0.15 must reach API unchanged, got: {params.get('min_score')}"
     )
+
+
+def test_archived_scope_sent_only_when_given() -> None:
+    """
+    INVARIANT: archived is passed through when set and omitted otherwise.
+    BREAKS: `--archived only` searches current content, or the server default is overridden.
+    """
+    client = _make_client()
+    captured: list[dict] = []
+
+    def fake_get(*args, **kwargs):
+        captured.append(kwargs.get("params", {}))
+        return _fake_get_ok()
+
+    with patch.object(httpx.Client, "get", fake_get):
+        client.search("test query")
+        client.search("test query", archived="only")
+
+    assert "archived" not in captured[0], f"Unexpected archived param: {captured[0]}"
+    assert captured[1].get("archived") == "only", (
+        f"archived='only' must reach the API, got: {captured[1]}"
+    )
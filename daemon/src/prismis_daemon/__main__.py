@@ -213,6 +213,13 @@ async def run_scheduler(config: Config, test_mode: bool = False) -> None:
         console.print(
             f"[green]✅ API server running on http://{config.api_host}:8989[/green]"
         )
+
+        # Let `prismis --remote` find this daemon on the LAN
+        from .discovery import start_discovery
+
+        discovery = await start_discovery(config.api_host, 8989)
+        if discovery:
+            console.print("[dim]Discoverable on the LAN as _prismis._tcp[/dim]")
         console.print("[dim]Press Ctrl+C to stop[/dim]\n")
 
         # Wait for shutdown signal
@@ -222,6 +229,8 @@ async def run_scheduler(config: Config, test_mode: bool = False) -> None:
         console.print("[yellow]Stopping scheduler...[/yellow]")
         if scheduler and scheduler.running:
             scheduler.shutdown(wait=True)
+        if discovery:
+            discovery.close()
         console.print("[yellow]Stopping API server...[/yellow]")
         api_server.should_exit = True
         # Give API server a moment to finish in-flight requests
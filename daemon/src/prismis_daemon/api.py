@@ -1,6 +1,7 @@
 """REST API server for Prismis daemon."""
 
 import asyncio
+import json
 import os
 import re
 import time
@@ -11,7 +12,13 @@ from pathlib import Path
 from fastapi import Depends, FastAPI, HTTPException, Query, Request
 from fastapi.exceptions import RequestValidationError
 from fastapi.middleware.cors import CORSMiddleware
-from fastapi.responses import FileResponse, JSONResponse, PlainTextResponse, Response
+from fastapi.responses import (
+    FileResponse,
+    JSONResponse,
+    PlainTextResponse,
+    Response,
+    StreamingResponse,
+)
 from fastapi.staticfiles import StaticFiles
 from rich.console import Console
 
@@ -24,23 +31,44 @@ from .api_errors import (
 )
 from .api_models import (
     APIResponse,
+    ArticleAudioResponse,
+    AskRequest,
+    AudioBriefingRequest,
     AudioBriefingResponse,
+    ContentBatchReadRequest,
     ContentItemModel,
     ContentResponse,
     ContentResponseData,
     ContentUpdateRequest,
+    ContextReanalyzeRequest,
+    ContextTopicBatchRequest,
+    ContextTopicRequest,
+    SourceMergeRequest,
     SourceRequest,
     SourceResponse,
 )
-from .audio import AudioScriptGenerator, LspeakTTSEngine
+from .article_chat import ArticleChat
+from .audio import (
+    AudioScriptGenerator,
+    LspeakTTSEngine,
+    article_narration,
+    briefing_items,
+    get_audio_dir,
+    load_transcript,
+    save_transcript,
+)
 from .auth import verify_api_key
 from .config import Config
 from .context_analyzer import ContextAnalyzer
+from .context_topics import add_topic, add_topics, get_context_path
 from .deep_extractor import CircuitOpenError
+from .discovery import daemon_version
 from .embeddings import Embedder
+from .evaluator import ContentEvaluator
 from .observability import log as obs_log
 from .reports import ReportGenerator
 from .storage import Storage
+from .summarizer import ContentSummarizer
 from .validator import SourceValidator
 
 console = Console()
@@ -436,15 +464,26 @@ async def add_source(
         # Add to database
         source_id = storage.add_source(normalized_url, request.type, name)
 
+        data = {
+            "id": source_id,
+            "url": normalized_url,
+            "type": request.type,
+            "name": name,
+        }
+        if request.category:
+            storage.set_source_category(source_id, request.category)
+            data["category"] = request.category.strip()
+        if request.priority_floor or request.priority_ceiling:
+            storage.set_source_priority_rule(
+                source_id, request.priority_floor, request.priority_ceiling
+            )
+            data["priority_floor"] = request.priority_floor or None
+            data["priority_ceiling"] = request.priority_ceiling or None
+
         return APIResponse(
             success=True,
             message="Source added successfully",
-            data={
-                "id": source_id,
-                "url": normalized_url,
-                "type": request.type,
-                "name": name,
-            },
+            data=data,
         )
 
     except APIError:
@@ -475,6 +514,9 @@ async def get_sources(storage: Storage = Depends(get_storage)) -> dict:
                     last_fetched=source.get("last_fetched_at"),
                     error_count=source.get("error_count", 0),
                     last_error=source.get("last_error"),
+                    category=source.get("category"),
+                    priority_floor=source.get("priority_floor"),
+                    priority_ceiling=source.get("priority_ceiling"),
                 )
             )
 
@@ -488,6 +530,27 @@ async def get_sources(storage: Storage = Depends(get_storage)) -> dict:
         raise ServerError(f"Failed to get sources: {str(e)}") from e
 
 
+@app.get("/api/sources/activity", dependencies=[Depends(verify_api_key)])
+async def get_source_activity(
+    days: int = Query(14, ge=1, le=90, description="Days to cover, ending today"),
+    storage: Storage = Depends(get_storage),
+) -> dict:
+    """Items per source per day (by publish date), oldest day first.
+
+    Feeds the TUI's activity sparklines; sources with nothing in the window
+    are omitted from counts.
+    """
+    try:
+        counts = storage.get_source_daily_counts(days)
+        return {
+            "success": True,
+            "message": f"Retrieved activity for {len(counts)} sources",
+            "data": {"days": days, "counts": counts},
+        }
+    except Exception as e:
+        raise ServerError(f"Failed to get source activity: {str(e)}") from e
+
+
 @app.patch(
     "/api/sources/{source_id}",
     response_model=APIResponse,
@@ -498,9 +561,11 @@ async def update_source(
     request: SourceRequest,
     storage: Storage = Depends(get_storage),
 ) -> APIResponse:
-    """Update a content source (name and/or URL).
+    """Update a content source (name, URL, category, and/or priority rule).
 
-    Updates the source with new name and/or URL.
+    Updates the source with new name and/or URL; an empty category removes
+    the source from its category, and an empty priority_floor or
+    priority_ceiling clears that side of the rule.
     """
     try:
         # Get the existing source first
@@ -540,6 +605,26 @@ async def update_source(
             if not success:
                 raise ServerError("Failed to update source")
 
+        # Category lives in its own tables
+        if request.category is not None and request.category.strip() != (
+            source.get("category") or ""
+        ):
+            storage.set_source_category(source_id, request.category)
+            update_data["category"] = request.category.strip()
+
+        # Priority rule, checked against the rule the source already has
+        if request.priority_floor is not None or request.priority_ceiling is not None:
+            try:
+                storage.set_source_priority_rule(
+                    source_id, request.priority_floor, request.priority_ceiling
+                )
+            except ValueError as e:
+                raise ValidationError(str(e)) from e
+            if request.priority_floor is not None:
+                update_data["priority_floor"] = request.priority_floor
+            if request.priority_ceiling is not None:
+                update_data["priority_ceiling"] = request.priority_ceiling
+
         return APIResponse(
             success=True,
             message="Source updated successfully",
@@ -558,14 +643,25 @@ async def update_source(
     dependencies=[Depends(verify_api_key)],
 )
 async def delete_source(
-    source_id: str, storage: Storage = Depends(get_storage)
+    source_id: str,
+    content: str = Query(
+        "delete",
+        description="What happens to the source's items: 'delete', 'keep' (detached), or 'archive'",
+    ),
+    storage: Storage = Depends(get_storage),
 ) -> APIResponse:
     """Delete a content source.
 
-    This will cascade delete all content from this source.
+    By default this deletes the source's content (favorited items are kept);
+    content=keep detaches the items instead, and content=archive also archives them.
     """
+    if content not in ("delete", "keep", "archive"):
+        raise ValidationError(
+            f"Invalid content mode: {content}. Expected 'delete', 'keep', or 'archive'"
+        )
+
     try:
-        success = storage.remove_source(source_id)
+        success = storage.remove_source(source_id, content)
 
         if not success:
             raise NotFoundError("Source", source_id)
@@ -580,6 +676,39 @@ async def delete_source(
         raise ServerError(f"Failed to remove source: {str(e)}") from e
 
 
+@app.post(
+    "/api/sources/{source_id}/merge",
+    response_model=APIResponse,
+    dependencies=[Depends(verify_api_key)],
+)
+async def merge_sources(
+    source_id: str,
+    request: SourceMergeRequest,
+    storage: Storage = Depends(get_storage),
+) -> APIResponse:
+    """Merge duplicate sources into this one.
+
+    Content of the listed sources moves to this source, then they are deleted.
+    """
+    if source_id in request.source_ids:
+        raise ValidationError("Cannot merge a source into itself")
+    try:
+        moved = storage.merge_sources(source_id, request.source_ids)
+        if moved is None:
+            raise NotFoundError("Source", ", ".join([source_id, *request.source_ids]))
+
+        return APIResponse(
+            success=True,
+            message=f"Merged {len(request.source_ids)} sources",
+            data={"id": source_id, "merged": request.source_ids, "moved": moved},
+        )
+
+    except APIError:
+        raise  # Re-raise our custom errors
+    except Exception as e:
+        raise ServerError(f"Failed to merge sources: {str(e)}") from e
+
+
 @app.patch(
     "/api/entries/{content_id}",
     response_model=APIResponse,
@@ -602,6 +731,7 @@ async def update_content(
             "read": request.read,
             "favorited": request.favorited,
             "interesting_override": request.interesting_override,
+            "pinned": request.pinned,
         }
 
         # Check if user_feedback was explicitly set in the request JSON
@@ -609,6 +739,12 @@ async def update_content(
         if hasattr(request, "user_feedback"):
             update_kwargs["user_feedback"] = request.user_feedback
 
+        if request.user_tags is not None:
+            update_kwargs["user_tags"] = request.user_tags
+
+        if request.snoozed_until is not None:
+            update_kwargs["snoozed_until"] = request.snoozed_until
+
         # Update content status
         success = storage.update_content_status(content_id, **update_kwargs)
 
@@ -631,6 +767,15 @@ async def update_content(
                 "user_feedback": updated_content.get("user_feedback")
                 if updated_content
                 else None,
+                "user_tags": updated_content.get("user_tags", [])
+                if updated_content
+                else [],
+                "snoozed_until": updated_content.get("snoozed_until")
+                if updated_content
+                else None,
+                "pinned": updated_content.get("pinned", False)
+                if updated_content
+                else False,
             },
         )
 
@@ -642,6 +787,31 @@ async def update_content(
         raise ServerError(f"Failed to update content: {str(e)}") from e
 
 
+@app.patch(
+    "/api/entries",
+    response_model=APIResponse,
+    dependencies=[Depends(verify_api_key)],
+)
+async def update_content_batch(
+    request: ContentBatchReadRequest,
+    storage: Storage = Depends(get_storage),
+) -> APIResponse:
+    """Mark many items read or unread in one transaction.
+
+    Used by the TUI's :markall and its undo. Unknown IDs are skipped.
+    """
+    try:
+        updated = storage.set_read_batch(request.ids, request.read)
+        return APIResponse(
+            success=True,
+            message=f"Marked {updated} items {'read' if request.read else 'unread'}",
+            data={"updated": updated, "read": request.read},
+        )
+
+    except Exception as e:
+        raise ServerError(f"Failed to update content: {str(e)}") from e
+
+
 @app.patch(
     "/api/sources/{source_id}/pause",
     response_model=APIResponse,
@@ -711,10 +881,17 @@ async def get_content(
     ),
     unread_only: bool = Query(False),
     include_archived: bool = Query(False),
+    archived: str | None = Query(
+        None,
+        description="Archived content in scope: 'no', 'yes' (alongside active), or 'only'; overrides include_archived",
+    ),
     interesting_override: bool | None = Query(
         None, description="Filter by interesting_override flag"
     ),
     limit: int = Query(50, le=10000, ge=1),
+    offset: int = Query(
+        0, ge=0, description="Items to skip in the sorted results (paging)"
+    ),
     since: str | None = Query(None, description="ISO8601 timestamp to filter content"),
     since_hours: int | None = Query(
         None, ge=1, le=720, description="Hours to look back (convenience parameter)"
@@ -733,6 +910,10 @@ async def get_content(
         True,
         description="Skip fuzzy title deduplication (default: True for faster responses)",
     ),
+    exclude_content: bool = Query(
+        False,
+        description="Leave out the article text (content), sending content_length instead; fetch it per entry with ?include=content",
+    ),
     storage: Storage = Depends(get_storage),
 ) -> dict:
     """Get content items with optional filtering.
@@ -742,8 +923,11 @@ async def get_content(
                  comma-separated ('high,medium,low')
         unread_only: Only return unread items (default: False)
         include_archived: Include archived content (default: False)
+        archived: Archived content in scope: 'no', 'yes', or 'only'. When given,
+                  it replaces include_archived
         interesting_override: Filter by interesting_override flag (default: None)
         limit: Maximum number of items to return (1-10000, default: 50)
+        offset: Items to skip in the sorted results, for paging (default: 0)
         since: ISO8601 timestamp to filter content (e.g., '2025-11-05T12:00:00Z')
         since_hours: Hours to look back (1-720). Convenience parameter - converted to timestamp.
                      If neither since nor since_hours provided, returns all content.
@@ -751,6 +935,7 @@ async def get_content(
         source: Filter results to sources containing this substring (case-insensitive)
         compact: Return compact format for LLM consumption
         skip_dedup: Skip fuzzy title deduplication (default: True for faster responses)
+        exclude_content: Leave out content, adding content_length (list views)
         storage: Storage instance injected by FastAPI
 
     Returns:
@@ -766,6 +951,13 @@ async def get_content(
                 f"Invalid priority value(s): {', '.join(invalid)}. Must be one of: high, medium, low"
             )
 
+    if archived is not None:
+        if archived not in ("no", "yes", "only"):
+            raise ValidationError(
+                f"Invalid archived scope: {archived}. Expected 'no', 'yes', or 'only'"
+            )
+        include_archived = archived != "no"
+
     # Validate sort_by parameter
     valid_sort_options = ["priority", "date", "unread"]
     effective_sort = sort_by if sort_by in valid_sort_options else "priority"
@@ -784,28 +976,47 @@ async def get_content(
                 ) from e
 
         content_items = []
+        # Storage limits count from the first item, so pages need offset + limit
+        window = offset + limit
+        # Archived-only views (the TUI's v key in remote mode) are scoped in
+        # storage, before any limit
+        archived_only = archived == "only"
+        # Set by paths whose storage calls stop at window
+        total: int | None = None
 
         # Handle interesting_override filter first (takes precedence)
         if interesting_override is True:
-            content_items = storage.get_flagged_items(limit)
+            # Flagged items are never archived
+            content_items = [] if archived_only else storage.get_flagged_items(window)
         elif priorities:
             # Get content by specific priority/priorities
             if unread_only:
                 # Call storage for each priority and combine results
                 for p in priorities:
-                    remaining = limit - len(content_items)
+                    remaining = window - len(content_items)
                     if remaining <= 0:
                         break
                     items = storage.get_content_by_priority(
-                        p, remaining, include_archived, source_filter=source
+                        p,
+                        remaining,
+                        include_archived,
+                        source_filter=source,
+                        archived_only=archived_only,
                     )
                     content_items.extend(items)
+                total = storage.count_unread_by_priority(
+                    priorities,
+                    include_archived,
+                    source_filter=source,
+                    archived_only=archived_only,
+                )
             else:
                 # Get content with time filter, then filter by priorities
                 all_content = storage.get_content_since(
                     since=since_dt,
                     include_archived=include_archived,
                     source_filter=source,
+                    archived_only=archived_only,
                 )
                 content_items = [
                     item for item in all_content if item.get("priority") in priorities
@@ -815,9 +1026,13 @@ async def get_content(
             if unread_only:
                 # Get unread from all priorities, respecting limit
                 high_items = storage.get_content_by_priority(
-                    "high", limit, include_archived, source_filter=source
+                    "high",
+                    window,
+                    include_archived,
+                    source_filter=source,
+                    archived_only=archived_only,
                 )
-                remaining_limit = limit - len(high_items)
+                remaining_limit = window - len(high_items)
 
                 medium_items = []
                 low_items = []
@@ -827,21 +1042,33 @@ async def get_content(
                         remaining_limit,
                         include_archived,
                         source_filter=source,
+                        archived_only=archived_only,
                     )
                     remaining_limit = remaining_limit - len(medium_items)
 
                 if remaining_limit > 0:
                     low_items = storage.get_content_by_priority(
-                        "low", remaining_limit, include_archived, source_filter=source
+                        "low",
+                        remaining_limit,
+                        include_archived,
+                        source_filter=source,
+                        archived_only=archived_only,
                     )
 
                 content_items = high_items + medium_items + low_items
+                total = storage.count_unread_by_priority(
+                    ["high", "medium", "low"],
+                    include_archived,
+                    source_filter=source,
+                    archived_only=archived_only,
+                )
             else:
                 # Get all content (or filtered by time if since/since_hours provided)
                 all_content = storage.get_content_since(
                     since=since_dt,
                     include_archived=include_archived,
                     source_filter=source,
+                    archived_only=archived_only,
                 )
                 content_items = all_content
 
@@ -852,6 +1079,9 @@ async def get_content(
 
         priority_order = {"high": 0, "medium": 1, "low": 2, None: 3}
 
+        # Break ties by id first so pages come out in the same order every request
+        content_items.sort(key=lambda x: x.get("id") or "")
+
         if effective_sort == "date":
             # Sort by published_at descending (newest first)
             content_items.sort(key=get_date, reverse=True)
@@ -875,8 +1105,13 @@ async def get_content(
             dedup_cap = min(len(content_items), 200)
             content_items = deduplicate_content(content_items[:dedup_cap])
 
-        # Apply limit AFTER deduplication to ensure duplicates are properly grouped
-        content_items = content_items[:limit]
+        # Apply offset and limit AFTER deduplication to ensure duplicates are properly grouped.
+        # total counts the matches before paging, so clients know how many pages remain;
+        # unread_only queries stop at offset + limit, so they count in storage
+        # (deduplicated results count what's left after grouping)
+        if total is None or not skip_dedup:
+            total = len(content_items)
+        content_items = content_items[offset:window]
 
         # Filter to compact fields if requested
         if compact:
@@ -896,6 +1131,11 @@ async def get_content(
                 for item in content_items
             ]
 
+        # List views load the article text per entry when it's opened
+        if exclude_content:
+            for item in content_items:
+                item["content_length"] = len(item.pop("content", None) or "")
+
         # INV-API-TS-4: route through Pydantic model so @field_serializer fires
         # on every datetime field. mode="json" produces a dict whose datetime
         # fields are already RFC3339 strings — FastAPI then JSON-encodes the dict
@@ -905,18 +1145,21 @@ async def get_content(
             message=f"Retrieved {len(content_items)} content items",
             data=ContentResponseData(
                 items=[ContentItemModel(**item) for item in content_items],
-                total=len(content_items),
+                total=total,
                 filters_applied={
                     "priority": priority,
                     "unread_only": unread_only,
                     "include_archived": include_archived,
+                    "archived": archived,
                     "interesting_override": interesting_override,
                     "limit": limit,
+                    "offset": offset,
                     "since": since,
                     "since_hours": since_hours,
                     "sort_by": effective_sort,
                     "source": source,
                     "compact": compact,
+                    "exclude_content": exclude_content,
                 },
             ),
         ).model_dump(mode="json")
@@ -941,6 +1184,10 @@ async def semantic_search(
     compact: bool = Query(
         False, description="Return compact format (excludes content and analysis)"
     ),
+    archived: str = Query(
+        "yes",
+        description="Archived content in scope: 'no', 'yes' (default, alongside active), or 'only'",
+    ),
     storage: Storage = Depends(get_storage),
 ) -> dict:
     """Semantic search across all content using embeddings.
@@ -955,11 +1202,17 @@ async def semantic_search(
             filters near-zero noise; pass 0.0 to disable)
         source: Filter results to sources containing this substring (case-insensitive)
         compact: Return compact format for LLM consumption
+        archived: Archived content in scope: 'no', 'yes' (default), or 'only'
         storage: Storage instance injected by FastAPI
 
     Returns:
         JSON response with ranked search results including relevance_score
     """
+    if archived not in ("no", "yes", "only"):
+        raise ValidationError(
+            f"Invalid archived scope: {archived}. Expected 'no', 'yes', or 'only'"
+        )
+
     try:
         # Initialize embedder and generate query embedding
         embedder = Embedder()
@@ -971,6 +1224,7 @@ async def semantic_search(
             limit=limit,
             min_score=min_score,
             source_filter=source,
+            archived=archived,
         )
 
         # Filter to compact fields if requested
@@ -1003,6 +1257,7 @@ async def semantic_search(
                     "min_score": min_score,
                     "source": source,
                     "compact": compact,
+                    "archived": archived,
                 },
             ),
         ).model_dump(mode="json")
@@ -1201,6 +1456,178 @@ async def extract_entry(
         }
 
 
+@app.post("/api/entries/{content_id}/summarize", dependencies=[Depends(verify_api_key)])
+async def summarize_entry(
+    content_id: str,
+    storage: Storage = Depends(get_storage),
+    config: Config = Depends(get_config),
+) -> dict:
+    """Summarize an entry that ingestion left without a reading summary.
+
+    Idempotent: an entry that already has `analysis.reading_summary` is
+    returned as-is without an LLM call. The new summary fields are merged
+    into the existing analysis so fetcher metrics, matched interests, and
+    deep extraction survive.
+
+    Args:
+        content_id: UUID of the content entry
+        storage: Storage instance injected by FastAPI
+        config: Config instance injected by FastAPI
+
+    Returns:
+        JSON with the entry's summary and full analysis
+
+    Raises:
+        NotFoundError: If entry with given ID doesn't exist
+        ValidationError: If the entry has no content to summarize
+        ServerError: If the summarizer fails
+    """
+    entry = storage.get_content_by_id(content_id)
+    if not entry:
+        raise NotFoundError("Entry", content_id)
+
+    analysis = entry.get("analysis") or {}
+    if analysis.get("reading_summary"):
+        return {
+            "success": True,
+            "message": "Existing summary returned",
+            "data": {"summary": entry.get("summary") or "", "analysis": analysis},
+        }
+
+    content = entry.get("content") or ""
+    if not content.strip():
+        raise ValidationError("Entry has no content to summarize")
+
+    summarizer = ContentSummarizer(config.llm_light_service)
+    try:
+        result = await asyncio.to_thread(
+            summarizer.summarize_with_analysis,
+            content=content,
+            title=entry.get("title") or "",
+            url=entry.get("url") or "",
+            source_type=entry.get("source_type") or "",
+            source_name=entry.get("source_name") or "",
+            metadata=analysis.get("metrics", {}),
+        )
+    except Exception as e:
+        obs_log("api.error", endpoint="/api/entries/summarize", error=str(e))
+        raise ServerError(f"Summarization failed: {e}") from e
+
+    if not result:
+        raise ServerError("Summarization produced no output")
+
+    analysis.update(
+        {
+            "reading_summary": result.reading_summary,
+            "alpha_insights": result.alpha_insights,
+            "patterns": result.patterns,
+            "entities": result.entities,
+            "quotes": result.quotes,
+            "tools": result.tools,
+            "urls": result.urls,
+            "metadata": result.metadata,
+        }
+    )
+    storage.update_summary(content_id, result.summary, analysis)
+
+    return {
+        "success": True,
+        "message": "Summary generated",
+        "data": {"summary": result.summary, "analysis": analysis},
+    }
+
+
+@app.post("/api/entries/{content_id}/ask", dependencies=[Depends(verify_api_key)])
+async def ask_entry(
+    content_id: str,
+    request: AskRequest,
+    storage: Storage = Depends(get_storage),
+    config: Config = Depends(get_config),
+) -> StreamingResponse:
+    """Answer a question about an entry, streamed as newline-delimited JSON.
+
+    Each line is one event: {"delta": "..."} for answer text, then a final
+    {"done": true}, or {"error": "..."} if the LLM call fails mid-stream.
+
+    Args:
+        content_id: UUID of the content entry
+        request: The question and the earlier turns of the conversation
+        storage: Storage instance injected by FastAPI
+        config: Config instance injected by FastAPI
+
+    Returns:
+        application/x-ndjson stream of answer events
+
+    Raises:
+        NotFoundError: If entry with given ID doesn't exist
+    """
+    entry = storage.get_content_by_id(content_id)
+    if not entry:
+        raise NotFoundError("Entry", content_id)
+
+    chat = ArticleChat(config.llm_light_service)
+    history = [turn.model_dump() for turn in request.history]
+
+    async def events():
+        # llm_core.complete has no streaming mode, so the answer arrives as a
+        # single delta. Clients consume deltas incrementally either way.
+        try:
+            answer = await asyncio.to_thread(
+                chat.answer, entry, request.question, history
+            )
+        except Exception as e:
+            obs_log("api.error", endpoint="/api/entries/ask", error=str(e))
+            yield json.dumps({"error": str(e)}) + "\n"
+            return
+        yield json.dumps({"delta": answer}) + "\n"
+        yield json.dumps({"done": True}) + "\n"
+
+    return StreamingResponse(events(), media_type="application/x-ndjson")
+
+
+# Bumped on incompatible changes to existing endpoints
+API_VERSION = 1
+
+# Optional capabilities a client should check before offering them. Clients
+# treat a daemon without /api/version as having none of these.
+FEATURES = [
+    "audio",
+    "prune",
+    "interesting",
+    "activity",
+    "merge",
+    "extract",
+    "summarize",
+    "ask",
+    "context",
+    "context_batch",
+    "archive",
+    "feedback",
+    "source_retention",
+    "entries_offset",
+    "categories",
+    "source_rules",
+]
+
+
+@app.get("/api/version")
+async def get_version() -> dict:
+    """Version handshake: daemon version, API version, and supported features.
+
+    No auth required, like /health, so clients can negotiate before a key
+    problem masks what the daemon supports.
+    """
+    return {
+        "success": True,
+        "message": "Version retrieved",
+        "data": {
+            "version": daemon_version(),
+            "api_version": API_VERSION,
+            "features": FEATURES,
+        },
+    }
+
+
 @app.get("/health")
 async def health_check(storage: Storage = Depends(get_storage)) -> dict:
     """Health check endpoint that verifies database connectivity (no auth required)."""
@@ -1309,15 +1736,19 @@ async def count_unprioritized(
 
 @app.post("/api/audio/briefings", dependencies=[Depends(verify_api_key)])
 async def generate_audio_briefing(
+    request: AudioBriefingRequest | None = None,
     storage: Storage = Depends(get_storage),
     config: Config = Depends(get_config),
 ) -> dict:
-    """Generate audio briefing from HIGH priority content.
+    """Generate audio briefing from prioritized content.
 
     Creates a Jarvis-style audio briefing using lspeak TTS engine.
     Generation is blocking and takes 10-30 seconds depending on content.
+    Without a body, covers the last 24 hours of HIGH priority items in the
+    configured voice.
 
     Args:
+        request: Optional priority floor, look-back window, voice, and item cap
         storage: Storage instance injected by FastAPI
         config: Config instance injected by FastAPI
 
@@ -1325,35 +1756,30 @@ async def generate_audio_briefing(
         JSON response with file path and metadata
 
     Raises:
-        ValidationError: If no HIGH priority content available
+        ValidationError: If no content at the requested priority is available
         ServerError: If lspeak not installed or generation fails
     """
+    request = request or AudioBriefingRequest()
     try:
-        # Generate daily report (this gets HIGH priority items)
         generator = ReportGenerator(storage)
-        report = generator.generate_daily_report(hours=24)
+        report = generator.generate_daily_report(hours=request.hours)
 
-        # Check if we have HIGH priority content
-        if not report.high_priority:
+        items = briefing_items(report, request.min_priority, request.max_items)
+        if not items:
+            label = "high" if request.min_priority == "high" else request.min_priority + "+"
             raise ValidationError(
-                "No high priority content available for briefing. "
-                "Add content sources or adjust prioritization context."
+                f"No {label} priority content available for briefing in the last "
+                f"{request.hours}h. Add content sources or adjust prioritization context."
             )
 
         # Generate conversational script using LLM
         script_gen = AudioScriptGenerator(config)
-        script = script_gen.generate_script(report)
-
-        # Set up output path
-        import os
-        from datetime import UTC, datetime
+        script = script_gen.generate_script(
+            report, request.min_priority, request.max_items
+        )
 
         # Use ~/.local/share/prismis/audio for output
-        audio_dir = (
-            Path(os.environ.get("XDG_DATA_HOME", str(Path.home() / ".local/share")))
-            / "prismis"
-            / "audio"
-        )
+        audio_dir = get_audio_dir()
         audio_dir.mkdir(parents=True, exist_ok=True)
 
         # Date-based filename
@@ -1363,10 +1789,14 @@ async def generate_audio_briefing(
 
         # Generate audio using lspeak
         tts_engine = LspeakTTSEngine(
-            provider=config.audio_provider, voice=config.audio_voice
+            provider=config.audio_provider, voice=request.voice or config.audio_voice
         )
         tts_engine.generate(script, output_path)
 
+        # Keep the script and its sources so clients can read the briefing
+        generated_at = datetime.now(UTC)
+        save_transcript(output_path, script, items, generated_at.isoformat())
+
         return {
             "success": True,
             "message": f"Audio briefing generated: {filename}",
@@ -1374,9 +1804,10 @@ async def generate_audio_briefing(
                 file_path=str(output_path),
                 filename=filename,
                 duration_estimate="2-5 minutes",
-                generated_at=datetime.now(UTC),
+                generated_at=generated_at,
                 provider=config.audio_provider,
                 high_priority_count=len(report.high_priority),
+                item_count=len(items),
             ).model_dump(mode="json"),
         }
 
@@ -1396,6 +1827,116 @@ async def generate_audio_briefing(
         raise ServerError(f"Failed to generate audio briefing: {str(e)}") from e
 
 
+@app.post("/api/entries/{content_id}/audio", dependencies=[Depends(verify_api_key)])
+async def generate_article_audio(
+    content_id: str,
+    storage: Storage = Depends(get_storage),
+    config: Config = Depends(get_config),
+) -> dict:
+    """Narrate a single article with the configured TTS provider.
+
+    The file is named after the entry, so repeat requests reuse it instead of
+    paying for TTS again. Generation is blocking and can take minutes for
+    long-form pieces.
+
+    Args:
+        content_id: UUID of the content entry
+        storage: Storage instance injected by FastAPI
+        config: Config instance injected by FastAPI
+
+    Returns:
+        JSON response with file path and metadata
+
+    Raises:
+        NotFoundError: If entry with given ID doesn't exist
+        ValidationError: If the entry has no content to narrate
+        ServerError: If lspeak not installed or generation fails
+    """
+    entry = storage.get_content_by_id(content_id)
+    if not entry:
+        raise NotFoundError("Entry", content_id)
+
+    filename = f"article-{content_id}.mp3"
+    output_path = get_audio_dir() / filename
+    cached = output_path.exists()
+
+    if not cached:
+        try:
+            script = article_narration(entry)
+        except ValueError as e:
+            raise ValidationError(str(e)) from e
+
+        try:
+            tts_engine = LspeakTTSEngine(
+                provider=config.audio_provider, voice=config.audio_voice
+            )
+            await asyncio.to_thread(tts_engine.generate, script, output_path)
+        except RuntimeError as e:
+            error_msg = str(e)
+            if "lspeak not found" in error_msg:
+                raise ServerError(
+                    "lspeak is not installed. Install with: "
+                    "uv tool install git+https://github.com/nickpending/lspeak.git"
+                ) from e
+            raise ServerError(f"Audio generation failed: {error_msg}") from e
+
+    return {
+        "success": True,
+        "message": f"Article audio {'reused' if cached else 'generated'}: {filename}",
+        "data": ArticleAudioResponse(
+            file_path=str(output_path),
+            filename=filename,
+            cached=cached,
+            provider=config.audio_provider,
+        ).model_dump(),
+    }
+
+
+@app.get("/api/audio/briefings/transcript", dependencies=[Depends(verify_api_key)])
+async def get_briefing_transcript(
+    filename: str | None = Query(None, description="Briefing MP3 filename"),
+) -> dict:
+    """Get a briefing's script and the items it covered.
+
+    Args:
+        filename: Briefing filename from the generation response; the most
+            recent briefing when omitted
+
+    Returns:
+        JSON response with the script, generation time, and source items
+
+    Raises:
+        NotFoundError: If no transcript exists for the briefing
+    """
+    transcript = load_transcript(filename)
+    if transcript is None:
+        raise NotFoundError("Transcript", filename or "latest")
+    return {
+        "success": True,
+        "message": f"Transcript for {transcript.get('filename', filename)}",
+        "data": transcript,
+    }
+
+
+@app.get("/api/audio/files/{filename}", dependencies=[Depends(verify_api_key)])
+async def get_audio_file(filename: str) -> FileResponse:
+    """Download a generated audio file, for clients on another machine.
+
+    Args:
+        filename: Bare filename from an audio generation response
+
+    Returns:
+        The MP3 file
+
+    Raises:
+        NotFoundError: If no such file exists in the audio directory
+    """
+    path = get_audio_dir() / filename
+    if Path(filename).name != filename or not path.is_file():
+        raise NotFoundError("Audio file", filename)
+    return FileResponse(path, media_type="audio/mpeg", filename=filename)
+
+
 @app.get("/api/archive/status", dependencies=[Depends(verify_api_key)])
 async def archive_status(
     storage: Storage = Depends(get_storage),
@@ -1490,6 +2031,156 @@ async def analyze_context(
         raise ServerError(f"Failed to analyze context: {str(e)}") from e
 
 
+@app.post("/api/context/topics", dependencies=[Depends(verify_api_key)])
+async def add_context_topic(request: ContextTopicRequest) -> dict:
+    """Add a topic to a section of context.md.
+
+    The next fetch cycle (and /api/context/reanalyze) evaluates against the
+    updated file, since Config re-reads context.md on every load.
+
+    Args:
+        request: Topic text and target section
+
+    Returns:
+        JSON response with whether the topic was added or already present
+
+    Raises:
+        ValidationError: If the section is unknown
+        ServerError: If context.md can't be written
+    """
+    context_path = get_context_path()
+    try:
+        current = context_path.read_text() if context_path.exists() else ""
+        updated, added = add_topic(current, request.topic, request.section)
+        if added:
+            context_path.parent.mkdir(parents=True, exist_ok=True)
+            tmp_path = context_path.with_suffix(".md.tmp")
+            tmp_path.write_text(updated)
+            tmp_path.replace(context_path)
+    except ValueError as e:
+        raise ValidationError(str(e)) from e
+    except OSError as e:
+        obs_log("api.error", endpoint="/api/context/topics", error=str(e))
+        raise ServerError(f"Failed to update context.md: {str(e)}") from e
+
+    return {
+        "success": True,
+        "message": f"Added '{request.topic}' to {request.section}"
+        if added
+        else f"'{request.topic}' is already in context.md",
+        "data": {"topic": request.topic, "section": request.section, "added": added},
+    }
+
+
+@app.post("/api/context/topics/batch", dependencies=[Depends(verify_api_key)])
+async def add_context_topics(request: ContextTopicBatchRequest) -> dict:
+    """Add several topics to context.md with a single write.
+
+    The TUI's flag review accepts topics one by one and applies them
+    together, so the daemon never evaluates against a half-updated file.
+
+    Args:
+        request: Topics and their target sections, in order
+
+    Returns:
+        JSON response with whether each topic was added or already present
+
+    Raises:
+        ValidationError: If a section is unknown
+        ServerError: If context.md can't be written
+    """
+    context_path = get_context_path()
+    try:
+        current = context_path.read_text() if context_path.exists() else ""
+        updated, added = add_topics(
+            current, [(t.topic, t.section) for t in request.topics]
+        )
+        if any(added):
+            context_path.parent.mkdir(parents=True, exist_ok=True)
+            tmp_path = context_path.with_suffix(".md.tmp")
+            tmp_path.write_text(updated)
+            tmp_path.replace(context_path)
+    except ValueError as e:
+        raise ValidationError(str(e)) from e
+    except OSError as e:
+        obs_log("api.error", endpoint="/api/context/topics/batch", error=str(e))
+        raise ServerError(f"Failed to update context.md: {str(e)}") from e
+
+    count = sum(added)
+    return {
+        "success": True,
+        "message": f"Added {count} of {len(added)} topics",
+        "data": {
+            "topics": [
+                {"topic": t.topic, "section": t.section, "added": a}
+                for t, a in zip(request.topics, added, strict=True)
+            ],
+            "added": count,
+        },
+    }
+
+
+@app.post("/api/context/reanalyze", dependencies=[Depends(verify_api_key)])
+async def reanalyze_unprioritized(
+    request: ContextReanalyzeRequest,
+    storage: Storage = Depends(get_storage),
+    config: Config = Depends(get_config),
+) -> dict:
+    """Re-evaluate recent unprioritized items against the current context.md.
+
+    Items the evaluator now prioritizes get their priority set and the new
+    matched interests and reasoning merged into their analysis. Items that
+    stay unprioritized are left untouched.
+
+    Args:
+        request: Look-back window and item cap
+        storage: Storage instance injected by FastAPI
+        config: Config instance injected by FastAPI (fresh context.md)
+
+    Returns:
+        JSON response with evaluated and prioritized counts
+
+    Raises:
+        ServerError: If the evaluator fails
+    """
+    try:
+        items = storage.get_recent_unprioritized(request.days, request.limit)
+        evaluator = ContentEvaluator(config.llm_light_service)
+
+        prioritized: dict[str, int] = {}
+        for item in items:
+            evaluation = await asyncio.to_thread(
+                evaluator.evaluate_content,
+                content=item["content"] or "",
+                title=item["title"] or "",
+                url=item["url"] or "",
+                context=config.context,
+            )
+            if evaluation.priority is None:
+                continue
+            priority = evaluation.priority.value
+            analysis = item["analysis"]
+            analysis["matched_interests"] = evaluation.matched_interests
+            analysis["priority_reasoning"] = evaluation.reasoning
+            storage.update_priority(item["id"], priority, analysis)
+            prioritized[priority] = prioritized.get(priority, 0) + 1
+
+        total = sum(prioritized.values())
+        return {
+            "success": True,
+            "message": f"Re-evaluated {len(items)} items, {total} now prioritized",
+            "data": {
+                "evaluated": len(items),
+                "prioritized": total,
+                "by_priority": prioritized,
+            },
+        }
+
+    except Exception as e:
+        obs_log("api.error", endpoint="/api/context/reanalyze", error=str(e))
+        raise ServerError(f"Failed to re-analyze content: {str(e)}") from e
+
+
 @app.get("/api/statistics", dependencies=[Depends(verify_api_key)])
 async def get_statistics(
     storage: Storage = Depends(get_storage),
@@ -3,7 +3,15 @@
 from datetime import datetime
 from typing import Any, Literal, overload
 
-from pydantic import BaseModel, Field, field_serializer, field_validator
+from pydantic import (
+    BaseModel,
+    Field,
+    field_serializer,
+    field_validator,
+    model_validator,
+)
+
+from .models import PRIORITY_ORDER
 
 
 @overload
@@ -30,6 +38,15 @@ class SourceRequest(BaseModel):
         ..., description="Type of source"
     )
     name: str | None = Field(None, description="Optional custom name for the source")
+    category: str | None = Field(
+        None, description="Category to file the source under; empty string clears it"
+    )
+    priority_floor: Literal["high", "medium", "low", ""] | None = Field(
+        None, description="Lowest priority the source's items get; empty clears it"
+    )
+    priority_ceiling: Literal["high", "medium", "low", ""] | None = Field(
+        None, description="Highest priority the source's items get; empty clears it"
+    )
 
     @field_validator("url", mode="before")
     def validate_url(cls, v: str) -> str:
@@ -39,6 +56,18 @@ class SourceRequest(BaseModel):
             raise ValueError("URL cannot be empty")
         return v
 
+    @model_validator(mode="after")
+    def validate_priority_rule(self) -> "SourceRequest":
+        """A floor above the ceiling could never be met."""
+        if (
+            self.priority_floor
+            and self.priority_ceiling
+            and PRIORITY_ORDER.index(self.priority_floor)
+            > PRIORITY_ORDER.index(self.priority_ceiling)
+        ):
+            raise ValueError("priority_floor is above priority_ceiling")
+        return self
+
 
 class APIResponse(BaseModel):
     """Standard API response format."""
@@ -59,6 +88,13 @@ class SourceResponse(BaseModel):
     last_fetched: datetime | None = Field(None, description="Last fetch timestamp")
     error_count: int = Field(0, description="Number of consecutive errors")
     last_error: str | None = Field(None, description="Last error message")
+    category: str | None = Field(None, description="Category the source is filed under")
+    priority_floor: str | None = Field(
+        None, description="Lowest priority the source's items get"
+    )
+    priority_ceiling: str | None = Field(
+        None, description="Highest priority the source's items get"
+    )
 
     @field_serializer("last_fetched")
     def _serialize_last_fetched(self, v: datetime | None) -> str | None:
@@ -84,6 +120,110 @@ class ContentUpdateRequest(BaseModel):
         None,
         description="User feedback: 'up' for useful, 'down' for not useful, null to clear",
     )
+    user_tags: list[str] | None = Field(
+        None, description="Replace the user's own tags (empty list clears them)"
+    )
+    snoozed_until: datetime | None = Field(
+        None, description="Hide the item in the TUI until this time"
+    )
+    pinned: bool | None = Field(None, description="Pin/unpin to the top of the TUI")
+
+
+class ContentBatchReadRequest(BaseModel):
+    """Request model for setting read status on many items at once."""
+
+    ids: list[str] = Field(
+        ..., min_length=1, max_length=10000, description="Content IDs to update"
+    )
+    read: bool = Field(..., description="Mark as read/unread")
+
+
+class SourceMergeRequest(BaseModel):
+    """Request model for folding duplicate sources into one."""
+
+    source_ids: list[str] = Field(
+        ..., min_length=1, max_length=100, description="Sources merged away"
+    )
+
+
+class ContextTopicRequest(BaseModel):
+    """Request model for adding a topic to context.md."""
+
+    topic: str = Field(..., description="Topic text to add as a bullet")
+    section: Literal["high", "medium", "low", "not_interested"] = Field(
+        "high", description="context.md section to add the topic to"
+    )
+
+    @field_validator("topic", mode="before")
+    def validate_topic(cls, v: str) -> str:
+        """Collapse whitespace and reject empty topics."""
+        v = " ".join(str(v).split())
+        if not v:
+            raise ValueError("Topic cannot be empty")
+        return v
+
+
+class ContextTopicBatchRequest(BaseModel):
+    """Request model for adding several topics to context.md in one write."""
+
+    topics: list[ContextTopicRequest] = Field(
+        ..., min_length=1, max_length=100, description="Topics to add, in order"
+    )
+
+
+class ContextReanalyzeRequest(BaseModel):
+    """Request model for re-evaluating recent unprioritized content."""
+
+    days: int = Field(7, ge=1, le=90, description="How far back to look")
+    limit: int = Field(50, ge=1, le=200, description="Maximum items to evaluate")
+
+
+class ChatTurn(BaseModel):
+    """One earlier turn of an article conversation."""
+
+    role: Literal["user", "assistant"] = Field(..., description="Who spoke")
+    content: str = Field(..., description="What was said")
+
+
+class AskRequest(BaseModel):
+    """Request model for asking a question about an entry."""
+
+    question: str = Field(..., description="Question about the article")
+    history: list[ChatTurn] = Field(
+        default_factory=list, description="Earlier turns, oldest first"
+    )
+
+    @field_validator("question", mode="before")
+    def validate_question(cls, v: str) -> str:
+        """Reject empty questions."""
+        v = str(v).strip()
+        if not v:
+            raise ValueError("Question cannot be empty")
+        return v
+
+
+class ArticleAudioResponse(BaseModel):
+    """Response model for per-article audio generation."""
+
+    file_path: str = Field(..., description="Path to generated audio file")
+    filename: str = Field(..., description="Filename of generated audio")
+    cached: bool = Field(..., description="True when an earlier file was reused")
+    provider: str = Field(..., description="TTS provider used")
+
+
+class AudioBriefingRequest(BaseModel):
+    """Optional parameters for audio briefing generation."""
+
+    min_priority: Literal["high", "medium", "low"] = Field(
+        "high", description="Lowest priority to include"
+    )
+    hours: int = Field(24, ge=1, le=168, description="How far back to look")
+    voice: str | None = Field(
+        None, description="TTS voice, overriding [audio] voice in config.toml"
+    )
+    max_items: int | None = Field(
+        None, ge=1, le=50, description="Maximum items to cover"
+    )
 
 
 class AudioBriefingResponse(BaseModel):
@@ -97,6 +237,7 @@ class AudioBriefingResponse(BaseModel):
     generated_at: datetime = Field(..., description="Generation timestamp")
     provider: str = Field(..., description="TTS provider used")
     high_priority_count: int = Field(..., description="Number of HIGH priority items")
+    item_count: int = Field(0, description="Number of items the briefing covers")
 
     @field_serializer("generated_at")
     def _serialize_generated_at(self, v: datetime) -> str:
@@ -129,17 +270,23 @@ class ContentItemModel(BaseModel):
     favorited: bool = False
     interesting_override: bool = False
     user_feedback: str | None = None
+    user_tags: list[str] = Field(default_factory=list)
+    snoozed_until: datetime | None = None
+    pinned: bool = False
+    author: str | None = None
     notes: str | None = None
     archived_at: datetime | None = None
     created_at: datetime | None = None
     updated_at: datetime | None = None
+    # Set instead of content when a list leaves the text out (exclude_content)
+    content_length: int | None = None
     # Search-only fields (None for non-search paths)
     relevance_score: float | None = None
     # Deduplication fields (None when dedup not applied)
     duplicate_count: int | None = None
     duplicate_sources: list[str] | None = None
 
-    @field_serializer("published_at", "fetched_at", "archived_at")
+    @field_serializer("published_at", "fetched_at", "archived_at", "snoozed_until")
     def _serialize_published_fetched_archived(self, v: datetime | None) -> str | None:
         return _rfc3339(v)
 
@@ -0,0 +1,88 @@
+"""Answer questions about a single article with the light LLM service."""
+
+import logging
+from typing import Any
+
+from llm_core import complete
+
+logger = logging.getLogger(__name__)
+
+# Keep the prompt within the light model's context; long articles are truncated
+MAX_ARTICLE_CHARS = 40_000
+
+# Only the most recent turns are replayed to the model
+MAX_HISTORY_TURNS = 20
+
+
+class ArticleChat:
+    """Conversational Q&A grounded in one article's content."""
+
+    def __init__(self, service_name: str):
+        """Initialize article chat.
+
+        Args:
+            service_name: Service name from ~/.config/llm-core/services.toml
+        """
+        self.service_name = service_name
+
+    def build_prompts(
+        self, entry: dict[str, Any], question: str, history: list[dict[str, str]]
+    ) -> tuple[str, str]:
+        """Build the system prompt and the conversation prompt.
+
+        Args:
+            entry: Content entry from storage (title, url, content, summary)
+            question: The user's new question
+            history: Earlier turns as {"role": "user"|"assistant", "content": str}
+
+        Returns:
+            Tuple of (system_prompt, prompt)
+        """
+        content = entry.get("content") or entry.get("summary") or ""
+        if len(content) > MAX_ARTICLE_CHARS:
+            content = content[:MAX_ARTICLE_CHARS] + "\n\n[Article truncated]"
+
+        system_prompt = f"""You are a reading assistant answering questions about one article.
+
+Answer from the article below. When the article doesn't cover something, say so
+before drawing on general knowledge. Be concise and use plain markdown.
+
+Title: {entry.get("title") or "Untitled"}
+URL: {entry.get("url") or ""}
+
+<article>
+{content}
+</article>"""
+
+        lines = []
+        for turn in history[-MAX_HISTORY_TURNS:]:
+            speaker = "User" if turn.get("role") == "user" else "Assistant"
+            lines.append(f"{speaker}: {turn.get('content', '').strip()}")
+        lines.append(f"User: {question.strip()}")
+        lines.append("Assistant:")
+
+        return system_prompt, "\n\n".join(lines)
+
+    def answer(
+        self, entry: dict[str, Any], question: str, history: list[dict[str, str]]
+    ) -> str:
+        """Answer a question about the entry.
+
+        Raises:
+            RuntimeError: If the LLM call fails or returns nothing
+        """
+        system_prompt, prompt = self.build_prompts(entry, question, history)
+        try:
+            result = complete(
+                prompt=prompt,
+                system_prompt=system_prompt,
+                service=self.service_name,
+            )
+        except Exception as e:
+            logger.error(f"Article chat failed: {e}")
+            raise RuntimeError(f"LLM answer failed: {e}") from e
+
+        text = (result.text or "").strip()
+        if not text:
+            raise RuntimeError("LLM returned an empty answer")
+        return text
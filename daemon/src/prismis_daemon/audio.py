@@ -3,22 +3,176 @@
 Uses lspeak for all TTS (system and ElevenLabs providers).
 """
 
+import json
 import logging
+import os
+import re
 import shutil
 import subprocess
 import time
 from pathlib import Path
+from typing import Any
 
 from llm_core import complete
 
 from .config import Config
-from .reports import DailyReport
+from .reports import ContentSummary, DailyReport
 
 logger = logging.getLogger(__name__)
 
 
 # No TTSEngine protocol needed - just use LspeakTTSEngine directly
 
+# Briefing priority floors, highest first
+BRIEFING_PRIORITIES = ("high", "medium", "low")
+
+
+def briefing_items(
+    report: DailyReport, min_priority: str = "high", max_items: int | None = None
+) -> list[ContentSummary]:
+    """Pick the report items a briefing covers, highest priority first.
+
+    Args:
+        report: DailyReport to draw from
+        min_priority: Lowest priority to include ("high", "medium", or "low")
+        max_items: Optional cap on the number of items
+
+    Returns:
+        Items at or above min_priority, at most max_items of them
+
+    Raises:
+        ValueError: If min_priority is unknown
+    """
+    if min_priority not in BRIEFING_PRIORITIES:
+        raise ValueError(
+            f"Unknown priority '{min_priority}' (available: {', '.join(BRIEFING_PRIORITIES)})"
+        )
+    by_priority = {
+        "high": report.high_priority,
+        "medium": report.medium_priority,
+        "low": report.low_priority,
+    }
+    items: list[ContentSummary] = []
+    for priority in BRIEFING_PRIORITIES[: BRIEFING_PRIORITIES.index(min_priority) + 1]:
+        items.extend(by_priority[priority])
+    return items[:max_items] if max_items else items
+
+
+# Cap per-article narration (roughly 45 minutes of speech) to bound TTS cost
+MAX_NARRATION_CHARS = 40_000
+
+
+def get_audio_dir() -> Path:
+    """Get the directory generated audio is written to (XDG data home)."""
+    data_home = os.environ.get("XDG_DATA_HOME", str(Path.home() / ".local/share"))
+    return Path(data_home) / "prismis" / "audio"
+
+
+def article_narration(entry: dict[str, Any]) -> str:
+    """Turn an article into plain text for TTS.
+
+    Reads the full content (falling back to the reading summary, then the
+    brief summary), with markdown links, emphasis, headings, and code fences
+    flattened so the voice doesn't read punctuation aloud.
+
+    Args:
+        entry: Content entry from storage
+
+    Returns:
+        Narration text starting with the title and source
+
+    Raises:
+        ValueError: If the entry has nothing to read
+    """
+    analysis = entry.get("analysis") or {}
+    text = (
+        entry.get("content")
+        or analysis.get("reading_summary")
+        or entry.get("summary")
+        or ""
+    )
+    if not text.strip():
+        raise ValueError("Entry has no content to narrate")
+
+    text = re.sub(r"```.*?```", "", text, flags=re.DOTALL)
+    text = re.sub(r"!\[[^\]]*\]\([^)]*\)", "", text)  # Images
+    text = re.sub(r"\[([^\]]*)\]\([^)]*\)", r"\1", text)  # Links keep their text
+    text = re.sub(r"https?://\S+", "", text)
+    text = re.sub(r"^\s{0,3}#+\s*", "", text, flags=re.MULTILINE)
+    text = re.sub(r"^\s*[-*+]\s+", "", text, flags=re.MULTILINE)
+    text = text.replace("**", "").replace("__", "").replace("`", "")
+    text = re.sub(r"\n{3,}", "\n\n", text).strip()
+
+    if len(text) > MAX_NARRATION_CHARS:
+        cut = text.rfind(".", 0, MAX_NARRATION_CHARS)
+        text = text[: cut + 1 if cut > 0 else MAX_NARRATION_CHARS]
+        text += "\n\nThe rest of this article was cut for length."
+
+    intro = entry.get("title") or "Untitled"
+    if entry.get("source_name"):
+        intro += f". From {entry['source_name']}"
+    return f"{intro}.\n\n{text}"
+
+
+def save_transcript(
+    audio_path: Path, script: str, items: list[ContentSummary], generated_at: str
+) -> Path:
+    """Write a briefing's script and source items next to its audio file.
+
+    Args:
+        audio_path: The briefing's MP3
+        script: Narration script the audio was generated from
+        items: Items the briefing covered
+        generated_at: RFC3339 generation time
+
+    Returns:
+        Path of the JSON transcript (same name as the audio, .json suffix)
+    """
+    transcript_path = audio_path.with_suffix(".json")
+    transcript = {
+        "filename": audio_path.name,
+        "generated_at": generated_at,
+        "script": script,
+        "items": [
+            {
+                "title": item.title,
+                "url": item.url,
+                "source_name": item.source_name,
+                "priority": item.priority,
+            }
+            for item in items
+        ],
+    }
+    transcript_path.write_text(json.dumps(transcript, indent=2))
+    return transcript_path
+
+
+def load_transcript(filename: str | None = None) -> dict[str, Any] | None:
+    """Read a briefing transcript by audio filename, or the newest one.
+
+    Args:
+        filename: Briefing MP3 filename; None picks the most recent transcript
+
+    Returns:
+        Transcript dict, or None when there is no such transcript
+    """
+    audio_dir = get_audio_dir()
+    if filename is None:
+        candidates = sorted(
+            audio_dir.glob("briefing-*.json"), key=lambda p: p.stat().st_mtime
+        )
+        if not candidates:
+            return None
+        path = candidates[-1]
+    else:
+        if Path(filename).name != filename:
+            return None
+        path = (audio_dir / filename).with_suffix(".json")
+    try:
+        return json.loads(path.read_text())
+    except (OSError, json.JSONDecodeError):
+        return None
+
 
 class AudioScriptGenerator:
     """Generate conversational Jarvis briefing scripts from daily reports."""
@@ -32,27 +186,40 @@ class AudioScriptGenerator:
         self.config = config
         self.service_name = config.llm_light_service
 
-    def generate_script(self, report: DailyReport) -> str:
+    def generate_script(
+        self,
+        report: DailyReport,
+        min_priority: str = "high",
+        max_items: int | None = None,
+    ) -> str:
         """Transform DailyReport into conversational Jarvis briefing script.
 
         Uses LLM to create personalized commentary with fictional expert consultations.
-        Focus on HIGH priority items only for concise 2-5 minute briefings.
+        Covers HIGH priority items by default for concise 2-5 minute briefings.
 
         Args:
             report: DailyReport to transform
+            min_priority: Lowest priority to include ("high", "medium", or "low")
+            max_items: Optional cap on the number of items covered
 
         Returns:
             Narration script ready for TTS (300-750 words target)
 
         Raises:
-            ValueError: If report has no high priority content
+            ValueError: If report has no content at or above min_priority
         """
-        if not report.high_priority:
-            raise ValueError("No high priority content available for briefing")
-
-        # Build context for LLM from high priority items
+        items = briefing_items(report, min_priority, max_items)
+        label = {
+            "high": "high priority",
+            "medium": "high or medium priority",
+            "low": "prioritized",
+        }[min_priority]
+        if not items:
+            raise ValueError(f"No {label} content available for briefing")
+
+        # Build context for LLM from the selected items
         content_summaries = []
-        for idx, item in enumerate(report.high_priority, 1):
+        for idx, item in enumerate(items, 1):
             content_summaries.append(
                 f"{idx}. {item.title}\n"
                 f"   Source: {item.source_name} ({item.time_ago()})\n"
@@ -65,7 +232,7 @@ class AudioScriptGenerator:
         # LLM prompt for Jarvis briefing generation
         prompt = f"""You are Jarvis, a sophisticated AI advisor providing a personalized tech intelligence briefing.
 
-Generate a conversational 2-5 minute audio briefing (300-750 words) from these HIGH priority items:
+Generate a conversational 2-5 minute audio briefing (300-750 words) from these {label.upper()} items:
 
 {items_text}
 
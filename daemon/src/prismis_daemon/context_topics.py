@@ -0,0 +1,96 @@
+"""Add topics to context.md sections without disturbing the rest of the file."""
+
+import os
+from pathlib import Path
+
+# Section key -> heading, in the order a new file lists them
+SECTION_HEADINGS = {
+    "high": "## High Priority Topics",
+    "medium": "## Medium Priority Topics",
+    "low": "## Low Priority Topics",
+    "not_interested": "## Not Interested",
+}
+
+
+def get_context_path() -> Path:
+    """Get the path to context.md from XDG config."""
+    xdg_config_home = os.environ.get("XDG_CONFIG_HOME", str(Path.home() / ".config"))
+    return Path(xdg_config_home) / "prismis" / "context.md"
+
+
+def add_topic(context_text: str, topic: str, section: str) -> tuple[str, bool]:
+    """Append a bullet for topic to the end of a context.md section.
+
+    The section is created at the end of the file when missing. A topic
+    already listed anywhere in the file (case-insensitive) is not added again.
+
+    Args:
+        context_text: Current context.md content
+        topic: Topic text, without the leading "- "
+        section: One of SECTION_HEADINGS' keys
+
+    Returns:
+        Tuple of (new context text, whether the topic was added)
+
+    Raises:
+        ValueError: If topic is empty or section is unknown
+    """
+    topic = " ".join(topic.split())
+    if not topic:
+        raise ValueError("Topic must not be empty")
+    if section not in SECTION_HEADINGS:
+        raise ValueError(
+            f"Unknown section '{section}' (available: {', '.join(SECTION_HEADINGS)})"
+        )
+
+    lines = context_text.splitlines()
+    for line in lines:
+        stripped = line.strip()
+        if stripped.startswith("- ") and stripped[2:].strip().lower() == topic.lower():
+            return context_text, False
+
+    heading = SECTION_HEADINGS[section]
+    start = next(
+        (i for i, line in enumerate(lines) if line.strip().lower() == heading.lower()),
+        None,
+    )
+    if start is None:
+        while lines and not lines[-1].strip():
+            lines.pop()
+        if lines:
+            lines.append("")
+        lines += [heading, f"- {topic}"]
+        return "\n".join(lines) + "\n", True
+
+    # Insert after the section's last non-blank line, before the next heading
+    end = start + 1
+    while end < len(lines) and not lines[end].startswith("#"):
+        end += 1
+    insert_at = end
+    while insert_at > start + 1 and not lines[insert_at - 1].strip():
+        insert_at -= 1
+    lines.insert(insert_at, f"- {topic}")
+    return "\n".join(lines) + "\n", True
+
+
+def add_topics(
+    context_text: str, topics: list[tuple[str, str]]
+) -> tuple[str, list[bool]]:
+    """Add several topics in one pass, as add_topic does for each in order.
+
+    Args:
+        context_text: Current context.md content
+        topics: (topic, section) pairs
+
+    Returns:
+        Tuple of (new context text, whether each topic was added)
+
+    Raises:
+        ValueError: If any topic is empty or names an unknown section; nothing
+            is applied then
+    """
+    added = []
+    for topic, section in topics:
+        context_text, was_added = add_topic(context_text, topic, section)
+        added.append(was_added)
+    return context_text, added
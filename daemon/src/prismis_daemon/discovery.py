@@ -0,0 +1,279 @@
+"""LAN discovery: answer mDNS queries for _prismis._tcp.local.
+
+A minimal responder, enough for `prismis --remote` to find the daemon
+without knowing its address. It only answers PTR/ANY questions for the
+prismis service (with SRV, TXT and A records alongside); it never probes,
+announces, or answers anything else, so it coexists with avahi/Bonjour on
+the same port.
+"""
+
+import asyncio
+import ipaddress
+import logging
+import socket
+import struct
+from importlib import metadata
+
+logger = logging.getLogger(__name__)
+
+SERVICE = "_prismis._tcp.local."
+MDNS_GROUP = "224.0.0.251"
+MDNS_PORT = 5353
+
+TYPE_A = 1
+TYPE_PTR = 12
+TYPE_TXT = 16
+TYPE_SRV = 33
+TYPE_ANY = 255
+CLASS_IN = 1
+CACHE_FLUSH = 0x8000  # Class bit marking a record as unique to this host
+UNICAST_RESPONSE = 0x8000  # Question class bit asking for a unicast reply
+
+
+def encode_name(name: str) -> bytes:
+    """Encode a dotted DNS name as length-prefixed labels (no compression)."""
+    out = b""
+    for label in name.rstrip(".").split("."):
+        raw = label.encode("utf-8")[:63]
+        out += bytes([len(raw)]) + raw
+    return out + b"\x00"
+
+
+def read_name(packet: bytes, offset: int) -> tuple[str, int]:
+    """Read a possibly compressed DNS name at offset.
+
+    Returns:
+        The dotted name (with trailing dot) and the offset just past it
+    """
+    labels: list[str] = []
+    end = None
+    for _ in range(128):  # Bound pointer chains in malformed packets
+        length = packet[offset]
+        if length & 0xC0 == 0xC0:
+            pointer = struct.unpack_from("!H", packet, offset)[0] & 0x3FFF
+            if end is None:
+                end = offset + 2
+            offset = pointer
+            continue
+        offset += 1
+        if length == 0:
+            break
+        labels.append(packet[offset : offset + length].decode("utf-8", "replace"))
+        offset += length
+    return ".".join(labels) + ".", end if end is not None else offset
+
+
+def parse_questions(packet: bytes) -> list[tuple[str, int, bool]]:
+    """Parse the questions of an mDNS query.
+
+    Returns:
+        (name, qtype, wants_unicast) for each question; empty for responses
+        and malformed packets
+    """
+    try:
+        _, flags, qdcount = struct.unpack_from("!HHH", packet, 0)
+        if flags & 0x8000:  # A response, not a query
+            return []
+        questions = []
+        offset = 12
+        for _ in range(qdcount):
+            name, offset = read_name(packet, offset)
+            qtype, qclass = struct.unpack_from("!HH", packet, offset)
+            offset += 4
+            questions.append(
+                (name.lower(), qtype, bool(qclass & UNICAST_RESPONSE))
+            )
+        return questions
+    except (IndexError, struct.error):
+        return []
+
+
+def wants_service(packet: bytes) -> bool:
+    """Whether a query asks for the prismis service."""
+    return any(
+        name == SERVICE and qtype in (TYPE_PTR, TYPE_ANY)
+        for name, qtype, _ in parse_questions(packet)
+    )
+
+
+def _record(name: str, rtype: int, rclass: int, ttl: int, rdata: bytes) -> bytes:
+    return (
+        encode_name(name)
+        + struct.pack("!HHIH", rtype, rclass, ttl, len(rdata))
+        + rdata
+    )
+
+
+def build_response(
+    instance: str,
+    hostname: str,
+    port: int,
+    addresses: list[str],
+    txt: dict[str, str],
+    query_id: int = 0,
+    legacy: bool = False,
+) -> bytes:
+    """Build the answer to a service query.
+
+    Args:
+        instance: Service instance label, e.g. the machine's hostname
+        hostname: Host label the SRV record points at (<hostname>.local.)
+        port: API port
+        addresses: IPv4 addresses to advertise for the host
+        txt: TXT key/value pairs (version and so on)
+        query_id: Query ID to echo for legacy unicast queries
+        legacy: The query came from a port other than 5353 (a one-shot
+            resolver such as the TUI), so the reply is plain unicast DNS:
+            echoed ID and question, short TTLs, no cache-flush bits
+
+    Returns:
+        The response packet
+    """
+    instance_name = f"{instance}.{SERVICE}"
+    host_name = f"{hostname}.local."
+    ttl = 10 if legacy else 120
+    unique = CLASS_IN if legacy else CLASS_IN | CACHE_FLUSH
+
+    question = encode_name(SERVICE) + struct.pack("!HH", TYPE_PTR, CLASS_IN)
+    answer = _record(SERVICE, TYPE_PTR, CLASS_IN, ttl, encode_name(instance_name))
+
+    txt_data = b"".join(
+        bytes([len(entry)]) + entry
+        for entry in (f"{k}={v}".encode("utf-8")[:255] for k, v in txt.items())
+    )
+    additional = [
+        _record(
+            instance_name,
+            TYPE_SRV,
+            unique,
+            ttl,
+            struct.pack("!HHH", 0, 0, port) + encode_name(host_name),
+        ),
+        _record(instance_name, TYPE_TXT, unique, ttl, txt_data or b"\x00"),
+    ]
+    for address in addresses:
+        additional.append(
+            _record(host_name, TYPE_A, unique, ttl, socket.inet_aton(address))
+        )
+
+    header = struct.pack(
+        "!HHHHHH",
+        query_id if legacy else 0,
+        0x8400,  # Response, authoritative
+        1 if legacy else 0,
+        1,
+        0,
+        len(additional),
+    )
+    return header + (question if legacy else b"") + answer + b"".join(additional)
+
+
+def daemon_version() -> str:
+    try:
+        return metadata.version("prismis-daemon")
+    except metadata.PackageNotFoundError:
+        return "unknown"
+
+
+def _address_toward(peer: str, bind_host: str) -> str | None:
+    """The local IPv4 address a reply to peer leaves from.
+
+    A daemon bound to one address advertises that one; bound to all
+    interfaces, it asks the routing table which interface reaches peer.
+    """
+    if bind_host not in ("0.0.0.0", ""):
+        return bind_host
+    probe = socket.socket(socket.AF_INET, socket.SOCK_DGRAM)
+    try:
+        probe.connect((peer, MDNS_PORT))
+        return probe.getsockname()[0]
+    except OSError:
+        return None
+    finally:
+        probe.close()
+
+
+def is_lan_host(host: str) -> bool:
+    """Whether the API listens beyond localhost, so advertising is useful."""
+    if host in ("", "0.0.0.0"):
+        return True
+    if host == "localhost":
+        return False
+    try:
+        return not ipaddress.ip_address(host).is_loopback
+    except ValueError:
+        return True  # A hostname: assume it's reachable
+
+
+class DiscoveryResponder(asyncio.DatagramProtocol):
+    """Answers prismis service queries on the mDNS multicast group."""
+
+    def __init__(self, bind_host: str, port: int):
+        self.bind_host = bind_host
+        self.port = port
+        self.hostname = socket.gethostname().split(".")[0] or "prismis"
+        self.txt = {"version": daemon_version(), "path": "/"}
+        self.transport: asyncio.DatagramTransport | None = None
+
+    def connection_made(self, transport) -> None:
+        self.transport = transport
+
+    def datagram_received(self, data: bytes, addr) -> None:
+        if self.transport is None or not wants_service(data):
+            return
+        address = _address_toward(addr[0], self.bind_host)
+        if address is None:
+            return
+
+        legacy = addr[1] != MDNS_PORT
+        response = build_response(
+            self.hostname,
+            self.hostname,
+            self.port,
+            [address],
+            self.txt,
+            query_id=struct.unpack_from("!H", data, 0)[0],
+            legacy=legacy,
+        )
+        unicast = legacy or any(u for _, _, u in parse_questions(data))
+        self.transport.sendto(response, addr if unicast else (MDNS_GROUP, MDNS_PORT))
+
+
+async def start_discovery(
+    bind_host: str, port: int
+) -> asyncio.DatagramTransport | None:
+    """Start answering LAN discovery queries for the API.
+
+    Args:
+        bind_host: Address the API listens on ([api] host)
+        port: API port
+
+    Returns:
+        The responder's transport (close it on shutdown), or None when the API
+        is localhost-only or the mDNS port can't be joined
+    """
+    if not is_lan_host(bind_host):
+        return None
+
+    sock = socket.socket(socket.AF_INET, socket.SOCK_DGRAM, socket.IPPROTO_UDP)
+    try:
+        sock.setsockopt(socket.SOL_SOCKET, socket.SO_REUSEADDR, 1)
+        if hasattr(socket, "SO_REUSEPORT"):
+            sock.setsockopt(socket.SOL_SOCKET, socket.SO_REUSEPORT, 1)
+        sock.bind(("", MDNS_PORT))
+        membership = struct.pack(
+            "4s4s", socket.inet_aton(MDNS_GROUP), socket.inet_aton("0.0.0.0")
+        )
+        sock.setsockopt(socket.IPPROTO_IP, socket.IP_ADD_MEMBERSHIP, membership)
+        sock.setsockopt(socket.IPPROTO_IP, socket.IP_MULTICAST_TTL, 255)
+        sock.setblocking(False)
+    except OSError as e:
+        sock.close()
+        logger.warning(f"LAN discovery disabled: {e}")
+        return None
+
+    loop = asyncio.get_running_loop()
+    transport, _ = await loop.create_datagram_endpoint(
+        lambda: DiscoveryResponder(bind_host, port), sock=sock
+    )
+    return transport
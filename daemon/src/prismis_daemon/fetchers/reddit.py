@@ -381,6 +381,7 @@ class RedditFetcher:
             published_at=published_at,
             fetched_at=datetime.now(UTC),
             analysis={"metrics": metrics},  # Store Reddit metrics here
+            author=f"u/{submission.author}" if submission.author else None,
         )
 
         return item
@@ -125,6 +125,7 @@ class RSSFetcher:
                         content=content,
                         published_at=published_at or fetched_at,
                         fetched_at=fetched_at,
+                        author=entry.get("author") or None,
                     )
 
                     items.append(item)
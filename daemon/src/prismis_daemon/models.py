@@ -27,6 +27,7 @@ class ContentItem:
     read: bool = False
     favorited: bool = False
     notes: Optional[str] = None
+    author: Optional[str] = None  # Byline, when the feed or post names one
 
     def to_dict(self) -> dict:
         """Convert to dictionary for database storage."""
@@ -45,6 +46,7 @@ class ContentItem:
             "read": self.read,
             "favorited": self.favorited,
             "notes": self.notes,
+            "author": self.author,
         }
 
 
@@ -76,3 +78,25 @@ class Source:
             "last_error": self.last_error,
             "last_fetched_at": self.last_fetched_at,
         }
+
+
+# Priorities from lowest to highest, as used by per-source priority rules
+PRIORITY_ORDER = ("low", "medium", "high")
+
+
+def apply_priority_rule(
+    priority: Optional[str], floor: Optional[str], ceiling: Optional[str]
+) -> Optional[str]:
+    """Clamp an evaluated priority to a source's floor and ceiling.
+
+    Unprioritized items stay unprioritized: a floor lifts items the evaluator
+    found relevant, it doesn't make irrelevant ones relevant.
+    """
+    if priority not in PRIORITY_ORDER:
+        return priority
+    rank = PRIORITY_ORDER.index(priority)
+    if floor in PRIORITY_ORDER:
+        rank = max(rank, PRIORITY_ORDER.index(floor))
+    if ceiling in PRIORITY_ORDER:
+        rank = min(rank, PRIORITY_ORDER.index(ceiling))
+    return PRIORITY_ORDER[rank]
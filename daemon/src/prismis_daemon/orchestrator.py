@@ -10,6 +10,7 @@ from .config import Config
 from .deep_extractor import ContentDeepExtractor
 from .embeddings import Embedder
 from .evaluator import ContentEvaluator
+from .models import apply_priority_rule
 from .notifier import Notifier
 from .observability import log as obs_log
 from .storage import Storage
@@ -301,6 +302,12 @@ class DaemonOrchestrator:
                             evaluation.priority.value if evaluation.priority else None
                         )
                     )
+                    # The source's priority rule has the last word
+                    priority = apply_priority_rule(
+                        priority,
+                        source.get("priority_floor"),
+                        source.get("priority_ceiling"),
+                    )
                     item_dict.update(
                         {
                             "summary": summary_result.summary,
@@ -373,7 +380,12 @@ class DaemonOrchestrator:
                     if is_new:
                         stats["items_new"] += 1
                         # Track new HIGH priority items for notifications
-                        if evaluation.priority and evaluation.priority.value == "high":
+                        # (unless the source's rule capped it)
+                        if (
+                            evaluation.priority
+                            and evaluation.priority.value == "high"
+                            and priority == "high"
+                        ):
                             stats["new_high_priority_items"].append(item_dict)
                     else:
                         stats["items_updated"] += 1
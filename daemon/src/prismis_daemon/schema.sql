@@ -27,7 +27,9 @@ CREATE TABLE IF NOT EXISTS sources (
     last_error TEXT,
     last_fetched_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    priority_floor TEXT CHECK(priority_floor IN ('high', 'medium', 'low')),  -- Items never below this (NULL = no rule)
+    priority_ceiling TEXT CHECK(priority_ceiling IN ('high', 'medium', 'low'))  -- Items never above this (NULL = no rule)
 );
 
 -- Many-to-many relationship between sources and categories
@@ -59,6 +61,10 @@ CREATE TABLE IF NOT EXISTS content (
     notes TEXT,
     archived_at TIMESTAMP DEFAULT NULL,  -- Soft archival (NULL = active)
     user_feedback TEXT CHECK(user_feedback IN ('up', 'down', NULL)),  -- User feedback: 'up' = useful, 'down' = not useful
+    user_tags TEXT,  -- User's own tags, comma-separated lowercase (NULL = none)
+    snoozed_until TIMESTAMP DEFAULT NULL,  -- Hidden in the TUI until this time (RFC3339 UTC)
+    pinned BOOLEAN DEFAULT 0,  -- Kept at the top of every TUI view until unpinned
+    author TEXT,  -- Byline from the feed or post (NULL = not given)
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (source_id) REFERENCES sources(id) ON DELETE CASCADE
@@ -10,10 +10,70 @@ from pathlib import Path
 from typing import Any
 
 from .database import get_db_connection
-from .models import ContentItem
+from .models import PRIORITY_ORDER, ContentItem
 from .observability import log as obs_log
 
 
+def _row_tags(row: sqlite3.Row) -> list[str]:
+    """Split the comma-separated user_tags column (absent before migration)."""
+    if "user_tags" not in row.keys() or not row["user_tags"]:
+        return []
+    return [tag for tag in row["user_tags"].split(",") if tag]
+
+
+def _row_snoozed_until(row: sqlite3.Row) -> str | None:
+    """Read the snoozed_until column (absent before migration)."""
+    if "snoozed_until" not in row.keys():
+        return None
+    return row["snoozed_until"]
+
+
+def _row_pinned(row: sqlite3.Row) -> bool:
+    """Read the pinned column (absent before migration)."""
+    return "pinned" in row.keys() and bool(row["pinned"])
+
+
+def _row_author(row: sqlite3.Row) -> str | None:
+    """Read the author column (absent before migration)."""
+    if "author" not in row.keys():
+        return None
+    return row["author"]
+
+
+def _row_rule(row: sqlite3.Row, column: str) -> str | None:
+    """Read a source's priority_floor or priority_ceiling (absent before migration)."""
+    if column not in row.keys():
+        return None
+    return row[column]
+
+
+def _store_author(
+    conn: sqlite3.Connection, content_id: str, author: str | None
+) -> None:
+    """Record a new item's byline; skipped until migration adds the column."""
+    if not author:
+        return
+    try:
+        conn.execute(
+            "UPDATE content SET author = ? WHERE id = ?", (author, content_id)
+        )
+    except sqlite3.OperationalError:
+        pass
+
+
+def normalize_tags(tags: list[str]) -> list[str]:
+    """Lowercase, trim, and de-duplicate tags, keeping first-seen order.
+
+    Commas can't appear inside a tag since they separate tags in storage.
+    """
+    seen: list[str] = []
+    for tag in tags:
+        for part in tag.split(","):
+            cleaned = part.strip().lower()
+            if cleaned and cleaned not in seen:
+                seen.append(cleaned)
+    return seen
+
 class Storage:
     """Repository for all database operations.
 
@@ -126,8 +186,7 @@ class Storage:
         try:
             cursor = self.conn.execute(
                 """
-                SELECT id, url, type, name, active, error_count, 
-                       last_error, last_fetched_at, created_at, updated_at
+                SELECT *
                 FROM sources
                 WHERE active = 1
                 ORDER BY id
@@ -148,6 +207,8 @@ class Storage:
                         "last_fetched_at": row["last_fetched_at"],
                         "created_at": row["created_at"],
                         "updated_at": row["updated_at"],
+                        "priority_floor": _row_rule(row, "priority_floor"),
+                        "priority_ceiling": _row_rule(row, "priority_ceiling"),
                     }
                 )
 
@@ -212,6 +273,8 @@ class Storage:
                 content_item.favorited = item["favorited"]
             if "notes" in item:
                 content_item.notes = item["notes"]
+            if "author" in item:
+                content_item.author = item["author"]
             item = content_item
 
         conn = get_db_connection(self.db_path)
@@ -280,6 +343,7 @@ class Storage:
                     item.notes,
                 ),
             )
+            _store_author(conn, item.id, item.author)
 
             conn.commit()
             duration_ms = int((time.time() - start_time) * 1000)
@@ -365,6 +429,8 @@ class Storage:
                 content_item.favorited = item["favorited"]
             if "notes" in item:
                 content_item.notes = item["notes"]
+            if "author" in item:
+                content_item.author = item["author"]
             item = content_item
 
         try:
@@ -440,6 +506,7 @@ class Storage:
                         item.notes,
                     ),
                 )
+                _store_author(self.conn, item.id, item.author)
                 self.conn.commit()
                 return item.id, True
 
@@ -562,6 +629,7 @@ class Storage:
         limit: int = 50,
         include_archived: bool = False,
         source_filter: str | None = None,
+        archived_only: bool = False,
     ) -> list[dict[str, Any]]:
         """Get unread content by priority level.
 
@@ -570,6 +638,7 @@ class Storage:
             limit: Maximum number of items to return
             include_archived: Include archived content if True
             source_filter: Filter by source name (case-insensitive substring match)
+            archived_only: Only archived content (overrides include_archived)
 
         Returns:
             List of content dictionaries
@@ -577,15 +646,18 @@ class Storage:
         try:
             # Build query with optional archived filter
             query = """
-                SELECT c.*, s.name as source_name, s.type as source_type
+                SELECT c.*, COALESCE(s.name, '(removed source)') as source_name,
+                       s.type as source_type
                 FROM content c
-                JOIN sources s ON c.source_id = s.id
+                LEFT JOIN sources s ON c.source_id = s.id
                 WHERE c.priority = ? AND c.read = 0
             """
             params: list[Any] = [priority]
 
             # Add archived filter unless explicitly including archived
-            if not include_archived:
+            if archived_only:
+                query += " AND c.archived_at IS NOT NULL"
+            elif not include_archived:
                 query += " AND c.archived_at IS NULL"
 
             # Add source filter if provided
@@ -624,6 +696,10 @@ class Storage:
                         "favorited": bool(row["favorited"]),
                         "interesting_override": bool(row["interesting_override"]),
                         "user_feedback": row["user_feedback"],
+                        "user_tags": _row_tags(row),
+                        "snoozed_until": _row_snoozed_until(row),
+                        "pinned": _row_pinned(row),
+                        "author": _row_author(row),
                         "notes": row["notes"],
                     }
                 )
@@ -638,6 +714,7 @@ class Storage:
         since: datetime | None = None,
         include_archived: bool = False,
         source_filter: str | None = None,
+        archived_only: bool = False,
     ) -> list[dict[str, Any]]:
         """Get content since a specific timestamp, or all content if since is None.
 
@@ -646,6 +723,7 @@ class Storage:
                    If None, returns all content regardless of time.
             include_archived: Include archived content if True
             source_filter: Filter by source name (case-insensitive substring match)
+            archived_only: Only archived content (overrides include_archived)
 
         Returns:
             List of content dictionaries with source information
@@ -653,9 +731,10 @@ class Storage:
         try:
             # Build query with optional time filter
             query = """
-                SELECT c.*, s.name as source_name, s.type as source_type
+                SELECT c.*, COALESCE(s.name, '(removed source)') as source_name,
+                       s.type as source_type
                 FROM content c
-                JOIN sources s ON c.source_id = s.id
+                LEFT JOIN sources s ON c.source_id = s.id
                 WHERE 1=1
             """
 
@@ -665,7 +744,9 @@ class Storage:
                 params.append(since.strftime("%Y-%m-%d %H:%M:%S.%f+00:00"))
 
             # Add archived filter unless explicitly including archived
-            if not include_archived:
+            if archived_only:
+                query += " AND c.archived_at IS NOT NULL"
+            elif not include_archived:
                 query += " AND c.archived_at IS NULL"
 
             # Add source filter if provided
@@ -703,6 +784,10 @@ class Storage:
                         "favorited": bool(row["favorited"]),
                         "interesting_override": bool(row["interesting_override"]),
                         "user_feedback": row["user_feedback"],
+                        "user_tags": _row_tags(row),
+                        "snoozed_until": _row_snoozed_until(row),
+                        "pinned": _row_pinned(row),
+                        "author": _row_author(row),
                         "notes": row["notes"],
                     }
                 )
@@ -738,6 +823,52 @@ class Storage:
             self.conn.rollback()
             raise sqlite3.Error(f"Failed to mark content as read: {e}") from e
 
+    def count_unread_by_priority(
+        self,
+        priorities: list[str],
+        include_archived: bool = False,
+        source_filter: str | None = None,
+        archived_only: bool = False,
+    ) -> int:
+        """Count the unread content get_content_by_priority would return for priorities.
+
+        Lets paged callers report a total beyond the page they fetched.
+
+        Args:
+            priorities: Priority levels to count ('high', 'medium', 'low')
+            include_archived: Include archived content if True
+            source_filter: Filter by source name (case-insensitive substring match)
+            archived_only: Only archived content (overrides include_archived)
+
+        Returns:
+            Number of matching items
+        """
+        if not priorities:
+            return 0
+        placeholders = ",".join("?" * len(priorities))
+        try:
+            query = f"""
+                SELECT COUNT(*)
+                FROM content c
+                LEFT JOIN sources s ON c.source_id = s.id
+                WHERE c.read = 0 AND c.priority IN ({placeholders})
+            """  # noqa: S608
+            params: list[Any] = list(priorities)
+
+            if archived_only:
+                query += " AND c.archived_at IS NOT NULL"
+            elif not include_archived:
+                query += " AND c.archived_at IS NULL"
+
+            if source_filter:
+                query += " AND LOWER(s.name) LIKE '%' || LOWER(?) || '%'"
+                params.append(source_filter)
+
+            return self.conn.execute(query, tuple(params)).fetchone()[0]
+
+        except sqlite3.Error as e:
+            raise sqlite3.Error(f"Failed to count unread content: {e}") from e
+
     def update_source_fetch_status(
         self, source_id: str, success: bool, error_message: str | None = None
     ) -> None:
@@ -864,6 +995,119 @@ class Storage:
             # Failed to update source
             return False
 
+    def set_source_category(self, source_id: str, category: str) -> bool:
+        """File a source under one category, creating the category if needed.
+
+        Sources belong to at most one category from the API's point of view, so
+        any existing assignment is replaced.
+
+        Args:
+            source_id: UUID of the source
+            category: Category name; empty string removes the source from its category
+
+        Returns:
+            True if the source exists and was updated, False if not found
+
+        Raises:
+            sqlite3.Error: If database operation fails
+        """
+        category = category.strip()
+        try:
+            cursor = self.conn.execute(
+                "SELECT 1 FROM sources WHERE id = ?", (source_id,)
+            )
+            if cursor.fetchone() is None:
+                return False
+
+            self.conn.execute(
+                "DELETE FROM source_categories WHERE source_id = ?", (source_id,)
+            )
+
+            if category:
+                row = self.conn.execute(
+                    "SELECT id FROM categories WHERE name = ?", (category,)
+                ).fetchone()
+                if row:
+                    category_id = row[0]
+                else:
+                    category_id = str(uuid.uuid4())
+                    self.conn.execute(
+                        "INSERT INTO categories (id, name) VALUES (?, ?)",
+                        (category_id, category),
+                    )
+                self.conn.execute(
+                    "INSERT INTO source_categories (source_id, category_id) VALUES (?, ?)",
+                    (source_id, category_id),
+                )
+
+            self.conn.commit()
+            return True
+
+        except sqlite3.Error as e:
+            self.conn.rollback()
+            raise sqlite3.Error(f"Failed to set source category: {e}") from e
+
+    def set_source_priority_rule(
+        self, source_id: str, floor: str | None = None, ceiling: str | None = None
+    ) -> bool:
+        """Set the lowest and/or highest priority a source's items get.
+
+        The rule applies as items are evaluated; items already stored keep
+        their priority.
+
+        Args:
+            source_id: UUID of the source
+            floor: 'high', 'medium' or 'low'; empty string clears it, None leaves it
+            ceiling: Same as floor
+
+        Returns:
+            True if the source exists and was updated, False if not found
+
+        Raises:
+            ValueError: If a priority is unknown or the floor is above the ceiling
+            sqlite3.Error: If database operation fails (including before migration)
+        """
+        for priority in (floor, ceiling):
+            if priority and priority not in PRIORITY_ORDER:
+                raise ValueError(
+                    f"Unknown priority '{priority}' (expected high, medium, or low)"
+                )
+        try:
+            row = self.conn.execute(
+                "SELECT priority_floor, priority_ceiling FROM sources WHERE id = ?",
+                (source_id,),
+            ).fetchone()
+            if row is None:
+                return False
+
+            new_floor = row["priority_floor"] if floor is None else floor or None
+            new_ceiling = (
+                row["priority_ceiling"] if ceiling is None else ceiling or None
+            )
+            if (
+                new_floor
+                and new_ceiling
+                and PRIORITY_ORDER.index(new_floor) > PRIORITY_ORDER.index(new_ceiling)
+            ):
+                raise ValueError(
+                    f"Priority floor '{new_floor}' is above ceiling '{new_ceiling}'"
+                )
+
+            self.conn.execute(
+                """
+                UPDATE sources
+                SET priority_floor = ?, priority_ceiling = ?, updated_at = CURRENT_TIMESTAMP
+                WHERE id = ?
+                """,
+                (new_floor, new_ceiling, source_id),
+            )
+            self.conn.commit()
+            return True
+
+        except sqlite3.Error as e:
+            self.conn.rollback()
+            raise sqlite3.Error(f"Failed to set source priority rule: {e}") from e
+
     def get_all_sources(self) -> list[dict[str, Any]]:
         """Get all content sources (active and inactive).
 
@@ -873,10 +1117,13 @@ class Storage:
         try:
             cursor = self.conn.execute(
                 """
-                SELECT id, url, type, name, active, error_count, 
-                       last_error, last_fetched_at, created_at, updated_at
-                FROM sources
-                ORDER BY created_at DESC
+                SELECT s.*,
+                       (SELECT cat.name FROM source_categories sc
+                        JOIN categories cat ON cat.id = sc.category_id
+                        WHERE sc.source_id = s.id
+                        ORDER BY cat.name LIMIT 1) AS category
+                FROM sources s
+                ORDER BY s.created_at DESC
                 """
             )
 
@@ -894,6 +1141,9 @@ class Storage:
                         "last_fetched_at": row["last_fetched_at"],
                         "created_at": row["created_at"],
                         "updated_at": row["updated_at"],
+                        "category": row["category"],
+                        "priority_floor": _row_rule(row, "priority_floor"),
+                        "priority_ceiling": _row_rule(row, "priority_ceiling"),
                     }
                 )
 
@@ -902,6 +1152,47 @@ class Storage:
         except sqlite3.Error as e:
             raise sqlite3.Error(f"Failed to get all sources: {e}") from e
 
+    def get_source_daily_counts(self, days: int = 14) -> dict[str, list[int]]:
+        """Count each source's items per day, by publish date, for activity sparklines.
+
+        Args:
+            days: Number of days to cover, ending today (server local time)
+
+        Returns:
+            Source ID to a list of `days` counts, oldest first. Sources with no
+            items in the window are left out.
+
+        Raises:
+            sqlite3.Error: If database operation fails
+        """
+        today = datetime.now().astimezone().date()
+        first = today - timedelta(days=days - 1)
+        # A day of slack on the string comparison covers timezone offsets
+        cutoff = datetime.combine(first - timedelta(days=1), datetime.min.time(), UTC)
+        try:
+            cursor = self.conn.execute(
+                """
+                SELECT source_id, published_at FROM content
+                WHERE source_id IS NOT NULL AND published_at >= ?
+                """,
+                (cutoff.isoformat(),),
+            )
+            counts: dict[str, list[int]] = {}
+            for row in cursor.fetchall():
+                try:
+                    published = datetime.fromisoformat(row["published_at"])
+                except (TypeError, ValueError):
+                    continue
+                if published.tzinfo is None:
+                    published = published.replace(tzinfo=UTC)
+                index = (published.astimezone().date() - first).days
+                if 0 <= index < days:
+                    counts.setdefault(row["source_id"], [0] * days)[index] += 1
+            return counts
+
+        except sqlite3.Error as e:
+            raise sqlite3.Error(f"Failed to count daily items: {e}") from e
+
     def pause_source(self, source_id: str) -> bool:
         """Pause a content source (set inactive).
 
@@ -955,33 +1246,54 @@ class Storage:
             self.conn.rollback()
             raise sqlite3.Error(f"Failed to resume source: {e}") from e
 
-    def remove_source(self, source_id: str) -> bool:
+    def remove_source(self, source_id: str, content: str = "delete") -> bool:
         """Remove a content source from the database.
 
-        This will preserve favorited content by setting their source_id to NULL,
-        while deleting all non-favorited content from the source.
+        By default this preserves favorited content by setting their source_id
+        to NULL, while deleting all non-favorited content from the source.
 
         Args:
             source_id: UUID of the source to remove
+            content: What happens to the source's items: "delete" (default),
+                "keep" (detach every item), or "archive" (detach and archive)
 
         Returns:
             True if source was removed, False if not found
 
         Raises:
+            ValueError: If content is not a known mode
             sqlite3.Error: If database operation fails
         """
+        if content not in ("delete", "keep", "archive"):
+            raise ValueError(f"Unknown content mode: {content}")
+
         try:
-            # First, preserve favorited content by setting source_id to NULL
-            self.conn.execute(
-                "UPDATE content SET source_id = NULL WHERE source_id = ? AND favorited = 1",
-                (source_id,),
-            )
+            if content == "archive":
+                # Archive live items first; already-archived ones keep their date
+                self.conn.execute(
+                    """UPDATE content SET archived_at = CURRENT_TIMESTAMP
+                       WHERE source_id = ? AND archived_at IS NULL""",
+                    (source_id,),
+                )
 
-            # Then delete all non-favorited content from this source
-            self.conn.execute(
-                "DELETE FROM content WHERE source_id = ? AND favorited = 0",
-                (source_id,),
-            )
+            if content == "delete":
+                # Preserve favorited content by setting source_id to NULL
+                self.conn.execute(
+                    "UPDATE content SET source_id = NULL WHERE source_id = ? AND favorited = 1",
+                    (source_id,),
+                )
+
+                # Then delete all non-favorited content from this source
+                self.conn.execute(
+                    "DELETE FROM content WHERE source_id = ? AND favorited = 0",
+                    (source_id,),
+                )
+            else:
+                # Keep every item, detached from the source
+                self.conn.execute(
+                    "UPDATE content SET source_id = NULL WHERE source_id = ?",
+                    (source_id,),
+                )
 
             # Clean up orphaned vectors (virtual tables don't support CASCADE)
             self.conn.execute(
@@ -997,6 +1309,56 @@ class Storage:
             self.conn.rollback()
             raise sqlite3.Error(f"Failed to remove source: {e}") from e
 
+    def merge_sources(self, keep_id: str, merge_ids: list[str]) -> int | None:
+        """Merge duplicate sources into one, keeping all their content.
+
+        Content of the merged sources is reassigned to the kept source, then
+        the merged sources are deleted, all in one transaction. external_id is
+        unique across sources, so no item can end up duplicated.
+
+        Args:
+            keep_id: UUID of the source that survives
+            merge_ids: UUIDs of the sources folded into it
+
+        Returns:
+            Number of content items moved, or None if any source was not found
+
+        Raises:
+            ValueError: If merge_ids is empty or contains keep_id
+            sqlite3.Error: If database operation fails
+        """
+        if not merge_ids or keep_id in merge_ids:
+            raise ValueError("Merge needs other sources than the one kept")
+
+        ids = [keep_id, *merge_ids]
+        placeholders = ",".join("?" for _ in merge_ids)
+        try:
+            found = self.conn.execute(
+                f"SELECT COUNT(*) FROM sources WHERE id IN ({','.join('?' for _ in ids)})",
+                ids,
+            ).fetchone()[0]
+            if found != len(set(ids)):
+                return None
+
+            cursor = self.conn.execute(
+                f"UPDATE content SET source_id = ? WHERE source_id IN ({placeholders})",
+                (keep_id, *merge_ids),
+            )
+            moved = cursor.rowcount
+            self.conn.execute(
+                f"DELETE FROM source_categories WHERE source_id IN ({placeholders})",
+                merge_ids,
+            )
+            self.conn.execute(
+                f"DELETE FROM sources WHERE id IN ({placeholders})", merge_ids
+            )
+            self.conn.commit()
+            return moved
+
+        except sqlite3.Error as e:
+            self.conn.rollback()
+            raise sqlite3.Error(f"Failed to merge sources: {e}") from e
+
     def update_content_status(
         self,
         content_id: str,
@@ -1004,8 +1366,11 @@ class Storage:
         favorited: bool | None = None,
         interesting_override: bool | None = None,
         user_feedback: str | None = "__NOT_PROVIDED__",
+        user_tags: list[str] | None = None,
+        snoozed_until: datetime | None = None,
+        pinned: bool | None = None,
     ) -> bool:
-        """Update read, favorited, interesting_override, and/or user_feedback status.
+        """Update read, favorited, interesting_override, user_feedback, user_tags, snoozed_until, and/or pinned.
 
         Args:
             content_id: UUID of the content to update
@@ -1014,6 +1379,9 @@ class Storage:
             interesting_override: Set interesting_override flag if provided
             user_feedback: Set user feedback ('up', 'down', or None to clear).
                           Use special value "__NOT_PROVIDED__" to indicate param was not passed.
+            user_tags: Replace the user's tags if provided (empty list clears them)
+            snoozed_until: Hide the item in the TUI until this time if provided
+            pinned: Set pinned status if provided
 
         Returns:
             True if content was updated, False if not found
@@ -1030,9 +1398,12 @@ class Storage:
             and favorited is None
             and interesting_override is None
             and not user_feedback_provided
+            and user_tags is None
+            and snoozed_until is None
+            and pinned is None
         ):
             raise ValueError(
-                "At least one of read, favorited, interesting_override, or user_feedback must be provided"
+                "At least one of read, favorited, interesting_override, user_feedback, user_tags, snoozed_until, or pinned must be provided"
             )
 
         # Validate user_feedback if provided
@@ -1066,6 +1437,23 @@ class Storage:
                 updates.append("user_feedback = ?")
                 params.append(user_feedback)  # Can be 'up', 'down', or None
 
+            if user_tags is not None:
+                updates.append("user_tags = ?")
+                params.append(",".join(normalize_tags(user_tags)) or None)
+
+            if snoozed_until is not None:
+                # Naive datetimes are taken as UTC, matching the other timestamps
+                if snoozed_until.tzinfo is None:
+                    snoozed_until = snoozed_until.replace(tzinfo=UTC)
+                updates.append("snoozed_until = ?")
+                params.append(
+                    snoozed_until.astimezone(UTC).strftime("%Y-%m-%dT%H:%M:%SZ")
+                )
+
+            if pinned is not None:
+                updates.append("pinned = ?")
+                params.append(1 if pinned else 0)
+
             params.append(content_id)
 
             # Field names are constants, only values are parameterized
@@ -1098,6 +1486,51 @@ class Storage:
             )
             raise sqlite3.Error(f"Failed to update content status: {e}") from e
 
+    def set_read_batch(self, content_ids: list[str], read: bool) -> int:
+        """Set read status on many content items in one transaction.
+
+        Args:
+            content_ids: UUIDs of the content to update
+            read: New read status
+
+        Returns:
+            Number of items updated (unknown IDs are skipped)
+        """
+        start_time = time.time()
+        updated = 0
+        try:
+            # Chunked to stay under SQLite's bound-parameter limit
+            for i in range(0, len(content_ids), 500):
+                chunk = content_ids[i : i + 500]
+                placeholders = ",".join("?" * len(chunk))
+                cursor = self.conn.execute(
+                    f"UPDATE content SET read = ? WHERE id IN ({placeholders})",  # noqa: S608
+                    [1 if read else 0, *chunk],
+                )
+                updated += cursor.rowcount
+            self.conn.commit()
+            obs_log(
+                "db.update",
+                table="content",
+                operation="set_read_batch",
+                row_count=updated,
+                duration_ms=int((time.time() - start_time) * 1000),
+                status="success",
+            )
+            return updated
+
+        except sqlite3.Error as e:
+            self.conn.rollback()
+            obs_log(
+                "db.update",
+                table="content",
+                operation="set_read_batch",
+                error=str(e),
+                duration_ms=int((time.time() - start_time) * 1000),
+                status="error",
+            )
+            raise sqlite3.Error(f"Failed to update read status: {e}") from e
+
     def flag_interesting(self, content_id: str) -> bool:
         """Flag a content item as interesting for context analysis.
 
@@ -1260,6 +1693,10 @@ class Storage:
                     "favorited": bool(row["favorited"]),
                     "interesting_override": bool(row["interesting_override"]),
                     "user_feedback": row["user_feedback"],
+                    "user_tags": _row_tags(row),
+                    "snoozed_until": _row_snoozed_until(row),
+                    "pinned": _row_pinned(row),
+                    "author": _row_author(row),
                     "notes": row["notes"],
                     "source_name": row["source_name"],
                     "source_type": row["source_type"],
@@ -1529,6 +1966,7 @@ class Storage:
         limit: int = 20,
         min_score: float = 0.0,
         source_filter: str | None = None,
+        archived: str = "yes",
     ) -> list[dict[str, Any]]:
         """Semantic search using similarity-first ranking with source authority.
 
@@ -1542,6 +1980,7 @@ class Storage:
             limit: Maximum number of results to return
             min_score: Minimum relevance score (0.0-1.0)
             source_filter: Optional substring to filter source names (case-insensitive)
+            archived: Archived content in scope: "no", "yes" (alongside active), or "only"
 
         Returns:
             List of content dicts with relevance_score field
@@ -1590,6 +2029,12 @@ class Storage:
                 query += " AND LOWER(s.name) LIKE '%' || LOWER(?) || '%'"
                 params.append(source_filter)
 
+            # Narrow the archive scope ("yes" searches everything)
+            if archived == "no":
+                query += " AND c.archived_at IS NULL"
+            elif archived == "only":
+                query += " AND c.archived_at IS NOT NULL"
+
             cursor = self.conn.execute(query, params)
 
             # Build dict of content by id
@@ -1618,6 +2063,10 @@ class Storage:
                     "read": bool(row["read"]),
                     "favorited": bool(row["favorited"]),
                     "user_feedback": row["user_feedback"],
+                    "user_tags": _row_tags(row),
+                    "snoozed_until": _row_snoozed_until(row),
+                    "pinned": _row_pinned(row),
+                    "author": _row_author(row),
                     "notes": row["notes"],
                     "source_name": row["source_name"],
                     "source_type": row["source_type"],
@@ -1853,6 +2302,117 @@ class Storage:
         except sqlite3.Error as e:
             raise sqlite3.Error(f"Failed to get content without analysis: {e}") from e
 
+    def get_recent_unprioritized(
+        self, days: int, limit: int = 50
+    ) -> list[dict[str, Any]]:
+        """Get recent analyzed items the evaluator left unprioritized.
+
+        Used to re-evaluate items after the user adds a topic to context.md.
+        Only items with a summary qualify; ones that failed analysis entirely
+        are retried by the fetch cycle instead.
+
+        Args:
+            days: Only include items published within this many days
+            limit: Maximum number of items to return
+
+        Returns:
+            List of dicts with id, title, url, content, and parsed analysis,
+            newest first
+
+        Raises:
+            sqlite3.Error: If database operation fails
+        """
+        try:
+            cutoff = datetime.now(UTC) - timedelta(days=days)
+            cursor = self.conn.execute(
+                """
+                SELECT id, title, url, content, analysis
+                FROM content
+                WHERE (priority IS NULL OR priority = '')
+                  AND summary IS NOT NULL
+                  AND archived_at IS NULL
+                  AND published_at >= ?
+                ORDER BY published_at DESC
+                LIMIT ?
+                """,
+                (cutoff.isoformat(), limit),
+            )
+
+            results = []
+            for row in cursor.fetchall():
+                analysis = {}
+                if row["analysis"]:
+                    try:
+                        analysis = json.loads(row["analysis"])
+                    except json.JSONDecodeError:
+                        analysis = {}
+                results.append(
+                    {
+                        "id": row["id"],
+                        "title": row["title"],
+                        "url": row["url"],
+                        "content": row["content"],
+                        "analysis": analysis,
+                    }
+                )
+            return results
+
+        except sqlite3.Error as e:
+            raise sqlite3.Error(f"Failed to get unprioritized content: {e}") from e
+
+    def update_summary(self, content_id: str, summary: str, analysis: dict) -> bool:
+        """Set a content row's summary along with its updated analysis.
+
+        Used by the on-demand summarize endpoint for items ingestion left
+        without one.
+
+        Args:
+            content_id: UUID of the content row
+            summary: Brief display summary
+            analysis: Full analysis dict to persist (caller merges as needed)
+
+        Returns:
+            True if a row was updated, False if no row matched.
+
+        Raises:
+            sqlite3.Error: on write failure
+        """
+        try:
+            cursor = self.conn.execute(
+                "UPDATE content SET summary = ?, analysis = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+                (summary, json.dumps(analysis), content_id),
+            )
+            self.conn.commit()
+            return cursor.rowcount > 0
+        except sqlite3.Error as e:
+            self.conn.rollback()
+            raise sqlite3.Error(f"Failed to update summary: {e}") from e
+
+    def update_priority(self, content_id: str, priority: str, analysis: dict) -> bool:
+        """Set a content row's priority along with its updated analysis.
+
+        Args:
+            content_id: UUID of the content row
+            priority: "high", "medium", or "low"
+            analysis: Full analysis dict to persist (caller merges as needed)
+
+        Returns:
+            True if a row was updated, False if no row matched.
+
+        Raises:
+            sqlite3.Error: on write failure
+        """
+        try:
+            cursor = self.conn.execute(
+                "UPDATE content SET priority = ?, analysis = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+                (priority, json.dumps(analysis), content_id),
+            )
+            self.conn.commit()
+            return cursor.rowcount > 0
+        except sqlite3.Error as e:
+            self.conn.rollback()
+            raise sqlite3.Error(f"Failed to update priority: {e}") from e
+
     def archive_old_content(self, config: dict[str, Any]) -> int:
         """Archive content based on priority-aware aging windows.
 
@@ -2149,13 +2709,13 @@ class Storage:
             # Per-source breakdown
             source_query = """
                 SELECT
-                    s.name,
+                    COALESCE(s.name, '(removed source)'),
                     s.id,
                     SUM(CASE WHEN c.user_feedback = 'up' THEN 1 ELSE 0 END) as upvotes,
                     SUM(CASE WHEN c.user_feedback = 'down' THEN 1 ELSE 0 END) as downvotes,
                     COUNT(CASE WHEN c.user_feedback IS NOT NULL THEN 1 END) as total
                 FROM content c
-                JOIN sources s ON c.source_id = s.id
+                LEFT JOIN sources s ON c.source_id = s.id
                 WHERE c.user_feedback IS NOT NULL
             """
             if since_days:
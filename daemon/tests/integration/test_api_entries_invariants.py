@@ -299,3 +299,70 @@ def test_api_content_invalid_parameters(
         assert response.status_code == 200, f"Should accept valid request: {url}"
         data = response.json()
         assert data["success"] is True
+
+
+def test_api_content_offset_pages(api_client: TestClient) -> None:
+    """
+    INVARIANT: limit/offset pages cover every entry exactly once, and total counts them all
+    BREAKS: The TUI's paged sync stops after the first page and misses the rest of the feed
+    """
+    headers = {"X-API-Key": "prismis-api-4d5e"}
+    everything = api_client.get("/api/entries", headers=headers).json()["data"]
+
+    seen = []
+    for offset in range(0, everything["total"] + 2, 2):
+        response = api_client.get(
+            f"/api/entries?limit=2&offset={offset}", headers=headers
+        )
+        assert response.status_code == 200
+        page = response.json()["data"]
+        assert page["total"] == everything["total"]
+        seen.extend(item["id"] for item in page["items"])
+
+    assert seen == [item["id"] for item in everything["items"]]
+
+
+def test_api_content_archived_only_pages(
+    api_client: TestClient, populated_storage: Storage
+) -> None:
+    """
+    INVARIANT: archived=only pages through every archived entry, with or without
+    unread_only, and total counts them all
+    BREAKS: Archived views in the TUI come back short or empty and paging stops early
+    """
+    source_id = populated_storage.get_all_sources()[0]["id"]
+    archived_ids = []
+    for i in range(5):
+        archived_ids.append(
+            populated_storage.add_content(
+                ContentItem(
+                    external_id=f"archived-{i}",
+                    source_id=source_id,
+                    title=f"Archived {i}",
+                    url=f"https://example.com/archived-{i}",
+                    content="Archived content",
+                    published_at=datetime.now(timezone.utc),
+                    priority="high",
+                )
+            )
+        )
+    populated_storage.conn.execute(
+        f"UPDATE content SET archived_at = CURRENT_TIMESTAMP WHERE id IN ({','.join('?' * 5)})",
+        archived_ids,
+    )
+    populated_storage.conn.commit()
+
+    headers = {"X-API-Key": "prismis-api-4d5e"}
+    for unread in ("false", "true"):
+        seen = []
+        for offset in range(0, 6, 2):
+            response = api_client.get(
+                f"/api/entries?archived=only&unread_only={unread}&limit=2&offset={offset}",
+                headers=headers,
+            )
+            assert response.status_code == 200
+            page = response.json()["data"]
+            assert page["total"] == 5
+            seen.extend(item["id"] for item in page["items"])
+
+        assert sorted(seen) == sorted(archived_ids)
@@ -331,3 +331,18 @@ def test_api_performance(api_client: TestClient, test_db: Path) -> None:
     )
     # POST with real validation might take up to 10 seconds
     assert operations[1][1] < 10, f"POST took {operations[1][1]:.1f}s, should be <10s"
+
+
+def test_version_handshake(api_client: TestClient) -> None:
+    """
+    INVARIANT: /api/version answers without a key and lists the optional features
+    BREAKS: The TUI greys out audio, prune and the interesting flag on current daemons
+    """
+    response = api_client.get("/api/version")
+    assert response.status_code == 200
+
+    data = response.json()["data"]
+    assert data["api_version"] >= 1
+    assert data["version"]
+    for feature in ("audio", "prune", "interesting"):
+        assert feature in data["features"]
@@ -456,3 +456,25 @@ def test_entry_detail_envelope_shape_full(
     fetched_at = entry.get("fetched_at")
     if fetched_at is not None:
         assert_rfc3339(fetched_at)
+
+
+def test_entries_exclude_content_sends_length_instead(
+    entries_client: TestClient,
+) -> None:
+    """/api/entries?exclude_content=true leaves the text out and reports its length.
+
+    INVARIANT: list views (the TUI's sync) get every list field but not the
+    article text, and can still sort and filter by length.
+    BREAKS: Each sync downloads megabytes of article text nobody is reading yet.
+    """
+    response = entries_client.get(
+        "/api/entries?exclude_content=true", headers={"X-API-Key": API_KEY}
+    )
+    assert response.status_code == 200, f"Unexpected status: {response.text}"
+    data = response.json()["data"]
+    assert data["filters_applied"]["exclude_content"] is True
+
+    item = data["items"][0]
+    assert not item.get("content"), f"content must be left out, got {item['content']!r}"
+    assert item["content_length"] == len("Content for RFC3339 wire format test")
+    assert item["title"] == "Wire Format Test Article"
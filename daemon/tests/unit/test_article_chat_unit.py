@@ -0,0 +1,56 @@
+"""Unit tests for article chat prompt building (article_chat.ArticleChat).
+
+Protects:
+- INV-CHAT-GROUNDED: The article content is in the system prompt
+- INV-CHAT-HISTORY: Earlier turns are replayed in order before the new question
+"""
+
+from prismis_daemon.article_chat import MAX_ARTICLE_CHARS, ArticleChat
+
+ENTRY = {
+    "title": "Async Rust in practice",
+    "url": "https://example.com/async-rust",
+    "content": "Tokio is the most used runtime.",
+}
+
+
+def test_build_prompts_grounds_in_article() -> None:
+    """
+    INVARIANT: The system prompt carries the title and the full article text.
+    BREAKS: Answers come from general knowledge instead of the article.
+    """
+    system_prompt, prompt = ArticleChat("svc").build_prompts(
+        ENTRY, "Which runtime?", []
+    )
+
+    assert "Async Rust in practice" in system_prompt
+    assert "Tokio is the most used runtime." in system_prompt
+    assert prompt == "User: Which runtime?\n\nAssistant:"
+
+
+def test_build_prompts_replays_history() -> None:
+    """
+    INVARIANT: Follow-ups see the earlier turns, oldest first.
+    BREAKS: "What about the second one?" loses its referent.
+    """
+    history = [
+        {"role": "user", "content": "Which runtime?"},
+        {"role": "assistant", "content": "Tokio."},
+    ]
+    _, prompt = ArticleChat("svc").build_prompts(ENTRY, "Why?", history)
+
+    assert prompt == (
+        "User: Which runtime?\n\nAssistant: Tokio.\n\nUser: Why?\n\nAssistant:"
+    )
+
+
+def test_build_prompts_truncates_long_articles() -> None:
+    """
+    INVARIANT: Article text beyond MAX_ARTICLE_CHARS is cut and marked.
+    BREAKS: Long articles overflow the light model's context and the call fails.
+    """
+    entry = {**ENTRY, "content": "x" * (MAX_ARTICLE_CHARS + 100)}
+    system_prompt, _ = ArticleChat("svc").build_prompts(entry, "Q", [])
+
+    assert "[Article truncated]" in system_prompt
+    assert "x" * (MAX_ARTICLE_CHARS + 1) not in system_prompt
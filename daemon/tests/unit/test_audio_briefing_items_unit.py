@@ -0,0 +1,93 @@
+"""Unit tests for briefing item selection (audio.briefing_items).
+
+Protects:
+- INV-BRIEFING-FLOOR: A priority floor includes every priority above it, highest first
+- INV-BRIEFING-CAP: max_items keeps the highest-priority items
+- INV-BRIEFING-TRANSCRIPT: A briefing's script and sources can be read back by filename
+"""
+
+from datetime import UTC, datetime
+
+import pytest
+
+from prismis_daemon.audio import briefing_items, load_transcript, save_transcript
+from prismis_daemon.reports import ContentSummary, DailyReport
+
+
+def _item(title: str, priority: str) -> ContentSummary:
+    return ContentSummary(
+        title=title,
+        source_name="Feed",
+        url=f"https://example.com/{title}",
+        summary="Summary",
+        published_at=datetime.now(UTC),
+        priority=priority,
+    )
+
+
+REPORT = DailyReport(
+    generated_at=datetime.now(UTC),
+    period_hours=24,
+    high_priority=[_item("h1", "high"), _item("h2", "high")],
+    medium_priority=[_item("m1", "medium")],
+    low_priority=[_item("l1", "low")],
+)
+
+
+def _titles(items: list[ContentSummary]) -> list[str]:
+    return [item.title for item in items]
+
+
+def test_briefing_items_priority_floor() -> None:
+    """
+    INVARIANT: "medium" covers HIGH then MEDIUM; the default stays HIGH only.
+    BREAKS: :audio medium drops the HIGH items, or the default briefing grows.
+    """
+    assert _titles(briefing_items(REPORT)) == ["h1", "h2"]
+    assert _titles(briefing_items(REPORT, "medium")) == ["h1", "h2", "m1"]
+    assert _titles(briefing_items(REPORT, "low")) == ["h1", "h2", "m1", "l1"]
+
+
+def test_briefing_items_cap_keeps_highest() -> None:
+    """
+    INVARIANT: The cap trims from the low end.
+    BREAKS: --max 2 with medium narrates a MEDIUM item instead of a HIGH one.
+    """
+    assert _titles(briefing_items(REPORT, "medium", max_items=2)) == ["h1", "h2"]
+
+
+def test_briefing_items_rejects_unknown_priority() -> None:
+    """
+    INVARIANT: Unknown priorities raise instead of producing an empty briefing.
+    BREAKS: A typo reports "no content" instead of the real problem.
+    """
+    with pytest.raises(ValueError):
+        briefing_items(REPORT, "urgent")
+
+
+def test_transcript_roundtrip(tmp_path, monkeypatch) -> None:
+    """
+    INVARIANT: save_transcript output is returned by filename and as the latest,
+    and filenames with path separators are refused.
+    BREAKS: The TUI can't show what a briefing said, or reads files outside the audio dir.
+    """
+    monkeypatch.setenv("XDG_DATA_HOME", str(tmp_path))
+    audio_dir = tmp_path / "prismis" / "audio"
+    audio_dir.mkdir(parents=True)
+
+    save_transcript(
+        audio_dir / "briefing-2026-01-02.mp3",
+        "Good morning.",
+        REPORT.high_priority,
+        "2026-01-02T08:00:00+00:00",
+    )
+
+    transcript = load_transcript("briefing-2026-01-02.mp3")
+    assert transcript is not None
+    assert transcript["script"] == "Good morning."
+    assert [i["title"] for i in transcript["items"]] == ["h1", "h2"]
+    assert transcript["items"][0]["url"] == "https://example.com/h1"
+
+    assert load_transcript() == transcript
+    assert load_transcript("briefing-2026-01-01.mp3") is None
+    assert load_transcript("../briefing-2026-01-02.mp3") is None
@@ -0,0 +1,55 @@
+"""Unit tests for per-article narration text (audio.article_narration).
+
+Protects:
+- INV-NARRATION-PLAIN: Markdown syntax and bare URLs never reach the TTS voice
+- INV-NARRATION-BOUNDED: Long articles are cut at a sentence to cap TTS cost
+"""
+
+import pytest
+
+from prismis_daemon.audio import MAX_NARRATION_CHARS, article_narration
+
+
+def test_narration_flattens_markdown() -> None:
+    """
+    INVARIANT: Headings, links, emphasis, code, and URLs are reduced to readable text.
+    BREAKS: The voice reads "hash hash" and whole URLs aloud.
+    """
+    entry = {
+        "title": "Async Rust",
+        "source_name": "Hacker News",
+        "content": "## Intro\n\nSee [the docs](https://tokio.rs) and **this**: "
+        "https://example.com/x\n\n```rust\nfn main() {}\n```\nDone.",
+    }
+
+    text = article_narration(entry)
+
+    assert text.startswith("Async Rust. From Hacker News.\n\n")
+    assert "Intro" in text and "##" not in text
+    assert "See the docs and this:" in text
+    assert "http" not in text and "fn main" not in text and "**" not in text
+
+
+def test_narration_falls_back_to_summary_and_rejects_empty() -> None:
+    """
+    INVARIANT: Items without content narrate their reading summary; empty items raise.
+    BREAKS: File-source items can't be listened to, or TTS bills for silence.
+    """
+    entry = {"title": "Notes", "analysis": {"reading_summary": "Smaller PRs."}}
+    assert article_narration(entry).endswith("Smaller PRs.")
+
+    with pytest.raises(ValueError):
+        article_narration({"title": "Empty", "content": "  "})
+
+
+def test_narration_is_capped_at_a_sentence() -> None:
+    """
+    INVARIANT: Narration longer than MAX_NARRATION_CHARS ends at a full stop.
+    BREAKS: A book-length page costs dollars of TTS and ends mid-word.
+    """
+    entry = {"title": "Long", "content": "One sentence here. " * 5000}
+
+    text = article_narration(entry)
+
+    assert len(text) < MAX_NARRATION_CHARS + 200
+    assert text.endswith("The rest of this article was cut for length.")
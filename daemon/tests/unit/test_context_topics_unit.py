@@ -0,0 +1,93 @@
+"""Unit tests for adding topics to context.md (context_topics.add_topic).
+
+Protects:
+- INV-TOPIC-SECTION: A topic lands at the end of its section, before the next heading
+- INV-TOPIC-DEDUPE: A topic already in context.md is never added twice
+"""
+
+import pytest
+
+from prismis_daemon.context_topics import add_topic, add_topics
+
+CONTEXT = """## High Priority Topics
+- Rust systems programming
+
+## Medium Priority Topics
+- Developer tool releases
+
+## Not Interested
+- Crypto
+"""
+
+
+def test_add_topic_appends_to_section_end() -> None:
+    """
+    INVARIANT: The new bullet follows the section's last bullet, not the next heading.
+    BREAKS: Topics end up under the wrong priority and the evaluator misranks them.
+    """
+    updated, added = add_topic(CONTEXT, "SQLite internals", "high")
+
+    assert added
+    assert updated.startswith(
+        "## High Priority Topics\n- Rust systems programming\n- SQLite internals\n\n"
+        "## Medium Priority Topics"
+    )
+
+
+def test_add_topic_creates_missing_section() -> None:
+    """
+    INVARIANT: A missing section is appended at the end of the file.
+    BREAKS: Adding a LOW topic to a file without that section silently fails.
+    """
+    updated, added = add_topic(CONTEXT, "Conference talks", "low")
+
+    assert added
+    assert updated.endswith("- Crypto\n\n## Low Priority Topics\n- Conference talks\n")
+
+
+def test_add_topic_skips_existing_topic() -> None:
+    """
+    INVARIANT: Re-adding a listed topic (any case, any section) is a no-op.
+    BREAKS: Quick-adding the same entity twice clutters context.md.
+    """
+    updated, added = add_topic(CONTEXT, "rust  SYSTEMS programming", "medium")
+
+    assert not added
+    assert updated == CONTEXT
+
+
+def test_add_topic_rejects_unknown_section() -> None:
+    """
+    INVARIANT: Unknown sections raise instead of writing a stray heading.
+    BREAKS: A typo creates a section the evaluator never reads.
+    """
+    with pytest.raises(ValueError):
+        add_topic(CONTEXT, "Go", "urgent")
+
+
+def test_add_topics_applies_in_order_and_dedupes() -> None:
+    """
+    INVARIANT: A batch adds each topic to its section once, even when it repeats.
+    BREAKS: Accepting the same entity from two flagged items lists it twice.
+    """
+    updated, added = add_topics(
+        CONTEXT,
+        [
+            ("SQLite internals", "high"),
+            ("sqlite INTERNALS", "medium"),
+            ("NFTs", "not_interested"),
+        ],
+    )
+
+    assert added == [True, False, True]
+    assert "- Rust systems programming\n- SQLite internals\n" in updated
+    assert updated.endswith("- Crypto\n- NFTs\n")
+
+
+def test_add_topics_rejects_whole_batch() -> None:
+    """
+    INVARIANT: One bad section raises before anything is returned to write.
+    BREAKS: A half-applied batch leaves context.md out of step with what the TUI reported.
+    """
+    with pytest.raises(ValueError):
+        add_topics(CONTEXT, [("Go", "high"), ("Zig", "urgent")])
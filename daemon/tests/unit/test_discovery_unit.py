@@ -0,0 +1,102 @@
+"""Unit tests for the LAN discovery responder's packet handling."""
+
+import socket
+import struct
+
+from prismis_daemon.discovery import (
+    SERVICE,
+    TYPE_A,
+    TYPE_PTR,
+    TYPE_SRV,
+    build_response,
+    encode_name,
+    is_lan_host,
+    parse_questions,
+    read_name,
+    wants_service,
+)
+
+
+def _query(name: str, qtype: int, qclass: int = 1, query_id: int = 0) -> bytes:
+    return (
+        struct.pack("!HHHHHH", query_id, 0, 1, 0, 0, 0)
+        + encode_name(name)
+        + struct.pack("!HH", qtype, qclass)
+    )
+
+
+def _records(packet: bytes) -> list[tuple[str, int, int, bytes]]:
+    """Parse every resource record as (name, type, ttl, rdata)."""
+    _, _, qd, an, ns, ar = struct.unpack_from("!HHHHHH", packet, 0)
+    offset = 12
+    for _ in range(qd):
+        _, offset = read_name(packet, offset)
+        offset += 4
+    records = []
+    for _ in range(an + ns + ar):
+        name, offset = read_name(packet, offset)
+        rtype, _, ttl, length = struct.unpack_from("!HHIH", packet, offset)
+        offset += 10
+        records.append((name, rtype, ttl, packet[offset : offset + length]))
+        offset += length
+    return records
+
+
+def test_wants_service_matches_only_prismis_queries() -> None:
+    """
+    INVARIANT: Only PTR/ANY questions for _prismis._tcp.local. are answered
+    BREAKS: Answering every mDNS query floods the LAN with prismis records
+    """
+    assert wants_service(_query(SERVICE, TYPE_PTR))
+    assert wants_service(_query("_PRISMIS._tcp.local.", 255))
+    assert not wants_service(_query("_http._tcp.local.", TYPE_PTR))
+    assert not wants_service(_query(SERVICE, TYPE_A))
+    assert not wants_service(b"\x00\x01")  # Truncated packet
+
+    # Responses (QR bit set) are never treated as questions
+    response = bytearray(_query(SERVICE, TYPE_PTR))
+    response[2] |= 0x80
+    assert parse_questions(bytes(response)) == []
+
+
+def test_parse_questions_reports_unicast_bit() -> None:
+    """
+    INVARIANT: The QU bit in the question class is reported, not mistaken for the class
+    BREAKS: A QU query would be answered on the multicast group only
+    """
+    assert parse_questions(_query(SERVICE, TYPE_PTR, qclass=0x8001)) == [
+        (SERVICE, TYPE_PTR, True)
+    ]
+
+
+def test_build_response_legacy_unicast() -> None:
+    """
+    INVARIANT: Legacy unicast replies echo ID and question and carry PTR, SRV, A records
+    BREAKS: The TUI's one-shot resolver drops replies without its query ID
+    """
+    packet = build_response(
+        "box", "box", 8989, ["192.168.1.5"], {"version": "0.2.0"},
+        query_id=0x1234, legacy=True,
+    )
+    query_id, flags, qd, an, _, ar = struct.unpack_from("!HHHHHH", packet, 0)
+    assert (query_id, flags, qd, an, ar) == (0x1234, 0x8400, 1, 1, 3)
+
+    records = {rtype: (name, ttl, rdata) for name, rtype, ttl, rdata in _records(packet)}
+    assert records[TYPE_PTR][0] == SERVICE
+    assert read_name(records[TYPE_PTR][2], 0)[0] == f"box.{SERVICE}"
+    srv_name, ttl, srv = records[TYPE_SRV]
+    assert srv_name == f"box.{SERVICE}" and ttl <= 10
+    assert struct.unpack_from("!HHH", srv, 0)[2] == 8989
+    assert read_name(srv, 6)[0] == "box.local."
+    assert socket.inet_ntoa(records[TYPE_A][2]) == "192.168.1.5"
+
+
+def test_is_lan_host() -> None:
+    """
+    INVARIANT: A localhost-only daemon is not advertised
+    BREAKS: Other machines discover a daemon they can't connect to
+    """
+    assert not is_lan_host("127.0.0.1")
+    assert not is_lan_host("localhost")
+    assert is_lan_host("0.0.0.0")
+    assert is_lan_host("192.168.1.20")
@@ -259,3 +259,26 @@ def test_orthogonal_unit_vectors_yield_zero_similarity(test_db: Path) -> None:
         f"Got actual_sim={actual_sim:.6f} (relevance_score={relevance_score}). "
         f"If actual_sim ≈ -0.414, the old `1 - d` formula has been re-introduced."
     )
+
+
+def test_archived_scope_narrows_results(seeded_storage: Storage) -> None:
+    """
+    INVARIANT: archived="yes" searches everything, "no" leaves archived items
+    out, and "only" returns nothing else.
+    BREAKS: Archived research is either unfindable or mixed into searches that
+    asked for current content only.
+    """
+    seeded_storage.conn.execute(
+        "UPDATE content SET archived_at = CURRENT_TIMESTAMP WHERE external_id = 'low-relevance'"
+    )
+    seeded_storage.conn.commit()
+
+    def titles(archived: str) -> list[str]:
+        results = seeded_storage.search_content(
+            _make_query(), limit=10, min_score=0.0, archived=archived
+        )
+        return sorted(r["title"] for r in results)
+
+    assert titles("yes") == ["High Relevance Article", "Low Relevance Article"]
+    assert titles("no") == ["High Relevance Article"]
+    assert titles("only") == ["Low Relevance Article"]
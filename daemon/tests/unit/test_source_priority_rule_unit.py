@@ -0,0 +1,70 @@
+"""Unit tests for per-source priority rules (floor and ceiling).
+
+Protects:
+- INV-SOURCE-RULE: A source's items never land outside its floor..ceiling
+- INV-SOURCE-RULE-STORED: Rules round-trip through storage and reach the fetch loop
+"""
+
+from pathlib import Path
+
+import pytest
+
+from prismis_daemon.models import apply_priority_rule
+from prismis_daemon.storage import Storage
+
+
+def test_rule_clamps_evaluated_priority() -> None:
+    """
+    INVARIANT: The ceiling caps and the floor lifts an evaluated priority.
+    BREAKS: A noisy feed set to "at most LOW" would keep flooding HIGH.
+    """
+    assert apply_priority_rule("high", None, "low") == "low"
+    assert apply_priority_rule("low", "medium", None) == "medium"
+    assert apply_priority_rule("medium", "low", "high") == "medium"
+    assert apply_priority_rule("high", None, None) == "high"
+
+
+def test_rule_leaves_unprioritized_alone() -> None:
+    """
+    INVARIANT: Items the evaluator left unprioritized stay unprioritized.
+    BREAKS: A floor would turn every irrelevant item from the feed into reading.
+    """
+    assert apply_priority_rule(None, "high", None) is None
+
+
+def test_rule_is_stored_and_reported(test_db: Path) -> None:
+    """
+    INVARIANT: A set rule shows in get_all_sources and get_active_sources.
+    BREAKS: The TUI would forget the rule, or the orchestrator would never apply it.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+
+    assert storage.set_source_priority_rule(source, ceiling="low") is True
+    assert storage.set_source_priority_rule(source, floor="low") is True
+
+    for listed in (storage.get_all_sources(), storage.get_active_sources()):
+        assert listed[0]["priority_floor"] == "low"
+        assert listed[0]["priority_ceiling"] == "low"
+
+
+def test_rule_clears_and_rejects_bad_values(test_db: Path) -> None:
+    """
+    INVARIANT: Empty clears one side; unknown priorities and a floor above the
+    ceiling are rejected without changing the rule; unknown sources report False.
+    BREAKS: An impossible rule would be saved, or clearing wouldn't stick.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    storage.set_source_priority_rule(source, floor="medium", ceiling="high")
+
+    with pytest.raises(ValueError):
+        storage.set_source_priority_rule(source, ceiling="low")
+    with pytest.raises(ValueError):
+        storage.set_source_priority_rule(source, floor="urgent")
+
+    assert storage.set_source_priority_rule(source, floor="") is True
+    rule = storage.get_all_sources()[0]
+    assert rule["priority_floor"] is None
+    assert rule["priority_ceiling"] == "high"
+    assert storage.set_source_priority_rule("missing", floor="low") is False
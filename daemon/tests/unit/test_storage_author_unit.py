@@ -0,0 +1,68 @@
+"""Unit tests for content bylines (the author column).
+
+Protects:
+- INV-AUTHOR: a fetched item's author is stored and returned with it
+"""
+
+import sqlite3
+from pathlib import Path
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def test_author_stored_and_returned(test_db: Path) -> None:
+    """
+    INVARIANT: author round-trips through add_content and create_or_update_content.
+    BREAKS: Bylines from multi-author feeds never reach the TUI.
+    """
+    storage = Storage(test_db)
+    src_id = storage.add_source("https://example.com/feed", "rss", "Test Feed")
+    content_id = storage.add_content(
+        ContentItem(
+            source_id=src_id,
+            external_id="item-1",
+            title="Article",
+            url="https://example.com/1",
+            content="Test content",
+            author="Jane Doe",
+        )
+    )
+    assert content_id is not None
+    assert storage.get_content_by_id(content_id)["author"] == "Jane Doe"
+
+    other_id, is_new = storage.create_or_update_content(
+        {
+            "source_id": src_id,
+            "external_id": "item-2",
+            "title": "Other",
+            "url": "https://example.com/2",
+        }
+    )
+    assert is_new
+    assert storage.get_content_by_id(other_id)["author"] is None
+
+
+def test_author_skipped_before_migration(test_db: Path) -> None:
+    """
+    INVARIANT: databases without the author column still store new items.
+    BREAKS: Fetching stops entirely until `make migrate` is run.
+    """
+    conn = sqlite3.connect(test_db)
+    conn.execute("ALTER TABLE content DROP COLUMN author")
+    conn.commit()
+    conn.close()
+
+    storage = Storage(test_db)
+    src_id = storage.add_source("https://example.com/feed", "rss", "Test Feed")
+    content_id = storage.add_content(
+        ContentItem(
+            source_id=src_id,
+            external_id="item-1",
+            title="Article",
+            url="https://example.com/1",
+            author="Jane Doe",
+        )
+    )
+    assert content_id is not None
+    assert storage.get_content_by_id(content_id)["author"] is None
@@ -0,0 +1,59 @@
+"""Unit tests for merging duplicate sources (Storage.merge_sources).
+
+Protects:
+- INV-SOURCE-MERGE: Merged sources' content moves to the kept source before they are deleted
+"""
+
+from pathlib import Path
+
+import pytest
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def _add(storage: Storage, source_id: str, key: str) -> None:
+    storage.add_content(
+        ContentItem(
+            source_id=source_id,
+            external_id=key,
+            title=key,
+            url=f"https://example.com/{key}",
+            content="Test content",
+        )
+    )
+
+
+def test_merge_moves_content_and_deletes_duplicates(test_db: Path) -> None:
+    """
+    INVARIANT: Every item of a merged source ends up on the kept source; the duplicates are gone.
+    BREAKS: Deduplicating feeds would silently delete reading history, or leave the duplicates behind.
+    """
+    storage = Storage(test_db)
+    keep = storage.add_source("https://example.com/feed", "rss", "Example")
+    http = storage.add_source("http://example.com/feed/", "rss", "Example (http)")
+    _add(storage, keep, "kept")
+    _add(storage, http, "moved-1")
+    _add(storage, http, "moved-2")
+
+    assert storage.merge_sources(keep, [http]) == 2
+
+    sources = {s["id"] for s in storage.get_all_sources()}
+    assert sources == {keep}
+    rows = storage.conn.execute("SELECT source_id FROM content").fetchall()
+    assert [row["source_id"] for row in rows] == [keep, keep, keep]
+
+
+def test_merge_rejects_unknown_or_self(test_db: Path) -> None:
+    """
+    INVARIANT: Nothing changes when a source is missing or the kept source is in the merge list.
+    BREAKS: A stale TUI list could delete the source meant to survive.
+    """
+    storage = Storage(test_db)
+    keep = storage.add_source("https://example.com/feed", "rss", "Example")
+    _add(storage, keep, "kept")
+
+    assert storage.merge_sources(keep, ["missing"]) is None
+    with pytest.raises(ValueError):
+        storage.merge_sources(keep, [keep])
+    assert [s["id"] for s in storage.get_all_sources()] == [keep]
@@ -0,0 +1,38 @@
+"""Unit tests for pinning content (update_content_status pinned).
+
+Protects:
+- INV-PIN: pinned toggles independently and is returned with the item
+"""
+
+from pathlib import Path
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def test_pin_and_unpin(test_db: Path) -> None:
+    """
+    INVARIANT: pinned is False by default, and pinning leaves read status alone.
+    BREAKS: Pinned items drop off the top of the TUI, or pinning marks them read.
+    """
+    storage = Storage(test_db)
+    src_id = storage.add_source("https://example.com/feed", "rss", "Test Feed")
+    content_id = storage.add_content(
+        ContentItem(
+            source_id=src_id,
+            external_id="item-1",
+            title="Article",
+            url="https://example.com/1",
+            content="Test content",
+        )
+    )
+    assert content_id is not None
+    assert storage.get_content_by_id(content_id)["pinned"] is False
+
+    assert storage.update_content_status(content_id, pinned=True)
+    item = storage.get_content_by_id(content_id)
+    assert item["pinned"] is True
+    assert item["read"] is False
+
+    assert storage.update_content_status(content_id, pinned=False)
+    assert storage.get_content_by_id(content_id)["pinned"] is False
@@ -0,0 +1,56 @@
+"""Unit tests for batch read updates (Storage.set_read_batch).
+
+Protects:
+- INV-BATCH-READ: Every listed item changes in one call; unknown IDs are skipped
+"""
+
+from pathlib import Path
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def _seed(storage: Storage, count: int) -> list[str]:
+    """Insert count content items. Returns their IDs."""
+    src_id = storage.add_source("https://example.com/feed", "rss", "Test Feed")
+    ids = []
+    for i in range(count):
+        content_id = storage.add_content(
+            ContentItem(
+                source_id=src_id,
+                external_id=f"item-{i}",
+                title=f"Article {i}",
+                url=f"https://example.com/{i}",
+                content="Test content",
+            )
+        )
+        assert content_id is not None
+        ids.append(content_id)
+    return ids
+
+
+def test_set_read_batch_marks_and_undoes(test_db: Path) -> None:
+    """
+    INVARIANT: set_read_batch updates exactly the listed items, both ways.
+    BREAKS: :markall would leave stragglers unread, and its undo would not restore them.
+    """
+    storage = Storage(test_db)
+    ids = _seed(storage, 3)
+
+    assert storage.set_read_batch(ids[:2] + ["missing-id"], True) == 2
+    assert [storage.get_content_by_id(i)["read"] for i in ids] == [True, True, False]
+
+    assert storage.set_read_batch(ids[:2], False) == 2
+    assert not any(storage.get_content_by_id(i)["read"] for i in ids)
+
+
+def test_set_read_batch_chunks_large_lists(test_db: Path) -> None:
+    """
+    INVARIANT: Lists longer than SQLite's parameter limit are still applied.
+    BREAKS: Marking a big backlog read fails with "too many SQL variables".
+    """
+    storage = Storage(test_db)
+    ids = _seed(storage, 2)
+
+    padded = ids + [f"missing-{i}" for i in range(1200)]
+    assert storage.set_read_batch(padded, True) == 2
@@ -0,0 +1,122 @@
+"""Unit tests for removing a source with a content mode (Storage.remove_source).
+
+Protects:
+- INV-SOURCE-RETENTION: content=keep/archive never deletes the source's items
+- INV-SOURCE-RETENTION-LISTED: Kept items stay in the content lists after their source is gone
+"""
+
+from pathlib import Path
+
+import pytest
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def _add(
+    storage: Storage, source_id: str, key: str, priority: str | None = None
+) -> str:
+    return storage.add_content(
+        ContentItem(
+            source_id=source_id,
+            external_id=key,
+            title=key,
+            url=f"https://example.com/{key}",
+            content="Test content",
+            priority=priority,
+        )
+    )
+
+
+def _rows(storage: Storage) -> list:
+    return storage.conn.execute(
+        "SELECT external_id, source_id, archived_at FROM content ORDER BY external_id"
+    ).fetchall()
+
+
+def test_delete_mode_removes_unfavorited_items(test_db: Path) -> None:
+    """
+    INVARIANT: The default mode deletes the source's items except favorites.
+    BREAKS: Existing removals would start leaving every item behind.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    _add(storage, source, "plain")
+    storage.update_content_status(_add(storage, source, "fav"), favorited=True)
+
+    assert storage.remove_source(source) is True
+
+    rows = _rows(storage)
+    assert [row["external_id"] for row in rows] == ["fav"]
+    assert rows[0]["source_id"] is None
+
+
+def test_keep_mode_detaches_every_item(test_db: Path) -> None:
+    """
+    INVARIANT: content=keep leaves every item in place, detached and unarchived.
+    BREAKS: Choosing "keep" in the TUI would still delete the reading history.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    _add(storage, source, "a")
+    _add(storage, source, "b")
+
+    assert storage.remove_source(source, "keep") is True
+
+    rows = _rows(storage)
+    assert [row["external_id"] for row in rows] == ["a", "b"]
+    assert all(row["source_id"] is None for row in rows)
+    assert all(row["archived_at"] is None for row in rows)
+    assert storage.get_all_sources() == []
+
+
+def test_archive_mode_detaches_and_archives(test_db: Path) -> None:
+    """
+    INVARIANT: content=archive keeps every item, detached and archived.
+    BREAKS: Archived leftovers would crowd the active list, or be deleted outright.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    _add(storage, source, "a")
+    _add(storage, source, "b")
+
+    assert storage.remove_source(source, "archive") is True
+
+    rows = _rows(storage)
+    assert [row["external_id"] for row in rows] == ["a", "b"]
+    assert all(row["source_id"] is None for row in rows)
+    assert all(row["archived_at"] is not None for row in rows)
+
+
+def test_unknown_mode_changes_nothing(test_db: Path) -> None:
+    """
+    INVARIANT: An unknown content mode is rejected before anything is touched.
+    BREAKS: A typo would fall through to deleting the source's content.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    _add(storage, source, "a")
+
+    with pytest.raises(ValueError):
+        storage.remove_source(source, "purge")
+
+    assert [s["id"] for s in storage.get_all_sources()] == [source]
+    assert len(_rows(storage)) == 1
+
+
+def test_kept_items_stay_listed(test_db: Path) -> None:
+    """
+    INVARIANT: Items kept from a removed source are still listed, under "(removed source)".
+    BREAKS: An inner join on sources hides them, so "keep" would look the same as "delete".
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    _add(storage, source, "a", priority="high")
+
+    storage.remove_source(source, "keep")
+
+    by_priority = storage.get_content_by_priority("high")
+    assert [item["external_id"] for item in by_priority] == ["a"]
+    assert by_priority[0]["source_name"] == "(removed source)"
+    assert by_priority[0]["source_id"] is None
+    assert [item["external_id"] for item in storage.get_content_since()] == ["a"]
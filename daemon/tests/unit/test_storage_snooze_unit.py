@@ -0,0 +1,60 @@
+"""Unit tests for snoozing content (update_content_status snoozed_until).
+
+Protects:
+- INV-SNOOZE: snoozed_until is stored as RFC3339 UTC and returned with the item
+"""
+
+from datetime import UTC, datetime, timedelta, timezone
+from pathlib import Path
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def _seed(storage: Storage) -> str:
+    """Insert one content item. Returns its ID."""
+    src_id = storage.add_source("https://example.com/feed", "rss", "Test Feed")
+    content_id = storage.add_content(
+        ContentItem(
+            source_id=src_id,
+            external_id="item-1",
+            title="Article",
+            url="https://example.com/1",
+            content="Test content",
+        )
+    )
+    assert content_id is not None
+    return content_id
+
+
+def test_snoozed_until_round_trips_as_utc(test_db: Path) -> None:
+    """
+    INVARIANT: A snooze time in any zone is stored and read back as UTC.
+    BREAKS: The TUI wakes items hours early or late for users outside UTC.
+    """
+    storage = Storage(test_db)
+    content_id = _seed(storage)
+    assert storage.get_content_by_id(content_id)["snoozed_until"] is None
+
+    until = datetime(2026, 5, 18, 9, 0, tzinfo=timezone(timedelta(hours=2)))
+    assert storage.update_content_status(content_id, snoozed_until=until)
+
+    stored = storage.get_content_by_id(content_id)["snoozed_until"]
+    assert stored == "2026-05-18T07:00:00Z"
+    assert datetime.fromisoformat(stored) == until
+
+
+def test_snooze_leaves_other_fields_alone(test_db: Path) -> None:
+    """
+    INVARIANT: Snoozing does not change read or favorited status.
+    BREAKS: A snoozed item comes back already read and never shows in the unread list.
+    """
+    storage = Storage(test_db)
+    content_id = _seed(storage)
+
+    storage.update_content_status(
+        content_id, snoozed_until=datetime.now(UTC) + timedelta(hours=3)
+    )
+    item = storage.get_content_by_id(content_id)
+    assert item["read"] is False
+    assert item["favorited"] is False
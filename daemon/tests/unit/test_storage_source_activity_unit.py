@@ -0,0 +1,50 @@
+"""Unit tests for per-source activity counts (Storage.get_source_daily_counts).
+
+Protects:
+- INV-SOURCE-ACTIVITY: Items land in the day they were published, oldest day first
+"""
+
+from datetime import UTC, datetime, timedelta
+from pathlib import Path
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage
+
+
+def _add(storage: Storage, source_id: str, key: str, published: datetime | None) -> None:
+    storage.add_content(
+        ContentItem(
+            source_id=source_id,
+            external_id=key,
+            title=key,
+            url=f"https://example.com/{key}",
+            content="Test content",
+            published_at=published,
+        )
+    )
+
+
+def test_daily_counts_bucket_by_publish_day(test_db: Path) -> None:
+    """
+    INVARIANT: Each item counts once, on its publish day; old and undated items are left out.
+    BREAKS: Sparklines would show dead feeds as active, or spread one day's burst over two.
+    """
+    storage = Storage(test_db)
+    busy = storage.add_source("https://example.com/busy", "rss", "Busy")
+    quiet = storage.add_source("https://example.com/quiet", "rss", "Quiet")
+
+    # Noon local time keeps each item inside its day whatever the timezone
+    noon = datetime.now().astimezone().replace(hour=12, minute=0, second=0, microsecond=0)
+    _add(storage, busy, "today-1", noon.astimezone(UTC))
+    _add(storage, busy, "today-2", noon)
+    _add(storage, busy, "week-ago", noon - timedelta(days=7))
+    _add(storage, busy, "too-old", noon - timedelta(days=30))
+    _add(storage, quiet, "undated", None)
+
+    counts = storage.get_source_daily_counts(14)
+
+    assert quiet not in counts
+    assert len(counts[busy]) == 14
+    assert counts[busy][13] == 2
+    assert counts[busy][6] == 1
+    assert sum(counts[busy]) == 3
@@ -0,0 +1,56 @@
+"""Unit tests for filing sources under categories (Storage.set_source_category).
+
+Protects:
+- INV-SOURCE-CATEGORY: A source has at most one category, and get_all_sources reports it
+"""
+
+from pathlib import Path
+
+from prismis_daemon.storage import Storage
+
+
+def test_category_is_created_and_reported(test_db: Path) -> None:
+    """
+    INVARIANT: Setting a new category creates it and get_all_sources returns its name.
+    BREAKS: Categories chosen in the TUI would vanish on the next source refresh.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+
+    assert storage.set_source_category(source, " work ") is True
+
+    assert storage.get_all_sources()[0]["category"] == "work"
+
+
+def test_category_is_replaced_and_shared(test_db: Path) -> None:
+    """
+    INVARIANT: Recategorizing replaces the old assignment; sources share categories by name.
+    BREAKS: A source would show up under two sidebar groups, or categories would duplicate.
+    """
+    storage = Storage(test_db)
+    first = storage.add_source("https://example.com/a", "rss", "A")
+    second = storage.add_source("https://example.com/b", "rss", "B")
+
+    storage.set_source_category(first, "news")
+    storage.set_source_category(first, "work")
+    storage.set_source_category(second, "work")
+
+    categories = {s["id"]: s["category"] for s in storage.get_all_sources()}
+    assert categories == {first: "work", second: "work"}
+    assert storage.conn.execute("SELECT COUNT(*) FROM source_categories").fetchone()[0] == 2
+    names = storage.conn.execute("SELECT name FROM categories ORDER BY name").fetchall()
+    assert [row["name"] for row in names] == ["news", "work"]
+
+
+def test_empty_category_clears_and_unknown_source_is_rejected(test_db: Path) -> None:
+    """
+    INVARIANT: An empty category uncategorizes the source; unknown sources report False.
+    BREAKS: Clearing the field in the TUI would leave the old category, or a stale ID would 500.
+    """
+    storage = Storage(test_db)
+    source = storage.add_source("https://example.com/feed", "rss", "Example")
+    storage.set_source_category(source, "work")
+
+    assert storage.set_source_category(source, "") is True
+    assert storage.get_all_sources()[0]["category"] is None
+    assert storage.set_source_category("missing", "work") is False
@@ -0,0 +1,59 @@
+"""Unit tests for user-defined tags on content (Storage.update_content_status).
+
+Protects:
+- INV-TAGS-NORMALIZED: Tags are stored trimmed, lowercase, and de-duplicated
+- INV-TAGS-CLEAR: An empty list clears tags; None leaves them untouched
+"""
+
+from pathlib import Path
+
+from prismis_daemon.models import ContentItem
+from prismis_daemon.storage import Storage, normalize_tags
+
+
+def _seed(storage: Storage) -> str:
+    """Insert one content item. Returns content_id."""
+    src_id = storage.add_source("https://example.com/feed", "rss", "Test Feed")
+    content_id = storage.add_content(
+        ContentItem(
+            source_id=src_id,
+            external_id="tagged",
+            title="Tagged article",
+            url="https://example.com/tagged",
+            content="Test content",
+        )
+    )
+    assert content_id is not None
+    return content_id
+
+
+def test_normalize_tags_trims_lowercases_and_dedupes() -> None:
+    """
+    INVARIANT: normalize_tags returns clean, unique tags in first-seen order.
+    BREAKS: "Rust" and "rust" become separate tags and filters miss items.
+    """
+    assert normalize_tags(["Rust, career", " rust ", "", "AI"]) == [
+        "rust",
+        "career",
+        "ai",
+    ]
+
+
+def test_user_tags_round_trip_and_clear(test_db: Path) -> None:
+    """
+    INVARIANT: user_tags replaces the item's tags; [] clears; None leaves them.
+    BREAKS: Marking an item read would wipe the user's tags.
+    """
+    storage = Storage(test_db)
+    content_id = _seed(storage)
+
+    assert storage.get_content_by_id(content_id)["user_tags"] == []
+
+    storage.update_content_status(content_id, user_tags=["Rust", "career"])
+    assert storage.get_content_by_id(content_id)["user_tags"] == ["rust", "career"]
+
+    storage.update_content_status(content_id, read=True)
+    assert storage.get_content_by_id(content_id)["user_tags"] == ["rust", "career"]
+
+    storage.update_content_status(content_id, user_tags=[])
+    assert storage.get_content_by_id(content_id)["user_tags"] == []
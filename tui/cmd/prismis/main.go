@@ -1,41 +1,108 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
 	"github.com/nickpending/prismis/internal/config"
 	"github.com/nickpending/prismis/internal/ui"
 )
 
 func main() {
 	// Parse CLI flags
-	remoteURL := flag.String("remote", "", "Remote daemon URL (e.g., http://server:8989)")
-	flag.Parse()
+	remoteURL := flag.String("remote", "", "Remote daemon: a URL (e.g., http://server:8989), a saved profile or host name, or nothing to search the LAN")
+	var start ui.StartOptions
+	flag.StringVar(&start.Priority, "priority", "", "Start in a priority view: high, medium, low, favorites, interesting, unprioritized, or all")
+	flag.StringVar(&start.Source, "source", "", "Start filtered to one source, by name or URL (e.g., \"r/rust\")")
+	flag.StringVar(&start.Reader, "reader", "", "Open the item with this ID in the reader")
+	flag.StringVar(&start.View, "view", "", "Start in a view: list (default) or digest")
+	cpuProfile := flag.String("cpuprofile", "", "Write a CPU profile to this file until the TUI exits")
+	memProfile := flag.String("memprofile", "", "Write a heap profile to this file when the TUI exits")
+	pprofListen := flag.String("pprof", "", "Serve net/http/pprof on this localhost port or address (e.g., 6060)")
+	flag.BoolVar(&start.Debug, "debug", false, "Overlay update/render timings and recent messages, and trace them to debug-trace.jsonl")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: prismis [flags] [command]\n\nFlags:\n")
+		flag.PrintDefaults()
+		printSubcommandUsage(flag.CommandLine.Output())
+	}
+	args, remoteGiven := bareRemote(os.Args[1:])
+	_ = flag.CommandLine.Parse(args) // ExitOnError: bad flags never return
 
 	// Create model: --remote flag > config [remote].url > local mode
-	var initialModel tea.Model
-	if *remoteURL != "" {
+	var model ui.Model
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	if flag.NArg() > 0 {
+		if _, ok := subcommands[flag.Arg(0)]; !ok {
+			fmt.Fprintf(os.Stderr, "prismis: unknown command '%s'\n", flag.Arg(0))
+			flag.Usage()
+			os.Exit(2)
+		}
+		remote := ""
+		if remoteGiven {
+			if remote, err = resolveRemote(*remoteURL, cfg); err != nil {
+				fmt.Fprintln(os.Stderr, "--remote:", err)
+				os.Exit(1)
+			}
+			api.SetRemoteURL(remote)
+		} else if cfg.HasRemoteConfig() {
+			remote = cfg.GetRemoteURL()
+		}
+		os.Exit(runSubcommand(flag.Args(), remote))
+	}
+	if remoteGiven {
 		// Explicit --remote flag takes priority
-		initialModel = ui.NewModelRemote(*remoteURL)
-	} else {
-		// Check config for [remote] section
-		cfg, err := config.LoadConfig()
-		if err == nil && cfg.HasRemoteConfig() {
-			initialModel = ui.NewModelRemote(cfg.GetRemoteURL())
-		} else {
-			initialModel = ui.NewModel()
+		url, err := resolveRemote(*remoteURL, cfg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "--remote:", err)
+			os.Exit(1)
 		}
+		model = ui.NewModelRemote(url)
+	} else if cfg.HasRemoteConfig() {
+		// Check config for [remote] section
+		model = ui.NewModelRemote(cfg.GetRemoteURL())
+	} else {
+		model = ui.NewModel()
+	}
+
+	model, err = model.WithStart(start)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	prof, err := startProfiling(*cpuProfile, *memProfile, *pprofListen)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
 
 	// Configure program to not clear screen on exit and use alt screen buffer
 	p := tea.NewProgram(
-		initialModel,
+		model,
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Enable mouse support
+		tea.WithReportFocus(),     // FocusMsg/BlurMsg for [refresh] blurred
 	)
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	prof.stop() // Before any os.Exit below, which skips deferred calls
+	if err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			// Bubble Tea has restored the terminal and printed the stack
+			if report := ui.CrashReport(); report != "" {
+				fmt.Fprintf(os.Stderr, "prismis crashed. A crash report with the stack and recent activity is at:\n  %s\n", report)
+			} else {
+				fmt.Fprintln(os.Stderr, "prismis crashed in a background task; the stack is above.")
+			}
+			os.Exit(1)
+		}
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	rpprof "runtime/pprof"
+	"strconv"
+)
+
+// profiling holds what --cpuprofile, --memprofile, and --pprof started
+type profiling struct {
+	cpu     *os.File
+	memPath string
+	server  *http.Server
+}
+
+// startProfiling starts the CPU profile and pprof listener the flags ask
+// for. The heap profile is written by stop, when the TUI exits.
+func startProfiling(cpuPath, memPath, listen string) (*profiling, error) {
+	p := &profiling{memPath: memPath}
+	if listen != "" {
+		addr, err := pprofAddr(listen)
+		if err != nil {
+			return nil, fmt.Errorf("--pprof: %w", err)
+		}
+		// Listen before the TUI takes the screen so a busy port is reported
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("--pprof: %w", err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		p.server = &http.Server{Handler: mux}
+		go p.server.Serve(ln) // Returns ErrServerClosed on stop
+		fmt.Fprintf(os.Stderr, "pprof listening on http://%s/debug/pprof/\n", ln.Addr())
+	}
+	if cpuPath != "" {
+		f, err := os.Create(cpuPath)
+		if err != nil {
+			p.stop()
+			return nil, fmt.Errorf("--cpuprofile: %w", err)
+		}
+		if err := rpprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			p.stop()
+			return nil, fmt.Errorf("--cpuprofile: %w", err)
+		}
+		p.cpu = f
+	}
+	return p, nil
+}
+
+// pprofAddr turns the --pprof argument into a loopback address: a bare
+// port listens on localhost, and other hosts are refused since the
+// profiles expose the program's memory
+func pprofAddr(listen string) (string, error) {
+	if _, err := strconv.Atoi(listen); err == nil {
+		return net.JoinHostPort("localhost", listen), nil
+	}
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return "", fmt.Errorf("expected a port or localhost:port, got '%s'", listen)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return "", errors.New("only listens on localhost; use ssh -L to profile a remote machine")
+	}
+	return listen, nil
+}
+
+// stop finishes the CPU profile, writes the heap profile, and closes the
+// listener. Errors go to stderr: the TUI has already exited.
+func (p *profiling) stop() {
+	if p.cpu != nil {
+		rpprof.StopCPUProfile()
+		p.cpu.Close()
+		fmt.Fprintf(os.Stderr, "CPU profile written to %s\n", p.cpu.Name())
+	}
+	if p.memPath != "" {
+		if err := writeHeapProfile(p.memPath); err != nil {
+			fmt.Fprintln(os.Stderr, "--memprofile:", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Heap profile written to %s\n", p.memPath)
+		}
+	}
+	if p.server != nil {
+		p.server.Close()
+	}
+}
+
+// writeHeapProfile saves live allocations as of exit
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	runtime.GC() // Up-to-date statistics
+	return rpprof.WriteHeapProfile(f)
+}
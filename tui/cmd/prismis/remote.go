@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nickpending/prismis/internal/config"
+	"github.com/nickpending/prismis/internal/discovery"
+)
+
+// discoveryTimeout is how long --remote waits for daemons to answer
+const discoveryTimeout = 1500 * time.Millisecond
+
+// bareRemote rewrites a valueless --remote (last argument, or followed by
+// another flag) to --remote= so the flag package accepts it. It reports
+// whether --remote was given at all.
+func bareRemote(args []string) ([]string, bool) {
+	given := false
+	out := make([]string, 0, len(args))
+	for i, arg := range args {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "remote" {
+			out = append(out, arg)
+			continue
+		}
+		given = true
+		if !hasValue && (i+1 == len(args) || strings.HasPrefix(args[i+1], "-")) {
+			arg = "--remote="
+		}
+		out = append(out, arg)
+	}
+	return out, given
+}
+
+// resolveRemote turns the --remote argument into a daemon URL: a URL is
+// used as given, a name matching a [remotes.<name>] profile uses the saved
+// URL, and anything else is looked up on the LAN. A daemon picked from
+// discovery is saved as a profile so the next start skips the lookup.
+func resolveRemote(arg string, cfg *config.Config) (string, error) {
+	arg = strings.TrimSpace(arg)
+	if strings.Contains(arg, "://") {
+		return arg, nil
+	}
+	if url, ok := cfg.GetRemoteProfile(arg); arg != "" && ok {
+		return url, nil
+	}
+	if arg == "" && cfg.HasRemoteConfig() {
+		return cfg.GetRemoteURL(), nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Looking for prismis daemons on the LAN...")
+	daemons, err := discovery.Browse(discoveryTimeout)
+	if err != nil && arg == "" {
+		return "", err
+	}
+	if arg != "" {
+		var matches []discovery.Daemon
+		for _, d := range daemons {
+			if d.Matches(arg) {
+				matches = append(matches, d)
+			}
+		}
+		daemons = matches
+	}
+
+	var chosen discovery.Daemon
+	switch len(daemons) {
+	case 0:
+		if arg == "" {
+			return "", fmt.Errorf("no prismis daemons found on the LAN; pass --remote http://host:8989, or set [api] host = \"0.0.0.0\" on the server")
+		}
+		// Not advertised (or discovery blocked): try the name as a host
+		if _, _, err := net.SplitHostPort(arg); err == nil {
+			return "http://" + arg, nil
+		}
+		return "http://" + net.JoinHostPort(arg, "8989"), nil
+	case 1:
+		chosen = daemons[0]
+	default:
+		chosen, err = chooseDaemon(daemons, os.Stdin, os.Stderr)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := config.SaveRemoteProfile(chosen.Name, chosen.URL()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: couldn't save the remote profile: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "Saved %s as [remotes.%s]; next time run: prismis --remote %s\n",
+			chosen.URL(), config.ProfileName(chosen.Name), config.ProfileName(chosen.Name))
+	}
+	return chosen.URL(), nil
+}
+
+// chooseDaemon lists daemons and reads the number of the one to use
+func chooseDaemon(daemons []discovery.Daemon, in io.Reader, out io.Writer) (discovery.Daemon, error) {
+	fmt.Fprintln(out, "Found several prismis daemons:")
+	for i, d := range daemons {
+		version := ""
+		if d.Version != "" {
+			version = " (v" + d.Version + ")"
+		}
+		fmt.Fprintf(out, "  %d) %-20s %s%s\n", i+1, d.Name, d.URL(), version)
+	}
+	fmt.Fprintf(out, "Connect to [1-%d]: ", len(daemons))
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && line == "" {
+		return discovery.Daemon{}, fmt.Errorf("no daemon chosen")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 1 || n > len(daemons) {
+		return discovery.Daemon{}, fmt.Errorf("expected a number from 1 to %d, got '%s'", len(daemons), strings.TrimSpace(line))
+	}
+	return daemons[n-1], nil
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/service"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// subcommand is one non-interactive command run in place of the TUI. run
+// gets the daemon URL from --remote or config, "" in local mode.
+type subcommand struct {
+	usage string
+	help  string
+	run   func(args []string, remote string, out io.Writer) error
+}
+
+// subcommands are the scriptable commands: prismis <name> [args]
+var subcommands = map[string]subcommand{
+	"add": {
+		usage: "add <url> [--name NAME] [--category CATEGORY]",
+		help:  "Add a source (RSS, Reddit, YouTube, or file URL)",
+		run:   runAdd,
+	},
+	"sources": {
+		usage: "sources",
+		help:  "List sources, tab-separated: name, type, status, unread, URL",
+		run:   runSources,
+	},
+	"prune": {
+		usage: "prune [age] [--dry-run]",
+		help:  "Delete unprioritized items, optionally only those older than age (7d, 2w, 1m)",
+		run:   runPrune,
+	},
+	"mark-read": {
+		usage: "mark-read <id>...",
+		help:  "Mark items read by ID",
+		run:   runMarkRead,
+	},
+}
+
+// runSubcommand runs the subcommand named by args[0], printing errors to
+// stderr, and returns the exit code: 0, 1 if it failed, 2 for bad usage
+func runSubcommand(args []string, remote string) int {
+	cmd := subcommands[args[0]]
+	err := cmd.run(args[1:], remote, os.Stdout)
+	var usage usageError
+	switch {
+	case errors.As(err, &usage):
+		fmt.Fprintf(os.Stderr, "%s: %v\nusage: prismis %s\n", args[0], err, cmd.usage)
+		return 2
+	case errors.Is(err, flag.ErrHelp):
+		fmt.Printf("usage: prismis %s\n%s\n", cmd.usage, cmd.help)
+		return 0
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// usageError is a subcommand given the wrong arguments
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// subcommandFlags parses a subcommand's flags, which may come before or
+// after its arguments, and returns the arguments
+func subcommandFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usageError(err.Error())
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// printSubcommandUsage lists the subcommands under the flag defaults
+func printSubcommandUsage(w io.Writer) {
+	fmt.Fprintln(w, "\nCommands (run without starting the TUI):")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range []string{"add", "sources", "prune", "mark-read"} {
+		fmt.Fprintf(tw, "  prismis %s\t%s\n", subcommands[name].usage, subcommands[name].help)
+	}
+	tw.Flush()
+}
+
+func runAdd(args []string, _ string, out io.Writer) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	name := fs.String("name", "", "Display name")
+	category := fs.String("category", "", "Category")
+	args, err := subcommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("expected one URL")
+	}
+
+	result := operations.AddSource(args[0], *name, *category)().(operations.SourceOperationMsg)
+	if !result.Success {
+		return errors.New(result.Message)
+	}
+	fmt.Fprintln(out, result.Message)
+	return nil
+}
+
+func runSources(args []string, remote string, out io.Writer) error {
+	if len(args) > 0 {
+		return usageError("takes no arguments")
+	}
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+
+	if remote == "" {
+		// Local mode reads the database, so this works with the daemon down
+		sources, err := db.GetSourcesWithCounts()
+		if err != nil {
+			return err
+		}
+		for _, s := range sources {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", s.Name, s.Type, sourceStatus(s.Active, s.ErrorCount), s.UnreadCount, s.URL)
+		}
+		return nil
+	}
+
+	client, err := api.NewClient()
+	if err != nil {
+		return err
+	}
+	list, err := client.GetSources(context.Background())
+	if err != nil {
+		return errors.New(api.ErrorMessage(err))
+	}
+	for _, s := range list.Sources {
+		name := s.URL
+		if s.Name != nil && *s.Name != "" {
+			name = *s.Name
+		}
+		// The API doesn't report unread counts
+		fmt.Fprintf(tw, "%s\t%s\t%s\t-\t%s\n", name, s.Type, sourceStatus(s.Active, s.ErrorCount), s.URL)
+	}
+	return nil
+}
+
+// sourceStatus is a source's state for the sources listing
+func sourceStatus(active bool, errorCount int) string {
+	switch {
+	case !active:
+		return "paused"
+	case errorCount > 0:
+		return fmt.Sprintf("failing(%d)", errorCount)
+	}
+	return "active"
+}
+
+func runPrune(args []string, _ string, out io.Writer) error {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Only count what would be deleted")
+	args, err := subcommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return usageError("expected at most one age")
+	}
+	var days *int
+	age := "all ages"
+	if len(args) == 1 {
+		parsed := commands.ParseAge(args[0])
+		if parsed < 0 {
+			return usageError(fmt.Sprintf("invalid age '%s' (use format like 7d, 2w, 1m)", args[0]))
+		}
+		days, age = &parsed, "older than "+args[0]
+	}
+
+	if *dryRun {
+		client, err := api.NewClient()
+		if err != nil {
+			return err
+		}
+		count, err := client.PruneCount(context.Background(), days)
+		if err != nil {
+			return errors.New(api.ErrorMessage(err))
+		}
+		fmt.Fprintf(out, "Would delete %d unprioritized items (%s)\n", count, age)
+		return nil
+	}
+
+	result := operations.ExecutePrune(days)().(operations.PruneResultMsg)
+	if result.Error != nil {
+		return result.Error
+	}
+	fmt.Fprintf(out, "Deleted %d unprioritized items (%s)\n", result.Deleted, age)
+	return nil
+}
+
+func runMarkRead(args []string, _ string, out io.Writer) error {
+	if len(args) == 0 {
+		return usageError("expected at least one item ID")
+	}
+	updated, err := service.SetReadBatch(args, true)
+	if errors.Is(err, service.ErrQueued) {
+		fmt.Fprintf(out, "Daemon unreachable: queued %d items to mark read on the next sync\n", len(args))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Marked %d of %d items read\n", updated, len(args))
+	if updated < len(args) {
+		return fmt.Errorf("%d of the IDs matched no item", len(args)-updated)
+	}
+	return nil
+}
@@ -0,0 +1,785 @@
+// Package apitest provides an in-memory fake of the prismis daemon's REST API
+// so api and operations tests can run without a live Python daemon.
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// DefaultKey is the API key the fake daemon accepts
+const DefaultKey = "apitest-key"
+
+// Source is a source as stored by the fake daemon
+type Source struct {
+	ID         string
+	URL        string
+	Type       string
+	Name       string
+	Category   string
+	Active     bool
+	ErrorCount int
+	LastError  string
+	// Priority rule, "" for none
+	PriorityFloor   string
+	PriorityCeiling string
+}
+
+// Entry is a content item as stored by the fake daemon
+type Entry struct {
+	ID           string
+	SourceID     string
+	Title        string
+	URL          string
+	Content      string
+	Summary      string
+	Priority     string // "high", "medium", "low", or "" for unprioritized
+	Read         bool
+	Favorited    bool
+	Interesting  bool
+	UserFeedback string
+	Archived     bool
+	SnoozedUntil time.Time // Zero when not snoozed
+	Pinned       bool
+	Author       string
+	Analysis     map[string]any // Sent as the entry's analysis object; nil sends none
+	PublishedAt  time.Time
+	FetchedAt    time.Time
+}
+
+// Daemon is a running fake daemon. Seed it with AddSource/AddEntry, point a
+// client at it with Client or Install, then inspect state with Sources/Entries.
+type Daemon struct {
+	*httptest.Server
+	Key string
+
+	mu       sync.Mutex
+	nextID   int
+	sources  []*Source
+	entries  []*Entry
+	failures map[string]failure
+	requests []string
+
+	transcripts map[string]map[string]any // Briefing transcripts by filename
+	latest      string                    // Most recent briefing filename
+	version     *api.VersionInfo          // GET /api/version answer; nil answers 404
+}
+
+type failure struct {
+	status  int
+	message string
+}
+
+// New starts a fake daemon that is shut down when the test ends
+func New(t testing.TB) *Daemon {
+	t.Helper()
+
+	d := &Daemon{Key: DefaultKey, failures: make(map[string]failure), transcripts: make(map[string]map[string]any)}
+	d.version = &api.VersionInfo{
+		Version:    "test",
+		APIVersion: 1,
+		Features:   []string{api.FeatureAudio, api.FeaturePrune, api.FeatureInteresting, api.FeatureActivity, api.FeatureMerge, api.FeatureAsk, api.FeatureArchive, api.FeatureFeedback, api.FeatureSourceRules, api.FeatureSourceRetention, api.FeatureEntriesOffset, api.FeatureCategories},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/version", d.getVersion)
+	mux.HandleFunc("GET /api/sources", d.listSources)
+	mux.HandleFunc("GET /api/sources/activity", d.sourceActivity)
+	mux.HandleFunc("POST /api/sources", d.addSource)
+	mux.HandleFunc("PATCH /api/sources/{id}", d.updateSource)
+	mux.HandleFunc("DELETE /api/sources/{id}", d.deleteSource)
+	mux.HandleFunc("POST /api/sources/{id}/merge", d.mergeSources)
+	mux.HandleFunc("PATCH /api/sources/{id}/pause", d.setActive(false))
+	mux.HandleFunc("PATCH /api/sources/{id}/resume", d.setActive(true))
+	mux.HandleFunc("GET /api/entries", d.listEntries)
+	mux.HandleFunc("GET /api/entries/{id}", d.getEntry)
+	mux.HandleFunc("PATCH /api/entries/{id}", d.updateEntry)
+	mux.HandleFunc("PATCH /api/entries", d.updateEntries)
+	mux.HandleFunc("GET /api/prune/count", d.prune(false))
+	mux.HandleFunc("POST /api/prune", d.prune(true))
+	mux.HandleFunc("POST /api/audio/briefings", d.audioBriefing)
+	mux.HandleFunc("GET /api/audio/briefings/transcript", d.briefingTranscript)
+
+	d.Server = httptest.NewServer(d.middleware(mux))
+	t.Cleanup(d.Close)
+	return d
+}
+
+// Client returns an APIClient pointed at the fake daemon
+func (d *Daemon) Client() *api.APIClient {
+	return api.NewClientWithKey(d.URL, d.Key)
+}
+
+// Install writes a config.toml under a temp XDG_CONFIG_HOME whose [remote]
+// section points at the fake daemon, so code calling api.NewClient() (the
+// operations package) talks to it. The environment is restored after the test.
+func (d *Daemon) Install(t testing.TB) {
+	t.Helper()
+
+	dir := t.TempDir()
+	configDir := filepath.Join(dir, "prismis")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("apitest: %v", err)
+	}
+	config := fmt.Sprintf("[api]\nkey = %q\n\n[remote]\nurl = %q\nkey = %q\n", d.Key, d.URL, d.Key)
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(config), 0o644); err != nil {
+		t.Fatalf("apitest: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", dir)
+}
+
+// SetVersion changes the version handshake answer; nil makes the daemon
+// answer 404 like one from before the handshake existed
+func (d *Daemon) SetVersion(info *api.VersionInfo) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.version = info
+}
+
+// Fail makes every request matching "METHOD /path" (e.g. "POST /api/sources")
+// return status with message until Recover is called
+func (d *Daemon) Fail(route string, status int, message string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failures[route] = failure{status: status, message: message}
+}
+
+// Recover clears all failures set with Fail
+func (d *Daemon) Recover() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failures = make(map[string]failure)
+}
+
+// Requests returns the "METHOD /path" of every request received so far
+func (d *Daemon) Requests() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.requests...)
+}
+
+// AddSource seeds a source, assigning an ID if empty, and returns a copy
+func (d *Daemon) AddSource(s Source) Source {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if s.ID == "" {
+		s.ID = d.newID("src")
+	}
+	stored := s
+	d.sources = append(d.sources, &stored)
+	return stored
+}
+
+// AddEntry seeds an entry, assigning an ID and fetch time if empty, and returns a copy
+func (d *Daemon) AddEntry(e Entry) Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if e.ID == "" {
+		e.ID = d.newID("entry")
+	}
+	if e.FetchedAt.IsZero() {
+		e.FetchedAt = time.Now().UTC()
+	}
+	stored := e
+	d.entries = append(d.entries, &stored)
+	return stored
+}
+
+// Sources returns a snapshot of the stored sources
+func (d *Daemon) Sources() []Source {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Source, len(d.sources))
+	for i, s := range d.sources {
+		out[i] = *s
+	}
+	return out
+}
+
+// Entries returns a snapshot of the stored entries
+func (d *Daemon) Entries() []Entry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Entry, len(d.entries))
+	for i, e := range d.entries {
+		out[i] = *e
+	}
+	return out
+}
+
+// newID returns a unique ID; callers hold d.mu
+func (d *Daemon) newID(prefix string) string {
+	d.nextID++
+	return fmt.Sprintf("%s-%d", prefix, d.nextID)
+}
+
+// middleware records requests, enforces the API key, and applies injected failures
+func (d *Daemon) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+
+		d.mu.Lock()
+		d.requests = append(d.requests, route)
+		fail, failing := d.failures[route]
+		d.mu.Unlock()
+
+		if r.Header.Get("X-API-Key") != d.Key {
+			writeJSON(w, http.StatusForbidden, false, "Invalid API key", nil)
+			return
+		}
+		if failing {
+			writeJSON(w, fail.status, false, fail.message, nil)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes the daemon's {success, message, data} envelope
+func writeJSON(w http.ResponseWriter, status int, success bool, message string, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"success": success,
+		"message": message,
+		"data":    data,
+	})
+}
+
+func (s *Source) wire() map[string]any {
+	m := map[string]any{
+		"id":          s.ID,
+		"url":         s.URL,
+		"type":        s.Type,
+		"active":      s.Active,
+		"error_count": s.ErrorCount,
+	}
+	if s.Name != "" {
+		m["name"] = s.Name
+	}
+	if s.Category != "" {
+		m["category"] = s.Category
+	}
+	if s.LastError != "" {
+		m["last_error"] = s.LastError
+	}
+	if s.PriorityFloor != "" {
+		m["priority_floor"] = s.PriorityFloor
+	}
+	if s.PriorityCeiling != "" {
+		m["priority_ceiling"] = s.PriorityCeiling
+	}
+	return m
+}
+
+func (e *Entry) wire() map[string]any {
+	m := map[string]any{
+		"id":                   e.ID,
+		"external_id":          e.URL,
+		"source_id":            e.SourceID,
+		"title":                e.Title,
+		"url":                  e.URL,
+		"content":              e.Content,
+		"summary":              e.Summary,
+		"published_at":         e.PublishedAt.UTC().Format(time.RFC3339),
+		"fetched_at":           e.FetchedAt.UTC().Format(time.RFC3339),
+		"read":                 e.Read,
+		"favorited":            e.Favorited,
+		"interesting_override": e.Interesting,
+		"user_feedback":        e.UserFeedback,
+		"archived_at":          nil,
+		"snoozed_until":        nil,
+		"pinned":               e.Pinned,
+		"author":               nil,
+		"priority":             nil,
+	}
+	if !e.SnoozedUntil.IsZero() {
+		m["snoozed_until"] = e.SnoozedUntil.UTC().Format(time.RFC3339)
+	}
+	if e.Author != "" {
+		m["author"] = e.Author
+	}
+	if e.Archived {
+		m["archived_at"] = e.FetchedAt.UTC().Format(time.RFC3339)
+	}
+	if e.Priority != "" {
+		m["priority"] = e.Priority
+	}
+	if e.Analysis != nil {
+		m["analysis"] = e.Analysis
+	}
+	return m
+}
+
+// findSource returns the source with id; callers hold d.mu
+func (d *Daemon) findSource(id string) (int, *Source) {
+	for i, s := range d.sources {
+		if s.ID == id {
+			return i, s
+		}
+	}
+	return -1, nil
+}
+
+func (d *Daemon) listSources(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	sources := make([]map[string]any, 0, len(d.sources))
+	for _, s := range d.sources {
+		sources = append(sources, s.wire())
+	}
+	writeJSON(w, http.StatusOK, true, "Sources retrieved", map[string]any{"sources": sources, "total": len(sources)})
+}
+
+func (d *Daemon) sourceActivity(w http.ResponseWriter, r *http.Request) {
+	days := 14
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 90 {
+			writeJSON(w, http.StatusUnprocessableEntity, false, "days must be between 1 and 90", nil)
+			return
+		}
+		days = n
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	counts := map[string][]int{}
+	for _, e := range d.entries {
+		local := e.PublishedAt.Local()
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+		index := days - 1 - int(today.Sub(day).Hours()+12)/24
+		if e.SourceID == "" || index < 0 || index >= days {
+			continue
+		}
+		if counts[e.SourceID] == nil {
+			counts[e.SourceID] = make([]int, days)
+		}
+		counts[e.SourceID][index]++
+	}
+	writeJSON(w, http.StatusOK, true, "Source activity retrieved", map[string]any{"days": days, "counts": counts})
+}
+
+func (d *Daemon) addSource(w http.ResponseWriter, r *http.Request) {
+	var req api.SourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.URL == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, false, "url is required", nil)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, s := range d.sources {
+		if s.URL == req.URL {
+			writeJSON(w, http.StatusConflict, false, "Source already exists", nil)
+			return
+		}
+	}
+
+	s := &Source{ID: d.newID("src"), URL: req.URL, Type: req.Type, Active: true}
+	if req.Name != nil {
+		s.Name = *req.Name
+	}
+	if req.Category != nil {
+		s.Category = *req.Category
+	}
+	d.sources = append(d.sources, s)
+	writeJSON(w, http.StatusOK, true, "Source added", s.wire())
+}
+
+func (d *Daemon) updateSource(w http.ResponseWriter, r *http.Request) {
+	var req api.SourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, false, "invalid request body", nil)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, s := d.findSource(r.PathValue("id"))
+	if s == nil {
+		writeJSON(w, http.StatusNotFound, false, "Source not found", nil)
+		return
+	}
+	if req.URL != "" {
+		s.URL = req.URL
+	}
+	if req.Name != nil {
+		s.Name = *req.Name
+	}
+	if req.Category != nil {
+		s.Category = *req.Category
+	}
+	if req.PriorityFloor != nil {
+		s.PriorityFloor = *req.PriorityFloor
+	}
+	if req.PriorityCeiling != nil {
+		s.PriorityCeiling = *req.PriorityCeiling
+	}
+	writeJSON(w, http.StatusOK, true, "Source updated", s.wire())
+}
+
+func (d *Daemon) deleteSource(w http.ResponseWriter, r *http.Request) {
+	retention := r.URL.Query().Get("content")
+	if retention == "" {
+		retention = api.RetentionDelete
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, s := d.findSource(r.PathValue("id"))
+	if s == nil {
+		writeJSON(w, http.StatusNotFound, false, "Source not found", nil)
+		return
+	}
+	d.sources = append(d.sources[:i], d.sources[i+1:]...)
+
+	kept := d.entries[:0]
+	for _, e := range d.entries {
+		if e.SourceID != s.ID {
+			kept = append(kept, e)
+			continue
+		}
+		switch {
+		case retention == api.RetentionDelete && !e.Favorited:
+			continue
+		case retention == api.RetentionArchive:
+			e.Archived = true
+		}
+		e.SourceID = ""
+		kept = append(kept, e)
+	}
+	d.entries = kept
+
+	writeJSON(w, http.StatusOK, true, "Source deleted", map[string]any{"id": s.ID})
+}
+
+func (d *Daemon) mergeSources(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SourceIDs []string `json:"source_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.SourceIDs) == 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, false, "invalid request body", nil)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	keep := r.PathValue("id")
+	for _, id := range append([]string{keep}, req.SourceIDs...) {
+		if _, s := d.findSource(id); s == nil {
+			writeJSON(w, http.StatusNotFound, false, "Source not found", nil)
+			return
+		}
+	}
+	merged := map[string]bool{}
+	for _, id := range req.SourceIDs {
+		merged[id] = true
+	}
+	moved := 0
+	for _, e := range d.entries {
+		if merged[e.SourceID] {
+			e.SourceID = keep
+			moved++
+		}
+	}
+	kept := d.sources[:0]
+	for _, s := range d.sources {
+		if !merged[s.ID] {
+			kept = append(kept, s)
+		}
+	}
+	d.sources = kept
+
+	writeJSON(w, http.StatusOK, true, "Sources merged", map[string]any{"id": keep, "merged": req.SourceIDs, "moved": moved})
+}
+
+func (d *Daemon) setActive(active bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		_, s := d.findSource(r.PathValue("id"))
+		if s == nil {
+			writeJSON(w, http.StatusNotFound, false, "Source not found", nil)
+			return
+		}
+		s.Active = active
+		writeJSON(w, http.StatusOK, true, "Source updated", s.wire())
+	}
+}
+
+func (d *Daemon) listEntries(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if v := r.URL.Query().Get("since"); v != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, false, "invalid since timestamp", nil)
+			return
+		}
+		since = parsed
+	}
+
+	// Archived entries only come back when asked for, as the daemon does it:
+	// archived=no|yes|only, or the older include_archived=true
+	archived := r.URL.Query().Get("archived")
+	if archived == "" && r.URL.Query().Get("include_archived") == "true" {
+		archived = "yes"
+	}
+	excludeContent := r.URL.Query().Get("exclude_content") == "true"
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	matched := make([]*Entry, 0, len(d.entries))
+	for _, e := range d.entries {
+		if !since.IsZero() && !e.FetchedAt.After(since) {
+			continue
+		}
+		if (e.Archived && archived != "yes" && archived != "only") || (!e.Archived && archived == "only") {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	// Newest first, as the daemon returns them
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].PublishedAt.After(matched[j].PublishedAt) })
+
+	// total counts every match; limit/offset select the page. Daemons
+	// without FeatureEntriesOffset ignore offset and count only what they send.
+	paging := d.version != nil && d.version.Supports(api.FeatureEntriesOffset)
+	total := len(matched)
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v > 0 && paging {
+		matched = matched[min(v, len(matched)):]
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v < len(matched) {
+		matched = matched[:v]
+	}
+	if !paging {
+		total = len(matched)
+	}
+	items := make([]map[string]any, 0, len(matched))
+	for _, e := range matched {
+		item := e.wire()
+		if excludeContent {
+			delete(item, "content")
+			item["content_length"] = utf8.RuneCountInString(e.Content)
+		}
+		items = append(items, item)
+	}
+	writeJSON(w, http.StatusOK, true, "Entries retrieved", map[string]any{"items": items, "total": total, "filters_applied": map[string]any{}})
+}
+
+func (d *Daemon) getEntry(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.entries {
+		if e.ID == r.PathValue("id") {
+			item := e.wire()
+			if r.URL.Query().Get("include") != "content" {
+				delete(item, "content")
+			}
+			writeJSON(w, http.StatusOK, true, "Entry retrieved successfully", item)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, false, "Entry not found", nil)
+}
+
+func (d *Daemon) updateEntry(w http.ResponseWriter, r *http.Request) {
+	var req api.ContentUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, false, "invalid request body", nil)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.entries {
+		if e.ID != r.PathValue("id") {
+			continue
+		}
+		if req.Read != nil {
+			e.Read = *req.Read
+		}
+		if req.Favorited != nil {
+			e.Favorited = *req.Favorited
+		}
+		if req.InterestingOverride != nil {
+			e.Interesting = *req.InterestingOverride
+		}
+		if req.UserFeedback != nil {
+			e.UserFeedback = *req.UserFeedback
+		}
+		if req.SnoozedUntil != nil {
+			e.SnoozedUntil = *req.SnoozedUntil
+		}
+		if req.Pinned != nil {
+			e.Pinned = *req.Pinned
+		}
+		writeJSON(w, http.StatusOK, true, "Content updated", e.wire())
+		return
+	}
+	writeJSON(w, http.StatusNotFound, false, "Content not found", nil)
+}
+
+// updateEntries sets read status on every listed entry that exists
+func (d *Daemon) updateEntries(w http.ResponseWriter, r *http.Request) {
+	var req api.ContentBatchReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.IDs) == 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, false, "invalid request body", nil)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	wanted := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		wanted[id] = true
+	}
+	updated := 0
+	for _, e := range d.entries {
+		if wanted[e.ID] {
+			e.Read = req.Read
+			updated++
+		}
+	}
+	writeJSON(w, http.StatusOK, true, "Content updated", map[string]any{"updated": updated, "read": req.Read})
+}
+
+func (d *Daemon) getVersion(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	info := d.version
+	d.mu.Unlock()
+	if info == nil {
+		writeJSON(w, http.StatusNotFound, false, "Not Found", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, true, "Version retrieved", info)
+}
+
+// prune counts (or deletes) unprioritized, unfavorited entries, optionally
+// only those older than ?days=
+func (d *Daemon) prune(execute bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var days *int
+		if v := r.URL.Query().Get("days"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				writeJSON(w, http.StatusUnprocessableEntity, false, "days must be an integer", nil)
+				return
+			}
+			days = &n
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		count := 0
+		kept := d.entries[:0]
+		for _, e := range d.entries {
+			prunable := e.Priority == "" && !e.Favorited &&
+				(days == nil || time.Since(e.FetchedAt) > time.Duration(*days)*24*time.Hour)
+			if prunable {
+				count++
+				if execute {
+					continue
+				}
+			}
+			kept = append(kept, e)
+		}
+		d.entries = kept
+
+		if execute {
+			writeJSON(w, http.StatusOK, true, "Pruned", map[string]any{"deleted": count, "days_filter": days})
+			return
+		}
+		writeJSON(w, http.StatusOK, true, "Count retrieved", map[string]any{"count": count, "days_filter": days})
+	}
+}
+
+func (d *Daemon) audioBriefing(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// The body is optional, like the real daemon's
+	var req struct {
+		MinPriority string `json:"min_priority"`
+		MaxItems    int    `json:"max_items"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	rank := map[string]int{"high": 0, "medium": 1, "low": 2}
+	floor := rank[req.MinPriority] // Missing means high
+
+	high := 0
+	var items []map[string]any
+	for _, e := range d.entries {
+		if e.Priority == "high" {
+			high++
+		}
+		if r, ok := rank[e.Priority]; ok && r <= floor {
+			sourceName := ""
+			if _, s := d.findSource(e.SourceID); s != nil {
+				sourceName = s.Name
+			}
+			items = append(items, map[string]any{
+				"title":       e.Title,
+				"url":         e.URL,
+				"source_name": sourceName,
+				"priority":    e.Priority,
+			})
+		}
+	}
+	if req.MaxItems > 0 && len(items) > req.MaxItems {
+		items = items[:req.MaxItems]
+	}
+	if len(items) == 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, false, "No HIGH priority content available for briefing", nil)
+		return
+	}
+
+	now := time.Now().UTC()
+	filename := "briefing-" + now.Format("2006-01-02-150405") + ".mp3"
+	d.transcripts[filename] = map[string]any{
+		"filename":     filename,
+		"generated_at": now.Format(time.RFC3339),
+		"script":       fmt.Sprintf("Briefing covering %d items.", len(items)),
+		"items":        items,
+	}
+	d.latest = filename
+	writeJSON(w, http.StatusOK, true, "Briefing generated", map[string]any{
+		"file_path":           filepath.Join(os.TempDir(), filename),
+		"filename":            filename,
+		"duration_estimate":   fmt.Sprintf("%d min", high),
+		"generated_at":        now.Format(time.RFC3339),
+		"provider":            "apitest",
+		"high_priority_count": high,
+		"item_count":          len(items),
+	})
+}
+
+func (d *Daemon) briefingTranscript(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	filename := r.URL.Query().Get("filename")
+	if filename == "" {
+		filename = d.latest
+	}
+	transcript, ok := d.transcripts[filename]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, false, "Transcript not found", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, true, "Transcript for "+filename, transcript)
+}
@@ -1,12 +1,13 @@
 package api
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"sync"
 	"time"
 
@@ -38,13 +39,23 @@ type APIClient struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+	isRemote   bool         // True when talking to a daemon other than localhost
+	middleware []Middleware // Extra request middleware, see Use
+
+	slowOnce sync.Once
+	slow     http.RoundTripper // Transport without a time-to-first-byte limit, see client
 }
 
 // SourceRequest represents a request to add a source
 type SourceRequest struct {
-	URL  string  `json:"url"`
-	Type string  `json:"type,omitempty"`
-	Name *string `json:"name,omitempty"`
+	URL      string  `json:"url"`
+	Type     string  `json:"type,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Category *string `json:"category,omitempty"` // Empty string clears the category
+	// Priority rule, with FeatureSourceRules: "high", "medium", or "low";
+	// an empty string clears it
+	PriorityFloor   *string `json:"priority_floor,omitempty"`
+	PriorityCeiling *string `json:"priority_ceiling,omitempty"`
 }
 
 // APIResponse represents the standard API response format
@@ -64,6 +75,10 @@ type Source struct {
 	LastFetched *time.Time `json:"last_fetched,omitempty"`
 	ErrorCount  int        `json:"error_count"`
 	LastError   *string    `json:"last_error,omitempty"`
+	Category    *string    `json:"category,omitempty"`
+	// The lowest and highest priority the daemon gives this source's items
+	PriorityFloor   *string `json:"priority_floor,omitempty"`
+	PriorityCeiling *string `json:"priority_ceiling,omitempty"`
 }
 
 // SourceListResponse represents the response from GET /api/sources
@@ -80,6 +95,7 @@ type ContentItem struct {
 	Title               string          `json:"title"`
 	URL                 string          `json:"url"`
 	Content             string          `json:"content"`
+	ContentLength       *int            `json:"content_length"` // Sent instead of content by FetchEntryListPaged
 	Summary             string          `json:"summary"`
 	PublishedAt         apiTime         `json:"published_at"`
 	FetchedAt           apiTime         `json:"fetched_at"`
@@ -87,6 +103,10 @@ type ContentItem struct {
 	Favorited           bool            `json:"favorited"`
 	InterestingOverride bool            `json:"interesting_override"`
 	UserFeedback        string          `json:"user_feedback"`
+	UserTags            []string        `json:"user_tags"`
+	SnoozedUntil        *apiTime        `json:"snoozed_until"`
+	Pinned              bool            `json:"pinned"`
+	Author              string          `json:"author"`
 	ArchivedAt          *apiTime        `json:"archived_at"`
 	Priority            *string         `json:"priority"`
 	Analysis            json.RawMessage `json:"analysis"` // JSON object from API
@@ -192,536 +212,410 @@ func NewClientWithURL(baseURL string) (*APIClient, error) {
 		baseURL:    baseURL,
 		apiKey:     apiKey,
 		httpClient: &http.Client{Transport: transport}, // No total timeout - body can take as long as needed
+		isRemote:   isRemote,
 	}, nil
 }
 
-// AddSource adds a new content source via the API
-func (c *APIClient) AddSource(request SourceRequest) (*APIResponse, error) {
-	// Marshal request to JSON
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("POST", c.baseURL+"/api/sources", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+// NewClientWithKey creates a client for baseURL without reading config.toml.
+// Used by tests (see apitest) and tools that already hold credentials.
+func NewClientWithKey(baseURL, apiKey string) *APIClient {
+	return &APIClient{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+		isRemote:   true,
 	}
-	defer resp.Body.Close()
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+// doAPIResponse runs r and returns the raw envelope for endpoints whose
+// callers read ad-hoc fields out of Data
+func (c *APIClient) doAPIResponse(ctx context.Context, r apiRequest) (*APIResponse, error) {
+	env, err := doRequest[map[string]interface{}](ctx, c, r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
+	return &APIResponse{Success: env.Success, Message: env.Message, Data: env.Data}, nil
+}
 
-	// Parse response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// AddSource adds a new content source via the API
+func (c *APIClient) AddSource(ctx context.Context, request SourceRequest) (*APIResponse, error) {
+	return c.doAPIResponse(ctx, apiRequest{method: "POST", path: "/api/sources", body: request, feature: request.feature()})
+}
 
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
+// feature is the daemon feature the request depends on: older daemons
+// would drop a category or priority rule without saying so. Daemons with
+// priority rules take categories too.
+func (r SourceRequest) feature() string {
+	if r.PriorityFloor != nil || r.PriorityCeiling != nil {
+		return FeatureSourceRules
 	}
-	if resp.StatusCode == 422 {
-		return &apiResp, fmt.Errorf("validation error: %s", apiResp.Message)
-	}
-	if resp.StatusCode >= 400 {
-		return &apiResp, fmt.Errorf("API error: %s", apiResp.Message)
+	if r.Category != nil {
+		return FeatureCategories
 	}
-
-	return &apiResp, nil
+	return ""
 }
 
-// DeleteSource removes a content source via the API
-func (c *APIClient) DeleteSource(sourceID string) (*APIResponse, error) {
-	// Create HTTP request
-	req, err := http.NewRequest("DELETE", c.baseURL+"/api/sources/"+sourceID, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
+// IsRemote reports whether the client targets a remote daemon rather than localhost
+func (c *APIClient) IsRemote() bool {
+	return c.isRemote
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+// Content retention choices when deleting a source
+const (
+	RetentionDelete  = "delete"  // Delete the source's items (favorites are still preserved)
+	RetentionKeep    = "keep"    // Keep items, detached from the deleted source
+	RetentionArchive = "archive" // Keep items detached and archived
+)
 
-	// Parse response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// DeleteSource removes a content source via the API using the daemon's default retention
+func (c *APIClient) DeleteSource(ctx context.Context, sourceID string) (*APIResponse, error) {
+	return c.DeleteSourceWithRetention(ctx, sourceID, "")
+}
 
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode == 404 {
-		return &apiResp, fmt.Errorf("source not found")
+// DeleteSourceWithRetention removes a content source and handles its items per retention
+// (RetentionDelete, RetentionKeep, RetentionArchive; empty uses the daemon default)
+func (c *APIClient) DeleteSourceWithRetention(ctx context.Context, sourceID string, retention string) (*APIResponse, error) {
+	req := apiRequest{method: "DELETE", path: "/api/sources/" + sourceID, notFound: "source"}
+	if retention != "" {
+		req.query = url.Values{"content": {retention}}
 	}
-	if resp.StatusCode >= 400 {
-		return &apiResp, fmt.Errorf("API error: %s", apiResp.Message)
+	if retention == RetentionKeep || retention == RetentionArchive {
+		// An older daemon would ignore the choice and delete the items
+		req.feature = FeatureSourceRetention
 	}
-
-	return &apiResp, nil
+	return c.doAPIResponse(ctx, req)
 }
 
 // UpdateSource updates a content source via the API
-func (c *APIClient) UpdateSource(sourceID string, request SourceRequest) (*APIResponse, error) {
-	// Marshal request to JSON
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("PATCH", c.baseURL+"/api/sources/"+sourceID, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
+func (c *APIClient) UpdateSource(ctx context.Context, sourceID string, request SourceRequest) (*APIResponse, error) {
+	return c.doAPIResponse(ctx, apiRequest{method: "PATCH", path: "/api/sources/" + sourceID, body: request, feature: request.feature(), notFound: "source"})
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+// MergeSources folds the sources in sourceIDs into keepID: their items move
+// to it and they are deleted. Returns how many items moved.
+func (c *APIClient) MergeSources(ctx context.Context, keepID string, sourceIDs []string) (int, error) {
+	env, err := doRequest[struct {
+		Moved int `json:"moved"`
+	}](ctx, c, apiRequest{
+		method:   "POST",
+		path:     "/api/sources/" + keepID + "/merge",
+		body:     map[string][]string{"source_ids": sourceIDs},
+		feature:  FeatureMerge,
+		notFound: "source",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return 0, err
 	}
-
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode == 404 {
-		return &apiResp, fmt.Errorf("source not found")
-	}
-	if resp.StatusCode >= 400 {
-		return &apiResp, fmt.Errorf("validation error: %s", apiResp.Message)
-	}
-
-	return &apiResp, nil
+	return env.Data.Moved, nil
 }
 
 // PauseSource pauses a content source (sets inactive)
-func (c *APIClient) PauseSource(sourceID string) (*APIResponse, error) {
-	// Create HTTP request
-	req, err := http.NewRequest("PATCH", c.baseURL+"/api/sources/"+sourceID+"/pause", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Check for API-level errors
-	if !apiResp.Success {
-		return &apiResp, fmt.Errorf("%s", apiResp.Message)
-	}
-
-	return &apiResp, nil
+func (c *APIClient) PauseSource(ctx context.Context, sourceID string) (*APIResponse, error) {
+	return c.doAPIResponse(ctx, apiRequest{method: "PATCH", path: "/api/sources/" + sourceID + "/pause", notFound: "source"})
 }
 
 // ResumeSource resumes a paused content source (sets active)
-func (c *APIClient) ResumeSource(sourceID string) (*APIResponse, error) {
-	// Create HTTP request
-	req, err := http.NewRequest("PATCH", c.baseURL+"/api/sources/"+sourceID+"/resume", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Check for API-level errors
-	if !apiResp.Success {
-		return &apiResp, fmt.Errorf("%s", apiResp.Message)
-	}
-
-	return &apiResp, nil
+func (c *APIClient) ResumeSource(ctx context.Context, sourceID string) (*APIResponse, error) {
+	return c.doAPIResponse(ctx, apiRequest{method: "PATCH", path: "/api/sources/" + sourceID + "/resume", notFound: "source"})
 }
 
 // GetSources retrieves all content sources from the API
-func (c *APIClient) GetSources() (*SourceListResponse, error) {
-	// Create HTTP request
-	req, err := http.NewRequest("GET", c.baseURL+"/api/sources", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+func (c *APIClient) GetSources(ctx context.Context) (*SourceListResponse, error) {
+	// Decode entries one at a time so a single malformed source doesn't hide the rest
+	env, err := doRequest[struct {
+		Sources []json.RawMessage `json:"sources"`
+		Total   int               `json:"total"`
+	}](ctx, c, apiRequest{method: "GET", path: "/api/sources"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode >= 400 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("API error: %s", apiResp.Message)
+	sourceList := SourceListResponse{Sources: make([]Source, 0, len(env.Data.Sources)), Total: env.Data.Total}
+	for _, raw := range env.Data.Sources {
+		var source Source
+		if err := json.Unmarshal(raw, &source); err != nil {
+			continue
 		}
-		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
-	}
-
-	// Parse the wrapped response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w (body: %s)", err, string(body))
-	}
-
-	// Check if operation was successful
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Message)
+		sourceList.Sources = append(sourceList.Sources, source)
 	}
 
-	// Extract the sources from the data field
-	var sourceList SourceListResponse
-	if data, ok := apiResp.Data["sources"].([]interface{}); ok {
-		// Convert []interface{} to []Source
-		sources := make([]Source, 0, len(data))
-		for _, item := range data {
-			// Marshal and unmarshal to convert map to Source struct
-			jsonBytes, err := json.Marshal(item)
-			if err != nil {
-				continue
-			}
-			var source Source
-			if err := json.Unmarshal(jsonBytes, &source); err != nil {
-				continue
-			}
-			sources = append(sources, source)
-		}
-		sourceList.Sources = sources
-	}
+	return &sourceList, nil
+}
 
-	// Extract total count
-	if total, ok := apiResp.Data["total"].(float64); ok {
-		sourceList.Total = int(total)
+// GetSourceDailyCounts retrieves each source's item count per day over the
+// last days days, oldest day first. Sources with no items are left out.
+func (c *APIClient) GetSourceDailyCounts(ctx context.Context, days int) (map[string][]int, error) {
+	env, err := doRequest[struct {
+		Counts map[string][]int `json:"counts"`
+	}](ctx, c, apiRequest{
+		method:  "GET",
+		path:    "/api/sources/activity",
+		query:   url.Values{"days": {strconv.Itoa(days)}},
+		feature: FeatureActivity,
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return &sourceList, nil
+	return env.Data.Counts, nil
 }
 
 // ContentUpdateRequest represents a request to update content properties
 type ContentUpdateRequest struct {
-	Read                *bool   `json:"read,omitempty"`
-	Favorited           *bool   `json:"favorited,omitempty"`
-	InterestingOverride *bool   `json:"interesting_override,omitempty"`
-	UserFeedback        *string `json:"user_feedback,omitempty"`
+	Read                *bool      `json:"read,omitempty"`
+	Favorited           *bool      `json:"favorited,omitempty"`
+	InterestingOverride *bool      `json:"interesting_override,omitempty"`
+	UserFeedback        *string    `json:"user_feedback,omitempty"`
+	UserTags            *[]string  `json:"user_tags,omitempty"` // Replaces all tags; empty slice clears
+	SnoozedUntil        *time.Time `json:"snoozed_until,omitempty"`
+	Pinned              *bool      `json:"pinned,omitempty"`
 }
 
 // UpdateContent updates content properties (read/favorited status)
-func (c *APIClient) UpdateContent(contentID string, request ContentUpdateRequest) (*APIResponse, error) {
-	// Marshal request to JSON
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("PATCH", c.baseURL+"/api/entries/"+contentID, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+func (c *APIClient) UpdateContent(ctx context.Context, contentID string, request ContentUpdateRequest) (*APIResponse, error) {
+	r := apiRequest{method: "PATCH", path: "/api/entries/" + contentID, body: request, notFound: "content"}
+	if request.InterestingOverride != nil {
+		r.feature = FeatureInteresting
 	}
+	return c.doAPIResponse(ctx, r)
+}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
+// ContentBatchReadRequest sets read status on many items at once
+type ContentBatchReadRequest struct {
+	IDs  []string `json:"ids"`
+	Read bool     `json:"read"`
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+// SetReadBatch marks many items read or unread in one request, returning how
+// many the daemon updated (unknown IDs are skipped)
+func (c *APIClient) SetReadBatch(ctx context.Context, ids []string, read bool) (int, error) {
+	env, err := doRequest[struct {
+		Updated int `json:"updated"`
+	}](ctx, c, apiRequest{method: "PATCH", path: "/api/entries", body: ContentBatchReadRequest{IDs: ids, Read: read}})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode == 404 {
-		return &apiResp, fmt.Errorf("content not found")
-	}
-	if resp.StatusCode == 422 {
-		return &apiResp, fmt.Errorf("validation error: %s", apiResp.Message)
-	}
-	if resp.StatusCode >= 400 {
-		return &apiResp, fmt.Errorf("API error: %s", apiResp.Message)
+		return 0, err
 	}
-
-	return &apiResp, nil
+	return env.Data.Updated, nil
 }
 
 // FetchEntries retrieves all content items from the API
-func (c *APIClient) FetchEntries() ([]ContentItem, error) {
-	return c.fetchEntriesWithParams("limit=10000")
+func (c *APIClient) FetchEntries(ctx context.Context) ([]ContentItem, error) {
+	return c.fetchEntriesWithParams(ctx, url.Values{"limit": {"10000"}})
 }
 
 // FetchEntriesSince retrieves content items created/modified after the given timestamp
-func (c *APIClient) FetchEntriesSince(since time.Time) ([]ContentItem, error) {
+func (c *APIClient) FetchEntriesSince(ctx context.Context, since time.Time) ([]ContentItem, error) {
 	// Format timestamp as ISO8601 with nanosecond precision
 	// RFC3339Nano preserves microseconds to prevent re-fetching same items
-	sinceParam := since.Format(time.RFC3339Nano)
-	return c.fetchEntriesWithParams("limit=10000&since=" + sinceParam)
+	return c.fetchEntriesWithParams(ctx, url.Values{"limit": {"10000"}, "since": {since.Format(time.RFC3339Nano)}})
 }
 
 // fetchEntriesWithParams is the common implementation for fetching entries
-func (c *APIClient) fetchEntriesWithParams(params string) ([]ContentItem, error) {
-	// Build URL with optional parameters
-	url := c.baseURL + "/api/entries"
-	if params != "" {
-		url += "?" + params
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("GET", url, nil)
+func (c *APIClient) fetchEntriesWithParams(ctx context.Context, params url.Values) ([]ContentItem, error) {
+	env, err := doRequest[EntriesResponse](ctx, c, apiRequest{method: "GET", path: "/api/entries", query: params})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	return env.Data.Items, nil
+}
 
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
+// SyncProgress reports how far a paged entries fetch has got
+type SyncProgress struct {
+	Page  int   // Pages fetched so far
+	Pages int   // Expected page count, 0 until the daemon reports a total
+	Items int   // Items received so far
+	Bytes int64 // Response bytes received so far
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	// Received holds the items so far, so a first sync can show them while
+	// the rest download. Valid only during the callback.
+	Received []ContentItem
+}
 
-	// Check for HTTP errors
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-	}
+// entriesPageSize is the page size for FetchEntriesPaged
+const entriesPageSize = 500
 
-	// Parse response - API returns {success, message, data: {items: [...], total: N}}
-	var apiResp struct {
-		Success bool            `json:"success"`
-		Message string          `json:"message"`
-		Data    EntriesResponse `json:"data"`
-	}
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// entriesLimitMax is the daemon's largest limit, used for the single request
+// daemons without FeatureEntriesOffset get
+const entriesLimitMax = 10000
 
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Message)
+// FetchEntriesPaged retrieves entries (all of them, or those changed after since
+// when non-zero) one page at a time, calling progress after each page.
+// Stops at a short page, or when a page repeats. Daemons that can't page get
+// one request for everything.
+func (c *APIClient) FetchEntriesPaged(ctx context.Context, since time.Time, progress func(SyncProgress)) ([]ContentItem, error) {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339Nano))
 	}
-
-	return apiResp.Data.Items, nil
+	return c.fetchEntries(ctx, query, progress)
 }
 
-// PruneCount gets the count of unprioritized items that would be pruned
-func (c *APIClient) PruneCount(days *int) (int, error) {
-	// Build URL with optional days parameter
-	url := c.baseURL + "/api/prune/count"
-	if days != nil {
-		url = fmt.Sprintf("%s?days=%d", url, *days)
+// FetchEntryListPaged is FetchEntriesPaged without the article text, which
+// is most of each entry's size; GetEntry fetches one item's in full.
+// ContentLength is set instead (daemons before it existed still send content).
+func (c *APIClient) FetchEntryListPaged(ctx context.Context, since time.Time, progress func(SyncProgress)) ([]ContentItem, error) {
+	query := url.Values{"exclude_content": {"true"}}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339Nano))
 	}
+	return c.fetchEntries(ctx, query, progress)
+}
 
-	// Create HTTP request
-	req, err := http.NewRequest("GET", url, nil)
+// GetEntry retrieves one entry with its content
+func (c *APIClient) GetEntry(ctx context.Context, contentID string) (*ContentItem, error) {
+	env, err := doRequest[ContentItem](ctx, c, apiRequest{
+		method:   "GET",
+		path:     "/api/entries/" + contentID,
+		query:    url.Values{"include": {"content"}},
+		notFound: "content",
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	return &env.Data, nil
+}
 
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
+// FetchArchivedEntries retrieves the archived entries, which the sync leaves
+// out, the way FetchEntriesPaged pages through them. Archived items have
+// ArchivedAt set; daemons that predate the archived scope ignore it and send
+// every entry, so callers keep only those.
+func (c *APIClient) FetchArchivedEntries(ctx context.Context, progress func(SyncProgress)) ([]ContentItem, error) {
+	return c.fetchEntries(ctx, url.Values{"archived": {"only"}, "include_archived": {"true"}}, progress)
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
-	}
+// fetchEntries pages through /api/entries with query's filters
+func (c *APIClient) fetchEntries(ctx context.Context, query url.Values, progress func(SyncProgress)) ([]ContentItem, error) {
+	var items []ContentItem
+	var p SyncProgress
+	seen := make(map[string]bool)
 
-	// Parse response
-	var apiResp struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			Count      int  `json:"count"`
-			DaysFilter *int `json:"days_filter"`
-		} `json:"data"`
+	// A daemon that ignores offset would send the first page again and again
+	pageSize := entriesPageSize
+	if info, err := c.Version(ctx); err == nil && !info.Supports(FeatureEntriesOffset) {
+		pageSize = entriesLimitMax
 	}
 
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return 0, fmt.Errorf("failed to parse response: %w", err)
-	}
+	for {
+		params := url.Values{
+			"limit":  {strconv.Itoa(pageSize)},
+			"offset": {strconv.Itoa(len(items))},
+		}
+		for key, values := range query {
+			params[key] = values
+		}
 
-	if !apiResp.Success {
-		return 0, fmt.Errorf("%s", apiResp.Message)
-	}
+		// Items decode one at a time as the page streams in
+		var page []ContentItem
+		total := 0
+		size, err := doDecode(ctx, c, apiRequest{method: "GET", path: "/api/entries", query: params}, func(dec *json.Decoder) error {
+			var err error
+			total, err = decodeEntries(dec, func(item ContentItem) {
+				page = append(page, item)
+			})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	return apiResp.Data.Count, nil
-}
+		if len(page) > 0 && seen[page[0].ID] {
+			break
+		}
+		for _, item := range page {
+			seen[item.ID] = true
+		}
+		items = append(items, page...)
 
-// PruneUnprioritized deletes unprioritized content items
-func (c *APIClient) PruneUnprioritized(days *int) (int, error) {
-	// Build URL with optional days parameter
-	url := c.baseURL + "/api/prune"
-	if days != nil {
-		url = fmt.Sprintf("%s?days=%d", url, *days)
-	}
+		p.Page++
+		p.Items = len(items)
+		p.Bytes += size
+		if total > len(page) {
+			p.Pages = (total + pageSize - 1) / pageSize
+		}
+		if progress != nil {
+			p.Received = items
+			progress(p)
+		}
 
-	// Create HTTP request
-	req, err := http.NewRequest("POST", url, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		if len(page) < pageSize {
+			break
+		}
 	}
 
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
+	return items, nil
+}
 
-	// Send request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("network error: %w", err)
+// decodeEntries walks an EntriesResponse, calling onItem for each item as
+// it is decoded, and returns the total the daemon reported
+func decodeEntries(dec *json.Decoder, onItem func(ContentItem)) (int, error) {
+	token, err := dec.Token()
+	if err != nil || token == nil {
+		return 0, err
 	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+	if token != json.Delim('{') {
+		return 0, fmt.Errorf("expected entries object, got %v", token)
 	}
 
-	// Parse response
-	var apiResp struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			Deleted    int  `json:"deleted"`
-			DaysFilter *int `json:"days_filter"`
-		} `json:"data"`
+	total := 0
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return 0, err
+		}
+		switch key {
+		case "items":
+			if err := expectDelim(dec, '['); err != nil {
+				return 0, err
+			}
+			for dec.More() {
+				var item ContentItem
+				if err := dec.Decode(&item); err != nil {
+					return 0, err
+				}
+				onItem(item)
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return 0, err
+			}
+		case "total":
+			err = dec.Decode(&total)
+		default:
+			err = dec.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			return 0, err
+		}
 	}
+	return total, expectDelim(dec, '}')
+}
 
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return 0, fmt.Errorf("failed to parse response: %w", err)
+// daysQuery builds the optional ?days= filter shared by the prune endpoints
+func daysQuery(days *int) url.Values {
+	if days == nil {
+		return nil
 	}
+	return url.Values{"days": {strconv.Itoa(*days)}}
+}
 
-	if !apiResp.Success {
-		return 0, fmt.Errorf("%s", apiResp.Message)
+// PruneCount gets the count of unprioritized items that would be pruned
+func (c *APIClient) PruneCount(ctx context.Context, days *int) (int, error) {
+	env, err := doRequest[struct {
+		Count int `json:"count"`
+	}](ctx, c, apiRequest{method: "GET", path: "/api/prune/count", query: daysQuery(days), feature: FeaturePrune})
+	if err != nil {
+		return 0, err
 	}
+	return env.Data.Count, nil
+}
 
-	return apiResp.Data.Deleted, nil
+// PruneUnprioritized deletes unprioritized content items
+func (c *APIClient) PruneUnprioritized(ctx context.Context, days *int) (int, error) {
+	env, err := doRequest[struct {
+		Deleted int `json:"deleted"`
+	}](ctx, c, apiRequest{method: "POST", path: "/api/prune", query: daysQuery(days), feature: FeaturePrune})
+	if err != nil {
+		return 0, err
+	}
+	return env.Data.Deleted, nil
 }
 
 // AudioBriefingResponse represents the response from POST /api/audio/briefings
@@ -732,160 +626,181 @@ type AudioBriefingResponse struct {
 	GeneratedAt       string `json:"generated_at"`
 	Provider          string `json:"provider"`
 	HighPriorityCount int    `json:"high_priority_count"`
+	ItemCount         int    `json:"item_count"` // Items the briefing covers
+}
+
+// AudioBriefingOptions narrows or widens a briefing; zero values keep the
+// daemon's defaults (HIGH items from the last 24 hours, configured voice)
+type AudioBriefingOptions struct {
+	MinPriority string `json:"min_priority,omitempty"` // "high", "medium", or "low"
+	Hours       int    `json:"hours,omitempty"`
+	Voice       string `json:"voice,omitempty"`
+	MaxItems    int    `json:"max_items,omitempty"`
 }
 
-// GenerateAudioBriefing generates an audio briefing from HIGH priority content
-func (c *APIClient) GenerateAudioBriefing() (*AudioBriefingResponse, error) {
-	// Create HTTP request - no body needed for POST
-	req, err := http.NewRequest("POST", c.baseURL+"/api/audio/briefings", nil)
+// GenerateAudioBriefing generates an audio briefing from prioritized content
+func (c *APIClient) GenerateAudioBriefing(ctx context.Context, opts AudioBriefingOptions) (*AudioBriefingResponse, error) {
+	env, err := doRequest[AudioBriefingResponse](ctx, c, apiRequest{
+		method:  "POST",
+		path:    "/api/audio/briefings",
+		body:    opts,
+		feature: FeatureAudio,
+		timeout: 60 * time.Second, // Audio generation takes 10-30 seconds
+		fallback: map[int]string{
+			422: "check if prioritized content exists",
+			500: "audio generation failed",
+		},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	return &env.Data, nil
+}
 
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
+// BriefingTranscript is what a briefing said and the items it covered
+type BriefingTranscript struct {
+	Filename    string           `json:"filename"` // The briefing's MP3
+	GeneratedAt string           `json:"generated_at"`
+	Script      string           `json:"script"`
+	Items       []TranscriptItem `json:"items"`
+}
 
-	// Use longer timeout for audio generation (60 seconds)
-	client := &http.Client{Timeout: 60 * time.Second}
+// TranscriptItem is one source item a briefing covered
+type TranscriptItem struct {
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	SourceName string `json:"source_name"`
+	Priority   string `json:"priority"`
+}
 
-	// Send request
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+// GetBriefingTranscript fetches a briefing's script and source items by its
+// audio filename; an empty filename fetches the most recent briefing
+func (c *APIClient) GetBriefingTranscript(ctx context.Context, filename string) (*BriefingTranscript, error) {
+	var query url.Values
+	if filename != "" {
+		query = url.Values{"filename": {filename}}
 	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	env, err := doRequest[BriefingTranscript](ctx, c, apiRequest{
+		method:   "GET",
+		path:     "/api/audio/briefings/transcript",
+		query:    query,
+		feature:  FeatureAudio,
+		notFound: "transcript",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode == 422 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("validation error: %s", apiResp.Message)
-		}
-		return nil, fmt.Errorf("validation error: check if HIGH priority content exists")
-	}
-	if resp.StatusCode == 500 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("server error: %s", apiResp.Message)
-		}
-		return nil, fmt.Errorf("server error: audio generation failed")
-	}
-	if resp.StatusCode >= 400 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("API error: %s", apiResp.Message)
-		}
-		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
+		return nil, err
 	}
+	return &env.Data, nil
+}
 
-	// Parse the wrapped response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// ArticleAudioResponse describes a narrated article's audio file
+type ArticleAudioResponse struct {
+	FilePath string `json:"file_path"` // Path on the daemon's machine
+	Filename string `json:"filename"`
+	Cached   bool   `json:"cached"` // True when an earlier narration was reused
+	Provider string `json:"provider"`
+}
 
-	// Check if operation was successful
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Message)
+// GenerateArticleAudio narrates a single entry with the daemon's TTS provider
+func (c *APIClient) GenerateArticleAudio(ctx context.Context, contentID string) (*ArticleAudioResponse, error) {
+	env, err := doRequest[ArticleAudioResponse](ctx, c, apiRequest{
+		method:   "POST",
+		path:     "/api/entries/" + contentID + "/audio",
+		feature:  FeatureAudio,
+		timeout:  4 * time.Minute, // lspeak allows itself 3 minutes for long articles
+		notFound: "entry",
+		fallback: map[int]string{
+			422: "entry has no content to narrate",
+			500: "audio generation failed",
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &env.Data, nil
+}
 
-	// Extract the audio briefing data from the data field
-	var audioResp AudioBriefingResponse
-	if data, ok := apiResp.Data["file_path"].(string); ok {
-		audioResp.FilePath = data
-	}
-	if data, ok := apiResp.Data["filename"].(string); ok {
-		audioResp.Filename = data
-	}
-	if data, ok := apiResp.Data["duration_estimate"].(string); ok {
-		audioResp.DurationEstimate = data
-	}
-	if data, ok := apiResp.Data["generated_at"].(string); ok {
-		audioResp.GeneratedAt = data
-	}
-	if data, ok := apiResp.Data["provider"].(string); ok {
-		audioResp.Provider = data
+// DownloadAudio fetches a generated audio file by name, for when the daemon
+// runs on another machine
+func (c *APIClient) DownloadAudio(ctx context.Context, filename string) ([]byte, error) {
+	r := apiRequest{
+		method:   "GET",
+		path:     "/api/audio/files/" + url.PathEscape(filename),
+		timeout:  2 * time.Minute,
+		notFound: "audio file",
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	resp, body, err := c.send(ctx, r, c.baseURL+r.path, nil)
+	if err != nil {
+		return nil, err
 	}
-	if data, ok := apiResp.Data["high_priority_count"].(float64); ok {
-		audioResp.HighPriorityCount = int(data)
+	if resp.StatusCode >= 400 {
+		return nil, statusError(r, resp.StatusCode, body)
 	}
-
-	return &audioResp, nil
+	return body, nil
 }
 
 // ExtractEntry triggers on-demand deep extraction for a content entry.
 // Returns the data field from the API response, which contains the
 // deep_extraction object on success (idempotent: repeat calls return cached result).
-func (c *APIClient) ExtractEntry(contentID string) (map[string]interface{}, error) {
-	req, err := http.NewRequest("POST", c.baseURL+"/api/entries/"+contentID+"/extract", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Deep extraction can take 10-30 seconds (LLM call); use a longer timeout.
-	client := &http.Client{Timeout: 60 * time.Second}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("entry not found")
-	}
-	if resp.StatusCode == 503 {
-		// Distinguish 503 sub-codes via data.reason so the user gets an actionable
-		// message. Daemon attaches reason="not_configured" or reason="circuit_open"
-		// (see api_errors.py ServiceUnavailableError). Falls back to the generic
-		// message when the daemon predates this contract or the field is missing.
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			if reason, ok := apiResp.Data["reason"].(string); ok {
-				switch reason {
-				case "not_configured":
-					return nil, fmt.Errorf("deep extraction not configured (set llm.deep_service in config.toml)")
-				case "circuit_open":
-					return nil, fmt.Errorf("deep extraction service unavailable, try again shortly")
-				}
-			}
-		}
-		return nil, fmt.Errorf("deep extraction unavailable (service not configured or circuit open)")
+func (c *APIClient) ExtractEntry(ctx context.Context, contentID string) (map[string]interface{}, error) {
+	env, err := doRequest[map[string]interface{}](ctx, c, apiRequest{
+		method:   "POST",
+		path:     "/api/entries/" + contentID + "/extract",
+		timeout:  60 * time.Second, // Deep extraction can take 10-30 seconds (LLM call)
+		notFound: "entry",
+		onStatus: extractUnavailable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return env.Data, nil
+}
+
+// SummarizeResult is an entry's summary and full analysis after POST /summarize
+type SummarizeResult struct {
+	Summary  string          `json:"summary"`
+	Analysis json.RawMessage `json:"analysis"`
+}
+
+// SummarizeEntry asks the daemon to summarize an entry that has no reading
+// summary yet (idempotent: already-summarized entries return unchanged)
+func (c *APIClient) SummarizeEntry(ctx context.Context, contentID string) (*SummarizeResult, error) {
+	env, err := doRequest[SummarizeResult](ctx, c, apiRequest{
+		method:   "POST",
+		path:     "/api/entries/" + contentID + "/summarize",
+		timeout:  60 * time.Second, // One LLM call over the full content
+		notFound: "entry",
+		fallback: map[int]string{
+			422: "entry has no content to summarize",
+			500: "summarization failed",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &env.Data, nil
+}
+
+// extractUnavailable distinguishes 503 sub-codes via data.reason so the user
+// gets an actionable message. Daemon attaches reason="not_configured" or
+// reason="circuit_open" (see api_errors.py ServiceUnavailableError). Falls back
+// to the generic message when the daemon predates this contract or the field is missing.
+func extractUnavailable(status int, resp *APIResponse) error {
+	if status != 503 {
+		return nil
 	}
-	if resp.StatusCode >= 400 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("API error: %s", apiResp.Message)
+	if reason, ok := resp.Data["reason"].(string); ok {
+		switch reason {
+		case "not_configured":
+			return fmt.Errorf("deep extraction not configured (set llm.deep_service in config.toml)")
+		case "circuit_open":
+			return fmt.Errorf("deep extraction service unavailable, try again shortly")
 		}
-		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
-	}
-
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Message)
 	}
-	return apiResp.Data, nil
+	return fmt.Errorf("deep extraction unavailable (service not configured or circuit open)")
 }
 
 // TopicSuggestion represents a suggested topic for context.md
@@ -904,85 +819,142 @@ type ContextSuggestionsResponse struct {
 }
 
 // GetContextSuggestions analyzes flagged items and suggests topics for context.md
-func (c *APIClient) GetContextSuggestions() (*ContextSuggestionsResponse, error) {
-	// Create HTTP request - no body needed for POST
-	req, err := http.NewRequest("POST", c.baseURL+"/api/context", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+func (c *APIClient) GetContextSuggestions(ctx context.Context) (*ContextSuggestionsResponse, error) {
+	env, err := doRequest[struct {
+		SuggestedTopics *[]TopicSuggestion `json:"suggested_topics"`
+	}](ctx, c, apiRequest{
+		method:  "POST",
+		path:    "/api/context",
+		timeout: 30 * time.Second, // LLM analysis
+		fallback: map[int]string{
+			422: "flag some items first using 'i' key",
+			500: "context analysis failed",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if env.Data.SuggestedTopics == nil {
+		return nil, fmt.Errorf("response missing suggested_topics field")
 	}
+	return &ContextSuggestionsResponse{SuggestedTopics: *env.Data.SuggestedTopics}, nil
+}
 
-	// Set headers
-	req.Header.Set("X-API-Key", c.apiKey)
-
-	// Use longer timeout for LLM analysis (30 seconds)
-	client := &http.Client{Timeout: 30 * time.Second}
+// ContextTopicResult reports what POST /api/context/topics did
+type ContextTopicResult struct {
+	Topic   string `json:"topic"`
+	Section string `json:"section"`
+	Added   bool   `json:"added"` // False when the topic was already listed
+}
 
-	// Send request
-	resp, err := client.Do(req)
+// AddContextTopic appends topic to a section of context.md ("high",
+// "medium", "low", or "not_interested")
+func (c *APIClient) AddContextTopic(ctx context.Context, topic, section string) (*ContextTopicResult, error) {
+	env, err := doRequest[ContextTopicResult](ctx, c, apiRequest{
+		method: "POST",
+		path:   "/api/context/topics",
+		body:   map[string]string{"topic": topic, "section": section},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return &env.Data, nil
+}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+// ContextTopic is one topic for AddContextTopics
+type ContextTopic struct {
+	Topic   string `json:"topic"`
+	Section string `json:"section"`
+}
+
+// AddContextTopics adds several topics to context.md in a single write,
+// reporting for each whether it was added or already listed
+func (c *APIClient) AddContextTopics(ctx context.Context, topics []ContextTopic) ([]ContextTopicResult, error) {
+	env, err := doRequest[struct {
+		Topics []ContextTopicResult `json:"topics"`
+	}](ctx, c, apiRequest{
+		method:  "POST",
+		path:    "/api/context/topics/batch",
+		body:    map[string][]ContextTopic{"topics": topics},
+		feature: FeatureContextBatch,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
+	return env.Data.Topics, nil
+}
 
-	// Check for specific HTTP status codes
-	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("authentication failed: invalid API key")
-	}
-	if resp.StatusCode == 422 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("validation error: %s", apiResp.Message)
-		}
-		return nil, fmt.Errorf("validation error: flag some items first using 'i' key")
-	}
-	if resp.StatusCode == 500 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("server error: %s", apiResp.Message)
-		}
-		return nil, fmt.Errorf("server error: context analysis failed")
-	}
-	if resp.StatusCode >= 400 {
-		var apiResp APIResponse
-		if err := json.Unmarshal(body, &apiResp); err == nil {
-			return nil, fmt.Errorf("API error: %s", apiResp.Message)
-		}
-		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
-	}
+// ReanalyzeResult reports what POST /api/context/reanalyze changed
+type ReanalyzeResult struct {
+	Evaluated   int            `json:"evaluated"`
+	Prioritized int            `json:"prioritized"`
+	ByPriority  map[string]int `json:"by_priority"`
+}
 
-	// Parse the wrapped response
-	var apiResp APIResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+// ReanalyzeUnprioritized re-evaluates unprioritized items from the last days
+// against the current context.md
+func (c *APIClient) ReanalyzeUnprioritized(ctx context.Context, days int) (*ReanalyzeResult, error) {
+	env, err := doRequest[ReanalyzeResult](ctx, c, apiRequest{
+		method:  "POST",
+		path:    "/api/context/reanalyze",
+		body:    map[string]int{"days": days},
+		timeout: 5 * time.Minute, // One LLM call per item
+		fallback: map[int]string{
+			500: "re-analysis failed",
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &env.Data, nil
+}
 
-	// Check if operation was successful
-	if !apiResp.Success {
-		return nil, fmt.Errorf("API error: %s", apiResp.Message)
-	}
+// ChatTurn is one turn of a conversation about an article
+type ChatTurn struct {
+	Role    string `json:"role"` // "user" or "assistant"
+	Content string `json:"content"`
+}
 
-	// Extract suggested_topics from Data map
-	suggestedTopicsData, ok := apiResp.Data["suggested_topics"]
-	if !ok {
-		return nil, fmt.Errorf("response missing suggested_topics field")
-	}
+// askEvent is one line of the /ask answer stream
+type askEvent struct {
+	Delta string `json:"delta"`
+	Done  bool   `json:"done"`
+	Error string `json:"error"`
+}
 
-	// Marshal and unmarshal to convert to proper type
-	suggestedTopicsJSON, err := json.Marshal(suggestedTopicsData)
+// AskEntry asks a question about an entry, with history as the earlier turns.
+// onDelta is called with each piece of the answer as the daemon streams it.
+func (c *APIClient) AskEntry(ctx context.Context, contentID, question string, history []ChatTurn, onDelta func(string)) error {
+	if history == nil {
+		history = []ChatTurn{}
+	}
+	done := false
+	err := c.doStream(ctx, apiRequest{
+		method:   "POST",
+		path:     "/api/entries/" + contentID + "/ask",
+		body:     map[string]any{"question": question, "history": history},
+		timeout:  2 * time.Minute, // LLM answer over the whole article
+		feature:  FeatureAsk,
+		notFound: "entry",
+	}, func(line []byte) error {
+		var event askEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("failed to parse answer stream: %w", err)
+		}
+		if event.Error != "" {
+			return &StatusError{StatusCode: http.StatusInternalServerError, Message: event.Error, Kind: ErrServer}
+		}
+		if event.Delta != "" {
+			onDelta(event.Delta)
+		}
+		done = done || event.Done
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal suggested_topics: %w", err)
+		return err
 	}
-
-	var contextResp ContextSuggestionsResponse
-	if err := json.Unmarshal(suggestedTopicsJSON, &contextResp.SuggestedTopics); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal suggested_topics: %w", err)
+	if !done {
+		return fmt.Errorf("answer stream ended early")
 	}
-
-	return &contextResp, nil
+	return nil
 }
@@ -0,0 +1,500 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/api/apitest"
+)
+
+func TestAddSource(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+
+	name := "Example"
+	resp, err := client.AddSource(context.Background(), api.SourceRequest{URL: "https://example.com/feed.xml", Type: "rss", Name: &name})
+	if err != nil {
+		t.Fatalf("AddSource failed: %v", err)
+	}
+	if resp.Data["name"] != "Example" {
+		t.Errorf("Expected name in response data, got %v", resp.Data)
+	}
+
+	// Adding the same URL again is rejected by the daemon
+	_, err = client.AddSource(context.Background(), api.SourceRequest{URL: "https://example.com/feed.xml", Type: "rss"})
+	if !strings.Contains(api.ErrorMessage(err), "already exists") {
+		t.Errorf("Expected duplicate error, got %v", err)
+	}
+}
+
+func TestDeleteSource(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+	src := daemon.AddSource(apitest.Source{URL: "https://example.com/feed.xml", Type: "rss", Active: true})
+
+	if _, err := client.DeleteSource(context.Background(), src.ID); err != nil {
+		t.Fatalf("DeleteSource failed: %v", err)
+	}
+	if len(daemon.Sources()) != 0 {
+		t.Errorf("Expected source removed, daemon has %v", daemon.Sources())
+	}
+}
+
+func TestGetSources(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.AddSource(apitest.Source{URL: "https://a.example/feed", Type: "rss", Name: "A", Category: "news", Active: true})
+	daemon.AddSource(apitest.Source{URL: "reddit://golang", Type: "reddit", Active: false})
+
+	sources, err := daemon.Client().GetSources(context.Background())
+	if err != nil {
+		t.Fatalf("GetSources failed: %v", err)
+	}
+	if sources.Total != 2 || len(sources.Sources) != 2 {
+		t.Fatalf("Expected 2 sources, got %+v", sources)
+	}
+	if sources.Sources[0].Category == nil || *sources.Sources[0].Category != "news" {
+		t.Errorf("Expected category to round-trip, got %v", sources.Sources[0].Category)
+	}
+	if sources.Sources[1].Active {
+		t.Error("Expected paused source to decode as inactive")
+	}
+}
+
+// TestGetSourceDailyCounts verifies activity comes back per source, oldest day first.
+// BREAKS: If the order flips, the source manager's sparklines run backwards.
+func TestGetSourceDailyCounts(t *testing.T) {
+	daemon := apitest.New(t)
+	now := time.Now()
+	daemon.AddEntry(apitest.Entry{SourceID: "s1", PublishedAt: now})
+	daemon.AddEntry(apitest.Entry{SourceID: "s1", PublishedAt: now})
+	daemon.AddEntry(apitest.Entry{SourceID: "s1", PublishedAt: now.AddDate(0, 0, -6)})
+	daemon.AddEntry(apitest.Entry{SourceID: "s2", PublishedAt: now.AddDate(0, 0, -60)})
+
+	counts, err := daemon.Client().GetSourceDailyCounts(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetSourceDailyCounts failed: %v", err)
+	}
+	if len(counts) != 1 || !slices.Equal(counts["s1"], []int{1, 0, 0, 0, 0, 0, 2}) {
+		t.Errorf("Expected s1 only, oldest day first, got %v", counts)
+	}
+}
+
+// TestMergeSources verifies merged sources' items move to the kept source and the duplicates go.
+// BREAKS: If items aren't reassigned, deduplicating a feed deletes its history.
+func TestMergeSources(t *testing.T) {
+	daemon := apitest.New(t)
+	keep := daemon.AddSource(apitest.Source{URL: "https://example.com/feed", Type: "rss"})
+	dupe := daemon.AddSource(apitest.Source{URL: "http://example.com/feed/", Type: "rss"})
+	daemon.AddEntry(apitest.Entry{SourceID: dupe.ID})
+
+	moved, err := daemon.Client().MergeSources(context.Background(), keep.ID, []string{dupe.ID})
+	if err != nil || moved != 1 {
+		t.Fatalf("Expected one item moved, got %d (%v)", moved, err)
+	}
+	if sources := daemon.Sources(); len(sources) != 1 || daemon.Entries()[0].SourceID != keep.ID {
+		t.Errorf("Expected only the kept source, holding the item, got %+v", sources)
+	}
+	if _, err := daemon.Client().MergeSources(context.Background(), keep.ID, []string{dupe.ID}); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("Expected a merged-away source to be not found, got %v", err)
+	}
+}
+
+// INVARIANT TEST: Delete operations must be idempotent
+func TestDeleteIdempotency(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+
+	// Delete non-existent source twice - must report not found, not break the client
+	for i := 0; i < 2; i++ {
+		_, err := client.DeleteSource(context.Background(), "definitely-does-not-exist-12345")
+		if !errors.Is(err, api.ErrNotFound) {
+			t.Errorf("Delete %d: expected ErrNotFound, got %v", i+1, err)
+		}
+	}
+
+	// Client should still be functional
+	if _, err := client.GetSources(context.Background()); err != nil {
+		t.Fatalf("Client broken after idempotent delete: %v", err)
+	}
+}
+
+// FAILURE TEST: Invalid API key must not leak the key
+func TestInvalidAPIKeyNoLeak(t *testing.T) {
+	daemon := apitest.New(t)
+
+	wrongKey := "wrong-key-should-not-appear-in-errors"
+	client := api.NewClientWithKey(daemon.URL, wrongKey)
+
+	_, err := client.GetSources(context.Background())
+	if !errors.Is(err, api.ErrAuth) {
+		t.Errorf("Expected ErrAuth, got %v", err)
+	}
+	if err != nil && strings.Contains(err.Error(), wrongKey) {
+		t.Fatalf("Wrong API key exposed in error: %v", err)
+	}
+
+	_, err = client.AddSource(context.Background(), api.SourceRequest{URL: "test", Type: "rss"})
+	if err != nil && strings.Contains(err.Error(), wrongKey) {
+		t.Fatalf("Wrong API key exposed in error: %v", err)
+	}
+}
+
+// TestFetchEntriesSince_OnlyNewer verifies the since filter round-trips through the wire format.
+// BREAKS: If since loses precision, incremental refresh re-fetches or skips items.
+func TestFetchEntriesSince_OnlyNewer(t *testing.T) {
+	daemon := apitest.New(t)
+	cutoff := time.Now().UTC().Add(-time.Hour)
+	daemon.AddEntry(apitest.Entry{Title: "old", FetchedAt: cutoff.Add(-time.Minute)})
+	daemon.AddEntry(apitest.Entry{Title: "new", FetchedAt: cutoff.Add(time.Minute), Priority: "high"})
+
+	items, err := daemon.Client().FetchEntriesSince(context.Background(), cutoff)
+	if err != nil {
+		t.Fatalf("FetchEntriesSince failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "new" || items[0].Priority == nil || *items[0].Priority != "high" {
+		t.Errorf("Expected only the newer high item, got %+v", items)
+	}
+}
+
+// TestPrune_CountThenDelete verifies prune count and execution agree and spare prioritized items.
+// BREAKS: If the count and delete disagree, the confirm dialog lies about what will be removed.
+func TestPrune_CountThenDelete(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+	daemon.AddEntry(apitest.Entry{Title: "noise"})
+	daemon.AddEntry(apitest.Entry{Title: "saved", Favorited: true})
+	daemon.AddEntry(apitest.Entry{Title: "signal", Priority: "medium"})
+
+	count, err := client.PruneCount(context.Background(), nil)
+	if err != nil || count != 1 {
+		t.Fatalf("Expected count 1, got %d (%v)", count, err)
+	}
+	deleted, err := client.PruneUnprioritized(context.Background(), nil)
+	if err != nil || deleted != 1 {
+		t.Fatalf("Expected 1 deleted, got %d (%v)", deleted, err)
+	}
+	if len(daemon.Entries()) != 2 {
+		t.Errorf("Expected 2 entries left, got %d", len(daemon.Entries()))
+	}
+}
+
+// TestSetReadBatch_MarksListedEntries verifies one batch call marks every listed entry and can be undone.
+// BREAKS: If IDs are dropped, :markall leaves items unread while claiming it marked them.
+func TestSetReadBatch_MarksListedEntries(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+	a := daemon.AddEntry(apitest.Entry{Title: "a"})
+	b := daemon.AddEntry(apitest.Entry{Title: "b"})
+	daemon.AddEntry(apitest.Entry{Title: "untouched"})
+
+	updated, err := client.SetReadBatch(context.Background(), []string{a.ID, b.ID, "missing"}, true)
+	if err != nil || updated != 2 {
+		t.Fatalf("Expected 2 updated, got %d (%v)", updated, err)
+	}
+	read := 0
+	for _, e := range daemon.Entries() {
+		if e.Read {
+			read++
+		}
+	}
+	if read != 2 {
+		t.Errorf("Expected 2 read entries, got %d", read)
+	}
+
+	if updated, err := client.SetReadBatch(context.Background(), []string{a.ID, b.ID}, false); err != nil || updated != 2 {
+		t.Errorf("Expected the undo to update 2, got %d (%v)", updated, err)
+	}
+}
+
+// TestGenerateAudioBriefing_NeedsHighPriority verifies the 422 path and the decoded success payload.
+// BREAKS: If the typed decode misses fields, the status bar shows an empty filename.
+func TestGenerateAudioBriefing_NeedsHighPriority(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+
+	if _, err := client.GenerateAudioBriefing(context.Background(), api.AudioBriefingOptions{}); !errors.Is(err, api.ErrValidation) {
+		t.Errorf("Expected ErrValidation without HIGH items, got %v", err)
+	}
+
+	daemon.AddEntry(apitest.Entry{Title: "big news", Priority: "high"})
+	briefing, err := client.GenerateAudioBriefing(context.Background(), api.AudioBriefingOptions{})
+	if err != nil {
+		t.Fatalf("GenerateAudioBriefing failed: %v", err)
+	}
+	if briefing.Filename == "" || briefing.HighPriorityCount != 1 {
+		t.Errorf("Unexpected briefing: %+v", briefing)
+	}
+}
+
+// TestGenerateAudioBriefing_Options verifies the priority floor and item cap reach the daemon.
+// BREAKS: :audio medium --max 2 silently narrates the default HIGH-only briefing.
+func TestGenerateAudioBriefing_Options(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+	for _, priority := range []string{"high", "medium", "medium"} {
+		daemon.AddEntry(apitest.Entry{Title: "news", Priority: priority})
+	}
+
+	briefing, err := client.GenerateAudioBriefing(context.Background(), api.AudioBriefingOptions{MinPriority: "medium"})
+	if err != nil || briefing.ItemCount != 3 {
+		t.Fatalf("Expected HIGH and MEDIUM items, got %+v, %v", briefing, err)
+	}
+	briefing, err = client.GenerateAudioBriefing(context.Background(), api.AudioBriefingOptions{MinPriority: "medium", MaxItems: 2, Voice: "nova", Hours: 12})
+	if err != nil || briefing.ItemCount != 2 {
+		t.Errorf("Expected the cap to apply, got %+v, %v", briefing, err)
+	}
+}
+
+// TestGetBriefingTranscript verifies a briefing's transcript is fetched by filename and as the latest.
+// BREAKS: If the filename query is dropped, the TUI shows a stale briefing's script.
+func TestGetBriefingTranscript(t *testing.T) {
+	daemon := apitest.New(t)
+	client := daemon.Client()
+	ctx := context.Background()
+	source := daemon.AddSource(apitest.Source{Name: "Lobsters", URL: "https://lobste.rs/rss"})
+	daemon.AddEntry(apitest.Entry{SourceID: source.ID, Title: "Go 1.26", URL: "https://go.dev/blog", Priority: "high"})
+
+	if _, err := client.GetBriefingTranscript(ctx, ""); !errors.Is(err, api.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound before any briefing, got %v", err)
+	}
+
+	briefing, err := client.GenerateAudioBriefing(ctx, api.AudioBriefingOptions{})
+	if err != nil {
+		t.Fatalf("GenerateAudioBriefing failed: %v", err)
+	}
+	transcript, err := client.GetBriefingTranscript(ctx, briefing.Filename)
+	if err != nil {
+		t.Fatalf("GetBriefingTranscript failed: %v", err)
+	}
+	if transcript.Filename != briefing.Filename || transcript.Script == "" || len(transcript.Items) != 1 {
+		t.Fatalf("Unexpected transcript: %+v", transcript)
+	}
+	if item := transcript.Items[0]; item.URL != "https://go.dev/blog" || item.SourceName != "Lobsters" || item.Priority != "high" {
+		t.Errorf("Unexpected transcript item: %+v", item)
+	}
+	if latest, err := client.GetBriefingTranscript(ctx, ""); err != nil || latest.Filename != briefing.Filename {
+		t.Errorf("Expected the latest transcript, got %+v, %v", latest, err)
+	}
+}
+
+// TestFetchEntriesPaged_ReportsProgress verifies paging walks every page and reports progress.
+// BREAKS: If offset isn't advanced, large libraries sync only the first page.
+func TestFetchEntriesPaged_ReportsProgress(t *testing.T) {
+	daemon := apitest.New(t)
+	for i := 0; i < 600; i++ {
+		daemon.AddEntry(apitest.Entry{Title: "item"})
+	}
+
+	var reports []api.SyncProgress
+	items, err := daemon.Client().FetchEntriesPaged(context.Background(), time.Time{}, func(p api.SyncProgress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("FetchEntriesPaged failed: %v", err)
+	}
+	if len(items) != 600 {
+		t.Errorf("Expected 600 items, got %d", len(items))
+	}
+	if len(reports) != 2 || reports[1].Page != 2 || reports[1].Pages != 2 || reports[1].Items != 600 || reports[1].Bytes == 0 {
+		t.Errorf("Unexpected progress reports: %+v", reports)
+	}
+}
+
+// TestFetchEntriesPaged_DaemonWithoutOffset verifies daemons that can't page get one request for everything.
+// BREAKS: If paging is attempted, the repeated first page ends the sync and libraries past 500 items are cut off.
+func TestFetchEntriesPaged_DaemonWithoutOffset(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.SetVersion(&api.VersionInfo{Version: "0.1.0", APIVersion: 1})
+	for i := 0; i < 600; i++ {
+		daemon.AddEntry(apitest.Entry{Title: "item"})
+	}
+
+	items, err := daemon.Client().FetchEntriesPaged(context.Background(), time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("FetchEntriesPaged failed: %v", err)
+	}
+	if len(items) != 600 {
+		t.Errorf("Expected 600 items, got %d", len(items))
+	}
+	entryCalls := 0
+	for _, route := range daemon.Requests() {
+		if route == "GET /api/entries" {
+			entryCalls++
+		}
+	}
+	if entryCalls != 1 {
+		t.Errorf("Expected one entries request, got %d", entryCalls)
+	}
+}
+
+// TestAddSource_OlderDaemonRefusesCategory verifies a category isn't sent to a daemon that would drop it.
+// BREAKS: If the request goes through, the source is added uncategorized and the user is told it worked.
+func TestAddSource_OlderDaemonRefusesCategory(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.SetVersion(&api.VersionInfo{Version: "0.1.0", APIVersion: 1})
+	client := daemon.Client()
+
+	category := "work"
+	_, err := client.AddSource(context.Background(), api.SourceRequest{URL: "https://a.example/feed", Type: "rss", Category: &category})
+	if !errors.Is(err, api.ErrUnsupported) {
+		t.Fatalf("Expected the category refused, got %v", err)
+	}
+	if _, err := client.AddSource(context.Background(), api.SourceRequest{URL: "https://a.example/feed", Type: "rss"}); err != nil {
+		t.Errorf("Expected an uncategorized add allowed, got %v", err)
+	}
+}
+
+// TestUpdateSource_OlderDaemonRefusesRule verifies a priority rule isn't sent to a daemon without source_rules.
+// BREAKS: If the request goes through, the daemon ignores the rule and the user is told it was saved.
+func TestUpdateSource_OlderDaemonRefusesRule(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.SetVersion(&api.VersionInfo{Version: "0.1.0", APIVersion: 1, Features: []string{api.FeatureCategories}})
+	client := daemon.Client()
+	id := daemon.AddSource(apitest.Source{URL: "https://a.example/feed", Name: "A"}).ID
+
+	ceiling := "low"
+	_, err := client.UpdateSource(context.Background(), id, api.SourceRequest{URL: "https://a.example/feed", Type: "rss", PriorityCeiling: &ceiling})
+	var unsupported *api.UnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.Feature != api.FeatureSourceRules {
+		t.Fatalf("Expected the rule refused, got %v", err)
+	}
+	category := "work"
+	if _, err := client.UpdateSource(context.Background(), id, api.SourceRequest{URL: "https://a.example/feed", Type: "rss", Category: &category}); err != nil {
+		t.Errorf("Expected a category-only update allowed, got %v", err)
+	}
+}
+
+// TestVersion_OlderDaemonRefusesMissingFeatures verifies features a daemon lacks fail locally with an explanation.
+// BREAKS: If the handshake is skipped, :prune and :audio on an older daemon show a bare "not found".
+func TestVersion_OlderDaemonRefusesMissingFeatures(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.SetVersion(&api.VersionInfo{Version: "0.1.0", APIVersion: 1, Features: []string{api.FeatureAudio}})
+	client := daemon.Client()
+	daemon.AddEntry(apitest.Entry{Title: "noise"})
+
+	_, err := client.PruneCount(context.Background(), nil)
+	var unsupported *api.UnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.Feature != api.FeaturePrune || !strings.Contains(err.Error(), "0.1.0") {
+		t.Fatalf("Expected prune refused for daemon 0.1.0, got %v", err)
+	}
+	flag := true
+	if _, err := client.UpdateContent(context.Background(), "x", api.ContentUpdateRequest{InterestingOverride: &flag}); !errors.Is(err, api.ErrUnsupported) {
+		t.Errorf("Expected the interesting flag refused, got %v", err)
+	}
+	if _, err := client.GenerateAudioBriefing(context.Background(), api.AudioBriefingOptions{}); errors.Is(err, api.ErrUnsupported) {
+		t.Errorf("Expected audio allowed, got %v", err)
+	}
+
+	versionCalls := 0
+	for _, route := range daemon.Requests() {
+		if route == "GET /api/version" {
+			versionCalls++
+		}
+		if strings.Contains(route, "prune") {
+			t.Errorf("Expected no prune request to reach the daemon, got %s", route)
+		}
+	}
+	if versionCalls != 1 {
+		t.Errorf("Expected one handshake per daemon, got %d", versionCalls)
+	}
+}
+
+// TestDeleteSourceWithRetention_OlderDaemonRefusesKeep verifies keep/archive are refused when the daemon can't honour them.
+// BREAKS: If the request goes through, an older daemon ignores content=keep and deletes the items the user chose to keep.
+func TestDeleteSourceWithRetention_OlderDaemonRefusesKeep(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.SetVersion(&api.VersionInfo{Version: "0.1.0", APIVersion: 1})
+	client := daemon.Client()
+	sourceID := daemon.AddSource(apitest.Source{Name: "Example"}).ID
+	daemon.AddEntry(apitest.Entry{Title: "kept", SourceID: sourceID})
+
+	if _, err := client.DeleteSourceWithRetention(context.Background(), sourceID, api.RetentionKeep); !errors.Is(err, api.ErrUnsupported) {
+		t.Fatalf("Expected keep refused, got %v", err)
+	}
+	if len(daemon.Entries()) != 1 {
+		t.Errorf("Expected the item untouched, got %d entries", len(daemon.Entries()))
+	}
+	if _, err := client.DeleteSourceWithRetention(context.Background(), sourceID, api.RetentionDelete); err != nil {
+		t.Errorf("Expected delete allowed, got %v", err)
+	}
+}
+
+// TestVersion_LegacyDaemon verifies a daemon without /api/version is treated as lacking the optional features.
+// BREAKS: If a 404 handshake is retried or treated as an error, every call to an old daemon pays an extra request.
+func TestVersion_LegacyDaemon(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.SetVersion(nil)
+	client := daemon.Client()
+
+	info, err := client.Version(context.Background())
+	if err != nil || !info.Legacy || len(info.Missing()) != 5 {
+		t.Fatalf("Expected a legacy daemon missing every feature, got %+v (%v)", info, err)
+	}
+	if _, err := client.GetBriefingTranscript(context.Background(), ""); !errors.Is(err, api.ErrUnsupported) || strings.Contains(err.Error(), "this one is") {
+		t.Errorf("Expected audio refused without a version, got %v", err)
+	}
+}
+
+// TestFetchArchivedEntries_OnlyArchived verifies the archived fetch asks the daemon for just the archived items.
+// BREAKS: If the scope isn't sent, the v key and archived:yes find nothing archived in remote mode, or download the whole feed again.
+func TestFetchArchivedEntries_OnlyArchived(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.AddEntry(apitest.Entry{Title: "current"})
+	daemon.AddEntry(apitest.Entry{Title: "old research", Archived: true})
+	client := daemon.Client()
+
+	synced, err := client.FetchEntriesPaged(context.Background(), time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("FetchEntriesPaged failed: %v", err)
+	}
+	if len(synced) != 1 || synced[0].Title != "current" {
+		t.Errorf("Expected the sync to leave archived items out, got %+v", synced)
+	}
+
+	items, err := client.FetchArchivedEntries(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("FetchArchivedEntries failed: %v", err)
+	}
+	archived := 0
+	for _, item := range items {
+		if item.ArchivedAt != nil {
+			archived++
+		}
+	}
+	if len(items) != 1 || archived != 1 {
+		t.Errorf("Expected only the archived item, got %d items, %d archived", len(items), archived)
+	}
+}
+
+// TestFetchEntryListPaged_LeavesContentOut verifies the list sync skips article text and GetEntry brings it back.
+// BREAKS: If exclude_content isn't sent, every remote sync downloads every article in full.
+func TestFetchEntryListPaged_LeavesContentOut(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.AddEntry(apitest.Entry{Title: "long read", Content: "héllo world"})
+	client := daemon.Client()
+
+	items, err := client.FetchEntryListPaged(context.Background(), time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("FetchEntryListPaged failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Content != "" || items[0].ContentLength == nil || *items[0].ContentLength != 11 {
+		t.Fatalf("Expected the item without content but with its length, got %+v", items)
+	}
+
+	full, err := client.GetEntry(context.Background(), items[0].ID)
+	if err != nil {
+		t.Fatalf("GetEntry failed: %v", err)
+	}
+	if full.Content != "héllo world" || full.Title != "long read" {
+		t.Errorf("Expected the full entry, got %+v", full)
+	}
+
+	if _, err := client.GetEntry(context.Background(), "missing"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("Expected not found for a missing entry, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,21 +13,6 @@ import (
 	"time"
 )
 
-// Helper to create a test client with proper config
-func createTestClient(t *testing.T) *APIClient {
-	// Check if we have a test config or daemon running
-	if os.Getenv("PRISMIS_TEST_API_KEY") == "" {
-		t.Skip("Set PRISMIS_TEST_API_KEY to run integration tests")
-		return nil
-	}
-
-	return &APIClient{
-		baseURL:    "http://localhost:8989",
-		apiKey:     os.Getenv("PRISMIS_TEST_API_KEY"),
-		httpClient: &http.Client{Timeout: 10 * time.Second},
-	}
-}
-
 func TestClientConfig(t *testing.T) {
 	// Test with missing config - should return error
 	// Set XDG_CONFIG_HOME to non-existent directory
@@ -70,72 +58,6 @@ key = "test-api-key-123"
 	}
 }
 
-func TestAddSource(t *testing.T) {
-	// This test requires the daemon to be running
-	// Create test config
-	client := createTestClient(t)
-	if client == nil {
-		t.Skip("Skipping integration test - no test setup")
-	}
-
-	// Try to add a source
-	req := SourceRequest{
-		URL:  "https://example.com/feed.xml",
-		Type: "rss",
-	}
-
-	resp, err := client.AddSource(req)
-	if err != nil {
-		// Check if it's a network error (daemon not running)
-		if resp == nil {
-			t.Skipf("Daemon not running: %v", err)
-		}
-		// Could be validation error which is expected
-		t.Logf("AddSource error (expected if invalid feed): %v", err)
-	} else {
-		t.Logf("AddSource response: success=%v, message=%s", resp.Success, resp.Message)
-	}
-}
-
-func TestDeleteSource(t *testing.T) {
-	// This test requires the daemon to be running
-	client := createTestClient(t)
-	if client == nil {
-		t.Skip("Skipping integration test - no test setup")
-	}
-
-	// Try to delete a non-existent source
-	resp, err := client.DeleteSource("non-existent-id")
-	if err != nil {
-		// Expected error for non-existent source
-		if resp == nil {
-			t.Skipf("Daemon not running: %v", err)
-		}
-		t.Logf("DeleteSource error (expected): %v", err)
-	} else {
-		t.Logf("DeleteSource response: success=%v, message=%s", resp.Success, resp.Message)
-	}
-}
-
-func TestGetSources(t *testing.T) {
-	// This test requires the daemon to be running
-	client := createTestClient(t)
-	if client == nil {
-		t.Skip("Skipping integration test - no test setup")
-	}
-
-	// Try to get sources
-	sources, err := client.GetSources()
-	if err != nil {
-		t.Skipf("Daemon not running: %v", err)
-	}
-
-	t.Logf("GetSources: found %d sources", sources.Total)
-	for _, source := range sources.Sources {
-		t.Logf("  - %s (%s): %s", source.ID, source.Type, source.URL)
-	}
-}
-
 // Test helper to run all integration tests with a real daemon
 func TestIntegrationWithDaemon(t *testing.T) {
 	// This test shows how to test with a real daemon
@@ -152,14 +74,14 @@ func TestIntegrationWithDaemon(t *testing.T) {
 		URL:  "https://simonwillison.net/atom/everything/",
 		Type: "rss",
 	}
-	resp, err := client.AddSource(req)
+	resp, err := client.AddSource(context.Background(), req)
 	if err != nil {
 		t.Fatalf("AddSource failed: %v", err)
 	}
 	t.Logf("Added source: %s", resp.Message)
 
 	// Test GetSources
-	sources, err := client.GetSources()
+	sources, err := client.GetSources(context.Background())
 	if err != nil {
 		t.Fatalf("GetSources failed: %v", err)
 	}
@@ -168,7 +90,7 @@ func TestIntegrationWithDaemon(t *testing.T) {
 	// Test DeleteSource (if we have sources)
 	if len(sources.Sources) > 0 {
 		sourceID := sources.Sources[0].ID
-		resp, err := client.DeleteSource(sourceID)
+		resp, err := client.DeleteSource(context.Background(), sourceID)
 		if err != nil {
 			t.Fatalf("DeleteSource failed: %v", err)
 		}
@@ -200,55 +122,22 @@ key = "` + secretKey + `"
 	client.baseURL = "http://localhost:99999" // Invalid port
 
 	// Try operations that could leak API key in errors
-	_, err := client.GetSources()
+	_, err := client.GetSources(context.Background())
 	if err != nil && containsString(err.Error(), secretKey) {
 		t.Fatalf("API key exposed in error: %v", err)
 	}
 
-	_, err = client.AddSource(SourceRequest{URL: "test", Type: "rss"})
+	_, err = client.AddSource(context.Background(), SourceRequest{URL: "test", Type: "rss"})
 	if err != nil && containsString(err.Error(), secretKey) {
 		t.Fatalf("API key exposed in error: %v", err)
 	}
 
-	_, err = client.DeleteSource("test-id")
+	_, err = client.DeleteSource(context.Background(), "test-id")
 	if err != nil && containsString(err.Error(), secretKey) {
 		t.Fatalf("API key exposed in error: %v", err)
 	}
 }
 
-// INVARIANT TEST: Delete operations must be idempotent
-func TestDeleteIdempotency(t *testing.T) {
-	client := createTestClient(t)
-	if client == nil {
-		t.Skip("Skipping integration test - no test setup")
-	}
-
-	// Delete non-existent source twice - should not fail fatally
-	nonExistentID := "definitely-does-not-exist-12345"
-
-	// First delete
-	resp1, err1 := client.DeleteSource(nonExistentID)
-	if err1 == nil {
-		t.Logf("First delete succeeded (unexpected): %v", resp1)
-	} else {
-		t.Logf("First delete error (expected): %v", err1)
-	}
-
-	// Second delete - must not cause fatal error
-	resp2, err2 := client.DeleteSource(nonExistentID)
-	if err2 == nil {
-		t.Logf("Second delete succeeded (unexpected): %v", resp2)
-	} else {
-		t.Logf("Second delete error (expected): %v", err2)
-	}
-
-	// Client should still be functional
-	_, err := client.GetSources()
-	if err != nil {
-		t.Fatalf("Client broken after idempotent delete: %v", err)
-	}
-}
-
 // INVARIANT TEST: Config path must follow XDG spec exactly
 func TestConfigXDGSpec(t *testing.T) {
 	// Test with XDG_CONFIG_HOME set
@@ -318,7 +207,7 @@ func TestDaemonUnavailable(t *testing.T) {
 	}
 
 	// Test all methods report daemon unavailable clearly
-	_, err := client.GetSources()
+	_, err := client.GetSources(context.Background())
 	if err == nil {
 		t.Fatal("Expected error when daemon unavailable")
 	}
@@ -326,40 +215,17 @@ func TestDaemonUnavailable(t *testing.T) {
 		t.Errorf("Error doesn't clearly indicate network issue: %v", err)
 	}
 
-	_, err = client.AddSource(SourceRequest{URL: "test", Type: "rss"})
+	_, err = client.AddSource(context.Background(), SourceRequest{URL: "test", Type: "rss"})
 	if err == nil {
 		t.Fatal("Expected error when daemon unavailable")
 	}
 
-	_, err = client.DeleteSource("test")
+	_, err = client.DeleteSource(context.Background(), "test")
 	if err == nil {
 		t.Fatal("Expected error when daemon unavailable")
 	}
 }
 
-// FAILURE TEST: Invalid API key must not leak the key
-func TestInvalidAPIKeyNoLeak(t *testing.T) {
-	client := createTestClient(t)
-	if client == nil {
-		t.Skip("Skipping integration test - no test setup")
-	}
-
-	// Use wrong API key
-	wrongKey := "wrong-key-should-not-appear-in-errors"
-	client.apiKey = wrongKey
-
-	// Try operations with wrong key
-	_, err := client.GetSources()
-	if err != nil && containsString(err.Error(), wrongKey) {
-		t.Fatalf("Wrong API key exposed in error: %v", err)
-	}
-
-	_, err = client.AddSource(SourceRequest{URL: "test", Type: "rss"})
-	if err != nil && containsString(err.Error(), wrongKey) {
-		t.Fatalf("Wrong API key exposed in error: %v", err)
-	}
-}
-
 // FAILURE TEST: Network timeout must leave client usable
 func TestNetworkTimeoutRecovery(t *testing.T) {
 	// Create client with very short timeout
@@ -370,7 +236,7 @@ func TestNetworkTimeoutRecovery(t *testing.T) {
 	}
 
 	// This should timeout
-	_, err := client.GetSources()
+	_, err := client.GetSources(context.Background())
 	if err == nil {
 		t.Skip("Expected timeout but got success - daemon too fast")
 	}
@@ -378,7 +244,7 @@ func TestNetworkTimeoutRecovery(t *testing.T) {
 	// Client should still be usable with normal timeout
 	client.httpClient.Timeout = 10 * time.Second
 	// This would work if daemon is running, but we just verify no panic
-	client.GetSources()
+	client.GetSources(context.Background())
 	// No panic = success
 }
 
@@ -387,6 +253,18 @@ func containsString(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
 
+// withFeatures answers the version handshake with features and passes every
+// other request to next
+func withFeatures(next http.HandlerFunc, features ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			json.NewEncoder(w).Encode(map[string]any{"success": true, "data": VersionInfo{Version: "test", APIVersion: 1, Features: features}})
+			return
+		}
+		next(w, r)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // INV-API-TS-2: apiTime.UnmarshalJSON must use exactly one layout (RFC3339).
 // Parse failures must fail loud — no fallback layouts.
@@ -497,3 +375,206 @@ func TestAPITimeUnmarshalJSON_SingleParseCall_SC26(t *testing.T) {
 		)
 	}
 }
+
+// TestDeleteSourceWithRetention_SendsContentParam verifies the retention choice reaches the daemon.
+// BREAKS: If the query param is dropped, "keep items" silently deletes them via the daemon default.
+func TestDeleteSourceWithRetention_SendsContentParam(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(withFeatures(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(`{"success": true, "message": "Source deleted"}`))
+	}, FeatureSourceRetention))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+
+	if _, err := client.DeleteSourceWithRetention(context.Background(), "abc", RetentionArchive); err != nil {
+		t.Fatalf("DeleteSourceWithRetention failed: %v", err)
+	}
+	if gotQuery != "content=archive" {
+		t.Errorf("Expected query content=archive, got %q", gotQuery)
+	}
+
+	if _, err := client.DeleteSource(context.Background(), "abc"); err != nil {
+		t.Fatalf("DeleteSource failed: %v", err)
+	}
+	if gotQuery != "" {
+		t.Errorf("Expected no query for default deletion, got %q", gotQuery)
+	}
+}
+
+// TestGetSources_CancelledContext verifies a cancelled context aborts an in-flight request.
+// BREAKS: If requests ignore ctx, Esc can't interrupt a slow sync and the UI waits on timeouts.
+func TestGetSources_CancelledContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release // Hang until the test ends
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := client.GetSources(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Error("Cancellation did not abort the request promptly")
+	}
+}
+
+// TestAskEntry_StreamsDeltasAndHistory verifies each streamed delta reaches the caller in order
+// and the earlier turns are sent with the question.
+// BREAKS: Follow-up questions lose their context, or the answer only appears once complete.
+func TestAskEntry_StreamsDeltasAndHistory(t *testing.T) {
+	var gotBody struct {
+		Question string     `json:"question"`
+		History  []ChatTurn `json:"history"`
+	}
+	server := httptest.NewServer(withFeatures(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/entries/abc/ask" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(`{"delta": "Tokio, "}` + "\n"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte(`{"delta": "mostly."}` + "\n" + `{"done": true}` + "\n"))
+	}, FeatureAsk))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+	history := []ChatTurn{{Role: "user", Content: "Which runtime?"}, {Role: "assistant", Content: "Tokio."}}
+
+	var deltas []string
+	err := client.AskEntry(context.Background(), "abc", "Why?", history, func(d string) {
+		deltas = append(deltas, d)
+	})
+	if err != nil {
+		t.Fatalf("AskEntry failed: %v", err)
+	}
+	if strings.Join(deltas, "|") != "Tokio, |mostly." {
+		t.Errorf("Unexpected deltas %q", deltas)
+	}
+	if gotBody.Question != "Why?" || len(gotBody.History) != 2 || gotBody.History[1].Content != "Tokio." {
+		t.Errorf("Unexpected request body %+v", gotBody)
+	}
+}
+
+// TestAskEntry_Errors verifies a mid-stream error event, a truncated stream, and a 404 all fail.
+// BREAKS: A failed LLM call shows up as a silently empty answer.
+func TestAskEntry_Errors(t *testing.T) {
+	bodies := map[string]string{
+		"/api/entries/llm/ask":   `{"error": "LLM answer failed"}` + "\n",
+		"/api/entries/short/ask": `{"delta": "Tok"}` + "\n",
+	}
+	server := httptest.NewServer(withFeatures(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "message": "Entry not found"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}, FeatureAsk))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+	ask := func(id string) error {
+		return client.AskEntry(context.Background(), id, "Q", nil, func(string) {})
+	}
+
+	if err := ask("llm"); !errors.Is(err, ErrServer) || !strings.Contains(err.Error(), "LLM answer failed") {
+		t.Errorf("Expected the daemon's error, got %v", err)
+	}
+	if err := ask("short"); err == nil {
+		t.Error("Expected a stream without done to fail")
+	}
+	if err := ask("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestAskEntry_OlderDaemon verifies :ask is refused before streaming when the daemon lacks it.
+// BREAKS: An older daemon answers 404, shown as "entry not found" for an article that exists.
+func TestAskEntry_OlderDaemon(t *testing.T) {
+	asked := false
+	server := httptest.NewServer(withFeatures(func(w http.ResponseWriter, r *http.Request) {
+		asked = true
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+	err := client.AskEntry(context.Background(), "abc", "Q", nil, func(string) {})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+	if asked {
+		t.Error("Expected no ask request to reach the daemon")
+	}
+}
+
+// TestDownloadAudio verifies audio bytes come back as-is and a missing file maps to ErrNotFound.
+// BREAKS: :listen against a remote daemon tries to play a path that only exists on the server.
+func TestDownloadAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/audio/files/article-abc.mp3" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "message": "Audio file not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("ID3fake"))
+	}))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+
+	data, err := client.DownloadAudio(context.Background(), "article-abc.mp3")
+	if err != nil || string(data) != "ID3fake" {
+		t.Fatalf("DownloadAudio = %q, %v", data, err)
+	}
+	if _, err := client.DownloadAudio(context.Background(), "missing.mp3"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestFetchEntriesPaged_DecodesStream verifies entries decode from the streamed envelope whatever order its keys come in,
+// and that a failed envelope is still an error.
+// BREAKS: If the incremental decoder assumes key order or skips the success flag, syncs drop items or hide daemon errors.
+func TestFetchEntriesPaged_DecodesStream(t *testing.T) {
+	body := `{"data": {"filters_applied": {"limit": 500}, "items": [{"id": "a", "title": "First"}, {"id": "b", "title": "Second"}], "total": 2}, "message": "ok", "success": true}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+
+	var received []string
+	items, err := client.FetchEntriesPaged(context.Background(), time.Time{}, func(p SyncProgress) {
+		for _, item := range p.Received {
+			received = append(received, item.ID)
+		}
+	})
+	if err != nil {
+		t.Fatalf("FetchEntriesPaged failed: %v", err)
+	}
+	if len(items) != 2 || items[1].Title != "Second" || strings.Join(received, ",") != "a,b" {
+		t.Errorf("Expected both items decoded and reported, got %+v (reported %v)", items, received)
+	}
+
+	body = `{"success": false, "message": "database locked", "data": null}`
+	if _, err := client.FetchEntriesPaged(context.Background(), time.Time{}, nil); !errors.Is(err, ErrServer) || !strings.Contains(err.Error(), "database locked") {
+		t.Errorf("Expected the envelope's failure, got %v", err)
+	}
+}
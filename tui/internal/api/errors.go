@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sentinel errors returned (wrapped) by APIClient methods. Callers should
+// branch on these with errors.Is rather than matching error strings.
+var (
+	// ErrDaemonDown means the daemon could not be reached at all
+	ErrDaemonDown = errors.New("network error")
+	// ErrAuth means the daemon rejected the API key
+	ErrAuth = errors.New("authentication failed: invalid API key")
+	// ErrNotFound means the requested source or entry does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrValidation means the daemon rejected the request as invalid (4xx)
+	ErrValidation = errors.New("validation error")
+	// ErrServer covers any other unsuccessful daemon response
+	ErrServer = errors.New("API error")
+	// ErrRateLimited means the daemon (or a proxy) kept answering 429 after retries
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// StatusError carries the HTTP status and daemon message for an unsuccessful
+// response. It unwraps to ErrValidation or ErrServer; use errors.As to get
+// the daemon's message for display.
+type StatusError struct {
+	StatusCode int
+	Message    string
+	Kind       error
+	RetryAfter time.Duration // Set for 429 responses that carried Retry-After
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%v: status %d", e.Kind, e.StatusCode)
+	}
+	return fmt.Sprintf("%v: %s", e.Kind, e.Message)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Kind
+}
+
+// ErrorMessage returns the daemon-provided message for err if it has one,
+// otherwise err's full text
+func ErrorMessage(err error) string {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Message != "" {
+		return statusErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestTypedErrors_StatusMapping verifies each daemon failure mode maps to its sentinel.
+// BREAKS: If a status maps to the wrong sentinel, operations show "daemon down" for a bad
+// API key or swallow validation messages the user needs to fix their input.
+func TestTypedErrors_StatusMapping(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusForbidden, ErrAuth},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnprocessableEntity, ErrValidation},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"success": false, "message": "Bad feed URL"}`))
+		}))
+		client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+
+		_, err := client.UpdateSource(context.Background(), "abc", SourceRequest{URL: "x", Type: "rss"})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.want, err)
+		}
+		server.Close()
+	}
+}
+
+// TestTypedErrors_ValidationMessage verifies the daemon's message is recoverable via errors.As.
+// BREAKS: If the message is lost, the add form shows "validation error" with no reason.
+func TestTypedErrors_ValidationMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"success": false, "message": "Subreddit r/ai does not exist"}`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+	_, err := client.AddSource(context.Background(), SourceRequest{URL: "reddit://ai", Type: "reddit"})
+
+	if got := ErrorMessage(err); got != "Subreddit r/ai does not exist" {
+		t.Errorf("Expected daemon message, got %q", got)
+	}
+	if err.Error() != "validation error: Subreddit r/ai does not exist" {
+		t.Errorf("Unexpected error text: %q", err.Error())
+	}
+}
+
+// TestTypedErrors_DaemonDown verifies connection failures unwrap to ErrDaemonDown.
+// BREAKS: If the transport error isn't wrapped with the sentinel, the local-DB fallback never triggers.
+func TestTypedErrors_DaemonDown(t *testing.T) {
+	client := &APIClient{
+		baseURL:    "http://localhost:44444",
+		apiKey:     "test",
+		httpClient: &http.Client{Timeout: 2 * time.Second},
+	}
+
+	_, err := client.GetSources(context.Background())
+	if !errors.Is(err, ErrDaemonDown) {
+		t.Errorf("Expected ErrDaemonDown, got %v", err)
+	}
+}
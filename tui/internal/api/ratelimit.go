@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateLimitAttempts is how many times a request is sent while the daemon answers 429
+	maxRateLimitAttempts = 3
+	// maxRateLimitWait caps a single Retry-After; longer waits fail immediately
+	maxRateLimitWait = 60 * time.Second
+	// defaultRetryAfter is used when a 429 carries no usable Retry-After
+	defaultRetryAfter = 2 * time.Second
+)
+
+// rateLimitHandler is told about each backoff so the UI can show a status
+var (
+	rateLimitHandler func(wait time.Duration)
+	rateLimitMu      sync.RWMutex
+)
+
+// SetRateLimitHandler registers fn to be called (from the request goroutine)
+// each time a client backs off after a 429. Pass nil to remove it.
+func SetRateLimitHandler(fn func(wait time.Duration)) {
+	rateLimitMu.Lock()
+	defer rateLimitMu.Unlock()
+	rateLimitHandler = fn
+}
+
+func notifyRateLimited(wait time.Duration) {
+	rateLimitMu.RLock()
+	fn := rateLimitHandler
+	rateLimitMu.RUnlock()
+	if fn != nil {
+		fn(wait)
+	}
+}
+
+// parseRetryAfter reads a Retry-After header in either delay-seconds or
+// HTTP-date form, falling back to defaultRetryAfter
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return defaultRetryAfter
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait.Round(time.Second)
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
+
+// rateLimitMessage formats the user-facing status for a backoff of wait
+func rateLimitMessage(wait time.Duration) string {
+	return fmt.Sprintf("rate limited, retry in %ds", int(wait.Round(time.Second).Seconds()))
+}
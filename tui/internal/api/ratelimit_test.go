@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestParseRetryAfter verifies both Retry-After forms and the fallback.
+// BREAKS: If HTTP-date values parse as the default, the client hammers a proxy that asked for minutes.
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultRetryAfter},
+		{"5", 5 * time.Second},
+		{"Wed, 01 Jan 2025 12:00:30 GMT", 30 * time.Second},
+		{"Wed, 01 Jan 2025 11:59:00 GMT", 0},
+		{"soon", defaultRetryAfter},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestDoRequest_RetriesAfter429 verifies a 429 is retried after notifying the handler.
+// BREAKS: If 429 isn't retried, a brief proxy throttle shows up as a generic API error.
+func TestDoRequest_RetriesAfter429(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success": true, "message": "ok", "data": {"sources": [], "total": 0}}`))
+	}))
+	defer server.Close()
+
+	var notified []time.Duration
+	SetRateLimitHandler(func(wait time.Duration) { notified = append(notified, wait) })
+	defer SetRateLimitHandler(nil)
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+	if _, err := client.GetSources(context.Background()); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+	if calls != 2 || len(notified) != 1 {
+		t.Errorf("Expected 2 calls and 1 notification, got %d and %v", calls, notified)
+	}
+}
+
+// TestDoRequest_RateLimitedGivesUp verifies long waits surface ErrRateLimited with the delay.
+// BREAKS: If the client sleeps through a 10-minute Retry-After, the TUI appears frozen.
+func TestDoRequest_RateLimitedGivesUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "test", httpClient: server.Client()}
+	_, err := client.PauseSource(context.Background(), "abc")
+
+	var statusErr *StatusError
+	if !errors.Is(err, ErrRateLimited) || !errors.As(err, &statusErr) || statusErr.RetryAfter != 600*time.Second {
+		t.Fatalf("Expected ErrRateLimited with RetryAfter 600s, got %v", err)
+	}
+	if ErrorMessage(err) != "rate limited, retry in 600s" {
+		t.Errorf("Unexpected message %q", ErrorMessage(err))
+	}
+}
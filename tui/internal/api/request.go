@@ -0,0 +1,463 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxStreamLine bounds one event of a streamed response; an LLM answer
+// delivered as a single event must fit
+const maxStreamLine = 4 * 1024 * 1024
+
+// Middleware wraps the transport used for every daemon request. Middleware
+// registered with Use runs outermost-first, before the API key is attached.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use appends middleware to the client's request chain
+func (c *APIClient) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// APIKeyMiddleware sets the X-API-Key header. It is always the innermost
+// middleware so logging and metrics never see the key.
+func APIKeyMiddleware(key string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-API-Key", key)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LoggingMiddleware logs method, path, status and duration of each request
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Printf("%s %s failed after %v: %v", req.Method, req.URL.Path, time.Since(start), err)
+				return resp, err
+			}
+			logger.Printf("%s %s %d (%v)", req.Method, req.URL.Path, resp.StatusCode, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// MetricsMiddleware reports each request to observe. status is 0 when the
+// daemon could not be reached.
+func MetricsMiddleware(observe func(method, path string, status int, elapsed time.Duration)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			observe(req.Method, req.URL.Path, status, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// RetryMiddleware retries idempotent (GET/HEAD) requests that fail at the
+// transport level, waiting backoff between attempts. Writes are never
+// retried since the daemon may already have applied them.
+func RetryMiddleware(attempts int, backoff time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next.RoundTrip(req)
+			}
+			var resp *http.Response
+			var err error
+			for i := 0; i < attempts; i++ {
+				if i > 0 {
+					select {
+					case <-req.Context().Done():
+						return nil, req.Context().Err()
+					case <-time.After(backoff):
+					}
+				}
+				resp, err = next.RoundTrip(req)
+				if err == nil {
+					return resp, nil
+				}
+			}
+			return resp, err
+		})
+	}
+}
+
+// apiRequest describes one daemon call for doRequest
+type apiRequest struct {
+	method  string
+	path    string
+	query   url.Values
+	body    any           // JSON-encoded when non-nil
+	timeout time.Duration // Overall deadline for slow endpoints (LLM, TTS); zero means none
+	feature string        // Optional daemon feature the endpoint needs (FeatureAudio, ...)
+
+	// notFound names the resource in 404 errors ("source not found")
+	notFound string
+	// fallback supplies a message per status when the body has none
+	fallback map[int]string
+	// onStatus may claim an error status before the default mapping;
+	// returning nil falls through to it
+	onStatus func(status int, resp *APIResponse) error
+}
+
+// envelope is the daemon's standard {success, message, data} response
+type envelope[T any] struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    T      `json:"data"`
+}
+
+// doRequest sends r through the middleware chain and decodes the response
+// envelope, mapping failures onto the sentinel errors in errors.go
+func doRequest[T any](ctx context.Context, c *APIClient, r apiRequest) (*envelope[T], error) {
+	if err := c.require(ctx, r.feature); err != nil {
+		return nil, err
+	}
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	endpoint := c.baseURL + r.path
+	if len(r.query) > 0 {
+		endpoint += "?" + r.query.Encode()
+	}
+
+	var jsonData []byte
+	if r.body != nil {
+		var err error
+		jsonData, err = json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	resp, err := c.openRateLimited(ctx, r, endpoint, jsonData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, statusError(r, resp.StatusCode, body)
+	}
+
+	var env envelope[T]
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if !env.Success {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Message: env.Message, Kind: ErrServer}
+	}
+	return &env, nil
+}
+
+// send performs a single attempt of r and reads the whole body
+func (c *APIClient) send(ctx context.Context, r apiRequest, endpoint string, jsonData []byte) (*http.Response, []byte, error) {
+	resp, err := c.open(ctx, r, endpoint, jsonData)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp, body, nil
+}
+
+// openRateLimited opens r, honoring Retry-After a few times when rate
+// limited before giving up
+func (c *APIClient) openRateLimited(ctx context.Context, r apiRequest, endpoint string, jsonData []byte) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.open(ctx, r, endpoint, jsonData)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if attempt >= maxRateLimitAttempts || wait > maxRateLimitWait {
+			return nil, &StatusError{
+				StatusCode: resp.StatusCode,
+				Message:    rateLimitMessage(wait),
+				Kind:       ErrRateLimited,
+				RetryAfter: wait,
+			}
+		}
+		notifyRateLimited(wait)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrDaemonDown, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// open performs a single attempt of r, leaving the body for the caller to
+// read and close
+func (c *APIClient) open(ctx context.Context, r apiRequest, endpoint string, jsonData []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if jsonData != nil {
+		bodyReader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, endpoint, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if jsonData != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client(r.timeout).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDaemonDown, err)
+	}
+	return resp, nil
+}
+
+// doDecode sends a GET like doRequest but hands the envelope's data to
+// decode as the body streams in, so a large response is put to use before
+// it has finished downloading. It returns the body size in bytes.
+func doDecode(ctx context.Context, c *APIClient, r apiRequest, decode func(dec *json.Decoder) error) (int64, error) {
+	if err := c.require(ctx, r.feature); err != nil {
+		return 0, err
+	}
+	endpoint := c.baseURL + r.path
+	if len(r.query) > 0 {
+		endpoint += "?" + r.query.Encode()
+	}
+
+	resp, err := c.openRateLimited(ctx, r, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, statusError(r, resp.StatusCode, body)
+	}
+
+	counted := &countingReader{r: resp.Body}
+	if err := decodeEnvelope(json.NewDecoder(counted), resp.StatusCode, decode); err != nil {
+		if ctx.Err() != nil {
+			return 0, fmt.Errorf("%w: %w", ErrDaemonDown, ctx.Err())
+		}
+		return 0, err
+	}
+	return counted.n, nil
+}
+
+// decodeEnvelope walks a {success, message, data} envelope, passing data to
+// decode. A failed envelope's data is skipped.
+func decodeEnvelope(dec *json.Decoder, status int, decode func(dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	success := true
+	var message string
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		switch {
+		case key == "success":
+			err = dec.Decode(&success)
+		case key == "message":
+			err = dec.Decode(&message)
+		case key == "data" && success:
+			err = decode(dec)
+		default:
+			err = dec.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	if !success {
+		return &StatusError{StatusCode: status, Message: message, Kind: ErrServer}
+	}
+	return nil
+}
+
+// expectDelim reads the next token and checks it is delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// statusError maps an error status to the matching sentinel
+func statusError(r apiRequest, status int, body []byte) error {
+	// Bodies that aren't the standard envelope leave apiResp empty
+	var apiResp APIResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		apiResp = APIResponse{}
+	}
+
+	if r.onStatus != nil {
+		if err := r.onStatus(status, &apiResp); err != nil {
+			return err
+		}
+	}
+
+	message := apiResp.Message
+	if message == "" {
+		message = r.fallback[status]
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth
+	case status == http.StatusNotFound && r.notFound != "":
+		return fmt.Errorf("%s %w", r.notFound, ErrNotFound)
+	case status == http.StatusNotFound:
+		return &StatusError{StatusCode: status, Message: message, Kind: ErrNotFound}
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return &StatusError{StatusCode: status, Message: message, Kind: ErrValidation}
+	default:
+		return &StatusError{StatusCode: status, Message: message, Kind: ErrServer}
+	}
+}
+
+// client returns an http.Client whose transport runs the middleware chain.
+// Slow endpoints pass their timeout so the transport's time-to-first-byte
+// limit doesn't cut them off before the daemon answers; they get a transport
+// with no such limit, built once per client so its connection pool is reused,
+// and rely on the request context's deadline instead.
+func (c *APIClient) client(timeout time.Duration) *http.Client {
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t, ok := base.(*http.Transport); ok && timeout > t.ResponseHeaderTimeout && t.ResponseHeaderTimeout > 0 {
+		c.slowOnce.Do(func() {
+			slow := t.Clone()
+			slow.ResponseHeaderTimeout = 0
+			c.slow = slow
+		})
+		base = c.slow
+	}
+	transport := APIKeyMiddleware(c.apiKey)(base)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		transport = c.middleware[i](transport)
+	}
+	client := *c.httpClient
+	client.Transport = transport
+	return &client
+}
+
+// doStream sends r and calls onLine for each line of a streamed
+// (newline-delimited) response body as it arrives. Error statuses are mapped
+// like doRequest's; rate-limited streams are not retried.
+func (c *APIClient) doStream(ctx context.Context, r apiRequest, onLine func(line []byte) error) error {
+	if err := c.require(ctx, r.feature); err != nil {
+		return err
+	}
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	var bodyReader io.Reader
+	if r.body != nil {
+		jsonData, err := json.Marshal(r.body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, c.baseURL+r.path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client(r.timeout).Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDaemonDown, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return statusError(r, resp.StatusCode, body)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLine)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := onLine(line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", ErrDaemonDown, ctx.Err())
+		}
+		return fmt.Errorf("failed to read stream: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestDoRequest_MiddlewareChain verifies registered middleware wraps every request
+// and the API key is attached inside the chain.
+// BREAKS: If the key isn't set by the innermost middleware, every call fails with ErrAuth;
+// if middleware is skipped, logging and metrics silently stop reporting.
+func TestDoRequest_MiddlewareChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"success": true, "message": "ok", "data": {"sources": [{"id": "a", "url": "u", "type": "rss"}], "total": 1}}`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, apiKey: "secret", httpClient: server.Client()}
+
+	var seenKey string
+	var observed []int
+	client.Use(
+		func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				seenKey = req.Header.Get("X-API-Key")
+				return next.RoundTrip(req)
+			})
+		},
+		MetricsMiddleware(func(method, path string, status int, elapsed time.Duration) {
+			observed = append(observed, status)
+		}),
+	)
+
+	list, err := client.GetSources(context.Background())
+	if err != nil {
+		t.Fatalf("GetSources failed: %v", err)
+	}
+	if len(list.Sources) != 1 || list.Total != 1 {
+		t.Errorf("Expected 1 source, got %+v", list)
+	}
+	if seenKey != "" {
+		t.Errorf("Outer middleware should not see the API key, saw %q", seenKey)
+	}
+	if len(observed) != 1 || observed[0] != http.StatusOK {
+		t.Errorf("Expected one observed 200, got %v", observed)
+	}
+}
+
+// TestRetryMiddleware_OnlyRetriesReads verifies transport failures are retried for GET only.
+// BREAKS: Retrying a POST could prune or add twice when the first attempt actually reached the daemon.
+func TestRetryMiddleware_OnlyRetriesReads(t *testing.T) {
+	calls := 0
+	failing := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("connection refused")
+	})
+	rt := RetryMiddleware(3, time.Millisecond)(failing)
+
+	get, _ := http.NewRequest("GET", "http://daemon/api/sources", nil)
+	rt.RoundTrip(get)
+	if calls != 3 {
+		t.Errorf("Expected 3 GET attempts, got %d", calls)
+	}
+
+	calls = 0
+	post, _ := http.NewRequest("POST", "http://daemon/api/prune", nil)
+	rt.RoundTrip(post)
+	if calls != 1 {
+		t.Errorf("Expected 1 POST attempt, got %d", calls)
+	}
+}
+
+// TestClient_SlowTransportBuiltOnce verifies slow endpoints share one transport
+// without a time-to-first-byte limit, while normal calls keep the base transport.
+// BREAKS: Cloning per call would leak a connection pool for every slow request.
+func TestClient_SlowTransportBuiltOnce(t *testing.T) {
+	base := &http.Transport{ResponseHeaderTimeout: 30 * time.Second}
+	client := &APIClient{httpClient: &http.Client{Transport: base}}
+
+	client.client(time.Minute)
+	slow := client.slow
+	client.client(2 * time.Minute)
+
+	transport, ok := slow.(*http.Transport)
+	if !ok || transport == base {
+		t.Fatalf("Expected a separate slow transport, got %T", slow)
+	}
+	if client.slow != slow {
+		t.Error("Slow transport was rebuilt on the second call")
+	}
+	if transport.ResponseHeaderTimeout != 0 {
+		t.Errorf("Expected no header timeout on the slow transport, got %v", transport.ResponseHeaderTimeout)
+	}
+	if base.ResponseHeaderTimeout != 30*time.Second {
+		t.Errorf("Base transport was modified: %v", base.ResponseHeaderTimeout)
+	}
+}
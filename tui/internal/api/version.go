@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Optional daemon features, as listed by GET /api/version. Requests that
+// need one are refused with an UnsupportedError when the daemon lacks it.
+const (
+	FeatureAudio       = "audio"       // Audio briefings and article narration
+	FeaturePrune       = "prune"       // Counting and deleting unprioritized items
+	FeatureInteresting = "interesting" // The interesting_override flag on entries
+	FeatureActivity    = "activity"    // Per-source daily item counts
+	FeatureMerge       = "merge"       // Merging duplicate sources
+	FeatureAsk         = "ask"         // Streamed questions about an article (:ask)
+	// Archived entries and upvote/downvote feedback. They share their names
+	// with db.SchemaArchive and db.SchemaFeedback, so one check covers the
+	// local database and the daemon.
+	FeatureArchive  = "archive"
+	FeatureFeedback = "feedback"
+	// Adding several context.md topics in one request. Left out of Missing:
+	// without it topics are added one request at a time.
+	FeatureContextBatch = "context_batch"
+	// Per-source priority floors and ceilings ("this feed is at most LOW").
+	// Left out of Missing: without it sources simply have no rules.
+	FeatureSourceRules = "source_rules"
+	// Keeping or archiving a deleted source's items (DELETE /api/sources
+	// content=keep|archive). Older daemons ignore the parameter and delete.
+	FeatureSourceRetention = "source_retention"
+	// Paging /api/entries with offset. Left out of Missing: without it
+	// entries come back in one request.
+	FeatureEntriesOffset = "entries_offset"
+	// Filing sources under a category. Left out of Missing: without it
+	// sources are simply uncategorized.
+	FeatureCategories = "categories"
+)
+
+// ErrUnsupported means the daemon is too old for the requested feature
+var ErrUnsupported = errors.New("not supported by this daemon")
+
+// UnsupportedError names the feature an older daemon lacks. It unwraps to
+// ErrUnsupported.
+type UnsupportedError struct {
+	Feature string
+	Version string // Daemon version, empty when it predates /api/version
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.Version == "" {
+		return fmt.Sprintf("%s needs a newer prismis daemon; upgrade the daemon to use it", e.Feature)
+	}
+	return fmt.Sprintf("%s needs a newer prismis daemon (this one is %s); upgrade the daemon to use it", e.Feature, e.Version)
+}
+
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupported
+}
+
+// VersionInfo is the daemon's answer to the version handshake
+type VersionInfo struct {
+	Version    string   `json:"version"`
+	APIVersion int      `json:"api_version"`
+	Features   []string `json:"features"`
+	Legacy     bool     `json:"-"` // The daemon predates /api/version
+}
+
+// Supports reports whether the daemon offers feature. Daemons from before
+// the handshake get none of the optional features.
+func (v *VersionInfo) Supports(feature string) bool {
+	return slices.Contains(v.Features, feature)
+}
+
+// Missing returns the known features the daemon lacks
+func (v *VersionInfo) Missing() []string {
+	var missing []string
+	for _, feature := range []string{FeatureAudio, FeaturePrune, FeatureInteresting, FeatureActivity, FeatureMerge} {
+		if !v.Supports(feature) {
+			missing = append(missing, feature)
+		}
+	}
+	return missing
+}
+
+// versions caches each daemon's handshake by base URL: clients are created
+// per operation, but a daemon only changes version across restarts
+var (
+	versions   = map[string]*VersionInfo{}
+	versionsMu sync.Mutex
+)
+
+// Version returns the daemon's version and features, asking it once per
+// process. A daemon that answers 404 predates the handshake.
+func (c *APIClient) Version(ctx context.Context) (*VersionInfo, error) {
+	versionsMu.Lock()
+	cached, ok := versions[c.baseURL]
+	versionsMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	info := &VersionInfo{}
+	env, err := doRequest[VersionInfo](ctx, c, apiRequest{method: "GET", path: "/api/version"})
+	switch {
+	case errors.Is(err, ErrNotFound):
+		info.Legacy = true
+	case err != nil:
+		return nil, err // Not cached: ask again once the daemon is back
+	default:
+		*info = env.Data
+	}
+
+	versionsMu.Lock()
+	versions[c.baseURL] = info
+	versionsMu.Unlock()
+	return info, nil
+}
+
+// require fails with an UnsupportedError when the daemon lacks feature. If
+// the handshake itself fails, the request goes ahead and reports its own error.
+func (c *APIClient) require(ctx context.Context, feature string) error {
+	if feature == "" {
+		return nil
+	}
+	info, err := c.Version(ctx)
+	if err != nil || info.Supports(feature) {
+		return nil
+	}
+	return &UnsupportedError{Feature: feature, Version: info.Version}
+}
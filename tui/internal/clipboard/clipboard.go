@@ -1,44 +1,88 @@
 package clipboard
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/nickpending/prismis/internal/config"
 )
 
+// osc52Output is where OSC 52 sequences are written: the controlling
+// terminal, via stderr since Bubble Tea renders on stdout (for testing)
+var osc52Output = func() (io.Writer, error) {
+	info, err := os.Stderr.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil, errors.New("stderr is not a terminal")
+	}
+	return os.Stderr, nil
+}
+
 // CopyToClipboard copies the given text to the system clipboard.
-// It detects the OS and uses the appropriate command.
+// A [tui] clipboard_command in config takes precedence. Over SSH, or when
+// no native command works, it falls back to an OSC 52 escape sequence that
+// asks the local terminal to set its clipboard.
 func CopyToClipboard(text string) error {
 	if text == "" {
 		return fmt.Errorf("cannot copy empty text to clipboard")
 	}
 
-	var cmd *exec.Cmd
+	if cfg, err := config.LoadConfig(); err == nil {
+		if command := cfg.GetClipboardCommand(); len(command) > 0 {
+			return runCopyCommand(exec.Command(command[0], command[1:]...), text)
+		}
+	}
+
+	// The remote host's clipboard is useless to someone on the other end of SSH
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return copyOSC52(text)
+	}
+
+	cmd, err := nativeCommand()
+	if err == nil {
+		if err = runCopyCommand(cmd, text); err == nil {
+			return nil
+		}
+	}
+	if oscErr := copyOSC52(text); oscErr != nil {
+		return fmt.Errorf("%w (OSC 52 fallback: %v)", err, oscErr)
+	}
+	return nil
+}
 
+// nativeCommand detects the OS and returns the appropriate copy command
+func nativeCommand() (*exec.Cmd, error) {
 	switch runtime.GOOS {
 	case "darwin":
 		// macOS: use pbcopy
-		cmd = exec.Command("pbcopy")
+		return exec.Command("pbcopy"), nil
 	case "linux":
-		// Linux: try xclip first, then xsel, then wl-copy for Wayland
-		if _, err := exec.LookPath("xclip"); err == nil {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
-		} else if _, err := exec.LookPath("xsel"); err == nil {
-			cmd = exec.Command("xsel", "--clipboard", "--input")
-		} else if _, err := exec.LookPath("wl-copy"); err == nil {
-			cmd = exec.Command("wl-copy")
-		} else {
-			return fmt.Errorf("no clipboard command found (install xclip, xsel, or wl-clipboard)")
+		// Linux: try xclip first, then xsel, then wl-copy for Wayland. Each
+		// needs a display; without one (a headless server) they fail quietly.
+		x11 := os.Getenv("DISPLAY") != ""
+		if _, err := exec.LookPath("xclip"); err == nil && x11 {
+			return exec.Command("xclip", "-selection", "clipboard"), nil
+		} else if _, err := exec.LookPath("xsel"); err == nil && x11 {
+			return exec.Command("xsel", "--clipboard", "--input"), nil
+		} else if _, err := exec.LookPath("wl-copy"); err == nil && os.Getenv("WAYLAND_DISPLAY") != "" {
+			return exec.Command("wl-copy"), nil
 		}
+		return nil, fmt.Errorf("no clipboard command found (install xclip, xsel, or wl-clipboard, or set [tui] clipboard_command)")
 	case "windows":
 		// Windows: use clip.exe (works in WSL too)
-		cmd = exec.Command("clip.exe")
+		return exec.Command("clip.exe"), nil
 	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+		return nil, fmt.Errorf("unsupported platform: %s", runtime.GOOS)
 	}
+}
 
+// runCopyCommand pipes text into a clipboard command
+func runCopyCommand(cmd *exec.Cmd, text string) error {
 	// Get stdin pipe
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -72,3 +116,23 @@ func CopyToClipboard(text string) error {
 
 	return nil
 }
+
+// copyOSC52 writes an OSC 52 sequence, wrapped for tmux or screen when
+// running inside one so it reaches the outer terminal
+func copyOSC52(text string) error {
+	w, err := osc52Output()
+	if err != nil {
+		return err
+	}
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	if _, err := seq.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write OSC 52 sequence: %w", err)
+	}
+	return nil
+}
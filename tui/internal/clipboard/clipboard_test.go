@@ -0,0 +1,77 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useConfig points LoadConfig at a temp config.toml with the given [tui] body
+func useConfig(t *testing.T, tui string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "prismis"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "prismis", "config.toml"), []byte("[tui]\n"+tui), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", dir)
+}
+
+// captureOSC52 collects OSC 52 output instead of writing to the terminal
+func captureOSC52(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	old := osc52Output
+	osc52Output = func() (io.Writer, error) { return &buf, nil }
+	t.Cleanup(func() { osc52Output = old })
+	return &buf
+}
+
+// TestCopyToClipboard_OSC52OverSSH verifies an SSH session copies via OSC 52, wrapped for tmux.
+// BREAKS: If yank runs xclip on the server, the URL lands in a clipboard the user can't reach.
+func TestCopyToClipboard_OSC52OverSSH(t *testing.T) {
+	useConfig(t, "")
+	out := captureOSC52(t)
+	t.Setenv("SSH_TTY", "/dev/pts/3")
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	if err := CopyToClipboard("https://example.com"); err != nil {
+		t.Fatalf("Expected OSC 52 copy to succeed, got %v", err)
+	}
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("https://example.com")) + "\x07"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+
+	out.Reset()
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if err := CopyToClipboard("x"); err != nil || !strings.HasPrefix(out.String(), "\x1bPtmux;") {
+		t.Errorf("Expected a tmux passthrough sequence, got %q (%v)", out.String(), err)
+	}
+}
+
+// TestCopyToClipboard_ConfiguredCommandWins verifies [tui] clipboard_command is used even over SSH.
+// BREAKS: If the override is ignored, users with a custom bridge (e.g. lemonade) can't copy at all.
+func TestCopyToClipboard_ConfiguredCommandWins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "copied.txt")
+	useConfig(t, "clipboard_command = \"tee "+path+"\"\n")
+	out := captureOSC52(t)
+	t.Setenv("SSH_TTY", "/dev/pts/3")
+
+	if err := CopyToClipboard("hello"); err != nil {
+		t.Fatalf("Expected the configured command to succeed, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "hello" {
+		t.Errorf("Expected the command to receive the text, got %q", data)
+	}
+	if out.Len() != 0 {
+		t.Error("Expected no OSC 52 output when a command is configured")
+	}
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"testing"
+)
+
+// TestAskCommand verifies :ask joins its arguments into the question.
+// BREAKS: Multi-word questions reach the daemon truncated to their first word.
+func TestAskCommand(t *testing.T) {
+	msg, ok := cmdAsk([]string{"what", "runtime", "wins?"})().(AskMsg)
+	if !ok || msg.Question != "what runtime wins?" {
+		t.Errorf("Expected the joined question, got %#v", msg)
+	}
+	if msg, ok := cmdAsk(nil)().(AskMsg); !ok || msg.Question != "" {
+		t.Errorf("Expected a bare :ask to open the conversation, got %#v", msg)
+	}
+}
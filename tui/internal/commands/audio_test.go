@@ -34,12 +34,37 @@ func TestAudioCommand_NoArguments(t *testing.T) {
 		t.Error("Command returned nil message")
 	}
 
-	// Should ignore extra arguments gracefully
+	// Unknown arguments are reported rather than silently dropped
 	cmd2 := cmdAudio([]string{"unexpected", "args"})
 	msg2 := cmd2()
 
-	if msg2 == nil {
-		t.Error("Command with args returned nil message")
+	if _, ok := msg2.(ErrorMsg); !ok {
+		t.Errorf("Expected ErrorMsg for unknown arguments, got %T", msg2)
+	}
+}
+
+// TestAudioCommand_Options verifies :audio maps priority, window, voice, and cap onto AudioMsg.
+// BREAKS: :audio medium 24h --voice nova --max 10 still narrates the default HIGH-only briefing.
+func TestAudioCommand_Options(t *testing.T) {
+	tests := []struct {
+		args []string
+		want AudioMsg
+	}{
+		{[]string{"medium", "24h", "--voice", "Nova", "--max", "10"}, AudioMsg{MinPriority: "medium", Hours: 24, Voice: "Nova", MaxItems: 10}},
+		{[]string{"low", "2d", "--max=5"}, AudioMsg{MinPriority: "low", Hours: 48, MaxItems: 5}},
+		{[]string{"--voice=Rachel"}, AudioMsg{Voice: "Rachel"}},
+		{[]string{"med", "1w"}, AudioMsg{MinPriority: "medium", Hours: 168}},
+	}
+	for _, tt := range tests {
+		if got := cmdAudio(tt.args)(); got != tt.want {
+			t.Errorf("cmdAudio(%v) = %#v, want %#v", tt.args, got, tt.want)
+		}
+	}
+
+	for _, args := range [][]string{{"--voice"}, {"--max", "0"}, {"--max", "lots"}, {"urgent"}} {
+		if _, ok := cmdAudio(args)().(ErrorMsg); !ok {
+			t.Errorf("cmdAudio(%v) should be an error", args)
+		}
 	}
 }
 
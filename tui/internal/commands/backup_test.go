@@ -0,0 +1,32 @@
+package commands
+
+import "testing"
+
+// TestBackupCommands_Paths verifies :backup takes an optional path and :restore requires one, spaces included.
+// BREAKS: If :restore runs without a path, it tries to restore from the working directory.
+func TestBackupCommands_Paths(t *testing.T) {
+	if msg, ok := cmdBackup(nil)().(BackupMsg); !ok || msg.Path != "" {
+		t.Errorf("Expected a default backup, got %+v", msg)
+	}
+	if msg, ok := cmdRestore([]string{"~/My", "Backups/prismis.db"})().(RestoreMsg); !ok || msg.Path != "~/My Backups/prismis.db" {
+		t.Errorf("Expected the path joined, got %+v", msg)
+	}
+	if _, ok := cmdRestore(nil)().(ErrorMsg); !ok {
+		t.Error("Expected ErrorMsg without a path")
+	}
+}
+
+// TestDBCommand_Subcommands verifies :db accepts vacuum, check, and stats and nothing else.
+// BREAKS: If unknown subcommands pass through, a typo silently does nothing.
+func TestDBCommand_Subcommands(t *testing.T) {
+	for _, action := range []string{"vacuum", "check", "stats"} {
+		if msg, ok := cmdDB([]string{action})().(DBMaintenanceMsg); !ok || msg.Action != action {
+			t.Errorf("Expected :db %s, got %+v", action, msg)
+		}
+	}
+	for _, args := range [][]string{nil, {"drop"}, {"vacuum", "now"}} {
+		if _, ok := cmdDB(args)().(ErrorMsg); !ok {
+			t.Errorf("Expected ErrorMsg for %v", args)
+		}
+	}
+}
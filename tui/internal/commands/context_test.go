@@ -30,6 +30,39 @@ func TestContextEditCommand(t *testing.T) {
 	}
 }
 
+// INVARIANT: :context edit! asks for $EDITOR; plain :context edit stays in the TUI
+// BREAKS: Users lose the escape hatch to their own editor
+func TestContextEditBangCommand(t *testing.T) {
+	if msg, ok := cmdContext([]string{"edit"})().(ContextEditMsg); !ok || msg.External {
+		t.Errorf("Expected in-TUI ContextEditMsg, got %#v", msg)
+	}
+	if msg, ok := cmdContext([]string{"edit!"})().(ContextEditMsg); !ok || !msg.External {
+		t.Errorf("Expected external ContextEditMsg, got %#v", msg)
+	}
+}
+
+// INVARIANT: :context add takes an optional leading section word and joins the rest as the topic
+// BREAKS: Multi-word topics get truncated, or land in the wrong section
+func TestContextAddCommand(t *testing.T) {
+	tests := []struct {
+		args    []string
+		topic   string
+		section string
+	}{
+		{[]string{"add", "SQLite", "internals"}, "SQLite internals", "medium"},
+		{[]string{"add", "high", "Rust"}, "Rust", "high"},
+		{[]string{"add", "not-interested", "crypto"}, "crypto", "not_interested"},
+		{[]string{"add", "low"}, "", "low"},
+		{[]string{"add"}, "", "medium"},
+	}
+	for _, tt := range tests {
+		msg, ok := cmdContext(tt.args)().(ContextAddMsg)
+		if !ok || msg.Topic != tt.topic || msg.Section != tt.section {
+			t.Errorf("%v: got %#v, want topic %q section %q", tt.args, msg, tt.topic, tt.section)
+		}
+	}
+}
+
 // INVARIANT: :context review creates ContextReviewMsg
 // BREAKS: Review won't display if message type wrong
 func TestContextReviewCommand(t *testing.T) {
@@ -0,0 +1,26 @@
+package commands
+
+import "testing"
+
+// TestDigestCommand_ParsesOptions verifies :digest arguments pick the priorities and export target.
+// BREAKS: If "medium" or "export" are dropped, the user gets the wrong digest silently.
+func TestDigestCommand_ParsesOptions(t *testing.T) {
+	tests := []struct {
+		args []string
+		want DigestMsg
+	}{
+		{nil, DigestMsg{}},
+		{[]string{"medium"}, DigestMsg{IncludeMedium: true}},
+		{[]string{"medium", "export"}, DigestMsg{IncludeMedium: true, Export: "markdown"}},
+		{[]string{"audio"}, DigestMsg{Export: "audio"}},
+	}
+	for _, tt := range tests {
+		if got := cmdDigest(tt.args)(); got != tt.want {
+			t.Errorf("digest %v: expected %+v, got %+v", tt.args, tt.want, got)
+		}
+	}
+
+	if _, ok := cmdDigest([]string{"bogus"})().(ErrorMsg); !ok {
+		t.Error("Expected an unknown argument to be an error")
+	}
+}
@@ -0,0 +1,35 @@
+package commands
+
+import "testing"
+
+// TestExportCommand_HTMLTakesOptionalPath verifies :export html passes the path through, spaces included.
+// BREAKS: If the path is split on spaces, exporting to "~/Reading Lists" writes to "~/Reading".
+func TestExportCommand_HTMLTakesOptionalPath(t *testing.T) {
+	if msg, ok := cmdExport([]string{"html"})().(ExportHTMLMsg); !ok || msg.Path != "" {
+		t.Errorf("Expected a default-path export, got %+v", msg)
+	}
+	if msg, ok := cmdExport([]string{"html", "~/Reading", "Lists"})().(ExportHTMLMsg); !ok || msg.Path != "~/Reading Lists" {
+		t.Errorf("Expected path '~/Reading Lists', got %+v", msg)
+	}
+	if _, ok := cmdExport([]string{"pdf"})().(ErrorMsg); !ok {
+		t.Error("Expected ErrorMsg for an unknown format")
+	}
+}
+
+// TestExportCommand_SourcesPathAndFormat verifies :export sources takes a path and a --format flag in any order.
+// BREAKS: If the flag is taken as part of the path, exports land in a file named "--format=opml".
+func TestExportCommand_SourcesPathAndFormat(t *testing.T) {
+	if msg, ok := cmdExport([]string{"sources"})().(ExportSourcesMsg); !ok || msg != (ExportSourcesMsg{}) {
+		t.Errorf("Expected a clipboard export, got %+v", msg)
+	}
+	msg, ok := cmdExport([]string{"sources", "--format=opml", "~/My", "Feeds"})().(ExportSourcesMsg)
+	if !ok || msg.Path != "~/My Feeds" || msg.Format != "opml" {
+		t.Errorf("Expected OPML to '~/My Feeds', got %+v", msg)
+	}
+	if msg, ok := cmdExport([]string{"sources", "out.txt", "--format=md"})().(ExportSourcesMsg); !ok || msg.Format != "markdown" {
+		t.Errorf("Expected md as markdown, got %+v", msg)
+	}
+	if _, ok := cmdExport([]string{"sources", "--format=csv"})().(ErrorMsg); !ok {
+		t.Error("Expected ErrorMsg for an unknown format")
+	}
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFilterCommand_ParsesCategory verifies :filter category=<name> produces a category FilterMsg.
+// BREAKS: If the name isn't split off the "=" correctly, the filter matches no sources.
+func TestFilterCommand_ParsesCategory(t *testing.T) {
+	msg := cmdFilter([]string{"category=Work", "Reading"})()
+
+	filter, ok := msg.(FilterMsg)
+	if !ok {
+		t.Fatalf("Expected FilterMsg, got %T", msg)
+	}
+	if filter.Field != "category" || filter.Value != "Work Reading" {
+		t.Errorf("Expected category 'Work Reading', got %+v", filter)
+	}
+}
+
+// TestFilterCommand_NoArgsClears verifies bare :filter clears all source filters.
+// BREAKS: If no-arg :filter errors, users have no way to reset a category filter.
+func TestFilterCommand_NoArgsClears(t *testing.T) {
+	msg := cmdFilter([]string{})()
+
+	filter, ok := msg.(FilterMsg)
+	if !ok {
+		t.Fatalf("Expected FilterMsg, got %T", msg)
+	}
+	if filter.Field != "" {
+		t.Errorf("Expected empty field to clear filters, got %q", filter.Field)
+	}
+}
+
+// TestFilterCommand_RejectsUnknownInput verifies malformed filters surface an error.
+// BREAKS: If bad input is accepted silently, typos like type=rs hide the entire feed.
+func TestFilterCommand_RejectsUnknownInput(t *testing.T) {
+	for _, args := range [][]string{{"category"}, {"color=red"}, {"type=rs"}} {
+		if _, ok := cmdFilter(args)().(ErrorMsg); !ok {
+			t.Errorf("Expected ErrorMsg for %v", args)
+		}
+	}
+}
+
+// TestFilterCommand_ParsesSource verifies :filter source=<name> produces a source FilterMsg.
+// BREAKS: If source isn't accepted, the palette's source entries have nothing to run.
+func TestFilterCommand_ParsesSource(t *testing.T) {
+	msg := cmdFilter([]string{"source=Hacker", "News"})()
+
+	filter, ok := msg.(FilterMsg)
+	if !ok {
+		t.Fatalf("Expected FilterMsg, got %T", msg)
+	}
+	if filter.Field != "source" || filter.Value != "Hacker News" {
+		t.Errorf("Expected source 'Hacker News', got %+v", filter)
+	}
+}
+
+// TestParseFilterTime_AgesDatesAndNames verifies since/until accept ages back from now, named days, and dates.
+// BREAKS: If ages count forward like :snooze, since=2d matches nothing that has already arrived.
+func TestParseFilterTime_AgesDatesAndNames(t *testing.T) {
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.Local)
+	cases := map[string]time.Time{
+		"12h":              now.Add(-12 * time.Hour),
+		"2d":               time.Date(2026, 10, 13, 14, 30, 0, 0, time.Local),
+		"1w":               time.Date(2026, 10, 8, 14, 30, 0, 0, time.Local),
+		"today":            time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+		"Yesterday":        time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local),
+		"2024-01-01":       time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+		"2024-01-01T09:15": time.Date(2024, 1, 1, 9, 15, 0, 0, time.Local),
+		"":                 {},
+	}
+	for value, want := range cases {
+		got, err := parseFilterTime(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseFilterTime(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"soon", "0d", "2024-13-01"} {
+		if _, err := parseFilterTime(value, now); err == nil {
+			t.Errorf("Expected %q rejected", value)
+		}
+	}
+
+	msg, ok := cmdFilter([]string{"until=2024-01-01"})().(FilterMsg)
+	if !ok || msg.Field != "until" || !msg.Time.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected an until FilterMsg, got %+v", msg)
+	}
+	if _, ok := cmdFilter([]string{"since=later"})().(ErrorMsg); !ok {
+		t.Error("Expected an invalid since rejected")
+	}
+}
+
+// TestFilterCommand_ParsesAuthor verifies :filter author=<name> keeps the name as typed, spaces included.
+// BREAKS: If the value is split on spaces, "Jane Doe" filters for "Jane" only.
+func TestFilterCommand_ParsesAuthor(t *testing.T) {
+	filter, ok := cmdFilter([]string{"author=Jane", "Doe"})().(FilterMsg)
+	if !ok || filter.Field != "author" || filter.Value != "Jane Doe" {
+		t.Errorf("Expected author 'Jane Doe', got %+v", filter)
+	}
+}
+
+// TestFilterCommand_ParsesExpression verifies anything beyond one field=value becomes a canonical expression.
+// BREAKS: If expressions take the field=value path, "source=rust AND NOT read" filters to a source with that whole name.
+func TestFilterCommand_ParsesExpression(t *testing.T) {
+	msg := cmdFilter([]string{"source~rust", "and", "length>2000", "not", "read"})()
+
+	filter, ok := msg.(FilterMsg)
+	if !ok {
+		t.Fatalf("Expected FilterMsg, got %T (%v)", msg, msg)
+	}
+	if filter.Field != "expr" || filter.Value != "source~rust AND length>2000 AND NOT read" {
+		t.Errorf("Expected canonical expression, got %+v", filter)
+	}
+
+	// Quotes the command line removed come back, whether around a value or the whole expression
+	if filter, _ := cmdFilter([]string{"title~rust async"})().(FilterMsg); filter.Value != `title~"rust async"` {
+		t.Errorf("Expected the quoted title kept whole, got %+v", filter)
+	}
+	if filter, _ := cmdFilter([]string{"priority=high AND NOT read"})().(FilterMsg); filter.Value != "priority=high AND NOT read" {
+		t.Errorf("Expected the quoted expression parsed, got %+v", filter)
+	}
+
+	// A lone field the per-field filters don't cover is an expression too
+	if filter, _ := cmdFilter([]string{"priority=HIGH"})().(FilterMsg); filter.Field != "expr" || filter.Value != "priority=high" {
+		t.Errorf("Expected priority=high as an expression, got %+v", filter)
+	}
+	// ...while one they do keeps its own meaning
+	if filter, _ := cmdFilter([]string{"source=Hacker", "News"})().(FilterMsg); filter.Field != "source" {
+		t.Errorf("Expected source=Hacker News as a source filter, got %+v", filter)
+	}
+
+	if errMsg, ok := cmdFilter([]string{"priority=high", "AND"})().(ErrorMsg); !ok || !strings.Contains(errMsg.Message, "AND needs") {
+		t.Errorf("Expected the parse error, got %v", errMsg)
+	}
+}
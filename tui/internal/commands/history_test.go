@@ -0,0 +1,27 @@
+package commands
+
+import "testing"
+
+// TestHistoryCommand_ParsesDays verifies :history defaults to a week and accepts a day count.
+// BREAKS: If the count is ignored, :history 30 quietly shows only the last week.
+func TestHistoryCommand_ParsesDays(t *testing.T) {
+	tests := []struct {
+		args []string
+		want HistoryMsg
+	}{
+		{nil, HistoryMsg{Days: 7}},
+		{[]string{"30"}, HistoryMsg{Days: 30}},
+		{[]string{"14d"}, HistoryMsg{Days: 14}},
+	}
+	for _, tt := range tests {
+		if got := cmdHistory(tt.args)(); got != tt.want {
+			t.Errorf("history %v: expected %+v, got %+v", tt.args, tt.want, got)
+		}
+	}
+
+	for _, args := range [][]string{{"0"}, {"week"}, {"7", "extra"}} {
+		if _, ok := cmdHistory(args)().(ErrorMsg); !ok {
+			t.Errorf("history %v: expected an error", args)
+		}
+	}
+}
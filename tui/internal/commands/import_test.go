@@ -0,0 +1,22 @@
+package commands
+
+import "testing"
+
+// TestImportCommand_ParsesSources verifies :import picks the reader and passes a newsboat path through, spaces included.
+// BREAKS: If unknown readers are accepted, a typo starts an import that reads nothing.
+func TestImportCommand_ParsesSources(t *testing.T) {
+	if msg, ok := cmdImport([]string{"newsboat"})().(ImportSourcesMsg); !ok || msg.From != "newsboat" || msg.Path != "" {
+		t.Errorf("Expected a newsboat import from the default file, got %+v", msg)
+	}
+	if msg, ok := cmdImport([]string{"newsboat", "~/old", "urls"})().(ImportSourcesMsg); !ok || msg.Path != "~/old urls" {
+		t.Errorf("Expected path '~/old urls', got %+v", msg)
+	}
+	if msg, ok := cmdImport([]string{"miniflux"})().(ImportSourcesMsg); !ok || msg.From != "miniflux" {
+		t.Errorf("Expected a miniflux import, got %+v", msg)
+	}
+	for _, args := range [][]string{nil, {"feedly"}, {"miniflux", "https://reader.example.com"}} {
+		if _, ok := cmdImport(args)().(ErrorMsg); !ok {
+			t.Errorf("Expected ErrorMsg for %v", args)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"testing"
+)
+
+// TestListenCommand verifies :listen plays by default and accepts pause/stop only.
+// BREAKS: A typo like :listen stpo starts narrating (and billing TTS for) the article.
+func TestListenCommand(t *testing.T) {
+	if msg, ok := cmdListen(nil)().(ListenMsg); !ok || msg.Action != "" {
+		t.Errorf("Expected a bare :listen to play, got %#v", msg)
+	}
+	if msg, ok := cmdListen([]string{"Pause"})().(ListenMsg); !ok || msg.Action != "pause" {
+		t.Errorf("Expected :listen pause, got %#v", msg)
+	}
+	if _, ok := cmdListen([]string{"stpo"})().(ErrorMsg); !ok {
+		t.Error("Expected an unknown argument to be an error")
+	}
+}
@@ -0,0 +1,37 @@
+package commands
+
+import "testing"
+
+// TestMarkAllCommand_ParsesScopes verifies each :markall scope produces the matching MarkAllMsg.
+// BREAKS: If a scope is misparsed, a confirmation could offer to mark the wrong items read.
+func TestMarkAllCommand_ParsesScopes(t *testing.T) {
+	cases := []struct {
+		args []string
+		want MarkAllMsg
+	}{
+		{nil, MarkAllMsg{Scope: "filter"}},
+		{[]string{"filter"}, MarkAllMsg{Scope: "filter"}},
+		{[]string{"source", "Hacker", "News"}, MarkAllMsg{Scope: "source", Source: "Hacker News"}},
+		{[]string{"older", "2w"}, MarkAllMsg{Scope: "older", Days: 14}},
+		{[]string{"undo"}, MarkAllMsg{Scope: "undo"}},
+	}
+	for _, c := range cases {
+		msg, ok := cmdMarkAll(c.args)().(MarkAllMsg)
+		if !ok {
+			t.Fatalf("Expected MarkAllMsg for %v", c.args)
+		}
+		if msg != c.want {
+			t.Errorf("For %v expected %+v, got %+v", c.args, c.want, msg)
+		}
+	}
+}
+
+// TestMarkAllCommand_RejectsBadInput verifies malformed scopes surface an error.
+// BREAKS: If "older 7" is accepted as zero days, every unread item would be offered.
+func TestMarkAllCommand_RejectsBadInput(t *testing.T) {
+	for _, args := range [][]string{{"source"}, {"older"}, {"older", "7"}, {"older", "0d"}, {"everything"}} {
+		if _, ok := cmdMarkAll(args)().(ErrorMsg); !ok {
+			t.Errorf("Expected ErrorMsg for %v", args)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"testing"
+)
+
+// TestMirrorCommand verifies :mirror defaults to the Wayback Machine and accepts archive.today.
+// BREAKS: If unknown services fall through, :mirror typo opens a broken archive URL.
+func TestMirrorCommand(t *testing.T) {
+	if msg, ok := cmdMirror(nil)().(MirrorMsg); !ok || msg.Service != "wayback" {
+		t.Errorf("Expected the Wayback Machine by default, got %#v", msg)
+	}
+	if msg, ok := cmdMirror([]string{"Today"})().(MirrorMsg); !ok || msg.Service != "today" {
+		t.Errorf("Expected archive.today, got %#v", msg)
+	}
+	if _, ok := cmdMirror([]string{"google"})().(ErrorMsg); !ok {
+		t.Error("Expected an unknown service to be an error")
+	}
+}
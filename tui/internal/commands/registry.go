@@ -2,9 +2,16 @@ package commands
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/filter"
 )
 
 // CommandFunc is a function that executes a command
@@ -28,23 +35,40 @@ func NewRegistry() *Registry {
 	r.Register("add", cmdAdd)
 	r.Register("remove", cmdRemove)
 	r.Register("logs", cmdLogs)
+	r.Register("messages", cmdMessages)
+	r.Register("errors", cmdErrors)
+	r.Register("find", cmdFind)
+	r.Register("set", cmdSet)
+	r.Register("sort", cmdSort)
+	r.Register("search", cmdSearch)
+	r.Register("zen", cmdZen)
+	r.Register("triage", cmdTriage)
 	r.Register("unprioritized", cmdUnprioritized)
 	r.Register("prune", cmdPrune)
 	r.Register("prune!", cmdPruneForce)
 	r.Register("pause", cmdPause)
 	r.Register("resume", cmdResume)
 	r.Register("edit", cmdEdit)
+	r.Register("sources", cmdSources)
 	r.Register("fabric", cmdFabric)
 
 	// Reader-specific commands (actions only, not navigation)
 	r.Register("mark", cmdMark)
+	r.Register("markall", cmdMarkAll)
 	r.Register("favorite", cmdFavorite)
 	// Note: :interesting removed - use :up/:down for feedback
 	r.Register("up", cmdUpvote)
 	r.Register("down", cmdDownvote)
 	r.Register("open", cmdOpen)
+	r.Register("download", cmdDownload)
+	r.Register("mirror", cmdMirror)
 	r.Register("yank", cmdYank)
 	r.Register("copy", cmdCopy)
+	r.Register("task", cmdTask)
+	r.Register("tag", cmdTag)
+	r.Register("snooze", cmdSnooze)
+	r.Register("remind", cmdRemind)
+	r.Register("pin", cmdPin)
 
 	// Theme switching
 	r.Register("theme", cmdTheme)
@@ -52,15 +76,45 @@ func NewRegistry() *Registry {
 	// Audio briefing generation
 	r.Register("audio", cmdAudio)
 
+	// Read the latest audio briefing instead of listening
+	r.Register("transcript", cmdTranscript)
+
+	// Narrate the current article
+	r.Register("listen", cmdListen)
+
+	// Morning digest of today's top items
+	r.Register("digest", cmdDigest)
+
+	// What was read, and when
+	r.Register("history", cmdHistory)
+
 	// On-demand deep extraction for current article
 	r.Register("extract", cmdExtract)
 
+	// On-demand summary for items ingestion left unsummarized
+	r.Register("summarize", cmdSummarize)
+
+	// Chat with the current article
+	r.Register("ask", cmdAsk)
+
 	// Export commands
 	r.Register("export", cmdExport)
+	r.Register("import", cmdImport)
+
+	// Local database backups
+	r.Register("backup", cmdBackup)
+	r.Register("restore", cmdRestore)
+	r.Register("db", cmdDB)
+
+	// Remote sync report
+	r.Register("sync", cmdSync)
 
 	// Archive toggle
 	r.Register("archived", cmdArchived)
 
+	// Source filters
+	r.Register("filter", cmdFilter)
+
 	// Context commands
 	r.Register("context", cmdContext)
 
@@ -167,13 +221,145 @@ func cmdLogs(args []string) tea.Cmd {
 	}
 }
 
+// cmdFind opens the title finder, optionally pre-filled with a query
+func cmdFind(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return FindMsg{Query: strings.Join(args, " ")}
+	}
+}
+
+// cmdSet changes a runtime option, vim-style: "name" turns it on, "noname"
+// off, "name!" toggles, "name=value" sets, "name?" shows it; bare :set lists
+// options. A --save flag also writes the new value to config.toml.
+func cmdSet(args []string) tea.Cmd {
+	return func() tea.Msg {
+		save := false
+		var rest []string
+		for _, arg := range args {
+			if arg == "--save" {
+				save = true
+				continue
+			}
+			rest = append(rest, arg)
+		}
+		if len(rest) == 0 {
+			return SetOptionMsg{}
+		}
+
+		arg := strings.ToLower(strings.Join(rest, " "))
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			return SetOptionMsg{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value), Save: save}
+		}
+		switch {
+		case strings.HasSuffix(arg, "?"):
+			return SetOptionMsg{Name: strings.TrimSuffix(arg, "?")}
+		case strings.HasSuffix(arg, "!"):
+			return SetOptionMsg{Name: strings.TrimSuffix(arg, "!"), Value: "toggle", Save: save}
+		case strings.HasPrefix(arg, "inv"):
+			return SetOptionMsg{Name: strings.TrimPrefix(arg, "inv"), Value: "toggle", Save: save}
+		case strings.HasPrefix(arg, "no"):
+			return SetOptionMsg{Name: strings.TrimPrefix(arg, "no"), Value: "false", Save: save}
+		}
+		return SetOptionMsg{Name: arg, Value: "true", Save: save}
+	}
+}
+
+// cmdSort orders the list by composed keys, e.g. ":sort priority,date desc".
+// No arguments shows the current order; "default" restores date order.
+func cmdSort(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return SortMsg{Spec: strings.Join(args, " ")}
+	}
+}
+
+// cmdSearch manages saved searches: `:search save cves "CVE OR vulnerability"`
+// keeps one, `:search cves` runs it against fresh items, `:search delete cves`
+// forgets it, `:search off` stops the running one, and bare :search lists them.
+// `:search /pattern/` runs a regular expression search without saving it.
+func cmdSearch(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return SearchMsg{}
+		}
+		if strings.HasPrefix(args[0], "/") {
+			expr := strings.Join(args, " ")
+			if _, err := filter.ParseSearch(expr); err != nil {
+				return ErrorMsg{Message: "search: " + err.Error()}
+			}
+			return SearchMsg{Action: "run", Expr: expr}
+		}
+		switch action := strings.ToLower(args[0]); action {
+		case "save":
+			if len(args) < 3 {
+				return ErrorMsg{Message: "search: usage :search save <name> <expression>"}
+			}
+			name := strings.ToLower(args[1])
+			if slices.Contains(searchActions, name) {
+				return ErrorMsg{Message: fmt.Sprintf("search: '%s' is reserved, pick another name", name)}
+			}
+			expr := filter.Join(args[2:])
+			if len(args) == 3 {
+				// Usually the whole expression, quoted
+				expr = args[2]
+			}
+			if _, err := filter.ParseSearch(expr); err != nil {
+				return ErrorMsg{Message: "search: " + err.Error()}
+			}
+			return SearchMsg{Action: "save", Name: name, Expr: expr}
+		case "delete":
+			if len(args) != 2 {
+				return ErrorMsg{Message: "search: usage :search delete <name>"}
+			}
+			return SearchMsg{Action: "delete", Name: strings.ToLower(args[1])}
+		case "off":
+			return SearchMsg{Action: "off"}
+		default:
+			if len(args) > 1 {
+				return ErrorMsg{Message: "search: usage :search <name>, or :search save <name> <expression>"}
+			}
+			return SearchMsg{Action: "run", Name: action}
+		}
+	}
+}
+
+// searchActions are :search's subcommands, which can't name a search
+var searchActions = []string{"save", "delete", "off"}
+
+// cmdZen toggles distraction-free reading
+func cmdZen(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return ZenMsg{}
+	}
+}
+
+// cmdTriage starts a one-item-at-a-time pass over unread items
+func cmdTriage(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return TriageMsg{}
+	}
+}
+
+// cmdMessages shows recent notifications
+func cmdMessages(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return MessagesMsg{}
+	}
+}
+
+// cmdErrors shows failed operations that can be retried
+func cmdErrors(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return ErrorsMsg{}
+	}
+}
+
 // cmdUnprioritized shows count of unprioritized items
 func cmdUnprioritized(args []string) tea.Cmd {
 	return func() tea.Msg {
 		// Parse optional age filter
 		var days *int
 		if len(args) > 0 {
-			parsedDays := parseAge(args[0])
+			parsedDays := ParseAge(args[0])
 			if parsedDays < 0 {
 				return ErrorMsg{Message: fmt.Sprintf("unprioritized: invalid age filter '%s' (use format like 7d, 2w, 1m)", args[0])}
 			}
@@ -193,7 +379,7 @@ func cmdPrune(args []string) tea.Cmd {
 		// Parse optional age filter
 		var days *int
 		if len(args) > 0 {
-			parsedDays := parseAge(args[0])
+			parsedDays := ParseAge(args[0])
 			if parsedDays < 0 {
 				return ErrorMsg{Message: fmt.Sprintf("prune: invalid age filter '%s' (use format like 7d, 2w, 1m)", args[0])}
 			}
@@ -214,7 +400,7 @@ func cmdPruneForce(args []string) tea.Cmd {
 		// Parse optional age filter
 		var days *int
 		if len(args) > 0 {
-			parsedDays := parseAge(args[0])
+			parsedDays := ParseAge(args[0])
 			if parsedDays < 0 {
 				return ErrorMsg{Message: fmt.Sprintf("prune!: invalid age filter '%s' (use format like 7d, 2w, 1m)", args[0])}
 			}
@@ -229,8 +415,8 @@ func cmdPruneForce(args []string) tea.Cmd {
 	}
 }
 
-// parseAge parses age strings like "7d", "2w", "1m" to days
-func parseAge(age string) int {
+// ParseAge parses age strings like "7d", "2w", "1m" to days
+func ParseAge(age string) int {
 	if len(age) < 2 {
 		return -1
 	}
@@ -309,6 +495,41 @@ func cmdMark(args []string) tea.Cmd {
 	}
 }
 
+// cmdMarkAll marks a scope of unread items read after confirmation:
+// the current list (default, or "filter"), "source <name>", or "older 7d".
+// "undo" marks the last batch unread again.
+func cmdMarkAll(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return MarkAllMsg{Scope: "filter"}
+		}
+		switch strings.ToLower(args[0]) {
+		case "filter":
+			if len(args) > 1 {
+				return ErrorMsg{Message: "markall: filter takes no arguments"}
+			}
+			return MarkAllMsg{Scope: "filter"}
+		case "source":
+			if len(args) < 2 {
+				return ErrorMsg{Message: "markall: usage :markall source <name>"}
+			}
+			return MarkAllMsg{Scope: "source", Source: strings.Join(args[1:], " ")}
+		case "older":
+			if len(args) != 2 {
+				return ErrorMsg{Message: "markall: usage :markall older 7d"}
+			}
+			days := ParseAge(args[1])
+			if days <= 0 {
+				return ErrorMsg{Message: fmt.Sprintf("markall: invalid age '%s' (use format like 7d, 2w, 1m)", args[1])}
+			}
+			return MarkAllMsg{Scope: "older", Days: days}
+		case "undo":
+			return MarkAllMsg{Scope: "undo"}
+		}
+		return ErrorMsg{Message: fmt.Sprintf("markall: unknown scope '%s' (use filter, source <name>, older 7d, or undo)", args[0])}
+	}
+}
+
 // cmdFavorite toggles favorite status of current article
 func cmdFavorite(args []string) tea.Cmd {
 	return func() tea.Msg {
@@ -337,6 +558,37 @@ func cmdOpen(args []string) tea.Cmd {
 	}
 }
 
+// cmdDownload saves the current paper's PDF locally
+func cmdDownload(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return DownloadMsg{}
+	}
+}
+
+// cmdTask turns the current article into a task; any arguments replace
+// its title as the task's description
+func cmdTask(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return TaskMsg{Description: strings.Join(args, " ")}
+	}
+}
+
+// cmdMirror opens the current article through the Wayback Machine, or
+// archive.today with "today"
+func cmdMirror(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return MirrorMsg{Service: "wayback"}
+		}
+		switch service := strings.ToLower(args[0]); service {
+		case "wayback", "today":
+			return MirrorMsg{Service: service}
+		default:
+			return ErrorMsg{Message: fmt.Sprintf("mirror: unknown service '%s' (available: wayback, today)", args[0])}
+		}
+	}
+}
+
 // cmdYank copies current article URL to clipboard
 func cmdYank(args []string) tea.Cmd {
 	return func() tea.Msg {
@@ -356,10 +608,100 @@ func cmdCopy(args []string) tea.Cmd {
 	}
 }
 
-// cmdAudio generates audio briefing from HIGH priority content
+// cmdAudio generates an audio briefing, HIGH priority items from the last
+// 24 hours by default: :audio [high|medium|low] [24h|2d] [--voice <v>] [--max <n>]
 func cmdAudio(args []string) tea.Cmd {
 	return func() tea.Msg {
-		return AudioMsg{}
+		return parseAudio(args)
+	}
+}
+
+// audioPriorities maps :audio's priority words to the daemon's floors
+var audioPriorities = map[string]string{
+	"high":   "high",
+	"medium": "medium",
+	"med":    "medium",
+	"low":    "low",
+}
+
+// parseAudio maps :audio arguments onto AudioMsg options
+func parseAudio(args []string) tea.Msg {
+	var msg AudioMsg
+	for i := 0; i < len(args); i++ {
+		arg := strings.ToLower(args[i])
+
+		// --flag value and --flag=value
+		flag, value, hasValue := strings.Cut(arg, "=")
+		if flag == "--voice" || flag == "--max" {
+			if !hasValue {
+				if i+1 >= len(args) {
+					return ErrorMsg{Message: fmt.Sprintf("audio: %s needs a value", flag)}
+				}
+				i++
+				value = args[i]
+			} else {
+				value = args[i][len(flag)+1:] // Keep the voice's case
+			}
+			if flag == "--voice" {
+				msg.Voice = value
+				continue
+			}
+			if _, err := fmt.Sscanf(value, "%d", &msg.MaxItems); err != nil || msg.MaxItems < 1 {
+				return ErrorMsg{Message: fmt.Sprintf("audio: --max must be a positive number, got '%s'", value)}
+			}
+			continue
+		}
+
+		if priority, ok := audioPriorities[arg]; ok {
+			msg.MinPriority = priority
+			continue
+		}
+		if hours := parseWindowHours(arg); hours > 0 {
+			msg.Hours = hours
+			continue
+		}
+		return ErrorMsg{Message: fmt.Sprintf("audio: unknown argument '%s' (use high|medium|low, a window like 24h or 3d, --voice <v>, --max <n>)", args[i])}
+	}
+	return msg
+}
+
+// parseWindowHours parses "12h" as hours, or a ParseAge string ("2d", "1w") as
+// that many days' worth of hours
+func parseWindowHours(window string) int {
+	if numStr, ok := strings.CutSuffix(window, "h"); ok {
+		var hours int
+		if _, err := fmt.Sscanf(numStr, "%d", &hours); err != nil {
+			return -1
+		}
+		return hours
+	}
+	if days := ParseAge(window); days > 0 {
+		return days * 24
+	}
+	return -1
+}
+
+// cmdTranscript shows the script and sources of the latest audio briefing,
+// or of the briefing file named in args
+func cmdTranscript(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return TranscriptMsg{Filename: strings.Join(args, " ")}
+	}
+}
+
+// cmdListen narrates the current article, or controls playback with
+// "pause" (toggle) and "stop"
+func cmdListen(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return ListenMsg{}
+		}
+		switch action := strings.ToLower(args[0]); action {
+		case "pause", "stop":
+			return ListenMsg{Action: action}
+		default:
+			return ErrorMsg{Message: fmt.Sprintf("listen: unknown argument '%s' (available: pause, stop)", args[0])}
+		}
 	}
 }
 
@@ -370,24 +712,350 @@ func cmdExtract(args []string) tea.Cmd {
 	}
 }
 
-// cmdExport handles export commands (currently only sources)
+// cmdSummarize requests a summary for the current article
+func cmdSummarize(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return SummarizeMsg{}
+	}
+}
+
+// cmdAsk asks a question about the current article; with no question it
+// just opens the conversation
+func cmdAsk(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return AskMsg{Question: strings.Join(args, " ")}
+	}
+}
+
+// cmdDigest shows today's HIGH (and optionally MEDIUM) items as one document
+func cmdDigest(args []string) tea.Cmd {
+	return func() tea.Msg {
+		msg := DigestMsg{}
+		for _, arg := range args {
+			switch strings.ToLower(arg) {
+			case "medium", "med":
+				msg.IncludeMedium = true
+			case "export", "md", "markdown":
+				msg.Export = "markdown"
+			case "audio":
+				msg.Export = "audio"
+			default:
+				return ErrorMsg{Message: fmt.Sprintf("digest: unknown argument '%s' (available: medium, export, audio)", arg)}
+			}
+		}
+		return msg
+	}
+}
+
+// cmdHistory shows the reading history, seven days unless a count is given
+func cmdHistory(args []string) tea.Cmd {
+	return func() tea.Msg {
+		msg := HistoryMsg{Days: 7}
+		if len(args) > 0 {
+			days, err := strconv.Atoi(strings.TrimSuffix(args[0], "d"))
+			if err != nil || days < 1 || len(args) > 1 {
+				return ErrorMsg{Message: "history: expected a number of days, e.g. :history 30"}
+			}
+			msg.Days = days
+		}
+		return msg
+	}
+}
+
+// cmdSources runs bulk source operations: "rename /old/new/" applies a
+// regex find/replace to every source name, after a preview; "dedupe" steps
+// through sources that point at the same feed and merges them
+func cmdSources(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 1 && strings.ToLower(args[0]) == "dedupe" {
+			return DedupeSourcesMsg{}
+		}
+		if len(args) < 2 || strings.ToLower(args[0]) != "rename" {
+			return ErrorMsg{Message: "sources: usage :sources rename /pattern/replacement/ or :sources dedupe"}
+		}
+		pattern, replacement, err := parseSubstitution(strings.Join(args[1:], " "))
+		if err != nil {
+			return ErrorMsg{Message: "sources rename: " + err.Error()}
+		}
+		return RenameSourcesMsg{Pattern: pattern, Replacement: replacement}
+	}
+}
+
+// parseSubstitution splits a sed-style /pattern/replacement/ expression. Any
+// punctuation can stand in for the slash, a backslash escapes it, and a
+// trailing i makes the pattern case-insensitive. The pattern must compile.
+func parseSubstitution(expr string) (pattern, replacement string, err error) {
+	delim, size := utf8.DecodeRuneInString(expr)
+	if size == 0 || (!unicode.IsPunct(delim) && !unicode.IsSymbol(delim)) || delim == '\\' {
+		return "", "", fmt.Errorf("expected /pattern/replacement/")
+	}
+
+	var parts []string
+	var part strings.Builder
+	rest := expr[size:]
+	for len(rest) > 0 {
+		r, n := utf8.DecodeRuneInString(rest)
+		rest = rest[n:]
+		switch {
+		case r == '\\' && strings.HasPrefix(rest, string(delim)):
+			part.WriteRune(delim)
+			rest = rest[utf8.RuneLen(delim):]
+		case r == delim:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteRune(r)
+		}
+	}
+	flags := part.String()
+	if len(parts) != 2 || (flags != "" && flags != "i") {
+		return "", "", fmt.Errorf("expected %[1]cpattern%[1]creplacement%[1]c", delim)
+	}
+	if parts[0] == "" {
+		return "", "", fmt.Errorf("empty pattern")
+	}
+
+	pattern = parts[0]
+	if flags == "i" {
+		pattern = "(?i)" + pattern
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", "", fmt.Errorf("invalid pattern: %v", err)
+	}
+	return pattern, parts[1], nil
+}
+
+// cmdTag adds (or, with a leading -, removes) user tags on the current article
+func cmdTag(args []string) tea.Cmd {
+	return func() tea.Msg {
+		msg := TagMsg{}
+		for _, tag := range strings.Split(strings.Join(args, ","), ",") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if remove, ok := strings.CutPrefix(tag, "-"); ok {
+				if remove = strings.TrimSpace(remove); remove != "" {
+					msg.Remove = append(msg.Remove, remove)
+				}
+			} else if tag != "" {
+				msg.Add = append(msg.Add, tag)
+			}
+		}
+		return msg
+	}
+}
+
+// cmdPin toggles whether the current article is pinned to the top
+func cmdPin(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return PinMsg{}
+	}
+}
+
+// snoozeHour is the local hour "tomorrow" and weekday snoozes wake at
+const snoozeHour = 9
+
+// cmdSnooze hides the current article until a time: "3h", "30m", "2d",
+// "1w", "tomorrow", or a weekday like "monday"
+func cmdSnooze(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) != 1 {
+			return ErrorMsg{Message: "snooze: usage :snooze 3h|tomorrow|monday"}
+		}
+		until, err := parseSnooze(args[0], time.Now())
+		if err != nil {
+			return ErrorMsg{Message: "snooze: " + err.Error()}
+		}
+		return SnoozeMsg{Until: until}
+	}
+}
+
+// parseSnooze resolves a :snooze argument to the time the item wakes.
+// Named days wake at snoozeHour local time; a weekday always means the
+// next one, a week out when it is today.
+func parseSnooze(arg string, now time.Time) (time.Time, error) {
+	arg = strings.ToLower(arg)
+	morning := func(days int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, snoozeHour, 0, 0, 0, now.Location())
+	}
+	if arg == "tomorrow" {
+		return morning(1), nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if arg == name || arg == name[:3] {
+			days := (int(day) - int(now.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return morning(days), nil
+		}
+	}
+
+	if len(arg) >= 2 {
+		if n, err := strconv.Atoi(arg[:len(arg)-1]); err == nil && n > 0 {
+			switch arg[len(arg)-1] {
+			case 'm':
+				return now.Add(time.Duration(n) * time.Minute), nil
+			case 'h':
+				return now.Add(time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, n), nil
+			case 'w':
+				return now.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s' (use 30m, 3h, 2d, 1w, tomorrow, or a weekday)", arg)
+}
+
+// cmdRemind makes a calendar reminder about the current article; words
+// after the time become its note
+func cmdRemind(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return ErrorMsg{Message: "remind: usage :remind tomorrow|friday|3d|2026-11-01|2026-11-01T14:00 [note]"}
+		}
+		at, err := parseRemind(args[0], time.Now())
+		if err != nil {
+			return ErrorMsg{Message: "remind: " + err.Error()}
+		}
+		return RemindMsg{At: at, Note: strings.Join(args[1:], " ")}
+	}
+}
+
+// parseRemind resolves a :remind time: a date (at snoozeHour), a date and
+// time, or anything :snooze takes. Dates are local and must be ahead.
+func parseRemind(arg string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02"} {
+		at, err := time.ParseInLocation(layout, arg, now.Location())
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			at = at.Add(snoozeHour * time.Hour)
+		}
+		if !at.After(now) {
+			return time.Time{}, fmt.Errorf("%s is in the past", arg)
+		}
+		return at, nil
+	}
+	at, err := parseSnooze(arg, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s' (use a date like 2026-11-01, 2026-11-01T14:00, 3d, tomorrow, or a weekday)", arg)
+	}
+	return at, nil
+}
+
+// cmdExport handles export commands: sources to the clipboard, or the
+// current list as an HTML page ("html [path]")
 func cmdExport(args []string) tea.Cmd {
 	return func() tea.Msg {
 		// Parse subcommand
 		if len(args) == 0 {
-			return ErrorMsg{Message: "export: subcommand required (sources)"}
+			return ErrorMsg{Message: "export: subcommand required (sources, html)"}
 		}
 
 		subcommand := args[0]
 		switch subcommand {
 		case "sources":
-			return ExportSourcesMsg{}
+			return parseExportSources(args[1:])
+		case "html":
+			return ExportHTMLMsg{Path: strings.Join(args[1:], " ")}
+		default:
+			return ErrorMsg{Message: fmt.Sprintf("export: unknown subcommand '%s' (available: sources, html)", subcommand)}
+		}
+	}
+}
+
+// parseExportSources reads :export sources [path] [--format=markdown|opml|json]
+func parseExportSources(args []string) tea.Msg {
+	var msg ExportSourcesMsg
+	var path []string
+	for _, arg := range args {
+		format, isFlag := strings.CutPrefix(arg, "--format=")
+		if !isFlag {
+			path = append(path, arg)
+			continue
+		}
+		switch format {
+		case "markdown", "md":
+			msg.Format = "markdown"
+		case "opml", "json":
+			msg.Format = format
+		default:
+			return ErrorMsg{Message: fmt.Sprintf("export: unknown format '%s' (available: markdown, opml, json)", format)}
+		}
+	}
+	msg.Path = strings.Join(path, " ")
+	return msg
+}
+
+// cmdImport subscribes to the feeds of another reader
+func cmdImport(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return ErrorMsg{Message: "import: source required (newsboat [path], miniflux)"}
+		}
+
+		switch args[0] {
+		case "newsboat":
+			return ImportSourcesMsg{From: "newsboat", Path: strings.Join(args[1:], " ")}
+		case "miniflux":
+			if len(args) > 1 {
+				return ErrorMsg{Message: "import: miniflux takes no arguments (set [miniflux] url and token in config.toml)"}
+			}
+			return ImportSourcesMsg{From: "miniflux"}
+		default:
+			return ErrorMsg{Message: fmt.Sprintf("import: unknown source '%s' (available: newsboat, miniflux)", args[0])}
+		}
+	}
+}
+
+// cmdBackup backs up the local database
+func cmdBackup(args []string) tea.Cmd {
+	return func() tea.Msg {
+		return BackupMsg{Path: strings.Join(args, " ")}
+	}
+}
+
+// cmdRestore restores the local database from a backup
+func cmdRestore(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return ErrorMsg{Message: "restore: backup path required"}
+		}
+		return RestoreMsg{Path: strings.Join(args, " ")}
+	}
+}
+
+// cmdDB runs local database maintenance
+func cmdDB(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) != 1 {
+			return ErrorMsg{Message: "db: subcommand required (vacuum, check, stats)"}
+		}
+		switch args[0] {
+		case "vacuum", "check", "stats":
+			return DBMaintenanceMsg{Action: args[0]}
 		default:
-			return ErrorMsg{Message: fmt.Sprintf("export: unknown subcommand '%s' (available: sources)", subcommand)}
+			return ErrorMsg{Message: fmt.Sprintf("db: unknown subcommand '%s' (available: vacuum, check, stats)", args[0])}
 		}
 	}
 }
 
+// cmdSync handles :sync status, the remote sync report, and :sync full,
+// which refetches everything from the daemon
+func cmdSync(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			return SyncMsg{Action: "status"}
+		}
+		if len(args) == 1 && (args[0] == "status" || args[0] == "full") {
+			return SyncMsg{Action: args[0]}
+		}
+		return ErrorMsg{Message: fmt.Sprintf("sync: unknown subcommand '%s' (available: status, full)", strings.Join(args, " "))}
+	}
+}
+
 // cmdFabric executes Fabric patterns on current content
 func cmdFabric(args []string) tea.Cmd {
 	return func() tea.Msg {
@@ -420,11 +1088,119 @@ func cmdArchived(args []string) tea.Cmd {
 	}
 }
 
+// cmdFilter sets a source filter: `:filter category=<name>`, `:filter type=<type>`,
+// `:filter author=<name>`, or an arrival window: `:filter since=2d`, `:filter until=2024-01-01`.
+// Anything else is an expression (`:filter priority=high AND source~rust AND NOT read`)
+// that replaces the view's last one. With no arguments (or an empty value) the filter is cleared.
+func cmdFilter(args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 || args[0] == "clear" {
+			return FilterMsg{}
+		}
+
+		// Anything beyond one field=value is an expression, filtered as a whole
+		text := strings.Join(args, " ")
+		expr, exprErr := filter.Parse(filter.Join(args))
+		if exprErr != nil && len(args) == 1 {
+			// The whole expression may have been quoted as one argument
+			if whole, err := filter.Parse(args[0]); err == nil {
+				expr, exprErr = whole, nil
+			}
+		}
+		if exprErr == nil {
+			if _, _, single := expr.Single(); !single {
+				return FilterMsg{Field: "expr", Value: expr.String()}
+			}
+		}
+
+		field, value, ok := strings.Cut(text, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+		if !ok || !slices.Contains(filterFields, field) {
+			if exprErr != nil {
+				return ErrorMsg{Message: "filter: " + exprErr.Error()}
+			}
+			return FilterMsg{Field: "expr", Value: expr.String()}
+		}
+
+		switch field {
+		case "category":
+			return FilterMsg{Field: "category", Value: value}
+		case "source":
+			return FilterMsg{Field: "source", Value: value}
+		case "tag":
+			return FilterMsg{Field: "tag", Value: strings.ToLower(value)}
+		case "author":
+			return FilterMsg{Field: "author", Value: value}
+		case "since", "until":
+			at, err := parseFilterTime(value, time.Now())
+			if err != nil {
+				return ErrorMsg{Message: "filter: " + err.Error()}
+			}
+			return FilterMsg{Field: field, Value: value, Time: at}
+		default: // type
+			value = strings.ToLower(value)
+			if value == "" {
+				value = "all"
+			}
+			switch value {
+			case "all", "rss", "reddit", "youtube", "file":
+				return FilterMsg{Field: "type", Value: value}
+			}
+			return ErrorMsg{Message: fmt.Sprintf("filter: unknown type '%s' (available: all, rss, reddit, youtube, file)", value)}
+		}
+	}
+}
+
+// filterFields are the fields :filter field=value sets on their own, kept
+// apart from expressions; source matches more than a name there
+var filterFields = []string{"category", "source", "tag", "author", "type", "since", "until"}
+
+// parseFilterTime resolves a since/until value: an age back from now (30m,
+// 12h, 2d, 1w), today or yesterday (local midnight), or a date or time
+// (2024-01-01, 2024-01-01T09:00). Empty clears the bound.
+func parseFilterTime(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(value)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value {
+	case "":
+		return time.Time{}, nil
+	case "today":
+		return midnight, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	}
+
+	if len(value) >= 2 {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n > 0 {
+			switch value[len(value)-1] {
+			case 'm':
+				return now.Add(-time.Duration(n) * time.Minute), nil
+			case 'h':
+				return now.Add(-time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02t15:04"} {
+		if parsed, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return parsed, nil
+		}
+	}
+	if parsed, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s' (use 12h, 2d, 1w, today, yesterday, or 2024-01-01)", value)
+}
+
 // cmdContext handles context commands
 func cmdContext(args []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(args) == 0 {
-			return ErrorMsg{Message: "context: subcommand required (review, suggest, edit)"}
+			return ErrorMsg{Message: "context: subcommand required (review, suggest, edit, add)"}
 		}
 
 		switch args[0] {
@@ -434,10 +1210,37 @@ func cmdContext(args []string) tea.Cmd {
 			return ContextSuggestMsg{}
 		case "edit":
 			return ContextEditMsg{}
+		case "edit!":
+			return ContextEditMsg{External: true}
+		case "add":
+			return parseContextAdd(args[1:])
 		default:
-			return ErrorMsg{Message: fmt.Sprintf("context: unknown subcommand '%s' (available: review, suggest, edit)", args[0])}
+			return ErrorMsg{Message: fmt.Sprintf("context: unknown subcommand '%s' (available: review, suggest, edit, edit!, add)", args[0])}
+		}
+	}
+}
+
+// contextSections maps :context add section words to context.md sections
+var contextSections = map[string]string{
+	"high":           "high",
+	"medium":         "medium",
+	"low":            "low",
+	"not-interested": "not_interested",
+	"not_interested": "not_interested",
+}
+
+// parseContextAdd handles ":context add [high|medium|low|not-interested] [topic]".
+// The section defaults to medium; an empty topic means pick one from the
+// current article's entities.
+func parseContextAdd(args []string) tea.Msg {
+	section := "medium"
+	if len(args) > 0 {
+		if s, ok := contextSections[strings.ToLower(args[0])]; ok {
+			section = s
+			args = args[1:]
 		}
 	}
+	return ContextAddMsg{Topic: strings.Join(args, " "), Section: section}
 }
 
 // showError returns a command that shows an error message
@@ -475,7 +1278,70 @@ type RemoveSourceMsg struct {
 // ShowLogsMsg signals to show daemon logs
 type ShowLogsMsg struct{}
 
+// FindMsg signals to open the title finder
+type FindMsg struct {
+	Query string // Initial query (may be empty)
+}
+
+// SetOptionMsg changes a runtime option (empty Name lists them)
+type SetOptionMsg struct {
+	Name  string // Empty lists every option
+	Value string // "true", "false", "toggle", or a raw value from name=value; empty shows Name
+	Save  bool   // Also write the new value to config.toml
+}
+
+// SortMsg changes the current view's sort order (empty Spec shows it)
+type SortMsg struct {
+	Spec string // Comma-separated keys, each optionally followed by asc or desc
+}
+
+// SearchMsg saves, deletes, runs, or lists saved searches
+type SearchMsg struct {
+	Action string // "save", "delete", "run", "off" (stop the running one), or "" to list
+	Name   string // Lowercase search name; empty runs Expr unsaved
+	Expr   string // The search expression, as typed (save, or an unsaved run)
+}
+
+// MessagesMsg signals to show recent notifications
+type MessagesMsg struct{}
+
+// ErrorsMsg signals to show the failed operations panel
+type ErrorsMsg struct{}
+
+// TagMsg signals to change the current article's user tags (both empty shows them)
+type TagMsg struct {
+	Add    []string
+	Remove []string
+}
+
+// PinMsg signals to toggle whether the current article is pinned
+type PinMsg struct{}
+
+// SnoozeMsg signals to hide the current article until Until
+type SnoozeMsg struct {
+	Until time.Time
+}
+
+// RemindMsg signals to make a calendar reminder about the current article
+type RemindMsg struct {
+	At   time.Time
+	Note string // Shown before the title, e.g. "CFP closes"
+}
+
+// ZenMsg signals to toggle distraction-free reading
+type ZenMsg struct{}
+
+// TriageMsg signals to start a triage session over unread items
+type TriageMsg struct{}
+
 // CleanupMsg signals to cleanup unprioritized content
+// MarkAllMsg signals to mark a scope of unread items read in one batch
+type MarkAllMsg struct {
+	Scope  string // "filter", "source", "older", or "undo"
+	Source string // Source name for the "source" scope
+	Days   int    // Age cutoff for the "older" scope
+}
+
 // PruneMsg signals to prune unprioritized content
 type PruneMsg struct {
 	Days      *int // Optional age filter in days
@@ -499,6 +1365,16 @@ type EditSourceMsg struct {
 	NewName    string
 }
 
+// RenameSourcesMsg signals to preview renaming every source whose name
+// matches Pattern (a regexp), with Replacement expanded like regexp's $1
+type RenameSourcesMsg struct {
+	Pattern     string
+	Replacement string
+}
+
+// DedupeSourcesMsg signals to find duplicate sources and offer to merge them
+type DedupeSourcesMsg struct{}
+
 // Reader command messages
 
 // MarkMsg signals to toggle read/unread status
@@ -513,9 +1389,22 @@ type UpvoteMsg struct{}
 // DownvoteMsg signals to downvote the current article
 type DownvoteMsg struct{}
 
-// OpenMsg signals to open URL in browser
+// OpenMsg signals to open URL in browser (papers in the PDF handler)
 type OpenMsg struct{}
 
+// DownloadMsg signals to save the current paper's PDF
+type DownloadMsg struct{}
+
+// TaskMsg signals to create a task from the current article
+type TaskMsg struct {
+	Description string // Task text; empty uses the article's title
+}
+
+// MirrorMsg signals to open an archived copy of the current article
+type MirrorMsg struct {
+	Service string // "wayback" (archive.org) or "today" (archive.today)
+}
+
 // YankMsg signals to copy URL to clipboard
 type YankMsg struct{}
 
@@ -524,12 +1413,47 @@ type CopyMsg struct {
 	Target string // "summary" (default) or "content"
 }
 
-// AudioMsg signals to generate an audio briefing
-type AudioMsg struct{}
+// AudioMsg signals to generate an audio briefing; zero fields keep the
+// daemon's defaults
+type AudioMsg struct {
+	MinPriority string // "high", "medium", or "low"
+	Hours       int    // Look-back window
+	Voice       string // TTS voice override
+	MaxItems    int    // Cap on items covered
+}
+
+// TranscriptMsg signals to show an audio briefing's transcript
+type TranscriptMsg struct {
+	Filename string // Briefing MP3 name; empty means the most recent
+}
+
+// ListenMsg signals to narrate the current article or control playback
+type ListenMsg struct {
+	Action string // "" plays the current article, "pause" toggles, "stop" ends
+}
+
+// DigestMsg signals to build the daily digest
+type DigestMsg struct {
+	IncludeMedium bool   // Add MEDIUM items after HIGH
+	Export        string // "" to view, "markdown" to save a file, "audio" for a briefing
+}
+
+// HistoryMsg signals to show what was read in the last Days days
+type HistoryMsg struct {
+	Days int
+}
 
 // ExtractMsg signals to trigger on-demand deep extraction for the current article
 type ExtractMsg struct{}
 
+// SummarizeMsg signals to summarize the current article
+type SummarizeMsg struct{}
+
+// AskMsg asks a question about the current article
+type AskMsg struct {
+	Question string // Empty opens the conversation without asking
+}
+
 // FabricMsg signals to execute a Fabric pattern
 type FabricMsg struct {
 	Pattern  string // Pattern name to execute, or "--list" for pattern list
@@ -540,13 +1464,68 @@ type FabricMsg struct {
 // ThemeMsg signals to cycle to the next theme
 type ThemeMsg struct{}
 
-// ExportSourcesMsg signals to export sources to clipboard
-type ExportSourcesMsg struct{}
+// ExportSourcesMsg signals to export sources to a file, or the clipboard without a path
+type ExportSourcesMsg struct {
+	Path   string
+	Format string // "markdown", "opml", or "json"; empty picks by the file extension
+}
+
+// ImportSourcesMsg signals to add the feeds of another reader as sources
+type ImportSourcesMsg struct {
+	From string // "newsboat" or "miniflux"
+	Path string // newsboat urls file; empty uses newsboat's own
+}
+
+// BackupMsg signals to back up the local database
+type BackupMsg struct {
+	Path string // File or directory; empty uses the backups directory next to the database
+}
+
+// RestoreMsg signals to restore the local database from a backup, after confirmation
+type RestoreMsg struct {
+	Path string
+}
+
+// DBMaintenanceMsg signals to vacuum, check, or measure the local database
+type DBMaintenanceMsg struct {
+	Action string // "vacuum", "check", or "stats"
+}
+
+// SyncMsg signals to show the last remote sync and its conflicts, or to
+// throw away the cache and fetch everything again
+type SyncMsg struct {
+	Action string // "status" or "full"
+}
+
+// ExportHTMLMsg signals to write the current list as an HTML page
+type ExportHTMLMsg struct {
+	Path string // Empty writes to the reports directory
+}
 
 // ArchivedMsg signals to toggle archived view
 type ArchivedMsg struct{}
 
+// FilterMsg signals to set or clear a source filter or arrival window
+type FilterMsg struct {
+	Field string    // "category", "source", "tag", "author", "type", "since", "until", "expr", or "" to clear all filters
+	Value string    // Empty clears that filter; source matches name, URL, or ID; author any part of the byline; expr is canonical
+	Time  time.Time // The since/until bound; zero clears it
+}
+
 // ContextReviewMsg signals to review flagged items
 type ContextReviewMsg struct{}
 type ContextSuggestMsg struct{}
-type ContextEditMsg struct{}
+
+// ContextAddMsg adds a topic to a context.md section ("high", "medium",
+// "low", or "not_interested"). An empty Topic asks to pick an entity from
+// the current article.
+type ContextAddMsg struct {
+	Topic   string
+	Section string
+}
+
+// ContextEditMsg opens context.md in the built-in editor, or in $EDITOR
+// when External is set (:context edit!)
+type ContextEditMsg struct {
+	External bool
+}
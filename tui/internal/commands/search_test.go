@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSearchCommand_Save verifies :search save keeps the quoted expression as typed and rejects bad ones.
+// BREAKS: If the expression is re-split or unchecked, a saved search silently matches nothing when run.
+func TestSearchCommand_Save(t *testing.T) {
+	msg := cmdSearch([]string{"save", "CVEs", "CVE OR vulnerability"})()
+	search, ok := msg.(SearchMsg)
+	if !ok {
+		t.Fatalf("Expected SearchMsg, got %T (%v)", msg, msg)
+	}
+	if search.Action != "save" || search.Name != "cves" || search.Expr != "CVE OR vulnerability" {
+		t.Errorf("Expected cves saved as typed, got %+v", search)
+	}
+
+	// Unquoted words are joined back into one expression
+	if search, _ := cmdSearch([]string{"save", "rust", "source~rust", "NOT", "read"})().(SearchMsg); search.Expr != "source~rust NOT read" {
+		t.Errorf("Expected the words joined, got %+v", search)
+	}
+
+	for _, args := range [][]string{{"save", "cves"}, {"save", "off", "cve"}, {"save", "bad", "length>lots"}, {"cves", "extra"}} {
+		if errMsg, ok := cmdSearch(args)().(ErrorMsg); !ok || !strings.HasPrefix(errMsg.Message, "search:") {
+			t.Errorf("Expected an error for %v, got %v", args, errMsg)
+		}
+	}
+}
+
+// TestSearchCommand_RunDeleteOffList verifies the other :search forms.
+// BREAKS: If a name is read as a subcommand (or the reverse), key bindings like ":search cves" do the wrong thing.
+func TestSearchCommand_RunDeleteOffList(t *testing.T) {
+	tests := []struct {
+		args []string
+		want SearchMsg
+	}{
+		{[]string{"CVEs"}, SearchMsg{Action: "run", Name: "cves"}},
+		{[]string{"delete", "cves"}, SearchMsg{Action: "delete", Name: "cves"}},
+		{[]string{"off"}, SearchMsg{Action: "off"}},
+		{nil, SearchMsg{}},
+	}
+	for _, tt := range tests {
+		if got := cmdSearch(tt.args)(); got != tt.want {
+			t.Errorf("cmdSearch(%v) = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+}
+
+// TestSearchCommand_Regex verifies :search /pattern/ runs unsaved and bad patterns come back as command-line errors.
+// BREAKS: If the pattern isn't compiled up front, a typo in a regex silently empties the list.
+func TestSearchCommand_Regex(t *testing.T) {
+	if got := cmdSearch([]string{`/cve-\d+`, `exploit/i`})(); got != (SearchMsg{Action: "run", Expr: `/cve-\d+ exploit/i`}) {
+		t.Errorf("Expected an unsaved regex run, got %+v", got)
+	}
+
+	errMsg, ok := cmdSearch([]string{"/cve-(/"})().(ErrorMsg)
+	if !ok || !strings.Contains(errMsg.Message, "invalid regex /cve-(/") {
+		t.Errorf("Expected the compile error, got %v", errMsg)
+	}
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSetCommand_VimForms verifies :set parses the vim on/off/toggle/value forms.
+// BREAKS: If "nopreview" isn't recognized, there's no way to turn an option off.
+func TestSetCommand_VimForms(t *testing.T) {
+	cases := map[string]SetOptionMsg{
+		"preview":    {Name: "preview", Value: "true"},
+		"nopreview":  {Name: "preview", Value: "false"},
+		"preview!":   {Name: "preview", Value: "toggle"},
+		"invpreview": {Name: "preview", Value: "toggle"},
+		"preview=on": {Name: "preview", Value: "on"},
+	}
+	for arg, want := range cases {
+		got, ok := cmdSet([]string{arg})().(SetOptionMsg)
+		if !ok || got != want {
+			t.Errorf("%s: expected %+v, got %+v", arg, want, got)
+		}
+	}
+
+	if got := cmdSet(nil)().(SetOptionMsg); got.Name != "" {
+		t.Errorf("Expected bare :set to list options, got %+v", got)
+	}
+}
+
+// TestSetCommand_SaveAndQuery verifies --save is split off and "name?" asks for the value.
+// BREAKS: If --save stays in the argument, ":set refresh=120 --save" sets refresh to "120 --save".
+func TestSetCommand_SaveAndQuery(t *testing.T) {
+	cases := map[string]SetOptionMsg{
+		"refresh=120 --save": {Name: "refresh", Value: "120", Save: true},
+		"--save nowrap":      {Name: "wrap", Value: "false", Save: true},
+		"refresh?":           {Name: "refresh"},
+		"--save":             {},
+	}
+	for arg, want := range cases {
+		got, ok := cmdSet(strings.Fields(arg))().(SetOptionMsg)
+		if !ok || got != want {
+			t.Errorf("%s: expected %+v, got %+v", arg, want, got)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseSnooze_ResolvesWakeTimes verifies durations, tomorrow, and weekdays resolve against now.
+// BREAKS: If "monday" on a Monday means today, the item reappears before the user has even left it.
+func TestParseSnooze_ResolvesWakeTimes(t *testing.T) {
+	now := time.Date(2026, 5, 18, 14, 30, 0, 0, time.UTC) // A Monday
+	cases := []struct {
+		arg  string
+		want time.Time
+	}{
+		{"3h", now.Add(3 * time.Hour)},
+		{"45m", now.Add(45 * time.Minute)},
+		{"2d", now.AddDate(0, 0, 2)},
+		{"1w", now.AddDate(0, 0, 7)},
+		{"tomorrow", time.Date(2026, 5, 19, 9, 0, 0, 0, time.UTC)},
+		{"Friday", time.Date(2026, 5, 22, 9, 0, 0, 0, time.UTC)},
+		{"sun", time.Date(2026, 5, 24, 9, 0, 0, 0, time.UTC)},
+		{"monday", time.Date(2026, 5, 25, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, err := parseSnooze(c.arg, now)
+		if err != nil || !got.Equal(c.want) {
+			t.Errorf("For %q expected %v, got %v (%v)", c.arg, c.want, got, err)
+		}
+	}
+}
+
+// TestSnoozeCommand_RejectsBadInput verifies malformed times surface an error instead of snoozing.
+// BREAKS: If "0h" or "soon" is accepted, the item is hidden with a wake time in the past or never.
+func TestSnoozeCommand_RejectsBadInput(t *testing.T) {
+	for _, args := range [][]string{nil, {"3"}, {"0h"}, {"-2d"}, {"soon"}, {"3h", "tomorrow"}} {
+		if _, ok := cmdSnooze(args)().(ErrorMsg); !ok {
+			t.Errorf("Expected ErrorMsg for %v", args)
+		}
+	}
+	if _, ok := cmdSnooze([]string{"3h"})().(SnoozeMsg); !ok {
+		t.Error("Expected SnoozeMsg for 3h")
+	}
+}
+
+// TestParseRemind_DatesAndSnoozeForms verifies :remind takes dates, date-times, and the :snooze forms, but not the past.
+// BREAKS: If a CFP deadline typed as a date is read as UTC or accepted in the past, the alarm fires at the wrong time or never.
+func TestParseRemind_DatesAndSnoozeForms(t *testing.T) {
+	loc := time.FixedZone("PDT", -7*3600)
+	now := time.Date(2026, 5, 18, 14, 30, 0, 0, loc)
+	cases := []struct {
+		arg  string
+		want time.Time
+	}{
+		{"2026-06-01", time.Date(2026, 6, 1, 9, 0, 0, 0, loc)},
+		{"2026-05-18T17:00", time.Date(2026, 5, 18, 17, 0, 0, 0, loc)},
+		{"3d", now.AddDate(0, 0, 3)},
+		{"friday", time.Date(2026, 5, 22, 9, 0, 0, 0, loc)},
+	}
+	for _, c := range cases {
+		got, err := parseRemind(c.arg, now)
+		if err != nil || !got.Equal(c.want) {
+			t.Errorf("For %q expected %v, got %v (%v)", c.arg, c.want, got, err)
+		}
+	}
+	for _, arg := range []string{"2026-05-18", "2026-05-01T10:00", "someday", "2026-13-01"} {
+		if _, err := parseRemind(arg, now); err == nil {
+			t.Errorf("Expected %q refused", arg)
+		}
+	}
+	if msg, ok := cmdRemind([]string{"2026-11-01", "CFP", "closes"})().(RemindMsg); !ok || msg.Note != "CFP closes" {
+		t.Errorf("Expected the words after the date as the note, got %#v", msg)
+	}
+}
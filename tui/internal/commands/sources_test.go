@@ -0,0 +1,32 @@
+package commands
+
+import "testing"
+
+// TestSourcesRename_ParsesSubstitution verifies sed-style expressions split into pattern and replacement.
+// BREAKS: If escapes or other delimiters aren't handled, renames containing slashes can't be written.
+func TestSourcesRename_ParsesSubstitution(t *testing.T) {
+	tests := []struct {
+		args []string
+		want RenameSourcesMsg
+	}{
+		{[]string{"rename", "/", "–", "RSS$//"}, RenameSourcesMsg{Pattern: " – RSS$", Replacement: ""}},
+		{[]string{"rename", "/^(.*)", "Blog$/$1/"}, RenameSourcesMsg{Pattern: "^(.*) Blog$", Replacement: "$1"}},
+		{[]string{"rename", "|a/b|c\\|d|"}, RenameSourcesMsg{Pattern: "a/b", Replacement: "c|d"}},
+		{[]string{"rename", "/feed/Feed/i"}, RenameSourcesMsg{Pattern: "(?i)feed", Replacement: "Feed"}},
+	}
+	for _, tt := range tests {
+		if got := cmdSources(tt.args)(); got != tt.want {
+			t.Errorf("sources %v: expected %+v, got %+v", tt.args, tt.want, got)
+		}
+	}
+
+	if _, ok := cmdSources([]string{"dedupe"})().(DedupeSourcesMsg); !ok {
+		t.Error("Expected :sources dedupe to ask for the dedupe flow")
+	}
+
+	for _, args := range [][]string{{"rename"}, {"dedupe", "now"}, {"rename", "old/new/"}, {"rename", "/a/b"}, {"rename", "/(/x/"}, {"rename", "//x/"}, {"rename", "/a/b/g"}, {"list"}} {
+		if _, ok := cmdSources(args)().(ErrorMsg); !ok {
+			t.Errorf("sources %v: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTagCommand_ParsesAddsAndRemovals verifies :tag splits on commas and spaces and "-" removes.
+// BREAKS: If "rust,career" is stored as one tag, :filter tag=rust never matches it.
+func TestTagCommand_ParsesAddsAndRemovals(t *testing.T) {
+	msg, ok := cmdTag([]string{"Rust,career", "-ai"})().(TagMsg)
+	if !ok {
+		t.Fatalf("Expected TagMsg")
+	}
+	if !reflect.DeepEqual(msg.Add, []string{"rust", "career"}) || !reflect.DeepEqual(msg.Remove, []string{"ai"}) {
+		t.Errorf("Expected add [rust career] remove [ai], got %+v", msg)
+	}
+
+	if msg := cmdTag(nil)().(TagMsg); msg.Add != nil || msg.Remove != nil {
+		t.Errorf("Expected bare :tag to only show tags, got %+v", msg)
+	}
+
+	if filter, ok := cmdFilter([]string{"tag=Rust"})().(FilterMsg); !ok || filter.Field != "tag" || filter.Value != "rust" {
+		t.Errorf("Expected :filter tag=Rust to filter tag 'rust', got %+v", filter)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -15,39 +16,80 @@ type Config struct {
 		Key string `toml:"key"`
 	} `toml:"api"`
 	TUI struct {
-		RefreshInterval int `toml:"refresh_interval"` // Auto-refresh interval in seconds, 0 disables
+		RefreshInterval  int      `toml:"refresh_interval"`  // Auto-refresh interval in seconds, 0 disables
+		PDFHandler       string   `toml:"pdf_handler"`       // Command that opens papers, e.g. "zathura"; empty uses the browser
+		DownloadDir      string   `toml:"download_dir"`      // Where :download saves PDFs, default ~/Downloads
+		CheckLinks       bool     `toml:"check_links"`       // HEAD-check unread links in the background and flag dead/paywalled ones
+		PaywallDomains   []string `toml:"paywall_domains"`   // Extra domains to flag as paywalled
+		RowFormat        string   `toml:"row_format"`        // List row template, e.g. "{icon} {title:60} {source:15} {age:>5}"
+		RememberScroll   bool     `toml:"remember_scroll"`   // Keep reader scroll positions across restarts
+		MarkRead         string   `toml:"mark_read"`         // When the reader marks items read: never (default), open, dwell, end
+		MarkReadDelay    int      `toml:"mark_read_delay"`   // Seconds in the reader before "dwell" marks read, default 10
+		ClipboardCommand string   `toml:"clipboard_command"` // Command that reads text to copy on stdin, e.g. "wl-copy"; overrides detection
+		Hyperlinks       *bool    `toml:"hyperlinks"`        // OSC 8 links on titles and URLs, default true
+		Wrap             *bool    `toml:"wrap"`              // Wrap reader text to the pane, default true
+		ShowAll          bool     `toml:"show_all"`          // Start with read items listed too
+		Goal             string   `toml:"goal"`              // Daily reading goal: "high" (clear unread HIGH) or an article count like "10"
+		Colors           string   `toml:"colors"`            // Color depth: auto (default, from COLORTERM/TERM), truecolor, 256 or 16
+		Background       string   `toml:"background"`        // Terminal background: auto (default, asks the terminal), light or dark
+		ReminderDir      string   `toml:"reminder_dir"`      // Where :remind saves .ics files; empty opens each in the calendar app
 	} `toml:"tui"`
 	Reports *struct {
 		OutputPath string `toml:"output_path"` // Directory to save reports, required
 	} `toml:"reports"`
 	Remote *struct {
-		URL string `toml:"url"` // Remote daemon URL (e.g., https://prismis.example.com)
-		Key string `toml:"key"` // API key for remote daemon
+		URL          string `toml:"url"`           // Remote daemon URL (e.g., https://prismis.example.com)
+		Key          string `toml:"key"`           // API key for remote daemon
+		OfflineCache bool   `toml:"offline_cache"` // Keep unread HIGH/MEDIUM items on disk for offline reading
 	} `toml:"remote"`
+	Remotes map[string]struct {
+		URL string `toml:"url"` // Daemon URL saved by --remote discovery or by hand
+	} `toml:"remotes"` // Named daemons for --remote <name>; the key is [remote].key
+	Miniflux *struct {
+		URL   string `toml:"url"`   // Miniflux instance for :import miniflux, e.g. https://reader.example.com
+		Token string `toml:"token"` // Miniflux API token
+	} `toml:"miniflux"`
+	Refresh *struct {
+		Focused *int `toml:"focused"` // Auto-refresh interval in seconds while the terminal has focus, default [tui] refresh_interval
+		Blurred *int `toml:"blurred"` // Interval while it doesn't, default 0: paused until focus returns
+	} `toml:"refresh"`
+	Task   *Task             `toml:"task"`
+	Keys   map[string]string `toml:"keys"`   // Key overrides: a key it acts as ("ctrl+j" = "j") or a command (x = ":mark")
+	Colors map[string]string `toml:"colors"` // Theme color overrides by name (high = "#FF0000", favorite = "205"), applied to every theme
 }
 
-// LoadConfig loads configuration from the standard XDG config path with sensible defaults
-func LoadConfig() (*Config, error) {
-	// Get config directory using XDG_CONFIG_HOME or fallback
+// Task configures where :task sends the current item
+type Task struct {
+	Backend  string `toml:"backend"`   // taskwarrior, todotxt, or webhook
+	File     string `toml:"file"`      // todo.txt file for todotxt, default ~/todo.txt
+	URL      string `toml:"url"`       // Endpoint the webhook backend POSTs each task to as JSON
+	Project  string `toml:"project"`   // Taskwarrior project, or todo.txt +project
+	MarkRead bool   `toml:"mark_read"` // Mark the item read once its task is created
+}
+
+// configFilePath returns config.toml under XDG_CONFIG_HOME or ~/.config
+func configFilePath() (string, error) {
 	configDir := os.Getenv("XDG_CONFIG_HOME")
 	if configDir == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
+			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
 		configDir = filepath.Join(home, ".config")
 	}
+	return filepath.Join(configDir, "prismis", "config.toml"), nil
+}
 
-	configPath := filepath.Join(configDir, "prismis", "config.toml")
+// LoadConfig loads configuration from the standard XDG config path with sensible defaults
+func LoadConfig() (*Config, error) {
+	configPath, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize config with defaults
-	config := &Config{
-		TUI: struct {
-			RefreshInterval int `toml:"refresh_interval"`
-		}{
-			RefreshInterval: 60, // Default to 60 seconds
-		},
-	}
+	config := &Config{}
+	config.TUI.RefreshInterval = 60 // Default to 60 seconds
 
 	// Read config file if it exists
 	if _, err := os.Stat(configPath); err == nil {
@@ -66,11 +108,79 @@ func LoadConfig() (*Config, error) {
 }
 
 // GetRefreshInterval returns the configured refresh interval in seconds
-// Returns 0 if auto-refresh is disabled
+// while the terminal has focus. Returns 0 if auto-refresh is disabled
 func (c *Config) GetRefreshInterval() int {
+	if c.Refresh != nil && c.Refresh.Focused != nil {
+		return *c.Refresh.Focused
+	}
 	return c.TUI.RefreshInterval
 }
 
+// GetBlurredRefreshInterval returns the refresh interval in seconds while
+// the terminal doesn't have focus. Defaults to 0, pausing auto-refresh
+// until focus returns
+func (c *Config) GetBlurredRefreshInterval() int {
+	if c.Refresh != nil && c.Refresh.Blurred != nil {
+		return *c.Refresh.Blurred
+	}
+	return 0
+}
+
+// GetPDFHandler returns the configured PDF viewer command split into its
+// program and arguments; empty when papers should open in the browser
+func (c *Config) GetPDFHandler() []string {
+	return strings.Fields(c.TUI.PDFHandler)
+}
+
+// GetClipboardCommand returns the configured copy command split into its
+// program and arguments; empty when the platform's command is detected
+func (c *Config) GetClipboardCommand() []string {
+	return strings.Fields(c.TUI.ClipboardCommand)
+}
+
+// HyperlinksEnabled returns whether titles and URLs are emitted as
+// clickable terminal hyperlinks. Defaults to true.
+func (c *Config) HyperlinksEnabled() bool {
+	return c.TUI.Hyperlinks == nil || *c.TUI.Hyperlinks
+}
+
+// WrapEnabled returns whether the reader wraps text to the pane width.
+// Defaults to true.
+func (c *Config) WrapEnabled() bool {
+	return c.TUI.Wrap == nil || *c.TUI.Wrap
+}
+
+// GetDownloadDir returns where downloaded PDFs are saved, expanding ~ to the
+// home directory. Defaults to ~/Downloads.
+func (c *Config) GetDownloadDir() (string, error) {
+	dir := c.TUI.DownloadDir
+	if dir == "" {
+		dir = "~/Downloads"
+	}
+	if strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory for download dir: %w", err)
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+	return dir, nil
+}
+
+// GetReminderDir returns where :remind saves calendar files, expanding ~ to
+// the home directory; empty when they're opened in the calendar app instead
+func (c *Config) GetReminderDir() (string, error) {
+	dir := c.TUI.ReminderDir
+	if strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory for reminder dir: %w", err)
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+	return dir, nil
+}
+
 // ValidateReports validates that reports configuration is present and valid
 func (c *Config) ValidateReports() error {
 	if c.Reports == nil {
@@ -104,6 +214,44 @@ func (c *Config) GetReportsOutputPath() (string, error) {
 	return outputPath, nil
 }
 
+// GetMiniflux returns the Miniflux instance and API token for :import miniflux
+func (c *Config) GetMiniflux() (string, string, error) {
+	if c.Miniflux == nil || c.Miniflux.URL == "" || c.Miniflux.Token == "" {
+		return "", "", fmt.Errorf("miniflux not configured. Add url and token to a [miniflux] section in config.toml")
+	}
+	return c.Miniflux.URL, c.Miniflux.Token, nil
+}
+
+// GetTask returns the :task backend settings, checked for what the chosen
+// backend needs and with ~ expanded in the todo.txt path
+func (c *Config) GetTask() (Task, error) {
+	if c.Task == nil || c.Task.Backend == "" {
+		return Task{}, fmt.Errorf("task backend not configured. Add a [task] section to config.toml with backend = \"taskwarrior\", \"todotxt\", or \"webhook\"")
+	}
+	task := *c.Task
+	switch task.Backend {
+	case "taskwarrior":
+	case "todotxt":
+		if task.File == "" {
+			task.File = "~/todo.txt"
+		}
+		if strings.HasPrefix(task.File, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return Task{}, fmt.Errorf("failed to get home directory for task file: %w", err)
+			}
+			task.File = filepath.Join(home, task.File[2:])
+		}
+	case "webhook":
+		if task.URL == "" {
+			return Task{}, fmt.Errorf("task.url not configured. The webhook backend needs url in the [task] section")
+		}
+	default:
+		return Task{}, fmt.Errorf("unknown task backend '%s': expected taskwarrior, todotxt, or webhook", task.Backend)
+	}
+	return task, nil
+}
+
 // HasRemoteConfig returns true if [remote] section is configured with a URL
 func (c *Config) HasRemoteConfig() bool {
 	return c.Remote != nil && c.Remote.URL != ""
@@ -117,6 +265,12 @@ func (c *Config) GetRemoteURL() string {
 	return ""
 }
 
+// OfflineCacheEnabled returns true if remote mode should keep unread
+// HIGH/MEDIUM items (and their images) on disk for reading offline
+func (c *Config) OfflineCacheEnabled() bool {
+	return c.Remote != nil && c.Remote.OfflineCache
+}
+
 // GetRemoteKey returns the remote API key if configured
 func (c *Config) GetRemoteKey() string {
 	if c.Remote != nil {
@@ -124,3 +278,105 @@ func (c *Config) GetRemoteKey() string {
 	}
 	return ""
 }
+
+// GetRemoteProfile returns the URL of the [remotes.<name>] profile
+func (c *Config) GetRemoteProfile(name string) (string, bool) {
+	profile, ok := c.Remotes[ProfileName(name)]
+	if !ok || profile.URL == "" {
+		return "", false
+	}
+	return profile.URL, true
+}
+
+// ProfileName turns a daemon or host name into a bare TOML key, so it can
+// name a [remotes.<name>] section: lowercase, other characters as dashes
+func ProfileName(name string) string {
+	name = strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "."), ".local")
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+// SaveRemoteProfile writes url as the [remotes.<name>] profile
+func SaveRemoteProfile(name, url string) error {
+	return setOption("remotes."+ProfileName(name), "url", url)
+}
+
+// SetTUIOption writes key = value into the [tui] section of config.toml,
+// replacing an existing setting or adding one. The rest of the file,
+// comments included, is left as it was. value is a bool, int, or string.
+func SetTUIOption(key string, value interface{}) error {
+	return setOption("tui", key, value)
+}
+
+// setOption writes key = value into the named section of config.toml
+func setOption(name, key string, value interface{}) error {
+	var literal string
+	switch v := value.(type) {
+	case bool, int:
+		literal = fmt.Sprint(v)
+	case string:
+		literal = strconv.Quote(v)
+	default:
+		return fmt.Errorf("unsupported value type %T for %s", value, key)
+	}
+
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	setting := key + " = " + literal
+	header := "[" + name + "]"
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	section, insertAt, replaced := "", -1, false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			section = strings.TrimSpace(strings.SplitN(trimmed, "#", 2)[0])
+			if section == header {
+				insertAt = i + 1
+			}
+			continue
+		}
+		if section != header {
+			continue
+		}
+		if name, _, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(name) == key {
+			lines[i] = setting
+			replaced = true
+			break
+		}
+		if trimmed != "" {
+			insertAt = i + 1 // After the section's last line
+		}
+	}
+	switch {
+	case replaced:
+	case insertAt >= 0:
+		lines = append(lines[:insertAt], append([]string{setting}, lines[insertAt:]...)...)
+	default:
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, header, setting)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
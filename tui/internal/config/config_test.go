@@ -116,13 +116,8 @@ key = "partial-key"
 }
 
 func TestGetRefreshInterval(t *testing.T) {
-	config := &Config{
-		TUI: struct {
-			RefreshInterval int `toml:"refresh_interval"`
-		}{
-			RefreshInterval: 90,
-		},
-	}
+	config := &Config{}
+	config.TUI.RefreshInterval = 90
 
 	interval := config.GetRefreshInterval()
 	if interval != 90 {
@@ -130,6 +125,38 @@ func TestGetRefreshInterval(t *testing.T) {
 	}
 }
 
+// TestLoadConfig_RefreshSection verifies [refresh] sets the focused and blurred intervals, each falling back sensibly.
+// BREAKS: If blurred doesn't default to paused, idle tmux panes keep hitting the daemon.
+func TestLoadConfig_RefreshSection(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "prismis", "config.toml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		content          string
+		focused, blurred int
+	}{
+		{"[tui]\nrefresh_interval = 90\n", 90, 0},
+		{"[tui]\nrefresh_interval = 90\n[refresh]\nblurred = 300\n", 90, 300},
+		{"[tui]\nrefresh_interval = 90\n[refresh]\nfocused = 30\n", 30, 0},
+		{"[refresh]\nfocused = 30\nblurred = 600\n", 30, 600},
+	} {
+		if err := os.WriteFile(configPath, []byte(tc.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		config, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("LoadConfig() failed: %v", err)
+		}
+		if got, got2 := config.GetRefreshInterval(), config.GetBlurredRefreshInterval(); got != tc.focused || got2 != tc.blurred {
+			t.Errorf("%q: expected focused %d, blurred %d; got %d, %d", tc.content, tc.focused, tc.blurred, got, got2)
+		}
+	}
+}
+
 func TestLoadConfig_DisabledRefresh(t *testing.T) {
 	// Test with refresh_interval = 0 (disabled)
 	oldXDG := os.Getenv("XDG_CONFIG_HOME")
@@ -163,3 +190,124 @@ refresh_interval = 0
 		t.Errorf("Expected refresh interval 0 (disabled), got %d", config.TUI.RefreshInterval)
 	}
 }
+
+func TestPDFSettings(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	config := &Config{}
+	if len(config.GetPDFHandler()) != 0 {
+		t.Errorf("Expected no PDF handler by default, got %v", config.GetPDFHandler())
+	}
+	if dir, err := config.GetDownloadDir(); err != nil || dir != filepath.Join(home, "Downloads") {
+		t.Errorf("Expected ~/Downloads by default, got %q, %v", dir, err)
+	}
+
+	config.TUI.PDFHandler = "open -a Preview"
+	config.TUI.DownloadDir = "~/papers"
+	if handler := config.GetPDFHandler(); len(handler) != 3 || handler[0] != "open" || handler[2] != "Preview" {
+		t.Errorf("Expected the handler split into arguments, got %v", handler)
+	}
+	if dir, _ := config.GetDownloadDir(); dir != filepath.Join(home, "papers") {
+		t.Errorf("Expected ~ expanded, got %q", dir)
+	}
+}
+
+func TestSetTUIOption(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "prismis", "config.toml")
+
+	// No file yet: the section is created
+	if err := SetTUIOption("refresh_interval", 120); err != nil {
+		t.Fatalf("SetTUIOption() failed: %v", err)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "[tui]\nrefresh_interval = 120\n" {
+		t.Errorf("Expected a new [tui] section, got %q", data)
+	}
+
+	// Existing file: replace in place, add to the right section, keep comments
+	original := `# My settings
+[api]
+key = "abc"
+
+[tui]
+refresh_interval = 30 # Every half minute
+mark_read = "end"
+
+[remote]
+url = "https://example.com"
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetTUIOption("refresh_interval", 90); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetTUIOption("hyperlinks", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetTUIOption("mark_read", "dwell"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# My settings
+[api]
+key = "abc"
+
+[tui]
+refresh_interval = 90
+mark_read = "dwell"
+hyperlinks = false
+
+[remote]
+url = "https://example.com"
+`
+	if data, _ := os.ReadFile(configPath); string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected the written file to parse, got %v", err)
+	}
+	if config.TUI.RefreshInterval != 90 || config.HyperlinksEnabled() || config.TUI.MarkRead != "dwell" || config.API.Key != "abc" {
+		t.Errorf("Unexpected config after writes: %+v", config.TUI)
+	}
+}
+
+func TestRemoteProfiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configPath := filepath.Join(tmpDir, "prismis", "config.toml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte("[remote]\nkey = \"abc\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveRemoteProfile("Media Server.local.", "http://192.168.1.5:8989"); err != nil {
+		t.Fatalf("SaveRemoteProfile() failed: %v", err)
+	}
+	if err := SaveRemoteProfile("media-server", "http://192.168.1.6:8989"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[remote]\nkey = \"abc\"\n\n[remotes.media-server]\nurl = \"http://192.168.1.6:8989\"\n"
+	if data, _ := os.ReadFile(configPath); string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+	if url, ok := config.GetRemoteProfile("Media Server"); !ok || url != "http://192.168.1.6:8989" {
+		t.Errorf("Expected the saved profile, got %q (%v)", url, ok)
+	}
+	if _, ok := config.GetRemoteProfile("laptop"); ok {
+		t.Error("Expected no profile for an unknown name")
+	}
+	if config.GetRemoteKey() != "abc" || config.HasRemoteConfig() {
+		t.Errorf("Expected [remote] untouched, got %+v", config.Remote)
+	}
+}
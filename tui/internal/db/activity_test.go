@@ -0,0 +1,69 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestGetSourceDailyCounts_BucketsByPublishDay verifies items land in their local publish day, oldest first.
+// BREAKS: If days are bucketed in UTC or newest first, the sparkline shows activity on the wrong day.
+func TestGetSourceDailyCounts_BucketsByPublishDay(t *testing.T) {
+	resetDBForTest(t)
+	dbPath := createTestDB(t) // Six items from test-source-1 published now
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	now := time.Now()
+	for id, published := range map[string]time.Time{
+		"old":    now.AddDate(0, 0, -30), // Outside the window
+		"week":   now.AddDate(0, 0, -7),
+		"other":  now.AddDate(0, 0, -13), // First day of the window
+		"broken": {},
+	} {
+		value := published.UTC().Format(time.RFC3339)
+		if id == "broken" {
+			value = "not a date"
+		}
+		source := "test-source-1"
+		if id == "other" {
+			source = "test-source-2"
+		}
+		if _, err := db.Exec(`INSERT INTO content (id, source_id, title, url, published_at) VALUES (?, ?, ?, ?, ?)`,
+			id, source, id, "http://example.com/"+id, value); err != nil {
+			t.Fatalf("Failed to insert %s: %v", id, err)
+		}
+	}
+	db.Close()
+
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	counts, err := GetSourceDailyCounts(14)
+	if err != nil {
+		t.Fatalf("GetSourceDailyCounts failed: %v", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("Expected counts for two sources, got %v", counts)
+	}
+	first := counts["test-source-1"]
+	if len(first) != 14 || first[13] != 6 || first[6] != 1 || sum(first) != 7 {
+		t.Errorf("Expected 6 today and 1 a week ago, got %v", first)
+	}
+	if second := counts["test-source-2"]; second[0] != 1 || sum(second) != 1 {
+		t.Errorf("Expected one item on the oldest day, got %v", second)
+	}
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
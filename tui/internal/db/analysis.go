@@ -0,0 +1,110 @@
+package db
+
+import (
+	"encoding/json"
+)
+
+// Analysis is the typed form of the content.analysis JSON blob. The daemon's
+// LLM pass writes the summary fields, fetchers write metrics, and deep
+// extraction is merged in later for HIGH-priority items.
+type Analysis struct {
+	ReadingSummary    string          `json:"reading_summary"`
+	AlphaInsights     stringList      `json:"alpha_insights"`
+	Patterns          stringList      `json:"patterns"`
+	Entities          stringList      `json:"entities"` // These are the "topics"
+	Quotes            stringList      `json:"quotes"`
+	Tools             stringList      `json:"tools"`
+	URLs              stringList      `json:"urls"`
+	MatchedInterests  stringList      `json:"matched_interests"`
+	PriorityReasoning string          `json:"priority_reasoning"`
+	Metrics           Metrics         `json:"metrics"`
+	Metadata          Metadata        `json:"metadata"`
+	DeepExtraction    *DeepExtraction `json:"deep_extraction"`
+}
+
+// Metrics holds source-specific engagement numbers written by the fetchers
+type Metrics struct {
+	Score       int     `json:"score"`        // Reddit
+	UpvoteRatio float64 `json:"upvote_ratio"` // Reddit
+	NumComments int     `json:"num_comments"` // Reddit
+	ViewCount   int     `json:"view_count"`   // YouTube
+	Duration    int     `json:"duration"`     // YouTube, in seconds
+}
+
+// Metadata describes the summarization run
+type Metadata struct {
+	Model             string `json:"model"`
+	ContentLength     int    `json:"content_length"`
+	WordCount         int    `json:"word_count"`
+	SummarizationMode string `json:"summarization_mode"`
+}
+
+// DeepExtraction represents second-tier LLM synthesis for HIGH-priority items
+type DeepExtraction struct {
+	Synthesis   string     `json:"synthesis"`
+	Quotables   stringList `json:"quotables"`
+	Model       string     `json:"model"`
+	ExtractedAt string     `json:"extracted_at"`
+}
+
+// stringList decodes a JSON array keeping only its string elements, so one
+// malformed entry from the LLM doesn't discard the whole analysis
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		*l = nil
+		return nil
+	}
+	out := make(stringList, 0, len(raw))
+	for _, elem := range raw {
+		var s string
+		if json.Unmarshal(elem, &s) == nil {
+			out = append(out, s)
+		}
+	}
+	*l = out
+	return nil
+}
+
+// ParseAnalysis decodes an analysis blob. Empty or malformed JSON yields an
+// empty Analysis, never nil.
+func ParseAnalysis(raw string) *Analysis {
+	a := &Analysis{}
+	if raw == "" {
+		return a
+	}
+	if err := json.Unmarshal([]byte(raw), a); err != nil {
+		return &Analysis{}
+	}
+	// A synthesis-less extraction would render an empty section in the reader
+	if a.DeepExtraction != nil && a.DeepExtraction.Synthesis == "" {
+		a.DeepExtraction = nil
+	}
+	return a
+}
+
+// analysisCache remembers which Analysis string a parse came from
+type analysisCache struct {
+	raw    string
+	parsed *Analysis
+}
+
+// SetAnalysis replaces the analysis JSON and caches its parsed form. Copies
+// of the item made afterwards share the cache.
+func (c *ContentItem) SetAnalysis(raw string) {
+	c.Analysis = raw
+	c.analysis = &analysisCache{raw: raw, parsed: ParseAnalysis(raw)}
+}
+
+// ParsedAnalysis returns the typed analysis, parsing Analysis at most once
+// per value. The result is shared and must not be modified; use
+// SetAnalysis to change it.
+func (c *ContentItem) ParsedAnalysis() *Analysis {
+	if c.analysis == nil || c.analysis.raw != c.Analysis {
+		c.SetAnalysis(c.Analysis)
+	}
+	return c.analysis.parsed
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"testing"
+)
+
+// TestParseAnalysis_DeepExtraction verifies ParseAnalysis correctly parses deep_extraction.
+// INV-D1: analysis["deep_extraction"] must be extracted into a typed *DeepExtraction with
+// non-empty Synthesis — if this breaks, deep extraction becomes invisible to every reader user.
+func TestParseAnalysis_DeepExtraction(t *testing.T) {
+	analysisJSON := `{
+		"entities": ["Go", "LLM"],
+		"deep_extraction": {
+			"synthesis": "Counterintuitive finding: the obvious conclusion is wrong.",
+			"quotables": ["Revenue grew 22% but profitability fell.", "Key verbatim quote."],
+			"model": "gpt-5-mini",
+			"extracted_at": "2026-04-27T12:00:00+00:00"
+		}
+	}`
+
+	analysis := ParseAnalysis(analysisJSON)
+
+	if analysis.DeepExtraction == nil {
+		t.Fatal("DeepExtraction should not be nil when analysis contains deep_extraction")
+	}
+	if analysis.DeepExtraction.Synthesis != "Counterintuitive finding: the obvious conclusion is wrong." {
+		t.Errorf("Synthesis mismatch: got %q", analysis.DeepExtraction.Synthesis)
+	}
+	if len(analysis.DeepExtraction.Quotables) != 2 {
+		t.Errorf("Expected 2 quotables, got %d", len(analysis.DeepExtraction.Quotables))
+	}
+	if analysis.DeepExtraction.Model != "gpt-5-mini" {
+		t.Errorf("Model mismatch: got %q", analysis.DeepExtraction.Model)
+	}
+}
+
+// TestParseAnalysis_DeepExtractionAbsent verifies DeepExtraction is nil when the key is
+// absent or has no synthesis.
+// BREAKS: an empty synthesis string would produce an empty "## Deep Synthesis" header in the reader.
+func TestParseAnalysis_DeepExtractionAbsent(t *testing.T) {
+	for _, raw := range []string{
+		`{"entities": ["Go"], "quotes": ["some quote"]}`,
+		`{"deep_extraction": {"synthesis": "", "quotables": ["x"]}}`,
+		`{"deep_extraction": null}`,
+	} {
+		if de := ParseAnalysis(raw).DeepExtraction; de != nil {
+			t.Errorf("DeepExtraction must be nil for %s, got %+v", raw, de)
+		}
+	}
+}
+
+// TestParseAnalysis_Fields verifies the summary, entities, metrics, and metadata all decode
+// from a daemon-shaped blob, and that stray non-string entries don't sink the parse.
+// BREAKS: list rows lose Reddit scores, YouTube durations, or RSS lengths.
+func TestParseAnalysis_Fields(t *testing.T) {
+	raw := `{
+		"reading_summary": "Long form.",
+		"entities": ["rust", 42, "async runtime", {"bad": true}],
+		"metrics": {"score": 1234, "upvote_ratio": 0.97, "num_comments": 56, "view_count": 1500000, "duration": 754},
+		"metadata": {"model": "gpt-5-mini", "content_length": 15234, "word_count": 2500}
+	}`
+
+	a := ParseAnalysis(raw)
+
+	if a.ReadingSummary != "Long form." {
+		t.Errorf("ReadingSummary = %q", a.ReadingSummary)
+	}
+	if len(a.Entities) != 2 || a.Entities[0] != "rust" || a.Entities[1] != "async runtime" {
+		t.Errorf("Entities = %v, want [rust async runtime]", a.Entities)
+	}
+	m := a.Metrics
+	if m.Score != 1234 || m.NumComments != 56 || m.UpvoteRatio != 0.97 || m.ViewCount != 1500000 || m.Duration != 754 {
+		t.Errorf("Metrics = %+v", m)
+	}
+	if a.Metadata.ContentLength != 15234 {
+		t.Errorf("ContentLength = %d, want 15234", a.Metadata.ContentLength)
+	}
+}
+
+// TestParseAnalysis_EmptyOrMalformed verifies bad input yields an empty, non-nil Analysis.
+// BREAKS: rendering an item with an unparseable analysis panics on a nil dereference.
+func TestParseAnalysis_EmptyOrMalformed(t *testing.T) {
+	for _, raw := range []string{"", "not json", `{"entities": `} {
+		a := ParseAnalysis(raw)
+		if a == nil {
+			t.Fatalf("ParseAnalysis(%q) returned nil", raw)
+		}
+		if a.ReadingSummary != "" || len(a.Entities) != 0 {
+			t.Errorf("ParseAnalysis(%q) = %+v, want empty", raw, a)
+		}
+	}
+}
+
+// TestContentItem_ParsedAnalysisCache verifies the parse is cached and follows Analysis changes.
+// BREAKS: every list render re-parses every item, or deep extraction stays invisible after :extract.
+func TestContentItem_ParsedAnalysisCache(t *testing.T) {
+	var item ContentItem
+	item.SetAnalysis(`{"reading_summary": "first"}`)
+
+	copied := item
+	if item.ParsedAnalysis() != copied.ParsedAnalysis() {
+		t.Error("copies of an item should share the cached parse")
+	}
+
+	item.SetAnalysis(`{"reading_summary": "second"}`)
+	if got := item.ParsedAnalysis().ReadingSummary; got != "second" {
+		t.Errorf("after SetAnalysis, ReadingSummary = %q, want second", got)
+	}
+
+	item.Analysis = `{"reading_summary": "third"}`
+	if got := item.ParsedAnalysis().ReadingSummary; got != "third" {
+		t.Errorf("after assigning Analysis, ReadingSummary = %q, want third", got)
+	}
+}
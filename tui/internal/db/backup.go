@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// BackupInfo describes a database backup file
+type BackupInfo struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// DefaultBackupDir is where :backup writes without a path: backups/ next to
+// the local database
+func DefaultBackupDir() (string, error) {
+	path, err := getDBPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "backups"), nil
+}
+
+// Backup copies the local database to path with SQLite's online backup API,
+// so the daemon can keep writing while it runs and the copy is consistent
+func Backup(path string) (BackupInfo, error) {
+	dbPath, err := getDBPath()
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to get database path: %w", err)
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return BackupInfo{}, fmt.Errorf("no local database at %s", dbPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := copyDatabase(dbPath, path); err != nil {
+		return BackupInfo{}, fmt.Errorf("backup failed: %w", err)
+	}
+	return StatBackup(path)
+}
+
+// StatBackup checks path holds a prismis database and describes it
+func StatBackup(path string) (BackupInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("no backup at %s", path)
+	}
+	if info.IsDir() {
+		return BackupInfo{}, fmt.Errorf("%s is a directory", path)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+	var tables int
+	err = db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('content', 'sources')`).Scan(&tables)
+	if err != nil || tables != 2 {
+		return BackupInfo{}, fmt.Errorf("%s is not a prismis database", path)
+	}
+	return BackupInfo{Path: path, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Restore replaces the local database's contents with the backup at path,
+// through the backup API so open connections (the daemon's) see the restored
+// data instead of a swapped-out file. The current database is saved first as
+// prismis.db.pre-restore.
+func Restore(path string) (BackupInfo, error) {
+	backup, err := StatBackup(path)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	dbPath, err := getDBPath()
+	if err != nil {
+		return BackupInfo{}, fmt.Errorf("failed to get database path: %w", err)
+	}
+	if _, err := os.Stat(dbPath); err == nil {
+		if err := copyDatabase(dbPath, dbPath+".pre-restore"); err != nil {
+			return BackupInfo{}, fmt.Errorf("failed to save the current database first: %w", err)
+		}
+	}
+	if err := copyDatabase(path, dbPath); err != nil {
+		return BackupInfo{}, fmt.Errorf("restore failed: %w", err)
+	}
+	return backup, nil
+}
+
+// copyDatabase runs an online backup of the database at src into dst
+func copyDatabase(src, dst string) error {
+	ctx := context.Background()
+	srcDB, err := sql.Open("sqlite3", src)
+	if err != nil {
+		return err
+	}
+	defer srcDB.Close()
+	dstDB, err := sql.Open("sqlite3", dst)
+	if err != nil {
+		return err
+	}
+	defer dstDB.Close()
+	for _, db := range []*sql.DB{srcDB, dstDB} {
+		if _, err := db.Exec("PRAGMA busy_timeout=5000"); err != nil {
+			return err
+		}
+	}
+
+	srcConn, err := srcDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+	dstConn, err := dstDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+
+	return dstConn.Raw(func(dstRaw any) error {
+		return srcConn.Raw(func(srcRaw any) error {
+			backup, err := dstRaw.(*sqlite3.SQLiteConn).Backup("main", srcRaw.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			// -1 copies every page in one step
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestBackup_RestoreRoundTrip verifies a backup captures the database and restoring it brings the old rows back.
+// BREAKS: If restore swaps files instead of using the backup API, or skips the pre-restore copy, data written since is lost with no way back.
+func TestBackup_RestoreRoundTrip(t *testing.T) {
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	backupPath := filepath.Join(t.TempDir(), "backups", "prismis.db")
+	info, err := Backup(backupPath)
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	if info.Size == 0 || info.ModTime.IsZero() {
+		t.Errorf("Expected size and time of the backup, got %+v", info)
+	}
+
+	live, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer live.Close()
+	count := func() int {
+		var n int
+		if err := live.QueryRow("SELECT COUNT(*) FROM content").Scan(&n); err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		return n
+	}
+	before := count()
+	if _, err := live.Exec("DELETE FROM content"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if _, err := Restore(backupPath); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if after := count(); after != before || before == 0 {
+		t.Errorf("Expected %d rows restored through the open connection, got %d", before, after)
+	}
+	if _, err := os.Stat(dbPath + ".pre-restore"); err != nil {
+		t.Errorf("Expected the replaced database saved: %v", err)
+	}
+
+	// Anything that isn't a prismis database is refused
+	other := filepath.Join(t.TempDir(), "notes.txt")
+	os.WriteFile(other, []byte("hello"), 0o644)
+	if _, err := Restore(other); err == nil {
+		t.Error("Expected a non-database to be refused")
+	}
+}
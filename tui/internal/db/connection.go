@@ -4,24 +4,46 @@ import (
 	"database/sql"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// Pool limits for the shared handle. SQLite lets one writer in at a time, so
+// a handful of connections covers concurrent reads; idle ones are closed so a
+// TUI left open doesn't keep readers on the WAL.
+const (
+	maxOpenConns    = 8
+	maxIdleConns    = 2
+	connMaxIdleTime = 5 * time.Minute
+)
+
 var (
+	// dbMu guards dbPool, dbOnce and dbErr, which CloseDB resets while other
+	// goroutines may be asking for the pool or its stats
+	dbMu sync.Mutex
 	// dbPool is the singleton database connection pool
 	dbPool *sql.DB
 	// dbOnce ensures the pool is created only once
 	dbOnce sync.Once
 	// dbErr stores any error from pool creation
 	dbErr error
+	// dbSchema is the pool's content schema, read when it connected
+	dbSchema atomic.Pointer[Schema]
+	// dbSchemaErr stores why the schema couldn't be read
+	dbSchemaErr error
 )
 
 // GetDB returns the singleton database connection pool.
 // It creates the pool on first call and reuses it for all subsequent calls.
 // This ensures efficient connection reuse across all database operations.
 func GetDB() (*sql.DB, error) {
+	dbMu.Lock()
+	defer dbMu.Unlock()
 	dbOnce.Do(func() {
+		dbSchema.Store(nil)
+		dbSchemaErr = nil
 		dbPath, err := getDBPath()
 		if err != nil {
 			dbErr = fmt.Errorf("failed to get database path: %w", err)
@@ -36,9 +58,10 @@ func GetDB() (*sql.DB, error) {
 		}
 
 		// Configure connection pool settings
-		dbPool.SetMaxOpenConns(25)   // Maximum number of open connections
-		dbPool.SetMaxIdleConns(5)    // Maximum number of idle connections
-		dbPool.SetConnMaxLifetime(0) // Connections don't expire (SQLite is local)
+		dbPool.SetMaxOpenConns(maxOpenConns)
+		dbPool.SetMaxIdleConns(maxIdleConns)
+		dbPool.SetConnMaxIdleTime(connMaxIdleTime)
+		dbPool.SetConnMaxLifetime(0) // Busy connections don't expire (SQLite is local)
 
 		// Set WAL mode and busy timeout on the pool
 		// These pragmas will be inherited by all connections from the pool
@@ -65,6 +88,12 @@ func GetDB() (*sql.DB, error) {
 			dbPool = nil
 			return
 		}
+
+		// Read the schema once so queries can leave out columns an older
+		// daemon database doesn't have yet
+		var schema *Schema
+		schema, dbSchemaErr = detectSchema(dbPool)
+		dbSchema.Store(schema)
 	})
 
 	if dbErr != nil {
@@ -74,16 +103,32 @@ func GetDB() (*sql.DB, error) {
 	return dbPool, nil
 }
 
+// PoolStats returns the shared pool's connection counts and waits; ok is
+// false when the pool hasn't been opened
+func PoolStats() (stats sql.DBStats, ok bool) {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	if dbPool == nil {
+		return sql.DBStats{}, false
+	}
+	return dbPool.Stats(), true
+}
+
 // CloseDB closes the singleton database connection pool.
 // This should only be called when the application is shutting down.
+// A pool that failed to open is reset too, so the next GetDB tries again.
 func CloseDB() error {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	var err error
 	if dbPool != nil {
-		err := dbPool.Close()
+		err = dbPool.Close()
 		dbPool = nil
-		dbErr = nil
-		// Reset the once so a new pool can be created
-		dbOnce = sync.Once{}
-		return err
 	}
-	return nil
+	dbErr = nil
+	dbSchema.Store(nil)
+	dbSchemaErr = nil
+	// Reset the once so a new pool can be created
+	dbOnce = sync.Once{}
+	return err
 }
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"sync"
 	"testing"
 )
 
@@ -40,3 +41,105 @@ func TestConnectionPool(t *testing.T) {
 
 	t.Log("✅ Connection pool singleton working correctly")
 }
+
+// TestPoolStats_ReportsSharedPool verifies pool stats are only available once the pool is open, with its limits applied.
+// BREAKS: If the limits aren't set, :db stats shows an unbounded pool and SQLite piles up busy connections.
+func TestPoolStats_ReportsSharedPool(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	if _, ok := PoolStats(); ok {
+		t.Fatal("Expected no stats before the pool opens")
+	}
+	if _, err := GetAllContent(false); err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	stats, ok := PoolStats()
+	if !ok || stats.MaxOpenConnections != maxOpenConns || stats.OpenConnections == 0 {
+		t.Errorf("Expected the open pool capped at %d, got %+v", maxOpenConns, stats)
+	}
+
+	report, err := GetStats()
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if report.Pool == nil || report.Pool.MaxOpenConnections != maxOpenConns {
+		t.Errorf("Expected :db stats to include the pool, got %+v", report.Pool)
+	}
+}
+
+// TestPoolStats_ConcurrentWithClose verifies stats can be read while the pool is opened and closed.
+// BREAKS: :db stats racing a shutdown would read a half-reset pool (caught by go test -race).
+func TestPoolStats_ConcurrentWithClose(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if _, err := GetDB(); err != nil {
+				t.Errorf("GetDB failed: %v", err)
+				return
+			}
+			CloseDB()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			PoolStats()
+		}
+	}()
+	wg.Wait()
+}
+
+// TestSchema_ConcurrentWithClose verifies the schema can be read while the pool is opened and closed.
+// BREAKS: GetSchema or schemaOf reading the pool unlocked races CloseDB (caught by go test -race).
+func TestSchema_ConcurrentWithClose(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatalf("GetDB failed: %v", err)
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			CloseDB()
+			GetDB()
+		}
+	}()
+	for _, read := range []func(){func() { GetSchema() }, func() { schemaOf(db) }} {
+		go func(read func()) {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					read()
+				}
+			}
+		}(read)
+	}
+	wg.Wait()
+}
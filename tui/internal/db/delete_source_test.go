@@ -0,0 +1,127 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newDeleteSourceTestDB creates an in-memory DB with one source and three items (one favorited)
+func newDeleteSourceTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	db.SetMaxOpenConns(1) // :memory: is per-connection
+	t.Cleanup(func() { db.Close() })
+
+	schema := `
+	PRAGMA foreign_keys=ON;
+	CREATE TABLE sources (id TEXT PRIMARY KEY, name TEXT);
+	CREATE TABLE content (
+		id TEXT PRIMARY KEY,
+		source_id TEXT,
+		favorited INTEGER DEFAULT 0,
+		archived_at TIMESTAMP DEFAULT NULL,
+		FOREIGN KEY (source_id) REFERENCES sources(id) ON DELETE CASCADE
+	);
+	INSERT INTO sources (id, name) VALUES ('s1', 'Feed');
+	INSERT INTO content (id, source_id, favorited) VALUES ('a', 's1', 0), ('b', 's1', 0), ('fav', 's1', 1);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	return db
+}
+
+// TestDeleteSource_Retention verifies each retention mode's effect on the source's content.
+// BREAKS: If items aren't detached before the source row goes, ON DELETE CASCADE silently
+// deletes content the user asked to keep.
+func TestDeleteSource_Retention(t *testing.T) {
+	tests := []struct {
+		retention    string
+		wantAffected int
+		wantItems    int
+		wantArchived int
+	}{
+		{"delete", 2, 1, 0}, // Favorite survives, detached
+		{"keep", 3, 3, 0},
+		{"archive", 3, 3, 3},
+	}
+
+	for _, tt := range tests {
+		db := newDeleteSourceTestDB(t)
+
+		affected, err := deleteSource(db, "s1", tt.retention)
+		if err != nil {
+			t.Fatalf("%s: deleteSource failed: %v", tt.retention, err)
+		}
+		if affected != tt.wantAffected {
+			t.Errorf("%s: expected %d affected, got %d", tt.retention, tt.wantAffected, affected)
+		}
+
+		var items, archived, attached, sources int
+		db.QueryRow("SELECT COUNT(*) FROM content").Scan(&items)
+		db.QueryRow("SELECT COUNT(*) FROM content WHERE archived_at IS NOT NULL").Scan(&archived)
+		db.QueryRow("SELECT COUNT(*) FROM content WHERE source_id IS NOT NULL").Scan(&attached)
+		db.QueryRow("SELECT COUNT(*) FROM sources").Scan(&sources)
+
+		if items != tt.wantItems || archived != tt.wantArchived || attached != 0 || sources != 0 {
+			t.Errorf("%s: items=%d archived=%d attached=%d sources=%d", tt.retention, items, archived, attached, sources)
+		}
+	}
+}
+
+// TestDeleteSource_InvalidRetention verifies unknown modes are rejected without deleting anything.
+// BREAKS: If a typo falls through to a default, content could be dropped unexpectedly.
+func TestDeleteSource_InvalidRetention(t *testing.T) {
+	db := newDeleteSourceTestDB(t)
+
+	if _, err := deleteSource(db, "s1", "purge"); err == nil {
+		t.Error("Expected error for invalid retention")
+	}
+
+	var sources int
+	db.QueryRow("SELECT COUNT(*) FROM sources").Scan(&sources)
+	if sources != 1 {
+		t.Errorf("Source should remain after invalid retention, got %d sources", sources)
+	}
+}
+
+// TestDeleteSource_KeptItemsStayListed verifies items kept from a removed source still show up in the lists.
+// BREAKS: An inner join on sources hides detached items, so "keep" would look the same as "delete".
+func TestDeleteSource_KeptItemsStayListed(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	if _, err := DeleteSource("test-source-1", "keep"); err != nil {
+		t.Fatalf("DeleteSource failed: %v", err)
+	}
+
+	items, err := GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	if len(items) != 6 {
+		t.Fatalf("Expected all 6 kept items listed, got %d", len(items))
+	}
+	if items[0].SourceName != "(removed source)" || items[0].SourceID != "" {
+		t.Errorf("Expected the removed-source fallback, got name=%q id=%q", items[0].SourceName, items[0].SourceID)
+	}
+
+	unread, err := GetUnreadContent()
+	if err != nil {
+		t.Fatalf("GetUnreadContent failed: %v", err)
+	}
+	if len(unread) != 5 {
+		t.Errorf("Expected 5 unread kept items, got %d", len(unread))
+	}
+	if unprioritized, _, err := GetUnprioritizedContent(true); err != nil || len(unprioritized) != 1 {
+		t.Errorf("Expected the unprioritized kept item, got %d (%v)", len(unprioritized), err)
+	}
+}
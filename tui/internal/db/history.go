@@ -0,0 +1,228 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// HistoryEntry is one stretch of reading an article in the reader
+type HistoryEntry struct {
+	ContentID  string
+	Title      string // Copied at read time so history survives pruning
+	SourceName string
+	URL        string
+	Priority   string    // "high", "medium", "low", or "" at read time
+	ReadAt     time.Time // When the article was opened
+	Duration   time.Duration
+}
+
+// HistoryDay summarizes one local day of reading
+type HistoryDay struct {
+	Day      time.Time // Local midnight
+	Articles int       // Distinct articles read
+	Duration time.Duration
+	Entries  []HistoryEntry // Newest first
+}
+
+// historySchema is created on first use. The history lives in its own file
+// so it works in remote mode and stays out of the daemon's schema.
+const historySchema = `
+CREATE TABLE IF NOT EXISTS reading_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	content_id TEXT NOT NULL,
+	title TEXT NOT NULL,
+	source_name TEXT,
+	url TEXT,
+	read_at TIMESTAMP NOT NULL,
+	duration_seconds INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_reading_history_read_at ON reading_history(read_at);
+CREATE TABLE IF NOT EXISTS goal_days (
+	day TEXT NOT NULL,
+	goal TEXT NOT NULL,
+	PRIMARY KEY (day, goal)
+);`
+
+// historyDayFormat keys goal_days by local date
+const historyDayFormat = "2006-01-02"
+
+var (
+	historyDB   *sql.DB
+	historyOnce sync.Once
+	historyErr  error
+
+	// historyPathFunc returns the history database location (for testing)
+	historyPathFunc = defaultHistoryPath
+)
+
+// defaultHistoryPath keeps the history next to the local database
+func defaultHistoryPath() (string, error) {
+	path, err := getDefaultDBPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "history.db"), nil
+}
+
+// getHistoryDB opens the history database, creating it on first use
+func getHistoryDB() (*sql.DB, error) {
+	historyOnce.Do(func() {
+		path, err := historyPathFunc()
+		if err != nil {
+			historyErr = fmt.Errorf("failed to get history path: %w", err)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			historyErr = fmt.Errorf("failed to create history directory: %w", err)
+			return
+		}
+		db, err := sql.Open("sqlite3", path)
+		if err != nil {
+			historyErr = fmt.Errorf("failed to open history: %w", err)
+			return
+		}
+		if _, err := db.Exec("PRAGMA busy_timeout=5000"); err != nil {
+			db.Close()
+			historyErr = fmt.Errorf("failed to set busy timeout: %w", err)
+			return
+		}
+		if _, err := db.Exec(historySchema); err != nil {
+			db.Close()
+			historyErr = fmt.Errorf("failed to create history table: %w", err)
+			return
+		}
+		// Histories from before priorities were recorded lack the column
+		if _, err := db.Exec("ALTER TABLE reading_history ADD COLUMN priority TEXT"); err != nil &&
+			!strings.Contains(err.Error(), "duplicate column") {
+			db.Close()
+			historyErr = fmt.Errorf("failed to migrate history: %w", err)
+			return
+		}
+		historyDB = db
+	})
+	return historyDB, historyErr
+}
+
+// RecordRead appends a reading session to the history
+func RecordRead(entry HistoryEntry) error {
+	db, err := getHistoryDB()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`INSERT INTO reading_history (content_id, title, source_name, url, priority, read_at, duration_seconds)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.ContentID, entry.Title, entry.SourceName, entry.URL, entry.Priority,
+		entry.ReadAt.UTC().Format(time.RFC3339), int(entry.Duration.Seconds()))
+	if err != nil {
+		return fmt.Errorf("failed to record read: %w", err)
+	}
+	return nil
+}
+
+// GetHistory returns the reading sessions since since, newest first
+func GetHistory(since time.Time) ([]HistoryEntry, error) {
+	db, err := getHistoryDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT content_id, title, source_name, url, priority, read_at, duration_seconds
+		FROM reading_history WHERE read_at >= ? ORDER BY read_at DESC, id DESC`,
+		since.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []HistoryEntry
+	for rows.Next() {
+		var entry HistoryEntry
+		var sourceName, url, priority sql.NullString
+		var readAt string
+		var seconds int
+		if err := rows.Scan(&entry.ContentID, &entry.Title, &sourceName, &url, &priority, &readAt, &seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan history: %w", err)
+		}
+		entry.SourceName = sourceName.String
+		entry.URL = url.String
+		entry.Priority = priority.String
+		entry.ReadAt, _ = time.Parse(time.RFC3339, readAt)
+		entry.Duration = time.Duration(seconds) * time.Second
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating history: %w", err)
+	}
+	return entries, nil
+}
+
+// MarkGoalMet records that goal was met on day (local date). Marking a day
+// twice is a no-op.
+func MarkGoalMet(goal string, day time.Time) error {
+	db, err := getHistoryDB()
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(`INSERT OR IGNORE INTO goal_days (day, goal) VALUES (?, ?)`,
+		day.Local().Format(historyDayFormat), goal); err != nil {
+		return fmt.Errorf("failed to record goal: %w", err)
+	}
+	return nil
+}
+
+// GetGoalDays returns the local dates ("2006-01-02") since since on which
+// goal was met
+func GetGoalDays(goal string, since time.Time) (map[string]bool, error) {
+	db, err := getHistoryDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT day FROM goal_days WHERE goal = ? AND day >= ?`,
+		goal, since.Local().Format(historyDayFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goal days: %w", err)
+	}
+	defer rows.Close()
+
+	days := map[string]bool{}
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("failed to scan goal day: %w", err)
+		}
+		days[day] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goal days: %w", err)
+	}
+	return days, nil
+}
+
+// SummarizeHistory groups newest-first entries into local days, newest
+// day first. Reopening an article adds its time but counts it once.
+func SummarizeHistory(entries []HistoryEntry) []HistoryDay {
+	var days []HistoryDay
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		local := entry.ReadAt.Local()
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+		if len(days) == 0 || !days[len(days)-1].Day.Equal(day) {
+			days = append(days, HistoryDay{Day: day})
+			seen = map[string]bool{}
+		}
+		current := &days[len(days)-1]
+		current.Duration += entry.Duration
+		current.Entries = append(current.Entries, entry)
+		if !seen[entry.ContentID] {
+			seen[entry.ContentID] = true
+			current.Articles++
+		}
+	}
+	return days
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// useTestHistory points the history at a fresh file for the test
+func useTestHistory(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		if historyDB != nil {
+			historyDB.Close()
+		}
+		historyDB = nil
+		historyErr = nil
+		historyOnce = sync.Once{}
+	}
+	reset()
+	original := historyPathFunc
+	path := filepath.Join(t.TempDir(), "prismis", "history.db")
+	historyPathFunc = func() (string, error) { return path, nil }
+	t.Cleanup(func() {
+		reset()
+		historyPathFunc = original
+	})
+}
+
+// TestHistory_RecordAndSummarize verifies sessions round-trip and group into days with distinct article counts.
+// BREAKS: If rereads count as new articles or days split in UTC, the day summaries overstate reading.
+func TestHistory_RecordAndSummarize(t *testing.T) {
+	useTestHistory(t)
+
+	today := time.Now().Truncate(time.Second)
+	yesterday := today.AddDate(0, 0, -1)
+	sessions := []HistoryEntry{
+		{ContentID: "a", Title: "A", SourceName: "Blog", URL: "https://a", Priority: "high", ReadAt: yesterday, Duration: time.Minute},
+		{ContentID: "b", Title: "B", ReadAt: today.Add(-2 * time.Second), Duration: 30 * time.Second},
+		{ContentID: "b", Title: "B", ReadAt: today, Duration: 90 * time.Second},
+		{ContentID: "old", Title: "Old", ReadAt: today.AddDate(0, 0, -30), Duration: time.Minute},
+	}
+	for _, s := range sessions {
+		if err := RecordRead(s); err != nil {
+			t.Fatalf("RecordRead failed: %v", err)
+		}
+	}
+
+	entries, err := GetHistory(today.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(entries) != 3 || !entries[0].ReadAt.Equal(today) || entries[2].SourceName != "Blog" || entries[2].Priority != "high" {
+		t.Fatalf("Expected the three recent sessions newest first, got %+v", entries)
+	}
+
+	days := SummarizeHistory(entries)
+	if len(days) != 2 {
+		t.Fatalf("Expected two days, got %+v", days)
+	}
+	if days[0].Articles != 1 || days[0].Duration != 2*time.Minute || len(days[0].Entries) != 2 {
+		t.Errorf("Expected today to count B once for 2m, got %+v", days[0])
+	}
+	if days[1].Articles != 1 || days[1].Duration != time.Minute {
+		t.Errorf("Expected yesterday's single article, got %+v", days[1])
+	}
+}
+
+// TestGoalDays_MarkedOncePerDay verifies met days are stored per goal and marking twice is harmless.
+// BREAKS: If goals share days or duplicates fail, streaks mix goals or the header errors every refresh.
+func TestGoalDays_MarkedOncePerDay(t *testing.T) {
+	useTestHistory(t)
+
+	now := time.Now()
+	for _, day := range []time.Time{now, now, now.AddDate(0, 0, -1), now.AddDate(0, 0, -40)} {
+		if err := MarkGoalMet("high", day); err != nil {
+			t.Fatalf("MarkGoalMet failed: %v", err)
+		}
+	}
+	if err := MarkGoalMet("10", now); err != nil {
+		t.Fatalf("MarkGoalMet failed: %v", err)
+	}
+
+	days, err := GetGoalDays("high", now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatalf("GetGoalDays failed: %v", err)
+	}
+	if len(days) != 2 || !days[now.Format("2006-01-02")] || !days[now.AddDate(0, 0, -1).Format("2006-01-02")] {
+		t.Errorf("Expected today and yesterday only, got %v", days)
+	}
+}
@@ -0,0 +1,297 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VacuumResult reports what :db vacuum reclaimed
+type VacuumResult struct {
+	Before   int64 // Database and WAL size before, in bytes
+	After    int64
+	Duration time.Duration
+}
+
+// ObjectStats is the size of one table or index
+type ObjectStats struct {
+	Name  string
+	Table string // The table an index belongs to; empty for tables
+	Rows  int64
+	Bytes int64
+	Exact bool // Measured by dbstat rather than estimated from column lengths
+}
+
+// Stats describes the local database for :db stats
+type Stats struct {
+	Path      string
+	FileSize  int64
+	WALSize   int64
+	PageSize  int64
+	PageCount int64
+	FreePages int64 // Pages VACUUM would give back
+	Objects   []ObjectStats
+	Pool      *sql.DBStats // The shared pool's connections; nil when it isn't open
+}
+
+// openMaintenance opens the local database on a single connection, so
+// pragmas and VACUUM all run on the same one
+func openMaintenance() (*sql.DB, string, error) {
+	path, err := getDBPath()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get database path: %w", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, "", fmt.Errorf("no local database at %s", path)
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout=5000"); err != nil {
+		db.Close()
+		return nil, "", fmt.Errorf("failed to set busy timeout: %w", err)
+	}
+	return db, path, nil
+}
+
+// diskSize is the database file plus its WAL
+func diskSize(path string) int64 {
+	var size int64
+	for _, name := range []string{path, path + "-wal"} {
+		if info, err := os.Stat(name); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// Vacuum rebuilds the database to give free pages back to the filesystem,
+// refreshes the query planner's statistics, and truncates the WAL
+func Vacuum() (VacuumResult, error) {
+	db, path, err := openMaintenance()
+	if err != nil {
+		return VacuumResult{}, err
+	}
+	defer db.Close()
+
+	start := time.Now()
+	result := VacuumResult{Before: diskSize(path)}
+	if _, err := db.Exec("VACUUM"); err != nil {
+		return result, fmt.Errorf("vacuum failed: %w", err)
+	}
+	if _, err := db.Exec("ANALYZE"); err != nil {
+		return result, fmt.Errorf("analyze failed: %w", err)
+	}
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return result, fmt.Errorf("checkpoint failed: %w", err)
+	}
+	result.After = diskSize(path)
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// IntegrityCheck runs SQLite's integrity and foreign key checks, returning
+// the problems found; none means the database is sound
+func IntegrityCheck() ([]string, error) {
+	db, _, err := openMaintenance()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var problems []string
+	rows, err := db.Query("PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	rows.Close()
+
+	rows, err = db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, fmt.Errorf("foreign key check failed: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, parent string
+		var rowid sql.NullInt64
+		var fkid int
+		if err := rows.Scan(&table, &rowid, &parent, &fkid); err != nil {
+			return nil, err
+		}
+		problems = append(problems, fmt.Sprintf("%s row %d points at a missing %s", table, rowid.Int64, parent))
+	}
+	return problems, rows.Err()
+}
+
+// quoteIdent quotes a table, index, or column name for SQL
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// GetStats measures the database and each table and index, largest first.
+// Sizes come from dbstat when SQLite has it, otherwise they are estimated
+// from the stored values' lengths.
+func GetStats() (Stats, error) {
+	db, path, err := openMaintenance()
+	if err != nil {
+		return Stats{}, err
+	}
+	defer db.Close()
+
+	stats := Stats{Path: path}
+	if pool, ok := PoolStats(); ok {
+		stats.Pool = &pool
+	}
+	if info, err := os.Stat(path); err == nil {
+		stats.FileSize = info.Size()
+	}
+	if info, err := os.Stat(path + "-wal"); err == nil {
+		stats.WALSize = info.Size()
+	}
+	for pragma, dest := range map[string]*int64{"page_size": &stats.PageSize, "page_count": &stats.PageCount, "freelist_count": &stats.FreePages} {
+		if err := db.QueryRow("PRAGMA " + pragma).Scan(dest); err != nil {
+			return Stats{}, fmt.Errorf("failed to read %s: %w", pragma, err)
+		}
+	}
+
+	// Virtual tables need their module loaded to read; their storage is
+	// in ordinary shadow tables, which are measured instead
+	rows, err := db.Query(`SELECT type, name, tbl_name FROM sqlite_master
+		WHERE type IN ('table', 'index') AND name NOT LIKE 'sqlite_%'
+		AND COALESCE(sql, '') NOT LIKE 'CREATE VIRTUAL TABLE%'`)
+	if err != nil {
+		return Stats{}, fmt.Errorf("failed to list tables: %w", err)
+	}
+	var objects []ObjectStats
+	for rows.Next() {
+		var kind string
+		var object ObjectStats
+		if err := rows.Scan(&kind, &object.Name, &object.Table); err != nil {
+			rows.Close()
+			return Stats{}, err
+		}
+		if kind == "table" {
+			object.Table = ""
+		}
+		objects = append(objects, object)
+	}
+	rows.Close()
+
+	exact := dbstatSizes(db)
+	tableRows := map[string]int64{}
+	for i := range objects {
+		object := &objects[i]
+		if object.Table != "" {
+			continue
+		}
+		columns, err := columnNames(db, "PRAGMA table_info("+quoteIdent(object.Name)+")", 1)
+		if err != nil {
+			return Stats{}, err
+		}
+		object.Rows, object.Bytes, err = measure(db, object.Name, columns)
+		if err != nil {
+			return Stats{}, err
+		}
+		tableRows[object.Name] = object.Rows
+	}
+	for i := range objects {
+		object := &objects[i]
+		if object.Table != "" {
+			object.Rows = tableRows[object.Table]
+			columns, err := columnNames(db, "PRAGMA index_info("+quoteIdent(object.Name)+")", 2)
+			if err != nil {
+				return Stats{}, err
+			}
+			_, keyBytes, err := measure(db, object.Table, columns)
+			if err != nil {
+				return Stats{}, err
+			}
+			object.Bytes = keyBytes + 8*object.Rows // Each entry also holds the rowid
+		}
+		if size, ok := exact[object.Name]; ok {
+			object.Bytes, object.Exact = size, true
+		}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool { return objects[i].Bytes > objects[j].Bytes })
+	stats.Objects = objects
+	return stats, nil
+}
+
+// dbstatSizes returns each table's and index's size on disk, or nothing
+// when SQLite was built without the dbstat table
+func dbstatSizes(db *sql.DB) map[string]int64 {
+	sizes := map[string]int64{}
+	rows, err := db.Query("SELECT name, SUM(pgsize) FROM dbstat GROUP BY name")
+	if err != nil {
+		return sizes
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var size int64
+		if rows.Scan(&name, &size) == nil {
+			sizes[name] = size
+		}
+	}
+	return sizes
+}
+
+// columnNames reads the column name field of a table_info or index_info
+// pragma, skipping expression columns
+func columnNames(db *sql.DB, pragma string, nameField int) ([]string, error) {
+	rows, err := db.Query(pragma)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	defer rows.Close()
+	fields, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for rows.Next() {
+		values := make([]any, len(fields))
+		var name sql.NullString
+		for i := range values {
+			values[i] = new(any)
+		}
+		values[nameField] = &name
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		if name.Valid {
+			names = append(names, name.String)
+		}
+	}
+	return names, rows.Err()
+}
+
+// measure counts a table's rows and the bytes stored in columns
+func measure(db *sql.DB, table string, columns []string) (int64, int64, error) {
+	lengths := []string{"0"}
+	for _, column := range columns {
+		lengths = append(lengths, "COALESCE(LENGTH("+quoteIdent(column)+"), 0)")
+	}
+	var rows, bytes int64
+	query := "SELECT COUNT(*), COALESCE(SUM(" + strings.Join(lengths, " + ") + "), 0) FROM " + quoteIdent(table)
+	if err := db.QueryRow(query).Scan(&rows, &bytes); err != nil {
+		return 0, 0, fmt.Errorf("failed to measure %s: %w", table, err)
+	}
+	return rows, bytes, nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestMaintenance_VacuumCheckStats verifies vacuum reclaims deleted space, a sound database passes the check, and stats cover tables and indexes.
+// BREAKS: If VACUUM runs on a pooled connection or the WAL isn't truncated, :db vacuum reports nothing reclaimed.
+func TestMaintenance_VacuumCheckStats(t *testing.T) {
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	filler := strings.Repeat("x", 4000)
+	for i := 0; i < 200; i++ {
+		if _, err := db.Exec(`INSERT INTO content (id, source_id, title, url, content) VALUES (?, 'test-source-1', 'filler', 'http://example.com', ?)`,
+			fmt.Sprintf("filler-%d", i), filler); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	if _, err := db.Exec("CREATE INDEX idx_content_title ON content(title)"); err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	if _, err := db.Exec("DELETE FROM content WHERE title = 'filler'"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	db.Close()
+
+	stats, err := GetStats()
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.FreePages == 0 || stats.PageSize == 0 {
+		t.Errorf("Expected free pages after the delete, got %+v", stats)
+	}
+	found := map[string]ObjectStats{}
+	for _, object := range stats.Objects {
+		found[object.Name] = object
+	}
+	if content := found["content"]; content.Rows == 0 || content.Bytes == 0 {
+		t.Errorf("Expected the content table measured, got %+v", content)
+	}
+	if index := found["idx_content_title"]; index.Table != "content" || index.Bytes == 0 {
+		t.Errorf("Expected the index measured against its table, got %+v", index)
+	}
+
+	result, err := Vacuum()
+	if err != nil {
+		t.Fatalf("Vacuum failed: %v", err)
+	}
+	if result.After >= result.Before {
+		t.Errorf("Expected vacuum to reclaim space, %d → %d bytes", result.Before, result.After)
+	}
+
+	problems, err := IntegrityCheck()
+	if err != nil || len(problems) != 0 {
+		t.Errorf("Expected a clean check, got %v (%v)", problems, err)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -18,15 +21,33 @@ type ContentItem struct {
 	Summary             string
 	Priority            string
 	Content             string
-	Analysis            string // JSON field containing reading_summary, alpha_insights, patterns, entities
+	Analysis            string // JSON blob; read it through ParsedAnalysis, change it with SetAnalysis
 	Published           time.Time
+	Fetched             time.Time // When the daemon fetched it (zero if unknown)
 	Read                bool
-	Favorited           bool   // Whether item is favorited
-	InterestingOverride bool   // Whether item is flagged as interesting for context analysis
-	UserFeedback        string // User feedback: "up", "down", or "" (empty = no vote)
-	SourceType          string // "rss", "reddit", "youtube", "file"
-	SourceName          string // Source name (e.g., "SimonW Blog", "r/rust", "3Blue1Brown")
-	SourceID            string // Source UUID for updates
+	Favorited           bool      // Whether item is favorited
+	InterestingOverride bool      // Whether item is flagged as interesting for context analysis
+	UserFeedback        string    // User feedback: "up", "down", or "" (empty = no vote)
+	SourceType          string    // "rss", "reddit", "youtube", "file"
+	SourceName          string    // Source name (e.g., "SimonW Blog", "r/rust", "3Blue1Brown")
+	SourceID            string    // Source UUID for updates
+	UserTags            []string  // User's own tags (lowercase), separate from LLM entities
+	SnoozedUntil        time.Time // Hidden from the list until this time (zero = not snoozed)
+	Pinned              bool      // Kept at the top of every view until unpinned
+	Author              string    // Byline from the feed or post ("" if not given)
+	Archived            bool      // Moved out of the active feed by retention
+	Partial             bool      // From a list query: no Content or reader-only analysis fields (see GetContentItemByID)
+	ContentLength       int       // Characters of content, set even when Partial
+
+	analysis *analysisCache // Parsed Analysis, see ParsedAnalysis
+}
+
+// Length is the content's length in characters, known even when Partial
+func (c ContentItem) Length() int {
+	if c.Partial {
+		return c.ContentLength
+	}
+	return utf8.RuneCountInString(c.Content)
 }
 
 // queryContent is a unified helper function for querying content with filters
@@ -41,13 +62,14 @@ func queryContentWithFilter(priorityFilter string, readFilter *bool, showUnprior
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
+	schema := schemaOf(db)
 	// Note: Don't close the pool connection - it's managed globally
 
 	// Build query with proper JOIN to get source info
 	query := `SELECT c.id, c.title, c.url, c.summary, c.priority, c.content, c.analysis,
-	                 c.published_at, c.read, c.favorited, c.interesting_override, c.user_feedback, s.type, s.name, c.source_id
+	                 c.published_at, c.read, c.favorited, ` + schema.column("interesting_override", "0") + `, ` + schema.column("user_feedback", "NULL") + `, s.type, COALESCE(s.name, '(removed source)'), COALESCE(c.source_id, '')
 	          FROM content c
-	          JOIN sources s ON c.source_id = s.id
+	          LEFT JOIN sources s ON c.source_id = s.id
 	          WHERE 1=1`
 
 	var args []interface{}
@@ -124,7 +146,7 @@ func queryContentWithFilter(priorityFilter string, readFilter *bool, showUnprior
 			item.Content = content.String
 		}
 		if analysis.Valid {
-			item.Analysis = analysis.String
+			item.SetAnalysis(analysis.String)
 		}
 		if userFeedback.Valid {
 			item.UserFeedback = userFeedback.String
@@ -176,35 +198,35 @@ func GetContentByPriority(priority string, showUnprioritized bool) ([]ContentIte
 	return items, hiddenCount, nil
 }
 
-// GetContentWithFilters fetches content with all filter options applied
-func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool, showArchived bool, showInteresting bool, filterType string, sortNewest bool) ([]ContentItem, int, error) {
+// FlagView reports whether priority names a view of flagged items: favorites
+// or interesting (upvoted). Flag views list every flagged item, read or not
+// and prioritized or not; the TUI's client-side filtering follows the same rule.
+func FlagView(priority string) bool {
+	return priority == "favorites" || priority == "interesting"
+}
+
+// GetContentWithFilters fetches content with all filter options applied.
+// since and until bound when items arrived (zero means unbounded). Items are
+// Partial, as from GetContentIn.
+func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool, showArchived bool, showInteresting bool, filterType string, sortNewest bool, since, until time.Time) ([]ContentItem, int, error) {
 	// Use singleton connection pool for efficiency
 	db, err := GetDB()
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get database connection: %w", err)
 	}
+	schema := schemaOf(db)
 
-	// Build query with proper JOIN to get source info
-	query := `SELECT c.id, c.title, c.url, c.summary, c.priority, c.content, c.analysis,
-	                 c.published_at, c.read, c.favorited, c.interesting_override, c.user_feedback, s.type, s.name, c.source_id
-	          FROM content c
-	          JOIN sources s ON c.source_id = s.id
-	          WHERE 1=1`
+	// List-level columns only: the reader loads content with GetContentItemByID
+	query := selectContent(schema, false) + ` WHERE 1=1`
 
 	var args []interface{}
 
 	// Add archived filter (default excludes archived)
-	if showArchived {
-		// Show only archived items
-		query += " AND c.archived_at IS NOT NULL"
-	} else {
-		// Default: exclude archived items
-		query += " AND c.archived_at IS NULL"
-	}
+	query += " AND " + schema.archivedClause(showArchived)
 
-	// Add read filter based on showAll flag (but skip for favorites)
-	if !showAll && priority != "favorites" {
-		// Show unread only (except for favorites which should always show)
+	// Add read filter based on showAll flag (but skip for flag views)
+	if !showAll && !FlagView(priority) {
+		// Show unread only (flagged items always show in their view)
 		query += " AND c.read = 0"
 	}
 
@@ -212,6 +234,8 @@ func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool
 	if priority == "favorites" {
 		// Special case for favorites - show only favorited items (regardless of read status)
 		query += " AND c.favorited = 1"
+	} else if priority == "interesting" {
+		query += " AND " + schema.column("user_feedback", "NULL") + " = 'up'"
 	} else if priority == "unprioritized" {
 		// Special case for unprioritized - show only items with NULL or empty priority
 		query += " AND (c.priority IS NULL OR c.priority = '')"
@@ -223,11 +247,11 @@ func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool
 	// Add upvoted filter if enabled (previously "interesting")
 	if showInteresting {
 		// Show only items user has upvoted
-		query += " AND c.user_feedback = 'up'"
+		query += " AND " + schema.column("user_feedback", "NULL") + " = 'up'"
 	}
 
-	// Filter out unprioritized content if requested (but not when showing interesting items)
-	if !showUnprioritized && !showInteresting {
+	// Filter out unprioritized content if requested (but not when showing flagged items)
+	if !showUnprioritized && !showInteresting && !FlagView(priority) {
 		query += " AND c.priority IS NOT NULL AND c.priority != ''"
 	}
 
@@ -237,6 +261,17 @@ func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool
 		args = append(args, filterType)
 	}
 
+	// Add arrival window: fetched_at, or published_at for items without one
+	arrived := "datetime(COALESCE(" + schema.column("fetched_at", "NULL") + ", c.published_at))"
+	if !since.IsZero() {
+		query += " AND " + arrived + " >= datetime(?)"
+		args = append(args, since.UTC().Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		query += " AND " + arrived + " < datetime(?)"
+		args = append(args, until.UTC().Format(time.RFC3339))
+	}
+
 	// Add sort order
 	if sortNewest {
 		query += " ORDER BY c.published_at DESC"
@@ -250,74 +285,9 @@ func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool
 	}
 	defer rows.Close()
 
-	var items []ContentItem
-	for rows.Next() {
-		var item ContentItem
-		var publishedStr sql.NullString
-		var priority sql.NullString
-		var summary sql.NullString
-		var content sql.NullString
-		var analysis sql.NullString
-		var userFeedback sql.NullString
-		var sourceType sql.NullString
-		var sourceName sql.NullString
-
-		err := rows.Scan(
-			&item.ID,
-			&item.Title,
-			&item.URL,
-			&summary,
-			&priority,
-			&content,
-			&analysis,
-			&publishedStr,
-			&item.Read,
-			&item.Favorited,
-			&item.InterestingOverride,
-			&userFeedback,
-			&sourceType,
-			&sourceName,
-			&item.SourceID,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
-		}
-
-		// Handle nullable fields
-		if priority.Valid {
-			item.Priority = priority.String
-		}
-		if summary.Valid {
-			item.Summary = summary.String
-		}
-		if content.Valid {
-			item.Content = content.String
-		}
-		if analysis.Valid {
-			item.Analysis = analysis.String
-		}
-		if userFeedback.Valid {
-			item.UserFeedback = userFeedback.String
-		}
-		if sourceType.Valid {
-			item.SourceType = sourceType.String
-		}
-		if sourceName.Valid {
-			item.SourceName = sourceName.String
-		}
-
-		// Parse published timestamp
-		if publishedStr.Valid {
-			if parsed, err := time.Parse(time.RFC3339, publishedStr.String); err == nil {
-				item.Published = parsed
-			}
-		}
-
-		items = append(items, item)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+	items, err := scanContent(rows, true)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Get count of hidden unprioritized items if filtering is active
@@ -333,36 +303,139 @@ func GetContentWithFilters(priority string, showUnprioritized bool, showAll bool
 	return items, hiddenCount, nil
 }
 
-// GetAllContent fetches all content with only archived filtering applied.
+// Predicate is an extra condition on content c joined with sources s, given
+// the database's schema so it can leave out columns the database predates
+type Predicate interface {
+	SQL(schema *Schema) (where string, args []any)
+}
+
+// ArchiveScope selects content by whether retention archived it
+type ArchiveScope int
+
+const (
+	ActiveContent   ArchiveScope = iota // Not archived (the feed)
+	ArchivedContent                     // Archived only
+	AnyContent                          // Both, for searches that reach into the archive
+)
+
+// Includes reports whether an item archived (or not) is in the scope
+func (s ArchiveScope) Includes(archived bool) bool {
+	switch s {
+	case AnyContent:
+		return true
+	case ArchivedContent:
+		return archived
+	default:
+		return !archived
+	}
+}
+
+// GetAllContent fetches all content with only archived filtering applied,
+// narrowed by any predicates (a :filter expression).
 // All other filtering (priority, read status, interesting, source type) happens client-side.
 // This unifies DB and API modes to use the same filtering logic in applyFiltersClientSide().
-func GetAllContent(showArchived bool) ([]ContentItem, error) {
+func GetAllContent(showArchived bool, predicates ...Predicate) ([]ContentItem, error) {
+	if showArchived {
+		return GetContentIn(ArchivedContent, predicates...)
+	}
+	return GetContentIn(ActiveContent, predicates...)
+}
+
+// ContentReader is implemented by predicates that also read each row's
+// content in Go, such as a regex SQLite can't run, so the rows they narrow
+// must carry the full text
+type ContentReader interface {
+	NeedsContent() bool
+}
+
+// heavyAnalysisFields are left out of the analysis list queries load: the
+// reader's sections, which GetContentItemByID brings when an item is opened
+var heavyAnalysisFields = []string{"deep_extraction", "alpha_insights", "patterns", "quotes", "tools", "urls"}
+
+// GetContentIn is GetAllContent for any archive scope. Its items are Partial:
+// without content and the heavy analysis fields, unless a predicate needs them.
+func GetContentIn(scope ArchiveScope, predicates ...Predicate) ([]ContentItem, error) {
 	db, err := GetDB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
+	schema := schemaOf(db)
+
+	full := false
+	for _, predicate := range predicates {
+		if reader, ok := predicate.(ContentReader); ok && reader.NeedsContent() {
+			full = true
+		}
+	}
 
 	// Minimal SQL - only archived filter applied server-side
-	query := `SELECT c.id, c.title, c.url, c.summary, c.priority, c.content, c.analysis,
-	                 c.published_at, c.read, c.favorited, c.interesting_override, c.user_feedback, s.type, s.name, c.source_id
-	          FROM content c
-	          JOIN sources s ON c.source_id = s.id
-	          WHERE `
+	query := selectContent(schema, full) + ` WHERE ` + schema.scopeClause(scope)
 
-	if showArchived {
-		query += "c.archived_at IS NOT NULL"
-	} else {
-		query += "c.archived_at IS NULL"
+	var args []any
+	for _, predicate := range predicates {
+		where, whereArgs := predicate.SQL(schema)
+		query += " AND (" + where + ")"
+		args = append(args, whereArgs...)
 	}
 
 	query += " ORDER BY c.published_at DESC"
 
-	rows, err := db.Query(query)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query content: %w", err)
 	}
 	defer rows.Close()
+	return scanContent(rows, !full)
+}
+
+// GetContentItemByID fetches one item in full, for the reader to open an
+// item a list query loaded Partial
+func GetContentItemByID(id string) (*ContentItem, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	rows, err := db.Query(selectContent(schemaOf(db), true)+` WHERE c.id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content: %w", err)
+	}
+	defer rows.Close()
+	items, err := scanContent(rows, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("content not found")
+	}
+	return &items[0], nil
+}
+
+// selectContent selects the columns scanContent reads from content c joined
+// with sources s. Items whose source was removed with their content kept
+// have no source and are listed under "(removed source)". Without full, content is left out (its length is kept) and
+// so are the heavy analysis fields.
+func selectContent(schema *Schema, full bool) string {
+	content, analysis := "c.content", "c.analysis"
+	if !full {
+		content = "NULL"
+		analysis = "CASE WHEN json_valid(c.analysis) THEN json_remove(c.analysis"
+		for _, field := range heavyAnalysisFields {
+			analysis += ", '$." + field + "'"
+		}
+		analysis += ") END"
+	}
+	return `SELECT c.id, c.title, c.url, c.summary, c.priority, ` + content + `, ` + analysis + `,
+	               c.published_at, c.read, c.favorited, ` + schema.column("interesting_override", "0") + `, ` + schema.column("user_feedback", "NULL") + `, s.type, COALESCE(s.name, '(removed source)'), COALESCE(c.source_id, ''),
+	               ` + schema.column("user_tags", "NULL") + `, ` + schema.column("snoozed_until", "NULL") + `, ` + schema.column("pinned", "NULL") + `, ` + schema.column("fetched_at", "NULL") + `, ` + schema.column("author", "NULL") + `,
+	               ` + schema.column("archived_at", "NULL") + ` IS NOT NULL, length(c.content)
+	        FROM content c
+	        LEFT JOIN sources s ON c.source_id = s.id`
+}
 
+// scanContent reads rows selected by selectContent, marking the items
+// Partial when content was left out
+func scanContent(rows *sql.Rows, partial bool) ([]ContentItem, error) {
 	var items []ContentItem
 	for rows.Next() {
 		var item ContentItem
@@ -374,6 +447,12 @@ func GetAllContent(showArchived bool) ([]ContentItem, error) {
 		var userFeedback sql.NullString
 		var sourceType sql.NullString
 		var sourceName sql.NullString
+		var userTags sql.NullString
+		var snoozedUntil sql.NullString
+		var pinned sql.NullBool
+		var fetchedStr sql.NullString
+		var author sql.NullString
+		var contentLength sql.NullInt64
 
 		err := rows.Scan(
 			&item.ID,
@@ -391,10 +470,19 @@ func GetAllContent(showArchived bool) ([]ContentItem, error) {
 			&sourceType,
 			&sourceName,
 			&item.SourceID,
+			&userTags,
+			&snoozedUntil,
+			&pinned,
+			&fetchedStr,
+			&author,
+			&item.Archived,
+			&contentLength,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		item.Partial = partial
+		item.ContentLength = int(contentLength.Int64)
 
 		if priority.Valid {
 			item.Priority = priority.String
@@ -406,7 +494,7 @@ func GetAllContent(showArchived bool) ([]ContentItem, error) {
 			item.Content = content.String
 		}
 		if analysis.Valid {
-			item.Analysis = analysis.String
+			item.SetAnalysis(analysis.String)
 		}
 		if userFeedback.Valid {
 			item.UserFeedback = userFeedback.String
@@ -417,23 +505,63 @@ func GetAllContent(showArchived bool) ([]ContentItem, error) {
 		if sourceName.Valid {
 			item.SourceName = sourceName.String
 		}
+		if userTags.Valid {
+			item.UserTags = SplitTags(userTags.String)
+		}
+		item.Pinned = pinned.Valid && pinned.Bool
+		if author.Valid {
+			item.Author = author.String
+		}
+		if snoozedUntil.Valid {
+			if parsed, err := time.Parse(time.RFC3339, snoozedUntil.String); err == nil {
+				item.SnoozedUntil = parsed
+			}
+		}
 
 		if publishedStr.Valid {
 			if parsed, err := time.Parse(time.RFC3339, publishedStr.String); err == nil {
 				item.Published = parsed
 			}
 		}
+		if fetchedStr.Valid {
+			item.Fetched = parseFetched(fetchedStr.String)
+		}
 
 		items = append(items, item)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
 	return items, nil
 }
 
+// parseFetched reads fetched_at, which is RFC 3339 when the daemon set it
+// and SQLite's CURRENT_TIMESTAMP (UTC) when the column default did
+func parseFetched(s string) time.Time {
+	if parsed, err := time.Parse(time.RFC3339, s); err == nil {
+		return parsed
+	}
+	if parsed, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+		return parsed
+	}
+	return time.Time{}
+}
+
+// SplitTags parses comma-separated tags, trimming, lowercasing, and
+// dropping empties and duplicates
+func SplitTags(s string) []string {
+	var tags []string
+	for _, part := range strings.Split(s, ",") {
+		tag := strings.ToLower(strings.TrimSpace(part))
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // dbPathFunc is a variable holding the function to get DB path (for testing)
 var dbPathFunc = getDefaultDBPath
 
@@ -463,6 +591,10 @@ type Source struct {
 	UnreadCount int
 	LastFetched *time.Time // When this source was last fetched
 	ErrorCount  int        // Number of errors
+	Category    string     // Category name ("" if uncategorized)
+	// Priority rule: the lowest and highest priority its items get, "" for none
+	PriorityFloor   string
+	PriorityCeiling string
 }
 
 // GetSourcesWithCounts fetches all sources with their unread item counts
@@ -474,6 +606,12 @@ func GetSourcesWithCounts() ([]Source, error) {
 	}
 	// Note: Don't close the pool connection - it's managed globally
 
+	// Priority rules, in databases from daemons that keep them
+	rules := "NULL, NULL"
+	if columns := tableColumns(db, "sources"); columns["priority_floor"] && columns["priority_ceiling"] {
+		rules = "s.priority_floor, s.priority_ceiling"
+	}
+
 	query := `
 		SELECT 
 			s.id,
@@ -483,7 +621,12 @@ func GetSourcesWithCounts() ([]Source, error) {
 			s.active,
 			COUNT(CASE WHEN c.read = 0 THEN 1 END) as unread_count,
 			s.last_fetched_at,
-			s.error_count
+			s.error_count,
+			(SELECT cat.name FROM source_categories sc
+			 JOIN categories cat ON cat.id = sc.category_id
+			 WHERE sc.source_id = s.id
+			 ORDER BY cat.name LIMIT 1) as category,
+			` + rules + `
 		FROM sources s
 		LEFT JOIN content c ON s.id = c.source_id
 		GROUP BY s.id, s.url, s.name, s.type, s.active, s.last_fetched_at, s.error_count
@@ -502,6 +645,8 @@ func GetSourcesWithCounts() ([]Source, error) {
 		var name sql.NullString
 		var lastFetchedStr sql.NullString
 		var errorCount sql.NullInt64
+		var category sql.NullString
+		var floor, ceiling sql.NullString
 
 		err := rows.Scan(
 			&source.ID,
@@ -512,6 +657,9 @@ func GetSourcesWithCounts() ([]Source, error) {
 			&source.UnreadCount,
 			&lastFetchedStr,
 			&errorCount,
+			&category,
+			&floor,
+			&ceiling,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -531,12 +679,65 @@ func GetSourcesWithCounts() ([]Source, error) {
 			source.ErrorCount = int(errorCount.Int64)
 		}
 
+		if category.Valid {
+			source.Category = category.String
+		}
+		source.PriorityFloor = floor.String
+		source.PriorityCeiling = ceiling.String
+
 		sources = append(sources, source)
 	}
 
 	return sources, nil
 }
 
+// GetSourceDailyCounts counts each source's items per day by publish date,
+// over the last days days (local time, ending today). Each slice is oldest
+// day first; sources with nothing in the window are left out.
+func GetSourceDailyCounts(days int) (map[string][]int, error) {
+	db, err := GetDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -(days - 1))
+	// A day of slack on the string comparison covers timezone offsets
+	cutoff := first.AddDate(0, 0, -1).UTC().Format(time.RFC3339)
+	rows, err := db.Query(`SELECT source_id, published_at FROM content
+		WHERE source_id IS NOT NULL AND published_at >= ?`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string][]int)
+	for rows.Next() {
+		var sourceID, publishedStr string
+		if err := rows.Scan(&sourceID, &publishedStr); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		published, err := time.Parse(time.RFC3339, publishedStr)
+		if err != nil {
+			continue
+		}
+		local := published.Local()
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+		index := int(day.Sub(first).Hours()+12) / 24 // Rounded: DST days aren't 24h
+		if index < 0 || index >= days {
+			continue
+		}
+		if counts[sourceID] == nil {
+			counts[sourceID] = make([]int, days)
+		}
+		counts[sourceID][index]++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return counts, nil
+}
+
 // getDBPath returns the path to the SQLite database
 func getDBPath() (string, error) {
 	return dbPathFunc()
@@ -555,12 +756,13 @@ func GetUnprioritizedContent(showAll bool) ([]ContentItem, int, error) {
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get database connection: %w", err)
 	}
+	schema := schemaOf(db)
 
 	// Build query for items with NULL or empty priority
 	query := `SELECT c.id, c.title, c.url, c.summary, c.priority, c.content, c.analysis, 
-	                 c.published_at, c.read, c.favorited, c.interesting_override, c.user_feedback, s.type, s.name, c.source_id
+	                 c.published_at, c.read, c.favorited, ` + schema.column("interesting_override", "0") + `, ` + schema.column("user_feedback", "NULL") + `, s.type, COALESCE(s.name, '(removed source)'), COALESCE(c.source_id, '')
 	          FROM content c
-	          JOIN sources s ON c.source_id = s.id
+	          LEFT JOIN sources s ON c.source_id = s.id
 	          WHERE (c.priority IS NULL OR c.priority = '')`
 
 	// Add read filter based on showAll flag
@@ -621,7 +823,7 @@ func GetUnprioritizedContent(showAll bool) ([]ContentItem, int, error) {
 			item.Content = content.String
 		}
 		if analysis.Valid {
-			item.Analysis = analysis.String
+			item.SetAnalysis(analysis.String)
 		}
 		if userFeedback.Valid {
 			item.UserFeedback = userFeedback.String
@@ -679,6 +881,10 @@ func GetArchivedCount() (int, error) {
 		return 0, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	if !schemaOf(db).Has("archived_at") {
+		return 0, nil // Nothing can be archived before the column exists
+	}
+
 	var count int
 	err = db.QueryRow(`
 		SELECT COUNT(*) FROM content
@@ -739,6 +945,9 @@ func SetUserFeedback(contentID string, vote string) error {
 	if vote != "" && vote != "up" && vote != "down" {
 		return fmt.Errorf("invalid vote value: %s (must be 'up', 'down', or empty)", vote)
 	}
+	if err := schemaOf(db).Require(SchemaFeedback); err != nil {
+		return err
+	}
 
 	// Use NULL for empty string to match database schema
 	var voteValue interface{}
@@ -763,11 +972,83 @@ func CountUpvotedItems() (int, error) {
 		return 0, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	schema := schemaOf(db)
+	if !schema.Has("user_feedback") {
+		return 0, nil
+	}
+
 	var count int
-	err = db.QueryRow("SELECT COUNT(*) FROM content WHERE user_feedback = 'up' AND archived_at IS NULL").Scan(&count)
+	err = db.QueryRow("SELECT COUNT(*) FROM content c WHERE c.user_feedback = 'up' AND " + schema.archivedClause(false)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count upvoted items: %w", err)
 	}
 
 	return count, nil
 }
+
+// DeleteSource removes a source directly from the local database, handling its
+// content per retention: "delete" removes items (favorites are detached instead),
+// "keep" detaches items, "archive" detaches and archives them.
+// Returns the number of content items affected.
+func DeleteSource(sourceID string, retention string) (int, error) {
+	db, err := GetDB()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	return deleteSource(db, sourceID, retention)
+}
+
+// deleteSource performs DeleteSource against the given connection in one transaction
+func deleteSource(db *sql.DB, sourceID string, retention string) (int, error) {
+	if retention == "archive" {
+		// Checked before the transaction holds what may be the only connection
+		if err := schemaOf(db).Require(SchemaArchive); err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var affected int64
+	switch retention {
+	case "delete":
+		res, err := tx.Exec("DELETE FROM content WHERE source_id = ? AND favorited = 0", sourceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete content: %w", err)
+		}
+		affected, _ = res.RowsAffected()
+	case "archive":
+		if _, err := tx.Exec("UPDATE content SET archived_at = CURRENT_TIMESTAMP WHERE source_id = ? AND archived_at IS NULL", sourceID); err != nil {
+			return 0, fmt.Errorf("failed to archive content: %w", err)
+		}
+	case "keep":
+	default:
+		return 0, fmt.Errorf("invalid retention: %s (must be 'delete', 'keep', or 'archive')", retention)
+	}
+
+	// Detach whatever remains so ON DELETE CASCADE doesn't take it with the source
+	res, err := tx.Exec("UPDATE content SET source_id = NULL WHERE source_id = ?", sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to detach content: %w", err)
+	}
+	if retention != "delete" {
+		affected, _ = res.RowsAffected()
+	}
+
+	res, err = tx.Exec("DELETE FROM sources WHERE id = ?", sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete source: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return 0, fmt.Errorf("source not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return int(affected), nil
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -480,7 +481,7 @@ func TestFavoritesFilter_ShowsReadItems(t *testing.T) {
 	}()
 
 	// Get favorites with showAll=false (should still show read favorites)
-	items, _, err := GetContentWithFilters("favorites", true, false, false, false, "all", true)
+	items, _, err := GetContentWithFilters("favorites", true, false, false, false, "all", true, time.Time{}, time.Time{})
 	if err != nil {
 		t.Fatalf("GetContentWithFilters failed: %v", err)
 	}
@@ -545,7 +546,7 @@ func TestFavoritesPersistAcrossStatusChanges(t *testing.T) {
 	}
 
 	// Verify it still appears in favorites filter
-	items, _, err := GetContentWithFilters("favorites", true, false, false, false, "all", true)
+	items, _, err := GetContentWithFilters("favorites", true, false, false, false, "all", true, time.Time{}, time.Time{})
 	if err != nil {
 		t.Fatalf("GetContentWithFilters failed: %v", err)
 	}
@@ -566,6 +567,41 @@ func TestFavoritesPersistAcrossStatusChanges(t *testing.T) {
 	}
 }
 
+// TestInterestingFilter_MatchesFavorites verifies the interesting view lists upvoted items read or unprioritized, as favorites does.
+// BREAKS: Upvoting an item and reading it drops it from the view meant to collect it.
+func TestInterestingFilter_MatchesFavorites(t *testing.T) {
+	resetDBForTest(t)
+	dbPath := createTestDB(t)
+	oldFunc := dbPathFunc
+	dbPathFunc = func() (string, error) {
+		return dbPath, nil
+	}
+	defer func() {
+		dbPathFunc = oldFunc
+		CloseDB()
+	}()
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`UPDATE content SET user_feedback = 'up' WHERE id IN ('1', '5', '6')`); err != nil {
+		t.Fatal(err)
+	}
+
+	items, _, err := GetContentWithFilters("interesting", false, false, false, false, "all", true, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("GetContentWithFilters failed: %v", err)
+	}
+	got := map[string]bool{}
+	for _, item := range items {
+		got[item.ID] = true
+	}
+	if len(items) != 3 || !got["1"] || !got["5"] || !got["6"] {
+		t.Errorf("Expected upvoted items 1, 5 (unprioritized) and 6 (read), got %v", got)
+	}
+}
+
 // TestZGetFavoritesCount_HandlesDBError tests graceful handling of database errors
 // Named with Z prefix to run last due to connection pool contamination
 func TestZGetFavoritesCount_HandlesDBError(t *testing.T) {
@@ -787,3 +823,254 @@ func TestGetAllContent_ArchivedFilter(t *testing.T) {
 		t.Errorf("Expected archived item ID '1', got '%s'", archived[0].ID)
 	}
 }
+
+func TestGetAllContent_UserTags(t *testing.T) {
+	// INVARIANT: user_tags load when the column exists and are simply empty before `make migrate`
+	// BREAKS: Local mode fails to start on databases that predate the column
+
+	resetDBForTest(t)
+	dbPath := createTestDB(t)
+
+	oldFunc := dbPathFunc
+	dbPathFunc = func() (string, error) {
+		return dbPath, nil
+	}
+	defer func() {
+		dbPathFunc = oldFunc
+	}()
+
+	items, err := GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed without user_tags column: %v", err)
+	}
+	for _, item := range items {
+		if len(item.UserTags) != 0 {
+			t.Errorf("Expected no tags before migration, got %v", item.UserTags)
+		}
+	}
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("ALTER TABLE content ADD COLUMN user_tags TEXT"); err != nil {
+		t.Fatalf("Failed to add column: %v", err)
+	}
+	if _, err := db.Exec("UPDATE content SET user_tags = 'rust, Career,,rust' WHERE id = '1'"); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err = GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	for _, item := range items {
+		if item.ID == "1" && strings.Join(item.UserTags, ",") != "rust,career" {
+			t.Errorf("Expected tags [rust career], got %v", item.UserTags)
+		}
+	}
+}
+
+func TestGetAllContent_SnoozedUntil(t *testing.T) {
+	// INVARIANT: snoozed_until loads as a UTC time and is zero before `make migrate`
+	// BREAKS: Local mode shows snoozed items, or fails on databases without the column
+
+	resetDBForTest(t)
+	dbPath := createTestDB(t)
+
+	oldFunc := dbPathFunc
+	dbPathFunc = func() (string, error) {
+		return dbPath, nil
+	}
+	defer func() {
+		dbPathFunc = oldFunc
+	}()
+
+	items, err := GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed without snoozed_until column: %v", err)
+	}
+	for _, item := range items {
+		if !item.SnoozedUntil.IsZero() {
+			t.Errorf("Expected no snooze before migration, got %v", item.SnoozedUntil)
+		}
+	}
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("ALTER TABLE content ADD COLUMN snoozed_until TIMESTAMP DEFAULT NULL"); err != nil {
+		t.Fatalf("Failed to add column: %v", err)
+	}
+	if _, err := db.Exec("UPDATE content SET snoozed_until = '2026-05-18T07:00:00Z' WHERE id = '1'"); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err = GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	want := time.Date(2026, 5, 18, 7, 0, 0, 0, time.UTC)
+	for _, item := range items {
+		if item.ID == "1" && !item.SnoozedUntil.Equal(want) {
+			t.Errorf("Expected snoozed until %v, got %v", want, item.SnoozedUntil)
+		}
+		if item.ID != "1" && !item.SnoozedUntil.IsZero() {
+			t.Errorf("Expected item %s not snoozed, got %v", item.ID, item.SnoozedUntil)
+		}
+	}
+}
+
+// TestGetContentWithFilters_ArrivalWindow verifies since/until bound items by fetched_at, falling back to published_at.
+// BREAKS: If the bounds compare raw strings, mixed timestamp formats from the daemon slip through or vanish.
+func TestGetContentWithFilters_ArrivalWindow(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("ALTER TABLE content ADD COLUMN fetched_at TIMESTAMP"); err != nil {
+		t.Fatal(err)
+	}
+	// An old post fetched an hour ago (SQLite's default format), and one fetched last week
+	lastHour := time.Now().UTC().Add(-time.Hour).Format("2006-01-02 15:04:05")
+	if _, err := db.Exec("UPDATE content SET published_at = '2020-01-01T00:00:00Z', fetched_at = ? WHERE id = '1'", lastHour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("UPDATE content SET fetched_at = ? WHERE id = '3'", time.Now().AddDate(0, 0, -7).Format(time.RFC3339)); err != nil {
+		t.Fatal(err)
+	}
+
+	items, _, err := GetContentWithFilters("all", true, true, false, false, "all", true, time.Now().AddDate(0, 0, -2), time.Time{})
+	if err != nil {
+		t.Fatalf("GetContentWithFilters failed: %v", err)
+	}
+	found := map[string]ContentItem{}
+	for _, item := range items {
+		found[item.ID] = item
+	}
+	if _, ok := found["1"]; !ok {
+		t.Errorf("Expected the recently fetched old post, got %v", found)
+	}
+	if _, ok := found["3"]; ok {
+		t.Error("Expected last week's fetch excluded")
+	}
+	if found["1"].Fetched.IsZero() {
+		t.Error("Expected fetched_at loaded")
+	}
+
+	items, _, err = GetContentWithFilters("all", true, true, false, false, "all", true, time.Time{}, time.Now().AddDate(0, 0, -2))
+	if err != nil || len(items) != 1 || items[0].ID != "3" {
+		t.Errorf("Expected only last week's item before the bound, got %v (%v)", items, err)
+	}
+}
+
+// TestGetAllContent_Author verifies the author column loads when present and is empty before `make migrate`.
+// BREAKS: If author is selected unconditionally, local mode fails on databases that predate the column.
+func TestGetAllContent_Author(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	if _, err := GetAllContent(false); err != nil {
+		t.Fatalf("GetAllContent failed without the author column: %v", err)
+	}
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("ALTER TABLE content ADD COLUMN author TEXT"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("UPDATE content SET author = 'Jane Doe' WHERE id = '1'"); err != nil {
+		t.Fatal(err)
+	}
+	items, err := GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	for _, item := range items {
+		if want := map[bool]string{true: "Jane Doe"}[item.ID == "1"]; item.Author != want {
+			t.Errorf("Item %s: expected author %q, got %q", item.ID, want, item.Author)
+		}
+	}
+}
+
+// textPredicate matches content containing a word, the way a text filter does
+type textPredicate string
+
+func (p textPredicate) SQL(*Schema) (string, []any) {
+	return "c.content LIKE ?", []any{"%" + string(p) + "%"}
+}
+
+func (p textPredicate) NeedsContent() bool { return true }
+
+// TestGetAllContent_LeavesContentOut verifies list queries skip content and the reader-only analysis fields, which GetContentItemByID loads.
+// BREAKS: If list queries select content, every refresh reads every article's full text.
+func TestGetAllContent_LeavesContentOut(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	db, err := GetDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysis := `{"reading_summary": "Short", "quotes": ["Long quote"], "deep_extraction": {"synthesis": "Deep"}}`
+	if _, err := db.Exec("UPDATE content SET content = 'Full article text', analysis = ? WHERE id = '1'", analysis); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := GetAllContent(false)
+	if err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	for _, item := range items {
+		if item.ID != "1" {
+			continue
+		}
+		if !item.Partial || item.Content != "" {
+			t.Errorf("Expected a partial item without content, got partial=%v content=%q", item.Partial, item.Content)
+		}
+		if item.Length() != len("Full article text") {
+			t.Errorf("Expected length %d, got %d", len("Full article text"), item.Length())
+		}
+		parsed := item.ParsedAnalysis()
+		if parsed.ReadingSummary != "Short" || len(parsed.Quotes) != 0 || parsed.DeepExtraction != nil {
+			t.Errorf("Expected only list-level analysis, got %+v", parsed)
+		}
+	}
+
+	items, err = GetAllContent(false, textPredicate("article"))
+	if err != nil {
+		t.Fatalf("GetAllContent failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Partial || items[0].Content != "Full article text" {
+		t.Errorf("Expected a text predicate to select full items, got %+v", items)
+	}
+
+	full, err := GetContentItemByID("1")
+	if err != nil {
+		t.Fatalf("GetContentItemByID failed: %v", err)
+	}
+	if full.Partial || full.Content != "Full article text" || full.ParsedAnalysis().DeepExtraction == nil {
+		t.Errorf("Expected the full item, got %+v", full)
+	}
+	if _, err := GetContentItemByID("missing"); err == nil {
+		t.Error("Expected an error for a missing item")
+	}
+}
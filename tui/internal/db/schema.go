@@ -0,0 +1,251 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Schema features: what each column added by `make migrate` enables
+const (
+	SchemaArchive  = "archive"
+	SchemaFeedback = "feedback"
+	SchemaTags     = "tags"
+	SchemaSnooze   = "snooze"
+	SchemaPins     = "pins"
+	SchemaAuthor   = "author"
+)
+
+// schemaMigrations are the content columns added since the first schema,
+// oldest first; the schema version counts the ones a database has in order
+var schemaMigrations = []struct {
+	column  string
+	feature string
+}{
+	{"archived_at", SchemaArchive},
+	{"interesting_override", ""}, // Superseded by user_feedback; only read
+	{"user_feedback", SchemaFeedback},
+	{"user_tags", SchemaTags},
+	{"snoozed_until", SchemaSnooze},
+	{"pinned", SchemaPins},
+	{"author", SchemaAuthor},
+}
+
+// SchemaVersion is the content schema this TUI is written against
+var SchemaVersion = len(schemaMigrations)
+
+// baseColumns are the content columns every daemon database has had
+var baseColumns = []string{
+	"id", "source_id", "external_id", "title", "url", "content", "summary", "analysis", "priority",
+	"published_at", "fetched_at", "read", "favorited", "notes", "created_at", "updated_at",
+}
+
+// Schema is what the local database's content table supports
+type Schema struct {
+	Version int      // Migrations applied, counted until the first missing one
+	Missing []string // Migration columns the database lacks: it's older than the TUI
+	Unknown []string // Columns the TUI doesn't know: the daemon is newer than the TUI
+	columns map[string]bool
+}
+
+// detectSchema reads the content table's columns
+func detectSchema(db *sql.DB) (*Schema, error) {
+	rows, err := db.Query("SELECT name FROM pragma_table_info('content')")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	defer rows.Close()
+	schema := &Schema{columns: map[string]bool{}}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read schema: %w", err)
+		}
+		schema.columns[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	if len(schema.columns) == 0 {
+		return nil, fmt.Errorf("database has no content table; start the daemon once to create it")
+	}
+
+	inOrder := true
+	for _, migration := range schemaMigrations {
+		if !schema.columns[migration.column] {
+			schema.Missing = append(schema.Missing, migration.column)
+			inOrder = false
+		} else if inOrder {
+			schema.Version++
+		}
+	}
+	for name := range schema.columns {
+		known := slices.Contains(baseColumns, name) || slices.ContainsFunc(schemaMigrations, func(m struct {
+			column  string
+			feature string
+		}) bool {
+			return m.column == name
+		})
+		if !known {
+			schema.Unknown = append(schema.Unknown, name)
+		}
+	}
+	slices.Sort(schema.Unknown)
+	return schema, nil
+}
+
+// tableColumns reads the names of table's columns, none if it can't be read
+func tableColumns(db *sql.DB, table string) map[string]bool {
+	columns := map[string]bool{}
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return columns
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if rows.Scan(&name) == nil {
+			columns[name] = true
+		}
+	}
+	return columns
+}
+
+// GetSchema returns the local database's schema, read when the pool connected
+func GetSchema() (*Schema, error) {
+	if _, err := GetDB(); err != nil {
+		return nil, err
+	}
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	schema := dbSchema.Load()
+	if schema == nil {
+		return nil, dbSchemaErr
+	}
+	return schema, nil
+}
+
+// schemaOf returns the schema of db: the pool's from connection time, or read
+// now for other connections. Nil (everything assumed present) if unreadable.
+func schemaOf(db *sql.DB) *Schema {
+	pooled := isPool(db)
+	if cached := dbSchema.Load(); pooled && cached != nil && len(cached.Missing) == 0 {
+		return cached
+	}
+	// Older databases are read again so `make migrate` applies without a restart
+	schema, err := detectSchema(db)
+	if err != nil {
+		return nil
+	}
+	if pooled {
+		dbMu.Lock()
+		if db == dbPool { // Not closed while the schema was read
+			dbSchema.Store(schema)
+		}
+		dbMu.Unlock()
+	}
+	return schema
+}
+
+// isPool reports whether db is the shared pool
+func isPool(db *sql.DB) bool {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	return db == dbPool
+}
+
+// Has reports whether the content table has column. A nil schema has them all.
+func (s *Schema) Has(column string) bool {
+	return s == nil || s.columns[column]
+}
+
+// Supports reports whether the database has the columns a schema feature
+// needs; features the schema doesn't govern are always supported
+func (s *Schema) Supports(feature string) bool {
+	for _, migration := range schemaMigrations {
+		if migration.feature == feature && feature != "" {
+			return s.Has(migration.column)
+		}
+	}
+	return true
+}
+
+// Require returns a SchemaError when the database lacks feature
+func (s *Schema) Require(feature string) error {
+	if s.Supports(feature) {
+		return nil
+	}
+	return &SchemaError{Feature: feature}
+}
+
+// MissingFeatures lists the features the database is too old for
+func (s *Schema) MissingFeatures() []string {
+	var missing []string
+	for _, migration := range schemaMigrations {
+		if migration.feature != "" && !s.Has(migration.column) {
+			missing = append(missing, migration.feature)
+		}
+	}
+	return missing
+}
+
+// Message explains a mismatch between the database and this TUI, or is
+// empty when they agree
+func (s *Schema) Message() string {
+	if s == nil {
+		return ""
+	}
+	if len(s.Missing) > 0 {
+		message := fmt.Sprintf("The daemon database is older than this TUI (schema %d of %d): run `make migrate`", s.Version, SchemaVersion)
+		if features := s.MissingFeatures(); len(features) > 0 {
+			message += "; " + strings.Join(features, ", ") + " unavailable until then"
+		}
+		return message
+	}
+	if len(s.Unknown) > 0 {
+		return fmt.Sprintf("The daemon database is newer than this TUI (new columns: %s): update the TUI", strings.Join(s.Unknown, ", "))
+	}
+	return ""
+}
+
+// column selects c.<name>, or fallback on databases that predate it
+func (s *Schema) column(name, fallback string) string {
+	if s.Has(name) {
+		return "c." + name
+	}
+	return fallback
+}
+
+// archivedClause filters to archived or active items; without archived_at
+// nothing is archived
+func (s *Schema) archivedClause(archived bool) string {
+	switch {
+	case !s.Has("archived_at"):
+		if archived {
+			return "0"
+		}
+		return "1"
+	case archived:
+		return "c.archived_at IS NOT NULL"
+	default:
+		return "c.archived_at IS NULL"
+	}
+}
+
+// scopeClause is archivedClause for an archive scope
+func (s *Schema) scopeClause(scope ArchiveScope) string {
+	if scope == AnyContent {
+		return "1"
+	}
+	return s.archivedClause(scope == ArchivedContent)
+}
+
+// SchemaError reports a feature the local database is too old for
+type SchemaError struct {
+	Feature string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s needs a newer database schema: run `make migrate`", e.Feature)
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// createOldTestDB creates a database from before `make migrate` added any
+// content columns, plus extra columns a newer daemon might have
+func createOldTestDB(t *testing.T, extra ...string) string {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer db.Close()
+
+	columns := ""
+	for _, name := range extra {
+		columns += ", " + name + " TEXT"
+	}
+	schema := `
+	CREATE TABLE sources (id TEXT PRIMARY KEY, name TEXT, type TEXT, url TEXT NOT NULL, active BOOLEAN DEFAULT 1);
+	CREATE TABLE content (
+		id TEXT PRIMARY KEY, source_id TEXT REFERENCES sources(id), external_id TEXT, title TEXT NOT NULL,
+		url TEXT NOT NULL, content TEXT, summary TEXT, analysis TEXT, priority TEXT, published_at TIMESTAMP,
+		fetched_at TIMESTAMP, read BOOLEAN DEFAULT 0, favorited BOOLEAN DEFAULT 0, notes TEXT,
+		created_at TIMESTAMP, updated_at TIMESTAMP` + columns + `
+	);
+	INSERT INTO sources (id, name, type, url) VALUES ('s1', 'Feed', 'rss', 'http://example.com/feed');
+	INSERT INTO content (id, source_id, title, url, priority, read) VALUES ('1', 's1', 'Old item', 'http://example.com/1', 'high', 0);`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	return dbPath
+}
+
+// TestSchema_OlderDatabase verifies queries work on a database missing every migrated column, and the schema says what's unavailable.
+// BREAKS: If queries name migrated columns directly, local mode fails with "no such column" on an unmigrated daemon database.
+func TestSchema_OlderDatabase(t *testing.T) {
+	resetDBForTest(t)
+	defer resetDBForTest(t)
+	dbPath := createOldTestDB(t)
+	originalDBPathFunc := dbPathFunc
+	dbPathFunc = func() (string, error) { return dbPath, nil }
+	defer func() { dbPathFunc = originalDBPathFunc }()
+
+	schema, err := GetSchema()
+	if err != nil {
+		t.Fatalf("GetSchema failed: %v", err)
+	}
+	if schema.Version != 0 || len(schema.Missing) != len(schemaMigrations) || len(schema.Unknown) != 0 {
+		t.Errorf("Expected version 0 with everything missing, got %+v", schema)
+	}
+	if message := schema.Message(); !strings.Contains(message, "older than this TUI") || !strings.Contains(message, "make migrate") ||
+		!strings.Contains(message, "archive, feedback, tags, snooze, pins") {
+		t.Errorf("Expected an older-database message naming the features, got %q", message)
+	}
+
+	items, err := GetAllContent(false)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Expected the item without archived_at, got %v (%v)", items, err)
+	}
+	if archived, err := GetAllContent(true); err != nil || len(archived) != 0 {
+		t.Errorf("Expected no archived items, got %v (%v)", archived, err)
+	}
+	if _, _, err := GetContentWithFilters("", true, true, false, true, "", true, time.Time{}, time.Time{}); err != nil {
+		t.Errorf("GetContentWithFilters failed: %v", err)
+	}
+	if _, err := queryContent("high", nil); err != nil {
+		t.Errorf("queryContent failed: %v", err)
+	}
+	if count, err := GetArchivedCount(); err != nil || count != 0 {
+		t.Errorf("Expected 0 archived, got %d (%v)", count, err)
+	}
+	if count, err := CountUpvotedItems(); err != nil || count != 0 {
+		t.Errorf("Expected 0 upvoted, got %d (%v)", count, err)
+	}
+
+	var schemaErr *SchemaError
+	if err := SetUserFeedback("1", "up"); !errors.As(err, &schemaErr) || schemaErr.Feature != SchemaFeedback {
+		t.Errorf("Expected a feedback SchemaError, got %v", err)
+	}
+	if _, err := DeleteSource("s1", "archive"); !errors.As(err, &schemaErr) {
+		t.Errorf("Expected archiving refused, got %v", err)
+	}
+}
+
+// TestSchema_NewerDatabase verifies columns this TUI doesn't know are reported as a newer database.
+// BREAKS: If unknown columns go unreported, an outdated TUI silently ignores what a newer daemon stores.
+func TestSchema_NewerDatabase(t *testing.T) {
+	dbPath := createOldTestDB(t, "archived_at", "interesting_override", "user_feedback", "user_tags", "snoozed_until", "pinned", "author", "reading_time", "language")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	schema, err := detectSchema(db)
+	if err != nil {
+		t.Fatalf("detectSchema failed: %v", err)
+	}
+	if schema.Version != SchemaVersion || len(schema.Missing) != 0 {
+		t.Errorf("Expected a current schema, got %+v", schema)
+	}
+	if !slices.Equal(schema.Unknown, []string{"language", "reading_time"}) {
+		t.Errorf("Expected the new columns, got %v", schema.Unknown)
+	}
+	if message := schema.Message(); !strings.Contains(message, "newer than this TUI") || !strings.Contains(message, "reading_time") {
+		t.Errorf("Expected a newer-database message, got %q", message)
+	}
+	if !schema.Supports(SchemaPins) || schema.Require(SchemaArchive) != nil {
+		t.Error("Expected every feature supported")
+	}
+}
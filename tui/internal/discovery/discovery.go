@@ -0,0 +1,175 @@
+// Package discovery finds prismis daemons on the local network over mDNS.
+package discovery
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Service is the DNS-SD service type the daemon advertises
+const Service = "_prismis._tcp.local."
+
+// mdnsAddr is where queries are sent: the mDNS multicast group (for testing)
+var mdnsAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// Daemon is a prismis daemon that answered a discovery query
+type Daemon struct {
+	Name    string // Instance name, usually the server's hostname
+	Host    string // Advertised host, e.g. "server.local."
+	Addr    net.IP
+	Port    int
+	Version string // Daemon version from the TXT record, if given
+}
+
+// URL returns the daemon's API base URL
+func (d Daemon) URL() string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(d.Addr.String(), fmt.Sprint(d.Port)))
+}
+
+// Matches reports whether query names this daemon: its instance name, host
+// (with or without ".local"), or address, ignoring case
+func (d Daemon) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(query), "."))
+	host := strings.TrimSuffix(d.Host, ".")
+	return query == strings.ToLower(d.Name) ||
+		query == host ||
+		query == strings.TrimSuffix(host, ".local") ||
+		query == d.Addr.String()
+}
+
+// Browse asks the LAN for prismis daemons and collects the answers that
+// arrive within timeout. The query goes out from an ephemeral port, so
+// daemons answer with plain unicast DNS and no mDNS stack is needed here.
+func Browse(timeout time.Duration) ([]Daemon, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open discovery socket: %w", err)
+	}
+	defer conn.Close()
+
+	var idBytes [2]byte
+	_, _ = rand.Read(idBytes[:])
+	id := binary.BigEndian.Uint16(idBytes[:])
+	query, err := buildQuery(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteToUDP(query, mdnsAddr); err != nil {
+		return nil, fmt.Errorf("failed to send discovery query: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	found := map[string]Daemon{}
+	buf := make([]byte, 9000)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			return nil, fmt.Errorf("failed to read discovery reply: %w", err)
+		}
+		for _, d := range parseResponse(buf[:n], id) {
+			found[d.URL()] = d
+		}
+	}
+
+	daemons := make([]Daemon, 0, len(found))
+	for _, d := range found {
+		daemons = append(daemons, d)
+	}
+	sort.Slice(daemons, func(i, j int) bool {
+		if daemons[i].Name != daemons[j].Name {
+			return daemons[i].Name < daemons[j].Name
+		}
+		return daemons[i].URL() < daemons[j].URL()
+	})
+	return daemons, nil
+}
+
+// buildQuery builds a PTR query for the prismis service asking for a
+// unicast reply (the QU bit in the question class)
+func buildQuery(id uint16) ([]byte, error) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: id},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(Service),
+			Type:  dnsmessage.TypePTR,
+			Class: dnsmessage.ClassINET | 1<<15,
+		}},
+	}
+	packet, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery query: %w", err)
+	}
+	return packet, nil
+}
+
+// parseResponse extracts the daemons a reply to query id describes,
+// joining each PTR to its SRV, TXT and A records. Replies to other
+// queries, other services, and instances without an address are dropped.
+func parseResponse(packet []byte, id uint16) []Daemon {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(packet); err != nil || !msg.Header.Response || msg.Header.ID != id {
+		return nil
+	}
+
+	var instances []string
+	srv := map[string]*dnsmessage.SRVResource{}
+	txt := map[string][]string{}
+	addrs := map[string]net.IP{}
+	for _, rr := range append(msg.Answers, msg.Additionals...) {
+		name := strings.ToLower(rr.Header.Name.String())
+		switch body := rr.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if name == Service {
+				instances = append(instances, strings.ToLower(body.PTR.String()))
+			}
+		case *dnsmessage.SRVResource:
+			srv[name] = body
+		case *dnsmessage.TXTResource:
+			txt[name] = body.TXT
+		case *dnsmessage.AResource:
+			if _, ok := addrs[name]; !ok {
+				addrs[name] = net.IP(body.A[:])
+			}
+		}
+	}
+
+	var daemons []Daemon
+	for _, instance := range instances {
+		record, ok := srv[instance]
+		if !ok {
+			continue
+		}
+		host := strings.ToLower(record.Target.String())
+		addr, ok := addrs[host]
+		if !ok {
+			continue
+		}
+		d := Daemon{
+			Name: strings.TrimSuffix(instance, "."+Service),
+			Host: host,
+			Addr: addr,
+			Port: int(record.Port),
+		}
+		for _, entry := range txt[instance] {
+			if value, ok := strings.CutPrefix(entry, "version="); ok {
+				d.Version = value
+			}
+		}
+		daemons = append(daemons, d)
+	}
+	return daemons
+}
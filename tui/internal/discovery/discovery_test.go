@@ -0,0 +1,95 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// buildReply answers a query the way the daemon's responder does
+func buildReply(t *testing.T, id uint16, instance, host string, addr [4]byte, port uint16) []byte {
+	t.Helper()
+	instanceName := dnsmessage.MustNewName(instance + "." + Service)
+	hostName := dnsmessage.MustNewName(host + ".local.")
+	hdr := func(name dnsmessage.Name, typ dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Type: typ, Class: dnsmessage.ClassINET, TTL: 10}
+	}
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: id, Response: true, Authoritative: true},
+		Answers: []dnsmessage.Resource{{
+			Header: hdr(dnsmessage.MustNewName(Service), dnsmessage.TypePTR),
+			Body:   &dnsmessage.PTRResource{PTR: instanceName},
+		}},
+		Additionals: []dnsmessage.Resource{
+			{Header: hdr(instanceName, dnsmessage.TypeSRV), Body: &dnsmessage.SRVResource{Target: hostName, Port: port}},
+			{Header: hdr(instanceName, dnsmessage.TypeTXT), Body: &dnsmessage.TXTResource{TXT: []string{"version=0.3.0", "path=/"}}},
+			{Header: hdr(hostName, dnsmessage.TypeA), Body: &dnsmessage.AResource{A: addr}},
+		},
+	}
+	packet, err := msg.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return packet
+}
+
+// TestParseResponse_JoinsRecords verifies a reply's PTR, SRV, TXT and A records combine into one daemon.
+// BREAKS: If records aren't joined by name, a daemon is listed without an address or port.
+func TestParseResponse_JoinsRecords(t *testing.T) {
+	packet := buildReply(t, 7, "Server", "server", [4]byte{192, 168, 1, 5}, 8989)
+
+	daemons := parseResponse(packet, 7)
+	if len(daemons) != 1 {
+		t.Fatalf("Expected one daemon, got %v", daemons)
+	}
+	d := daemons[0]
+	if d.Name != "server" || d.URL() != "http://192.168.1.5:8989" || d.Version != "0.3.0" {
+		t.Errorf("Unexpected daemon %+v (%s)", d, d.URL())
+	}
+	if !d.Matches("server.local") || !d.Matches("SERVER") || !d.Matches("192.168.1.5") || d.Matches("laptop") {
+		t.Errorf("Unexpected Matches results for %+v", d)
+	}
+
+	if got := parseResponse(packet, 8); got != nil {
+		t.Errorf("Expected replies to another query ignored, got %v", got)
+	}
+}
+
+// TestBrowse_CollectsUnicastReplies verifies Browse sends a QU query and gathers replies until the timeout.
+// BREAKS: If the query lacks the unicast bit or replies are read once, daemons go unfound.
+func TestBrowse_CollectsUnicastReplies(t *testing.T) {
+	responder, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer responder.Close()
+	original := mdnsAddr
+	mdnsAddr = responder.LocalAddr().(*net.UDPAddr)
+	defer func() { mdnsAddr = original }()
+
+	go func() {
+		buf := make([]byte, 1500)
+		n, from, err := responder.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		if query.Unpack(buf[:n]) != nil || len(query.Questions) != 1 || query.Questions[0].Class&(1<<15) == 0 {
+			return
+		}
+		id := query.Header.ID
+		_, _ = responder.WriteToUDP(buildReply(t, id, "beta", "beta", [4]byte{10, 0, 0, 2}, 8989), from)
+		_, _ = responder.WriteToUDP(buildReply(t, id, "alpha", "alpha", [4]byte{10, 0, 0, 1}, 9000), from)
+		_, _ = responder.WriteToUDP(buildReply(t, id, "alpha", "alpha", [4]byte{10, 0, 0, 1}, 9000), from)
+	}()
+
+	daemons, err := Browse(300 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daemons) != 2 || daemons[0].Name != "alpha" || daemons[1].URL() != "http://10.0.0.2:8989" {
+		t.Errorf("Expected alpha and beta once each, got %+v", daemons)
+	}
+}
@@ -0,0 +1,188 @@
+package feeds
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Candidate represents a feed advertised by a web page via <link rel="alternate">
+type Candidate struct {
+	URL   string // Absolute feed URL
+	Title string // Title attribute from the link tag (may be empty)
+	Type  string // MIME type, e.g. "application/rss+xml"
+}
+
+// feedMIMETypes lists the link types we treat as subscribable feeds
+var feedMIMETypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/feed+json": true,
+	"application/rdf+xml":   true,
+}
+
+// maxPageBytes caps how much of a page we read while looking for link tags.
+// Feed links live in <head>, so the first 1MB is plenty.
+const maxPageBytes = 1 << 20
+
+// httpClient is the client used for discovery requests
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// LooksLikeFeedURL reports whether a URL already points at a feed, so callers
+// can skip discovery for the common /feed.xml, /rss, /atom.xml cases.
+func LooksLikeFeedURL(rawURL string) bool {
+	lower := strings.ToLower(rawURL)
+	if strings.HasPrefix(lower, "rss://") {
+		return true
+	}
+
+	parsed, err := url.Parse(lower)
+	if err != nil {
+		return false
+	}
+	path := strings.TrimSuffix(parsed.Path, "/")
+
+	for _, suffix := range []string{".xml", ".rss", ".atom", ".rdf", "/feed", "/rss", "/atom", "/feed.json", "/index.json"} {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return strings.Contains(parsed.RawQuery, "feed=") || strings.Contains(parsed.RawQuery, "format=rss")
+}
+
+// Discover fetches pageURL and returns the feeds it advertises.
+// If pageURL itself serves a feed, it is returned as the only candidate.
+func Discover(pageURL string) ([]Candidate, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid URL: %s", pageURL)
+	}
+
+	req, err := http.NewRequest("GET", pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "prismis-tui (feed discovery)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/rss+xml,application/atom+xml;q=0.9,*/*;q=0.8")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("network error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("page returned status %d", resp.StatusCode)
+	}
+
+	// Page is already a feed - nothing to discover
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	if isFeedContentType(contentType) {
+		return []Candidate{{URL: resp.Request.URL.String(), Type: mediaType(contentType)}}, nil
+	}
+
+	// Resolve relative hrefs against the final URL after redirects
+	if resp.Request != nil && resp.Request.URL != nil {
+		base = resp.Request.URL
+	}
+
+	return parseFeedLinks(io.LimitReader(resp.Body, maxPageBytes), base)
+}
+
+// parseFeedLinks scans an HTML document for <link rel="alternate"> feed tags
+func parseFeedLinks(r io.Reader, base *url.URL) ([]Candidate, error) {
+	var candidates []Candidate
+	seen := make(map[string]bool)
+
+	tokenizer := html.NewTokenizer(r)
+	for {
+		tt := tokenizer.Next()
+		switch tt {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return candidates, nil
+			}
+			return candidates, fmt.Errorf("failed to parse page: %w", tokenizer.Err())
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := string(name)
+
+			// Feed links only appear in <head>; stop once the body starts
+			if tag == "body" {
+				return candidates, nil
+			}
+			if tag != "link" || !hasAttr {
+				continue
+			}
+
+			attrs := make(map[string]string)
+			for {
+				key, val, more := tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = string(val)
+				if !more {
+					break
+				}
+			}
+
+			if !hasRel(attrs["rel"], "alternate") {
+				continue
+			}
+			linkType := strings.ToLower(strings.TrimSpace(attrs["type"]))
+			if !feedMIMETypes[linkType] {
+				continue
+			}
+			href := strings.TrimSpace(attrs["href"])
+			if href == "" {
+				continue
+			}
+
+			ref, err := url.Parse(href)
+			if err != nil {
+				continue
+			}
+			abs := base.ResolveReference(ref).String()
+			if seen[abs] {
+				continue
+			}
+			seen[abs] = true
+
+			candidates = append(candidates, Candidate{
+				URL:   abs,
+				Title: strings.TrimSpace(attrs["title"]),
+				Type:  linkType,
+			})
+		}
+	}
+}
+
+// hasRel reports whether a space-separated rel attribute contains want
+func hasRel(rel, want string) bool {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == want {
+			return true
+		}
+	}
+	return false
+}
+
+// isFeedContentType reports whether a Content-Type header denotes a feed document
+func isFeedContentType(contentType string) bool {
+	mt := mediaType(contentType)
+	return mt == "application/rss+xml" || mt == "application/atom+xml" ||
+		mt == "application/rdf+xml" || mt == "application/feed+json" ||
+		mt == "application/xml" || mt == "text/xml"
+}
+
+// mediaType strips parameters (e.g. charset) from a Content-Type header
+func mediaType(contentType string) string {
+	if idx := strings.Index(contentType, ";"); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	return strings.TrimSpace(contentType)
+}
@@ -0,0 +1,112 @@
+package feeds
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDiscover_FindsAlternateLinks verifies RSS and Atom links in <head> are returned
+// with relative hrefs resolved against the page URL.
+// BREAKS: If relative hrefs aren't resolved, the daemon receives "/feed.xml" and rejects it.
+func TestDiscover_FindsAlternateLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<!doctype html><html><head>
+<link rel="stylesheet" href="/style.css">
+<link rel="alternate" type="application/rss+xml" title="Posts" href="/feed.xml">
+<link rel="alternate" type="application/atom+xml" title="Atom" href="https://cdn.example.com/atom.xml">
+<link rel="alternate" type="application/rss+xml" href="/feed.xml">
+<link rel="alternate" hreflang="de" href="/de/">
+</head><body><link rel="alternate" type="application/rss+xml" href="/ignored.xml"></body></html>`)
+	}))
+	defer server.Close()
+
+	candidates, err := Discover(server.URL + "/blog/")
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates (deduped, body ignored), got %d: %+v", len(candidates), candidates)
+	}
+	if candidates[0].URL != server.URL+"/feed.xml" {
+		t.Errorf("Expected resolved URL %s/feed.xml, got %s", server.URL, candidates[0].URL)
+	}
+	if candidates[0].Title != "Posts" {
+		t.Errorf("Expected title 'Posts', got %q", candidates[0].Title)
+	}
+	if candidates[1].Type != "application/atom+xml" {
+		t.Errorf("Expected atom type, got %q", candidates[1].Type)
+	}
+}
+
+// TestDiscover_PageIsFeed verifies a URL that already serves XML is returned as-is.
+func TestDiscover_PageIsFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		fmt.Fprint(w, `<?xml version="1.0"?><rss version="2.0"><channel></channel></rss>`)
+	}))
+	defer server.Close()
+
+	candidates, err := Discover(server.URL + "/posts")
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].URL != server.URL+"/posts" {
+		t.Errorf("Expected the page itself as the only candidate, got %+v", candidates)
+	}
+}
+
+// TestDiscover_NoFeeds verifies pages without feed links return an empty list, not an error.
+func TestDiscover_NoFeeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>Nothing</title></head><body></body></html>`)
+	}))
+	defer server.Close()
+
+	candidates, err := Discover(server.URL)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("Expected no candidates, got %+v", candidates)
+	}
+}
+
+// TestDiscover_HTTPError verifies 4xx/5xx pages surface an error.
+func TestDiscover_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	if _, err := Discover(server.URL); err == nil {
+		t.Error("Expected error for 404 page")
+	}
+}
+
+func TestLooksLikeFeedURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com/feed.xml", true},
+		{"https://example.com/rss", true},
+		{"https://example.com/blog/feed/", true},
+		{"https://example.com/atom.xml", true},
+		{"https://example.com/?feed=rss2", true},
+		{"rss://example.com/posts", true},
+		{"https://example.com", false},
+		{"https://example.com/blog/", false},
+		{"https://example.com/rss-news-today", false},
+	}
+
+	for _, tt := range tests {
+		if got := LooksLikeFeedURL(tt.url); got != tt.want {
+			t.Errorf("LooksLikeFeedURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
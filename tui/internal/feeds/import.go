@@ -0,0 +1,155 @@
+package feeds
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Subscription is a feed exported from another reader
+type Subscription struct {
+	URL      string
+	Name     string // Title set in the other reader; empty lets the daemon use the feed's
+	Category string
+}
+
+// ParseNewsboatURLs reads a newsboat urls file: one feed per line followed by
+// its tags. The first tag becomes the category and a "~Title" tag the name.
+// Comments and query:, exec:, and filter: feeds are skipped.
+func ParseNewsboatURLs(r io.Reader) ([]Subscription, error) {
+	var subs []Subscription
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := newsboatFields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		lower := strings.ToLower(fields[0])
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			continue // query:, exec:, and filter: feeds have no URL to subscribe to
+		}
+
+		sub := Subscription{URL: fields[0]}
+		for _, tag := range fields[1:] {
+			switch {
+			case strings.HasPrefix(tag, "~"):
+				sub.Name = strings.TrimSpace(tag[1:])
+			case tag == "!" || tag == "":
+				// Hidden from newsboat's feed list; nothing to carry over
+			case sub.Category == "":
+				sub.Category = tag
+			}
+		}
+		subs = append(subs, sub)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read urls file: %w", err)
+	}
+	return subs, nil
+}
+
+// newsboatFields splits a urls line on whitespace, keeping "double quoted"
+// tags together and stopping at a comment
+func newsboatFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inQuotes, inField := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inField = true
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case !inQuotes && r == '#' && !inField:
+			if len(fields) == 0 {
+				return []string{"#"}
+			}
+			return fields
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// NewsboatURLsPath returns where newsboat keeps its urls file:
+// ~/.newsboat/urls if it exists, else under XDG_CONFIG_HOME or ~/.config
+func NewsboatURLsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	legacy := filepath.Join(home, ".newsboat", "urls")
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, nil
+	}
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "newsboat", "urls"), nil
+}
+
+// minifluxFeed is the part of a Miniflux /v1/feeds entry we import
+type minifluxFeed struct {
+	FeedURL  string `json:"feed_url"`
+	Title    string `json:"title"`
+	Category struct {
+		Title string `json:"title"`
+	} `json:"category"`
+}
+
+// FetchMinifluxFeeds lists the feeds of a Miniflux account through its API,
+// authenticating with an API token
+func FetchMinifluxFeeds(ctx context.Context, baseURL, token string) ([]Subscription, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimRight(baseURL, "/")+"/v1/feeds", nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Miniflux URL: %w", err)
+	}
+	req.Header.Set("X-Auth-Token", token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Miniflux: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("Miniflux rejected the API token (HTTP %d)", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Miniflux returned HTTP %d", resp.StatusCode)
+	}
+
+	var feeds []minifluxFeed
+	if err := json.NewDecoder(resp.Body).Decode(&feeds); err != nil {
+		return nil, fmt.Errorf("failed to parse Miniflux feeds: %w", err)
+	}
+	subs := make([]Subscription, 0, len(feeds))
+	for _, feed := range feeds {
+		if feed.FeedURL == "" {
+			continue
+		}
+		sub := Subscription{URL: feed.FeedURL, Name: feed.Title, Category: feed.Category.Title}
+		if sub.Category == "All" {
+			sub.Category = "" // Miniflux's default category, i.e. none
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
@@ -0,0 +1,69 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestParseNewsboatURLs_TagsAndTitles verifies the first tag becomes the category, "~" tags the name, and non-feeds are skipped.
+// BREAKS: If quoted tags are split or query feeds are kept, imports get categories like "\"tech" and bogus sources.
+func TestParseNewsboatURLs_TagsAndTitles(t *testing.T) {
+	urls := `# my feeds
+https://example.com/feed.xml tech "~Example Blog" news
+http://blog.example.org/rss "long reads" ! # read on weekends
+
+"query:Unread:unread = \"yes\""
+exec:~/bin/feed.sh tech
+https://example.net/atom.xml
+`
+	subs, err := ParseNewsboatURLs(strings.NewReader(urls))
+	if err != nil {
+		t.Fatalf("ParseNewsboatURLs failed: %v", err)
+	}
+	want := []Subscription{
+		{URL: "https://example.com/feed.xml", Name: "Example Blog", Category: "tech"},
+		{URL: "http://blog.example.org/rss", Category: "long reads"},
+		{URL: "https://example.net/atom.xml"},
+	}
+	if len(subs) != len(want) {
+		t.Fatalf("Expected %d feeds, got %d: %+v", len(want), len(subs), subs)
+	}
+	for i := range want {
+		if subs[i] != want[i] {
+			t.Errorf("Feed %d: expected %+v, got %+v", i, want[i], subs[i])
+		}
+	}
+}
+
+// TestFetchMinifluxFeeds_UsesToken verifies the API token is sent and feeds come back with their titles and categories.
+// BREAKS: If the token header or the "All" default category is mishandled, imports fail or every source lands in "All".
+func TestFetchMinifluxFeeds_UsesToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/feeds" || r.Header.Get("X-Auth-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+{"id": 1, "feed_url": "https://example.com/feed.xml", "title": "Example", "category": {"id": 1, "title": "All"}},
+{"id": 2, "feed_url": "https://example.org/rss", "title": "Org", "category": {"id": 2, "title": "Security"}}
+]`)
+	}))
+	defer server.Close()
+
+	subs, err := FetchMinifluxFeeds(context.Background(), server.URL+"/", "secret")
+	if err != nil {
+		t.Fatalf("FetchMinifluxFeeds failed: %v", err)
+	}
+	if len(subs) != 2 || subs[0].Category != "" || subs[1] != (Subscription{URL: "https://example.org/rss", Name: "Org", Category: "Security"}) {
+		t.Errorf("Unexpected feeds: %+v", subs)
+	}
+
+	if _, err := FetchMinifluxFeeds(context.Background(), server.URL, "wrong"); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("Expected a rejected-token error, got %v", err)
+	}
+}
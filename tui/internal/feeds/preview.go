@@ -0,0 +1,155 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxFeedBytes caps how much of a feed we read for a preview
+const maxFeedBytes = 5 << 20
+
+// Preview summarizes a single fetch of a feed, used to sanity-check a source before saving it
+type Preview struct {
+	URL         string // Final URL after redirects
+	Format      string // "RSS", "Atom", "RDF" or "JSON Feed"
+	Title       string // Feed title
+	ItemCount   int    // Number of entries in the document
+	LatestTitle string // Title of the first (newest) entry
+}
+
+// xmlFeed covers the fields we need from RSS 2.0, RSS 1.0 (RDF) and Atom documents
+type xmlFeed struct {
+	XMLName xml.Name
+	// RSS 2.0 nests items under <channel>; RDF puts them at the top level
+	Channel struct {
+		Title string `xml:"title"`
+		Items []struct {
+			Title string `xml:"title"`
+		} `xml:"item"`
+	} `xml:"channel"`
+	Items []struct {
+		Title string `xml:"title"`
+	} `xml:"item"`
+	// Atom
+	Title   string `xml:"title"`
+	Entries []struct {
+		Title string `xml:"title"`
+	} `xml:"entry"`
+}
+
+// jsonFeed covers the fields we need from a JSON Feed document
+type jsonFeed struct {
+	Version string `json:"version"`
+	Title   string `json:"title"`
+	Items   []struct {
+		Title string `json:"title"`
+	} `json:"items"`
+}
+
+// FetchPreview fetches feedURL once and reports what the daemon would see.
+// Nothing is stored; it is purely a dry run.
+func FetchPreview(feedURL string) (*Preview, error) {
+	req, err := http.NewRequest("GET", feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %s", feedURL)
+	}
+	req.Header.Set("User-Agent", "prismis-tui (feed preview)")
+	req.Header.Set("Accept", "application/rss+xml,application/atom+xml,application/feed+json,application/xml;q=0.9,*/*;q=0.8")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("network error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("feed returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+
+	preview, err := parsePreview(body)
+	if err != nil {
+		return nil, err
+	}
+	preview.URL = feedURL
+	if resp.Request != nil && resp.Request.URL != nil {
+		preview.URL = resp.Request.URL.String()
+	}
+	return preview, nil
+}
+
+// parsePreview detects the feed format and extracts the summary fields
+func parsePreview(body []byte) (*Preview, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+
+	if trimmed[0] == '{' {
+		var feed jsonFeed
+		if err := json.Unmarshal(trimmed, &feed); err != nil || !strings.Contains(feed.Version, "jsonfeed.org") {
+			return nil, fmt.Errorf("not a feed: JSON document is not a JSON Feed")
+		}
+		p := &Preview{Format: "JSON Feed", Title: feed.Title, ItemCount: len(feed.Items)}
+		if len(feed.Items) > 0 {
+			p.LatestTitle = feed.Items[0].Title
+		}
+		return p, nil
+	}
+
+	var feed xmlFeed
+	if err := xml.Unmarshal(trimmed, &feed); err != nil {
+		if isHTML(trimmed) {
+			return nil, fmt.Errorf("not a feed: URL serves an HTML page")
+		}
+		return nil, fmt.Errorf("not a feed: %w", err)
+	}
+
+	p := &Preview{}
+	switch strings.ToLower(feed.XMLName.Local) {
+	case "rss":
+		p.Format = "RSS"
+		p.Title = feed.Channel.Title
+		p.ItemCount = len(feed.Channel.Items)
+		if p.ItemCount > 0 {
+			p.LatestTitle = feed.Channel.Items[0].Title
+		}
+	case "rdf":
+		p.Format = "RDF"
+		p.Title = feed.Channel.Title
+		p.ItemCount = len(feed.Items)
+		if p.ItemCount > 0 {
+			p.LatestTitle = feed.Items[0].Title
+		}
+	case "feed":
+		p.Format = "Atom"
+		p.Title = feed.Title
+		p.ItemCount = len(feed.Entries)
+		if p.ItemCount > 0 {
+			p.LatestTitle = feed.Entries[0].Title
+		}
+	case "html":
+		return nil, fmt.Errorf("not a feed: URL serves an HTML page")
+	default:
+		return nil, fmt.Errorf("not a feed: unexpected <%s> document", feed.XMLName.Local)
+	}
+
+	p.Title = strings.TrimSpace(p.Title)
+	p.LatestTitle = strings.TrimSpace(p.LatestTitle)
+	return p, nil
+}
+
+// isHTML reports whether a body looks like an HTML document rather than a feed
+func isHTML(body []byte) bool {
+	head := strings.ToLower(string(body[:min(len(body), 512)]))
+	return strings.Contains(head, "<!doctype html") || strings.Contains(head, "<html")
+}
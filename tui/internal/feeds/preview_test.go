@@ -0,0 +1,62 @@
+package feeds
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestFetchPreview_RSS verifies item count and latest title come from an RSS channel.
+// BREAKS: If RSS items aren't read from <channel>, every RSS source previews as empty.
+func TestFetchPreview_RSS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?><rss version="2.0"><channel><title>Blog</title>
+<item><title> Newest post </title></item><item><title>Older post</title></item></channel></rss>`)
+	}))
+	defer server.Close()
+
+	preview, err := FetchPreview(server.URL + "/feed.xml")
+	if err != nil {
+		t.Fatalf("FetchPreview failed: %v", err)
+	}
+	if preview.Format != "RSS" || preview.ItemCount != 2 || preview.LatestTitle != "Newest post" || preview.Title != "Blog" {
+		t.Errorf("Unexpected preview: %+v", preview)
+	}
+}
+
+// TestParsePreview_Formats verifies Atom, RDF and JSON Feed documents are recognized.
+// BREAKS: If format detection falls through, valid non-RSS feeds are reported as "not a feed".
+func TestParsePreview_Formats(t *testing.T) {
+	tests := []struct {
+		body   string
+		format string
+		count  int
+	}{
+		{`<feed xmlns="http://www.w3.org/2005/Atom"><title>A</title><entry><title>One</title></entry></feed>`, "Atom", 1},
+		{`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"><channel><title>R</title></channel><item><title>One</title></item><item><title>Two</title></item></rdf:RDF>`, "RDF", 2},
+		{`{"version":"https://jsonfeed.org/version/1.1","title":"J","items":[{"title":"One"}]}`, "JSON Feed", 1},
+	}
+
+	for _, tt := range tests {
+		preview, err := parsePreview([]byte(tt.body))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.format, err)
+			continue
+		}
+		if preview.Format != tt.format || preview.ItemCount != tt.count || preview.LatestTitle != "One" {
+			t.Errorf("%s: unexpected preview %+v", tt.format, preview)
+		}
+	}
+}
+
+// TestParsePreview_HTMLPage verifies an HTML page is rejected with a clear reason.
+// BREAKS: If HTML is reported as a generic XML error, users can't tell they need the feed URL.
+func TestParsePreview_HTMLPage(t *testing.T) {
+	_, err := parsePreview([]byte(`<!doctype html><html><head><title>Hi</title></head><body><p>x</body></html>`))
+	if err == nil || !strings.Contains(err.Error(), "HTML page") {
+		t.Errorf("Expected HTML page error, got %v", err)
+	}
+}
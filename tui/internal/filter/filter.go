@@ -0,0 +1,512 @@
+// Package filter parses :filter expressions such as
+//
+//	priority=high AND source~rust AND length>2000 AND NOT read
+//
+// into predicates that run as SQL against the local database or in Go
+// against items synced from a remote daemon, with the same results either way.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// Fields lists what expressions can test, for help and error messages
+const Fields = "priority, source, type, tag, author, title, text, length, age, read, favorited, pinned, upvoted, downvoted"
+
+// fieldKind decides which operators and values a field takes
+type fieldKind int
+
+const (
+	textField   fieldKind = iota // = and != compare case-insensitively
+	searchField                  // Text that ~ and !~ also search within
+	bodyField                    // Text searched only with ~ and !~
+	numberField                  // An integer compared with = != < <= > >=
+	ageField                     // A duration since publishing: < <= > >=
+	flagField                    // A bare name that is true or false
+)
+
+var fieldKinds = map[string]fieldKind{
+	"priority":  textField,
+	"type":      textField,
+	"tag":       textField,
+	"source":    searchField,
+	"author":    searchField,
+	"title":     searchField,
+	"text":      bodyField,
+	"length":    numberField,
+	"age":       ageField,
+	"read":      flagField,
+	"favorited": flagField,
+	"pinned":    flagField,
+	"upvoted":   flagField,
+	"downvoted": flagField,
+}
+
+// Allowed values for the fields that only take a few
+var fieldValues = map[string][]string{
+	"priority": {"high", "medium", "low", "none"},
+	"type":     {"rss", "reddit", "youtube", "file"},
+}
+
+// Expr is a parsed filter expression
+type Expr struct {
+	root     node
+	archived string // A search's archived:no|yes|only scope, "" to follow the view
+}
+
+// archiveScopes are the values archived: takes in a search
+var archiveScopes = map[string]db.ArchiveScope{
+	"no":   db.ActiveContent,
+	"yes":  db.AnyContent,
+	"only": db.ArchivedContent,
+}
+
+// node is one term of an expression tree
+type node interface {
+	match(item db.ContentItem, now time.Time) bool
+	sql(schema *db.Schema, now time.Time, args *[]any) string
+	format() string
+}
+
+// Match reports whether item passes the expression; now anchors age
+func (e *Expr) Match(item db.ContentItem, now time.Time) bool {
+	return e.root.match(item, now)
+}
+
+// SQL renders the expression as a condition on content c joined with
+// sources s, leaving out columns the database predates. It satisfies
+// db.Predicate. Regex terms can't run in SQL, so with them the condition
+// keeps extra rows and Match has the final say.
+func (e *Expr) SQL(schema *db.Schema) (string, []any) {
+	var args []any
+	where := e.root.sql(schema, time.Now(), &args)
+	return where, args
+}
+
+// String is the expression in canonical form, as it is saved and shown
+func (e *Expr) String() string {
+	if e.archived == "" {
+		return e.root.format()
+	}
+	return strings.TrimSpace(grouped(e.root) + " archived:" + e.archived)
+}
+
+// Archived is the archive scope a search asked for with archived:no, yes,
+// or only; without one (ok false) it searches what the view shows
+func (e *Expr) Archived() (scope db.ArchiveScope, ok bool) {
+	scope, ok = archiveScopes[e.archived]
+	return scope, ok
+}
+
+// Highlights finds where the expression's searches hit s: the byte ranges
+// of its text~, title~, and /regex/ terms outside a NOT, in order and merged
+// where they overlap
+func (e *Expr) Highlights(s string) [][2]int {
+	var ranges [][2]int
+	for _, re := range searchTerms(e.root) {
+		for _, at := range re.FindAllStringIndex(s, -1) {
+			if at[1] > at[0] {
+				ranges = append(ranges, [2]int{at[0], at[1]})
+			}
+		}
+	}
+	slices.SortFunc(ranges, func(a, b [2]int) int { return a[0] - b[0] })
+	var merged [][2]int
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && r[0] <= merged[last][1] {
+			merged[last][1] = max(merged[last][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// searchTerms compiles the text searches under n that an item is matched
+// for; those under a NOT are what it lacks, so they're skipped
+func searchTerms(n node) []*regexp.Regexp {
+	switch n := n.(type) {
+	case *andNode:
+		return append(searchTerms(n.left), searchTerms(n.right)...)
+	case *orNode:
+		return append(searchTerms(n.left), searchTerms(n.right)...)
+	case *regexNode:
+		return []*regexp.Regexp{n.re}
+	case *cmpNode:
+		if n.op == "~" && (n.field == "text" || n.field == "title") && n.value != "" {
+			return []*regexp.Regexp{regexp.MustCompile("(?i)" + regexp.QuoteMeta(n.value))}
+		}
+	}
+	return nil
+}
+
+// NeedsContent reports whether the expression reads item content in Go:
+// regexes, which SQL can't run, and text searches, whose hits search results
+// show in context. It satisfies db.ContentReader, so local queries load the
+// text for it.
+func (e *Expr) NeedsContent() bool {
+	return readsContent(e.root)
+}
+
+func readsContent(n node) bool {
+	switch n := n.(type) {
+	case *andNode:
+		return readsContent(n.left) || readsContent(n.right)
+	case *orNode:
+		return readsContent(n.left) || readsContent(n.right)
+	case *notNode:
+		return readsContent(n.x)
+	case *regexNode:
+		return true
+	case *cmpNode:
+		return n.field == "text"
+	}
+	return false
+}
+
+// Single returns the field and value when the expression is one field=value
+// test, which :filter treats as its simpler per-field filter
+func (e *Expr) Single() (field, value string, ok bool) {
+	if c, isCmp := e.root.(*cmpNode); isCmp && c.op == "=" {
+		return c.field, c.value, true
+	}
+	return "", "", false
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ x node }
+
+func (n *andNode) match(item db.ContentItem, now time.Time) bool {
+	return n.left.match(item, now) && n.right.match(item, now)
+}
+
+func (n *orNode) match(item db.ContentItem, now time.Time) bool {
+	return n.left.match(item, now) || n.right.match(item, now)
+}
+
+func (n *notNode) match(item db.ContentItem, now time.Time) bool {
+	return !n.x.match(item, now)
+}
+
+func (n *andNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	return "(" + n.left.sql(schema, now, args) + " AND " + n.right.sql(schema, now, args) + ")"
+}
+
+func (n *orNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	return "(" + n.left.sql(schema, now, args) + " OR " + n.right.sql(schema, now, args) + ")"
+}
+
+func (n *notNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	if hasRegex(n.x) {
+		// A regex keeps every row in SQL; negated that would keep none
+		return "1"
+	}
+	return "NOT " + n.x.sql(schema, now, args)
+}
+
+// hasRegex reports whether a regex term is anywhere under n
+func hasRegex(n node) bool {
+	switch n := n.(type) {
+	case *regexNode:
+		return true
+	case *andNode:
+		return hasRegex(n.left) || hasRegex(n.right)
+	case *orNode:
+		return hasRegex(n.left) || hasRegex(n.right)
+	case *notNode:
+		return hasRegex(n.x)
+	}
+	return false
+}
+
+func (n *andNode) format() string {
+	return grouped(n.left) + " AND " + grouped(n.right)
+}
+
+func (n *orNode) format() string {
+	return n.left.format() + " OR " + n.right.format()
+}
+
+func (n *notNode) format() string {
+	if _, isOr := n.x.(*orNode); isOr {
+		return "NOT (" + n.x.format() + ")"
+	}
+	if _, isAnd := n.x.(*andNode); isAnd {
+		return "NOT (" + n.x.format() + ")"
+	}
+	return "NOT " + n.x.format()
+}
+
+// grouped parenthesizes an OR inside an AND, where it would otherwise bind wrong
+func grouped(n node) string {
+	if _, isOr := n.(*orNode); isOr {
+		return "(" + n.format() + ")"
+	}
+	return n.format()
+}
+
+// allNode matches every item: a search that is only archived:only
+type allNode struct{}
+
+func (allNode) match(item db.ContentItem, now time.Time) bool {
+	return true
+}
+
+func (allNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	return "1"
+}
+
+func (allNode) format() string {
+	return ""
+}
+
+// flagNode tests a boolean field
+type flagNode struct{ field string }
+
+func (n *flagNode) match(item db.ContentItem, now time.Time) bool {
+	switch n.field {
+	case "read":
+		return item.Read
+	case "favorited":
+		return item.Favorited
+	case "pinned":
+		return item.Pinned
+	case "upvoted":
+		return item.UserFeedback == "up"
+	case "downvoted":
+		return item.UserFeedback == "down"
+	}
+	return false
+}
+
+func (n *flagNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	switch n.field {
+	case "read":
+		return "(COALESCE(c.read, 0) = 1)"
+	case "favorited":
+		return "(COALESCE(c.favorited, 0) = 1)"
+	case "pinned":
+		return "(COALESCE(" + column(schema, "pinned", "0") + ", 0) = 1)"
+	case "upvoted":
+		return "(COALESCE(" + column(schema, "user_feedback", "''") + ", '') = 'up')"
+	case "downvoted":
+		return "(COALESCE(" + column(schema, "user_feedback", "''") + ", '') = 'down')"
+	}
+	return "0"
+}
+
+func (n *flagNode) format() string {
+	return n.field
+}
+
+// cmpNode compares a field with a value
+type cmpNode struct {
+	field string
+	op    string
+	value string
+	n     int           // length's value
+	age   time.Duration // age's value
+}
+
+func (n *cmpNode) match(item db.ContentItem, now time.Time) bool {
+	switch n.field {
+	case "length":
+		return compare(item.Length(), n.op, n.n)
+	case "age":
+		// An older item has an earlier publish time: age<2d is published after now-2d
+		cutoff := now.Add(-n.age)
+		return compare(int(cutoff.Unix()), n.op, int(item.Published.Unix()))
+	case "tag":
+		has := false
+		for _, tag := range item.UserTags {
+			if strings.EqualFold(tag, n.value) {
+				has = true
+			}
+		}
+		return has == (n.op == "=")
+	}
+
+	text := strings.ToLower(n.text(item))
+	value := strings.ToLower(n.value)
+	switch n.op {
+	case "=":
+		return text == value
+	case "!=":
+		return text != value
+	case "~":
+		return strings.Contains(text, value)
+	case "!~":
+		return !strings.Contains(text, value)
+	}
+	return false
+}
+
+// text is the item's value for a text field
+func (n *cmpNode) text(item db.ContentItem) string {
+	switch n.field {
+	case "priority":
+		if item.Priority == "" {
+			return "none"
+		}
+		return item.Priority
+	case "type":
+		return item.SourceType
+	case "source":
+		return item.SourceName
+	case "author":
+		return item.Author
+	case "title":
+		return item.Title
+	case "text":
+		return item.Title + "\n" + item.Summary + "\n" + item.Content
+	}
+	return ""
+}
+
+func (n *cmpNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	switch n.field {
+	case "length":
+		*args = append(*args, n.n)
+		return "(LENGTH(COALESCE(c.content, '')) " + n.op + " ?)"
+	case "age":
+		// Same flip as match: the cutoff is on the left so the operator reads as written
+		*args = append(*args, now.Add(-n.age).UTC().Format("2006-01-02 15:04:05"))
+		return "(datetime(?) " + n.op + " datetime(COALESCE(c.published_at, '0001-01-01')))"
+	case "tag":
+		*args = append(*args, ","+strings.ToLower(n.value)+",")
+		tags := "REPLACE(LOWER(COALESCE(" + column(schema, "user_tags", "''") + ", '')), ', ', ',')"
+		has := "instr(',' || " + tags + " || ',', ?) > 0"
+		if n.op == "=" {
+			return "(" + has + ")"
+		}
+		return "(NOT " + has + ")"
+	}
+
+	var text string
+	switch n.field {
+	case "priority":
+		text = "COALESCE(NULLIF(c.priority, ''), 'none')"
+	case "type":
+		text = "COALESCE(s.type, '')"
+	case "source":
+		text = "COALESCE(s.name, '')"
+	case "author":
+		text = "COALESCE(" + column(schema, "author", "''") + ", '')"
+	case "title":
+		text = "COALESCE(c.title, '')"
+	case "text":
+		text = "COALESCE(c.title, '') || char(10) || COALESCE(c.summary, '') || char(10) || COALESCE(c.content, '')"
+	}
+	*args = append(*args, strings.ToLower(n.value))
+	switch n.op {
+	case "~":
+		return "(instr(LOWER(" + text + "), ?) > 0)"
+	case "!~":
+		return "(instr(LOWER(" + text + "), ?) = 0)"
+	}
+	return "(LOWER(" + text + ") " + n.op + " ?)"
+}
+
+func (n *cmpNode) format() string {
+	return n.field + n.op + quote(n.value)
+}
+
+// regexNode matches a regular expression in the title, summary, and content
+type regexNode struct {
+	pattern string
+	fold    bool
+	re      *regexp.Regexp
+}
+
+// newRegex compiles a /pattern/; RE2 runs in linear time, so no pattern can
+// stall the list
+func newRegex(pattern string, fold bool) (node, error) {
+	source := pattern
+	if fold {
+		source = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex /%s/: %s", pattern, strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	return &regexNode{pattern: pattern, fold: fold, re: re}, nil
+}
+
+func (n *regexNode) match(item db.ContentItem, now time.Time) bool {
+	return n.re.MatchString(item.Title + "\n" + item.Summary + "\n" + item.Content)
+}
+
+// SQLite has no REGEXP, so the query keeps every row and Match narrows them
+func (n *regexNode) sql(schema *db.Schema, now time.Time, args *[]any) string {
+	return "1"
+}
+
+func (n *regexNode) format() string {
+	if n.fold {
+		return "/" + n.pattern + "/i"
+	}
+	return "/" + n.pattern + "/"
+}
+
+// compare applies a numeric comparison operator
+func compare(a int, op string, b int) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+// column selects c.<name>, or fallback on databases that predate it
+func column(schema *db.Schema, name, fallback string) string {
+	if schema.Has(name) {
+		return "c." + name
+	}
+	return fallback
+}
+
+// quote wraps a value in double quotes when it wouldn't read back as one
+// word; values can't hold both a space and a quote, so none need escaping
+func quote(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t()") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// parseAge reads an age such as 30m, 12h, 2d, or 1w
+func parseAge(value string) (time.Duration, error) {
+	if len(value) >= 2 {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n > 0 {
+			switch value[len(value)-1] {
+			case 'm':
+				return time.Duration(n) * time.Minute, nil
+			case 'h':
+				return time.Duration(n) * time.Hour, nil
+			case 'd':
+				return time.Duration(n) * 24 * time.Hour, nil
+			case 'w':
+				return time.Duration(n) * 7 * 24 * time.Hour, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid age '%s' (use 30m, 12h, 2d, or 1w)", value)
+}
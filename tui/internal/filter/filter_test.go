@@ -0,0 +1,427 @@
+package filter
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestParse_Canonical verifies expressions read back in one canonical form.
+// BREAKS: If String drifts from Parse, a saved per-view filter changes meaning
+// (or stops parsing) after a restart.
+func TestParse_Canonical(t *testing.T) {
+	tests := map[string]string{
+		"priority=high AND source~rust AND length>2000 AND NOT read": "priority=high AND source~rust AND length>2000 AND NOT read",
+		"Priority=HIGH source~rust":                                  "priority=high AND source~rust",
+		"read or pinned and favorited":                               "read OR pinned AND favorited",
+		"(read OR pinned) favorited":                                 "(read OR pinned) AND favorited",
+		"not (read or upvoted)":                                      "NOT (read OR upvoted)",
+		`title~"rust async" AND tag=c++`:                             `title~"rust async" AND tag=c++`,
+		"age<=2d AND length!=0":                                      "age<=2d AND length!=0",
+	}
+	for input, want := range tests {
+		expr, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", input, err)
+			continue
+		}
+		if got := expr.String(); got != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", input, got, want)
+		}
+		again, err := Parse(expr.String())
+		if err != nil || again.String() != want {
+			t.Errorf("Canonical form %q doesn't read back: %v", want, err)
+		}
+	}
+}
+
+// TestParse_Errors verifies malformed expressions are rejected with a reason.
+// BREAKS: If bad input parses, a typo silently filters the view to nothing.
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":                       "empty",
+		"colour=red":             "unknown field 'colour'",
+		"priority=urgent":        "unknown priority",
+		"length>lots":            "whole number",
+		"age=2d":                 "can't use =",
+		"age<soon":               "invalid age",
+		"priority~hi":            "can't use ~",
+		"source":                 "needs a comparison",
+		"read AND":               "AND needs something",
+		"(read OR pinned":        "missing ')'",
+		`title~"unclosed`:        "unclosed quote",
+		"read)":                  "unexpected ')'",
+		"!read":                  "unexpected '!'",
+		"source= AND read":       "",
+		"priority=high OR OR re": "unexpected",
+	}
+	for input, want := range tests {
+		_, err := Parse(input)
+		if want == "" {
+			// source= takes AND as the value, then read: still valid
+			if err != nil {
+				t.Errorf("Parse(%q): %v", input, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want it to mention %q", input, err, want)
+		}
+	}
+}
+
+// TestMatch_Fields verifies each field tests the item the way the README says.
+// BREAKS: If a field reads the wrong property, remote mode lists different
+// items than the query would.
+func TestMatch_Fields(t *testing.T) {
+	now := time.Now()
+	item := db.ContentItem{
+		Title:        "Async Rust in practice",
+		Priority:     "high",
+		Content:      strings.Repeat("x", 2500),
+		Published:    now.Add(-time.Hour),
+		SourceType:   "rss",
+		SourceName:   "This Week in Rust",
+		Author:       "Jane Doe",
+		UserTags:     []string{"rust", "career"},
+		UserFeedback: "up",
+		Pinned:       true,
+	}
+	tests := map[string]bool{
+		"priority=high":                     true,
+		"priority=none":                     false,
+		"priority!=low":                     true,
+		"source~rust":                       true,
+		"source!~rust":                      false,
+		"source=rust":                       false,
+		`source="this week in rust"`:        true,
+		"type=rss":                          true,
+		"tag=Rust":                          true,
+		"tag!=career":                       false,
+		"author~doe":                        true,
+		"title~async":                       true,
+		"length>2000":                       true,
+		"length<=2000":                      false,
+		"age<2d":                            true,
+		"age>2h":                            false,
+		"read":                              false,
+		"NOT read":                          true,
+		"pinned AND upvoted":                true,
+		"downvoted OR favorited":            false,
+		"priority=low OR (tag=rust read)":   false,
+		"priority=low OR tag=rust NOT read": true,
+	}
+	for input, want := range tests {
+		expr, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		if got := expr.Match(item, now); got != want {
+			t.Errorf("%q matched %v, want %v", input, got, want)
+		}
+	}
+}
+
+// TestSQL_AgreesWithMatch verifies the local query and the client-side match
+// pick the same items.
+// BREAKS: If SQL and Match drift, local and remote mode list different items
+// for the same saved filter.
+func TestSQL_AgreesWithMatch(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	dbPath := filepath.Join(dataHome, "prismis", "prismis.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts := []string{
+		`CREATE TABLE sources (id TEXT PRIMARY KEY, url TEXT, type TEXT, name TEXT)`,
+		`CREATE TABLE content (id TEXT PRIMARY KEY, source_id TEXT, external_id TEXT, title TEXT, url TEXT,
+			content TEXT, summary TEXT, analysis TEXT, priority TEXT, published_at TIMESTAMP, fetched_at TIMESTAMP,
+			read BOOLEAN DEFAULT 0, favorited BOOLEAN DEFAULT 0, interesting_override BOOLEAN DEFAULT 0,
+			archived_at TIMESTAMP, user_feedback TEXT, user_tags TEXT, snoozed_until TIMESTAMP, pinned BOOLEAN, author TEXT)`,
+		`INSERT INTO sources VALUES ('s1', 'https://rust.example', 'rss', 'This Week in Rust'), ('s2', 'https://reddit.com/r/go', 'reddit', 'r/golang')`,
+	}
+	now := time.Now().UTC()
+	items := []struct {
+		id, source, title, content, priority, tags, feedback, author string
+		age                                                          time.Duration
+		read, pinned                                                 int
+	}{
+		{"1", "s1", "Async Rust", strings.Repeat("x", 2500), "high", "rust, career", "up", "Jane Doe", time.Hour, 0, 1},
+		{"2", "s1", "Rust 2024 edition", "short", "medium", "", "", "", 72 * time.Hour, 1, 0},
+		{"3", "s2", "Go generics", strings.Repeat("é", 3000), "", "go", "down", "u/gopher", 30 * time.Minute, 0, 0},
+		{"4", "s2", "Go 1.23 iterators", "", "low", "machine learning", "", "", 10 * 24 * time.Hour, 1, 0},
+	}
+	for _, stmt := range stmts {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	for _, item := range items {
+		if _, err := conn.Exec(`INSERT INTO content (id, source_id, external_id, title, url, content, priority, published_at, read, pinned, user_tags, user_feedback, author)
+			VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''))`,
+			item.id, item.source, item.id, item.title, "https://example.com/"+item.id, item.content, item.priority,
+			now.Add(-item.age).Format(time.RFC3339), item.read, item.pinned, item.tags, item.feedback, item.author); err != nil {
+			t.Fatalf("Failed to insert %s: %v", item.id, err)
+		}
+	}
+	conn.Close()
+	db.CloseDB()
+	defer db.CloseDB()
+
+	all, err := db.GetAllContent(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(items) {
+		t.Fatalf("Expected %d items, got %d", len(items), len(all))
+	}
+
+	for _, input := range []string{
+		"priority=high AND source~rust AND length>2000 AND NOT read",
+		"priority=none",
+		"priority!=high",
+		"type=reddit OR pinned",
+		"tag=rust",
+		`tag="machine learning"`,
+		"NOT tag=go",
+		"author~gopher",
+		"author!~jane",
+		"title~RUST",
+		"length>=3000",
+		"length=0",
+		"age<2d",
+		"age>=3d",
+		"upvoted OR downvoted",
+		"NOT (read OR favorited)",
+		"text~GENERICS OR text~async",
+		"text!~rust",
+	} {
+		expr, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		var want []string
+		for _, item := range all {
+			if expr.Match(item, time.Now()) {
+				want = append(want, item.ID)
+			}
+		}
+		matched, err := db.GetAllContent(false, expr)
+		if err != nil {
+			t.Fatalf("%q: %v", input, err)
+		}
+		var got []string
+		for _, item := range matched {
+			got = append(got, item.ID)
+		}
+		slices.Sort(want)
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("%q: SQL matched %v, Match matched %v", input, got, want)
+		}
+		if len(want) == 0 {
+			t.Errorf("%q matches nothing, so it doesn't test agreement", input)
+		}
+	}
+
+	// Regexes can't run in SQL: the query must keep at least what Match keeps
+	for _, input := range []string{"/go \\d/i", "NOT /RUST/i", "/rust/i OR tag=go", "NOT (/async/ AND pinned)"} {
+		expr, err := ParseSearch(input)
+		if err != nil {
+			t.Fatalf("ParseSearch(%q): %v", input, err)
+		}
+		matched, err := db.GetAllContent(false, expr)
+		if err != nil {
+			t.Fatalf("%q: %v", input, err)
+		}
+		for _, item := range all {
+			if expr.Match(item, time.Now()) && !slices.ContainsFunc(matched, func(m db.ContentItem) bool { return m.ID == item.ID }) {
+				t.Errorf("%q: SQL dropped %s, which Match keeps", input, item.ID)
+			}
+		}
+	}
+}
+
+// TestParse_Regex verifies /pattern/ terms compile, match the text, and report bad patterns.
+// BREAKS: If a bad pattern parses (or panics), :search /cve-(/ shows nothing instead of the mistake.
+func TestParse_Regex(t *testing.T) {
+	item := db.ContentItem{Title: "Patch CVE-2024-1234 now", Summary: "OpenSSL", Content: "path a/b"}
+	tests := map[string]bool{
+		`/cve-\d{4}/i`:             true,
+		`/cve-\d{4}/`:              false,
+		`/a\/b/`:                   true,
+		`/openssl/i priority=none`: true,
+		`NOT /patch/i`:             false,
+	}
+	for input, want := range tests {
+		expr, err := ParseSearch(input)
+		if err != nil {
+			t.Errorf("ParseSearch(%q): %v", input, err)
+			continue
+		}
+		if got := expr.Match(item, time.Now()); got != want {
+			t.Errorf("%q matched %v, want %v", input, got, want)
+		}
+		if again, err := ParseSearch(expr.String()); err != nil || again.String() != expr.String() {
+			t.Errorf("%q doesn't read back from %q: %v", input, expr.String(), err)
+		}
+	}
+
+	for input, want := range map[string]string{
+		"/cve-(/":   "invalid regex /cve-(/: missing closing )",
+		"/cve":      "unclosed regex",
+		"/cve/x":    "only /i",
+		"//":        "empty regex",
+		"title~/x/": "",
+	} {
+		_, err := ParseSearch(input)
+		if want == "" {
+			// After an operator a slash is just part of the value
+			if err != nil {
+				t.Errorf("ParseSearch(%q): %v", input, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseSearch(%q) error = %v, want it to mention %q", input, err, want)
+		}
+	}
+}
+
+// TestParseSearch_Words verifies bare words and quoted phrases search the text while field tests keep working.
+// BREAKS: If words aren't read as text, a saved search like "CVE OR vulnerability" can't be saved at all.
+func TestParseSearch_Words(t *testing.T) {
+	tests := map[string]string{
+		"CVE OR vulnerability":         "text~CVE OR text~vulnerability",
+		`"remote code" priority=high`:  `text~"remote code" AND priority=high`,
+		"source rust":                  "text~source AND text~rust",
+		"NOT read AND exploit":         "NOT read AND text~exploit",
+		"(cve OR exploit) NOT upvoted": "(text~cve OR text~exploit) AND NOT upvoted",
+	}
+	for input, want := range tests {
+		expr, err := ParseSearch(input)
+		if err != nil {
+			t.Errorf("ParseSearch(%q): %v", input, err)
+			continue
+		}
+		if got := expr.String(); got != want {
+			t.Errorf("ParseSearch(%q).String() = %q, want %q", input, got, want)
+		}
+	}
+
+	item := db.ContentItem{Title: "Patch now", Summary: "A new CVE in OpenSSL", Content: "remote code execution"}
+	for input, want := range map[string]bool{"cve": true, `"remote code"`: true, "cve AND kernel": false} {
+		expr, _ := ParseSearch(input)
+		if got := expr.Match(item, time.Now()); got != want {
+			t.Errorf("%q matched %v, want %v", input, got, want)
+		}
+	}
+
+	// :filter stays strict, so typos don't become searches
+	if _, err := Parse("CVE OR vulnerability"); err == nil {
+		t.Error("Expected Parse to reject bare words")
+	}
+}
+
+// TestJoin_RestoresQuotes verifies arguments the command line unquoted get their quotes back.
+// BREAKS: If quotes are lost, :filter title~"rust async" tests for "rust" and an unknown field "async".
+func TestJoin_RestoresQuotes(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"priority=high", "AND", "NOT", "read"}, "priority=high AND NOT read"},
+		{[]string{"title~rust async", "AND", "read"}, `title~"rust async" AND read`},
+		{[]string{"remote code", "OR", "cve"}, `"remote code" OR cve`},
+	}
+	for _, tt := range tests {
+		if got := Join(tt.args); got != tt.want {
+			t.Errorf("Join(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+// TestHighlights_FindsSearchHits verifies the ranges cover text, title, and regex hits but not negated terms.
+// BREAKS: If negated words were highlighted, a result would show exactly what the search excludes as its reason.
+func TestHighlights_FindsSearchHits(t *testing.T) {
+	expr, err := ParseSearch(`CVE OR /open\w+/i AND NOT kernel AND title~patch`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := "Patch cve-1 in OpenSSL, not the kernel"
+	var got []string
+	for _, r := range expr.Highlights(s) {
+		got = append(got, s[r[0]:r[1]])
+	}
+	if want := []string{"Patch", "cve", "OpenSSL"}; !slices.Equal(got, want) {
+		t.Errorf("Expected %q highlighted, got %q", want, got)
+	}
+
+	// Overlapping hits merge into one range
+	expr, _ = ParseSearch("open OR openssl")
+	if got := expr.Highlights("OpenSSL"); len(got) != 1 || got[0] != [2]int{0, 7} {
+		t.Errorf("Expected one merged range, got %v", got)
+	}
+}
+
+// TestParseSearch_ArchivedScope verifies archived:no|yes|only sets a search's scope and survives saving.
+// BREAKS: If the scope is read as a word, archived:yes searches for the text "archived:yes" and finds nothing archived.
+func TestParseSearch_ArchivedScope(t *testing.T) {
+	tests := map[string]struct {
+		canonical string
+		scope     db.ArchiveScope
+		scoped    bool
+	}{
+		"openssl":                        {"text~openssl", db.ActiveContent, false},
+		"openssl archived:yes":           {"text~openssl archived:yes", db.AnyContent, true},
+		"ARCHIVED:only (cve OR openssl)": {"(text~cve OR text~openssl) archived:only", db.ArchivedContent, true},
+		"archived:no priority=high":      {"priority=high archived:no", db.ActiveContent, true},
+		"archived:only":                  {"archived:only", db.ArchivedContent, true},
+		`"archived:yes"`:                 {"text~archived:yes", db.ActiveContent, false},
+	}
+	for input, want := range tests {
+		expr, err := ParseSearch(input)
+		if err != nil {
+			t.Errorf("ParseSearch(%q): %v", input, err)
+			continue
+		}
+		if got := expr.String(); got != want.canonical {
+			t.Errorf("%q: expected %q, got %q", input, want.canonical, got)
+		}
+		if scope, ok := expr.Archived(); scope != want.scope || ok != want.scoped {
+			t.Errorf("%q: expected scope %v (%v), got %v (%v)", input, want.scope, want.scoped, scope, ok)
+		}
+		if again, err := ParseSearch(expr.String()); err != nil || again.String() != expr.String() {
+			t.Errorf("%q doesn't read back from %q: %v", input, expr.String(), err)
+		}
+	}
+
+	if expr, _ := ParseSearch("archived:only"); !expr.Match(db.ContentItem{Title: "anything"}, time.Now()) {
+		t.Error("Expected a scope-only search to match every item in its scope")
+	}
+
+	for input, want := range map[string]string{
+		"cve archived:maybe":           "takes no, yes, or only",
+		"cve archived:yes archived:no": "given twice",
+		"cve NOT archived:yes":         "can't be negated",
+	} {
+		if _, err := ParseSearch(input); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseSearch(%q): expected an error containing %q, got %v", input, want, err)
+		}
+	}
+	if _, err := Parse("archived:yes"); err == nil {
+		t.Error("Expected :filter to leave the scope to searches")
+	}
+}
@@ -0,0 +1,396 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// token is a word, operator, parenthesis, quoted value, or /regex/
+type token struct {
+	text   string
+	quoted bool // A "quoted value", never a keyword
+	regex  bool // A /pattern/ (text holds the pattern)
+	fold   bool // The regex ends /i: ignore case
+}
+
+// Parse reads an expression: field tests (priority=high, source~rust,
+// length>2000, age<2d, read) joined by AND, OR, NOT, and parentheses.
+// Terms side by side are ANDed; keywords and field names ignore case.
+func Parse(expr string) (*Expr, error) {
+	return parse(expr, false)
+}
+
+// ParseSearch reads an expression like Parse, except that a bare word or
+// quoted phrase that isn't a field test searches the item's title, summary,
+// and content: `CVE OR vulnerability AND priority=high`. Both take /regex/
+// terms (RE2 syntax, /pattern/i ignores case) over the same text. A search
+// may also set its scope with archived:yes (archived items too) or
+// archived:only, anywhere in it.
+func ParseSearch(expr string) (*Expr, error) {
+	return parse(expr, true)
+}
+
+func parse(expr string, words bool) (*Expr, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	archived := ""
+	if words {
+		if tokens, archived, err = archiveScope(tokens); err != nil {
+			return nil, err
+		}
+		if len(tokens) == 0 && archived != "" {
+			return &Expr{root: allNode{}, archived: archived}, nil
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+	p := &parser{tokens: tokens, words: words}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected '%s'", tok.text)
+	}
+	return &Expr{root: root, archived: archived}, nil
+}
+
+// archiveScope takes a search's archived:<scope> term out of its tokens. It
+// sets where the search looks rather than testing items, so it can't be
+// negated or given twice.
+func archiveScope(tokens []token) ([]token, string, error) {
+	var rest []token
+	archived := ""
+	for i, tok := range tokens {
+		name, value, found := strings.Cut(strings.ToLower(tok.text), ":")
+		isValue := i > 0 && isOperator(tokens[i-1])
+		if tok.quoted || tok.regex || isValue || !found || name != "archived" {
+			rest = append(rest, tok)
+			continue
+		}
+		if _, ok := archiveScopes[value]; !ok {
+			return nil, "", fmt.Errorf("archived: takes no, yes, or only (not '%s')", value)
+		}
+		if archived != "" {
+			return nil, "", fmt.Errorf("archived: given twice")
+		}
+		if i > 0 && !tokens[i-1].quoted && strings.EqualFold(tokens[i-1].text, "not") {
+			return nil, "", fmt.Errorf("archived:%s sets where to search and can't be negated (use archived:no or archived:only)", value)
+		}
+		archived = value
+	}
+	return rest, archived, nil
+}
+
+// operators, longest first so >= isn't read as >
+var operators = []string{"!=", "!~", ">=", "<=", "=", "~", ">", "<"}
+
+// tokenize splits an expression; a field test's value runs to the next
+// space or parenthesis unless quoted
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	rest := expr
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return tokens, nil
+		}
+		switch {
+		case rest[0] == '(' || rest[0] == ')':
+			tokens = append(tokens, token{text: rest[:1]})
+			rest = rest[1:]
+		case rest[0] == '"':
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed quote")
+			}
+			tokens = append(tokens, token{text: rest[1 : end+1], quoted: true})
+			rest = rest[end+2:]
+		default:
+			if n := len(tokens); n > 0 && isOperator(tokens[n-1]) {
+				// A value runs to the next space or parenthesis, so c++ and a=b read whole
+				end := strings.IndexFunc(rest, func(r rune) bool {
+					return unicode.IsSpace(r) || r == '(' || r == ')'
+				})
+				if end < 0 {
+					end = len(rest)
+				}
+				tokens = append(tokens, token{text: rest[:end]})
+				rest = rest[end:]
+				continue
+			}
+			if rest[0] == '/' {
+				tok, n, err := regexToken(rest)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, tok)
+				rest = rest[n:]
+				continue
+			}
+			if op := operatorAt(rest); op != "" {
+				tokens = append(tokens, token{text: op})
+				rest = rest[len(op):]
+				continue
+			}
+			end := strings.IndexFunc(rest, func(r rune) bool {
+				return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || strings.ContainsRune("!=~<>", r)
+			})
+			if end == 0 {
+				return nil, fmt.Errorf("unexpected '%c'", rest[0])
+			}
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, token{text: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+}
+
+// Join rebuilds an expression from command-line arguments. The command line
+// has already removed their quotes, so an argument holding a space was quoted:
+// title~"rust async" arrives as title~rust async and gets its quotes back.
+func Join(args []string) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = arg
+		if !strings.ContainsAny(arg, " \t") {
+			continue
+		}
+		if at := strings.IndexAny(arg, "!=~<>"); at > 0 && !strings.ContainsAny(arg[:at], " \t()") {
+			if op := operatorAt(arg[at:]); op != "" {
+				parts[i] = arg[:at+len(op)] + quote(arg[at+len(op):])
+				continue
+			}
+		}
+		parts[i] = quote(arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// regexToken reads a /pattern/ or /pattern/i from the start of s, returning
+// how many bytes it took. A backslash keeps the next character in the
+// pattern, so \/ matches a slash.
+func regexToken(s string) (token, int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '/':
+			tok := token{text: s[1:i], regex: true}
+			n := i + 1
+			if n < len(s) && s[n] == 'i' {
+				tok.fold = true
+				n++
+			}
+			if n < len(s) && !unicode.IsSpace(rune(s[n])) && s[n] != '(' && s[n] != ')' {
+				return token{}, 0, fmt.Errorf("unexpected '%c' after /%s/ (only /i is supported)", s[n], tok.text)
+			}
+			if tok.text == "" {
+				return token{}, 0, fmt.Errorf("empty regex //")
+			}
+			return tok, n, nil
+		}
+	}
+	return token{}, 0, fmt.Errorf("unclosed regex: end it with /")
+}
+
+// operatorAt returns the comparison operator s starts with, if any
+func operatorAt(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isOperator(tok token) bool {
+	return !tok.quoted && slices.Contains(operators, tok.text)
+}
+
+// parser is a recursive descent over the tokens: OR binds loosest, then
+// AND, then NOT
+type parser struct {
+	tokens []token
+	pos    int
+	words  bool // Bare words search the text (ParseSearch)
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// keyword reports whether the next token is word, consuming it if so
+func (p *parser) keyword(word string) bool {
+	tok, ok := p.peek()
+	if ok && !tok.quoted && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		explicit := p.keyword("and")
+		tok, ok := p.peek()
+		if !ok || tok.text == ")" || (!tok.quoted && strings.EqualFold(tok.text, "or")) {
+			if explicit {
+				return nil, fmt.Errorf("AND needs something after it")
+			}
+			return left, nil
+		}
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+}
+
+func (p *parser) not() (node, error) {
+	if p.keyword("not") {
+		x, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{x}, nil
+	}
+	return p.term()
+}
+
+func (p *parser) term() (node, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("filter ends early")
+	}
+	p.pos++
+	if tok.text == "(" && !tok.quoted {
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.text != ")" || closing.quoted {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return inner, nil
+	}
+	if tok.regex {
+		return newRegex(tok.text, tok.fold)
+	}
+	if p.words && tok.quoted {
+		return &cmpNode{field: "text", op: "~", value: tok.text}, nil
+	}
+	if tok.quoted || tok.text == ")" || isOperator(tok) || strings.EqualFold(tok.text, "and") || strings.EqualFold(tok.text, "or") {
+		return nil, fmt.Errorf("unexpected '%s'", tok.text)
+	}
+
+	field := strings.ToLower(tok.text)
+	kind, known := fieldKinds[field]
+	if next, ok := p.peek(); p.words && (!known || kind != flagField) && (!ok || !isOperator(next)) {
+		return &cmpNode{field: "text", op: "~", value: tok.text}, nil
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown field '%s' (available: %s)", tok.text, Fields)
+	}
+	if kind == flagField {
+		return &flagNode{field: field}, nil
+	}
+
+	opTok, ok := p.peek()
+	if !ok || !isOperator(opTok) {
+		return nil, fmt.Errorf("%s needs a comparison (e.g. %s)", field, example(field))
+	}
+	p.pos++
+	valueTok, ok := p.peek()
+	if !ok || (!valueTok.quoted && (valueTok.text == "(" || valueTok.text == ")")) {
+		return nil, fmt.Errorf("%s%s needs a value", field, opTok.text)
+	}
+	p.pos++
+	return newComparison(field, kind, opTok.text, valueTok.text)
+}
+
+// newComparison checks the operator and value suit the field
+func newComparison(field string, kind fieldKind, op, value string) (node, error) {
+	allowed := map[fieldKind][]string{
+		textField:   {"=", "!="},
+		searchField: {"=", "!=", "~", "!~"},
+		bodyField:   {"~", "!~"},
+		numberField: {"=", "!=", "<", "<=", ">", ">="},
+		ageField:    {"<", "<=", ">", ">="},
+	}[kind]
+	if !slices.Contains(allowed, op) {
+		return nil, fmt.Errorf("%s can't use %s (use %s)", field, op, strings.Join(allowed, " "))
+	}
+
+	c := &cmpNode{field: field, op: op, value: value}
+	if values, ok := fieldValues[field]; ok {
+		c.value = strings.ToLower(value)
+		if !slices.Contains(values, c.value) {
+			return nil, fmt.Errorf("unknown %s '%s' (available: %s)", field, value, strings.Join(values, ", "))
+		}
+	}
+	switch kind {
+	case numberField:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s needs a whole number, not '%s'", field, value)
+		}
+		c.n = n
+	case ageField:
+		age, err := parseAge(strings.ToLower(value))
+		if err != nil {
+			return nil, err
+		}
+		c.age = age
+		c.value = strings.ToLower(value)
+	}
+	return c, nil
+}
+
+// example shows how to test field, for error messages
+func example(field string) string {
+	switch fieldKinds[field] {
+	case numberField:
+		return field + ">2000"
+	case ageField:
+		return field + "<2d"
+	case searchField, bodyField:
+		return field + "~rust"
+	}
+	if values, ok := fieldValues[field]; ok {
+		return field + "=" + values[0]
+	}
+	return field + "=rust"
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nickpending/prismis/internal/api"
 )
@@ -31,7 +34,9 @@ func initContentService() error {
 	return nil
 }
 
-// MarkAsRead marks a content item as read via the API
+// MarkAsRead marks a content item as read via the API.
+// The mutation helpers return ErrQueued when the daemon is unreachable; the
+// change is saved and sent later by ReplayPending.
 func MarkAsRead(contentID string) error {
 	if err := initContentService(); err != nil {
 		return err
@@ -42,12 +47,11 @@ func MarkAsRead(contentID string) error {
 		Read: &readStatus,
 	}
 
-	_, err := globalContentService.client.UpdateContent(contentID, request)
-	if err != nil {
+	err := updateOrQueue(contentID, "read", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
 		return fmt.Errorf("failed to mark as read: %w", err)
 	}
-
-	return nil
+	return err
 }
 
 // MarkAsUnread marks a content item as unread via the API
@@ -61,12 +65,41 @@ func MarkAsUnread(contentID string) error {
 		Read: &readStatus,
 	}
 
-	_, err := globalContentService.client.UpdateContent(contentID, request)
-	if err != nil {
+	err := updateOrQueue(contentID, "read", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
 		return fmt.Errorf("failed to mark as unread: %w", err)
 	}
+	return err
+}
 
-	return nil
+// SetReadBatch marks many content items read or unread in one request,
+// returning how many the daemon updated. When the daemon is unreachable every
+// item is queued and ErrQueued is returned.
+func SetReadBatch(contentIDs []string, read bool) (int, error) {
+	if err := initContentService(); err != nil {
+		return 0, err
+	}
+
+	updated, err := globalContentService.client.SetReadBatch(context.Background(), contentIDs, read)
+	if err == nil {
+		// Queued read writes to these items are stale now; best effort
+		discardAll(contentIDs, "read")
+		return updated, nil
+	}
+	if !errors.Is(err, api.ErrDaemonDown) {
+		return 0, fmt.Errorf("failed to update read status: %w", err)
+	}
+
+	now := time.Now().UTC()
+	writes := make([]PendingWrite, len(contentIDs))
+	for i, id := range contentIDs {
+		readStatus := read
+		writes[i] = PendingWrite{ContentID: id, Field: "read", Update: api.ContentUpdateRequest{Read: &readStatus}, QueuedAt: now}
+	}
+	if qerr := enqueueAll(writes); qerr != nil {
+		return 0, fmt.Errorf("%w (queueing failed: %v)", err, qerr)
+	}
+	return len(contentIDs), ErrQueued
 }
 
 // ToggleFavorite toggles the favorite status of a content item via the API
@@ -79,12 +112,11 @@ func ToggleFavorite(contentID string, favorited bool) error {
 		Favorited: &favorited,
 	}
 
-	_, err := globalContentService.client.UpdateContent(contentID, request)
-	if err != nil {
+	err := updateOrQueue(contentID, "favorited", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
 		return fmt.Errorf("failed to toggle favorite: %w", err)
 	}
-
-	return nil
+	return err
 }
 
 // SetUserFeedback sets the user feedback vote for a content item via the API
@@ -106,10 +138,65 @@ func SetUserFeedback(contentID string, vote string) error {
 		UserFeedback: votePtr,
 	}
 
-	_, err := globalContentService.client.UpdateContent(contentID, request)
-	if err != nil {
+	err := updateOrQueue(contentID, "user_feedback", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
 		return fmt.Errorf("failed to set user feedback: %w", err)
 	}
+	return err
+}
+
+// SetUserTags replaces the user's tags on a content item via the API
+// (an empty list clears them)
+func SetUserTags(contentID string, tags []string) error {
+	if err := initContentService(); err != nil {
+		return err
+	}
 
-	return nil
+	if tags == nil {
+		tags = []string{}
+	}
+	request := api.ContentUpdateRequest{
+		UserTags: &tags,
+	}
+
+	err := updateOrQueue(contentID, "user_tags", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
+		return fmt.Errorf("failed to set tags: %w", err)
+	}
+	return err
+}
+
+// SnoozeUntil hides a content item until the given time via the API
+func SnoozeUntil(contentID string, until time.Time) error {
+	if err := initContentService(); err != nil {
+		return err
+	}
+
+	until = until.UTC()
+	request := api.ContentUpdateRequest{
+		SnoozedUntil: &until,
+	}
+
+	err := updateOrQueue(contentID, "snoozed_until", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
+		return fmt.Errorf("failed to snooze: %w", err)
+	}
+	return err
+}
+
+// SetPinned pins or unpins a content item via the API
+func SetPinned(contentID string, pinned bool) error {
+	if err := initContentService(); err != nil {
+		return err
+	}
+
+	request := api.ContentUpdateRequest{
+		Pinned: &pinned,
+	}
+
+	err := updateOrQueue(contentID, "pinned", request)
+	if err != nil && !errors.Is(err, ErrQueued) {
+		return fmt.Errorf("failed to update pin: %w", err)
+	}
+	return err
 }
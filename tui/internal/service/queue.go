@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// ErrQueued is returned by the mutation helpers when the daemon was
+// unreachable and the change was saved for replay instead of being lost
+var ErrQueued = errors.New("daemon unreachable, change queued for sync")
+
+// PendingWrite is a content mutation waiting for the daemon to come back
+type PendingWrite struct {
+	ContentID string                   `json:"content_id"`
+	Field     string                   `json:"field"` // "read", "favorited", "user_feedback", "user_tags", "snoozed_until", or "pinned"
+	Update    api.ContentUpdateRequest `json:"update"`
+	QueuedAt  time.Time                `json:"queued_at"`
+}
+
+// queueMu serializes access to the queue file
+var queueMu sync.Mutex
+
+// queuePathFunc returns the queue file location (overridable for testing)
+var queuePathFunc = defaultQueuePath
+
+// defaultQueuePath keeps the queue next to the local database
+func defaultQueuePath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "prismis", "pending_writes.json"), nil
+}
+
+// loadQueue reads the queue file; callers hold queueMu
+func loadQueue() ([]PendingWrite, error) {
+	path, err := queuePathFunc()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending writes: %w", err)
+	}
+	var queue []PendingWrite
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("failed to parse pending writes: %w", err)
+	}
+	return queue, nil
+}
+
+// saveQueue writes the queue file, removing it when empty; callers hold queueMu
+func saveQueue(queue []PendingWrite) error {
+	path, err := queuePathFunc()
+	if err != nil {
+		return err
+	}
+	if len(queue) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to clear pending writes: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	data, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pending writes: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// enqueue records a write, replacing any earlier queued write to the same
+// field of the same item (last write wins)
+func enqueue(write PendingWrite) error {
+	return enqueueAll([]PendingWrite{write})
+}
+
+// enqueueAll records several writes with one rewrite of the queue file
+func enqueueAll(writes []PendingWrite) error {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+
+	queue, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	replaced := make(map[[2]string]bool, len(writes))
+	for _, w := range writes {
+		replaced[[2]string{w.ContentID, w.Field}] = true
+	}
+	kept := queue[:0]
+	for _, w := range queue {
+		if !replaced[[2]string{w.ContentID, w.Field}] {
+			kept = append(kept, w)
+		}
+	}
+	return saveQueue(append(kept, writes...))
+}
+
+// discard drops any queued write to field of contentID
+func discard(contentID, field string) error {
+	return discardAll([]string{contentID}, field)
+}
+
+// discardAll drops any queued write to field of the given items
+func discardAll(contentIDs []string, field string) error {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+
+	queue, err := loadQueue()
+	if err != nil || len(queue) == 0 {
+		return err
+	}
+	dropped := make(map[string]bool, len(contentIDs))
+	for _, id := range contentIDs {
+		dropped[id] = true
+	}
+	kept := queue[:0]
+	for _, w := range queue {
+		if !dropped[w.ContentID] || w.Field != field {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == len(queue) {
+		return nil
+	}
+	return saveQueue(kept)
+}
+
+// PendingCount returns how many writes are waiting to sync
+func PendingCount() int {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+	queue, err := loadQueue()
+	if err != nil {
+		return 0
+	}
+	return len(queue)
+}
+
+// PendingWrites returns the queued writes, oldest first
+func PendingWrites() ([]PendingWrite, error) {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+	return loadQueue()
+}
+
+// ReplayPending sends queued writes to the daemon oldest first. Queued writes
+// win over whatever the daemon has, since they are the user's latest intent.
+// Replay stops at the first connection failure, leaving the rest queued;
+// writes the daemon rejects (item gone, invalid) are dropped. Returns how many
+// writes were applied and how many remain.
+func ReplayPending(ctx context.Context) (int, int, error) {
+	if err := initContentService(); err != nil {
+		return 0, PendingCount(), err
+	}
+
+	queueMu.Lock()
+	defer queueMu.Unlock()
+
+	queue, err := loadQueue()
+	if err != nil || len(queue) == 0 {
+		return 0, 0, err
+	}
+	sort.SliceStable(queue, func(i, j int) bool { return queue[i].QueuedAt.Before(queue[j].QueuedAt) })
+
+	replayed := 0
+	var replayErr error
+	for len(queue) > 0 {
+		w := queue[0]
+		_, err := globalContentService.client.UpdateContent(ctx, w.ContentID, w.Update)
+		if errors.Is(err, api.ErrDaemonDown) {
+			replayErr = err
+			break
+		}
+		if err == nil {
+			replayed++
+		}
+		queue = queue[1:]
+	}
+
+	if err := saveQueue(queue); err != nil {
+		return replayed, len(queue), err
+	}
+	return replayed, len(queue), replayErr
+}
+
+// updateOrQueue sends request, queueing it when the daemon can't be reached
+func updateOrQueue(contentID, field string, request api.ContentUpdateRequest) error {
+	_, err := globalContentService.client.UpdateContent(context.Background(), contentID, request)
+	if err == nil {
+		// A stale queued write to this field must not replay over the new value.
+		// Best effort: the write itself already succeeded.
+		discard(contentID, field)
+		return nil
+	}
+	if !errors.Is(err, api.ErrDaemonDown) {
+		return err
+	}
+	write := PendingWrite{ContentID: contentID, Field: field, Update: request, QueuedAt: time.Now().UTC()}
+	if qerr := enqueue(write); qerr != nil {
+		return fmt.Errorf("%w (queueing failed: %v)", err, qerr)
+	}
+	return ErrQueued
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/api/apitest"
+)
+
+// useQueueFile points the queue at a temp file and the service at baseURL
+func useQueueFile(t *testing.T, baseURL string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pending_writes.json")
+	queuePathFunc = func() (string, error) { return path, nil }
+	globalContentService = &ContentService{client: api.NewClientWithKey(baseURL, apitest.DefaultKey)}
+	t.Cleanup(func() {
+		queuePathFunc = defaultQueuePath
+		globalContentService = nil
+	})
+}
+
+// TestOfflineWrites_QueueAndReplay verifies writes made while the daemon is down survive and replay.
+// BREAKS: If queueing fails, toggles made offline are silently lost on the next refresh.
+func TestOfflineWrites_QueueAndReplay(t *testing.T) {
+	daemon := apitest.New(t)
+	entry := daemon.AddEntry(apitest.Entry{Title: "offline read"})
+
+	// Daemon unreachable: writes are queued, repeat writes to a field coalesce
+	useQueueFile(t, "http://127.0.0.1:1")
+	if err := MarkAsRead(entry.ID); !errors.Is(err, ErrQueued) {
+		t.Fatalf("Expected ErrQueued, got %v", err)
+	}
+	MarkAsUnread(entry.ID)
+	MarkAsRead(entry.ID)
+	if err := ToggleFavorite(entry.ID, true); !errors.Is(err, ErrQueued) {
+		t.Fatalf("Expected ErrQueued, got %v", err)
+	}
+	if got := PendingCount(); got != 2 {
+		t.Fatalf("Expected 2 coalesced writes, got %d", got)
+	}
+
+	// Still down: replay keeps everything queued
+	if _, pending, err := ReplayPending(context.Background()); !errors.Is(err, api.ErrDaemonDown) || pending != 2 {
+		t.Fatalf("Expected writes kept while down, got pending=%d err=%v", pending, err)
+	}
+
+	// Daemon back: replay applies the latest intent
+	globalContentService = &ContentService{client: daemon.Client()}
+	replayed, pending, err := ReplayPending(context.Background())
+	if err != nil || replayed != 2 || pending != 0 {
+		t.Fatalf("Expected 2 replayed, 0 pending, got %d, %d, %v", replayed, pending, err)
+	}
+	got := daemon.Entries()[0]
+	if !got.Read || !got.Favorited {
+		t.Errorf("Expected entry read and favorited on daemon, got %+v", got)
+	}
+}
+
+// TestOfflineWrites_OnlineWriteSupersedesQueued verifies a successful write drops the stale queued one.
+// BREAKS: If the queued write survives, replay later reverts the user's newer change.
+func TestOfflineWrites_OnlineWriteSupersedesQueued(t *testing.T) {
+	daemon := apitest.New(t)
+	entry := daemon.AddEntry(apitest.Entry{Title: "flip flop"})
+
+	useQueueFile(t, "http://127.0.0.1:1")
+	MarkAsRead(entry.ID)
+
+	globalContentService = &ContentService{client: daemon.Client()}
+	if err := MarkAsUnread(entry.ID); err != nil {
+		t.Fatalf("MarkAsUnread failed: %v", err)
+	}
+	if got := PendingCount(); got != 0 {
+		t.Errorf("Expected queued write discarded, %d pending", got)
+	}
+}
+
+// TestOfflineWrites_DropsRejected verifies writes for deleted items don't block the queue.
+// BREAKS: If a 404 is retried forever, the pending-sync badge never clears.
+func TestOfflineWrites_DropsRejected(t *testing.T) {
+	daemon := apitest.New(t)
+
+	useQueueFile(t, "http://127.0.0.1:1")
+	MarkAsRead("gone")
+
+	globalContentService = &ContentService{client: daemon.Client()}
+	replayed, pending, err := ReplayPending(context.Background())
+	if err != nil || replayed != 0 || pending != 0 {
+		t.Errorf("Expected rejected write dropped, got %d, %d, %v", replayed, pending, err)
+	}
+}
+
+// TestSetReadBatch_QueuesEachItemOffline verifies a batch made while the daemon is down queues every item.
+// BREAKS: If the batch is lost offline, :markall appears to work and the items come back unread.
+func TestSetReadBatch_QueuesEachItemOffline(t *testing.T) {
+	daemon := apitest.New(t)
+	a := daemon.AddEntry(apitest.Entry{Title: "a"})
+	b := daemon.AddEntry(apitest.Entry{Title: "b"})
+
+	useQueueFile(t, "http://127.0.0.1:1")
+	MarkAsUnread(a.ID) // Superseded by the batch
+	if n, err := SetReadBatch([]string{a.ID, b.ID}, true); !errors.Is(err, ErrQueued) || n != 2 {
+		t.Fatalf("Expected 2 queued, got %d (%v)", n, err)
+	}
+	if got := PendingCount(); got != 2 {
+		t.Fatalf("Expected one queued write per item, got %d", got)
+	}
+
+	globalContentService = &ContentService{client: daemon.Client()}
+	if n, err := SetReadBatch([]string{a.ID, b.ID}, true); err != nil || n != 2 {
+		t.Fatalf("Expected 2 updated online, got %d (%v)", n, err)
+	}
+	if got := PendingCount(); got != 0 {
+		t.Errorf("Expected the online batch to drop the stale queued writes, got %d", got)
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestAuthorFilter_MatchesBylines verifies :filter author= keeps items whose byline contains the name, ignoring case.
+// BREAKS: If the match is exact, "doe" misses "Jane Doe" and the filter looks broken.
+func TestAuthorFilter_MatchesBylines(t *testing.T) {
+	items := []db.ContentItem{
+		{ID: "a", Priority: "high", Author: "Jane Doe"},
+		{ID: "b", Priority: "high", Author: "John Smith"},
+		{ID: "c", Priority: "high"},
+	}
+	m := Model{priority: "all", filterType: "all", filterAuthor: "doe"}
+	if got := applyFiltersClientSide(items, m); len(got) != 1 || got[0].ID != "a" {
+		t.Errorf("Expected only Jane Doe's item, got %v", got)
+	}
+}
+
+// TestAuthorByline_ShownUnlessItRepeatsTheSource verifies bylines render in the metadata line but not when they're just the source name.
+// BREAKS: If every byline shows, single-author blogs read "SimonW Blog | by SimonW Blog".
+func TestAuthorByline_ShownUnlessItRepeatsTheSource(t *testing.T) {
+	meta := strings.Join(itemMetaParts(db.ContentItem{SourceName: "The Verge", Author: "Jane Doe"}, CleanCyberTheme), " | ")
+	if !strings.Contains(meta, "by Jane Doe") {
+		t.Errorf("Expected the byline, got %q", meta)
+	}
+	if byline := itemByline(db.ContentItem{SourceName: "3Blue1Brown", Author: "3blue1brown"}); byline != "" {
+		t.Errorf("Expected no byline repeating the source, got %q", byline)
+	}
+}
+
+// TestAuthor_SyncedFromDaemon verifies remote mode carries the API's author onto items.
+// BREAKS: If the field isn't converted, bylines only show in local mode.
+func TestAuthor_SyncedFromDaemon(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{Title: "remote item", Priority: "high", Author: "Jane Doe"})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	result := fetchItemsRemote(m, nil, nil)
+	if result.err != nil || len(result.allItems) != 1 || result.allItems[0].Author != "Jane Doe" {
+		t.Fatalf("Expected the author synced, got %+v (%v)", result.allItems, result.err)
+	}
+}
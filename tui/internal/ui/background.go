@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Terminal backgrounds for :set background and [tui] background; auto asks
+// the terminal
+const (
+	backgroundAuto  = "auto"
+	backgroundLight = "light"
+	backgroundDark  = "dark"
+)
+
+// hasDarkBackground asks the terminal for its background color (an OSC 11
+// query, falling back to COLORFGBG). lipgloss asks once and caches the
+// answer, so the first call must come before the program reads input.
+var hasDarkBackground = lipgloss.HasDarkBackground
+
+// parseBackground validates a :set background or [tui] background value
+func parseBackground(value string) (string, error) {
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case "", backgroundAuto:
+		return backgroundAuto, nil
+	case backgroundLight, backgroundDark:
+		return value, nil
+	}
+	return backgroundAuto, fmt.Errorf("expected light, dark or auto, got '%s'", value)
+}
+
+// lightBackground reports whether themes should be drawn for a light
+// background: the :set background value, or the terminal's for auto
+func (m *Model) lightBackground() bool {
+	switch m.background {
+	case backgroundLight:
+		return true
+	case backgroundDark:
+		return false
+	}
+	return !hasDarkBackground()
+}
+
+// backgroundSetting shows the background option, with the detected
+// background for auto
+func (m *Model) backgroundSetting() string {
+	if m.background == "" || m.background == backgroundAuto {
+		if m.lightBackground() {
+			return backgroundAuto + " (" + backgroundLight + ")"
+		}
+		return backgroundAuto + " (" + backgroundDark + ")"
+	}
+	return m.background
+}
+
+// themeForBackground keeps the named theme when it suits the background and
+// otherwise picks the default one for it: daylight on light backgrounds,
+// clean_cyber on dark ones
+func themeForBackground(name string, light bool) string {
+	for _, theme := range AvailableThemes {
+		if theme.Name == name && theme.Light == light {
+			return name
+		}
+	}
+	if light {
+		return DaylightTheme.Name
+	}
+	return CleanCyberTheme.Name
+}
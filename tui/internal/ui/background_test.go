@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/nickpending/prismis/internal/commands"
+)
+
+// TestThemeForBackground verifies a theme is kept on its own background and swapped for the default on the other.
+// BREAKS: If a dark theme stays on a light terminal, near-white text disappears into the background.
+func TestThemeForBackground(t *testing.T) {
+	cases := []struct {
+		name  string
+		light bool
+		want  string
+	}{
+		{"clean_cyber", false, "clean_cyber"},
+		{"monokai_pro", false, "monokai_pro"},
+		{"clean_cyber", true, "daylight"},
+		{"light", true, "daylight"},
+		{"daylight", true, "daylight"},
+		{"daylight", false, "clean_cyber"},
+	}
+	for _, c := range cases {
+		if got := themeForBackground(c.name, c.light); got != c.want {
+			t.Errorf("%s on light=%v: expected %s, got %s", c.name, c.light, c.want, got)
+		}
+	}
+}
+
+// TestSetBackground_SwitchesTheme verifies :set background picks the theme for it, and auto follows the terminal.
+// BREAKS: If the option ignores the background, a light terminal keeps a theme drawn for a dark one.
+func TestSetBackground_SwitchesTheme(t *testing.T) {
+	dark := true
+	defer func(detect func() bool) { hasDarkBackground = detect }(hasDarkBackground)
+	hasDarkBackground = func() bool { return dark }
+
+	m := testModel()
+	m.useTheme(MonokaiProTheme.Name)
+	m.setOption(commands.SetOptionMsg{Name: "background", Value: "light"})
+	if m.theme.Name != DaylightTheme.Name {
+		t.Errorf("Expected daylight on a light background, got %s", m.theme.Name)
+	}
+
+	m.setOption(commands.SetOptionMsg{Name: "background", Value: "auto"})
+	if m.theme.Name != CleanCyberTheme.Name {
+		t.Errorf("Expected clean_cyber on the terminal's dark background, got %s", m.theme.Name)
+	}
+
+	dark = false
+	if got := findOption("background").get(&m); got != "auto (light)" {
+		t.Errorf("Expected the detected background shown, got %q", got)
+	}
+	if _, err := findOption("background").set(&m, "sepia"); err == nil {
+		t.Error("Expected an unknown background rejected")
+	}
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// backupDoneMsg reports a finished :backup
+type backupDoneMsg struct {
+	info db.BackupInfo
+	err  error
+}
+
+// restoreCheckedMsg carries the backup :restore will apply, once checked
+type restoreCheckedMsg struct {
+	info db.BackupInfo
+	err  error
+}
+
+// restoreDoneMsg reports a finished :restore
+type restoreDoneMsg struct {
+	info db.BackupInfo
+	err  error
+}
+
+// describeBackup is "prismis.db (12.3 MB, 2026-10-15 14:02)" for the status line
+func describeBackup(info db.BackupInfo) string {
+	return fmt.Sprintf("%s (%s, %s)", info.Path, formatBytes(info.Size), info.ModTime.Format("2006-01-02 15:04"))
+}
+
+// expandHome resolves a leading ~/ in a user-given path
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, path[2:]), nil
+}
+
+// backupPath resolves :backup's path argument. Empty means the backups
+// directory next to the database; a directory gets a dated file name inside it.
+func backupPath(path string, now time.Time) (string, error) {
+	name := "prismis-" + now.Format("2006-01-02-150405") + ".db"
+	if path == "" {
+		dir, err := db.DefaultBackupDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, name), nil
+	}
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, name), nil
+	}
+	return path, nil
+}
+
+// startBackup backs up the local database
+func (m *Model) startBackup(msg commands.BackupMsg) tea.Cmd {
+	if m.remoteURL != "" {
+		return m.notify(toastWarn, "Backups cover the local database; run :backup on the daemon's machine", 4*time.Second)
+	}
+	m.statusMessage = "Backing up..."
+	return func() tea.Msg {
+		path, err := backupPath(msg.Path, time.Now())
+		if err != nil {
+			return backupDoneMsg{err: err}
+		}
+		info, err := db.Backup(path)
+		return backupDoneMsg{info: info, err: err}
+	}
+}
+
+// startRestore checks the backup before asking to restore it
+func (m *Model) startRestore(msg commands.RestoreMsg) tea.Cmd {
+	if m.remoteURL != "" {
+		return m.notify(toastWarn, "Restore replaces the local database; run :restore on the daemon's machine", 4*time.Second)
+	}
+	return func() tea.Msg {
+		path, err := expandHome(msg.Path)
+		if err != nil {
+			return restoreCheckedMsg{err: err}
+		}
+		info, err := db.StatBackup(path)
+		return restoreCheckedMsg{info: info, err: err}
+	}
+}
+
+// confirmRestore handles y/n for a checked :restore
+func (m Model) confirmRestore(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		backup := m.restoreConfirm
+		m.restoreConfirm = db.BackupInfo{}
+		m.statusMessage = "Restoring..."
+		return m, func() tea.Msg {
+			info, err := db.Restore(backup.Path)
+			return restoreDoneMsg{info: info, err: err}
+		}
+	case "n", "N", "esc":
+		m.restoreConfirm = db.BackupInfo{}
+		m.statusMessage = ""
+		return m, m.notify(toastInfo, "Restore cancelled", 3*time.Second)
+	default:
+		return m, nil
+	}
+}
+
+// restoreDone reports the restore and reloads everything from the restored database
+func (m *Model) restoreDone(msg restoreDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMessage = ""
+		return m.notify(toastError, fmt.Sprintf("Restore failed: %v", msg.err), 6*time.Second)
+	}
+	m.statusMessage = "Restored " + describeBackup(msg.info) + " · previous database kept as prismis.db.pre-restore"
+	return tea.Batch(
+		fetchSources(m.remoteURL),
+		func() tea.Msg { return commands.RefreshMsg{} },
+	)
+}
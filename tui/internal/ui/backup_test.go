@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestBackupPath_DirectoryGetsDatedName verifies a directory argument gets a timestamped file inside it.
+// BREAKS: If directories are used as the file name, :backup ~/backups fails or overwrites the last backup.
+func TestBackupPath_DirectoryGetsDatedName(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.Local)
+
+	path, err := backupPath(dir, now)
+	if err != nil || path != filepath.Join(dir, "prismis-2026-03-04-050607.db") {
+		t.Errorf("Expected a dated file in the directory, got %q (%v)", path, err)
+	}
+	file := filepath.Join(dir, "mine.db")
+	if path, _ := backupPath(file, now); path != file {
+		t.Errorf("Expected a file path used as given, got %q", path)
+	}
+}
+
+// TestRestore_AsksWithSizeAndTime verifies :restore shows the backup's size and time and only restores on y.
+// BREAKS: If the prompt is skipped, a mistyped path silently replaces the whole database.
+func TestRestore_AsksWithSizeAndTime(t *testing.T) {
+	m := testModel()
+	info := db.BackupInfo{Path: "/tmp/prismis.db", Size: 3 << 20, ModTime: time.Date(2026, 10, 1, 9, 30, 0, 0, time.Local)}
+
+	updated, _ := m.Update(restoreCheckedMsg{info: info})
+	m = updated.(Model)
+	if !strings.Contains(m.statusMessage, "3.0 MB, 2026-10-01 09:30") || !strings.HasSuffix(m.statusMessage, "(y/n)") {
+		t.Fatalf("Expected size and time in the prompt, got %q", m.statusMessage)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m = updated.(Model); cmd != nil || m.restoreConfirm.Path == "" {
+		t.Fatal("Expected other keys to leave the restore pending")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m = updated.(Model); m.restoreConfirm.Path != "" || m.statusMessage != "" {
+		t.Error("Expected n to cancel")
+	}
+
+	updated, _ = m.Update(restoreCheckedMsg{info: info})
+	updated, cmd = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m = updated.(Model); cmd == nil || m.restoreConfirm.Path != "" {
+		t.Error("Expected y to start the restore")
+	}
+}
@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// ChatModal is a conversation with the daemon about one article (:ask). Each
+// article keeps its own history for the session, and answers keep streaming
+// into it while the modal is closed.
+type ChatModal struct {
+	Modal    // Embed base modal
+	viewport viewport.Model
+	input    textinput.Model
+	itemID   string                        // Article the modal is showing
+	title    string                        // That article's title
+	history  map[string][]api.ChatTurn     // Turns per article ID
+	pending  map[string]bool               // Articles with an answer streaming
+	cancels  map[string]context.CancelFunc // Stops each streaming answer (Esc)
+	err      string                        // Last failed question's error, shown in the footer
+}
+
+// NewChatModal creates a new ChatModal instance
+func NewChatModal() ChatModal {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "Ask a follow-up..."
+	// A steady cursor means the modal only needs key messages
+	input.Cursor.SetMode(cursor.CursorStatic)
+	return ChatModal{
+		Modal:    NewModal("ASK", 80, 20), // Will be sized dynamically
+		viewport: viewport.New(76, 12),
+		input:    input,
+		history:  make(map[string][]api.ChatTurn),
+		pending:  make(map[string]bool),
+		cancels:  make(map[string]context.CancelFunc),
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *ChatModal) SetSize(width, height int) {
+	modalWidth := min(max(60, width*3/4), width-4)
+	modalHeight := max(12, height-6)
+	m.Modal.width = modalWidth
+	m.Modal.height = modalHeight
+	// Title, its margin, the article line, the input, the footer, and the
+	// three blank lines between them take eight rows
+	m.viewport.Width = modalWidth - 4
+	m.viewport.Height = max(1, modalHeight-10)
+	m.input.Width = modalWidth - 8
+	m.refresh()
+}
+
+// Open shows the conversation for an article, scrolled to the latest turn
+func (m *ChatModal) Open(itemID, title string) {
+	m.itemID = itemID
+	m.title = title
+	m.err = ""
+	m.input.Reset()
+	m.input.Focus()
+	m.refresh()
+	m.Show()
+}
+
+// Ask records question for the open article and returns the command that
+// streams its answer. It returns nil while an answer is still streaming.
+func (m *ChatModal) Ask(question string) tea.Cmd {
+	question = strings.TrimSpace(question)
+	if question == "" || m.pending[m.itemID] {
+		return nil
+	}
+	earlier := m.history[m.itemID]
+	m.history[m.itemID] = append(earlier,
+		api.ChatTurn{Role: "user", Content: question},
+		api.ChatTurn{Role: "assistant"})
+	m.pending[m.itemID] = true
+	m.err = ""
+	m.refresh()
+	// Its own context, so Esc stops this answer and nothing else
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancels[m.itemID] = cancel
+	return operations.AskArticle(ctx, m.itemID, question, earlier)
+}
+
+// AppendDelta adds streamed answer text to an article's latest turn
+func (m *ChatModal) AppendDelta(itemID, text string) {
+	turns := m.history[itemID]
+	if len(turns) == 0 || !m.pending[itemID] {
+		return
+	}
+	turns[len(turns)-1].Content += text
+	if itemID == m.itemID {
+		m.refresh()
+	}
+}
+
+// Finish ends an article's answer stream. A failed question is taken back
+// out of the history and, when its article is open, returned to the input
+// so it can be retried.
+func (m *ChatModal) Finish(itemID string, err error) {
+	delete(m.pending, itemID)
+	if cancel, ok := m.cancels[itemID]; ok {
+		cancel()
+		delete(m.cancels, itemID)
+	}
+	if err == nil {
+		if itemID == m.itemID {
+			m.refresh()
+		}
+		return
+	}
+
+	turns := m.history[itemID]
+	if len(turns) < 2 {
+		return
+	}
+	question := turns[len(turns)-2].Content
+	m.history[itemID] = turns[:len(turns)-2]
+	if itemID == m.itemID {
+		m.input.SetValue(question)
+		m.input.CursorEnd()
+		if !operations.IsCancelled(err) {
+			m.err = err.Error()
+		}
+		m.refresh()
+	}
+}
+
+// Pending reports whether an answer is streaming for itemID
+func (m ChatModal) Pending(itemID string) bool {
+	return m.pending[itemID]
+}
+
+// Turns returns the conversation about itemID
+func (m ChatModal) Turns(itemID string) []api.ChatTurn {
+	return m.history[itemID]
+}
+
+// refresh re-renders the conversation and follows it to the bottom
+func (m *ChatModal) refresh() {
+	m.viewport.SetContent(renderChat(m.history[m.itemID], m.pending[m.itemID], m.viewport.Width))
+	m.viewport.GotoBottom()
+}
+
+// renderChat lays out the turns as alternating "You" / "Assistant" blocks
+func renderChat(turns []api.ChatTurn, pending bool, width int) string {
+	theme := CleanCyberTheme
+	if len(turns) == 0 {
+		return lipgloss.NewStyle().Foreground(theme.Gray).Italic(true).
+			Render("Ask anything about this article.")
+	}
+
+	youStyle := lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	assistantStyle := lipgloss.NewStyle().Foreground(theme.Purple).Bold(true)
+	grayStyle := lipgloss.NewStyle().Foreground(theme.Gray).Italic(true)
+
+	var blocks []string
+	for i, turn := range turns {
+		if turn.Role == "user" {
+			blocks = append(blocks, youStyle.Render("You")+"\n"+wrapText(turn.Content, width))
+			continue
+		}
+		body := renderSimpleMarkdown(strings.TrimSpace(turn.Content), width)
+		if pending && i == len(turns)-1 && turn.Content == "" {
+			body = grayStyle.Render("Thinking...")
+		}
+		blocks = append(blocks, assistantStyle.Render("Assistant")+"\n"+body)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// Update handles input for the chat modal. Enter asks; Esc stops a streaming
+// answer, or closes the modal when nothing is streaming.
+func (m ChatModal) Update(msg tea.Msg) (ChatModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			if cancel, ok := m.cancels[m.itemID]; ok && m.pending[m.itemID] {
+				cancel()
+				return m, nil
+			}
+			m.input.Blur()
+			m.Hide()
+			return m, nil
+		case "enter":
+			cmd := m.Ask(m.input.Value())
+			if cmd != nil {
+				m.input.Reset()
+			}
+			return m, cmd
+		case "up":
+			m.viewport.ScrollUp(1)
+			return m, nil
+		case "down":
+			m.viewport.ScrollDown(1)
+			return m, nil
+		case "pgup", "ctrl+u":
+			m.viewport.HalfPageUp()
+			return m, nil
+		case "pgdown", "ctrl+d":
+			m.viewport.HalfPageDown()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the conversation over the background
+func (m ChatModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	grayStyle := lipgloss.NewStyle().Foreground(theme.Gray).Italic(true)
+	var footer string
+	switch {
+	case m.err != "":
+		footer = lipgloss.NewStyle().Foreground(theme.Red).Render("✗ " + m.err)
+	case m.pending[m.itemID]:
+		footer = grayStyle.Render("Answering... · Esc stop")
+	default:
+		footer = grayStyle.Render("Enter ask · ↑/↓ scroll · Esc close")
+	}
+
+	header := lipgloss.NewStyle().Foreground(theme.Gray).Render(truncate(m.title, m.viewport.Width))
+
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	left := lipgloss.NewStyle().Width(m.viewport.Width).Align(lipgloss.Left)
+	body := left.Render(header + "\n\n" + m.viewport.View() + "\n\n" + m.input.View())
+
+	modal := m.Modal
+	modal.SetContent(body + "\n\n" + footer)
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestChat_StreamsAnswerIntoPerItemHistory verifies :ask opens the conversation, streamed
+// deltas build the answer, and a failed follow-up is handed back for retry.
+// BREAKS: Answers only appear once complete, conversations bleed between articles,
+// or a failed question is lost.
+func TestChat_StreamsAnswerIntoPerItemHistory(t *testing.T) {
+	items := []db.ContentItem{{ID: "a", Title: "Async Rust"}, {ID: "b", Title: "SQLite"}}
+	m := Model{width: 120, height: 40, view: "reader", items: items, chat: NewChatModal()}
+	update := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+
+	if cmd := update(commands.AskMsg{Question: "Which runtime?"}); cmd == nil {
+		t.Fatal("Expected :ask to start streaming an answer")
+	}
+	if !m.chat.IsVisible() || !m.chat.Pending("a") || !strings.Contains(m.View(), "Thinking...") {
+		t.Fatal("Expected the open conversation to wait for the answer")
+	}
+
+	update(operations.AskDeltaMsg{ContentID: "a", Text: "Tokio, "})
+	if !strings.Contains(m.View(), "Tokio,") {
+		t.Error("Expected the first delta to show before the answer completes")
+	}
+	update(operations.AskDeltaMsg{ContentID: "a", Text: "mostly."})
+	update(operations.AskDoneMsg{ContentID: "a"})
+
+	turns := m.chat.Turns("a")
+	if len(turns) != 2 || turns[1].Content != "Tokio, mostly." || m.chat.Pending("a") {
+		t.Fatalf("Unexpected conversation %+v", turns)
+	}
+	if len(m.chat.Turns("b")) != 0 {
+		t.Error("Expected other articles to have no history")
+	}
+
+	// A follow-up typed in the modal that fails goes back into the input
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Why?")})
+	if cmd := update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("Expected Enter to ask the follow-up")
+	}
+	update(operations.AskDoneMsg{ContentID: "a", Error: errors.New("LLM answer failed")})
+	if len(m.chat.Turns("a")) != 2 || m.chat.input.Value() != "Why?" {
+		t.Errorf("Expected the failed question back in the input, got %q", m.chat.input.Value())
+	}
+	if !strings.Contains(m.View(), "LLM answer failed") {
+		t.Error("Expected the error in the modal footer")
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.chat.IsVisible() {
+		t.Error("Expected Esc to close the conversation")
+	}
+}
+
+// TestChat_EscStopsOnlyTheAnswer verifies Esc during an answer cancels that stream and
+// leaves other running operations alone.
+// BREAKS: Stopping an answer also aborts a sync or audio briefing running in the background.
+func TestChat_EscStopsOnlyTheAnswer(t *testing.T) {
+	chat := NewChatModal()
+	chat.SetSize(120, 40)
+	chat.Open("a", "Async Rust")
+	if cmd := chat.Ask("Which runtime?"); cmd == nil {
+		t.Fatal("Expected the question to start streaming")
+	}
+	other, release := operations.Cancellable()
+	defer release()
+
+	chat, _ = chat.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if other.Err() != nil {
+		t.Error("Expected other operations to keep running")
+	}
+	if !chat.IsVisible() {
+		t.Error("Expected the modal to stay open while the answer winds down")
+	}
+	chat.Finish("a", context.Canceled)
+	if chat.Pending("a") || chat.input.Value() != "Which runtime?" {
+		t.Errorf("Expected the stopped question back in the input, got %q", chat.input.Value())
+	}
+}
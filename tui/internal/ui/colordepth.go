@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Color depths for :set colors and [tui] colors; auto picks one from the
+// terminal's environment
+const (
+	colorsAuto      = "auto"
+	colorsTrueColor = "truecolor"
+	colors256       = "256"
+	colors16        = "16"
+)
+
+// truecolorTerms are TERM prefixes of terminals that take 24-bit color
+// without advertising it in COLORTERM
+var truecolorTerms = []string{"xterm-kitty", "alacritty", "wezterm", "foot", "xterm-ghostty", "contour"}
+
+// ansi16Palettes pick each theme's colors from the 16 ANSI colors by role
+// rather than by distance, which would turn most accents gray or white.
+// 8 is bright black, 9-15 the bright variants of 1-7.
+var ansi16Palettes = map[string]colorOverrides{
+	"clean_cyber": {
+		"cyan": "14", "purple": "13", "vibrant_purple": "5", "green": "10", "red": "9", "orange": "11",
+		"gray": "8", "dark_gray": "8", "white": "15",
+		"high": "9", "medium": "11", "low": "14", "unprioritized": "8", "read": "8", "favorite": "5", "selection": "14",
+	},
+	"monokai_pro": {
+		"cyan": "14", "purple": "13", "vibrant_purple": "9", "green": "10", "red": "9", "orange": "3",
+		"gray": "8", "dark_gray": "8", "white": "15",
+		"high": "9", "medium": "3", "low": "14", "unprioritized": "8", "read": "8", "favorite": "9", "selection": "14",
+	},
+	"light": {
+		"cyan": "6", "purple": "5", "vibrant_purple": "13", "green": "2", "red": "1", "orange": "3",
+		"gray": "8", "dark_gray": "8", "white": "7",
+		"high": "1", "medium": "3", "low": "6", "unprioritized": "8", "read": "8", "favorite": "13", "selection": "6",
+	},
+	"daylight": {
+		"cyan": "4", "purple": "5", "vibrant_purple": "5", "green": "2", "red": "1", "orange": "3",
+		"gray": "8", "dark_gray": "7", "white": "0",
+		"high": "1", "medium": "3", "low": "4", "unprioritized": "8", "read": "8", "favorite": "5", "selection": "4",
+	},
+}
+
+// parseColorDepth validates a :set colors or [tui] colors value
+func parseColorDepth(value string) (string, error) {
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case "", colorsAuto:
+		return colorsAuto, nil
+	case colorsTrueColor, "24bit":
+		return colorsTrueColor, nil
+	case colors256, colors16:
+		return value, nil
+	}
+	return colorsAuto, fmt.Errorf("expected auto, truecolor, 256 or 16, got '%s'", value)
+}
+
+// detectColorDepth reads the terminal's color support from COLORTERM, then
+// from its terminfo name in TERM: -direct entries are 24-bit, -256color
+// ones 256 colors, and anything else is assumed to manage 16
+func detectColorDepth(getenv func(string) string) string {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return colorsTrueColor
+	}
+	term := strings.ToLower(getenv("TERM"))
+	if strings.HasSuffix(term, "-direct") {
+		return colorsTrueColor
+	}
+	for _, prefix := range truecolorTerms {
+		if strings.HasPrefix(term, prefix) {
+			return colorsTrueColor
+		}
+	}
+	if strings.Contains(term, "256color") {
+		return colors256
+	}
+	return colors16
+}
+
+// activeColorDepth is the depth the theme is drawn in: the :set colors
+// value, or the detected one for auto
+func (m *Model) activeColorDepth() string {
+	if m.colorDepth == "" || m.colorDepth == colorsAuto {
+		return detectColorDepth(os.Getenv)
+	}
+	return m.colorDepth
+}
+
+// colorDepthSetting shows the colors option, with the detected depth for auto
+func (m *Model) colorDepthSetting() string {
+	if m.colorDepth == "" || m.colorDepth == colorsAuto {
+		return colorsAuto + " (" + m.activeColorDepth() + ")"
+	}
+	return m.colorDepth
+}
+
+// useTheme switches to the named theme at the current color depth, with the
+// [colors] overrides on top
+func (m *Model) useTheme(name string) {
+	for _, theme := range AvailableThemes {
+		if theme.Name == name {
+			m.theme = theme.atDepth(m.activeColorDepth(), m.colors)
+			break
+		}
+	}
+	useColorProfile(m.activeColorDepth())
+}
+
+// atDepth is t with overrides applied, limited to depth's colors. At 16
+// colors the theme's own ANSI palette replaces its hex colors; whatever is
+// left in hex (or past 15 at 16 colors) is mapped to its nearest color.
+func (t StyleTheme) atDepth(depth string, overrides colorOverrides) StyleTheme {
+	if depth == colors16 {
+		t = t.withColors(ansi16Palettes[t.Name])
+	}
+	t = t.withColors(overrides)
+	if profile := depthProfile(depth); profile != termenv.TrueColor {
+		for _, field := range colorFields {
+			color := field(&t)
+			*color = downsample(*color, profile)
+		}
+	}
+	return t
+}
+
+// downsample maps a hex or ANSI color number into profile's range
+func downsample(color lipgloss.Color, profile termenv.Profile) lipgloss.Color {
+	var from termenv.Color = termenv.RGBColor(color)
+	if n, err := strconv.Atoi(string(color)); err == nil {
+		if n < 16 {
+			return color
+		}
+		from = termenv.ANSI256Color(n)
+	}
+	switch to := profile.Convert(from).(type) {
+	case termenv.ANSIColor:
+		return lipgloss.Color(strconv.Itoa(int(to)))
+	case termenv.ANSI256Color:
+		return lipgloss.Color(strconv.Itoa(int(to)))
+	}
+	return color
+}
+
+// depthProfile is the termenv profile drawing in depth's colors
+func depthProfile(depth string) termenv.Profile {
+	switch depth {
+	case colors256:
+		return termenv.ANSI256
+	case colors16:
+		return termenv.ANSI
+	}
+	return termenv.TrueColor
+}
+
+var (
+	terminalProfileOnce sync.Once
+	terminalProfile     termenv.Profile
+)
+
+// useColorProfile limits lipgloss to depth's colors too, so the colors
+// outside the theme (gradients, fixed grays) degrade the same way. It never
+// goes past what lipgloss detected for the output.
+func useColorProfile(depth string) {
+	terminalProfileOnce.Do(func() { terminalProfile = lipgloss.ColorProfile() })
+	profile := terminalProfile
+	if forced := depthProfile(depth); forced > profile {
+		profile = forced
+	}
+	lipgloss.SetColorProfile(profile)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/commands"
+)
+
+// TestDetectColorDepth verifies the depth read from COLORTERM and TERM.
+// BREAKS: If detection misreads the terminal, themes show in the wrong palette or hex colors degrade on their own.
+func TestDetectColorDepth(t *testing.T) {
+	cases := []struct {
+		colorterm, term, want string
+	}{
+		{"truecolor", "xterm-256color", colorsTrueColor},
+		{"24bit", "screen", colorsTrueColor},
+		{"", "xterm-direct", colorsTrueColor},
+		{"", "xterm-kitty", colorsTrueColor},
+		{"", "tmux-256color", colors256},
+		{"", "xterm", colors16},
+		{"", "", colors16},
+	}
+	for _, c := range cases {
+		env := map[string]string{"COLORTERM": c.colorterm, "TERM": c.term}
+		if got := detectColorDepth(func(key string) string { return env[key] }); got != c.want {
+			t.Errorf("COLORTERM=%q TERM=%q: expected %s, got %s", c.colorterm, c.term, c.want, got)
+		}
+	}
+}
+
+// TestAtDepth_MapsThemeColors verifies each depth keeps theme colors in its range, with [colors] on top.
+// BREAKS: If hex colors reach a 16-color terminal, priorities come out in whatever the terminal rounds them to.
+func TestAtDepth_MapsThemeColors(t *testing.T) {
+	overrides := colorOverrides{"favorite": "#FF5FAF"}
+
+	full := CleanCyberTheme.atDepth(colorsTrueColor, overrides)
+	if full.High != CleanCyberTheme.High || full.Favorite != "#FF5FAF" {
+		t.Errorf("Expected truecolor to keep hex colors, got high=%s favorite=%s", full.High, full.Favorite)
+	}
+
+	ansi256 := CleanCyberTheme.atDepth(colors256, overrides)
+	if ansi256.High != "197" || ansi256.Favorite != "205" {
+		t.Errorf("Expected the nearest 256 colors, got high=%s favorite=%s", ansi256.High, ansi256.Favorite)
+	}
+
+	for _, theme := range AvailableThemes {
+		ansi16 := theme.atDepth(colors16, overrides)
+		for name, field := range colorFields {
+			if color := *field(&ansi16); !isANSI16(color) {
+				t.Errorf("%s at 16 colors: expected 0-15 for %s, got %s", theme.Name, name, color)
+			}
+		}
+	}
+	if got := CleanCyberTheme.atDepth(colors16, nil); got.High != "9" || got.Medium != "11" || got.Low != "14" {
+		t.Errorf("Expected the theme's 16-color palette, got high=%s medium=%s low=%s", got.High, got.Medium, got.Low)
+	}
+}
+
+// TestSetColors_RedrawsTheme verifies :set colors switches the current theme's palette and auto returns to hex.
+// BREAKS: If the option doesn't redraw the theme, forcing 256 or 16 colors takes a restart.
+func TestSetColors_RedrawsTheme(t *testing.T) {
+	t.Setenv("COLORTERM", "truecolor")
+	m := testModel()
+	m.useTheme(MonokaiProTheme.Name)
+
+	m.setOption(commands.SetOptionMsg{Name: "colors", Value: "16"})
+	if m.theme.Name != MonokaiProTheme.Name || m.theme.High != "9" {
+		t.Errorf("Expected monokai_pro in 16 colors, got %s high=%s", m.theme.Name, m.theme.High)
+	}
+	if _, err := findOption("colors").set(&m, "88"); err == nil {
+		t.Error("Expected an unknown depth rejected")
+	}
+
+	m.setOption(commands.SetOptionMsg{Name: "colors", Value: "auto"})
+	if m.theme.High != MonokaiProTheme.High {
+		t.Errorf("Expected auto on a truecolor terminal to restore hex colors, got high=%s", m.theme.High)
+	}
+	if got := findOption("colors").get(&m); got != "auto (truecolor)" {
+		t.Errorf("Expected the detected depth shown, got %q", got)
+	}
+}
+
+func isANSI16(color lipgloss.Color) bool {
+	switch color {
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15":
+		return true
+	}
+	return false
+}
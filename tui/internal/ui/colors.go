@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// colorsInvalidMsg reports [colors] entries that were dropped
+type colorsInvalidMsg struct {
+	err error
+}
+
+// colorOverrides are the [colors] entries by theme color name
+type colorOverrides map[string]lipgloss.Color
+
+// colorFields maps the [colors] names to the theme colors they set
+var colorFields = map[string]func(t *StyleTheme) *lipgloss.Color{
+	"high":           func(t *StyleTheme) *lipgloss.Color { return &t.High },
+	"medium":         func(t *StyleTheme) *lipgloss.Color { return &t.Medium },
+	"low":            func(t *StyleTheme) *lipgloss.Color { return &t.Low },
+	"unprioritized":  func(t *StyleTheme) *lipgloss.Color { return &t.Unprioritized },
+	"read":           func(t *StyleTheme) *lipgloss.Color { return &t.Read },
+	"favorite":       func(t *StyleTheme) *lipgloss.Color { return &t.Favorite },
+	"selection":      func(t *StyleTheme) *lipgloss.Color { return &t.Selection },
+	"cyan":           func(t *StyleTheme) *lipgloss.Color { return &t.Cyan },
+	"purple":         func(t *StyleTheme) *lipgloss.Color { return &t.Purple },
+	"vibrant_purple": func(t *StyleTheme) *lipgloss.Color { return &t.VibrantPurple },
+	"green":          func(t *StyleTheme) *lipgloss.Color { return &t.Green },
+	"red":            func(t *StyleTheme) *lipgloss.Color { return &t.Red },
+	"orange":         func(t *StyleTheme) *lipgloss.Color { return &t.Orange },
+	"gray":           func(t *StyleTheme) *lipgloss.Color { return &t.Gray },
+	"dark_gray":      func(t *StyleTheme) *lipgloss.Color { return &t.DarkGray },
+	"white":          func(t *StyleTheme) *lipgloss.Color { return &t.White },
+}
+
+// hexColorPattern matches #RGB and #RRGGBB
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// parseColors validates [colors]: each name is a theme color and each value
+// a hex color or an ANSI color number (0-255)
+func parseColors(colors map[string]string) (colorOverrides, error) {
+	overrides := colorOverrides{}
+	var bad []string
+	for name, value := range colors {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if _, ok := colorFields[name]; !ok || !validColor(value) {
+			bad = append(bad, name)
+			continue
+		}
+		overrides[name] = lipgloss.Color(value)
+	}
+	if len(bad) > 0 {
+		sort.Strings(bad)
+		return overrides, fmt.Errorf("ignoring [colors] %s: expected a theme color like high or selection set to \"#RRGGBB\" or 0-255", strings.Join(bad, ", "))
+	}
+	return overrides, nil
+}
+
+// validColor accepts a hex color or an ANSI color number
+func validColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// withColors is t with the [colors] overrides applied
+func (t StyleTheme) withColors(overrides colorOverrides) StyleTheme {
+	for name, color := range overrides {
+		*colorFields[name](&t) = color
+	}
+	return t
+}
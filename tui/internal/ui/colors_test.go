@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestParseColors_ValidatesNamesAndValues verifies [colors] keeps good entries and names the bad ones.
+// BREAKS: If a typo silently applies or aborts the rest, a custom theme half-works with no hint why.
+func TestParseColors_ValidatesNamesAndValues(t *testing.T) {
+	overrides, err := parseColors(map[string]string{
+		"favorite":  "#FF00AA",
+		"Selection": " 205 ",
+		"high":      "#F0A",
+		"hearts":    "#FF0000",
+		"read":      "grey",
+		"low":       "256",
+	})
+	if len(overrides) != 3 || overrides["selection"] != lipgloss.Color("205") {
+		t.Errorf("Expected the three valid overrides, got %v", overrides)
+	}
+	if err == nil || !strings.Contains(err.Error(), "hearts, low, read") {
+		t.Errorf("Expected the bad entries named, got %v", err)
+	}
+}
+
+// TestWithColors_ReachesItemStates verifies overrides change the list's state colors under every theme.
+// BREAKS: If rows read hardcoded palette colors, a custom favorite or priority color never shows.
+func TestWithColors_ReachesItemStates(t *testing.T) {
+	overrides := colorOverrides{"favorite": "#123456", "high": "#ABCDEF"}
+	for _, base := range AvailableThemes {
+		theme := base.withColors(overrides)
+		if theme.Favorite != "#123456" || theme.PriorityColor("high") != "#ABCDEF" {
+			t.Errorf("%s: expected overrides applied, got %+v", base.Name, theme)
+		}
+		if theme.Medium != base.Medium || base.Favorite == "#123456" {
+			t.Errorf("%s: expected other colors and the preset untouched", base.Name)
+		}
+	}
+
+	theme := CleanCyberTheme.withColors(overrides)
+	favorite := itemIndicator(db.ContentItem{Favorited: true}, theme)
+	if favorite != lipgloss.NewStyle().Foreground(theme.Favorite).Render("♥") {
+		t.Errorf("Expected the heart in the favorite color, got %q", favorite)
+	}
+}
@@ -69,6 +69,13 @@ func (c *CommandMode) Show() {
 	c.completionBase = ""
 }
 
+// ShowWith activates command mode with text already typed (e.g. "add ")
+func (c *CommandMode) ShowWith(text string) {
+	c.Show()
+	c.input.SetValue(text)
+	c.input.CursorEnd()
+}
+
 // Hide deactivates command mode
 func (c *CommandMode) Hide() {
 	c.active = false
@@ -350,7 +357,11 @@ func parseCommandWithQuotes(cmd string) []string {
 
 		switch {
 		case escaped:
-			// Previous character was backslash, add this literally
+			// Previous character was backslash: it escapes a quote, space, or
+			// backslash, and is kept before anything else so regexes like \d survive
+			if r != '"' && r != ' ' && r != '\\' {
+				current.WriteRune('\\')
+			}
 			current.WriteRune(r)
 			escaped = false
 
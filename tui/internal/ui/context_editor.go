@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// contextEditorMaxLines caps context.md's length in the editor; it also sets
+// the line-number gutter to four digits
+const contextEditorMaxLines = 9999
+
+// ContextEditorModal edits context.md without suspending the TUI (:context edit)
+type ContextEditorModal struct {
+	Modal          // Embed base modal
+	editor         textarea.Model
+	path           string
+	saved          string // Content as last loaded or saved
+	saving         string // Content of the save in flight
+	confirmDiscard bool   // Esc pressed once with unsaved changes
+}
+
+// NewContextEditorModal creates a new ContextEditorModal instance
+func NewContextEditorModal() ContextEditorModal {
+	editor := textarea.New()
+	editor.Prompt = ""
+	editor.CharLimit = 0
+	editor.MaxHeight = contextEditorMaxLines
+	// A steady cursor means the modal only needs key messages
+	editor.Cursor.SetMode(cursor.CursorStatic)
+	return ContextEditorModal{
+		Modal:  NewModal("CONTEXT.MD", 80, 20), // Will be sized dynamically
+		editor: editor,
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *ContextEditorModal) SetSize(width, height int) {
+	modalWidth := min(max(60, width*3/4), width-4)
+	modalHeight := max(12, height-4)
+	m.Modal.width = modalWidth
+	m.Modal.height = modalHeight
+	// Title, its margin, the blank line, and the footer take four rows
+	m.editor.SetWidth(modalWidth - 4)
+	m.editor.SetHeight(max(1, modalHeight-6))
+}
+
+// Open loads content into the editor with the cursor at the top
+func (m *ContextEditorModal) Open(path, content string) {
+	m.path = path
+	m.saved = content
+	m.confirmDiscard = false
+	m.editor.SetValue(content)
+	for m.editor.Line() > 0 {
+		m.editor.CursorUp()
+	}
+	m.editor.CursorStart()
+	m.editor.Focus()
+	m.Show()
+}
+
+// Dirty reports whether the editor holds unsaved changes
+func (m ContextEditorModal) Dirty() bool {
+	return m.editor.Value() != m.saved
+}
+
+// Saved records that the in-flight save reached disk
+func (m *ContextEditorModal) Saved() {
+	m.saved = m.saving
+}
+
+// Update handles input for the context editor. Ctrl-S saves; Esc closes,
+// asking for a second Esc when there are unsaved changes.
+func (m ContextEditorModal) Update(msg tea.Msg) (ContextEditorModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+s":
+			m.confirmDiscard = false
+			m.saving = m.editor.Value()
+			return m, operations.SaveContextFile(m.path, m.saving)
+		case "esc":
+			if m.Dirty() && !m.confirmDiscard {
+				m.confirmDiscard = true
+				return m, nil
+			}
+			m.editor.Blur()
+			m.Hide()
+			return m, nil
+		}
+		m.confirmDiscard = false
+		var cmd tea.Cmd
+		m.editor, cmd = m.editor.Update(msg)
+		return m, cmd
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// contextSection returns the "## " heading the given line falls under
+func contextSection(lines []string, row int) string {
+	for i := min(row, len(lines)-1); i >= 0; i-- {
+		if heading, ok := strings.CutPrefix(strings.TrimSpace(lines[i]), "## "); ok {
+			return heading
+		}
+	}
+	return ""
+}
+
+// sectionColor maps a context.md heading to its priority color
+func sectionColor(heading string, theme StyleTheme) lipgloss.Color {
+	heading = strings.ToLower(heading)
+	switch {
+	case strings.Contains(heading, "not interested"):
+		return theme.Gray
+	case strings.Contains(heading, "high"):
+		return theme.Red
+	case strings.Contains(heading, "medium"):
+		return theme.Orange
+	case strings.Contains(heading, "low"):
+		return theme.Cyan
+	}
+	return theme.Purple
+}
+
+// highlightHeadings colors the section headings in the rendered editor. The
+// cursor's line is left as the textarea drew it so the cursor stays visible.
+func (m ContextEditorModal) highlightHeadings(view string, theme StyleTheme) string {
+	current := ""
+	if lines := strings.Split(m.editor.Value(), "\n"); m.editor.Line() < len(lines) {
+		current = strings.TrimSpace(lines[m.editor.Line()])
+	}
+	gutterStyle := lipgloss.NewStyle().Foreground(theme.DarkGray)
+
+	rendered := strings.Split(view, "\n")
+	for i, line := range rendered {
+		plain := ansi.Strip(line)
+		text := strings.TrimLeft(plain, " 0123456789")
+		if !strings.HasPrefix(text, "#") || strings.TrimSpace(text) == current {
+			continue
+		}
+		heading := strings.TrimSpace(strings.TrimLeft(text, "#"))
+		style := lipgloss.NewStyle().Foreground(sectionColor(heading, theme)).Bold(true)
+		rendered[i] = gutterStyle.Render(plain[:len(plain)-len(text)]) + style.Render(text)
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// ViewWithOverlay renders the editor over the background
+func (m ContextEditorModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	grayStyle := lipgloss.NewStyle().Foreground(theme.Gray).Italic(true)
+	var footer string
+	switch {
+	case m.confirmDiscard:
+		footer = lipgloss.NewStyle().Foreground(theme.Orange).
+			Render("Unsaved changes. Esc again to discard, Ctrl-S to save")
+	default:
+		footer = "Ctrl-S save · Esc close"
+		if section := contextSection(strings.Split(m.editor.Value(), "\n"), m.editor.Line()); section != "" {
+			footer = lipgloss.NewStyle().Foreground(sectionColor(section, theme)).Render(section) +
+				grayStyle.Render(" · "+footer)
+		} else {
+			footer = grayStyle.Render(footer)
+		}
+		if m.Dirty() {
+			footer = lipgloss.NewStyle().Foreground(theme.Orange).Render("● modified ") + footer
+		}
+	}
+
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	body := lipgloss.NewStyle().Width(m.Modal.width - 4).Align(lipgloss.Left).
+		Render(m.highlightHeadings(m.editor.View(), theme))
+
+	modal := m.Modal
+	modal.SetContent(body + "\n\n" + footer)
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestContextEditor_EditSaveAndClose verifies :context edit opens context.md in the modal,
+// Ctrl-S writes it back, and Esc guards unsaved changes.
+// BREAKS: Edits are lost silently, or the TUI still suspends into $EDITOR.
+func TestContextEditor_EditSaveAndClose(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	path := filepath.Join(configDir, "prismis", "context.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("## High Priority Topics\n- rust\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{width: 120, height: 40, view: "list", contextEditor: NewContextEditorModal()}
+	run := func(msg tea.Msg) tea.Msg {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	loaded := run(commands.ContextEditMsg{})
+	if _, ok := loaded.(operations.ContextLoadedMsg); !ok {
+		t.Fatalf("Expected :context edit to load the file, got %T", loaded)
+	}
+	run(loaded)
+	if !m.contextEditor.IsVisible() {
+		t.Fatal("Expected the editor to open")
+	}
+	if view := m.View(); !strings.Contains(view, "High Priority Topics") {
+		t.Error("Expected the file contents in the editor")
+	}
+
+	// Type at the top of the file
+	run(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("# Me\n")})
+	if !m.contextEditor.Dirty() {
+		t.Fatal("Expected typing to mark the editor dirty")
+	}
+
+	// First Esc only warns
+	run(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.contextEditor.IsVisible() || !strings.Contains(m.View(), "Esc again to discard") {
+		t.Fatal("Expected Esc with unsaved changes to ask for confirmation")
+	}
+
+	saved := run(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if _, ok := saved.(operations.ContextSavedMsg); !ok {
+		t.Fatalf("Expected Ctrl-S to save, got %T", saved)
+	}
+	updated, _ := m.Update(saved) // Its only command is the toast's expiry timer
+	m = updated.(Model)
+	if m.contextEditor.Dirty() {
+		t.Error("Expected the editor to be clean after saving")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "# Me\n## High Priority Topics\n- rust\n" {
+		t.Errorf("Unexpected saved file: %q", data)
+	}
+
+	run(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.contextEditor.IsVisible() {
+		t.Error("Expected Esc on a clean editor to close it")
+	}
+}
+
+// TestContextSection verifies the footer names the heading the cursor is under.
+// BREAKS: Users can't tell which priority section they're adding topics to.
+func TestContextSection(t *testing.T) {
+	lines := []string{"# Context", "## High Priority Topics", "- rust", "", "## Not Interested", "- crypto"}
+	if got := contextSection(lines, 2); got != "High Priority Topics" {
+		t.Errorf("row 2 section = %q", got)
+	}
+	if got := contextSection(lines, 5); got != "Not Interested" {
+		t.Errorf("row 5 section = %q", got)
+	}
+	if got := contextSection(lines, 0); got != "" {
+		t.Errorf("row 0 section = %q, want none", got)
+	}
+}
+
+// TestContextAdd_PicksEntityAndOffersReanalysis verifies a bare :context add offers the
+// article's entities, and a successful add asks before re-analyzing.
+// BREAKS: Quick-add needs the topic typed out, or re-analysis runs LLM calls unasked.
+func TestContextAdd_PicksEntityAndOffersReanalysis(t *testing.T) {
+	item := db.ContentItem{ID: "a", Title: "Async Rust"}
+	item.SetAnalysis(`{"entities": ["tokio", "async runtimes"]}`)
+	m := Model{width: 120, height: 40, view: "reader", items: []db.ContentItem{item}, palette: NewCommandPalette()}
+
+	updated, _ := m.Update(commands.ContextAddMsg{Section: "high"})
+	m = updated.(Model)
+	if !m.palette.IsVisible() || len(m.palette.matches) != 2 {
+		t.Fatalf("Expected a picker over the 2 entities, got %d", len(m.palette.matches))
+	}
+	if line := m.palette.matches[1].line; strings.Join(line, " ") != "context add high async runtimes" {
+		t.Errorf("Unexpected command for entity: %v", line)
+	}
+
+	// Enter picks "tokio" and runs :context add high tokio
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if msg, ok := cmd().(commands.ContextAddMsg); !ok || msg.Topic != "tokio" || msg.Section != "high" {
+		t.Fatalf("Expected the picked entity to be added to high, got %#v", msg)
+	}
+
+	updated, _ = m.Update(operations.ContextTopicAddedMsg{Topic: "tokio", Section: "high", Added: true})
+	m = updated.(Model)
+	if !m.reanalyzeConfirm || !strings.Contains(m.statusMessage, "Re-analyze") {
+		t.Fatalf("Expected a re-analysis offer, got %q", m.statusMessage)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	if m.reanalyzeConfirm || m.statusMessage != "" || cmd != nil {
+		t.Error("Expected n to dismiss the offer without re-analyzing")
+	}
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// reviewSectionKeys maps the accept keys to context.md sections
+var reviewSectionKeys = map[string]string{
+	"a": "medium",
+	"1": "high",
+	"2": "medium",
+	"3": "low",
+}
+
+// reviewDecision is what a :context review session does with one item:
+// add one of its entities as a topic, or just dismiss it
+type reviewDecision struct {
+	topic     string
+	section   string
+	dismissed bool
+}
+
+// contextReviewOpenMsg asks the model to open a flagged item's link
+type contextReviewOpenMsg struct{ item db.ContentItem }
+
+// contextReviewApplyMsg carries a finished session: the topics to add to
+// context.md and the items to unflag
+type contextReviewApplyMsg struct {
+	topics   []api.ContextTopic
+	resolved []string
+}
+
+// ContextReviewModal lists the items flagged interesting with their
+// entities, staging topics for context.md until the session is applied
+// (:context review)
+type ContextReviewModal struct {
+	Modal     // Embed base modal
+	items     []db.ContentItem
+	entities  [][]string
+	entity    []int // Selected entity per item
+	decisions map[string]reviewDecision
+	cursor    int
+}
+
+// NewContextReviewModal creates a new ContextReviewModal instance
+func NewContextReviewModal() ContextReviewModal {
+	return ContextReviewModal{
+		Modal: NewModal("CONTEXT REVIEW", 80, 20), // Will be sized dynamically
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *ContextReviewModal) SetSize(width, height int) {
+	m.Modal.width = max(50, min(int(float64(width)*0.75), width-4))
+	m.Modal.height = max(10, height-8)
+}
+
+// Open starts a session over the flagged items
+func (m *ContextReviewModal) Open(items []db.ContentItem) {
+	m.items = items
+	m.entities = make([][]string, len(items))
+	m.entity = make([]int, len(items))
+	for i, item := range items {
+		m.entities[i] = item.ParsedAnalysis().Entities
+	}
+	m.decisions = map[string]reviewDecision{}
+	m.cursor = 0
+	m.Show()
+}
+
+// stage records a decision for the item under the cursor and moves on
+func (m *ContextReviewModal) stage(d reviewDecision) {
+	m.decisions[m.items[m.cursor].ID] = d
+	m.cursor = min(len(m.items)-1, m.cursor+1)
+}
+
+// batch collects the session's decisions: each accepted topic once, and
+// every accepted or dismissed item
+func (m ContextReviewModal) batch() contextReviewApplyMsg {
+	var msg contextReviewApplyMsg
+	seen := map[string]bool{}
+	for _, item := range m.items {
+		d, ok := m.decisions[item.ID]
+		if !ok {
+			continue
+		}
+		msg.resolved = append(msg.resolved, item.ID)
+		if key := strings.ToLower(d.topic); !d.dismissed && !seen[key] {
+			seen[key] = true
+			msg.topics = append(msg.topics, api.ContextTopic{Topic: d.topic, Section: d.section})
+		}
+	}
+	return msg
+}
+
+// Update handles input for the context review modal
+func (m ContextReviewModal) Update(msg tea.Msg) (ContextReviewModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+		if key == "esc" || key == "q" {
+			m.Hide()
+			return m, nil
+		}
+		if key == "enter" {
+			m.Hide()
+			batch := m.batch()
+			if len(batch.resolved) == 0 {
+				return m, nil
+			}
+			return m, func() tea.Msg { return batch }
+		}
+		if len(m.items) == 0 {
+			return m, nil
+		}
+		item, entities := m.items[m.cursor], m.entities[m.cursor]
+		switch key {
+		case "k", "up":
+			m.cursor = max(0, m.cursor-1)
+		case "j", "down":
+			m.cursor = min(len(m.items)-1, m.cursor+1)
+		case "h", "left":
+			if len(entities) > 0 {
+				m.entity[m.cursor] = (m.entity[m.cursor] + len(entities) - 1) % len(entities)
+			}
+		case "l", "right", "tab":
+			if len(entities) > 0 {
+				m.entity[m.cursor] = (m.entity[m.cursor] + 1) % len(entities)
+			}
+		case "a", "1", "2", "3":
+			if len(entities) > 0 {
+				m.stage(reviewDecision{topic: entities[m.entity[m.cursor]], section: reviewSectionKeys[key]})
+			}
+		case "d", "x":
+			m.stage(reviewDecision{dismissed: true})
+		case "u":
+			delete(m.decisions, item.ID)
+		case "o":
+			return m, func() tea.Msg { return contextReviewOpenMsg{item: item} }
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the flagged items around the cursor over the background
+func (m ContextReviewModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	lineWidth := m.Modal.width - 4 // Inside padding
+	// Each item takes two lines; the title, tally, hints, and margins take eight
+	perPage := max(1, (m.Modal.height-8)/2)
+	start, end := visibleRange(m.cursor, len(m.items), perPage)
+
+	lineStyle := lipgloss.NewStyle().Width(lineWidth).MaxHeight(1)
+	gray := lipgloss.NewStyle().Foreground(theme.Gray)
+	selected := lipgloss.NewStyle().Foreground(theme.Selection).Bold(true)
+
+	var content strings.Builder
+	if len(m.items) == 0 {
+		content.WriteString(lineStyle.Foreground(theme.Gray).Render("No items flagged; press i on an item that should have matched your topics"))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		selector, titleStyle := "  ", lipgloss.NewStyle().Foreground(theme.White)
+		if i == m.cursor {
+			selector = selected.Render("▸ ")
+			titleStyle = selected
+		}
+		var mark string
+		if d, ok := m.decisions[item.ID]; ok {
+			if d.dismissed {
+				mark = gray.Render("dismissed ")
+			} else {
+				mark = lipgloss.NewStyle().Foreground(theme.Green).Render(fmt.Sprintf("+%s → %s ", d.topic, d.section))
+			}
+		}
+		content.WriteString(lineStyle.Render(selector + mark + titleStyle.Render(item.Title)))
+		content.WriteString("\n")
+		content.WriteString(lineStyle.Render("    " + m.renderEntities(i, theme)))
+		content.WriteString("\n")
+	}
+
+	accepted, dismissed := 0, 0
+	for _, d := range m.decisions {
+		if d.dismissed {
+			dismissed++
+		} else {
+			accepted++
+		}
+	}
+	content.WriteString("\n")
+	content.WriteString(lineStyle.Render(gray.Render(fmt.Sprintf("%d flagged · %d accepted · %d dismissed", len(m.items), accepted, dismissed))))
+	content.WriteString("\n")
+	content.WriteString(gray.Italic(true).Render("h/l entity · a accept (1 high 2 medium 3 low) · d dismiss · u undo · o open · enter apply · esc discard"))
+
+	modal := m.Modal
+	modal.SetContent(content.String())
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
+
+// renderEntities lists an item's entities, highlighting the selected one on
+// the cursor's row
+func (m ContextReviewModal) renderEntities(i int, theme StyleTheme) string {
+	gray := lipgloss.NewStyle().Foreground(theme.Gray)
+	if len(m.entities[i]) == 0 {
+		return gray.Italic(true).Render("no entities")
+	}
+	parts := make([]string, len(m.entities[i]))
+	for j, entity := range m.entities[i] {
+		if i == m.cursor && j == m.entity[i] {
+			parts[j] = lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true).Render("[" + entity + "]")
+		} else {
+			parts[j] = gray.Render(entity)
+		}
+	}
+	return strings.Join(parts, gray.Render(" · "))
+}
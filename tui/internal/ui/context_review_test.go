@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestContextReview_StagesTopicsAndAppliesOneBatch verifies :context review lists flagged items with their entities and batches the accepted topics.
+// BREAKS: Without the batch, every accepted topic rewrites context.md and asks to re-analyze separately.
+func TestContextReview_StagesTopicsAndAppliesOneBatch(t *testing.T) {
+	items := []db.ContentItem{
+		{ID: "a", Title: "Async Rust", UserFeedback: "up", Analysis: `{"entities": ["rust", "async runtime"]}`},
+		{ID: "skip", Title: "Not flagged", Analysis: `{"entities": ["golang"]}`},
+		{ID: "b", Title: "Hype piece", UserFeedback: "up"},
+		{ID: "c", Title: "Tokio internals", UserFeedback: "up", Analysis: `{"entities": ["Async Runtime"]}`},
+	}
+	m := testModelWithItems(items)
+	m.remoteURL = "http://daemon:8989"
+	m.itemsCache = items
+	m.contextReview = NewContextReviewModal()
+
+	updated, _ := m.Update(commands.ContextReviewMsg{})
+	m = updated.(Model)
+	if !m.contextReview.IsVisible() || len(m.contextReview.items) != 3 {
+		t.Fatalf("Expected a review of the 3 flagged items, got %d", len(m.contextReview.items))
+	}
+	if view := m.View(); !strings.Contains(view, "[rust]") || !strings.Contains(view, "Tokio internals") {
+		t.Errorf("Expected the flagged items and their entities:\n%s", view)
+	}
+
+	press := func(key string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	press("l")
+	press("1") // "async runtime" to high
+	press("d") // Dismiss the item with no entities
+	press("a") // "Async Runtime" again, already staged
+	cmd := press("enter")
+	if m.contextReview.IsVisible() || cmd == nil {
+		t.Fatal("Expected enter to close the review and apply it")
+	}
+	batch, ok := cmd().(contextReviewApplyMsg)
+	if !ok {
+		t.Fatalf("Expected a contextReviewApplyMsg, got %T", cmd())
+	}
+	want := []api.ContextTopic{{Topic: "async runtime", Section: "high"}}
+	if len(batch.topics) != 1 || batch.topics[0] != want[0] {
+		t.Errorf("Expected one topic %v, got %v", want, batch.topics)
+	}
+	if strings.Join(batch.resolved, ",") != "a,b,c" {
+		t.Errorf("Expected every reviewed item resolved, got %v", batch.resolved)
+	}
+
+	updated, _ = m.Update(operations.ContextReviewAppliedMsg{Added: []string{"async runtime"}, Section: "high", Unflagged: batch.resolved})
+	m = updated.(Model)
+	if m.items[0].UserFeedback != "" || m.items[1].UserFeedback != "" || m.itemsCache[3].UserFeedback != "" {
+		t.Error("Expected the reviewed items unflagged")
+	}
+	if !m.reanalyzeConfirm || !strings.Contains(m.statusMessage, "Added 1 topic to high") {
+		t.Errorf("Expected the re-analysis offer, got %q", m.statusMessage)
+	}
+}
+
+// TestContextReview_EscDiscards verifies esc closes the review without touching flags or context.md.
+// BREAKS: A session abandoned halfway would still rewrite context.md.
+func TestContextReview_EscDiscards(t *testing.T) {
+	m := testModel()
+	m.contextReview.Open([]db.ContentItem{{ID: "a", Title: "A", UserFeedback: "up", Analysis: `{"entities": ["rust"]}`}})
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.contextReview.IsVisible() || cmd != nil {
+		t.Error("Expected esc to close the review with nothing to apply")
+	}
+}
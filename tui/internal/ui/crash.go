@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxRecentMsgs is how many handled messages a crash report lists
+const maxRecentMsgs = 100
+
+// stateDirFunc returns prismis's state directory (overridable for testing)
+var stateDirFunc = defaultStateDir
+
+// defaultStateDir keeps crash reports and debug traces under XDG_STATE_HOME
+func defaultStateDir() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "prismis"), nil
+}
+
+// lastCrashReport is the report written for the panic that ended the program
+var lastCrashReport atomic.Value
+
+// CrashReport returns the path of the crash report written this run, or ""
+// if none was. Bubble Tea restores the terminal after a panic and Run
+// returns tea.ErrProgramPanic; main then points at this file.
+func CrashReport() string {
+	path, _ := lastCrashReport.Load().(string)
+	return path
+}
+
+// describeMsg summarizes a message for the crash report's history: the key
+// pressed, or the message's type
+func describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return "key " + msg.String()
+	case tea.WindowSizeMsg:
+		return fmt.Sprintf("resize %dx%d", msg.Width, msg.Height)
+	}
+	return fmt.Sprintf("%T", msg)
+}
+
+// reportPanic is deferred by Update and View: on a panic it writes a crash
+// report and panics again so Bubble Tea still restores the terminal
+func (m Model) reportPanic(where string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	// Update can run nested (a result handed back through m.Update); the
+	// innermost call, closest to the panic, writes the report
+	if CrashReport() == "" {
+		if path, err := writeCrashReport(m, where, r, debug.Stack()); err == nil {
+			lastCrashReport.Store(path)
+		}
+	}
+	panic(r)
+}
+
+// writeCrashReport saves the panic, its stack, and what led up to it to
+// crash-<timestamp>.log
+func writeCrashReport(m Model, where string, r any, stack []byte) (string, error) {
+	dir, err := stateDirFunc()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create crash directory: %w", err)
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	fmt.Fprintf(&b, "prismis crash report\n\n")
+	fmt.Fprintf(&b, "time:   %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "go:     %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "panic:  %v\n", r)
+	fmt.Fprintf(&b, "in:     %s\n", where)
+	mode := "local"
+	if m.remoteURL != "" {
+		mode = "remote " + m.remoteURL
+	}
+	fmt.Fprintf(&b, "state:  view=%s mode=%s items=%d cursor=%d size=%dx%d\n", m.view, mode, len(m.items), m.cursor, m.width, m.height)
+
+	fmt.Fprintf(&b, "\nstack:\n%s\n", stack)
+
+	fmt.Fprintf(&b, "recent messages (oldest first):\n")
+	for _, msg := range m.recentMsgs.list() {
+		fmt.Fprintf(&b, "  %s\n", msg)
+	}
+	fmt.Fprintf(&b, "\nrecent notifications:\n")
+	for _, t := range m.toastHistory.list() {
+		fmt.Fprintf(&b, "  %s %-5s %s\n", t.at.Format("15:04:05"), t.level.label(), t.text)
+	}
+
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405")+".log")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestReportPanic_WritesCrashReport verifies a panic leaves a report with the stack and recent activity, then still panics.
+// BREAKS: If the panic is swallowed Bubble Tea never restores the terminal; without the report a crash is just a garbled screen.
+func TestReportPanic_WritesCrashReport(t *testing.T) {
+	dir := t.TempDir()
+	stateDirFunc = func() (string, error) { return dir, nil }
+	defer func() {
+		stateDirFunc = defaultStateDir
+		lastCrashReport.Store("")
+	}()
+
+	m := testModelWithItems([]db.ContentItem{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}})
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	m.notify(toastError, "Sync failed: boom", time.Second)
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		defer m.reportPanic("Update(key G)")
+		panic("index out of range")
+	}()
+	if recovered != "index out of range" {
+		t.Fatalf("Expected the panic passed on to Bubble Tea, got %v", recovered)
+	}
+
+	path := CrashReport()
+	if filepath.Dir(path) != dir || !strings.HasPrefix(filepath.Base(path), "crash-") {
+		t.Fatalf("Expected a crash-<ts>.log in the state dir, got %q", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := string(data)
+	for _, want := range []string{"panic:  index out of range", "in:     Update(key G)", "TestReportPanic_WritesCrashReport", "key j", "Sync failed: boom"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in the report:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// dbMaintenanceMsg carries a :db report
+type dbMaintenanceMsg struct {
+	action   string
+	markdown string
+	err      error
+}
+
+// startDBMaintenance runs :db vacuum, check, or stats in the background
+func (m *Model) startDBMaintenance(msg commands.DBMaintenanceMsg) tea.Cmd {
+	if m.remoteURL != "" {
+		return m.notify(toastWarn, ":db works on the local database; run it on the daemon's machine", 4*time.Second)
+	}
+	m.statusMessage = map[string]string{
+		"vacuum": "Vacuuming database...",
+		"check":  "Checking database integrity...",
+		"stats":  "Measuring database...",
+	}[msg.Action]
+	return func() tea.Msg {
+		var markdown string
+		var err error
+		switch msg.Action {
+		case "vacuum":
+			var result db.VacuumResult
+			if result, err = db.Vacuum(); err == nil {
+				markdown = buildVacuumReport(result)
+			}
+		case "check":
+			var problems []string
+			if problems, err = db.IntegrityCheck(); err == nil {
+				markdown = buildCheckReport(problems)
+			}
+		case "stats":
+			var stats db.Stats
+			if stats, err = db.GetStats(); err == nil {
+				markdown = buildStatsReport(stats)
+			}
+		}
+		return dbMaintenanceMsg{action: msg.Action, markdown: markdown, err: err}
+	}
+}
+
+// buildVacuumReport describes what a vacuum reclaimed
+func buildVacuumReport(result db.VacuumResult) string {
+	var doc strings.Builder
+	doc.WriteString("# Vacuum\n\n")
+	fmt.Fprintf(&doc, "- Before: %s\n", formatBytes(result.Before))
+	fmt.Fprintf(&doc, "- After: %s\n", formatBytes(result.After))
+	reclaimed := result.Before - result.After
+	if reclaimed < 0 {
+		reclaimed = 0 // ANALYZE's statistics can outgrow what was freed
+	}
+	fmt.Fprintf(&doc, "- Reclaimed: %s\n", formatBytes(reclaimed))
+	fmt.Fprintf(&doc, "- Took %s; query planner statistics refreshed\n", result.Duration.Round(time.Millisecond))
+	return doc.String()
+}
+
+// buildCheckReport lists integrity problems, or says there are none
+func buildCheckReport(problems []string) string {
+	var doc strings.Builder
+	doc.WriteString("# Integrity check\n\n")
+	if len(problems) == 0 {
+		doc.WriteString("No problems found.\n")
+		return doc.String()
+	}
+	fmt.Fprintf(&doc, "%d problems found. Restore a backup with :restore if data is missing.\n\n", len(problems))
+	for _, problem := range problems {
+		fmt.Fprintf(&doc, "- %s\n", problem)
+	}
+	return doc.String()
+}
+
+// buildStatsReport lists the file sizes and each table and index, largest first
+func buildStatsReport(stats db.Stats) string {
+	var doc strings.Builder
+	doc.WriteString("# Database\n\n")
+	fmt.Fprintf(&doc, "- File: %s (%s)\n", stats.Path, formatBytes(stats.FileSize))
+	if stats.WALSize > 0 {
+		fmt.Fprintf(&doc, "- Write-ahead log: %s\n", formatBytes(stats.WALSize))
+	}
+	fmt.Fprintf(&doc, "- Pages: %d of %s\n", stats.PageCount, formatBytes(stats.PageSize))
+	if stats.FreePages > 0 {
+		fmt.Fprintf(&doc, "- Free: %d pages (%s); :db vacuum reclaims them\n", stats.FreePages, formatBytes(stats.FreePages*stats.PageSize))
+	}
+
+	estimated := false
+	for _, title := range []string{"Tables", "Indexes"} {
+		fmt.Fprintf(&doc, "\n## %s\n\n", title)
+		for _, object := range stats.Objects {
+			if (object.Table == "") != (title == "Tables") {
+				continue
+			}
+			size := formatBytes(object.Bytes)
+			if !object.Exact {
+				size = "~" + size
+				estimated = true
+			}
+			if object.Table == "" {
+				fmt.Fprintf(&doc, "- %s: %s · %d rows\n", object.Name, size, object.Rows)
+			} else {
+				fmt.Fprintf(&doc, "- %s (on %s): %s\n", object.Name, object.Table, size)
+			}
+		}
+	}
+	if estimated {
+		doc.WriteString("\n~ sizes are estimated from the stored values\n")
+	}
+
+	if pool := stats.Pool; pool != nil {
+		doc.WriteString("\n## Connections\n\n")
+		fmt.Fprintf(&doc, "- Open: %d of %d (%d in use, %d idle)\n", pool.OpenConnections, pool.MaxOpenConnections, pool.InUse, pool.Idle)
+		if pool.WaitCount > 0 {
+			fmt.Fprintf(&doc, "- Waited for a connection: %d times, %s in all\n", pool.WaitCount, pool.WaitDuration.Round(time.Millisecond))
+		} else {
+			doc.WriteString("- Never waited for a connection\n")
+		}
+		if closed := pool.MaxIdleClosed + pool.MaxIdleTimeClosed + pool.MaxLifetimeClosed; closed > 0 {
+			fmt.Fprintf(&doc, "- Closed while idle: %d\n", closed)
+		}
+	}
+	return doc.String()
+}
+
+// dbMaintenanceDone shows a :db report in a modal
+func (m *Model) dbMaintenanceDone(msg dbMaintenanceMsg) tea.Cmd {
+	m.statusMessage = ""
+	if msg.err != nil {
+		return m.notify(toastError, fmt.Sprintf("db %s failed: %v", msg.action, msg.err), 6*time.Second)
+	}
+	m.digestModal.SetSize(m.width, m.height)
+	m.digestModal.OpenDocument("DATABASE", "", msg.markdown)
+	return nil
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestBuildStatsReport_SplitsTablesAndIndexes verifies stats list tables with rows, indexes with their table, and flag estimates.
+// BREAKS: If estimated sizes aren't marked, users size disks from numbers that can be far off.
+func TestBuildStatsReport_SplitsTablesAndIndexes(t *testing.T) {
+	report := buildStatsReport(db.Stats{
+		Path: "/data/prismis.db", FileSize: 3 << 20, PageSize: 4096, PageCount: 768, FreePages: 10,
+		Objects: []db.ObjectStats{
+			{Name: "content", Rows: 1200, Bytes: 2 << 20, Exact: true},
+			{Name: "idx_content_source", Table: "content", Bytes: 40 << 10},
+		},
+	})
+
+	tables := report[strings.Index(report, "## Tables"):strings.Index(report, "## Indexes")]
+	if !strings.Contains(tables, "- content: 2.0 MB · 1200 rows") {
+		t.Errorf("Expected the content table with its rows:\n%s", report)
+	}
+	if !strings.Contains(report, "- idx_content_source (on content): ~40 KB") || !strings.Contains(report, "estimated") {
+		t.Errorf("Expected the estimated index marked:\n%s", report)
+	}
+	if !strings.Contains(report, "Free: 10 pages (40 KB)") {
+		t.Errorf("Expected reclaimable space:\n%s", report)
+	}
+}
+
+// TestBuildStatsReport_Connections verifies the pool section shows open connections and time spent waiting.
+// BREAKS: If waits aren't reported, a starved pool looks like a slow disk.
+func TestBuildStatsReport_Connections(t *testing.T) {
+	report := buildStatsReport(db.Stats{Path: "/data/prismis.db", PageSize: 4096})
+	if strings.Contains(report, "## Connections") {
+		t.Errorf("Expected no pool section without a pool:\n%s", report)
+	}
+
+	report = buildStatsReport(db.Stats{Path: "/data/prismis.db", PageSize: 4096, Pool: &sql.DBStats{
+		MaxOpenConnections: 8, OpenConnections: 3, InUse: 1, Idle: 2,
+		WaitCount: 4, WaitDuration: 1500 * time.Millisecond, MaxIdleTimeClosed: 5,
+	}})
+	for _, want := range []string{"- Open: 3 of 8 (1 in use, 2 idle)", "- Waited for a connection: 4 times, 1.5s in all", "- Closed while idle: 5"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// debugOverlayMsgs is how many recent messages the debug overlay lists
+const debugOverlayMsgs = 8
+
+// timing accumulates one kind of duration for the debug overlay
+type timing struct {
+	last, max, total time.Duration
+	count            int
+}
+
+func (t *timing) add(d time.Duration) {
+	t.last, t.total, t.count = d, t.total+d, t.count+1
+	if d > t.max {
+		t.max = d
+	}
+}
+
+func (t timing) String() string {
+	if t.count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s (avg %s, max %s)", ms(t.last), ms(t.total/time.Duration(t.count)), ms(t.max))
+}
+
+// ms formats d in milliseconds
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+// debugTracer times updates and renders for the debug overlay (--debug,
+// :set debug) and writes each one to a JSON-lines trace. It's a pointer on
+// the Model so View, which can't change the Model, records into it too.
+type debugTracer struct {
+	update, render timing
+	trace          *json.Encoder
+	file           *os.File
+	path           string
+	traceErr       error // Why the trace couldn't be opened; the overlay still works
+}
+
+// traceEvent is one line of the debug trace
+type traceEvent struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`          // start, update, or render
+	Msg     string    `json:"msg,omitempty"` // The message an update handled
+	Micros  int64     `json:"us"`
+	Bytes   int       `json:"bytes,omitempty"`   // Size of a rendered frame
+	SyncJob int       `json:"sync_jobs"`         // Remote syncs waiting for the worker
+	Events  int       `json:"sync_events"`       // Worker results waiting for Update
+	Pending int       `json:"pending_writes"`    // Offline changes waiting for the daemon
+	View    string    `json:"view,omitempty"`    // The view after an update
+	Items   int       `json:"items,omitempty"`   // Items listed after an update
+	Cmds    bool      `json:"cmd,omitempty"`     // The update returned a command
+	Version string    `json:"version,omitempty"` // Trace format, on start
+}
+
+// newDebugTracer starts a trace at debug-trace.jsonl in the state
+// directory, replacing the last one
+func newDebugTracer() *debugTracer {
+	d := &debugTracer{}
+	dir, err := stateDirFunc()
+	if err == nil {
+		err = os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		d.traceErr = err
+		return d
+	}
+	d.path = filepath.Join(dir, "debug-trace.jsonl")
+	d.file, d.traceErr = os.Create(d.path)
+	if d.traceErr == nil {
+		d.trace = json.NewEncoder(d.file)
+		d.write(traceEvent{Kind: "start", Version: "1"})
+	}
+	return d
+}
+
+// close ends the trace
+func (d *debugTracer) close() {
+	if d != nil && d.file != nil {
+		d.file.Close()
+		d.file, d.trace = nil, nil
+	}
+}
+
+// write appends ev to the trace; a failed write stops tracing
+func (d *debugTracer) write(ev traceEvent) {
+	if d.trace == nil {
+		return
+	}
+	ev.Time = time.Now()
+	if err := d.trace.Encode(ev); err != nil {
+		d.traceErr = err
+		d.close()
+	}
+}
+
+// queueDepths are the sync worker's job and event backlogs and the offline
+// write queue
+func (m Model) queueDepths() (jobs, events, pending int) {
+	if m.syncer != nil {
+		jobs, events = len(m.syncer.jobs), len(m.syncer.events)
+	}
+	return jobs, events, m.pendingWrites
+}
+
+// traceUpdate records how long Update took on msg
+func (d *debugTracer) traceUpdate(msg string, took time.Duration, m Model, cmd bool) {
+	d.update.add(took)
+	jobs, events, pending := m.queueDepths()
+	d.write(traceEvent{Kind: "update", Msg: msg, Micros: took.Microseconds(), SyncJob: jobs, Events: events,
+		Pending: pending, View: m.view, Items: len(m.items), Cmds: cmd})
+}
+
+// traceRender records how long View took to draw a frame
+func (d *debugTracer) traceRender(took time.Duration, frame string, m Model) {
+	d.render.add(took)
+	jobs, events, pending := m.queueDepths()
+	d.write(traceEvent{Kind: "render", Micros: took.Microseconds(), Bytes: len(frame), SyncJob: jobs, Events: events, Pending: pending})
+}
+
+// setDebug turns the debug overlay and trace on or off
+func (m *Model) setDebug(on bool) {
+	switch {
+	case on && m.debug == nil:
+		m.debug = newDebugTracer()
+	case !on && m.debug != nil:
+		m.debug.close()
+		m.debug = nil
+	}
+}
+
+// overlayDebug draws the timings, queues, and latest messages in the
+// top-right corner, below the header
+func overlayDebug(view string, m Model, width int) string {
+	d, theme := m.debug, m.theme
+	label := lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	gray := lipgloss.NewStyle().Foreground(theme.Gray)
+
+	jobs, events, pending := m.queueDepths()
+	rows := []string{
+		label.Render("update ") + d.update.String(),
+		label.Render("render ") + d.render.String(),
+		label.Render("frames ") + fmt.Sprint(d.render.count),
+		label.Render("queues ") + fmt.Sprintf("sync %d · events %d · writes %d", jobs, events, pending),
+	}
+	recent := m.recentMsgs.list()
+	recent = recent[max(0, len(recent)-debugOverlayMsgs):]
+	for i := len(recent) - 1; i >= 0; i-- {
+		rows = append(rows, gray.Render("  "+recent[i]))
+	}
+	switch {
+	case d.traceErr != nil:
+		rows = append(rows, lipgloss.NewStyle().Foreground(theme.Red).Render("trace: "+d.traceErr.Error()))
+	case d.path != "":
+		rows = append(rows, gray.Render("trace: "+d.path))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Orange).
+		Padding(0, 1).
+		MaxWidth(max(20, width/2)).
+		Render(strings.Join(rows, "\n"))
+
+	lines := strings.Split(view, "\n")
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	// Start below the header line
+	if len(lines) < len(boxLines)+1 || boxWidth > width {
+		return view
+	}
+	for i, boxLine := range boxLines {
+		left := ansi.Truncate(lines[1+i], width-boxWidth, "")
+		lines[1+i] = left + strings.Repeat(" ", max(0, width-boxWidth-lipgloss.Width(left))) + boxLine
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestDebug_OverlayAndTrace verifies :set debug times updates and frames, shows them, and traces each to JSON lines.
+// BREAKS: Without timings a slow render after a sync looks the same as a hung daemon.
+func TestDebug_OverlayAndTrace(t *testing.T) {
+	dir := t.TempDir()
+	stateDirFunc = func() (string, error) { return dir, nil }
+	defer func() { stateDirFunc = defaultStateDir }()
+
+	m := testModelWithItems([]db.ContentItem{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}})
+	m.width, m.height = 120, 30
+	updated, _ := m.Update(commands.SetOptionMsg{Name: "debug", Value: "true"})
+	m = updated.(Model)
+	if m.debug == nil {
+		t.Fatal("Expected :set debug to turn the overlay on")
+	}
+	defer m.setDebug(false)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	view := m.View()
+	for _, want := range []string{"update", "render", "queues", "key j"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the overlay:\n%s", want, view)
+		}
+	}
+
+	m.setOption(commands.SetOptionMsg{})
+	if strings.Contains(lastToast(m), "debug=") {
+		t.Errorf("Expected debug left out of the bare :set list, got %q", lastToast(m))
+	}
+
+	m.setDebug(false)
+	f, err := os.Open(filepath.Join(dir, "debug-trace.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	kinds := map[string]int{}
+	var keyTraced bool
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev traceEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			t.Fatalf("Expected JSON lines, got %q: %v", scanner.Text(), err)
+		}
+		kinds[ev.Kind]++
+		keyTraced = keyTraced || ev.Msg == "key j"
+	}
+	if kinds["start"] != 1 || kinds["update"] < 2 || kinds["render"] != 1 || !keyTraced {
+		t.Errorf("Expected a start, both updates, and the frame traced, got %v (key j traced: %v)", kinds, keyTraced)
+	}
+}
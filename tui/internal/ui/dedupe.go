@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// feedburnerHosts serve one FeedBurner feed under several names
+var feedburnerHosts = map[string]bool{
+	"feeds.feedburner.com":  true,
+	"feeds2.feedburner.com": true,
+	"feedproxy.google.com":  true,
+	"feedburner.com":        true,
+}
+
+// canonicalFeedURL reduces a source URL to what identifies the feed: scheme,
+// "www.", default ports, and trailing slashes don't matter, and FeedBurner
+// aliases collapse to the feed name
+func canonicalFeedURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.ToLower(strings.TrimRight(strings.TrimSpace(raw), "/"))
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.TrimRight(u.EscapedPath(), "/")
+	if feedburnerHosts[host] {
+		// The query is only a format hint (?format=xml)
+		return "feedburner:" + strings.ToLower(strings.Trim(path, "/"))
+	}
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+	key := host + path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// duplicateSourceGroups finds sources of the same type pointing at the same
+// feed. Each group lists the best source to keep first: HTTPS, active, with
+// the fewest errors.
+func duplicateSourceGroups(sources []db.Source) [][]db.Source {
+	byKey := map[string][]db.Source{}
+	var keys []string
+	for _, source := range sources {
+		key := source.Type + " " + canonicalFeedURL(source.URL)
+		if _, seen := byKey[key]; !seen {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], source)
+	}
+
+	var groups [][]db.Source
+	for _, key := range keys {
+		group := byKey[key]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			a, b := group[i], group[j]
+			if https := strings.HasPrefix(a.URL, "https://"); https != strings.HasPrefix(b.URL, "https://") {
+				return https
+			}
+			if a.Active != b.Active {
+				return a.Active
+			}
+			return a.ErrorCount < b.ErrorCount
+		})
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// dedupeMergeMsg asks the model to merge a group into the source kept
+type dedupeMergeMsg struct {
+	keep  db.Source
+	merge []db.Source
+}
+
+// DedupeModal steps through groups of duplicate sources (:sources dedupe),
+// asking which one to keep
+type DedupeModal struct {
+	Modal   // Embed base modal
+	groups  [][]db.Source
+	pos     int
+	cursor  int // The source kept in the current group
+	merged  int // Sources merged away
+	skipped int // Groups left alone
+	done    bool
+}
+
+// NewDedupeModal creates a new DedupeModal instance
+func NewDedupeModal() DedupeModal {
+	return DedupeModal{
+		Modal: NewModal("DUPLICATE SOURCES", 80, 20), // Will be sized dynamically
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *DedupeModal) SetSize(width, height int) {
+	m.Modal.width = min(max(60, width*2/3), width-4)
+	m.Modal.height = min(max(14, height*2/3), height-4)
+}
+
+// Start opens a session over groups
+func (m *DedupeModal) Start(groups [][]db.Source) {
+	m.groups = groups
+	m.pos = 0
+	m.cursor = 0
+	m.merged = 0
+	m.skipped = 0
+	m.done = len(groups) == 0
+	m.Show()
+}
+
+// next moves on to the following group
+func (m *DedupeModal) next() {
+	m.pos++
+	m.cursor = 0
+	if m.pos >= len(m.groups) {
+		m.done = true
+	}
+}
+
+// Update handles input for the dedupe modal
+func (m DedupeModal) Update(msg tea.Msg) (DedupeModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.done {
+			// Any key dismisses the summary
+			m.Hide()
+			return m, nil
+		}
+		group := m.groups[m.pos]
+		switch msg.String() {
+		case "esc", "q":
+			m.skipped += len(m.groups) - m.pos
+			m.done = true
+		case "j", "down":
+			m.cursor = min(m.cursor+1, len(group)-1)
+		case "k", "up":
+			m.cursor = max(m.cursor-1, 0)
+		case "s", " ":
+			m.skipped++
+			m.next()
+		case "enter", "m":
+			keep := group[m.cursor]
+			var merge []db.Source
+			for i, source := range group {
+				if i != m.cursor {
+					merge = append(merge, source)
+				}
+			}
+			m.merged += len(merge)
+			m.next()
+			return m, func() tea.Msg {
+				return dedupeMergeMsg{keep: keep, merge: merge}
+			}
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the current group, or the summary, over the background
+func (m DedupeModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	lineWidth := m.Modal.width - 4 // Inside padding
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	lineStyle := lipgloss.NewStyle().Width(lineWidth)
+	grayStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+	hintStyle := grayStyle.Italic(true)
+
+	var content strings.Builder
+	content.WriteString(triageProgressBar(m.pos, len(m.groups), lineWidth, theme))
+	content.WriteString("\n\n")
+
+	if m.done {
+		summary := lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true).
+			Render(fmt.Sprintf("Merged %d duplicate sources", m.merged))
+		if m.skipped > 0 {
+			summary += "\n" + fmt.Sprintf("  %d groups left as they are", m.skipped)
+		}
+		content.WriteString(lineStyle.Render(summary))
+		content.WriteString("\n\n")
+		content.WriteString(hintStyle.Render("Press any key to close"))
+	} else {
+		content.WriteString(lineStyle.Render(lipgloss.NewStyle().Foreground(theme.White).Bold(true).
+			Render("These sources point at the same feed. Keep which one?")))
+		content.WriteString("\n\n")
+		for i, source := range m.groups[m.pos] {
+			content.WriteString(lineStyle.Render(dedupeRow(source, i == m.cursor, lineWidth, theme)))
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+		content.WriteString(hintStyle.Render("j/k choose · Enter keep it and merge the rest in · s skip · q finish"))
+	}
+
+	modal := m.Modal
+	modal.SetContent(content.String())
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
+
+// dedupeRow renders one source of a group: the kept one marked ●, the ones
+// to merge ○, with what tells them apart
+func dedupeRow(source db.Source, keep bool, width int, theme StyleTheme) string {
+	marker := lipgloss.NewStyle().Foreground(theme.Gray).Render("○ ")
+	nameStyle := lipgloss.NewStyle().Foreground(theme.White)
+	if keep {
+		marker = lipgloss.NewStyle().Foreground(theme.Green).Render("● ")
+		nameStyle = nameStyle.Bold(true)
+	}
+	name := source.Name
+	if name == "" {
+		name = source.URL
+	}
+
+	var notes []string
+	if source.UnreadCount > 0 {
+		notes = append(notes, fmt.Sprintf("%d unread", source.UnreadCount))
+	}
+	if !source.Active {
+		notes = append(notes, "paused")
+	}
+	if source.ErrorCount > 0 {
+		notes = append(notes, fmt.Sprintf("%d errors", source.ErrorCount))
+	}
+	detail := source.URL
+	if len(notes) > 0 {
+		detail += " · " + strings.Join(notes, ", ")
+	}
+	return marker + nameStyle.Render(truncate(name, max(10, width-2))) + "\n  " +
+		lipgloss.NewStyle().Foreground(theme.Gray).Render(truncate(detail, max(10, width-2)))
+}
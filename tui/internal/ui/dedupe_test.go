@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestDuplicateSourceGroups_CanonicalFeeds verifies scheme, www., trailing slashes, and FeedBurner hosts don't hide duplicates.
+// BREAKS: If URLs are compared as typed, the same feed added twice is never offered for merging.
+func TestDuplicateSourceGroups_CanonicalFeeds(t *testing.T) {
+	sources := []db.Source{
+		{ID: "1", Type: "rss", URL: "http://example.com/feed/", Active: true},
+		{ID: "2", Type: "rss", URL: "https://www.Example.com/feed", Active: true},
+		{ID: "3", Type: "rss", URL: "http://feeds.feedburner.com/Foo"},
+		{ID: "4", Type: "rss", URL: "https://feedproxy.google.com/foo?format=xml"},
+		{ID: "5", Type: "rss", URL: "https://example.com/other"},
+		{ID: "6", Type: "reddit", URL: "https://example.com/feed"},
+	}
+
+	groups := duplicateSourceGroups(sources)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 duplicate groups, got %d: %+v", len(groups), groups)
+	}
+	if len(groups[0]) != 2 || groups[0][0].ID != "2" || groups[0][1].ID != "1" {
+		t.Errorf("Expected the HTTPS example.com source first, got %+v", groups[0])
+	}
+	if len(groups[1]) != 2 || groups[1][0].ID != "4" {
+		t.Errorf("Expected the FeedBurner aliases grouped with HTTPS first, got %+v", groups[1])
+	}
+}
+
+// TestDedupeModal_MergesIntoChosenSource verifies the chosen source is kept and the rest of the group is merged into it.
+// BREAKS: If the cursor is ignored, the modal deletes the source the user asked to keep.
+func TestDedupeModal_MergesIntoChosenSource(t *testing.T) {
+	modal := NewDedupeModal()
+	modal.SetSize(120, 40)
+	modal.Start([][]db.Source{
+		{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		{{ID: "d"}, {ID: "e"}},
+	})
+
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	modal, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected Enter to merge the group")
+	}
+	msg, ok := cmd().(dedupeMergeMsg)
+	if !ok || msg.keep.ID != "b" || len(msg.merge) != 2 || msg.merge[0].ID != "a" || msg.merge[1].ID != "c" {
+		t.Fatalf("Expected a and c merged into b, got %+v", msg)
+	}
+
+	modal, cmd = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd != nil || !modal.done || modal.merged != 2 || modal.skipped != 1 {
+		t.Errorf("Expected s to skip the last group and finish, done=%v merged=%d skipped=%d", modal.done, modal.merged, modal.skipped)
+	}
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if modal.IsVisible() {
+		t.Error("Expected any key to close the summary")
+	}
+}
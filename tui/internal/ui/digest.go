@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/config"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// digestWindow is how far back "today" reaches, so a morning skim still
+// sees what arrived overnight
+const digestWindow = 24 * time.Hour
+
+// digestItemsMsg carries every item in the archive scope for the digest
+type digestItemsMsg struct {
+	includeMedium bool
+	export        string
+	items         []db.ContentItem
+	err           error
+}
+
+// digestExportedMsg reports where the Markdown digest was written
+type digestExportedMsg struct {
+	path string
+	err  error
+}
+
+// loadDigestItems fetches all items regardless of view filters. Remote mode
+// already holds them in itemsCache; local mode reads them from the database.
+func loadDigestItems(m Model, includeMedium bool, export string) tea.Cmd {
+	if m.remoteURL != "" {
+		items := m.itemsCache
+		return func() tea.Msg {
+			return digestItemsMsg{includeMedium: includeMedium, export: export, items: items}
+		}
+	}
+	return func() tea.Msg {
+		items, err := db.GetAllContent(false)
+		return digestItemsMsg{includeMedium: includeMedium, export: export, items: items, err: err}
+	}
+}
+
+// digestItems picks the items published in the digest window, HIGH first
+// (then MEDIUM when asked), newest first within each priority
+func digestItems(items []db.ContentItem, includeMedium bool, now time.Time) []db.ContentItem {
+	var picked []db.ContentItem
+	for _, item := range items {
+		if item.Published.Before(now.Add(-digestWindow)) {
+			continue
+		}
+		if item.Priority == "high" || (includeMedium && item.Priority == "medium") {
+			picked = append(picked, item)
+		}
+	}
+	sort.SliceStable(picked, func(i, j int) bool {
+		if picked[i].Priority != picked[j].Priority {
+			return picked[i].Priority == "high"
+		}
+		return picked[i].Published.After(picked[j].Published)
+	})
+	return picked
+}
+
+// buildDigest renders the digest as a Markdown document
+func buildDigest(items []db.ContentItem, now time.Time) string {
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "# Daily Digest: %s\n\n", now.Format("Monday, January 2, 2006"))
+	if len(items) == 0 {
+		doc.WriteString("Nothing prioritized in the last 24 hours.\n")
+		return doc.String()
+	}
+
+	section := ""
+	for _, item := range items {
+		if item.Priority != section {
+			section = item.Priority
+			fmt.Fprintf(&doc, "## %s\n\n", strings.ToUpper(section))
+		}
+		fmt.Fprintf(&doc, "### %s\n\n", item.Title)
+
+		meta := []string{}
+		if item.SourceName != "" {
+			meta = append(meta, item.SourceName)
+		}
+		meta = append(meta, item.Published.Local().Format("15:04"))
+		if item.URL != "" {
+			meta = append(meta, item.URL)
+		}
+		if len(item.UserTags) > 0 {
+			meta = append(meta, "#"+strings.Join(item.UserTags, " #"))
+		}
+		fmt.Fprintf(&doc, "*%s*\n\n", strings.Join(meta, " · "))
+
+		summary := item.ParsedAnalysis().ReadingSummary
+		if summary == "" {
+			summary = item.Summary
+		}
+		if summary == "" {
+			summary = "No summary available."
+		}
+		doc.WriteString(strings.TrimSpace(summary))
+		doc.WriteString("\n\n")
+	}
+	return doc.String()
+}
+
+// exportDigest writes the Markdown digest into the reports directory
+func exportDigest(markdown string, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return digestExportedMsg{err: err}
+		}
+		dir, err := cfg.GetReportsOutputPath()
+		if err != nil {
+			return digestExportedMsg{err: err}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return digestExportedMsg{err: fmt.Errorf("failed to create reports directory: %w", err)}
+		}
+		path := filepath.Join(dir, "digest-"+now.Format("2006-01-02")+".md")
+		if err := os.WriteFile(path, []byte(markdown), 0o644); err != nil {
+			return digestExportedMsg{err: fmt.Errorf("failed to write digest: %w", err)}
+		}
+		return digestExportedMsg{path: path}
+	}
+}
+
+// DigestModal shows the daily digest as one scrollable document (:digest).
+// :history reuses it for the reading history.
+type DigestModal struct {
+	Modal    // Embed base modal
+	viewport viewport.Model
+	markdown string
+	note     string // Extra footer hint, e.g. how to export
+}
+
+// NewDigestModal creates a new DigestModal instance
+func NewDigestModal() DigestModal {
+	return DigestModal{
+		Modal:    NewModal("DAILY DIGEST", 80, 20), // Will be sized dynamically
+		viewport: viewport.New(76, 14),
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *DigestModal) SetSize(width, height int) {
+	modalWidth := min(max(60, width*3/4), width-4)
+	modalHeight := max(10, height-6)
+	m.Modal.width = modalWidth
+	m.Modal.height = modalHeight
+	// Title, its margin, the blank line, and the footer hint take four rows
+	m.viewport.Width = modalWidth - 4
+	m.viewport.Height = max(1, modalHeight-6)
+	if m.markdown != "" {
+		m.viewport.SetContent(renderSimpleMarkdown(m.markdown, m.viewport.Width))
+	}
+}
+
+// Open shows the modal with the rendered digest, scrolled to the top
+func (m *DigestModal) Open(markdown string) {
+	m.OpenDocument("DAILY DIGEST", ":digest export saves it", markdown)
+}
+
+// OpenDocument shows any Markdown document under title, with note added to
+// the footer hint
+func (m *DigestModal) OpenDocument(title, note, markdown string) {
+	m.title = title
+	m.note = note
+	m.markdown = markdown
+	m.viewport.SetContent(renderSimpleMarkdown(markdown, m.viewport.Width))
+	m.viewport.GotoTop()
+	m.Show()
+}
+
+// Update handles input for the digest modal
+func (m DigestModal) Update(msg tea.Msg) (DigestModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			m.Hide()
+		case "j", "down":
+			m.viewport.ScrollDown(1)
+		case "k", "up":
+			m.viewport.ScrollUp(1)
+		case " ", "pgdown", "ctrl+d":
+			m.viewport.HalfPageDown()
+		case "b", "pgup", "ctrl+u":
+			m.viewport.HalfPageUp()
+		case "g", "home":
+			m.viewport.GotoTop()
+		case "G", "end":
+			m.viewport.GotoBottom()
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the digest over the background
+func (m DigestModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	body := lipgloss.NewStyle().Width(m.viewport.Width).Align(lipgloss.Left).Render(m.viewport.View())
+	hint := fmt.Sprintf("j/k scroll · Space page · %d%% · ESC close", int(m.viewport.ScrollPercent()*100))
+	if m.note != "" {
+		hint += " · " + m.note
+	}
+
+	modal := m.Modal
+	modal.SetContent(body + "\n\n" + lipgloss.NewStyle().Foreground(theme.Gray).Italic(true).Render(hint))
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestDigest_TodaysTopItemsWithSummaries verifies the digest keeps recent HIGH (and optionally MEDIUM) items, HIGH first.
+// BREAKS: If old or LOW items leak in, the morning skim turns back into the full feed.
+func TestDigest_TodaysTopItemsWithSummaries(t *testing.T) {
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	items := []db.ContentItem{
+		{ID: "m", Title: "Medium News", Priority: "medium", Summary: "medium summary", Published: now.Add(-time.Hour)},
+		{ID: "h1", Title: "Older High", Priority: "high", Summary: "older summary", Published: now.Add(-20 * time.Hour)},
+		{ID: "h2", Title: "Fresh High", Priority: "high", Analysis: `{"reading_summary": "rich summary"}`, Summary: "plain", Published: now.Add(-2 * time.Hour)},
+		{ID: "old", Title: "Stale High", Priority: "high", Published: now.Add(-30 * time.Hour)},
+		{ID: "low", Title: "Low News", Priority: "low", Published: now.Add(-time.Hour)},
+	}
+
+	var ids []string
+	for _, item := range digestItems(items, false, now) {
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "h2,h1" {
+		t.Errorf("Expected today's HIGH items newest first, got %v", ids)
+	}
+
+	ids = nil
+	for _, item := range digestItems(items, true, now) {
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "h2,h1,m" {
+		t.Errorf("Expected MEDIUM after HIGH, got %v", ids)
+	}
+
+	doc := buildDigest(digestItems(items, true, now), now)
+	for _, want := range []string{"# Daily Digest: Monday, March 2, 2026", "## HIGH", "### Fresh High", "rich summary", "## MEDIUM", "medium summary"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected digest to contain %q, got:\n%s", want, doc)
+		}
+	}
+	if strings.Index(doc, "## HIGH") > strings.Index(doc, "## MEDIUM") {
+		t.Error("Expected the HIGH section before MEDIUM")
+	}
+}
+
+// TestDigest_ExportWritesMarkdownToReports verifies :digest export saves into reports.output_path.
+// BREAKS: If export ignores the configured directory, the file lands somewhere the user won't look.
+func TestDigest_ExportWritesMarkdownToReports(t *testing.T) {
+	configHome := t.TempDir()
+	reports := filepath.Join(t.TempDir(), "reports")
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	if err := os.MkdirAll(filepath.Join(configHome, "prismis"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	toml := "[reports]\noutput_path = \"" + reports + "\"\n"
+	if err := os.WriteFile(filepath.Join(configHome, "prismis", "config.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	msg := exportDigest("# Daily Digest\n", now)().(digestExportedMsg)
+	if msg.err != nil {
+		t.Fatalf("Expected export to succeed, got %v", msg.err)
+	}
+	if msg.path != filepath.Join(reports, "digest-2026-03-02.md") {
+		t.Errorf("Expected dated file in the reports directory, got %s", msg.path)
+	}
+	if data, _ := os.ReadFile(msg.path); string(data) != "# Daily Digest\n" {
+		t.Errorf("Expected the Markdown to be written, got %q", data)
+	}
+}
+
+// TestDigest_OpensScrollableModal verifies :digest shows the document in a modal that takes the keys.
+// BREAKS: If the modal doesn't capture j/k, scrolling the digest moves the list underneath.
+func TestDigest_OpensScrollableModal(t *testing.T) {
+	m := Model{width: 120, height: 40, view: "list", digestModal: NewDigestModal(), items: []db.ContentItem{{ID: "a"}, {ID: "b"}}}
+	fresh := []db.ContentItem{{ID: "h", Title: "Top Story", Priority: "high", Summary: "the gist", Published: time.Now()}}
+
+	updated, _ := m.Update(digestItemsMsg{items: fresh})
+	m = updated.(Model)
+	if !m.digestModal.IsVisible() || !strings.Contains(m.digestModal.viewport.View(), "Top Story") {
+		t.Fatalf("Expected the digest modal with the story, got %q", m.digestModal.viewport.View())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	if m.cursor != 0 {
+		t.Error("Expected j to scroll the digest, not move the list")
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ErrorsModal lists failed operations with a retry for each (:errors)
+type ErrorsModal struct {
+	Modal   // Embed base modal
+	entries []failure
+	cursor  int
+}
+
+// NewErrorsModal creates a new ErrorsModal instance
+func NewErrorsModal() ErrorsModal {
+	return ErrorsModal{
+		Modal: NewModal("ERRORS", 80, 20), // Will be sized dynamically
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *ErrorsModal) SetSize(width, height int) {
+	m.Modal.width = max(50, min(int(float64(width)*0.75), width-4))
+	m.Modal.height = max(8, height-8)
+}
+
+// Open shows the modal on the newest failure
+func (m *ErrorsModal) Open(failures []failure) {
+	m.entries = failures
+	m.cursor = len(failures) - 1
+	m.Show()
+}
+
+// setEntries replaces the list after a retry, dismissal, or new failure,
+// keeping the cursor in range
+func (m *ErrorsModal) setEntries(failures []failure) {
+	m.entries = failures
+	m.cursor = max(0, min(m.cursor, len(failures)-1))
+}
+
+// selected is the failure under the cursor
+func (m ErrorsModal) selected() (failure, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return failure{}, false
+	}
+	return m.entries[m.cursor], true
+}
+
+// Update handles input for the errors modal. r and d go to the model, which
+// owns the list.
+func (m ErrorsModal) Update(msg tea.Msg) (ErrorsModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			m.Hide()
+		case "k", "up":
+			m.cursor = max(0, m.cursor-1)
+		case "j", "down":
+			m.cursor = min(len(m.entries)-1, m.cursor+1)
+		case "r", "enter":
+			if f, ok := m.selected(); ok {
+				return m, func() tea.Msg { return failureRetryMsg{id: f.id} }
+			}
+		case "d", "x":
+			if f, ok := m.selected(); ok {
+				return m, func() tea.Msg { return failureDismissedMsg{id: f.id} }
+			}
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the failures around the cursor over the background
+func (m ErrorsModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	lineWidth := m.Modal.width - 4 // Inside padding
+	// Each failure takes two lines; the title, its margin, and the footer take six
+	perPage := max(1, (m.Modal.height-6)/2)
+	start, end := visibleRange(m.cursor, len(m.entries), perPage)
+
+	lineStyle := lipgloss.NewStyle().Width(lineWidth).MaxHeight(1)
+	gray := lipgloss.NewStyle().Foreground(theme.Gray)
+	kindStyle := lipgloss.NewStyle().Foreground(theme.Red).Bold(true)
+
+	var content strings.Builder
+	if len(m.entries) == 0 {
+		content.WriteString(lineStyle.Foreground(theme.Gray).Render("No failed operations"))
+		content.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		f := m.entries[i]
+		selector, opStyle := "  ", lipgloss.NewStyle().Foreground(theme.White)
+		if i == m.cursor {
+			selector = lipgloss.NewStyle().Foreground(theme.Selection).Bold(true).Render("▸ ")
+			opStyle = opStyle.Foreground(theme.Selection).Bold(true)
+		}
+		repeats := ""
+		if f.count > 1 {
+			repeats = gray.Render(fmt.Sprintf(" ×%d", f.count))
+		}
+		line := selector + gray.Render(f.at.Format("15:04:05")) + " " +
+			kindStyle.Render(padRight(errorKind(f.err), 12)) + " " +
+			opStyle.Render(f.op) + gray.Render(": ") + opStyle.Render(f.object) + repeats
+		content.WriteString(lineStyle.Render(line))
+		content.WriteString("\n")
+		content.WriteString(lineStyle.Render("    " + gray.Render(f.text)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(gray.Italic(true).Render("r retry · d dismiss · j/k move · ESC to close"))
+
+	modal := m.Modal
+	modal.SetContent(content.String())
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/config"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// htmlExportedMsg reports where :export html wrote the page
+type htmlExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// htmlExportItem is one list entry as the page template sees it
+type htmlExportItem struct {
+	Title     string
+	URL       string
+	Priority  string // "high", "medium", "low", or "" (drives the badge class)
+	Source    string
+	Published string
+	Tags      []string
+	Summary   []string // Paragraphs
+}
+
+// htmlExportPage is the page template's data
+type htmlExportPage struct {
+	Title     string
+	View      string
+	Generated string
+	Theme     StyleTheme
+	Items     []htmlExportItem
+}
+
+// htmlExportTemplate renders a standalone page: inline CSS in the TUI's
+// theme colors, no scripts, no external assets
+var htmlExportTemplate = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { background: #0d0d0d; color: {{.Theme.White}}; font: 16px/1.6 ui-monospace, SFMono-Regular, Menlo, monospace; margin: 0; }
+  main { max-width: 52rem; margin: 0 auto; padding: 2rem 1.25rem; }
+  h1 { color: {{.Theme.Cyan}}; font-size: 1.4rem; margin: 0 0 .25rem; }
+  .view, .meta { color: {{.Theme.Gray}}; font-size: .85rem; }
+  .view { border-bottom: 1px solid {{.Theme.DarkGray}}; padding-bottom: 1rem; margin-bottom: 1.5rem; }
+  article { border-left: 3px solid {{.Theme.DarkGray}}; padding: .25rem 0 .25rem 1rem; margin-bottom: 1.75rem; }
+  article.high { border-color: {{.Theme.Red}}; }
+  article.medium { border-color: {{.Theme.Orange}}; }
+  article.low { border-color: {{.Theme.Cyan}}; }
+  h2 { font-size: 1.05rem; margin: 0 0 .25rem; }
+  a { color: {{.Theme.White}}; text-decoration: none; }
+  a:hover { color: {{.Theme.Cyan}}; text-decoration: underline; }
+  .badge { font-size: .75rem; font-weight: bold; margin-right: .5rem; }
+  .high .badge { color: {{.Theme.Red}}; }
+  .medium .badge { color: {{.Theme.Orange}}; }
+  .low .badge { color: {{.Theme.Cyan}}; }
+  .tag { color: {{.Theme.VibrantPurple}}; }
+  p { margin: .5rem 0 0; color: {{.Theme.Purple}}; }
+  footer { color: {{.Theme.Gray}}; font-size: .8rem; border-top: 1px solid {{.Theme.DarkGray}}; padding-top: 1rem; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<div class="view">{{.View}} · {{len .Items}} items</div>
+{{range .Items}}<article class="{{.Priority}}">
+  <h2>{{if .Priority}}<span class="badge">{{.Priority}}</span>{{end}}{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h2>
+  <div class="meta">{{if .Source}}{{.Source}} · {{end}}{{.Published}}{{range .Tags}} <span class="tag">#{{.}}</span>{{end}}</div>
+  {{range .Summary}}<p>{{.}}</p>
+  {{end}}
+</article>
+{{else}}<p>No items in this view.</p>
+{{end}}<footer>Exported from prismis on {{.Generated}}</footer>
+</main>
+</body>
+</html>
+`))
+
+// buildHTMLExport renders items as a standalone HTML reading list
+func buildHTMLExport(items []db.ContentItem, view string, theme StyleTheme, now time.Time) ([]byte, error) {
+	page := htmlExportPage{
+		Title:     "Reading list: " + now.Format("January 2, 2006"),
+		View:      view,
+		Generated: now.Format("2006-01-02 15:04"),
+		Theme:     theme,
+	}
+	for _, item := range items {
+		summary := item.ParsedAnalysis().ReadingSummary
+		if summary == "" {
+			summary = item.Summary
+		}
+		var paragraphs []string
+		for _, para := range strings.Split(strings.TrimSpace(summary), "\n\n") {
+			if para = strings.TrimSpace(para); para != "" {
+				paragraphs = append(paragraphs, para)
+			}
+		}
+		page.Items = append(page.Items, htmlExportItem{
+			Title:     item.Title,
+			URL:       item.URL,
+			Priority:  item.Priority,
+			Source:    item.SourceName,
+			Published: item.Published.Local().Format("Jan 2, 15:04"),
+			Tags:      item.UserTags,
+			Summary:   paragraphs,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := htmlExportTemplate.Execute(&buf, page); err != nil {
+		return nil, fmt.Errorf("failed to render page: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// htmlExportPath resolves :export html's path argument. Empty means the
+// reports directory; a directory gets a dated file name inside it.
+func htmlExportPath(path string, now time.Time) (string, error) {
+	name := "reading-list-" + now.Format("2006-01-02-1504") + ".html"
+	if path == "" {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		dir, err := cfg.GetReportsOutputPath()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, name), nil
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, name), nil
+	}
+	return path, nil
+}
+
+// exportHTML writes the current list as a standalone HTML page
+func exportHTML(m Model, path string) tea.Cmd {
+	items := m.items
+	view := buildViewStateString(m)
+	theme := m.theme
+	return func() tea.Msg {
+		now := time.Now()
+		page, err := buildHTMLExport(items, view, theme, now)
+		if err != nil {
+			return htmlExportedMsg{err: err}
+		}
+		path, err := htmlExportPath(path, now)
+		if err != nil {
+			return htmlExportedMsg{err: err}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return htmlExportedMsg{err: fmt.Errorf("failed to create directory: %w", err)}
+		}
+		if err := os.WriteFile(path, page, 0o644); err != nil {
+			return htmlExportedMsg{err: fmt.Errorf("failed to write page: %w", err)}
+		}
+		return htmlExportedMsg{path: path, count: len(items)}
+	}
+}
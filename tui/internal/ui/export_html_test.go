@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestBuildHTMLExport_StandalonePageWithSummaries verifies the page carries each item's link, summary, and theme colors.
+// BREAKS: If summaries or links are dropped, the shared reading list is just a column of bare titles.
+func TestBuildHTMLExport_StandalonePageWithSummaries(t *testing.T) {
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	items := []db.ContentItem{
+		{Title: "Rust <3 WASM", URL: "https://example.com/rust", Priority: "high", SourceName: "Lobsters", Analysis: `{"reading_summary": "First point.\n\nSecond point."}`, UserTags: []string{"rust"}},
+		{Title: "Sketchy", URL: "javascript:alert(1)", Summary: "plain summary"},
+	}
+
+	page, err := buildHTMLExport(items, "Priority: HIGH", CleanCyberTheme, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(page)
+	for _, want := range []string{
+		`<a href="https://example.com/rust">Rust &lt;3 WASM</a>`,
+		"<p>First point.</p>", "<p>Second point.</p>", "<p>plain summary</p>",
+		`<article class="high">`, "#rust", "Priority: HIGH · 2 items",
+		"#00D9FF", "#FF0066",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected page to contain %q", want)
+		}
+	}
+	if strings.Contains(doc, "javascript:") || strings.Contains(doc, "<script") {
+		t.Error("Expected unsafe links neutralized and no scripts")
+	}
+}
+
+// TestHTMLExportPath_DirectoryGetsDatedName verifies a directory argument gets a file name and a file path is kept.
+// BREAKS: If a directory path is used as-is, the write fails with "is a directory".
+func TestHTMLExportPath_DirectoryGetsDatedName(t *testing.T) {
+	now := time.Date(2026, 3, 2, 8, 5, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	got, err := htmlExportPath(dir, now)
+	if err != nil || got != filepath.Join(dir, "reading-list-2026-03-02-0805.html") {
+		t.Errorf("Expected a dated file in the directory, got %q (%v)", got, err)
+	}
+
+	file := filepath.Join(dir, "list.html")
+	if got, _ := htmlExportPath(file, now); got != file {
+		t.Errorf("Expected the file path kept, got %q", got)
+	}
+
+	home, _ := os.UserHomeDir()
+	if got, _ := htmlExportPath("~/list.html", now); got != filepath.Join(home, "list.html") {
+		t.Errorf("Expected ~ expanded, got %q", got)
+	}
+}
+
+// TestExportHTML_WritesCurrentList verifies :export html writes the visible list to the given path.
+// BREAKS: If the export reads the whole archive instead of m.items, the shared list ignores the user's filters.
+func TestExportHTML_WritesCurrentList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "list.html")
+	m := Model{priority: "high", filterType: "all", theme: CleanCyberTheme, items: []db.ContentItem{{Title: "Only this"}}}
+
+	msg, ok := exportHTML(m, path)().(htmlExportedMsg)
+	if !ok || msg.err != nil || msg.path != path || msg.count != 1 {
+		t.Fatalf("Expected 1 item written to %s, got %+v", path, msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "Only this") {
+		t.Errorf("Expected the list in the file, got %v", err)
+	}
+}
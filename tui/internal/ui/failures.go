@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// maxFailures bounds the :errors panel; the oldest failure drops off first
+const maxFailures = 50
+
+// failure is a failed operation kept for :errors until it's retried,
+// dismissed, or succeeds on its own
+type failure struct {
+	id     int
+	op     string // What failed, e.g. "add source"
+	object string // What it failed on: a URL, an article title, the daemon
+	err    error
+	text   string // The message the toast showed
+	at     time.Time
+	count  int     // Times it failed in a row
+	retry  tea.Cmd // Runs the original operation again
+}
+
+// operationFailedMsg carries a failed operation's result along with what
+// :errors needs to retry it. The model records the failure and then handles
+// the result like any other.
+type operationFailedMsg struct {
+	failure failure
+	result  tea.Msg
+}
+
+// failureRetryMsg and failureDismissedMsg come from the :errors panel
+type failureRetryMsg struct{ id int }
+type failureDismissedMsg struct{ id int }
+
+// retryable wraps an operation so its failure lands in :errors with the
+// command to run it again. Cancellations aren't failures.
+func retryable(op, object string, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		result := cmd()
+		err, text := operationError(result)
+		if err == nil || operations.IsCancelled(err) {
+			return result
+		}
+		return operationFailedMsg{
+			failure: failure{op: op, object: object, err: err, text: text, retry: retryable(op, object, cmd)},
+			result:  result,
+		}
+	}
+}
+
+// operationError pulls the error and its message out of an operation result
+func operationError(msg tea.Msg) (error, string) {
+	switch msg := msg.(type) {
+	case operations.SourceOperationMsg:
+		if !msg.Success && msg.Error != nil {
+			return msg.Error, msg.Message
+		}
+	case operations.AudioOperationMsg:
+		if !msg.Success && msg.Error != nil {
+			return msg.Error, msg.Message
+		}
+	case operations.ArticleAudioMsg:
+		if msg.Error != nil {
+			return msg.Error, msg.Error.Error()
+		}
+	case operations.TaskCreatedMsg:
+		if msg.Error != nil {
+			return msg.Error, msg.Error.Error()
+		}
+	case operations.ContextReviewAppliedMsg:
+		if msg.Error != nil {
+			return msg.Error, msg.Error.Error()
+		}
+	}
+	return nil, ""
+}
+
+// errorKind names the class of an API error for the :errors panel
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, api.ErrDaemonDown):
+		return "unreachable"
+	case errors.Is(err, api.ErrAuth):
+		return "auth"
+	case errors.Is(err, api.ErrNotFound):
+		return "not found"
+	case errors.Is(err, api.ErrValidation):
+		return "invalid"
+	case errors.Is(err, api.ErrRateLimited):
+		return "rate limited"
+	case errors.Is(err, api.ErrUnsupported):
+		return "unsupported"
+	case errors.Is(err, api.ErrServer):
+		return "server"
+	}
+	return "error"
+}
+
+// recordFailure adds f to :errors. A repeat of an operation that is already
+// listed replaces it and counts the repeat, so a daemon that stays down is
+// one entry rather than one per sync.
+func (m *Model) recordFailure(f failure) {
+	m.failureSeq++
+	f.id, f.at, f.count = m.failureSeq, time.Now(), 1
+	kept := make([]failure, 0, len(m.failures)+1)
+	for _, existing := range m.failures {
+		if existing.op == f.op && existing.object == f.object {
+			f.count += existing.count
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	if len(kept) >= maxFailures {
+		kept = kept[len(kept)-maxFailures+1:]
+	}
+	m.failures = append(kept, f)
+	m.errorsModal.setEntries(m.failures)
+}
+
+// resolveFailure drops op's entry for object once the operation succeeds
+func (m *Model) resolveFailure(op, object string) {
+	kept := make([]failure, 0, len(m.failures))
+	for _, f := range m.failures {
+		if f.op != op || f.object != object {
+			kept = append(kept, f)
+		}
+	}
+	m.failures = kept
+	m.errorsModal.setEntries(m.failures)
+}
+
+// takeFailure removes the failure with id, returning it
+func (m *Model) takeFailure(id int) (failure, bool) {
+	for i, f := range m.failures {
+		if f.id == id {
+			m.failures = append(m.failures[:i:i], m.failures[i+1:]...)
+			m.errorsModal.setEntries(m.failures)
+			return f, true
+		}
+	}
+	return failure{}, false
+}
+
+// retryFailure runs a failed operation again; it comes back to :errors if
+// it fails again
+func (m *Model) retryFailure(id int) tea.Cmd {
+	f, ok := m.takeFailure(id)
+	if !ok {
+		return nil
+	}
+	return tea.Batch(f.retry, m.notify(toastInfo, fmt.Sprintf("Retrying %s: %s", f.op, f.object), 3*time.Second))
+}
+
+// syncRetry is the retry for a failed sync: the refresh that started it
+func syncRetry() tea.Msg {
+	return commands.RefreshMsg{PreserveCursor: true}
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestErrors_RetryRedispatchesOperation verifies a failed operation lands in :errors and r runs it again.
+// BREAKS: If failures only toast, a source add that hit a daemon restart has to be typed again from memory.
+func TestErrors_RetryRedispatchesOperation(t *testing.T) {
+	calls := 0
+	addSource := func() tea.Msg {
+		calls++
+		err := fmt.Errorf("%w: connection refused", api.ErrDaemonDown)
+		return operations.SourceOperationMsg{Message: "Cannot connect to daemon - is it running?", Error: err}
+	}
+
+	m := testModel()
+	updated, cmd := m.Update(retryable("add source", "https://example.com/feed", addSource)())
+	m = updated.(Model)
+	// The result comes back as its own message
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.failures) != 1 || m.failures[0].op != "add source" || errorKind(m.failures[0].err) != "unreachable" {
+		t.Fatalf("Expected the failed add recorded, got %+v", m.failures)
+	}
+	if history := m.toastHistory.list(); len(history) != 1 || history[0].level != toastError {
+		t.Errorf("Expected the result still toasted, got %+v", history)
+	}
+
+	updated, _ = m.Update(commands.ErrorsMsg{})
+	m = updated.(Model)
+	if !m.errorsModal.IsVisible() || !strings.Contains(m.View(), "https://example.com/feed") {
+		t.Fatal("Expected :errors to list the failure")
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = updated.(Model)
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.failures) != 0 {
+		t.Errorf("Expected the retried failure taken off the list, got %d", len(m.failures))
+	}
+	// The retry comes first in the batch, ahead of the toast's timer
+	if msg, ok := cmd().(tea.BatchMsg)[0]().(operationFailedMsg); ok {
+		updated, _ = m.Update(msg)
+		m = updated.(Model)
+	}
+	if calls != 2 || len(m.failures) != 1 {
+		t.Errorf("Expected the add run again and failing back into the list, got %d calls and %d failures", calls, len(m.failures))
+	}
+}
+
+// TestErrors_SyncFailuresCollapse verifies repeated sync failures are one entry that a successful sync clears.
+// BREAKS: If every auto-refresh adds an entry, a daemon that's down for an hour buries everything else.
+func TestErrors_SyncFailuresCollapse(t *testing.T) {
+	m := testModel()
+	m.remoteURL = "http://server:8989"
+	for i := 0; i < 3; i++ {
+		updated, _ := m.Update(itemsLoadedMsg{err: api.ErrDaemonDown, syncFailed: true})
+		m = updated.(Model)
+	}
+	if len(m.failures) != 1 || m.failures[0].count != 3 || m.failures[0].object != m.remoteURL {
+		t.Fatalf("Expected one sync failure counted three times, got %+v", m.failures)
+	}
+	if msg := m.failures[0].retry(); msg != (commands.RefreshMsg{PreserveCursor: true}) {
+		t.Errorf("Expected the retry to refresh, got %#v", msg)
+	}
+
+	m.recordFailure(failure{op: "narrate", object: "Long read", err: api.ErrServer})
+	updated, _ := m.Update(itemsLoadedMsg{syncedAt: m.lastSync.Add(1)})
+	m = updated.(Model)
+	if len(m.failures) != 1 || m.failures[0].op != "narrate" {
+		t.Errorf("Expected only the sync failure cleared, got %+v", m.failures)
+	}
+}
+
+// TestRetryable_IgnoresCancellation verifies a cancelled operation isn't listed as failed.
+// BREAKS: If Esc counts as a failure, every cancelled briefing clutters :errors.
+func TestRetryable_IgnoresCancellation(t *testing.T) {
+	cancelled := func() tea.Msg {
+		return operations.AudioOperationMsg{Message: "Audio briefing cancelled", Error: context.Canceled}
+	}
+	if _, failed := retryable("audio briefing", "high items", cancelled)().(operationFailedMsg); failed {
+		t.Error("Expected a cancellation passed through")
+	}
+}
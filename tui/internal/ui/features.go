@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// commandFeatures are the commands that need an optional daemon feature or
+// a database column `make migrate` adds
+var commandFeatures = map[string]string{
+	"audio":         api.FeatureAudio,
+	"transcript":    api.FeatureAudio,
+	"listen":        api.FeatureAudio,
+	"prune":         api.FeaturePrune,
+	"prune!":        api.FeaturePrune,
+	"unprioritized": api.FeaturePrune,
+	"archived":      db.SchemaArchive,
+	"up":            db.SchemaFeedback,
+	"down":          db.SchemaFeedback,
+	"tag":           db.SchemaTags,
+	"snooze":        db.SchemaSnooze,
+	"pin":           db.SchemaPins,
+}
+
+// commandFeature returns the daemon feature a command line needs, if any.
+// Pausing and stopping narration are local and always available.
+func commandFeature(line []string) string {
+	if len(line) == 0 {
+		return ""
+	}
+	if line[0] == "listen" && len(line) > 1 {
+		return ""
+	}
+	return commandFeatures[line[0]]
+}
+
+// missingFeature explains why feature can't be used, or returns nil when the
+// daemon and database support it or haven't been checked yet (the request
+// then reports its own error)
+func (m Model) missingFeature(feature string) error {
+	if err := m.schema.Require(feature); err != nil {
+		return err
+	}
+	if feature == "" || m.daemon == nil || m.daemon.Supports(feature) {
+		return nil
+	}
+	return &api.UnsupportedError{Feature: feature, Version: m.daemon.Version}
+}
+
+// refuseMissing returns a command showing why feature is unavailable, or nil
+// when the command can go ahead
+func (m Model) refuseMissing(feature string) tea.Cmd {
+	err := m.missingFeature(feature)
+	if err == nil {
+		return nil
+	}
+	return func() tea.Msg { return commands.ErrorMsg{Message: err.Error()} }
+}
+
+// unavailableHint is the palette's short reason for a missing feature
+func unavailableHint(err error) string {
+	var schemaErr *db.SchemaError
+	if errors.As(err, &schemaErr) {
+		return "needs make migrate"
+	}
+	return "needs a newer daemon"
+}
+
+// handleDaemonVersion records the handshake and warns once about the
+// features an older daemon lacks
+func (m *Model) handleDaemonVersion(msg operations.DaemonVersionMsg) tea.Cmd {
+	if msg.Error != nil || msg.Info == nil {
+		return nil // Unreachable daemons are reported by the load itself
+	}
+	m.daemon = msg.Info
+	missing := msg.Info.Missing()
+	if len(missing) == 0 {
+		return nil
+	}
+	version := "The daemon"
+	if msg.Info.Version != "" {
+		version = "Daemon " + msg.Info.Version
+	}
+	return m.notify(toastWarn, fmt.Sprintf("%s is older than this TUI: %s unavailable until it's upgraded", version, strings.Join(missing, ", ")), 8*time.Second)
+}
+
+// schemaCheckedMsg carries the local database's schema
+type schemaCheckedMsg struct {
+	schema *db.Schema
+}
+
+// unsatisfiedFilters describes the active flag filters the database or
+// daemon can't serve, e.g. "archived (needs a newer daemon)". Their views
+// would otherwise come up empty without saying why.
+func (m Model) unsatisfiedFilters() []string {
+	var unsatisfied []string
+	if m.archiveScope() != db.ActiveContent {
+		if err := m.missingFeature(db.SchemaArchive); err != nil {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("archived (%s)", unavailableHint(err)))
+		}
+	}
+	if m.showInteresting || m.priority == "interesting" {
+		if err := m.missingFeature(db.SchemaFeedback); err != nil {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("interesting (%s)", unavailableHint(err)))
+		}
+	}
+	return unsatisfied
+}
+
+// warnUnsatisfied toasts the active filters that can't be applied, so an
+// empty list isn't mistaken for nothing matching
+func (m *Model) warnUnsatisfied() tea.Cmd {
+	unsatisfied := m.unsatisfiedFilters()
+	if len(unsatisfied) == 0 {
+		return nil
+	}
+	return m.notify(toastWarn, "Can't filter by "+strings.Join(unsatisfied, ", ")+"; the list may be empty", 5*time.Second)
+}
+
+// checkSchema reads which columns the local database has; unreadable
+// databases are reported by the first load instead
+func checkSchema() tea.Msg {
+	schema, err := db.GetSchema()
+	if err != nil {
+		return schemaCheckedMsg{}
+	}
+	return schemaCheckedMsg{schema: schema}
+}
+
+// handleSchema records the database's schema and warns once when it's
+// older or newer than this TUI
+func (m *Model) handleSchema(msg schemaCheckedMsg) tea.Cmd {
+	if msg.schema == nil {
+		return nil
+	}
+	m.schema = msg.schema
+	if message := msg.schema.Message(); message != "" {
+		return m.notify(toastWarn, message, 8*time.Second)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestDaemonVersion_GreysOutMissingFeatures verifies an older daemon's missing features are flagged in the palette and refused.
+// BREAKS: If commands aren't gated, :prune on an old daemon fails with a bare 404.
+func TestDaemonVersion_GreysOutMissingFeatures(t *testing.T) {
+	m := Model{}
+	updated, _ := m.Update(operations.DaemonVersionMsg{Info: &api.VersionInfo{Version: "0.1.0", Features: []string{api.FeatureAudio}}})
+	m = updated.(Model)
+	if !strings.Contains(lastToast(m), "prune, interesting") {
+		t.Errorf("Expected a warning naming the missing features, got %q", lastToast(m))
+	}
+
+	for _, e := range m.paletteEntries() {
+		if e.kind != "command" {
+			continue
+		}
+		switch strings.Join(e.line, " ") {
+		case "prune", "unprioritized":
+			if !strings.Contains(e.unavailable, "0.1.0") {
+				t.Errorf("Expected %s greyed out, got %+v", e.label, e)
+			}
+		case "audio", "listen stop", "refresh":
+			if e.unavailable != "" {
+				t.Errorf("Expected %s available, got %q", e.label, e.unavailable)
+			}
+		}
+	}
+
+	_, cmd := m.Update(commands.PruneMsg{})
+	if cmd == nil {
+		t.Fatal("Expected :prune refused")
+	}
+	if msg, ok := cmd().(commands.ErrorMsg); !ok || !strings.Contains(msg.Message, "newer prismis daemon") {
+		t.Errorf("Expected an explanatory error, got %#v", msg)
+	}
+}
+
+// TestDaemonVersion_UnansweredAllowsEverything verifies commands aren't blocked before or without a handshake.
+// BREAKS: If a failed handshake disables features, a slow daemon start greys out audio for the whole session.
+func TestDaemonVersion_UnansweredAllowsEverything(t *testing.T) {
+	m := Model{}
+	updated, cmd := m.Update(operations.DaemonVersionMsg{Error: api.ErrDaemonDown})
+	m = updated.(Model)
+	if cmd != nil || m.daemon != nil || m.refuseMissing(api.FeatureAudio) != nil {
+		t.Error("Expected nothing gated when the handshake fails")
+	}
+
+	updated, cmd = m.Update(operations.DaemonVersionMsg{Info: &api.VersionInfo{Features: []string{api.FeatureAudio, api.FeaturePrune, api.FeatureInteresting, api.FeatureActivity, api.FeatureMerge}}})
+	if cmd != nil || updated.(Model).refuseMissing(api.FeaturePrune) != nil {
+		t.Error("Expected no warning for a current daemon")
+	}
+}
+
+// TestSchema_OlderDatabaseGatesFeatures verifies a database that predates `make migrate` warns and refuses what it can't store.
+// BREAKS: If the schema isn't checked, :pin on an unmigrated database fails with a bare "no such column".
+func TestSchema_OlderDatabaseGatesFeatures(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	dbPath := filepath.Join(dataHome, "prismis", "prismis.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Exec(`CREATE TABLE content (id TEXT PRIMARY KEY, source_id TEXT, title TEXT, url TEXT, read BOOLEAN, favorited BOOLEAN, archived_at TIMESTAMP, interesting_override BOOLEAN, user_feedback TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	db.CloseDB()
+	defer db.CloseDB()
+
+	m := testModelWithItems([]db.ContentItem{{ID: "1", Title: "Item"}})
+	updated, _ := m.Update(checkSchema())
+	m = updated.(Model)
+	if toast := lastToast(m); !strings.Contains(toast, "older than this TUI") || !strings.Contains(toast, "tags, snooze, pins") {
+		t.Errorf("Expected a warning naming the missing features, got %q", toast)
+	}
+
+	for _, e := range m.paletteEntries() {
+		if e.kind != "command" {
+			continue
+		}
+		switch e.line[0] {
+		case "pin", "tag", "snooze":
+			if e.hint != "needs make migrate" || !strings.Contains(e.unavailable, "make migrate") {
+				t.Errorf("Expected %s greyed out, got %+v", e.label, e)
+			}
+		case "archived", "up":
+			if e.unavailable != "" {
+				t.Errorf("Expected %s available, got %q", e.label, e.unavailable)
+			}
+		}
+	}
+
+	_, cmd := m.Update(commands.PinMsg{})
+	if cmd == nil {
+		t.Fatal("Expected :pin refused")
+	}
+	if msg, ok := cmd().(commands.ErrorMsg); !ok || !strings.Contains(msg.Message, "make migrate") {
+		t.Errorf("Expected an explanatory error, got %#v", msg)
+	}
+}
@@ -2,11 +2,11 @@ package ui
 
 import (
 	"fmt"
-	"runtime"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/nickpending/prismis/internal/db"
 )
 
@@ -26,6 +26,8 @@ func buildViewStateString(m Model) string {
 		states = append(states, "Priority: UNPRIORITIZED")
 	case "favorites":
 		states = append(states, "Priority: ★ FAVORITES")
+	case "interesting":
+		states = append(states, "Priority: 👍 INTERESTING")
 	default:
 		states = append(states, "Priority: PRIORITIZED")
 	}
@@ -44,8 +46,15 @@ func buildViewStateString(m Model) string {
 		states = append(states, "ARCHIVED")
 	}
 
-	// Sort state (newest vs oldest)
-	if m.sortNewest {
+	// Flag filters the database or daemon can't apply
+	if unsatisfied := m.unsatisfiedFilters(); len(unsatisfied) > 0 {
+		states = append(states, "⚠ Unavailable: "+strings.Join(unsatisfied, ", "))
+	}
+
+	// Sort state (newest vs oldest, or the view's :sort order)
+	if _, saved := m.viewSorts[m.priority]; saved {
+		states = append(states, "Sort: "+strings.ToUpper(formatSortSpec(m.sortOrder())))
+	} else if m.sortNewest {
 		states = append(states, "Sort: NEWEST")
 	} else {
 		states = append(states, "Sort: OLDEST")
@@ -65,6 +74,59 @@ func buildViewStateString(m Model) string {
 		states = append(states, "Filter: ALL")
 	}
 
+	// The view's :filter expression and the running saved search
+	if expr := m.filterExpr(); expr != nil {
+		states = append(states, "Where: "+expr.String())
+	}
+	if expr := m.searchExpr(); expr != nil {
+		label := m.search.name
+		if label == "" {
+			label = expr.String()
+		} else if scope, ok := expr.Archived(); ok && scope == db.AnyContent {
+			label += " +archived"
+		} else if ok && scope == db.ArchivedContent {
+			label += " (archived only)"
+		}
+		states = append(states, "Search: "+label)
+	}
+
+	// Category filter
+	if m.filterCategory != "" {
+		states = append(states, "Category: "+strings.ToUpper(m.filterCategory))
+	}
+
+	// Type-to-filter query
+	if q := m.listFilter.Query(); q != "" {
+		states = append(states, fmt.Sprintf("Match: %q", q))
+	}
+
+	// Single-source filter
+	if m.filterSource != "" {
+		name := m.filterSource
+		if source, ok := findSource(m.sources, m.filterSource); ok && source.Name != "" {
+			name = source.Name
+		}
+		states = append(states, "Source: "+strings.ToUpper(name))
+	}
+
+	// User tag filter
+	if m.filterTag != "" {
+		states = append(states, "Tag: #"+m.filterTag)
+	}
+
+	// Byline filter
+	if m.filterAuthor != "" {
+		states = append(states, "Author: "+m.filterAuthor)
+	}
+
+	// Arrival window
+	if !m.filterSince.IsZero() {
+		states = append(states, "Since: "+formatFilterTime(m.filterSince))
+	}
+	if !m.filterUntil.IsZero() {
+		states = append(states, "Until: "+formatFilterTime(m.filterUntil))
+	}
+
 	// Add hidden count if applicable
 	if m.hiddenCount > 0 && !m.showUnprioritized {
 		states = append(states, fmt.Sprintf("Hidden: %d", m.hiddenCount))
@@ -81,6 +143,16 @@ func buildViewStateString(m Model) string {
 	return strings.Join(states, " | ")
 }
 
+// formatFilterTime shows a since/until bound: the date alone at midnight,
+// the time too otherwise
+func formatFilterTime(t time.Time) string {
+	t = t.Local()
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
 // RenderList renders the feed view with clean cyber styling
 func RenderList(m Model) string {
 	// Use defaults if WindowSizeMsg hasn't arrived yet
@@ -93,7 +165,9 @@ func RenderList(m Model) string {
 		height = 24 // Standard terminal default
 	}
 
-	if m.loading {
+	// Only blank the screen on first load; refreshes keep the list visible
+	// while the header shows progress
+	if m.loading && len(m.items) == 0 && !m.syncing {
 		return renderLoading()
 	}
 
@@ -103,11 +177,20 @@ func RenderList(m Model) string {
 
 	theme := m.theme
 
+	// Zen mode: just the article and the command line
+	if m.zenActive() {
+		bottomLine, stacked := renderBottomLine(m, width, theme)
+		return overlayToasts(renderZen(m, width, bottomLine, theme), stacked, width, theme)
+	}
+
 	// Build the header content with padding built-in
 	title := " PRISMIS" // Add space for left padding
 
 	// Build state string
 	stateString := buildViewStateString(m)
+	if m.syncing {
+		stateString = syncStatus(m.syncSpinner.View(), m.syncProgress) + "  ◆ " + stateString
+	}
 
 	// Add time
 	timeString := time.Now().Format("15:04")
@@ -129,26 +212,27 @@ func RenderList(m Model) string {
 	// Create gradient background for the full width header (no additional styling)
 	header := RenderWithGradientBackground(headerContent, width, string(theme.Cyan), string(theme.VibrantPurple))
 
-	// Main content area
-	// Reserve space: header(1) + empty(1) + status(1) + command(1) + borders(1) = 5
-	contentHeight := height - 5
-
-	// Left sidebar (25% width)
-	sidebarWidth := width / 4
-	if sidebarWidth < 30 {
-		sidebarWidth = 30
-	}
-
-	// Right content (75% width)
-	contentWidth := width - sidebarWidth - 1
+	// Main content area: sidebar on the left, list/reader (and preview) on the right
+	l := m.layout()
+	contentHeight := l.contentHeight
+	sidebarWidth := l.sidebarWidth
+	contentWidth := l.contentWidth
 
 	// Build sidebar
-	sidebar := renderSidebar(m, sidebarWidth, contentHeight, theme)
+	var sidebar string
+	if !m.hideSidebar {
+		sidebar = renderSidebar(m, sidebarWidth, contentHeight, theme)
+	}
 
 	// Build main content - either list or reader based on view
 	var content string
 	if m.view == "reader" {
 		content = renderReaderContent(m, contentWidth, contentHeight, theme)
+	} else if l.previewHeight > 0 {
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Height(l.listHeight).MaxHeight(l.listHeight).Render(renderContentList(m, contentWidth, l.listHeight, theme)),
+			renderPreview(m, contentWidth, theme),
+		)
 	} else {
 		content = renderContentList(m, contentWidth, contentHeight, theme)
 	}
@@ -167,11 +251,10 @@ func RenderList(m Model) string {
 		Height(contentHeight).
 		Padding(0, 1)
 
-	main := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		sidebarStyle.Render(sidebar),
-		contentStyle.Render(content),
-	)
+	main := contentStyle.Render(content)
+	if !m.hideSidebar {
+		main = lipgloss.JoinHorizontal(lipgloss.Top, sidebarStyle.Render(sidebar), main)
+	}
 
 	// Status bar
 	statusStyle := lipgloss.NewStyle().
@@ -209,27 +292,58 @@ func RenderList(m Model) string {
 	// Status bar always shows counts
 	statusText := fmt.Sprintf("HIGH: %d  MED: %d  LOW: %d  ★: %d  |  Press ? for help",
 		highCount, medCount, lowCount, totalFavCount)
+	if m.pendingWrites > 0 {
+		// Offline changes waiting for the daemon
+		statusText = fmt.Sprintf("⟳ %d pending sync  |  %s", m.pendingWrites, statusText)
+	}
+	if len(m.failures) > 0 {
+		// Failed operations waiting in :errors
+		statusText = fmt.Sprintf("⚠ %d failed (:errors)  |  %s", len(m.failures), statusText)
+	}
+	if playing := m.player.status(); playing != "" {
+		statusText = playing + "  |  " + statusText
+	}
 	// Always show status bar
 	statusBar := statusStyle.Render(statusText)
 
 	// Bottom line: command/message area (like vim)
-	var bottomLine string
+	bottomLine, stacked := renderBottomLine(m, width, theme)
+
+	view := lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		main,
+		statusBar,
+		bottomLine,
+	)
+	if m.windowPending {
+		view = overlayWindowHints(view, width, theme)
+	}
+	return overlayToasts(view, stacked, width, theme)
+}
+
+// renderBottomLine renders the command/message line (like vim), returning
+// the toasts still to be stacked above it
+func renderBottomLine(m Model, width int, theme StyleTheme) (bottomLine string, stacked []toast) {
+	stacked = m.toasts // Toasts drawn above the status bar
 	if m.commandMode.IsActive() {
 		// Show command input
 		bottomLine = m.commandMode.View(theme)
+	} else if m.listFilter.IsActive() {
+		// Show the type-to-filter input
+		bottomLine = m.listFilter.View(width, theme)
 	} else if m.statusMessage != "" {
-		// Show status message (errors, success messages)
-		// Use different colors for errors vs success
-		messageColor := theme.Cyan // Default for success messages
-		if strings.Contains(strings.ToLower(m.statusMessage), "failed") ||
-			strings.Contains(strings.ToLower(m.statusMessage), "error") {
-			messageColor = theme.VibrantPurple // Vibrant purple for errors - noticeable but not harsh
-		}
+		// Show sticky prompt or progress (confirmations, "Pruning...")
 		messageStyle := lipgloss.NewStyle().
-			Foreground(messageColor).
+			Foreground(theme.Cyan).
 			Width(width).
 			Padding(0, 1)
 		bottomLine = messageStyle.Render(m.statusMessage)
+	} else if len(m.toasts) > 0 {
+		// Newest toast; older ones stack above the status bar
+		bottomLine = renderToast(m.toasts[len(m.toasts)-1], width, theme)
+		stacked = m.toasts[:len(m.toasts)-1]
 	} else {
 		// Empty line when no message or command
 		emptyStyle := lipgloss.NewStyle().
@@ -237,15 +351,7 @@ func RenderList(m Model) string {
 			Height(1)
 		bottomLine = emptyStyle.Render(" ")
 	}
-
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		"",
-		main,
-		statusBar,
-		bottomLine,
-	)
+	return bottomLine, stacked
 }
 
 func renderSidebar(m Model, width, height int, theme StyleTheme) string {
@@ -284,20 +390,16 @@ func renderSidebar(m Model, width, height int, theme StyleTheme) string {
 		lastUpdate = formatTime(time.Since(mostRecent)) + " ago"
 	}
 
-	// Get memory usage
-	memStats := getMemoryUsage()
-
 	statsContent := []string{
 		fmt.Sprintf("Sources:     %d active", sourceCount),
 		fmt.Sprintf("Total:       %d items", totalItems),
 		fmt.Sprintf("Priority:    %s %d high",
-			lipgloss.NewStyle().Foreground(theme.Red).Render("▲"), highCount),
-		fmt.Sprintf("Feed Health: %s Online",
-			lipgloss.NewStyle().Foreground(theme.Green).Render("●")),
-		fmt.Sprintf("Memory:      %s", memStats),
-		fmt.Sprintf("Updates:     %s",
-			lipgloss.NewStyle().Foreground(theme.Gray).Render(lastUpdate)),
+			lipgloss.NewStyle().Foreground(theme.High).Render("▲"), highCount),
 	}
+	statsContent = append(statsContent, goalStats(m, theme)...)
+	statsContent = append(statsContent, syncStats(m, theme)...)
+	statsContent = append(statsContent, fmt.Sprintf("Updates:     %s",
+		lipgloss.NewStyle().Foreground(theme.Gray).Render(lastUpdate)))
 
 	statsSection := lipgloss.NewStyle().
 		Height(statsHeight).
@@ -330,7 +432,8 @@ func renderSidebar(m Model, width, height int, theme StyleTheme) string {
 		lipgloss.Left,
 		actualHeader,
 		"", // Add blank line after header
-		m.sourcesViewport.View(),
+		withScrollbar(m.sourcesViewport.View(), m.sourcesViewport.Width-1, m.sourcesViewport.Height,
+			m.sourcesViewport.TotalLineCount(), m.sourcesViewport.YOffset, theme),
 	)
 
 	return lipgloss.JoinVertical(
@@ -340,182 +443,322 @@ func renderSidebar(m Model, width, height int, theme StyleTheme) string {
 	)
 }
 
+// syncStats describes the connection in the SYSTEM section: daemon health,
+// and in remote mode the API latency, last sync, and queued writes
+func syncStats(m Model, theme StyleTheme) []string {
+	dot := func(color lipgloss.Color, text string) string {
+		return lipgloss.NewStyle().Foreground(color).Render("●") + " " + text
+	}
+	gray := lipgloss.NewStyle().Foreground(theme.Gray)
+
+	if m.remoteURL == "" {
+		return []string{"Feed Health: " + dot(theme.Green, "Online")}
+	}
+
+	health := dot(theme.Green, "Online")
+	switch {
+	case m.syncFailed && m.lastSyncAt.IsZero():
+		health = dot(theme.Red, "Unreachable")
+	case m.syncFailed:
+		health = dot(theme.Orange, "Sync failing")
+	case m.lastSyncAt.IsZero():
+		health = dot(theme.Gray, "Connecting")
+	}
+
+	latency := gray.Render("–")
+	if m.latency > 0 {
+		color := theme.Green
+		switch {
+		case m.latency >= 500*time.Millisecond:
+			color = theme.Red
+		case m.latency >= 150*time.Millisecond:
+			color = theme.Orange
+		}
+		latency = lipgloss.NewStyle().Foreground(color).Render(formatLatency(m.latency))
+	}
+
+	synced := "never"
+	switch since := time.Since(m.lastSyncAt); {
+	case m.lastSyncAt.IsZero():
+	case since < time.Minute:
+		synced = "just now"
+	default:
+		synced = formatTime(since) + " ago"
+	}
+	synced = gray.Render(synced)
+	if m.pendingWrites > 0 {
+		synced += lipgloss.NewStyle().Foreground(theme.Orange).Render(fmt.Sprintf(" · %d queued", m.pendingWrites))
+	}
+
+	return []string{
+		"Feed Health: " + health,
+		"Latency:     " + latency,
+		"Synced:      " + synced,
+	}
+}
+
+// formatLatency shows a round trip in ms, or seconds once it's that slow
+func formatLatency(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", max(1, int(d.Milliseconds())))
+}
+
 func renderContentList(m Model, width, height int, theme StyleTheme) string {
 	if len(m.items) == 0 {
 		return renderEmptyState(theme)
 	}
+	if expr := m.searchExpr(); expr != nil {
+		return renderSearchResults(m, expr, width, height, theme)
+	}
 
 	var lines []string
 
 	// Calculate visible items
 	itemHeight := 2 // lines per item
-	maxVisible := height / itemHeight
-
-	startIdx := 0
-	if m.cursor > maxVisible-3 {
-		startIdx = m.cursor - maxVisible + 3
+	if m.rowFormat != nil {
+		itemHeight = len(m.rowFormat)
 	}
-	endIdx := startIdx + maxVisible
-	if endIdx > len(m.items) {
-		endIdx = len(m.items)
-		if endIdx-startIdx < maxVisible {
-			startIdx = max(0, endIdx-maxVisible)
-		}
+	if m.compact {
+		// One line each; the last line shows the selected item's metadata
+		itemHeight = 1
+		height = max(1, height-1)
 	}
+	maxVisible := height / itemHeight
+	startIdx, endIdx := visibleRange(m.cursor, len(m.items), maxVisible)
 
 	for i := startIdx; i < endIdx; i++ {
 		item := m.items[i]
 
-		// Priority indicator - star for favorited, checkmark for read items, dot for unread
-		var priorityIndicator string
-		if item.Favorited {
-			// Heart for favorited items (overrides all other indicators) - vibrant purple
-			priorityIndicator = lipgloss.NewStyle().Foreground(theme.VibrantPurple).Render("♥")
-		} else if item.Read {
-			// Use checkmark for read items in gray
-			priorityIndicator = lipgloss.NewStyle().Foreground(theme.Gray).Render("✓")
-		} else {
-			// Use colored dot for unread items
-			var dotColor lipgloss.Color
-			switch item.Priority {
-			case "high":
-				dotColor = theme.Red
-			case "medium":
-				dotColor = theme.Orange
-			case "low":
-				dotColor = theme.Cyan
-			default:
-				// Default to gray if priority is empty or null
-				dotColor = theme.Gray
-			}
-			priorityIndicator = lipgloss.NewStyle().Foreground(dotColor).Render("●")
-		}
-
 		// Selection indicator and flash effect
 		selector := "  "
 		titleColor := theme.White
 		if i == m.cursor {
-			selector = lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true).Render("▸ ")
-			titleColor = theme.Cyan
+			selector = lipgloss.NewStyle().Foreground(theme.Selection).Bold(true).Render("▸ ")
+			titleColor = theme.Selection
 		}
 
 		// Dim read items
 		if item.Read {
-			titleColor = theme.Gray // Dim the title for read items
+			titleColor = theme.Read // Dim the title for read items
+		}
+
+		// A [tui] row_format template replaces the built-in layout
+		if m.rowFormat != nil {
+			rows := m.rowFormat.render(m, i, selector, titleColor, width, theme)
+			if m.compact {
+				rows = rows[:1]
+			}
+			lines = append(lines, rows...)
+			continue
 		}
 
 		// No separate star indicator needed - stars are now part of priority indicator
 
 		// Format line 1: number, title
 		titleText := truncate(item.Title, width-20) // Standard width since no separate star
-		line1 := fmt.Sprintf("%s%s %2d. %s",
+		badge := m.linkBadge(item, theme)
+		if badge != "" {
+			titleText = truncate(item.Title, width-22)
+			badge = " " + badge
+		}
+		line1 := fmt.Sprintf("%s%s %2d. %s%s",
 			selector,
-			priorityIndicator,
+			itemIndicator(item, theme),
 			i+1,
-			lipgloss.NewStyle().Foreground(titleColor).Render(titleText),
+			m.titleLink(item, lipgloss.NewStyle().Foreground(titleColor).Render(titleText)),
+			badge,
 		)
 
+		if m.compact {
+			lines = append(lines, line1)
+			continue
+		}
+
 		// Format line 2: metadata
-		timeAgo := formatTime(time.Since(item.Published))
-		metaStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+		line2 := "        " + strings.Join(itemMetaParts(item, theme), " | ")
 
-		// Build metadata line with real data
-		var line2 string
-		tags := extractTags(item.Analysis)
-		contentLength := extractContentLength(item.Analysis)
+		lines = append(lines, line1, line2)
+	}
 
-		// Build metadata components
-		var metaParts []string
+	// Scrollbar along the right edge of the pane's inner width (padding takes two columns)
+	list := withScrollbar(strings.Join(lines, "\n"), width-3, maxVisible*itemHeight, len(m.items)*itemHeight, startIdx*itemHeight, theme)
+	lines = strings.Split(list, "\n")
 
-		// Source name if available (no need for source type - it's obvious from the name)
-		if item.SourceName != "" {
-			metaParts = append(metaParts, metaStyle.Render(item.SourceName))
+	if m.compact && m.cursor < len(m.items) {
+		// Keep the footer on the bottom line even when the list is short
+		for len(lines) < height {
+			lines = append(lines, "")
 		}
+		footer := "  " + strings.Join(itemMetaParts(m.items[m.cursor], theme), " | ")
+		lines = append(lines, ansi.Truncate(footer, width, "…"))
+	}
 
-		// For RSS feeds, also show the domain
-		if item.SourceType == "rss" {
-			domain := extractDomain(item.URL)
-			metaParts = append(metaParts, metaStyle.Render(domain))
-		}
+	return strings.Join(lines, "\n")
+}
 
-		// Time ago
-		metaParts = append(metaParts, metaStyle.Render(timeAgo))
-
-		// Reddit-specific metrics
-		if item.SourceType == "reddit" {
-			redditMetrics := extractRedditMetrics(item.Analysis)
-			if redditMetrics.score > 0 || redditMetrics.numComments > 0 {
-				// Show upvotes with arrow
-				if redditMetrics.score > 0 {
-					upvoteStr := fmt.Sprintf("↑%d", redditMetrics.score)
-					metaParts = append(metaParts, lipgloss.NewStyle().Foreground(theme.Orange).Render(upvoteStr))
-				}
-				// Show comments
-				if redditMetrics.numComments > 0 {
-					commentStr := fmt.Sprintf("%dc", redditMetrics.numComments)
-					metaParts = append(metaParts, metaStyle.Render(commentStr))
-				}
-			}
+// visibleRange is the slice of a list of total items that fits maxVisible
+// rows, scrolled to keep a few items of context below the cursor
+func visibleRange(cursor, total, maxVisible int) (startIdx, endIdx int) {
+	if cursor > maxVisible-3 {
+		startIdx = cursor - maxVisible + 3
+	}
+	endIdx = startIdx + maxVisible
+	if endIdx > total {
+		endIdx = total
+		if endIdx-startIdx < maxVisible {
+			startIdx = max(0, endIdx-maxVisible)
 		}
+	}
+	return startIdx, endIdx
+}
 
-		// YouTube-specific metrics
-		if item.SourceType == "youtube" {
-			youtubeMetrics := extractYouTubeMetrics(item.Analysis)
-			if youtubeMetrics.viewCount > 0 {
-				// Format view count without stupid emoji
-				var viewStr string
-				if youtubeMetrics.viewCount >= 1000000 {
-					viewStr = fmt.Sprintf("%.1fM views", float64(youtubeMetrics.viewCount)/1000000)
-				} else if youtubeMetrics.viewCount >= 1000 {
-					viewStr = fmt.Sprintf("%.1fK views", float64(youtubeMetrics.viewCount)/1000)
-				} else {
-					viewStr = fmt.Sprintf("%d views", youtubeMetrics.viewCount)
-				}
-				metaParts = append(metaParts, metaStyle.Render(viewStr))
-			}
-			if youtubeMetrics.duration > 0 {
-				// Format duration in minutes only (no seconds)
-				durationStr := formatDurationMinutes(youtubeMetrics.duration)
-				metaParts = append(metaParts, metaStyle.Render(durationStr))
-			}
-		}
+// itemMetaParts builds a list item's metadata: source, age, metrics, tags,
+// and the why hint for HIGH items
+func itemMetaParts(item db.ContentItem, theme StyleTheme) []string {
+	timeAgo := formatTime(time.Since(item.Published))
+	metaStyle := lipgloss.NewStyle().Foreground(theme.Gray)
 
-		// Content length if available (more compact display) - only for RSS
-		if item.SourceType == "rss" && contentLength > 0 {
-			var lengthStr string
-			if contentLength >= 10000 {
-				lengthStr = fmt.Sprintf("%.1fk", float64(contentLength)/1000)
-			} else if contentLength >= 1000 {
-				lengthStr = fmt.Sprintf("%.1fk", float64(contentLength)/1000)
-			} else {
-				lengthStr = fmt.Sprintf("%d", contentLength)
-			}
-			metaParts = append(metaParts, metaStyle.Render(lengthStr+" chars"))
-		}
+	analysis := item.ParsedAnalysis()
+	tags := formatEntities(analysis.Entities, 2)
+
+	// Build metadata components
+	var metaParts []string
+
+	// Source name if available (no need for source type - it's obvious from the name)
+	if item.SourceName != "" {
+		metaParts = append(metaParts, metaStyle.Render(item.SourceName))
+	}
+
+	// Byline, for multi-author feeds
+	if byline := itemByline(item); byline != "" {
+		metaParts = append(metaParts, metaStyle.Render(byline))
+	}
+
+	// For RSS feeds, also show the domain
+	if item.SourceType == "rss" {
+		domain := extractDomain(item.URL)
+		metaParts = append(metaParts, metaStyle.Render(domain))
+	}
+
+	// Time ago
+	metaParts = append(metaParts, metaStyle.Render(timeAgo))
+
+	metaParts = append(metaParts, itemMetrics(item, analysis, theme)...)
+
+	// Content length for every source type, as :sort length measures it
+	if contentLength := itemLength(&item); contentLength > 0 {
+		metaParts = append(metaParts, metaStyle.Render(formatContentLength(contentLength)))
+	}
 
-		// Tags if available
-		if tags != "" {
-			metaParts = append(metaParts, tags)
+	// Tags if available
+	if tags != "" {
+		metaParts = append(metaParts, tags)
+	}
+	if userTags := renderUserTags(item.UserTags, theme); userTags != "" {
+		metaParts = append(metaParts, userTags)
+	}
+
+	// What the LLM keyed on, so HIGH items show why at a glance
+	if item.Priority == "high" {
+		if hint := whyHint(analysis); hint != "" {
+			metaParts = append(metaParts, metaStyle.Italic(true).Render(hint))
 		}
+	}
+
+	// User feedback indicator (prepend so it's visible)
+	if feedbackIndicator := feedbackIcon(item.UserFeedback, theme); feedbackIndicator != "" {
+		metaParts = append([]string{feedbackIndicator}, metaParts...)
+	}
+
+	return metaParts
+}
+
+// itemByline is "by <author>", or empty when there's no author or it just
+// repeats the source name (single-author blogs, YouTube channels)
+func itemByline(item db.ContentItem) string {
+	author := strings.TrimSpace(item.Author)
+	if author == "" || strings.EqualFold(author, item.SourceName) {
+		return ""
+	}
+	return "by " + author
+}
+
+// itemIndicator marks a list item: flag for pinned, heart for favorited,
+// checkmark for read, and a priority-colored dot for unread items (a clock
+// once back from :snooze)
+func itemIndicator(item db.ContentItem, theme StyleTheme) string {
+	if item.Pinned {
+		// Flag for pinned items, which sit above everything else
+		return lipgloss.NewStyle().Foreground(theme.Green).Bold(true).Render("⚑")
+	}
+	if item.Favorited {
+		// Heart for favorited items (overrides all other indicators)
+		return lipgloss.NewStyle().Foreground(theme.Favorite).Render("♥")
+	}
+	if item.Read {
+		return lipgloss.NewStyle().Foreground(theme.Read).Render("✓")
+	}
+	mark := "●"
+	if wokeFromSnooze(item, time.Now()) {
+		mark = "◷"
+	}
+	return lipgloss.NewStyle().Foreground(theme.PriorityColor(item.Priority)).Render(mark)
+}
 
-		// User feedback indicator (prepend so it's visible)
-		var feedbackIndicator string
-		switch item.UserFeedback {
-		case "up":
-			feedbackIndicator = lipgloss.NewStyle().Foreground(theme.Green).Render("👍")
-		case "down":
-			feedbackIndicator = lipgloss.NewStyle().Foreground(theme.Red).Render("👎")
+// itemMetrics renders Reddit score/comments and YouTube views/duration
+func itemMetrics(item db.ContentItem, analysis *db.Analysis, theme StyleTheme) []string {
+	metaStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+	var parts []string
+	switch item.SourceType {
+	case "reddit":
+		redditMetrics := analysis.Metrics
+		// Show upvotes with arrow
+		if redditMetrics.Score > 0 {
+			parts = append(parts, lipgloss.NewStyle().Foreground(theme.Orange).Render(fmt.Sprintf("↑%d", redditMetrics.Score)))
 		}
-		if feedbackIndicator != "" {
-			metaParts = append([]string{feedbackIndicator}, metaParts...)
+		if redditMetrics.NumComments > 0 {
+			parts = append(parts, metaStyle.Render(fmt.Sprintf("%dc", redditMetrics.NumComments)))
 		}
+	case "youtube":
+		youtubeMetrics := analysis.Metrics
+		if youtubeMetrics.ViewCount > 0 {
+			var viewStr string
+			if youtubeMetrics.ViewCount >= 1000000 {
+				viewStr = fmt.Sprintf("%.1fM views", float64(youtubeMetrics.ViewCount)/1000000)
+			} else if youtubeMetrics.ViewCount >= 1000 {
+				viewStr = fmt.Sprintf("%.1fK views", float64(youtubeMetrics.ViewCount)/1000)
+			} else {
+				viewStr = fmt.Sprintf("%d views", youtubeMetrics.ViewCount)
+			}
+			parts = append(parts, metaStyle.Render(viewStr))
+		}
+		if youtubeMetrics.Duration > 0 {
+			// Format duration in minutes only (no seconds)
+			parts = append(parts, metaStyle.Render(formatDurationMinutes(youtubeMetrics.Duration)))
+		}
+	}
+	return parts
+}
 
-		line2 = "        " + strings.Join(metaParts, " | ")
-
-		lines = append(lines, line1, line2)
+// formatContentLength shows an article length compactly, e.g. "12.3k chars"
+func formatContentLength(n int) string {
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fk chars", float64(n)/1000)
 	}
+	return fmt.Sprintf("%d chars", n)
+}
 
-	return strings.Join(lines, "\n")
+// feedbackIcon shows the user's vote on an item, empty when there is none
+func feedbackIcon(feedback string, theme StyleTheme) string {
+	switch feedback {
+	case "up":
+		return lipgloss.NewStyle().Foreground(theme.Green).Render("👍")
+	case "down":
+		return lipgloss.NewStyle().Foreground(theme.Red).Render("👎")
+	}
+	return ""
 }
 
 func renderLoading() string {
@@ -580,149 +823,22 @@ func extractDomain(url string) string {
 	return domain
 }
 
-// extractContentLength extracts the content_length from the Analysis JSON metadata
-func extractContentLength(analysis string) int {
-	if analysis == "" {
-		return 0
-	}
-
-	// Look for "content_length": <number>
-	start := strings.Index(analysis, `"content_length":`)
-	if start == -1 {
-		return 0
-	}
-
-	// Move past the key
-	start += len(`"content_length":`)
-
-	// Find the next comma or closing brace
-	end := strings.IndexAny(analysis[start:], ",}")
-	if end == -1 {
-		return 0
-	}
-
-	// Extract and parse the number
-	numStr := strings.TrimSpace(analysis[start : start+end])
-	var length int
-	fmt.Sscanf(numStr, "%d", &length)
-	return length
-}
-
-// extractTags extracts tags from the Analysis JSON field
-func extractTags(analysis string) string {
-	if analysis == "" {
-		return ""
-	}
-
-	// Parse tags from the JSON-like analysis field
-	theme := CleanCyberTheme
-	tagStyle := lipgloss.NewStyle().Foreground(theme.Purple)
-
-	// Look for entities array in the analysis JSON
-	// Pattern: "entities": ["tag1", "tag2", ...]
-	start := strings.Index(analysis, `"entities"`)
-	if start == -1 {
-		return ""
-	}
-
-	// Find the array start
-	arrayStart := strings.Index(analysis[start:], "[")
-	if arrayStart == -1 {
-		return ""
-	}
-	start += arrayStart + 1
-
-	// Find the array end
-	arrayEnd := strings.Index(analysis[start:], "]")
-	if arrayEnd == -1 {
-		return ""
-	}
-
-	tagStr := analysis[start : start+arrayEnd]
-	tagStr = strings.ReplaceAll(tagStr, `"`, "")
-	tagStr = strings.ReplaceAll(tagStr, " ", "")
-
-	if tagStr == "" {
-		return ""
-	}
-
-	tagList := strings.Split(tagStr, ",")
-
-	// Format first 2-3 tags in a single container
-	tagsToShow := []string{}
-	for i, tag := range tagList {
-		if i >= 2 {
+// formatEntities renders the analysis entities as "go • llm", showing at
+// most limit of them (all when limit <= 0)
+func formatEntities(entities []string, limit int) string {
+	var shown []string
+	for _, entity := range entities {
+		if limit > 0 && len(shown) >= limit {
 			break
 		}
-		if tag != "" {
-			tagsToShow = append(tagsToShow, tag)
-		}
-	}
-
-	if len(tagsToShow) == 0 {
-		return ""
-	}
-
-	// Join tags with separator (no container for clean look)
-	tagString := strings.Join(tagsToShow, " • ")
-	return tagStyle.Render(tagString)
-}
-
-// extractAllTags extracts ALL tags from the Analysis JSON field (for reader modal)
-func extractAllTags(analysis string) string {
-	if analysis == "" {
-		return ""
-	}
-
-	// Parse tags from the JSON-like analysis field
-	theme := CleanCyberTheme
-	tagStyle := lipgloss.NewStyle().Foreground(theme.Purple)
-
-	// Look for entities array in the analysis JSON
-	// Pattern: "entities": ["tag1", "tag2", ...]
-	start := strings.Index(analysis, `"entities"`)
-	if start == -1 {
-		return ""
-	}
-
-	// Find the array start
-	arrayStart := strings.Index(analysis[start:], "[")
-	if arrayStart == -1 {
-		return ""
-	}
-	start += arrayStart + 1
-
-	// Find the array end
-	arrayEnd := strings.Index(analysis[start:], "]")
-	if arrayEnd == -1 {
-		return ""
-	}
-
-	tagStr := analysis[start : start+arrayEnd]
-	tagStr = strings.ReplaceAll(tagStr, `"`, "")
-	tagStr = strings.ReplaceAll(tagStr, " ", "")
-
-	if tagStr == "" {
-		return ""
-	}
-
-	tagList := strings.Split(tagStr, ",")
-
-	// Format ALL tags for reader modal in a single container
-	tagsToShow := []string{}
-	for _, tag := range tagList {
-		if tag != "" {
-			tagsToShow = append(tagsToShow, tag)
+		if entity = strings.TrimSpace(entity); entity != "" {
+			shown = append(shown, entity)
 		}
 	}
-
-	if len(tagsToShow) == 0 {
+	if len(shown) == 0 {
 		return ""
 	}
-
-	// Join all tags with separator (no container for clean look)
-	tagString := strings.Join(tagsToShow, " • ")
-	return tagStyle.Render(tagString)
+	return lipgloss.NewStyle().Foreground(CleanCyberTheme.Purple).Render(strings.Join(shown, " • "))
 }
 
 // renderReaderContent renders the article reader in the content pane (right side)
@@ -739,6 +855,9 @@ func renderReaderContent(m Model, width, height int, theme StyleTheme) string {
 
 	// Article position indicator
 	positionText := fmt.Sprintf("ARTICLE %d of %d", m.cursor+1, len(m.items))
+	if m.viewport.TotalLineCount() > m.viewport.Height {
+		positionText += fmt.Sprintf(" · %d%%", int(m.viewport.ScrollPercent()*100))
+	}
 	positionStyle := lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
 	content.WriteString(positionStyle.Render(positionText))
 	content.WriteString("\n\n")
@@ -749,27 +868,15 @@ func renderReaderContent(m Model, width, height int, theme StyleTheme) string {
 
 	if item.Favorited {
 		priorityDot = "♥"
-		dotColor = theme.VibrantPurple
+		dotColor = theme.Favorite
 	} else {
-		switch item.Priority {
-		case "high":
-			priorityDot = "●"
-			dotColor = theme.Red
-		case "medium":
-			priorityDot = "●"
-			dotColor = theme.Orange
-		case "low":
-			priorityDot = "●"
-			dotColor = theme.Cyan
-		default:
-			priorityDot = "●"
-			dotColor = theme.Gray
-		}
+		priorityDot = "●"
+		dotColor = theme.PriorityColor(item.Priority)
 	}
 
 	priorityDotRendered := lipgloss.NewStyle().Foreground(dotColor).Render(priorityDot)
 	titleStyle := lipgloss.NewStyle().Foreground(theme.White).Bold(true)
-	titleText := titleStyle.Render(item.Title)
+	titleText := m.titleLink(item, titleStyle.Render(item.Title))
 
 	// Build metadata to go on same line as title
 	timeAgo := formatTime(time.Since(item.Published))
@@ -781,6 +888,10 @@ func renderReaderContent(m Model, width, height int, theme StyleTheme) string {
 		metaParts = append(metaParts, metaStyle.Render(item.SourceName))
 	}
 
+	if byline := itemByline(item); byline != "" {
+		metaParts = append(metaParts, metaStyle.Render(byline))
+	}
+
 	if item.SourceType == "rss" {
 		domain := extractDomain(item.URL)
 		metaParts = append(metaParts, metaStyle.Render(domain))
@@ -788,14 +899,24 @@ func renderReaderContent(m Model, width, height int, theme StyleTheme) string {
 
 	metaParts = append(metaParts, metaStyle.Render(timeAgo))
 
+	if isArxiv(item.URL) {
+		metaParts = append(metaParts, lipgloss.NewStyle().Foreground(theme.Orange).Render("arXiv"))
+	} else if isPaper(item) {
+		metaParts = append(metaParts, lipgloss.NewStyle().Foreground(theme.Orange).Render("PDF"))
+	}
+
+	if userTags := renderUserTags(item.UserTags, theme); userTags != "" {
+		metaParts = append(metaParts, userTags)
+	}
+
 	if item.SourceType == "reddit" {
-		redditMetrics := extractRedditMetrics(item.Analysis)
-		if redditMetrics.score > 0 {
+		redditMetrics := item.ParsedAnalysis().Metrics
+		if redditMetrics.Score > 0 {
 			metaParts = append(metaParts,
-				lipgloss.NewStyle().Foreground(theme.Orange).Render(fmt.Sprintf("↑%d", redditMetrics.score)))
+				lipgloss.NewStyle().Foreground(theme.Orange).Render(fmt.Sprintf("↑%d", redditMetrics.Score)))
 		}
-		if redditMetrics.numComments > 0 {
-			metaParts = append(metaParts, metaStyle.Render(fmt.Sprintf("%dc", redditMetrics.numComments)))
+		if redditMetrics.NumComments > 0 {
+			metaParts = append(metaParts, metaStyle.Render(fmt.Sprintf("%dc", redditMetrics.NumComments)))
 		}
 	}
 
@@ -807,7 +928,7 @@ func renderReaderContent(m Model, width, height int, theme StyleTheme) string {
 	content.WriteString(priorityDotRendered + " " + titleText + leftBracket + metadataStr + rightBracket)
 
 	// Tags on their own line, indented to align with title
-	tags := extractAllTags(item.Analysis)
+	tags := formatEntities(item.ParsedAnalysis().Entities, 0)
 	if tags != "" {
 		content.WriteString("\n")
 		content.WriteString("  " + tags) // Two spaces to align with title after "● "
@@ -823,7 +944,7 @@ func renderReaderContent(m Model, width, height int, theme StyleTheme) string {
 	content.WriteString("\n\n")
 
 	// Article content from viewport
-	content.WriteString(m.viewport.View())
+	content.WriteString(viewportWithScrollbar(m.viewport, theme))
 
 	return content.String()
 }
@@ -834,17 +955,3 @@ func max(a, b int) int {
 	}
 	return b
 }
-
-// getMemoryUsage returns current memory usage
-func getMemoryUsage() string {
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-
-	// Convert to MB
-	allocMB := float64(m.Alloc) / 1024 / 1024
-
-	if allocMB < 10 {
-		return fmt.Sprintf("%.1f MB", allocMB)
-	}
-	return fmt.Sprintf("%.0f MB", allocMB)
-}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// finderItemsMsg carries every item (ignoring view filters) for the title finder
+type finderItemsMsg struct {
+	query string
+	items []db.ContentItem
+	err   error
+}
+
+// loadFinderItems fetches all items in the current archive scope. Remote mode
+// already holds them in itemsCache; local mode reads them from the database.
+func loadFinderItems(m Model, query string) tea.Cmd {
+	if m.remoteURL != "" {
+		items := m.itemsCache
+		return func() tea.Msg {
+			return finderItemsMsg{query: query, items: items}
+		}
+	}
+	showArchived := m.showArchived
+	return func() tea.Msg {
+		items, err := db.GetAllContent(showArchived)
+		return finderItemsMsg{query: query, items: items, err: err}
+	}
+}
+
+// finderEntries turns items into palette rows labelled by title
+func finderEntries(items []db.ContentItem) []paletteEntry {
+	entries := make([]paletteEntry, 0, len(items))
+	for _, item := range items {
+		if item.Title == "" {
+			continue
+		}
+		hint := item.SourceName
+		if item.Priority != "" {
+			hint = strings.ToUpper(item.Priority) + " · " + hint
+		}
+		entries = append(entries, paletteEntry{kind: "item", label: item.Title, hint: hint, itemID: item.ID})
+	}
+	return entries
+}
+
+// revealItem relaxes the view filters just enough for item to be listed,
+// leaving them alone when it already passes. The view's :filter expression
+// and a running saved search are dropped only if they would still hide it.
+func (m *Model) revealItem(item db.ContentItem) {
+	if len(applyFiltersClientSide([]db.ContentItem{item}, *m)) > 0 {
+		return
+	}
+
+	if item.Priority == "" {
+		m.priority = "unprioritized"
+		m.showUnprioritized = true
+	} else if m.priority != "all" && m.priority != item.Priority {
+		m.priority = "all"
+	}
+	if item.Read {
+		m.showAll = true
+	}
+	m.showInteresting = false
+	m.filterType = "all"
+	m.filterCategory = ""
+	m.filterSource = ""
+	m.filterTag = ""
+	m.filterAuthor = ""
+	m.filterSince = time.Time{}
+	m.filterUntil = time.Time{}
+	if expr := m.filterExpr(); expr != nil && !expr.Match(item, time.Now()) {
+		m.setFilterExpr("")
+	}
+	if search := m.searchExpr(); search != nil && !search.Match(item, time.Now()) {
+		m.search = runningSearch{}
+	}
+	m.updateSourcesViewport()
+}
+
+// jumpToItem refetches with the current filters and moves the cursor to id
+func (m *Model) jumpToItem(id string) tea.Cmd {
+	fetch := m.fetchList(false)
+	return func() tea.Msg {
+		msg := fetch()
+		if loaded, ok := msg.(itemsLoadedMsg); ok {
+			loaded.jumpToID = id
+			return loaded
+		}
+		return msg
+	}
+}
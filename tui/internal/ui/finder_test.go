@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestRevealItem_RelaxesFiltersToShowItem verifies the finder widens filters for a hidden item.
+// BREAKS: If filters aren't relaxed, jumping to a read LOW item lands on an empty list.
+func TestRevealItem_RelaxesFiltersToShowItem(t *testing.T) {
+	m := Model{priority: "high", filterType: "rss", filterSource: "other"}
+	item := db.ContentItem{ID: "x", Priority: "low", Read: true, SourceType: "reddit", SourceID: "src"}
+
+	m.revealItem(item)
+	if len(applyFiltersClientSide([]db.ContentItem{item}, m)) != 1 {
+		t.Errorf("Expected item visible after reveal, filters: priority=%s showAll=%v type=%s source=%s",
+			m.priority, m.showAll, m.filterType, m.filterSource)
+	}
+}
+
+// TestRevealItem_KeepsFiltersWhenVisible verifies filters are untouched when the item already shows.
+// BREAKS: If filters always reset, finding an item throws away the user's current view.
+func TestRevealItem_KeepsFiltersWhenVisible(t *testing.T) {
+	m := Model{priority: "high", filterType: "all"}
+	m.revealItem(db.ContentItem{ID: "x", Priority: "high"})
+	if m.priority != "high" || m.showAll {
+		t.Errorf("Expected filters unchanged, got priority=%s showAll=%v", m.priority, m.showAll)
+	}
+}
+
+// TestFinderSelect_MovesCursorToListedItem verifies picking a listed title jumps straight to it.
+// BREAKS: If the cursor isn't moved, the finder closes without going anywhere.
+func TestFinderSelect_MovesCursorToListedItem(t *testing.T) {
+	items := []db.ContentItem{{ID: "a", Title: "Alpha"}, {ID: "b", Title: "Beta"}}
+	m := Model{view: "reader", items: items, finderItems: items}
+
+	updated, _ := m.Update(paletteSelectedMsg{entry: paletteEntry{kind: "item", itemID: "b"}})
+	m = updated.(Model)
+	if m.cursor != 1 || m.view != "list" {
+		t.Errorf("Expected cursor on Beta in list view, got cursor=%d view=%s", m.cursor, m.view)
+	}
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// setFocus handles the terminal reporting focus lost or regained. While
+// unfocused the spinner and link checks stop and auto-refresh drops to
+// [refresh] blurred; coming back refreshes straight away and reports what
+// arrived in the meantime.
+func (m *Model) setFocus(focused bool) tea.Cmd {
+	if focused != m.blurred {
+		return nil // No change, e.g. the focus report some terminals send at startup
+	}
+	if !focused {
+		before := m.currentRefreshInterval()
+		m.blurred = true
+		m.awaySeen = make(map[string]bool, len(m.listBase))
+		for _, item := range m.listBase {
+			m.awaySeen[item.ID] = true
+		}
+		if m.currentRefreshInterval() == before {
+			return nil // The running timer already has the right interval
+		}
+		return m.scheduleRefresh()
+	}
+
+	m.blurred = false
+	var cmds []tea.Cmd
+	if m.refreshInterval > 0 {
+		cmds = append(cmds, m.refreshNow())
+	} else {
+		m.awaySeen = nil // No refresh coming to compare against
+		cmds = append(cmds, m.scheduleRefresh())
+	}
+	if m.syncing {
+		cmds = append(cmds, m.syncSpinner.Tick) // Stopped while unfocused
+	}
+	if m.checkLinks && !m.linkTicking {
+		m.linkTicking = true
+		cmds = append(cmds, linkCheckTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+// welcomeBack reports the items an auto-refresh found that weren't listed
+// when the terminal lost focus, once it has it again
+func (m *Model) welcomeBack(items []db.ContentItem) tea.Cmd {
+	if m.awaySeen == nil || m.blurred {
+		return nil
+	}
+	arrived := 0
+	for _, item := range items {
+		if !m.awaySeen[item.ID] {
+			arrived++
+		}
+	}
+	m.awaySeen = nil
+	if arrived == 0 {
+		return m.notify(toastInfo, "No new items while you were away", 3*time.Second)
+	}
+	return m.notify(toastSuccess, fmt.Sprintf("%d new item%s while you were away", arrived, pluralize(arrived)), 5*time.Second)
+}
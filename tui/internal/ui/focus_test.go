@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestFocus_PausesWhileUnfocusedAndWelcomesBack verifies losing focus stops the spinner, link checks, and refresh, and regaining it refreshes and reports arrivals.
+// BREAKS: Idle tmux panes keep polling the daemon, or return without saying anything arrived.
+func TestFocus_PausesWhileUnfocusedAndWelcomesBack(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "a", Title: "A"}})
+	m.listBase = m.items
+	m.refreshInterval = time.Minute
+	m.checkLinks, m.linkTicking = true, true
+	m.syncing = true
+	m.syncSpinner = spinner.New()
+
+	updated, cmd := m.Update(tea.BlurMsg{})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("Expected no timer while unfocused with the default [refresh] blurred = 0")
+	}
+	if _, cmd = m.Update(spinner.TickMsg{}); cmd != nil {
+		t.Error("Expected the spinner to stop while unfocused")
+	}
+	updated, cmd = m.Update(linkCheckTickMsg{})
+	m = updated.(Model)
+	if cmd != nil || m.linkTicking {
+		t.Error("Expected link checks to stop while unfocused")
+	}
+
+	updated, cmd = m.Update(tea.FocusMsg{})
+	m = updated.(Model)
+	if cmd == nil || !m.linkTicking {
+		t.Fatal("Expected focus to refresh and restart link checks")
+	}
+
+	updated, _ = m.Update(itemsLoadedMsg{items: []db.ContentItem{{ID: "a"}, {ID: "b"}, {ID: "c"}}, isAutoRefresh: true})
+	m = updated.(Model)
+	if got := lastToast(m); got != "2 new items while you were away" {
+		t.Errorf("Expected the welcome-back toast, got %q", got)
+	}
+	if m.awaySeen != nil {
+		t.Error("Expected the welcome-back toast only once")
+	}
+}
+
+// TestFocus_RepeatedFocusIgnored verifies a focus report while already focused changes nothing.
+// BREAKS: Terminals that report focus at startup would trigger a refresh on launch.
+func TestFocus_RepeatedFocusIgnored(t *testing.T) {
+	m := testModel()
+	m.refreshInterval = time.Minute
+	gen := m.refreshGen
+	updated, cmd := m.Update(tea.FocusMsg{})
+	if cmd != nil || updated.(Model).refreshGen != gen {
+		t.Error("Expected focus while focused to leave the timer alone")
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// maxGoalStreak is how far back streaks are counted
+const maxGoalStreak = 365
+
+// readingGoal is the daily goal from [tui] goal / :set goal: clear every
+// unread HIGH item, or read a number of articles. The zero value is no goal.
+type readingGoal struct {
+	high  bool
+	count int
+}
+
+// parseGoal parses a goal setting: "high", a daily article count like "10"
+// or "10/day", or "off"
+func parseGoal(value string) (readingGoal, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "off", "none", "false":
+		return readingGoal{}, nil
+	case "high":
+		return readingGoal{high: true}, nil
+	}
+	count, err := strconv.Atoi(strings.TrimSuffix(value, "/day"))
+	if err != nil || count < 1 {
+		return readingGoal{}, fmt.Errorf("expected high, a daily article count like 10, or off")
+	}
+	return readingGoal{count: count}, nil
+}
+
+func (g readingGoal) set() bool {
+	return g.high || g.count > 0
+}
+
+// String is the goal as written to config
+func (g readingGoal) String() string {
+	switch {
+	case g.high:
+		return "high"
+	case g.count > 0:
+		return strconv.Itoa(g.count)
+	default:
+		return "off"
+	}
+}
+
+// goalStatusMsg is today's progress toward the goal and the current streak
+type goalStatusMsg struct {
+	goal     readingGoal
+	progress int
+	target   int
+	met      bool
+	streak   int // Consecutive days met, ending today (or yesterday while today is open)
+	err      error
+}
+
+// loadGoalStatus computes the goal's progress from the reading history. A
+// count goal is met by distinct articles read in the reader; the HIGH goal
+// is met once no unread HIGH item is left, which is remembered per day since
+// past backlogs can't be reconstructed. Remote mode passes its cached items;
+// locally they are read from the database.
+func loadGoalStatus(goal readingGoal, cached []db.ContentItem, remote bool) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		entries, err := db.GetHistory(today.AddDate(0, 0, -maxGoalStreak))
+		if err != nil {
+			return goalStatusMsg{goal: goal, err: err}
+		}
+		days := db.SummarizeHistory(entries)
+		status := goalStatusMsg{goal: goal}
+		met := map[string]bool{}
+
+		if goal.count > 0 {
+			for _, day := range days {
+				if day.Articles >= goal.count {
+					met[dayKey(day.Day)] = true
+				}
+				if day.Day.Equal(today) {
+					status.progress = day.Articles
+				}
+			}
+			status.target = goal.count
+		} else {
+			items := cached
+			if !remote {
+				if items, err = db.GetAllContent(false); err != nil {
+					return goalStatusMsg{goal: goal, err: err}
+				}
+			}
+			readHigh := map[string]bool{}
+			if len(days) > 0 && days[0].Day.Equal(today) {
+				for _, entry := range days[0].Entries {
+					if entry.Priority == "high" {
+						readHigh[entry.ContentID] = true
+					}
+				}
+			}
+			remaining := countUnreadHigh(items, now)
+			status.progress = len(readHigh)
+			status.target = len(readHigh) + remaining
+			// Before the first remote sync nothing is known to be cleared
+			if remaining == 0 && (!remote || cached != nil) {
+				if err := db.MarkGoalMet("high", now); err != nil {
+					return goalStatusMsg{goal: goal, err: err}
+				}
+			}
+			if met, err = db.GetGoalDays("high", today.AddDate(0, 0, -maxGoalStreak)); err != nil {
+				return goalStatusMsg{goal: goal, err: err}
+			}
+		}
+
+		status.met = met[dayKey(today)]
+		status.streak = goalStreak(met, today)
+		return status
+	}
+}
+
+// countUnreadHigh counts the HIGH items still waiting to be read
+func countUnreadHigh(items []db.ContentItem, now time.Time) int {
+	count := 0
+	for _, item := range items {
+		if item.Priority == "high" && !item.Read && !item.SnoozedUntil.After(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// goalStreak counts consecutive met days back from today. An unmet today
+// doesn't break the streak yet: it counts from yesterday.
+func goalStreak(met map[string]bool, today time.Time) int {
+	day := today
+	if !met[dayKey(day)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for met[dayKey(day)] && streak < maxGoalStreak {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// dayKey formats a local date the way goal days are stored
+func dayKey(day time.Time) string {
+	return day.Format("2006-01-02")
+}
+
+// refreshGoal reloads the goal status when a goal is set
+func (m Model) refreshGoal() tea.Cmd {
+	if !m.goal.set() {
+		return nil
+	}
+	return loadGoalStatus(m.goal, m.itemsCache, m.remoteURL != "")
+}
+
+// goalStats is the SYSTEM section's goal line, empty without a goal
+func goalStats(m Model, theme StyleTheme) []string {
+	if !m.goal.set() {
+		return nil
+	}
+	status := m.goalStatus
+	if status.goal != m.goal {
+		return []string{"Goal:        " + lipgloss.NewStyle().Foreground(theme.Gray).Render("–")}
+	}
+
+	progress := fmt.Sprintf("%d/%d", status.progress, status.target)
+	if m.goal.high {
+		progress += " high"
+	}
+	if status.met {
+		progress = lipgloss.NewStyle().Foreground(theme.Green).Render("✓ " + progress)
+	} else {
+		progress = lipgloss.NewStyle().Foreground(theme.Cyan).Render(progress)
+	}
+	if status.streak > 0 {
+		progress += lipgloss.NewStyle().Foreground(theme.Orange).Render(fmt.Sprintf(" · %dd streak", status.streak))
+	}
+	return []string{"Goal:        " + progress}
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseGoal verifies goal settings parse to the HIGH goal, a daily count, or none.
+// BREAKS: If "10/day" or "off" are rejected, config values from the README fail silently.
+func TestParseGoal(t *testing.T) {
+	tests := []struct {
+		value string
+		want  readingGoal
+	}{
+		{"high", readingGoal{high: true}},
+		{"10", readingGoal{count: 10}},
+		{"10/day", readingGoal{count: 10}},
+		{"off", readingGoal{}},
+		{"", readingGoal{}},
+	}
+	for _, tt := range tests {
+		got, err := parseGoal(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("parseGoal(%q) = %+v, %v; expected %+v", tt.value, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"0", "lots", "-3"} {
+		if _, err := parseGoal(bad); err == nil {
+			t.Errorf("parseGoal(%q): expected an error", bad)
+		}
+	}
+}
+
+// TestGoalStreak_CountsBackFromYesterdayUntilMet verifies an open today doesn't break the streak.
+// BREAKS: If today must already be met, every morning shows the streak reset to zero.
+func TestGoalStreak_CountsBackFromYesterdayUntilMet(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	met := map[string]bool{"2026-03-09": true, "2026-03-08": true, "2026-03-06": true}
+
+	if got := goalStreak(met, today); got != 2 {
+		t.Errorf("Expected 2 days with today open, got %d", got)
+	}
+	met["2026-03-10"] = true
+	if got := goalStreak(met, today); got != 3 {
+		t.Errorf("Expected 3 days once today is met, got %d", got)
+	}
+	if got := goalStreak(map[string]bool{"2026-03-08": true}, today); got != 0 {
+		t.Errorf("Expected a missed yesterday to end the streak, got %d", got)
+	}
+}
+
+// TestGoalStats_ShowsProgressAndStreak verifies the sidebar line once the status for the current goal arrives.
+// BREAKS: If a stale status for an old goal is shown, :set goal appears not to take effect.
+func TestGoalStats_ShowsProgressAndStreak(t *testing.T) {
+	m := testModel()
+	if lines := goalStats(m, CleanCyberTheme); lines != nil {
+		t.Fatalf("Expected no goal line without a goal, got %v", lines)
+	}
+
+	m.goal = readingGoal{count: 10}
+	if lines := goalStats(m, CleanCyberTheme); !strings.Contains(lines[0], "–") {
+		t.Errorf("Expected a placeholder while loading, got %v", lines)
+	}
+
+	updated, _ := m.Update(goalStatusMsg{goal: m.goal, progress: 4, target: 10, streak: 3})
+	m = updated.(Model)
+	line := goalStats(m, CleanCyberTheme)[0]
+	if !strings.Contains(line, "4/10") || !strings.Contains(line, "3d streak") || strings.Contains(line, "✓") {
+		t.Errorf("Expected open progress with the streak, got %q", line)
+	}
+
+	m.goalStatus.met = true
+	if line := goalStats(m, CleanCyberTheme)[0]; !strings.Contains(line, "✓ 4/10") {
+		t.Errorf("Expected a met goal checked off, got %q", line)
+	}
+}
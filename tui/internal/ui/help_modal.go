@@ -3,21 +3,36 @@ package ui
 import (
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
-// HelpModal represents the help/keyboard shortcuts modal
+// HelpModal shows every key and command, generated from the key table, the
+// user's [keys], and the command registry; / searches it
 type HelpModal struct {
-	Modal  // Embed base modal
-	width  int
-	height int
+	Modal     // Embed base modal
+	width     int
+	height    int
+	rows      []helpRow
+	search    textinput.Model
+	searching bool // The search box has focus
+	viewport  viewport.Model
 }
 
 // NewHelpModal creates a new HelpModal instance
 func NewHelpModal() HelpModal {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = "search keys and commands"
+	ti.CharLimit = 60
+
 	return HelpModal{
-		Modal: NewModal("", 80, 30), // Will be sized dynamically
+		Modal:    NewModal("", 80, 30), // Will be sized dynamically
+		search:   ti,
+		viewport: viewport.New(76, 22),
 	}
 }
 
@@ -44,6 +59,22 @@ func (m *HelpModal) SetSize(width, height int) {
 	m.height = modalHeight
 	m.Modal.width = modalWidth
 	m.Modal.height = modalHeight
+	// Title, intro, and footer take six of the lines inside the padding
+	m.viewport.Width = max(1, modalWidth-4)
+	m.viewport.Height = max(1, modalHeight-8)
+	m.search.Width = modalWidth - 8
+	m.render()
+}
+
+// Open shows the help for rows with the search cleared
+func (m *HelpModal) Open(rows []helpRow) {
+	m.rows = rows
+	m.searching = false
+	m.search.Blur()
+	m.search.SetValue("")
+	m.render()
+	m.viewport.GotoTop()
+	m.Show()
 }
 
 // Update handles input for the help modal
@@ -54,10 +85,55 @@ func (m HelpModal) Update(msg tea.Msg) (HelpModal, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.searching {
+			switch msg.String() {
+			case "esc":
+				// Drop the search, stay in help
+				m.searching = false
+				m.search.Blur()
+				m.search.SetValue("")
+			case "enter":
+				// Keep the matches and scroll them
+				m.searching = false
+				m.search.Blur()
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.search, cmd = m.search.Update(msg)
+				m.render()
+				m.viewport.GotoTop()
+				return m, cmd
+			}
+			m.render()
+			return m, nil
+		}
+
 		switch msg.String() {
-		case "esc", "q", "?":
+		case "/":
+			m.searching = true
+			return m, m.search.Focus()
+		case "esc":
+			// First Esc clears a kept search
+			if m.search.Value() != "" {
+				m.search.SetValue("")
+				m.render()
+				return m, nil
+			}
 			m.Hide()
-			return m, nil
+		case "q", "?":
+			m.Hide()
+		case "j", "down":
+			m.viewport.ScrollDown(1)
+		case "k", "up":
+			m.viewport.ScrollUp(1)
+		case " ", "pgdown", "ctrl+d":
+			m.viewport.HalfPageDown()
+		case "b", "pgup", "ctrl+u":
+			m.viewport.HalfPageUp()
+		case "g", "home":
+			m.viewport.GotoTop()
+		case "G", "end":
+			m.viewport.GotoBottom()
 		}
 
 	case tea.WindowSizeMsg:
@@ -67,6 +143,89 @@ func (m HelpModal) Update(msg tea.Msg) (HelpModal, tea.Cmd) {
 	return m, nil
 }
 
+// matches returns the rows matching the search: every word must appear in
+// the row's section, key, or description
+func (m HelpModal) matches() []helpRow {
+	terms := strings.Fields(strings.ToLower(m.search.Value()))
+	if len(terms) == 0 {
+		return m.rows
+	}
+	var matched []helpRow
+	for _, row := range m.rows {
+		text := strings.ToLower(row.section + " " + row.key + " " + row.desc)
+		all := true
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, row)
+		}
+	}
+	return matched
+}
+
+// render lays the matching rows out under section headers, two to a line
+// when the modal is wide enough
+func (m *HelpModal) render() {
+	theme := CleanCyberTheme
+	sectionStyle := lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Purple).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(theme.White)
+	width := m.viewport.Width
+
+	rows := m.matches()
+	if len(rows) == 0 {
+		m.viewport.SetContent(lipgloss.NewStyle().Foreground(theme.Gray).Italic(true).
+			Render("  No keys or commands match \"" + m.search.Value() + "\""))
+		return
+	}
+
+	var content strings.Builder
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].section == rows[start].section {
+			end++
+		}
+		section := rows[start:end]
+
+		if start > 0 {
+			content.WriteString("\n")
+		}
+		header := "── " + section[0].section + " "
+		content.WriteString(sectionStyle.Render(header + strings.Repeat("─", max(0, width-4-lipgloss.Width(header)))))
+		content.WriteString("\n")
+
+		// Keys line up within a section; long command lines get one column
+		keyWidth := 12
+		for _, row := range section {
+			keyWidth = max(keyWidth, lipgloss.Width(row.key)+2)
+		}
+		columns := 1
+		if width > 70 && 2*(keyWidth+24) <= width {
+			columns = 2
+		}
+		colWidth := width/columns - 2
+		formatRow := func(row helpRow) string {
+			key := keyStyle.Render(row.key) + strings.Repeat(" ", max(0, keyWidth-lipgloss.Width(row.key)))
+			desc := ansi.Truncate(row.desc, max(0, colWidth-keyWidth), "…")
+			cell := "  " + key + descStyle.Render(desc)
+			return cell + strings.Repeat(" ", max(0, colWidth+2-lipgloss.Width(cell)))
+		}
+		for i := 0; i < len(section); i += columns {
+			line := formatRow(section[i])
+			if columns == 2 && i+1 < len(section) {
+				line += formatRow(section[i+1])
+			}
+			content.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+		start = end
+	}
+	m.viewport.SetContent(strings.TrimRight(content.String(), "\n"))
+}
+
 // View renders the help modal
 func (m HelpModal) View(theme StyleTheme) string {
 	if !m.visible {
@@ -91,128 +250,34 @@ func (m HelpModal) View(theme StyleTheme) string {
 	content.WriteString(titleStyle.Render(centeredTitle))
 	content.WriteString("\n\n")
 
-	// Add intro text about command mode
-	introStyle := lipgloss.NewStyle().
-		Foreground(theme.Gray).
-		Italic(true)
-	introText := "Press : to enter command mode. Use Tab to complete commands and fabric patterns."
-	introPadding := (m.width - 4 - lipgloss.Width(introText)) / 2
-	if introPadding < 0 {
-		introPadding = 0
-	}
-	centeredIntro := strings.Repeat(" ", introPadding) + introText
-	content.WriteString(introStyle.Render(centeredIntro))
-	content.WriteString("\n\n")
-
-	// Section header style (like in reader modal)
-	sectionStyle := lipgloss.NewStyle().
-		Foreground(theme.Cyan).
-		Bold(true)
-
-	// Command style
-	keyStyle := lipgloss.NewStyle().
-		Foreground(theme.Purple).
-		Bold(true)
-
-	descStyle := lipgloss.NewStyle().
-		Foreground(theme.White)
-
-	// Helper function to format a command row
-	formatCmd := func(key, desc string) string {
-		// Fixed width columns for alignment
-		keyCol := keyStyle.Render(key)
-		descCol := descStyle.Render(desc)
-		// Pad key column to fixed width
-		keyPadded := keyCol + strings.Repeat(" ", max(0, 12-lipgloss.Width(key)))
-		return "  " + keyPadded + descCol
-	}
-
-	// Helper function to format two-column layout (if width permits)
-	format2Col := func(key1, desc1, key2, desc2 string) string {
-		// Only use two columns if we have enough width (>70 chars)
-		if m.width > 70 {
-			col1 := formatCmd(key1, desc1)
-			// Calculate spacing for second column
-			col1Width := lipgloss.Width(col1)
-			spacing := max(2, (m.width/2)-col1Width)
-			col2 := formatCmd(key2, desc2)
-			return col1 + strings.Repeat(" ", spacing) + col2
+	// The search box replaces the intro while in use
+	if m.searching || m.search.Value() != "" {
+		content.WriteString(m.search.View())
+	} else {
+		introStyle := lipgloss.NewStyle().
+			Foreground(theme.Gray).
+			Italic(true)
+		introText := "Press : to enter command mode. Use Tab to complete commands and fabric patterns."
+		introPadding := (m.width - 4 - lipgloss.Width(introText)) / 2
+		if introPadding < 0 {
+			introPadding = 0
 		}
-		// Fall back to single column for narrow terminals
-		return formatCmd(key1, desc1) + "\n" + formatCmd(key2, desc2)
+		centeredIntro := strings.Repeat(" ", introPadding) + introText
+		content.WriteString(introStyle.Render(centeredIntro))
 	}
-
-	// Helper function to create section headers
-	sectionHeader := func(title string) string {
-		headerText := "── " + title + " "
-		remainingWidth := m.width - 8 - lipgloss.Width(headerText)
-		if remainingWidth < 0 {
-			remainingWidth = 0
-		}
-		return sectionStyle.Render(headerText + strings.Repeat("─", remainingWidth))
-	}
-
-	// NAVIGATION section
-	content.WriteString(sectionHeader("NAVIGATION"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("j/k", "Move up/down", "g/G", "Jump to top/bottom"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("Enter", "Read article", "q", "Quit/Back"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":", "Command mode", "?", "This help"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("S", "Source manager", "", ""))
 	content.WriteString("\n\n")
 
-	// FILTERS & SORTING section
-	content.WriteString(sectionHeader("FILTERS & SORTING"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("1/2/3/4", "Priority/Favorites", "0/i", "Unprioritized/Interesting"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("a/u/v", "All/Unread/Archived", "d/s", "Date sort/Sources"))
-	content.WriteString("\n\n")
-
-	// ARTICLE COMMANDS section
-	content.WriteString(sectionHeader("ARTICLE COMMANDS (:)"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":mark", "Toggle read", ":favorite", "Toggle star"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":up / +", "Upvote (feedback)", ":down / -", "Downvote (feedback)"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("i", "View upvoted items", ":open", "Open in browser"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":yank/:copy", "Copy URL/field", ":fabric <pattern>", "AI analysis"))
-	content.WriteString("\n\n")
-
-	// SOURCE COMMANDS section
-	content.WriteString(sectionHeader("SOURCE COMMANDS (:)"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":add/:remove", "Add/remove source", ":pause/:resume", "Pause/resume"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":edit <id> <name>", "Rename source", ":export sources", "Export OPML"))
-	content.WriteString("\n\n")
-
-	// MAINTENANCE COMMANDS section
-	content.WriteString(sectionHeader("MAINTENANCE (:)"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":unprioritized", "Count unprioritized", ":prune[!] [days]", "Delete old"))
-	content.WriteString("\n")
-	content.WriteString(format2Col(":context ...", "review/suggest/edit", ":audio", "Audio briefing"))
-	content.WriteString("\n\n")
-
-	// READER MODE section - Simplified
-	content.WriteString(sectionHeader("READER MODE"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("j/k", "Scroll up/down", "h/l", "Prev/Next article"))
-	content.WriteString("\n")
-	content.WriteString(format2Col("Space", "Page down", "ESC/q", "Back to list"))
+	content.WriteString(lipgloss.NewStyle().Width(m.viewport.Width).Height(m.viewport.Height).Render(m.viewport.View()))
 	content.WriteString("\n\n")
 
 	// Footer hint
 	footerStyle := lipgloss.NewStyle().
 		Foreground(theme.Gray).
 		Italic(true)
-	footerText := "Press ESC or ? to close"
+	footerText := "j/k scroll · / search · ESC or ? to close"
+	if m.searching {
+		footerText = "Enter keep matches · ESC clear search"
+	}
 	footerPadding := (m.width - 4 - lipgloss.Width(footerText)) / 2
 	if footerPadding < 0 {
 		footerPadding = 0
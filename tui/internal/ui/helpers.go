@@ -286,3 +286,22 @@ func wrapTextWithPrefix(text string, width int, firstPrefix, contPrefix string)
 
 	return strings.Join(lines, "\n")
 }
+
+// fuzzyMatch reports whether every character of pattern appears in s, in order
+// (case-insensitive), so "hnws" matches "Hacker News"
+func fuzzyMatch(pattern, s string) bool {
+	pattern = strings.ToLower(pattern)
+	s = strings.ToLower(s)
+
+	pi := 0
+	patternRunes := []rune(pattern)
+	for _, r := range s {
+		if pi == len(patternRunes) {
+			break
+		}
+		if r == patternRunes[pi] {
+			pi++
+		}
+	}
+	return pi == len(patternRunes)
+}
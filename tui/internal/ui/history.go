@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// minHistoryDwell is how long an article must stay open to count as read;
+// shorter visits are just passing through
+const minHistoryDwell = 2 * time.Second
+
+// historyLoadedMsg carries the reading sessions for :history
+type historyLoadedMsg struct {
+	days    int
+	entries []db.HistoryEntry
+	err     error
+}
+
+// historyRecordedMsg reports a saved reading session, which may move the goal
+type historyRecordedMsg struct{}
+
+// historyFailedMsg reports that a reading session couldn't be saved
+type historyFailedMsg struct {
+	err error
+}
+
+// trackReading notices when the article in the reader changes and records
+// the session that just ended. Called after every update.
+func (m *Model) trackReading(now time.Time) tea.Cmd {
+	current := ""
+	if m.view == "reader" && m.cursor < len(m.items) {
+		current = m.items[m.cursor].ID
+	}
+	if current == m.readingItem.ID {
+		return nil
+	}
+	record := m.endReading(now)
+	if current != "" {
+		m.readingItem = m.items[m.cursor]
+		m.readingSince = now
+	}
+	return record
+}
+
+// endReading closes the open reading session, returning the command that
+// saves it (nil when nothing was open long enough to count)
+func (m *Model) endReading(now time.Time) tea.Cmd {
+	item, since := m.readingItem, m.readingSince
+	m.readingItem = db.ContentItem{}
+	m.readingSince = time.Time{}
+	if item.ID == "" || now.Sub(since) < minHistoryDwell {
+		return nil
+	}
+	entry := db.HistoryEntry{
+		ContentID:  item.ID,
+		Title:      item.Title,
+		SourceName: item.SourceName,
+		URL:        item.URL,
+		Priority:   item.Priority,
+		ReadAt:     since,
+		Duration:   now.Sub(since),
+	}
+	return func() tea.Msg {
+		if err := db.RecordRead(entry); err != nil {
+			return historyFailedMsg{err: err}
+		}
+		return historyRecordedMsg{}
+	}
+}
+
+// loadHistory fetches the reading sessions of the last days days
+func loadHistory(days int) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -(days - 1))
+		entries, err := db.GetHistory(since)
+		return historyLoadedMsg{days: days, entries: entries, err: err}
+	}
+}
+
+// buildHistory renders the reading history as Markdown: a heading per day
+// with its totals, then what was read, newest first
+func buildHistory(days []db.HistoryDay, window int) string {
+	var doc strings.Builder
+	articles, total := 0, time.Duration(0)
+	for _, day := range days {
+		articles += day.Articles
+		total += day.Duration
+	}
+	fmt.Fprintf(&doc, "# Reading History: last %d days\n\n", window)
+	if len(days) == 0 {
+		doc.WriteString("Nothing read yet. Articles open in the reader for a few seconds are recorded here.\n")
+		return doc.String()
+	}
+	fmt.Fprintf(&doc, "%s in %s\n\n", pluralArticles(articles), formatReadingTime(total))
+
+	today := time.Now()
+	for _, day := range days {
+		fmt.Fprintf(&doc, "## %s · %s · %s\n\n", historyDayLabel(day.Day, today), pluralArticles(day.Articles), formatReadingTime(day.Duration))
+		for _, entry := range day.Entries {
+			line := fmt.Sprintf("- %s  %s  %s", entry.ReadAt.Local().Format("15:04"), formatReadingTime(entry.Duration), entry.Title)
+			if entry.SourceName != "" {
+				line += " — " + entry.SourceName
+			}
+			doc.WriteString(line + "\n")
+		}
+		doc.WriteString("\n")
+	}
+	return doc.String()
+}
+
+// historyDayLabel names a day relative to today
+func historyDayLabel(day, today time.Time) string {
+	y, mo, d := today.Date()
+	midnight := time.Date(y, mo, d, 0, 0, 0, 0, time.Local)
+	switch {
+	case day.Equal(midnight):
+		return "Today"
+	case day.Equal(midnight.AddDate(0, 0, -1)):
+		return "Yesterday"
+	default:
+		return day.Format("Mon Jan 2")
+	}
+}
+
+// pluralArticles formats an article count
+func pluralArticles(n int) string {
+	if n == 1 {
+		return "1 article"
+	}
+	return fmt.Sprintf("%d articles", n)
+}
+
+// formatReadingTime formats time spent reading: seconds under a minute,
+// then minutes, then hours and minutes
+func formatReadingTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestTrackReading_RecordsSessionsOnLeave verifies a session is saved when the reader moves on, but not for a glance.
+// BREAKS: If sessions end on every update or glances count, the history fills with noise or misses what was read.
+func TestTrackReading_RecordsSessionsOnLeave(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}})
+	start := time.Now()
+
+	m.view = "reader"
+	if cmd := m.trackReading(start); cmd != nil || m.readingItem.ID != "a" {
+		t.Fatalf("Expected a session opened for a, got %q", m.readingItem.ID)
+	}
+	if cmd := m.trackReading(start.Add(time.Minute)); cmd != nil {
+		t.Error("Expected nothing recorded while still reading")
+	}
+
+	// A glance at b after a minute on a: a is recorded, b is not
+	m.cursor = 1
+	if cmd := m.trackReading(start.Add(time.Minute)); cmd == nil || m.readingItem.ID != "b" {
+		t.Fatal("Expected a's session recorded on moving to b")
+	}
+	m.view = "list"
+	if cmd := m.trackReading(start.Add(time.Minute + time.Second)); cmd != nil || m.readingItem.ID != "" {
+		t.Error("Expected a one-second glance not recorded")
+	}
+}
+
+// TestBuildHistory_DaySummaries verifies each day heads its reads with article count and time.
+// BREAKS: If days lose their totals, :history can't answer "how much did I read".
+func TestBuildHistory_DaySummaries(t *testing.T) {
+	now := time.Now()
+	entries := []db.HistoryEntry{
+		{ContentID: "a", Title: "Rust 2.0", SourceName: "Blog", ReadAt: now, Duration: 5 * time.Minute},
+		{ContentID: "b", Title: "Go news", ReadAt: now.AddDate(0, 0, -1), Duration: 90 * time.Minute},
+	}
+	doc := buildHistory(db.SummarizeHistory(entries), 7)
+
+	for _, want := range []string{
+		"2 articles in 1h 35m",
+		"## Today · 1 article · 5m",
+		"## Yesterday · 1 article · 1h 30m",
+		"5m  Rust 2.0 — Blog",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected %q in:\n%s", want, doc)
+		}
+	}
+	if !strings.Contains(buildHistory(nil, 7), "Nothing read yet") {
+		t.Error("Expected an empty history to say so")
+	}
+}
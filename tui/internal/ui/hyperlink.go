@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// bareURLPattern finds http(s) URLs in rendered reader text; styling codes
+// wrap whole words, so a URL never has an escape sequence inside it
+var bareURLPattern = regexp.MustCompile(`https?://[^\s\x1b<>"]+`)
+
+// hyperlink wraps text in an OSC 8 hyperlink to target so supporting
+// terminals open it on Cmd/Ctrl-click. Others ignore the sequence. Anything
+// but a clean http(s) URL is left unlinked.
+func hyperlink(target, text string) string {
+	if !linkableURL(target) {
+		return text
+	}
+	return ansi.SetHyperlink(target) + text + ansi.ResetHyperlink()
+}
+
+// linkableURL reports whether target is safe to put in an escape sequence
+func linkableURL(target string) bool {
+	if strings.IndexFunc(target, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0 {
+		return false // A stray ESC or BEL would end the sequence early
+	}
+	u, err := url.Parse(target)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// linkifyURLs turns every bare URL in rendered text into a hyperlink
+func linkifyURLs(s string) string {
+	return bareURLPattern.ReplaceAllStringFunc(s, func(match string) string {
+		target := trimURLPunctuation(match)
+		return hyperlink(target, target) + match[len(target):]
+	})
+}
+
+// trimURLPunctuation drops sentence punctuation that follows a URL in prose,
+// keeping a closing paren that belongs to the URL itself
+func trimURLPunctuation(match string) string {
+	for match != "" {
+		last := match[len(match)-1]
+		switch {
+		case strings.IndexByte(".,;:!?'*_]", last) >= 0:
+			match = match[:len(match)-1]
+		case last == ')' && strings.Count(match, "(") < strings.Count(match, ")"):
+			match = match[:len(match)-1]
+		default:
+			return match
+		}
+	}
+	return match
+}
+
+// titleLink links a rendered title to its article when hyperlinks are on
+func (m Model) titleLink(item db.ContentItem, title string) string {
+	if !m.hyperlinks {
+		return title
+	}
+	return hyperlink(item.URL, title)
+}
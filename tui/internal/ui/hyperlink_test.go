@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestLinkifyURLs_WrapsBareURLs verifies reader URLs become OSC 8 links without the sentence punctuation.
+// BREAKS: If the trailing period is linked, Cmd-click opens a 404 for every URL that ends a sentence.
+func TestLinkifyURLs_WrapsBareURLs(t *testing.T) {
+	got := linkifyURLs("See https://example.com/a_(b). Or http://x.io/y, then.")
+	want := "See " + ansi.SetHyperlink("https://example.com/a_(b)") + "https://example.com/a_(b)" + ansi.ResetHyperlink() + ". Or " +
+		ansi.SetHyperlink("http://x.io/y") + "http://x.io/y" + ansi.ResetHyperlink() + ", then."
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if ansi.Strip(got) != "See https://example.com/a_(b). Or http://x.io/y, then." {
+		t.Errorf("Expected the visible text unchanged, got %q", ansi.Strip(got))
+	}
+}
+
+// TestHyperlink_RejectsUnsafeTargets verifies only clean http(s) URLs are put in an escape sequence.
+// BREAKS: If an ESC in a feed URL is passed through, the feed can inject terminal control sequences.
+func TestHyperlink_RejectsUnsafeTargets(t *testing.T) {
+	for _, target := range []string{"", "javascript:alert(1)", "file:///etc/passwd", "https://x.io/\x1b]0;pwned\x07", "/relative"} {
+		if got := hyperlink(target, "title"); got != "title" {
+			t.Errorf("Expected %q left unlinked, got %q", target, got)
+		}
+	}
+}
+
+// TestRenderContentList_TitlesAreHyperlinks verifies list titles link to the article only when hyperlinks are on.
+// BREAKS: If :set nohyperlinks is ignored, terminals that print OSC 8 literally show garbage around every title.
+func TestRenderContentList_TitlesAreHyperlinks(t *testing.T) {
+	m := Model{view: "list", width: 120, height: 30, theme: CleanCyberTheme, hyperlinks: true,
+		items: []db.ContentItem{{ID: "a", Title: "Working notes", URL: "https://example.com/notes"}}}
+
+	out := renderContentList(m, 100, 20, CleanCyberTheme)
+	if !strings.Contains(out, ansi.SetHyperlink("https://example.com/notes")) {
+		t.Errorf("Expected the title linked, got %q", out)
+	}
+
+	m.hyperlinks = false
+	if out := renderContentList(m, 100, 20, CleanCyberTheme); strings.Contains(out, "\x1b]8;") {
+		t.Errorf("Expected no hyperlinks when off, got %q", out)
+	}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/feeds"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// importState tracks a running :import, which adds one source at a time so
+// the status bar can show progress
+type importState struct {
+	from    string
+	queue   []feeds.Subscription // Still to add; the first is in flight
+	total   int
+	added   int
+	existed int      // Already sources, here or on the daemon
+	failed  []string // "url: reason" for the summary
+}
+
+// active reports whether an import is running
+func (s importState) active() bool {
+	return s.total > 0
+}
+
+// newSubscriptions drops the feeds that are already sources, comparing
+// canonical URLs so http/https and trailing slashes don't matter
+func newSubscriptions(subs []feeds.Subscription, sources []db.Source) []feeds.Subscription {
+	have := map[string]bool{}
+	for _, source := range sources {
+		have[canonicalFeedURL(source.URL)] = true
+	}
+	var fresh []feeds.Subscription
+	for _, sub := range subs {
+		key := canonicalFeedURL(sub.URL)
+		if have[key] {
+			continue
+		}
+		have[key] = true // Listed twice in the import
+		fresh = append(fresh, sub)
+	}
+	return fresh
+}
+
+// startImport reads the other reader's feeds
+func (m *Model) startImport(msg commands.ImportSourcesMsg) tea.Cmd {
+	if m.sourceImport.active() {
+		return m.notify(toastInfo, "An import is already running", 3*time.Second)
+	}
+	m.statusMessage = fmt.Sprintf("Reading %s feeds...", msg.From)
+	return operations.LoadSubscriptions(msg.From, msg.Path)
+}
+
+// subscriptionsLoaded queues the feeds that aren't sources yet and adds the first
+func (m *Model) subscriptionsLoaded(msg operations.SubscriptionsLoadedMsg) tea.Cmd {
+	m.statusMessage = ""
+	if msg.Error != nil {
+		return m.notify(toastError, fmt.Sprintf("Import failed: %v", msg.Error), 6*time.Second)
+	}
+	if len(msg.Subs) == 0 {
+		return m.notify(toastInfo, fmt.Sprintf("No feeds found in %s", msg.From), 4*time.Second)
+	}
+	queue := newSubscriptions(msg.Subs, m.sources)
+	if len(queue) == 0 {
+		return m.notify(toastInfo, fmt.Sprintf("All %d %s feeds are already sources", len(msg.Subs), msg.From), 4*time.Second)
+	}
+
+	m.sourceImport = importState{
+		from:    msg.From,
+		queue:   queue,
+		total:   len(msg.Subs),
+		existed: len(msg.Subs) - len(queue),
+	}
+	m.statusMessage = m.sourceImport.progress()
+	return operations.ImportSource(queue[0])
+}
+
+// progress describes the import for the status bar
+func (s importState) progress() string {
+	name := s.queue[0].Name
+	if name == "" {
+		name = s.queue[0].URL
+	}
+	return fmt.Sprintf("Importing %d/%d: %s", s.total-len(s.queue)+1, s.total, name)
+}
+
+// sourceImported records one result and moves on to the next feed
+func (m *Model) sourceImported(msg operations.SourceImportedMsg) tea.Cmd {
+	state := &m.sourceImport
+	if !state.active() {
+		return nil
+	}
+	switch {
+	case msg.Error != nil:
+		state.failed = append(state.failed, fmt.Sprintf("%s: %v", msg.Sub.URL, msg.Error))
+	case msg.Exists:
+		state.existed++
+	default:
+		state.added++
+	}
+	state.queue = state.queue[1:]
+
+	if msg.Fatal {
+		// The daemon is unreachable; the rest would fail the same way
+		for _, sub := range state.queue {
+			state.failed = append(state.failed, sub.URL+": not attempted")
+		}
+		state.queue = nil
+	}
+	if len(state.queue) > 0 {
+		m.statusMessage = state.progress()
+		return operations.ImportSource(state.queue[0])
+	}
+	return m.finishImport()
+}
+
+// finishImport reports the import, listing failures in a document, and reloads the sources
+func (m *Model) finishImport() tea.Cmd {
+	state := m.sourceImport
+	m.sourceImport = importState{}
+	m.statusMessage = ""
+
+	text := fmt.Sprintf("Imported %d sources from %s", state.added, state.from)
+	if state.existed > 0 {
+		text += fmt.Sprintf(", %d already there", state.existed)
+	}
+	cmds := []tea.Cmd{fetchSources(m.remoteURL), func() tea.Msg { return commands.RefreshMsg{PreserveCursor: true} }}
+	if len(state.failed) == 0 {
+		return tea.Batch(append(cmds, m.notify(toastSuccess, text, 5*time.Second))...)
+	}
+
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "# %d feeds not imported\n\n", len(state.failed))
+	for _, failure := range state.failed {
+		fmt.Fprintf(&doc, "- %s\n", failure)
+	}
+	m.digestModal.SetSize(m.width, m.height)
+	m.digestModal.OpenDocument("IMPORT ERRORS", "", doc.String())
+	text += fmt.Sprintf(", %d failed", len(state.failed))
+	return tea.Batch(append(cmds, m.notify(toastError, text, 8*time.Second))...)
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/feeds"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestImport_SkipsExistingAndSummarizes verifies feeds already subscribed are skipped and failures are listed at the end.
+// BREAKS: If existing sources are re-sent or failures are dropped, an import spams the daemon and hides what was lost.
+func TestImport_SkipsExistingAndSummarizes(t *testing.T) {
+	m := testModel()
+	m.digestModal = NewDigestModal()
+	m.sources = []db.Source{{ID: "1", URL: "https://example.com/feed/", Type: "rss"}}
+
+	subs := []feeds.Subscription{
+		{URL: "http://example.com/feed"}, // Already a source
+		{URL: "https://a.example/rss", Name: "A"},
+		{URL: "https://b.example/rss"},
+	}
+	cmd := m.subscriptionsLoaded(operations.SubscriptionsLoadedMsg{From: "newsboat", Subs: subs})
+	if cmd == nil || len(m.sourceImport.queue) != 2 || m.sourceImport.existed != 1 {
+		t.Fatalf("Expected two feeds queued and one skipped, got %+v", m.sourceImport)
+	}
+	if m.statusMessage != "Importing 2/3: A" {
+		t.Errorf("Expected progress in the status bar, got %q", m.statusMessage)
+	}
+
+	if m.sourceImported(operations.SourceImportedMsg{Sub: subs[1]}) == nil || m.statusMessage != "Importing 3/3: https://b.example/rss" {
+		t.Fatalf("Expected the next feed to start, status %q", m.statusMessage)
+	}
+	m.sourceImported(operations.SourceImportedMsg{Sub: subs[2], Error: errors.New("invalid feed")})
+	if m.sourceImport.active() || m.statusMessage != "" {
+		t.Error("Expected the import finished")
+	}
+	if !m.digestModal.IsVisible() || !strings.Contains(m.digestModal.markdown, "https://b.example/rss: invalid feed") {
+		t.Errorf("Expected the failure listed, got %q", m.digestModal.markdown)
+	}
+}
+
+// TestImport_StopsWhenDaemonDown verifies a fatal error stops the import instead of trying every remaining feed.
+// BREAKS: If fatal errors don't stop it, a stopped daemon means waiting through hundreds of failing requests.
+func TestImport_StopsWhenDaemonDown(t *testing.T) {
+	m := testModel()
+	m.digestModal = NewDigestModal()
+	subs := []feeds.Subscription{{URL: "https://a.example/rss"}, {URL: "https://b.example/rss"}, {URL: "https://c.example/rss"}}
+	m.subscriptionsLoaded(operations.SubscriptionsLoadedMsg{From: "miniflux", Subs: subs})
+
+	m.sourceImported(operations.SourceImportedMsg{Sub: subs[0], Error: errors.New("daemon down"), Fatal: true})
+	if m.sourceImport.active() {
+		t.Fatal("Expected the import stopped")
+	}
+	if !strings.Contains(m.digestModal.markdown, "3 feeds not imported") || !strings.Contains(m.digestModal.markdown, "c.example/rss: not attempted") {
+		t.Errorf("Expected the untried feeds listed, got %q", m.digestModal.markdown)
+	}
+}
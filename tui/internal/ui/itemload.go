@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// itemLoadedMsg carries an item loaded in full for the reader
+type itemLoadedMsg struct {
+	id   string
+	item db.ContentItem
+	err  error
+}
+
+// loadItem fetches an item with the content and analysis list queries leave
+// out: from the database, or from the daemon in remote mode
+func loadItem(ctx context.Context, remoteURL, id string) (db.ContentItem, error) {
+	if remoteURL == "" {
+		item, err := db.GetContentItemByID(id)
+		if err != nil {
+			return db.ContentItem{}, err
+		}
+		return *item, nil
+	}
+	client, err := api.NewClientWithURL(remoteURL)
+	if err != nil {
+		return db.ContentItem{}, err
+	}
+	apiItem, err := client.GetEntry(ctx, id)
+	if err != nil {
+		return db.ContentItem{}, err
+	}
+	return contentFromAPI(*apiItem), nil
+}
+
+// withBody fills a list item in from its full copy. The list's own fields
+// (read, tags, ...) stay, since they may be newer than the fetched copy.
+func withBody(item, full db.ContentItem) db.ContentItem {
+	item.Content = full.Content
+	item.ContentLength = full.Length()
+	item.SetAnalysis(full.Analysis)
+	item.Partial = false
+	return item
+}
+
+// hasPartial reports whether any item came without its content
+func hasPartial(items []db.ContentItem) bool {
+	for _, item := range items {
+		if item.Partial {
+			return true
+		}
+	}
+	return false
+}
+
+// fillItem swaps a loaded item's body into every list holding it
+func (m *Model) fillItem(full db.ContentItem) {
+	for _, list := range [][]db.ContentItem{m.items, m.listBase, m.itemsCache, m.archivedCache} {
+		for i := range list {
+			if list[i].ID == full.ID && list[i].Partial {
+				list[i] = withBody(list[i], full)
+			}
+		}
+	}
+}
+
+// currentItemFull is the item under the cursor with its content, loading it
+// first if the list only has its list-level columns
+func (m *Model) currentItemFull() (db.ContentItem, error) {
+	item := m.items[m.cursor]
+	if !item.Partial {
+		return item, nil
+	}
+	full, err := loadItem(context.Background(), m.remoteURL, item.ID)
+	if err != nil {
+		return item, err
+	}
+	m.fillItem(full)
+	return m.items[m.cursor], nil
+}
+
+// loadOpenItem fills in the reader's item when it came from a list query:
+// straight from the database, or from the daemon in the background. An item
+// that failed to load isn't retried until the cursor leaves it.
+func (m *Model) loadOpenItem() tea.Cmd {
+	if m.view != "reader" || m.cursor < 0 || m.cursor >= len(m.items) {
+		return nil
+	}
+	item := m.items[m.cursor]
+	if !item.Partial || m.loadingItem == item.ID {
+		return nil
+	}
+	m.loadingItem = item.ID
+
+	if m.remoteURL != "" {
+		remoteURL := m.remoteURL
+		return func() tea.Msg {
+			ctx, release := operations.Cancellable()
+			defer release()
+			full, err := loadItem(ctx, remoteURL, item.ID)
+			return itemLoadedMsg{id: item.ID, item: full, err: err}
+		}
+	}
+
+	full, err := loadItem(context.Background(), "", item.ID)
+	if err != nil {
+		return m.notify(toastError, fmt.Sprintf("Failed to load item: %v", err), 3*time.Second)
+	}
+	m.loadingItem = ""
+	m.fillItem(full)
+	m.updateReaderContent()
+	return nil
+}
+
+// handleItemLoaded fills in an item the daemon sent for the reader
+func (m *Model) handleItemLoaded(msg itemLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		if operations.IsCancelled(msg.err) {
+			return nil
+		}
+		return m.notify(toastError, fmt.Sprintf("Failed to load item: %v", msg.err), 3*time.Second)
+	}
+	if m.loadingItem == msg.id {
+		m.loadingItem = ""
+	}
+	m.fillItem(msg.item)
+	if m.view == "reader" && m.cursor < len(m.items) && m.items[m.cursor].ID == msg.id {
+		m.updateReaderContent()
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestOpenReader_LoadsContentLocally verifies opening the reader on a list item fills in the content the list query left out.
+// BREAKS: If the reader shows list items as they are, articles without a reading summary read "No content available".
+func TestOpenReader_LoadsContentLocally(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	dbPath := filepath.Join(dataHome, "prismis", "prismis.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE sources (id TEXT PRIMARY KEY, url TEXT, type TEXT, name TEXT)`,
+		`CREATE TABLE content (id TEXT PRIMARY KEY, source_id TEXT, title TEXT, url TEXT, summary TEXT, content TEXT,
+			analysis TEXT, priority TEXT, published_at TIMESTAMP, read BOOLEAN DEFAULT 0, favorited BOOLEAN DEFAULT 0,
+			archived_at TIMESTAMP)`,
+		`INSERT INTO sources VALUES ('s1', 'https://example.com/feed', 'rss', 'Example')`,
+		`INSERT INTO content (id, source_id, title, url, content, priority) VALUES ('a', 's1', 'Long read', 'https://example.com/a', 'The whole article body', 'high')`,
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	conn.Close()
+	db.CloseDB()
+	defer db.CloseDB()
+
+	m := testModel()
+	m.filterType, m.showAll = "all", true
+	msg := fetchItemsWithState(m, false)().(itemsLoadedMsg)
+	if msg.err != nil || len(msg.items) != 1 || !msg.items[0].Partial {
+		t.Fatalf("Expected one partial item from the list query, got %+v (%v)", msg.items, msg.err)
+	}
+	m.items = msg.items
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.view != "reader" {
+		t.Fatalf("Expected the reader open, got %s", m.view)
+	}
+	if m.items[0].Partial || m.items[0].Content != "The whole article body" {
+		t.Errorf("Expected the item loaded in full, got %+v", m.items[0])
+	}
+	if !strings.Contains(m.viewport.View(), "whole article body") {
+		t.Errorf("Expected the reader to show the content, got %q", m.viewport.View())
+	}
+}
+
+// TestOpenReader_LoadsContentRemotely verifies remote mode syncs the list without content and fetches it when the reader opens.
+// BREAKS: If the sync pulls every article's text, or the reader never asks for it, remote reading is slow or empty.
+func TestOpenReader_LoadsContentRemotely(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{ID: "a", Title: "Long read", Content: "The whole article body", Priority: "high"})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.filterType, m.showAll = "all", true
+	synced := fetchItemsRemote(m, nil, nil)
+	if synced.err != nil || len(synced.items) != 1 {
+		t.Fatalf("Expected one synced item, got %d (%v)", len(synced.items), synced.err)
+	}
+	if item := synced.items[0]; !item.Partial || item.Content != "" || item.Length() != len("The whole article body") {
+		t.Fatalf("Expected the sync to leave content out, got %+v", item)
+	}
+	m.items, m.itemsCache = synced.items, synced.allItems
+
+	m.view = "reader"
+	load := m.loadOpenItem()
+	if load == nil {
+		t.Fatal("Expected a load for the partial item")
+	}
+	if again := m.loadOpenItem(); again != nil {
+		t.Error("Expected no second load while the first is running")
+	}
+	m.updateReaderContent()
+	if !strings.Contains(m.viewport.View(), "Loading article") {
+		t.Errorf("Expected a loading note meanwhile, got %q", m.viewport.View())
+	}
+
+	updated, _ := m.Update(load())
+	m = updated.(Model)
+	if m.items[0].Partial || m.itemsCache[0].Content != "The whole article body" {
+		t.Errorf("Expected the item filled in everywhere, got %+v / %+v", m.items[0], m.itemsCache[0])
+	}
+	if !strings.Contains(m.viewport.View(), "whole article body") {
+		t.Errorf("Expected the reader to show the content, got %q", m.viewport.View())
+	}
+}
+
+// TestOpenReader_LoadsAnalysisRemotely verifies opening a synced item fetches that one entry with its content and analysis.
+// BREAKS: If the reader keeps the list's copy, remote articles open without their text or reading sections.
+func TestOpenReader_LoadsAnalysisRemotely(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{
+		ID:       "a",
+		Title:    "Long read",
+		Content:  "The whole article body",
+		Priority: "high",
+		Analysis: map[string]any{"reading_summary": "The gist", "quotes": []string{"A line worth keeping"}},
+	})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.filterType, m.showAll = "all", true
+	synced := fetchItemsRemote(m, nil, nil)
+	if synced.err != nil || len(synced.items) != 1 {
+		t.Fatalf("Expected one synced item, got %d (%v)", len(synced.items), synced.err)
+	}
+	m.items, m.itemsCache = synced.items, synced.allItems
+	m.view = "reader"
+
+	load := m.loadOpenItem()
+	if load == nil {
+		t.Fatal("Expected a load for the partial item")
+	}
+	updated, _ := m.Update(load())
+	m = updated.(Model)
+
+	if got := daemon.Requests(); got[len(got)-1] != "GET /api/entries/a" {
+		t.Errorf("Expected the reader to fetch only its entry, last request %v", got[len(got)-1])
+	}
+	item := m.items[0]
+	analysis := item.ParsedAnalysis()
+	if item.Content != "The whole article body" || analysis == nil || analysis.ReadingSummary != "The gist" ||
+		len(analysis.Quotes) != 1 || analysis.Quotes[0] != "A line worth keeping" {
+		t.Errorf("Expected the entry's content and analysis, got %q / %+v", item.Content, analysis)
+	}
+}
+
+// TestRemoteSync_FullWhenFilterReadsContent verifies a filter over article text syncs the items in full.
+// BREAKS: If the list sync stays content-free, /regex/ and text~ filters never match article bodies remotely.
+func TestRemoteSync_FullWhenFilterReadsContent(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{Title: "Release notes", Content: "Fixes CVE-2024-1234", Priority: "high"})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.filterType, m.showAll = "all", true
+	m.itemsCache = fetchItemsRemote(m, nil, nil).allItems
+	if !hasPartial(m.itemsCache) {
+		t.Fatal("Expected the plain sync to be partial")
+	}
+
+	m.setFilterExpr("/CVE-\\d+/")
+	if !m.needsContent() {
+		t.Fatal("Expected a regex filter to need content")
+	}
+	msg := fetchItemsRemote(m, nil, nil)
+	if msg.err != nil || len(msg.items) != 1 || msg.items[0].Partial {
+		t.Errorf("Expected the full item to match, got %+v (%v)", msg.items, msg.err)
+	}
+}
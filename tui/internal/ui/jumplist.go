@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxJumps bounds the jump list like vim's (100 entries)
+const maxJumps = 100
+
+// viewFilters is the filter state that decides which items are listed
+type viewFilters struct {
+	priority          string
+	showAll           bool
+	showArchived      bool
+	showInteresting   bool
+	showUnprioritized bool
+	sortNewest        bool
+	filterType        string
+	filterCategory    string
+	filterSource      string
+	filterTag         string
+	filterAuthor      string
+	filterSince       time.Time
+	filterUntil       time.Time
+	search            runningSearch
+}
+
+// jumpLocation is one place in the jump list: a view, its filters, and the
+// item being read (or under the cursor, for list locations)
+type jumpLocation struct {
+	view    string
+	itemID  string
+	filters viewFilters
+}
+
+// same reports whether two locations are the same jump. Cursor moves within
+// a list aren't jumps, so list locations ignore the item.
+func (l jumpLocation) same(other jumpLocation) bool {
+	if l.view == "list" && other.view == "list" {
+		return l.filters == other.filters
+	}
+	return l == other
+}
+
+// jumpList is vim's jump list: pos == len(entries) while at the live end
+type jumpList struct {
+	entries []jumpLocation
+	pos     int
+}
+
+// record adds loc as a place to come back to, dropping any forward history
+func (j jumpList) record(loc jumpLocation) jumpList {
+	entries := append([]jumpLocation{}, j.entries[:min(j.pos, len(j.entries))]...)
+	if len(entries) == 0 || !entries[len(entries)-1].same(loc) {
+		entries = append(entries, loc)
+	}
+	if len(entries) > maxJumps {
+		entries = entries[len(entries)-maxJumps:]
+	}
+	return jumpList{entries: entries, pos: len(entries)}
+}
+
+// back steps to the previous location. Leaving the live end records current
+// first so forward can return to it.
+func (j jumpList) back(current jumpLocation) (jumpList, jumpLocation, bool) {
+	if j.pos >= len(j.entries) {
+		j = j.record(current)
+		j.pos = len(j.entries) - 1
+	}
+	if j.pos <= 0 {
+		return j, jumpLocation{}, false
+	}
+	j.pos--
+	return j, j.entries[j.pos], true
+}
+
+// forward steps to the next location after a back
+func (j jumpList) forward() (jumpList, jumpLocation, bool) {
+	if j.pos >= len(j.entries)-1 {
+		return j, jumpLocation{}, false
+	}
+	j.pos++
+	return j, j.entries[j.pos], true
+}
+
+// canForward reports whether forward has somewhere to go
+func (j jumpList) canForward() bool {
+	return j.pos < len(j.entries)-1
+}
+
+// location captures where the model is now
+func (m Model) location() jumpLocation {
+	loc := jumpLocation{
+		view: m.view,
+		filters: viewFilters{
+			priority:          m.priority,
+			showAll:           m.showAll,
+			showArchived:      m.showArchived,
+			showInteresting:   m.showInteresting,
+			showUnprioritized: m.showUnprioritized,
+			sortNewest:        m.sortNewest,
+			filterType:        m.filterType,
+			filterCategory:    m.filterCategory,
+			filterSource:      m.filterSource,
+			filterTag:         m.filterTag,
+			filterAuthor:      m.filterAuthor,
+			filterSince:       m.filterSince,
+			filterUntil:       m.filterUntil,
+			search:            m.search,
+		},
+	}
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		loc.itemID = m.items[m.cursor].ID
+	}
+	return loc
+}
+
+// goTo restores loc: filters now, then the item (and reader) once reloaded
+func (m Model) goTo(loc jumpLocation) (Model, tea.Cmd) {
+	f := loc.filters
+	m.priority = f.priority
+	m.showAll = f.showAll
+	m.showArchived = f.showArchived
+	m.showInteresting = f.showInteresting
+	m.showUnprioritized = f.showUnprioritized
+	m.sortNewest = f.sortNewest
+	m.filterType = f.filterType
+	m.filterCategory = f.filterCategory
+	m.filterSource = f.filterSource
+	m.filterTag = f.filterTag
+	m.filterAuthor = f.filterAuthor
+	m.filterSince = f.filterSince
+	m.filterUntil = f.filterUntil
+	m.search = f.search
+	m.updateSourcesViewport()
+
+	m.view = "list"
+	m.jumping = true
+	m.loading = true
+	fetch := m.jumpToItem(loc.itemID)
+	return m, func() tea.Msg {
+		msg := fetch()
+		if loaded, ok := msg.(itemsLoadedMsg); ok {
+			loaded.openReader = loc.view == "reader"
+			return loaded
+		}
+		return msg
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestJumpList_BackAndForward verifies vim-style back/forward over recorded locations.
+// BREAKS: If the live position isn't saved on the first back, Ctrl-I can't return to where you were.
+func TestJumpList_BackAndForward(t *testing.T) {
+	a := jumpLocation{view: "reader", itemID: "a"}
+	b := jumpLocation{view: "reader", itemID: "b"}
+	c := jumpLocation{view: "reader", itemID: "c"}
+
+	var j jumpList
+	j = j.record(a)
+	j = j.record(b)
+
+	j, loc, ok := j.back(c)
+	if !ok || loc != b {
+		t.Fatalf("Expected back to b, got %+v (%v)", loc, ok)
+	}
+	j, loc, _ = j.back(b)
+	if loc != a {
+		t.Fatalf("Expected back to a, got %+v", loc)
+	}
+	if _, _, ok := j.back(a); ok {
+		t.Error("Expected no jump before the oldest entry")
+	}
+	j, loc, _ = j.forward()
+	j, loc, _ = j.forward()
+	if loc != c || j.canForward() {
+		t.Errorf("Expected forward to end at c, got %+v canForward=%v", loc, j.canForward())
+	}
+}
+
+// TestJumpLocation_ListCursorMovesAreNotJumps verifies j/k in the list don't flood the jump list.
+// BREAKS: If every cursor move is a jump, Ctrl-O steps one row at a time.
+func TestJumpLocation_ListCursorMovesAreNotJumps(t *testing.T) {
+	a := jumpLocation{view: "list", itemID: "a"}
+	b := jumpLocation{view: "list", itemID: "b"}
+	if !a.same(b) {
+		t.Error("Expected list locations with the same filters to be the same jump")
+	}
+	b.filters.priority = "high"
+	if a.same(b) {
+		t.Error("Expected a filter change to be a new jump")
+	}
+}
+
+// TestUpdate_RecordsReaderVisits verifies opening an article records where you came from.
+// BREAKS: If Update doesn't record, Ctrl-O has nothing to go back to.
+func TestUpdate_RecordsReaderVisits(t *testing.T) {
+	m := Model{view: "list", items: []db.ContentItem{{ID: "a"}}}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.view != "reader" || len(m.jumps.entries) != 1 || m.jumps.entries[0].view != "list" {
+		t.Fatalf("Expected list location recorded on entering reader, got %+v", m.jumps)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = updated.(Model)
+	if m.view != "list" || !m.jumping || cmd == nil {
+		t.Errorf("Expected Ctrl-O to restore the list, got view=%s jumping=%v", m.view, m.jumping)
+	}
+}
@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+)
+
+// helpRow is one line of the help modal
+type helpRow struct {
+	section string
+	key     string
+	desc    string
+}
+
+// keyHelp documents the built-in keys of the list and reader, by section
+var keyHelp = []helpRow{
+	{"NAVIGATION", "j/k", "Move up/down"},
+	{"NAVIGATION", "g/G", "Jump to top/bottom"},
+	{"NAVIGATION", "n/p", "Next/prev unread"},
+	{"NAVIGATION", "]/[", "Next/prev HIGH"},
+	{"NAVIGATION", "Enter", "Read article"},
+	{"NAVIGATION", "q", "Quit/Back"},
+	{"NAVIGATION", ":", "Command mode"},
+	{"NAVIGATION", "?", "This help"},
+	{"NAVIGATION", "S", "Source manager"},
+	{"NAVIGATION", "Ctrl-P", "Command palette"},
+	{"NAVIGATION", "Ctrl-T", "Find item by title"},
+	{"NAVIGATION", "Ctrl-O", "Jump back"},
+	{"NAVIGATION", "Ctrl-I/Tab", "Jump forward"},
+	{"NAVIGATION", "Ctrl-W h/l/j/k", "Focus sources/content/preview/list"},
+	{"NAVIGATION", "Ctrl-W w", "Next pane"},
+	{"NAVIGATION", "Ctrl-W =", "Reset sidebar width"},
+	{"NAVIGATION", "Ctrl-W s", "Toggle preview split"},
+	{"NAVIGATION", "< / >", "Shrink/grow sidebar"},
+	{"NAVIGATION", "+ / -", "Upvote/downvote (feedback)"},
+	{"FILTERS & SORTING", "1/2/3/4/6", "Priority/Favorites/Interesting"},
+	{"FILTERS & SORTING", "0/i", "Unprioritized/Interesting"},
+	{"FILTERS & SORTING", "a/u/v", "All/Unread/Archived"},
+	{"FILTERS & SORTING", "d/s", "Date sort/Sources"},
+	{"FILTERS & SORTING", "R", "Reset filters"},
+	{"FILTERS & SORTING", "/", "Filter list as you type"},
+	{"FILTERS & SORTING", "Esc", "Clear filter"},
+	{"READER MODE", "j/k", "Scroll up/down"},
+	{"READER MODE", "h/l", "Prev/Next article"},
+	{"READER MODE", "Space", "Page down"},
+	{"READER MODE", "ESC/q", "Back to list"},
+	{"READER MODE", "Z", "Zen mode"},
+	{"READER MODE", "w", "Why prioritized"},
+}
+
+// commandSections groups registry commands in the help modal; commands not
+// listed here still show up, under OTHER COMMANDS
+var commandSections = map[string]string{
+	"mark": "ARTICLE", "favorite": "ARTICLE", "pin": "ARTICLE", "up": "ARTICLE", "down": "ARTICLE",
+	"open": "ARTICLE", "download": "ARTICLE", "mirror": "ARTICLE", "yank": "ARTICLE", "copy": "ARTICLE", "task": "ARTICLE",
+	"extract": "ARTICLE", "summarize": "ARTICLE", "ask": "ARTICLE", "fabric": "ARTICLE", "tag": "ARTICLE",
+	"snooze": "ARTICLE", "remind": "ARTICLE", "listen": "ARTICLE", "zen": "ARTICLE",
+	"add": "SOURCES", "remove": "SOURCES", "pause": "SOURCES", "resume": "SOURCES", "edit": "SOURCES",
+	"filter": "SOURCES", "sources": "SOURCES", "import": "SOURCES",
+	"refresh": "LISTS", "find": "LISTS", "sort": "LISTS", "archived": "LISTS", "markall": "LISTS",
+	"triage": "LISTS", "search": "LISTS",
+	"audio": "REPORTS", "transcript": "REPORTS", "digest": "REPORTS", "history": "REPORTS", "export": "REPORTS",
+	"context": "MAINTENANCE", "unprioritized": "MAINTENANCE", "prune": "MAINTENANCE", "logs": "MAINTENANCE",
+	"messages": "MAINTENANCE", "errors": "MAINTENANCE", "backup": "MAINTENANCE", "restore": "MAINTENANCE", "db": "MAINTENANCE", "sync": "MAINTENANCE",
+	"set": "SETTINGS", "theme": "SETTINGS",
+	"help": "APP", "quit": "APP",
+}
+
+// commandSectionOrder is the order command sections appear in after the keys
+var commandSectionOrder = []string{"ARTICLE", "SOURCES", "LISTS", "REPORTS", "MAINTENANCE", "SETTINGS", "APP", "OTHER"}
+
+// helpRows builds the help modal's content from the key table, the user's
+// [keys], and the command registry, so it can't drift from what runs
+func (m Model) helpRows() []helpRow {
+	var rows []helpRow
+
+	custom := make([]string, 0, len(m.keymap))
+	for key := range m.keymap {
+		custom = append(custom, key)
+	}
+	sort.Strings(custom)
+	for _, key := range custom {
+		rows = append(rows, helpRow{"YOUR KEYS", key, describeBinding(key, m.keymap[key])})
+	}
+
+	rows = append(rows, keyHelp...)
+
+	bySection := map[string][]helpRow{}
+	described := map[string]bool{}
+	for _, c := range paletteCommands {
+		name := strings.Fields(c.line)[0]
+		described[name] = true
+		key := ":" + c.line
+		if c.args {
+			key += " …"
+		}
+		section := commandSections[name]
+		if section == "" {
+			section = "OTHER"
+		}
+		bySection[section] = append(bySection[section], helpRow{section + " COMMANDS", key, c.hint})
+	}
+	names := commands.NewRegistry().GetCommands()
+	sort.Strings(names)
+	for _, name := range names {
+		if !described[name] {
+			bySection["OTHER"] = append(bySection["OTHER"], helpRow{"OTHER COMMANDS", ":" + name, ""})
+		}
+	}
+	for _, section := range commandSectionOrder {
+		rows = append(rows, bySection[section]...)
+	}
+	return rows
+}
+
+// describeBinding says what a [keys] entry does: runs a command, or acts
+// like a built-in key
+func describeBinding(key, target string) string {
+	desc := ""
+	if command, ok := strings.CutPrefix(target, ":"); ok {
+		desc = "Runs :" + command
+	} else {
+		desc = "Same as " + target
+		if builtin := builtinKeyHelp(target); builtin != "" {
+			desc += " (" + builtin + ")"
+		}
+	}
+	if builtinKeyHelp(key) != "" {
+		desc += ", replacing the built-in key"
+	}
+	return desc
+}
+
+// builtinKeyHelp returns the description of the built-in key, or "" when
+// key isn't one
+func builtinKeyHelp(key string) string {
+	for _, row := range keyHelp {
+		if strings.HasPrefix(row.key, "Ctrl-W ") {
+			continue // Two-key window commands, not keys of their own
+		}
+		for _, k := range strings.FieldsFunc(row.key, func(r rune) bool { return r == '/' || r == ' ' }) {
+			if strings.EqualFold(strings.ReplaceAll(k, "-", "+"), key) && (len(k) > 1 || k == key) {
+				return row.desc
+			}
+		}
+	}
+	return ""
+}
+
+// keyTypes maps key names as bubbletea prints them ("enter", "ctrl+j") to
+// their types, for replaying [keys] aliases
+var keyTypes = func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-100); t <= 127; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// keyMsgFor builds the key press named name, e.g. "j", "enter", "ctrl+d", or
+// "alt+x"
+func keyMsgFor(name string) (tea.KeyMsg, bool) {
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t}, true
+	}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok {
+		msg, ok := keyMsgFor(rest)
+		msg.Alt = true
+		return msg, ok
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// keymapInvalidMsg reports [keys] entries that were dropped
+type keymapInvalidMsg struct {
+	err error
+}
+
+// parseKeymap validates [keys]: each key maps to another key (an alias) or
+// to a :command. Bad entries are dropped and reported.
+func parseKeymap(keys map[string]string) (map[string]string, error) {
+	keymap := map[string]string{}
+	var bad []string
+	for key, target := range keys {
+		target = strings.TrimSpace(target)
+		_, keyOK := keyMsgFor(key)
+		_, targetOK := keyMsgFor(target)
+		if !keyOK || (!targetOK && !strings.HasPrefix(target, ":")) || target == ":" || key == target {
+			bad = append(bad, key)
+			continue
+		}
+		keymap[key] = target
+	}
+	if len(bad) > 0 {
+		sort.Strings(bad)
+		return keymap, fmt.Errorf("ignoring [keys] %s: expected a key like \"j\" or \"ctrl+d\", or a command like \":mark\"", strings.Join(bad, ", "))
+	}
+	return keymap, nil
+}
+
+// applyKeymap runs a [keys] binding for a key pressed in the list or reader.
+// ok is false when the key isn't bound.
+func (m Model) applyKeymap(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	target, ok := m.keymap[msg.String()]
+	if !ok {
+		return m, nil, false
+	}
+	if command, ok := strings.CutPrefix(target, ":"); ok {
+		fields := strings.Fields(command)
+		if refuse := m.refuseMissing(commandFeature(fields)); refuse != nil {
+			return m, refuse, true
+		}
+		return m, commands.NewRegistry().Execute(fields[0], fields[1:]), true
+	}
+	// Aliases replay the built-in key, like vim's noremap, so they can't loop
+	replay, _ := keyMsgFor(target)
+	keymap := m.keymap
+	m.keymap = nil
+	updated, cmd := m.update(replay)
+	if model, ok := updated.(Model); ok {
+		model.keymap = keymap
+		updated = model
+	}
+	return updated, cmd, true
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestHelpRows_CoverRegistryAndUserKeys verifies the help lists every registry command and the user's [keys].
+// BREAKS: If help is hand-written again, new commands and rebound keys go undocumented.
+func TestHelpRows_CoverRegistryAndUserKeys(t *testing.T) {
+	m := testModel()
+	m.keymap = map[string]string{"x": ":mark", "ctrl+j": "j"}
+	rows := m.helpRows()
+
+	keys := map[string]helpRow{}
+	for _, row := range rows {
+		keys[row.key] = row
+	}
+	for _, name := range commands.NewRegistry().GetCommands() {
+		found := false
+		for key := range keys {
+			if key == ":"+name || strings.HasPrefix(key, ":"+name+" ") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected :%s in the help", name)
+		}
+	}
+
+	if row := keys["x"]; row.section != "YOUR KEYS" || row.desc != "Runs :mark" {
+		t.Errorf("Expected x documented as running :mark, got %+v", row)
+	}
+	if row := keys["ctrl+j"]; !strings.Contains(row.desc, "Move up/down") {
+		t.Errorf("Expected ctrl+j described by the key it aliases, got %+v", row)
+	}
+}
+
+// TestHelpModal_SearchFilters verifies typing in the search narrows the rows and Esc clears before closing.
+// BREAKS: If search doesn't match or Esc closes straight away, finding a key in a long help is back to scrolling.
+func TestHelpModal_SearchFilters(t *testing.T) {
+	help := NewHelpModal()
+	help.SetSize(120, 40)
+	help.Open(testModel().helpRows())
+
+	help, _ = help.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "history" {
+		help, _ = help.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	matches := help.matches()
+	if len(matches) == 0 || len(matches) == len(help.rows) {
+		t.Fatalf("Expected the search to narrow the rows, got %d of %d", len(matches), len(help.rows))
+	}
+	for _, row := range matches {
+		if !strings.Contains(strings.ToLower(row.key+row.desc), "history") {
+			t.Errorf("Unexpected match %+v", row)
+		}
+	}
+
+	// Enter keeps the matches; Esc then clears them, and a second Esc closes
+	help, _ = help.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	help, _ = help.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !help.IsVisible() || len(help.matches()) != len(help.rows) {
+		t.Fatal("Expected the first Esc to clear the search")
+	}
+	help, _ = help.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if help.IsVisible() {
+		t.Error("Expected the second Esc to close help")
+	}
+}
+
+// TestKeymap_BindingsRunCommandsAndAliases verifies [keys] entries run their command or act as their key.
+// BREAKS: If bindings are ignored or loop through themselves, user overrides silently do nothing or hang.
+func TestKeymap_BindingsRunCommandsAndAliases(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "a"}, {ID: "b"}})
+	m.focusedPane = "content"
+	m.keymap = map[string]string{"x": ":mark", "ctrl+j": "j", "j": "k"}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("Expected x to run :mark")
+	}
+	if _, ok := cmd().(commands.MarkMsg); !ok {
+		t.Error("Expected x to produce a MarkMsg")
+	}
+
+	// ctrl+j → j, but j's own binding doesn't apply to the replay
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlJ})
+	if updated.(Model).cursor != 1 {
+		t.Errorf("Expected ctrl+j to move down like j, cursor at %d", updated.(Model).cursor)
+	}
+	if m.keymap["ctrl+j"] != "j" {
+		t.Error("Expected the binding restored after the replay")
+	}
+
+	_, err := parseKeymap(map[string]string{"x": "nonsense", "ctrl+d": ":", "y": ":yank"})
+	if err == nil || !strings.Contains(err.Error(), "ctrl+d, x") {
+		t.Errorf("Expected the bad entries reported, got %v", err)
+	}
+}
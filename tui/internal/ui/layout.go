@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Sidebar split, as a percentage of the terminal width
+const (
+	defaultSidebarPercent = 25
+	minSidebarPercent     = 10
+	maxSidebarPercent     = 50
+	sidebarPercentStep    = 5
+	minSidebarWidth       = 20 // Narrowest sidebar that still fits source names
+)
+
+// paneLayout is the geometry of the main panes, shared by rendering and
+// viewport sizing so they can't disagree
+type paneLayout struct {
+	sidebarWidth  int
+	contentWidth  int
+	contentHeight int
+	listHeight    int // Rows for the list; less than contentHeight when the preview splits the pane
+	previewHeight int // 0 when the preview pane is off
+}
+
+// layout computes pane sizes for the current terminal and options
+func (m Model) layout() paneLayout {
+	// Reserve space: header(1) + empty(1) + status(1) + command(1) + borders(1) = 5
+	l := paneLayout{contentHeight: m.height - 5}
+
+	// Zen mode: one centered column, with only a top margin and the command line
+	if m.zenActive() {
+		l.contentHeight = m.height - 2
+		l.contentWidth = min(m.width, zenReadingWidth)
+		l.listHeight = l.contentHeight
+		return l
+	}
+
+	// Left sidebar (a share of the width), right content the rest
+	if m.hideSidebar {
+		l.contentWidth = m.width
+	} else {
+		l.sidebarWidth = max(minSidebarWidth, m.width*m.sidebarRatio()/100)
+		l.contentWidth = m.width - l.sidebarWidth - 1
+	}
+
+	l.listHeight = l.contentHeight
+	if m.previewActive() {
+		// List on top, divider, preview below
+		l.previewHeight = l.contentHeight * 45 / 100
+		l.listHeight = l.contentHeight - l.previewHeight - 1
+	}
+	return l
+}
+
+// sidebarRatio returns the sidebar's share of the width in percent
+func (m Model) sidebarRatio() int {
+	if m.sidebarPercent == 0 {
+		return defaultSidebarPercent
+	}
+	return m.sidebarPercent
+}
+
+// resizeSidebar grows or shrinks the sidebar by delta percent, showing it
+// again if it was hidden. Returns false when already at the limit.
+func (m *Model) resizeSidebar(delta int) bool {
+	if m.hideSidebar {
+		m.setSidebar(true)
+		return true
+	}
+	percent := min(maxSidebarPercent, max(minSidebarPercent, m.sidebarRatio()+delta))
+	if percent == m.sidebarRatio() {
+		return false
+	}
+	m.sidebarPercent = percent
+	m.relayout()
+	return true
+}
+
+// setSidebar shows or hides the sidebar, moving focus off it when hidden
+func (m *Model) setSidebar(show bool) {
+	m.hideSidebar = !show
+	if m.hideSidebar && m.focusedPane == "sources" {
+		m.focusedPane = "content"
+	}
+	m.relayout()
+}
+
+// relayout resizes viewports after the pane geometry changes
+func (m *Model) relayout() {
+	l := m.layout()
+	m.sourcesViewport.Width = max(0, l.sidebarWidth-2) // Padding for borders
+	m.previewItemID = ""                               // Re-render at the new width
+	if m.view == "reader" {
+		m.updateReaderContent()
+	}
+}
+
+// previewActive reports whether the list pane is split with a preview
+func (m Model) previewActive() bool {
+	return m.showPreview && m.view == "list"
+}
+
+// panes returns the focusable panes in Tab order
+func (m Model) panes() []string {
+	var panes []string
+	if !m.hideSidebar {
+		panes = append(panes, "sources")
+	}
+	panes = append(panes, "content")
+	if m.previewActive() {
+		panes = append(panes, "preview")
+	}
+	return panes
+}
+
+// cyclePane moves focus to the next pane, wrapping around
+func (m *Model) cyclePane() {
+	panes := m.panes()
+	next := 0
+	for i, pane := range panes {
+		if pane == m.focusedPane {
+			next = (i + 1) % len(panes)
+			break
+		}
+	}
+	m.focusedPane = panes[next]
+	m.statusMessage = ""
+}
+
+// syncPreview points the preview viewport at the item under the cursor,
+// re-rendering only when the item or pane width changes
+func (m *Model) syncPreview() {
+	if !m.previewActive() {
+		if m.focusedPane == "preview" {
+			m.focusedPane = "content"
+		}
+		return
+	}
+
+	l := m.layout()
+	width := max(10, l.contentWidth-4)
+	m.preview.Height = max(1, l.previewHeight-1) // Title line
+
+	var id string
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		id = m.items[m.cursor].ID
+	}
+	if id == m.previewItemID && width == m.preview.Width {
+		return
+	}
+	m.previewItemID = id
+	m.preview.Width = width
+
+	if id == "" {
+		m.preview.SetContent("")
+		return
+	}
+	item := m.items[m.cursor]
+	summary := item.ParsedAnalysis().ReadingSummary
+	if summary == "" {
+		summary = item.Summary
+	}
+	if summary == "" {
+		summary = "No summary available. Press Enter to read."
+	}
+	m.preview.SetContent(renderSimpleMarkdown(summary, width))
+	m.preview.GotoTop()
+}
+
+// renderPreview renders the preview pane under the list
+func renderPreview(m Model, width int, theme StyleTheme) string {
+	dividerColor := theme.DarkGray
+	if m.focusedPane == "preview" {
+		dividerColor = theme.Cyan
+	}
+	divider := lipgloss.NewStyle().Foreground(dividerColor).Render(strings.Repeat("─", max(0, width-2)))
+
+	titleLine := ""
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		item := m.items[m.cursor]
+		titleLine = m.titleLink(item, lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true).Render(truncate(item.Title, width-4)))
+	}
+
+	return divider + "\n" + titleLine + "\n" + m.preview.View()
+}
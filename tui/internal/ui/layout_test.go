@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestLayout_PreviewSplitsContentPane verifies the list and preview heights add up to the pane.
+// BREAKS: If the split overflows, the status bar is pushed off screen.
+func TestLayout_PreviewSplitsContentPane(t *testing.T) {
+	m := Model{width: 120, height: 40, view: "list"}
+	if l := m.layout(); l.previewHeight != 0 || l.listHeight != l.contentHeight {
+		t.Fatalf("Expected no preview by default, got %+v", l)
+	}
+
+	m.showPreview = true
+	l := m.layout()
+	if l.previewHeight == 0 || l.listHeight+1+l.previewHeight != l.contentHeight {
+		t.Errorf("Expected list + divider + preview = content height, got %+v", l)
+	}
+
+	m.view = "reader"
+	if m.layout().previewHeight != 0 {
+		t.Error("Expected the reader to use the full pane")
+	}
+}
+
+// TestSetPreview_FollowsCursorAndJoinsFocusCycle verifies :set preview shows the selected summary and is focusable.
+// BREAKS: If the preview doesn't follow the cursor, it shows a stale article.
+func TestSetPreview_FollowsCursorAndJoinsFocusCycle(t *testing.T) {
+	m := Model{width: 120, height: 40, view: "list", focusedPane: "content", items: []db.ContentItem{
+		{ID: "a", Title: "A", Summary: "alpha summary"},
+		{ID: "b", Title: "B", Summary: "beta summary"},
+	}}
+
+	updated, _ := m.Update(commands.SetOptionMsg{Name: "preview", Value: "toggle"})
+	m = updated.(Model)
+	if !m.showPreview || !strings.Contains(m.preview.View(), "alpha summary") {
+		t.Fatalf("Expected preview of first item, got %q", m.preview.View())
+	}
+
+	m.cursor = 1
+	updated, _ = m.Update(nil)
+	m = updated.(Model)
+	if !strings.Contains(m.preview.View(), "beta summary") {
+		t.Errorf("Expected preview to follow cursor, got %q", m.preview.View())
+	}
+
+	m.cyclePane()
+	if m.focusedPane != "preview" {
+		t.Errorf("Expected Tab from content to reach preview, got %s", m.focusedPane)
+	}
+}
+
+// TestSidebar_HideAndResizePersist verifies :set sidebar and </> change the split and survive a restart.
+// BREAKS: If the ratio isn't saved, narrow terminals lose the user's layout on every launch.
+func TestSidebar_HideAndResizePersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui_state.json")
+	uiStatePathFunc = func() (string, error) { return path, nil }
+	defer func() { uiStatePathFunc = defaultUIStatePath }()
+
+	m := Model{width: 80, height: 24, view: "list", focusedPane: "sources"}
+	if l := m.layout(); l.sidebarWidth != minSidebarWidth || l.sidebarWidth+1+l.contentWidth != 80 {
+		t.Fatalf("Expected a %d-column sidebar at 80 columns, got %+v", minSidebarWidth, l)
+	}
+
+	updated, cmd := m.Update(commands.SetOptionMsg{Name: "sidebar", Value: "off"})
+	m = updated.(Model)
+	if l := m.layout(); l.sidebarWidth != 0 || l.contentWidth != 80 {
+		t.Errorf("Expected content to take the full width, got %+v", l)
+	}
+	if m.focusedPane != "content" || slices.Contains(m.panes(), "sources") {
+		t.Errorf("Expected focus to leave the hidden sidebar, got %s %v", m.focusedPane, m.panes())
+	}
+	cmd()
+
+	// > brings the sidebar back, then grows it
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	m = updated.(Model)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	m = updated.(Model)
+	if m.hideSidebar || m.sidebarRatio() != defaultSidebarPercent+sidebarPercentStep {
+		t.Fatalf("Expected a visible %d%% sidebar, got hidden=%v %d%%", defaultSidebarPercent+sidebarPercentStep, m.hideSidebar, m.sidebarRatio())
+	}
+	cmd()
+
+	state, err := loadUIState()
+	if err != nil || !reflect.DeepEqual(state, m.savedLayout()) {
+		t.Errorf("Expected saved layout %+v, got %+v (%v)", m.savedLayout(), state, err)
+	}
+}
+
+// TestDensity_CompactRowsWithFooter verifies :set density=compact shows one line per item plus a metadata footer.
+// BREAKS: If compact rows keep the metadata line, small terminals see no more items than before.
+func TestDensity_CompactRowsWithFooter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui_state.json")
+	uiStatePathFunc = func() (string, error) { return path, nil }
+	defer func() { uiStatePathFunc = defaultUIStatePath }()
+
+	m := Model{view: "list", cursor: 1}
+	for _, source := range []string{"Alpha Feed", "Beta Feed", "Gamma Feed"} {
+		m.items = append(m.items, db.ContentItem{Title: "Story from " + source, SourceName: source, Published: time.Now()})
+	}
+
+	updated, cmd := m.Update(commands.SetOptionMsg{Name: "density", Value: "compact"})
+	m = updated.(Model)
+	if !m.compact {
+		t.Fatal("Expected compact density")
+	}
+	cmd()
+
+	lines := strings.Split(renderContentList(m, 60, 10, CleanCyberTheme), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("Expected the list to fill 10 lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[2], "Story from Gamma Feed") {
+		t.Errorf("Expected the third item on the third line, got %q", lines[2])
+	}
+	if footer := lines[9]; !strings.Contains(footer, "Beta Feed") || strings.Contains(footer, "Alpha") {
+		t.Errorf("Expected the footer to describe the selected item, got %q", footer)
+	}
+
+	state, err := loadUIState()
+	if err != nil || state.Density != "compact" {
+		t.Errorf("Expected compact density saved, got %q (%v)", state.Density, err)
+	}
+
+	updated, _ = m.Update(commands.SetOptionMsg{Name: "density", Value: "sparse"})
+	if updated.(Model).compact != true {
+		t.Error("Expected an unknown density to leave the setting alone")
+	}
+}
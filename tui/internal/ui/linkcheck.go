@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// linkCheckInterval spaces background link checks so a long list never
+// turns into a burst of requests
+const linkCheckInterval = 2 * time.Second
+
+// paywallDomains are sites that usually show a paywall instead of the article;
+// [tui] paywall_domains adds more
+var paywallDomains = []string{
+	"bloomberg.com",
+	"economist.com",
+	"ft.com",
+	"hbr.org",
+	"newyorker.com",
+	"nytimes.com",
+	"technologyreview.com",
+	"theatlantic.com",
+	"theinformation.com",
+	"thetimes.co.uk",
+	"washingtonpost.com",
+	"wired.com",
+	"wsj.com",
+}
+
+// linkCheckTickMsg asks for the next background link check
+type linkCheckTickMsg struct{}
+
+// linkCheckTick schedules the next background link check
+func linkCheckTick() tea.Cmd {
+	return tea.Tick(linkCheckInterval, func(time.Time) tea.Msg {
+		return linkCheckTickMsg{}
+	})
+}
+
+// nextLinkCheck starts a check of the first unread listed item whose link
+// hasn't been checked, one request at a time
+func (m *Model) nextLinkCheck() tea.Cmd {
+	for _, status := range m.linkStatus {
+		if status == -1 {
+			return nil // Still waiting on the last one
+		}
+	}
+	for _, item := range m.items {
+		if item.Read || item.URL == "" || isPaywalled(item.URL, m.paywallDomains) {
+			continue
+		}
+		if !strings.HasPrefix(item.URL, "http") {
+			continue // File sources have no page to check
+		}
+		if cmd := m.checkLink(item.URL); cmd != nil {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// isPaywalled reports whether rawURL is on a known paywalled domain or one of extra
+func isPaywalled(rawURL string, extra []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, domains := range [][]string{paywallDomains, extra} {
+		for _, domain := range domains {
+			domain = strings.ToLower(domain)
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// linkBadge marks a list item whose link is dead (✗) or paywalled ($).
+// Empty unless link checking is on.
+func (m Model) linkBadge(item db.ContentItem, theme StyleTheme) string {
+	if !m.checkLinks {
+		return ""
+	}
+	switch status := m.linkStatus[item.URL]; {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return lipgloss.NewStyle().Foreground(theme.Red).Render("✗")
+	case isPaywalled(item.URL, m.paywallDomains):
+		return lipgloss.NewStyle().Foreground(theme.Orange).Render("$")
+	}
+	return ""
+}
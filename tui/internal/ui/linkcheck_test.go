@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestLinkCheck_ChecksUnreadLinksOneAtATime verifies background checks skip read, paywalled, and checked items and never overlap.
+// BREAKS: If checks overlap, a 500-item list fires hundreds of requests at once.
+func TestLinkCheck_ChecksUnreadLinksOneAtATime(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{
+		{ID: "read", URL: "https://example.com/read", Read: true},
+		{ID: "pay", URL: "https://www.nytimes.com/2026/story"},
+		{ID: "file", URL: "/home/me/notes.md"},
+		{ID: "a", URL: "https://example.com/a"},
+		{ID: "b", URL: "https://example.com/b"},
+	})
+	m.checkLinks = true
+
+	if m.nextLinkCheck() == nil || m.linkStatus["https://example.com/a"] != -1 {
+		t.Fatalf("Expected the first unread checkable link, got %v", m.linkStatus)
+	}
+	if m.nextLinkCheck() != nil {
+		t.Error("Expected no second check while one is running")
+	}
+
+	updated, _ := m.Update(operations.LinkCheckedMsg{URL: "https://example.com/a", Status: 404})
+	m = updated.(Model)
+	if m.nextLinkCheck() == nil || m.linkStatus["https://example.com/b"] != -1 {
+		t.Errorf("Expected the next link to be checked, got %v", m.linkStatus)
+	}
+	if len(m.linkStatus) != 2 {
+		t.Errorf("Expected read, paywalled, and file items skipped, got %v", m.linkStatus)
+	}
+}
+
+// TestLinkCheck_Badges verifies dead and paywalled links get list icons only when link checking is on.
+// BREAKS: If badges show with checks off, users who never opted in see stray icons.
+func TestLinkCheck_Badges(t *testing.T) {
+	m := testModelWithItems(nil)
+	m.linkStatus = map[string]int{"https://example.com/gone": 410, "https://example.com/ok": 200}
+	theme := CleanCyberTheme
+
+	dead := db.ContentItem{URL: "https://example.com/gone"}
+	paywalled := db.ContentItem{URL: "https://blog.example.org/post"}
+	m.paywallDomains = []string{"Example.org"}
+
+	if m.linkBadge(dead, theme) != "" {
+		t.Error("Expected no badges with link checking off")
+	}
+	m.checkLinks = true
+	if !strings.Contains(m.linkBadge(dead, theme), "✗") {
+		t.Error("Expected a dead-link badge for a 410")
+	}
+	if !strings.Contains(m.linkBadge(paywalled, theme), "$") {
+		t.Error("Expected a configured domain's subdomain to be flagged as paywalled")
+	}
+	if m.linkBadge(db.ContentItem{URL: "https://example.com/ok"}, theme) != "" {
+		t.Error("Expected no badge for a live, free link")
+	}
+	if !isPaywalled("https://www.wsj.com/articles/x", nil) || isPaywalled("https://notwsj.com/x", nil) {
+		t.Error("Expected built-in domains matched by suffix on a dot boundary")
+	}
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// ListFilter is the "/" type-to-filter bar. It narrows the loaded list in
+// place as you type; the query sticks after Enter until Esc clears it.
+type ListFilter struct {
+	active bool
+	input  textinput.Model
+}
+
+// NewListFilter creates a new ListFilter instance
+func NewListFilter() ListFilter {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+	return ListFilter{input: ti}
+}
+
+// IsActive returns whether the filter bar is taking input
+func (f ListFilter) IsActive() bool {
+	return f.active
+}
+
+// Query returns the current filter text
+func (f ListFilter) Query() string {
+	return strings.TrimSpace(f.input.Value())
+}
+
+// Show focuses the bar, keeping any sticky query for editing
+func (f *ListFilter) Show() {
+	f.active = true
+	f.input.CursorEnd()
+	f.input.Focus()
+}
+
+// Hide stops taking input; the query keeps applying
+func (f *ListFilter) Hide() {
+	f.active = false
+	f.input.Blur()
+}
+
+// Clear hides the bar and drops the query
+func (f *ListFilter) Clear() {
+	f.Hide()
+	f.input.SetValue("")
+}
+
+// View renders the bar for the bottom line
+func (f ListFilter) View(width int, theme StyleTheme) string {
+	return lipgloss.NewStyle().
+		Foreground(theme.Cyan).
+		Width(width).
+		Padding(0, 1).
+		Render(f.input.View())
+}
+
+// matchesListQuery reports whether every term in query appears in the
+// item's title, source name, author, extracted entities, or user tags (case-insensitive)
+func matchesListQuery(item db.ContentItem, query string) bool {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return true
+	}
+
+	haystack := strings.ToLower(item.Title + "\n" + item.SourceName + "\n" + item.Author + "\n" +
+		strings.Join(item.ParsedAnalysis().Entities, "\n") + "\n" +
+		strings.Join(item.UserTags, "\n"))
+	for _, term := range terms {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterListQuery returns the items matching query, or items itself when query is empty
+func filterListQuery(items []db.ContentItem, query string) []db.ContentItem {
+	if strings.TrimSpace(query) == "" {
+		return items
+	}
+	matched := make([]db.ContentItem, 0, len(items))
+	for _, item := range items {
+		if matchesListQuery(item, query) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// updateListFilter feeds a key to the filter bar and re-narrows the list
+func (m Model) updateListFilter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Restore the full list on the same item, then resync it in case
+		// items changed while filtered
+		var currentID string
+		if m.cursor < len(m.items) {
+			currentID = m.items[m.cursor].ID
+		}
+		m.listFilter.Clear()
+		m.items = m.listBase
+		m.cursor = 0
+		for i, item := range m.items {
+			if item.ID == currentID {
+				m.cursor = i
+				break
+			}
+		}
+		cmd := m.jumpToItem(currentID)
+		return m, cmd
+	case "enter":
+		m.listFilter.Hide()
+		return m, nil
+	case "up", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	before := m.listFilter.Query()
+	m.listFilter.input, cmd = m.listFilter.input.Update(msg)
+	if m.listFilter.Query() != before {
+		m.items = filterListQuery(m.listBase, m.listFilter.Query())
+		m.cursor = 0
+	}
+	return m, cmd
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestMatchesListQuery_TitleSourceEntities verifies every term must hit title, source, or entities.
+// BREAKS: If entities aren't searched, typing a company name misses articles that only mention it in analysis.
+func TestMatchesListQuery_TitleSourceEntities(t *testing.T) {
+	item := db.ContentItem{Title: "Rust 2.0 released", SourceName: "Hacker News", Analysis: `{"entities":["Mozilla"]}`}
+
+	for _, q := range []string{"rust", "hacker rust", "MOZILLA", ""} {
+		if !matchesListQuery(item, q) {
+			t.Errorf("Expected %q to match", q)
+		}
+	}
+	if matchesListQuery(item, "rust golang") {
+		t.Error("Expected all terms to be required")
+	}
+}
+
+// TestListFilter_TypingNarrowsAndEscRestores verifies live narrowing and Esc restoring the full list.
+// BREAKS: If Esc doesn't restore, the user is stuck on a filtered list.
+func TestListFilter_TypingNarrowsAndEscRestores(t *testing.T) {
+	items := []db.ContentItem{{ID: "a", Title: "Go generics"}, {ID: "b", Title: "Rust traits"}}
+	m := Model{view: "list", items: items, listBase: items, listFilter: NewListFilter()}
+
+	press := func(key tea.KeyMsg) {
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "rust" {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.items) != 1 || m.items[0].ID != "b" {
+		t.Fatalf("Expected only Rust item, got %+v", m.items)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.items) != 2 || m.listFilter.Query() != "" || m.cursor != 1 {
+		t.Errorf("Expected full list restored on the same item, got %d items cursor=%d query=%q", len(m.items), m.cursor, m.listFilter.Query())
+	}
+}
+
+// TestListFilter_QuerySurvivesReload verifies an applied query keeps filtering refreshed items.
+// BREAKS: If reloads drop the query, auto-refresh silently undoes the user's filter.
+func TestListFilter_QuerySurvivesReload(t *testing.T) {
+	m := Model{view: "list", listFilter: NewListFilter()}
+	m.listFilter.input.SetValue("rust")
+
+	updated, _ := m.Update(itemsLoadedMsg{items: []db.ContentItem{{ID: "a", Title: "Go"}, {ID: "b", Title: "Rust"}}})
+	m = updated.(Model)
+	if len(m.items) != 1 || len(m.listBase) != 2 {
+		t.Errorf("Expected 1 of 2 items shown, got %d of %d", len(m.items), len(m.listBase))
+	}
+}
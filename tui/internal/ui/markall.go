@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// markAllConfirmState holds a :markall batch waiting for y/n
+type markAllConfirmState struct {
+	active bool
+	ids    []string
+	label  string // Describes the scope in the prompt, e.g. "from Hacker News"
+}
+
+// markAllItemsMsg carries every item for a :markall scope beyond the list
+type markAllItemsMsg struct {
+	scope commands.MarkAllMsg
+	items []db.ContentItem
+	err   error
+}
+
+// loadMarkAllItems fetches all items regardless of view filters. Remote mode
+// already holds them in itemsCache; local mode reads them from the database.
+func loadMarkAllItems(m Model, scope commands.MarkAllMsg) tea.Cmd {
+	if m.remoteURL != "" {
+		items := m.itemsCache
+		return func() tea.Msg {
+			return markAllItemsMsg{scope: scope, items: items}
+		}
+	}
+	return func() tea.Msg {
+		items, err := db.GetAllContent(false)
+		return markAllItemsMsg{scope: scope, items: items, err: err}
+	}
+}
+
+// markAllTargets picks the unread items in a :markall scope, returning their
+// IDs and a description of the scope for the prompt
+func markAllTargets(items []db.ContentItem, scope commands.MarkAllMsg, now time.Time) ([]string, string) {
+	label := "in this list"
+	switch scope.Scope {
+	case "source":
+		label = "from " + scope.Source
+	case "older":
+		label = fmt.Sprintf("older than %dd", scope.Days)
+	}
+
+	var ids []string
+	for _, item := range items {
+		if item.Read {
+			continue
+		}
+		if scope.Scope == "source" && !strings.EqualFold(item.SourceName, scope.Source) {
+			continue
+		}
+		if scope.Scope == "older" && !item.Published.Before(now.AddDate(0, 0, -scope.Days)) {
+			continue
+		}
+		ids = append(ids, item.ID)
+	}
+	return ids, label
+}
+
+// startMarkAll resolves a :markall scope, asking for confirmation before
+// anything is written
+func (m *Model) startMarkAll(msg commands.MarkAllMsg) tea.Cmd {
+	switch msg.Scope {
+	case "undo":
+		if len(m.lastMarkAll) == 0 {
+			return m.notify(toastInfo, "Nothing to undo", 3*time.Second)
+		}
+		m.statusMessage = fmt.Sprintf("Marking %d items unread...", len(m.lastMarkAll))
+		return operations.MarkArticlesRead(m.lastMarkAll, false)
+	case "filter":
+		return m.confirmMarkAll(markAllTargets(m.items, msg, time.Now()))
+	}
+	return loadMarkAllItems(*m, msg)
+}
+
+// confirmMarkAll shows the y/n prompt for a batch, or says there is nothing to do
+func (m *Model) confirmMarkAll(ids []string, label string) tea.Cmd {
+	if len(ids) == 0 {
+		return m.notify(toastInfo, "No unread items "+label, 3*time.Second)
+	}
+	m.markAllConfirm = markAllConfirmState{active: true, ids: ids, label: label}
+	m.statusMessage = fmt.Sprintf("Mark %d unread items %s as read? (y/n)", len(ids), label)
+	return nil
+}
+
+// markAllDone reports a finished batch and refreshes the list. A batch
+// marking items read becomes the one :markall undo reverts.
+func (m *Model) markAllDone(msg operations.ArticlesMarkedMsg) tea.Cmd {
+	m.statusMessage = ""
+	if !msg.Success {
+		return m.notify(toastError, fmt.Sprintf("Failed to mark items: %v", msg.Error), 5*time.Second)
+	}
+
+	m.setCachedState(msg.IDs, func(item *db.ContentItem) { item.Read = msg.Read })
+	state := "read"
+	if msg.Read {
+		m.lastMarkAll = msg.IDs
+	} else {
+		state = "unread"
+		m.lastMarkAll = nil
+	}
+	text := fmt.Sprintf("Marked %d items %s", len(msg.IDs), state)
+	if msg.Read {
+		text += " (:markall undo to revert)"
+	}
+
+	cmds := []tea.Cmd{func() tea.Msg { return commands.RefreshMsg{PreserveCursor: true} }}
+	if msg.Queued {
+		text = fmt.Sprintf("Marked %d items %s (offline, will sync)", len(msg.IDs), state)
+		cmds = append(cmds, operations.CountPendingWrites())
+	}
+	return tea.Batch(append(cmds, m.notify(toastSuccess, text, 4*time.Second))...)
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestMarkAllTargets_PicksUnreadInScope verifies each scope selects only its unread items.
+// BREAKS: If read items or other sources slip in, the count in the prompt is wrong and undo unmarks items the user had read.
+func TestMarkAllTargets_PicksUnreadInScope(t *testing.T) {
+	now := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+	items := []db.ContentItem{
+		{ID: "a", SourceName: "Hacker News", Published: now.Add(-time.Hour)},
+		{ID: "b", SourceName: "hacker news", Published: now.AddDate(0, 0, -10)},
+		{ID: "c", SourceName: "Hacker News", Published: now.AddDate(0, 0, -10), Read: true},
+		{ID: "d", SourceName: "Lobsters", Published: now.AddDate(0, 0, -8)},
+	}
+
+	cases := []struct {
+		scope commands.MarkAllMsg
+		want  []string
+	}{
+		{commands.MarkAllMsg{Scope: "filter"}, []string{"a", "b", "d"}},
+		{commands.MarkAllMsg{Scope: "source", Source: "HACKER NEWS"}, []string{"a", "b"}},
+		{commands.MarkAllMsg{Scope: "older", Days: 7}, []string{"b", "d"}},
+	}
+	for _, c := range cases {
+		ids, _ := markAllTargets(items, c.scope, now)
+		if !reflect.DeepEqual(ids, c.want) {
+			t.Errorf("Scope %+v: expected %v, got %v", c.scope, c.want, ids)
+		}
+	}
+}
+
+// TestMarkAll_ConfirmsBeforeWriting verifies :markall only prompts, and n cancels without a write.
+// BREAKS: If the batch runs before y, a stray :markall clears the whole inbox.
+func TestMarkAll_ConfirmsBeforeWriting(t *testing.T) {
+	m := Model{view: "list", items: []db.ContentItem{{ID: "a"}, {ID: "b", Read: true}, {ID: "c"}}}
+
+	updated, cmd := m.Update(commands.MarkAllMsg{Scope: "filter"})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Fatal("Expected no command until the batch is confirmed")
+	}
+	if !m.markAllConfirm.active || len(m.markAllConfirm.ids) != 2 {
+		t.Fatalf("Expected a pending batch of 2 items, got %+v", m.markAllConfirm)
+	}
+	if m.statusMessage == "" {
+		t.Error("Expected a y/n prompt showing the count")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	if m.markAllConfirm.active || m.statusMessage != "" {
+		t.Errorf("Expected n to cancel the batch, got %+v / %q", m.markAllConfirm, m.statusMessage)
+	}
+}
+
+// TestMarkAll_UndoRevertsLastBatch verifies a finished batch is remembered for undo and cleared once undone.
+// BREAKS: If the batch isn't kept, :markall undo has nothing to revert; if it isn't cleared, undo runs twice.
+func TestMarkAll_UndoRevertsLastBatch(t *testing.T) {
+	m := Model{view: "list"}
+
+	updated, _ := m.Update(operations.ArticlesMarkedMsg{IDs: []string{"a", "c"}, Read: true, Success: true})
+	m = updated.(Model)
+	if !reflect.DeepEqual(m.lastMarkAll, []string{"a", "c"}) {
+		t.Fatalf("Expected the batch to be kept for undo, got %v", m.lastMarkAll)
+	}
+	if _, cmd := m.Update(commands.MarkAllMsg{Scope: "undo"}); cmd == nil {
+		t.Error("Expected undo to mark the batch unread")
+	}
+
+	updated, _ = m.Update(operations.ArticlesMarkedMsg{IDs: []string{"a", "c"}, Read: false, Success: true})
+	m = updated.(Model)
+	if m.lastMarkAll != nil {
+		t.Errorf("Expected undo to clear the batch, got %v", m.lastMarkAll)
+	}
+	m.toasts = nil
+	updated, _ = m.Update(commands.MarkAllMsg{Scope: "undo"})
+	if len(updated.(Model).toasts) == 0 {
+		t.Error("Expected a notice when there is nothing to undo")
+	}
+}
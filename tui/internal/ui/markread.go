@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// defaultMarkReadDelay is how long "dwell" waits in the reader before marking
+const defaultMarkReadDelay = 10 * time.Second
+
+// markReadDueMsg fires when an article has been open for the dwell delay
+type markReadDueMsg struct {
+	id  string
+	seq int // Matches markReadSeq while the article stays open
+}
+
+// parseMarkRead parses a [tui] mark_read / :set markread value: "never",
+// "open", "end", "dwell", or a dwell delay like "10s" or "30". delay is the
+// current dwell delay, replaced by the default when unset.
+func parseMarkRead(value string, delay time.Duration) (string, time.Duration, error) {
+	if delay <= 0 {
+		delay = defaultMarkReadDelay
+	}
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case "", "never", "off", "false":
+		return "never", delay, nil
+	case "open", "end", "dwell":
+		return value, delay, nil
+	}
+	seconds, err := strconv.Atoi(strings.TrimSuffix(value, "s"))
+	if err != nil || seconds <= 0 {
+		return "", 0, fmt.Errorf("expected never, open, end, or a delay like 10s")
+	}
+	return "dwell", time.Duration(seconds) * time.Second, nil
+}
+
+// markReadSetting describes the mark-read behavior for :set
+func (m Model) markReadSetting() string {
+	if m.markRead == "dwell" {
+		return fmt.Sprintf("%ds", int(m.markReadDelay.Seconds()))
+	}
+	return m.markRead
+}
+
+// autoMarkRead marks the article in the reader read when the mark_read
+// setting says so: as soon as it opens, once it has been open for the dwell
+// delay, or when it is scrolled to the end. Called after every update.
+func (m *Model) autoMarkRead() tea.Cmd {
+	if m.markRead == "" || m.markRead == "never" || m.view != "reader" || m.cursor >= len(m.items) {
+		m.markReadItem = "" // Reopening an article arms it again
+		return nil
+	}
+	item := m.items[m.cursor]
+	if item.Read || m.markReadSent == item.ID {
+		return nil
+	}
+
+	if item.ID != m.markReadItem {
+		m.markReadItem = item.ID
+		switch m.markRead {
+		case "open":
+			m.markReadSent = item.ID
+			return operations.AutoMarkArticleRead(item.ID)
+		case "dwell":
+			m.markReadSeq++
+			due := markReadDueMsg{id: item.ID, seq: m.markReadSeq}
+			return tea.Tick(m.markReadDelay, func(time.Time) tea.Msg { return due })
+		}
+	}
+
+	if m.markRead == "end" && m.viewport.AtBottom() {
+		m.markReadSent = item.ID
+		return operations.AutoMarkArticleRead(item.ID)
+	}
+	return nil
+}
+
+// markReadDue marks the dwelled-on article read if it has stayed open since
+// the timer started
+func (m *Model) markReadDue(msg markReadDueMsg) tea.Cmd {
+	if m.view != "reader" || m.cursor >= len(m.items) || m.markReadItem != msg.id || m.markReadSeq != msg.seq {
+		return nil
+	}
+	if item := m.items[m.cursor]; item.ID != msg.id || item.Read || m.markReadSent == msg.id {
+		return nil
+	}
+	m.markReadSent = msg.id
+	return operations.AutoMarkArticleRead(msg.id)
+}
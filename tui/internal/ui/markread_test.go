@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestParseMarkRead verifies the mark_read modes and dwell delays.
+// BREAKS: If "30s" isn't read as a dwell delay, the setting silently does nothing.
+func TestParseMarkRead(t *testing.T) {
+	tests := []struct {
+		value string
+		mode  string
+		delay time.Duration
+	}{
+		{"", "never", defaultMarkReadDelay},
+		{"open", "open", defaultMarkReadDelay},
+		{"END", "end", defaultMarkReadDelay},
+		{"dwell", "dwell", defaultMarkReadDelay},
+		{"30s", "dwell", 30 * time.Second},
+		{"5", "dwell", 5 * time.Second},
+	}
+	for _, tt := range tests {
+		mode, delay, err := parseMarkRead(tt.value, 0)
+		if err != nil || mode != tt.mode || delay != tt.delay {
+			t.Errorf("parseMarkRead(%q) = %s, %v, %v; want %s, %v", tt.value, mode, delay, err, tt.mode, tt.delay)
+		}
+	}
+	if _, _, err := parseMarkRead("sometimes", 0); err == nil {
+		t.Error("Expected an unknown mode to fail")
+	}
+}
+
+// readerModel opens a long unread article in the reader
+func readerModel(mode string) Model {
+	m := Model{width: 100, height: 40, view: "reader", markRead: mode, markReadDelay: time.Second, items: []db.ContentItem{
+		{ID: "a", Title: "Long read", Content: strings.Repeat("Paragraph.\n\n", 100)},
+	}}
+	m.updateReaderContent()
+	return m
+}
+
+// TestAutoMarkRead_Modes verifies each mode marks at the right moment, once.
+// BREAKS: If "end" fires on open, scrolling readers lose unread items they haven't finished.
+func TestAutoMarkRead_Modes(t *testing.T) {
+	m := readerModel("never")
+	if cmd := m.autoMarkRead(); cmd != nil {
+		t.Error("Expected never to leave read state alone")
+	}
+
+	m = readerModel("open")
+	if cmd := m.autoMarkRead(); cmd == nil {
+		t.Error("Expected open to mark as soon as the article shows")
+	}
+	if cmd := m.autoMarkRead(); cmd != nil {
+		t.Error("Expected the article to be marked only once")
+	}
+
+	m = readerModel("end")
+	if cmd := m.autoMarkRead(); cmd != nil {
+		t.Error("Expected end to wait until the article is scrolled through")
+	}
+	m.viewport.GotoBottom()
+	if cmd := m.autoMarkRead(); cmd == nil {
+		t.Error("Expected end to mark at the bottom")
+	}
+
+	m = readerModel("dwell")
+	if cmd := m.autoMarkRead(); cmd == nil {
+		t.Fatal("Expected dwell to start a timer")
+	}
+	stale := markReadDueMsg{id: "a", seq: m.markReadSeq - 1}
+	if cmd := m.markReadDue(stale); cmd != nil {
+		t.Error("Expected a timer from an earlier visit to be ignored")
+	}
+	if cmd := m.markReadDue(markReadDueMsg{id: "a", seq: m.markReadSeq}); cmd == nil {
+		t.Error("Expected dwell to mark once the delay passes with the article open")
+	}
+}
+
+// TestAutoMarkRead_StaysInReader verifies an automatic mark updates the item without leaving the reader.
+// BREAKS: If auto marks refresh like :mark, the unread view yanks the article away mid-read.
+func TestAutoMarkRead_StaysInReader(t *testing.T) {
+	m := readerModel("open")
+	updated, cmd := m.Update(operations.ArticleMarkedMsg{ID: "a", Read: true, Auto: true, Success: true})
+	m = updated.(Model)
+	if !m.items[0].Read {
+		t.Error("Expected the item marked read")
+	}
+	if m.view != "reader" || cmd != nil || len(m.toasts) != 0 {
+		t.Errorf("Expected a silent update in the reader, got view=%s cmd=%v toasts=%d", m.view, cmd != nil, len(m.toasts))
+	}
+}
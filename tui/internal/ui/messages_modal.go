@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MessagesModal lists recent toast notifications (:messages)
+type MessagesModal struct {
+	Modal   // Embed base modal
+	entries []toast
+	scroll  int // Rows scrolled up from the newest messages
+}
+
+// NewMessagesModal creates a new MessagesModal instance
+func NewMessagesModal() MessagesModal {
+	return MessagesModal{
+		Modal: NewModal("MESSAGES", 80, 20), // Will be sized dynamically
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *MessagesModal) SetSize(width, height int) {
+	modalWidth := int(float64(width) * 0.75)
+	if modalWidth < 50 {
+		modalWidth = 50
+	}
+	if modalWidth > width-4 {
+		modalWidth = width - 4
+	}
+	modalHeight := height - 8
+	if modalHeight < 8 {
+		modalHeight = 8
+	}
+	m.Modal.width = modalWidth
+	m.Modal.height = modalHeight
+}
+
+// Open shows the modal with a snapshot of the toast history, scrolled to
+// the newest
+func (m *MessagesModal) Open(history []toast) {
+	m.entries = history
+	m.scroll = 0
+	m.Show()
+}
+
+// rows is how many messages fit: the title, its margin, the footer hint,
+// and padding take the rest
+func (m MessagesModal) rows() int {
+	return max(1, m.Modal.height-6)
+}
+
+// scrollBy moves the window by delta rows, positive toward older messages
+func (m *MessagesModal) scrollBy(delta int) {
+	m.scroll = max(0, min(m.scroll+delta, len(m.entries)-m.rows()))
+}
+
+// Update handles input for the messages modal
+func (m MessagesModal) Update(msg tea.Msg) (MessagesModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "enter":
+			m.Hide()
+		case "k", "up":
+			m.scrollBy(1)
+		case "j", "down":
+			m.scrollBy(-1)
+		case "pgup", "ctrl+u":
+			m.scrollBy(m.rows())
+		case "pgdown", "ctrl+d", " ":
+			m.scrollBy(-m.rows())
+		case "g", "home":
+			m.scrollBy(len(m.entries))
+		case "G", "end":
+			m.scroll = 0
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the messages in the scroll window over the background
+func (m MessagesModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	lineWidth := m.Modal.width - 4 // Inside padding
+	end := max(0, len(m.entries)-m.scroll)
+	start := max(0, end-m.rows())
+	entries := m.entries[start:end]
+
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	lineStyle := lipgloss.NewStyle().Width(lineWidth).MaxHeight(1)
+	timeStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+
+	var content strings.Builder
+	if len(entries) == 0 {
+		content.WriteString(lineStyle.Foreground(theme.Gray).Render("No messages yet"))
+		content.WriteString("\n")
+	}
+	for _, t := range entries {
+		levelStyle := lipgloss.NewStyle().Foreground(t.level.color(theme)).Bold(true)
+		line := timeStyle.Render(t.at.Format("15:04:05")) + " " +
+			levelStyle.Render(padRight(t.level.label(), 5)) + " " +
+			lipgloss.NewStyle().Foreground(theme.White).Render(t.text)
+		content.WriteString(lineStyle.Render(line))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	hint := "Press ESC to close"
+	if len(m.entries) > len(entries) {
+		hint = fmt.Sprintf("%d-%d of %d · j/k scroll · g/G oldest/newest · ESC to close", start+1, end, len(m.entries))
+	}
+	content.WriteString(lipgloss.NewStyle().Foreground(theme.Gray).Italic(true).Render(hint))
+
+	modal := m.Modal
+	modal.SetContent(content.String())
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
+
+// padRight pads s with spaces to n columns
+func padRight(s string, n int) string {
+	if w := lipgloss.Width(s); w < n {
+		return s + strings.Repeat(" ", n-w)
+	}
+	return s
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// mirrorURL returns an archived copy's address: the Wayback Machine's latest
+// snapshot by default, or archive.today's newest with service "today"
+func mirrorURL(url, service string) string {
+	if service == "today" {
+		return "https://archive.ph/newest/" + url
+	}
+	return "https://web.archive.org/web/" + url
+}
+
+// deadLinkHint suggests the mirror for a link that answered status
+func deadLinkHint(status int) string {
+	return fmt.Sprintf("Original link is gone (%d): :mirror opens an archived copy", status)
+}
+
+// checkLink starts a background check of url unless it's already known or
+// being checked
+func (m *Model) checkLink(url string) tea.Cmd {
+	if url == "" {
+		return nil
+	}
+	if m.linkStatus == nil {
+		m.linkStatus = make(map[string]int)
+	}
+	if _, known := m.linkStatus[url]; known {
+		return nil
+	}
+	m.linkStatus[url] = -1
+	return operations.CheckLink(url)
+}
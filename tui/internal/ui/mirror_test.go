@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestMirror_URLs verifies both archive services get the original URL appended.
+// BREAKS: If the URL is escaped, archive.org searches for a mangled address and finds nothing.
+func TestMirror_URLs(t *testing.T) {
+	if got := mirrorURL("https://example.com/a?b=1", "wayback"); got != "https://web.archive.org/web/https://example.com/a?b=1" {
+		t.Errorf("Unexpected Wayback URL: %s", got)
+	}
+	if got := mirrorURL("https://example.com/a", "today"); got != "https://archive.ph/newest/https://example.com/a" {
+		t.Errorf("Unexpected archive.today URL: %s", got)
+	}
+}
+
+// TestMirror_SuggestedWhenLinkIsDead verifies a 404 on the article being read suggests :mirror, once per URL.
+// BREAKS: If checks aren't remembered, every visit to an article re-requests the site.
+func TestMirror_SuggestedWhenLinkIsDead(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "a", Title: "Gone", URL: "https://example.com/gone"}})
+	m.view = "reader"
+
+	if m.checkLink("https://example.com/gone") == nil {
+		t.Fatal("Expected a first check to run")
+	}
+	if m.checkLink("https://example.com/gone") != nil {
+		t.Error("Expected a running check not to be repeated")
+	}
+
+	updated, cmd := m.Update(operations.LinkCheckedMsg{URL: "https://example.com/gone", Status: 404})
+	m = updated.(Model)
+	if cmd == nil || m.linkStatus["https://example.com/gone"] != 404 {
+		t.Fatalf("Expected the status recorded and a toast, got %v", m.linkStatus)
+	}
+	if len(m.toasts) == 0 || !strings.Contains(m.toasts[len(m.toasts)-1].text, ":mirror") {
+		t.Errorf("Expected a :mirror suggestion, got %+v", m.toasts)
+	}
+}
@@ -1,13 +1,20 @@
 package ui
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -36,22 +43,97 @@ type Model struct {
 	showUnprioritized bool           // Show items with null/empty priority (default false)
 	hiddenCount       int            // Count of hidden unprioritized items
 	// View state fields for header display
-	showAll         bool   // Show all items vs unread only (default false - unread only)
-	showArchived    bool   // Show archived items only (default false - exclude archived)
-	showInteresting bool   // Show only items flagged as interesting (default false)
-	sortNewest      bool   // Sort by newest first vs oldest first (default true - newest)
-	filterType      string // Source type filter: "all", "rss", "reddit", "youtube", "file" (default "all")
+	showAll         bool              // Show all items vs unread only (default false - unread only)
+	showArchived    bool              // Show archived items only (default false - exclude archived)
+	showInteresting bool              // Show only items flagged as interesting (default false)
+	sortNewest      bool              // Sort by newest first vs oldest first (default true - newest)
+	viewSorts       map[string]string // :sort order per priority view, persisted in UI state
+	viewExprs       map[string]string // :filter expression per priority view, persisted in UI state
+	savedSearches   map[string]string // :search expressions by name, persisted in UI state
+	search          runningSearch     // Search narrowing every view (zero for none)
+	filterType      string            // Source type filter: "all", "rss", "reddit", "youtube", "file" (default "all")
+	filterCategory  string            // Source category filter (empty = all categories)
+	filterSource    string            // Single-source filter by source ID (empty = all sources)
+	filterTag       string            // User tag filter (empty = any tags)
+	filterAuthor    string            // Byline filter, matched case-insensitively anywhere in it (empty = any author)
+	filterSince     time.Time         // Only items that arrived at or after this (zero = no bound)
+	filterUntil     time.Time         // Only items that arrived before this (zero = no bound)
 	// Status message for user feedback
-	statusMessage string // Temporary status message to display
-	flashItem     int    // Index of item to flash (-1 for none)
+	statusMessage string         // Sticky prompt or progress text (e.g. confirmations, "Pruning...")
+	statusOp      *operations.Op // The operation the progress text reports; Esc cancels it (nil for none)
+	toasts        []toast        // Visible notifications, oldest first
+	toastHistory  ring[toast]    // Recent notifications for :messages
+	toastSeq      int            // Last toast id handed out
+	recentMsgs    ring[string]   // Recent messages handled, for crash reports and the debug overlay
+	debug         *debugTracer   // Debug overlay and trace (--debug, :set debug); nil when off
+	failures      []failure      // Failed operations for :errors, oldest first
+	failureSeq    int            // Last failure id handed out
+	flashItem     int            // Index of item to flash (-1 for none)
 	// Modal state
-	sourceModal SourceModal // Modal for managing sources
-	helpModal   HelpModal   // Modal for keyboard shortcuts help
+	sourceModal   SourceModal        // Modal for managing sources
+	helpModal     HelpModal          // Modal for keyboard shortcuts help
+	messagesModal MessagesModal      // Modal for recent notifications
+	errorsModal   ErrorsModal        // Modal for failed operations (:errors)
+	digestModal   DigestModal        // Modal for the daily digest
+	transcript    TranscriptModal    // Audio briefing script and sources (:transcript)
+	triageModal   TriageModal        // Modal for :triage sessions
+	dedupeModal   DedupeModal        // Modal for :sources dedupe
+	contextReview ContextReviewModal // Modal for :context review
+	contextEditor ContextEditorModal // Built-in context.md editor (:context edit)
+	chat          ChatModal          // Conversations about articles (:ask)
+	palette       CommandPalette     // Ctrl-P fuzzy picker (also the Ctrl-T title finder)
+	finderItems   []db.ContentItem   // Items behind the open title finder
+	listFilter    ListFilter         // "/" type-to-filter bar
+	listBase      []db.ContentItem   // Loaded items before the type-to-filter query
+	// Preview pane (:set preview)
+	zen           bool           // Distraction-free reader (:zen / Z)
+	showWhy       bool           // Expand the reader's WHY section (w)
+	showPreview   bool           // Split the list pane with a summary preview
+	preview       viewport.Model // Scrollable summary of the item under the cursor
+	previewItemID string         // Item currently rendered in the preview
+	// Sidebar (:set sidebar, < / >), persisted in ui_state.json
+	hideSidebar    bool
+	sidebarPercent int // Share of the width; 0 means the default
+	// Reader offsets per article; saved on quit with [tui] remember_scroll
+	scrolls        scrollPositions
+	readerItemID   string // Article the reader viewport holds
+	persistScrolls bool
+	// Automatic mark-read ([tui] mark_read, :set markread)
+	markRead      string         // "never", "open", "dwell", or "end"
+	markReadDelay time.Duration  // How long "dwell" waits
+	markReadItem  string         // Article the reader last armed
+	markReadSent  string         // Article already auto-marked, so it isn't sent twice
+	markReadSeq   int            // Invalidates dwell timers for articles since left
+	readingItem   db.ContentItem // Article open in the reader, for the history
+	readingSince  time.Time      // When readingItem was opened
+	goal          readingGoal    // Daily reading goal, zero for none
+	goalStatus    goalStatusMsg  // Today's progress toward goal
+	// Row density (:set density=compact), persisted in ui_state.json
+	compact bool // One line per item, with the selected item's metadata in a footer
+	// Ctrl-W window commands
+	windowPending bool // Ctrl-W was pressed; the next key picks the command
+	// Jump list (Ctrl-O / Ctrl-I)
+	jumps       jumpList
+	jumping     bool        // Restoring a jump; don't record the moves it makes
 	commandMode CommandMode // Neovim-style command mode
 	// Auto-refresh state
-	refreshInterval time.Duration // Interval for auto-refresh (0 = disabled)
+	refreshInterval time.Duration   // Interval for auto-refresh (0 = disabled)
+	refreshGen      int             // Bumped whenever a timer is scheduled, retiring the old one
+	blurredInterval time.Duration   // Auto-refresh interval while the terminal lacks focus ([refresh] blurred)
+	blurred         bool            // The terminal reported losing focus
+	awaySeen        map[string]bool // Item IDs listed when focus was lost, for the welcome-back toast
+	refreshHeld     bool            // A tick came while the reader or a modal was open; refresh once they close
+	start           startState      // Command-line start options waiting on the first load
 	// Prune confirmation state
 	pruneConfirm pruneConfirmState
+	// :markall confirmation, and the last batch for :markall undo
+	markAllConfirm markAllConfirmState
+	sourceRenames  []operations.SourceRename // :sources rename batch waiting for y/n
+	sourceImport   importState               // Running :import
+	restoreConfirm db.BackupInfo             // :restore backup waiting for y/n
+	lastMarkAll    []string
+	// Offered after :context add adds a topic
+	reanalyzeConfirm bool
 	// Sources viewport for scrollable source list
 	sourcesViewport viewport.Model // Viewport for source list scrolling
 	// Pane focus system (vim-style)
@@ -59,9 +141,45 @@ type Model struct {
 	// Theme system
 	theme StyleTheme // Current color theme
 	// Remote mode
-	remoteURL  string           // If non-empty, use API instead of local DB
-	lastSync   time.Time        // Last successful API fetch timestamp
-	itemsCache []db.ContentItem // Cached items for remote mode
+	remoteURL      string            // If non-empty, use API instead of local DB
+	daemon         *api.VersionInfo  // Daemon version handshake; nil until it answers
+	schema         *db.Schema        // Local database's columns; nil in remote mode or until read
+	lastSync       time.Time         // Last successful API fetch timestamp
+	lastSyncAt     time.Time         // Wall-clock time of the last successful remote sync
+	latency        time.Duration     // API round trip measured during that sync
+	syncFailed     bool              // The latest remote sync failed (daemon unreachable)
+	itemsCache     []db.ContentItem  // Cached items for remote mode
+	archivedCache  []db.ContentItem  // Archived items for remote mode, fetched once a search or the archived view needs them
+	loadingItem    string            // Item whose content the reader is loading (see loadOpenItem)
+	readerRenders  readerRenderCache // Rendered articles, including the reader's prefetched neighbours
+	prefetchedAt   string            // Article and width whose neighbours were last prefetched
+	fetchSeq       int               // Latest list fetch (newFetch); results of earlier ones are stale
+	offline        bool              // Keep unread HIGH/MEDIUM items on disk ([remote] offline_cache)
+	offlineBusy    bool              // An offline cache write is running
+	linkStatus     map[string]int    // HTTP status of checked article URLs; -1 while a check runs
+	checkLinks     bool              // Background link checks and dead/paywall badges ([tui] check_links, :set linkcheck)
+	linkTicking    bool              // The background link check loop is scheduled
+	paywallDomains []string          // Extra paywalled domains from [tui] paywall_domains
+	hyperlinks     bool              // OSC 8 links on titles and URLs ([tui] hyperlinks, :set hyperlinks)
+	noWrap         bool              // Reader lines run past the pane and ←/→ scroll sideways (:set nowrap)
+	rowFormat      rowFormat         // List row template from [tui] row_format; nil uses the built-in layout
+	rowFormatErr   error             // Why row_format was rejected, shown once at startup
+	keymap         map[string]string // [keys]: key → key it acts as, or :command it runs
+	keymapErr      error             // Why [keys] entries were dropped, shown once at startup
+	colors         colorOverrides    // [colors] overrides, applied to whichever theme is current
+	colorsErr      error             // Why [colors] entries were dropped, shown once at startup
+	colorDepth     string            // :set colors: auto, or truecolor, 256 or 16 forced
+	background     string            // :set background: auto, or light or dark forced
+	// Offline write queue
+	pendingWrites int            // Read/favorite/vote changes waiting for the daemon
+	syncConflicts []syncConflict // Read/favorite states the daemon overrode at a sync (:sync status)
+
+	player *audioPlayer // Plays :listen narrations in the background
+	// Background sync (remote mode)
+	syncer       *syncWorker      // Runs remote fetches off the Update path
+	syncing      bool             // A remote sync is running
+	syncProgress api.SyncProgress // Latest progress of the running sync
+	syncSpinner  spinner.Model    // Header spinner while syncing
 }
 
 // itemsLoadedMsg represents content items loaded from database
@@ -72,10 +190,20 @@ type itemsLoadedMsg struct {
 	preserveCursor bool   // If true, try to preserve cursor position
 	targetItemID   string // Item ID to position cursor on (if preserveCursor is true)
 	isAutoRefresh  bool   // If true, this was triggered by auto-refresh timer
+	jumpToID       string // Item ID to move the cursor to (title finder, jump list)
+	openReader     bool   // Open jumpToID in the reader once found (jump list)
 	// Remote mode fields
 	allItems    []db.ContentItem // Unfiltered items for caching (remote mode only)
 	updateCache bool             // If true, update cache and lastSync
 	newLastSync time.Time        // Newest fetched_at timestamp from API (remote mode only)
+	offlineAt   time.Time        // Set when items came from the offline cache: when it was saved
+	syncedAt    time.Time        // When a remote sync succeeded (zero for re-filters and failures)
+	latency     time.Duration    // Fastest API round trip during that sync
+	conflicts   []syncConflict   // Cached read/favorite states the daemon's replaced
+	fullResync  bool             // From :sync full, which started without a cache
+	syncFailed  bool             // A remote sync was attempted and failed
+	archived    []db.ContentItem // Archived items fetched for the list's scope (remote mode only)
+	seq         int              // The list fetch this answers (Model.fetchSeq when it started)
 }
 
 // sourcesLoadedMsg represents sources loaded from database
@@ -84,14 +212,20 @@ type sourcesLoadedMsg struct {
 	err     error
 }
 
-// clearStatusMsg is sent to clear the status message after a delay
+// clearStatusMsg is sent to clear the source modal's status message after a delay
 type clearStatusMsg struct{}
 
 // clearFlashMsg is sent to clear the flash effect after a delay
 type clearFlashMsg struct{}
 
 // autoRefreshMsg is sent by the timer to trigger automatic refresh
-type autoRefreshMsg struct{}
+type autoRefreshMsg struct {
+	gen int // Matches refreshGen while this timer is current
+}
+
+// reanalyzeDays is how far back :context add offers to re-evaluate
+// unprioritized items against the new topic
+const reanalyzeDays = 7
 
 // pruneConfirmState tracks the prune confirmation workflow
 type pruneConfirmState struct {
@@ -134,18 +268,68 @@ func newModel(remoteURL string) Model {
 		flashItem:     -1,               // No item flashing initially
 		sourceModal:   NewSourceModal(), // Initialize source modal
 		helpModal:     NewHelpModal(),   // Initialize help modal
+		messagesModal: NewMessagesModal(),
+		errorsModal:   NewErrorsModal(),
+		digestModal:   NewDigestModal(),
+		transcript:    NewTranscriptModal(),
+		triageModal:   NewTriageModal(),
+		dedupeModal:   NewDedupeModal(),
+		contextReview: NewContextReviewModal(),
+		contextEditor: NewContextEditorModal(),
+		chat:          NewChatModal(),
+		palette:       NewCommandPalette(),
+		listFilter:    NewListFilter(),
 		commandMode:   NewCommandMode(), // Initialize command mode
 		// Initialize sources viewport
 		sourcesViewport: viewport.New(20, 10), // Will be resized properly in View()
 		focusedPane:     "content",            // Start with content focused (list or reader)
 		// Initialize theme
-		theme:     CleanCyberTheme, // Default theme
-		remoteURL: remoteURL,       // Remote mode if non-empty
+		theme:      CleanCyberTheme, // Default theme
+		remoteURL:  remoteURL,       // Remote mode if non-empty
+		hyperlinks: true,
+	}
+
+	if cfg, err := config.LoadConfig(); err == nil {
+		m.checkLinks = cfg.TUI.CheckLinks
+		m.linkTicking = m.checkLinks // Init starts the loop
+		m.paywallDomains = cfg.TUI.PaywallDomains
+		m.hyperlinks = cfg.HyperlinksEnabled()
+		m.noWrap = !cfg.WrapEnabled()
+		m.showAll = cfg.TUI.ShowAll
+		m.goal, _ = parseGoal(cfg.TUI.Goal) // A bad goal is just no goal
+		m.markRead, m.markReadDelay, _ = parseMarkRead(cfg.TUI.MarkRead, time.Duration(cfg.TUI.MarkReadDelay)*time.Second)
+		if cfg.TUI.RememberScroll {
+			m.persistScrolls = true
+			m.scrolls, _ = loadScrollPositions() // Unreadable file: start fresh
+		}
+		if cfg.TUI.RowFormat != "" {
+			m.rowFormat, m.rowFormatErr = parseRowFormat(cfg.TUI.RowFormat)
+		}
+		m.keymap, m.keymapErr = parseKeymap(cfg.Keys)
+		m.colors, m.colorsErr = parseColors(cfg.Colors)
+		m.colorDepth, _ = parseColorDepth(cfg.TUI.Colors)     // A bad depth is just auto
+		m.background, _ = parseBackground(cfg.TUI.Background) // Likewise a bad background
+	}
+	m.useTheme(themeForBackground(m.theme.Name, m.lightBackground()))
+
+	// Restore the saved layout; a bad state file just means defaults
+	if state, err := loadUIState(); err == nil {
+		m.hideSidebar = state.SidebarHidden
+		m.sidebarPercent = state.SidebarPercent
+		m.compact = state.Density == "compact"
+		m.viewSorts = state.Sorts
+		m.viewExprs = state.Filters
+		m.savedSearches = state.Searches
 	}
 
 	// Propagate remote URL to source modal for API-based source fetching
 	if remoteURL != "" {
 		m.sourceModal.SetRemoteURL(remoteURL)
+		m.syncer = newSyncWorker()
+		m.syncSpinner = spinner.New(spinner.WithSpinner(spinner.Dot))
+		if cfg, err := config.LoadConfig(); err == nil {
+			m.offline = cfg.OfflineCacheEnabled()
+		}
 	}
 
 	return m
@@ -153,7 +337,8 @@ func newModel(remoteURL string) Model {
 
 // initRefreshMsg is sent to trigger refresh interval setup
 type initRefreshMsg struct {
-	interval time.Duration
+	interval        time.Duration
+	blurredInterval time.Duration
 }
 
 // Init initializes the model and returns a command to fetch initial content
@@ -164,16 +349,41 @@ func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		fetchItemsWithState(m, true),
 		fetchSources(m.remoteURL),
+		operations.ListenRateLimits(),
+		operations.CountPendingWrites(),
+		operations.CheckDaemonVersion(),
+		m.refreshGoal(),
+	}
+	if m.remoteURL == "" {
+		cmds = append(cmds, checkSchema)
+	}
+	if m.syncer != nil {
+		cmds = append(cmds, m.syncer.listen())
+	}
+	if m.checkLinks {
+		cmds = append(cmds, linkCheckTick())
+	}
+	if m.rowFormatErr != nil {
+		err := m.rowFormatErr
+		cmds = append(cmds, func() tea.Msg { return rowFormatInvalidMsg{err: err} })
+	}
+	if m.keymapErr != nil {
+		err := m.keymapErr
+		cmds = append(cmds, func() tea.Msg { return keymapInvalidMsg{err: err} })
+	}
+	if m.colorsErr != nil {
+		err := m.colorsErr
+		cmds = append(cmds, func() tea.Msg { return colorsInvalidMsg{err: err} })
 	}
 
 	// Load config and send refresh interval as message
 	if cfg, err := config.LoadConfig(); err == nil {
-		interval := cfg.GetRefreshInterval()
-		if interval > 0 {
-			refreshInterval := time.Duration(interval) * time.Second
+		interval := time.Duration(cfg.GetRefreshInterval()) * time.Second
+		blurred := time.Duration(cfg.GetBlurredRefreshInterval()) * time.Second
+		if interval > 0 || blurred > 0 {
 			// Send message to set up refresh (can't modify model in Init)
 			cmds = append(cmds, func() tea.Msg {
-				return initRefreshMsg{interval: refreshInterval}
+				return initRefreshMsg{interval: interval, blurredInterval: blurred}
 			})
 		}
 	}
@@ -181,8 +391,53 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-// Update handles messages and updates the model state
+// Update handles messages and updates the model state, recording a jump
+// whenever the view, filters, or article being read change
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	desc := describeMsg(msg)
+	if _, tick := msg.(spinner.TickMsg); !tick {
+		m.recentMsgs.push(desc, maxRecentMsgs) // The spinner would crowd out the rest
+	}
+	defer m.reportPanic("Update(" + desc + ")")
+	began := time.Now()
+
+	before := m.location()
+	updated, cmd := m.update(msg)
+	next, ok := updated.(Model)
+	if !ok {
+		return updated, cmd
+	}
+	if !m.jumping && !next.jumping && !next.location().same(before) {
+		next.jumps = next.jumps.record(before)
+	}
+	if next.zen && next.view != "reader" {
+		// Leaving the reader ends zen mode
+		next.zen = false
+	}
+	next.syncPreview()
+	if load := next.loadOpenItem(); load != nil {
+		cmd = tea.Batch(cmd, load)
+	}
+	if prefetch := next.prefetchNeighbours(); prefetch != nil {
+		cmd = tea.Batch(cmd, prefetch)
+	}
+	if mark := next.autoMarkRead(); mark != nil {
+		cmd = tea.Batch(cmd, mark)
+	}
+	if record := next.trackReading(time.Now()); record != nil {
+		cmd = tea.Batch(cmd, record)
+	}
+	if resume := next.resumeRefresh(); resume != nil {
+		cmd = tea.Batch(cmd, resume)
+	}
+	if next.debug != nil {
+		next.debug.traceUpdate(desc, time.Since(began), next, cmd != nil)
+	}
+	return next, cmd
+}
+
+// update is Update without jump recording
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -195,24 +450,93 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport.Width = msg.Width - 4
 		m.viewport.Height = msg.Height - 8
 		m.ready = true
-		// Set sources viewport size (sidebar is 1/4 width)
-		sidebarWidth := msg.Width / 4
-		if sidebarWidth < 30 {
-			sidebarWidth = 30
-		}
-		m.sourcesViewport.Width = sidebarWidth - 2 // Padding for borders
+		// Set sources viewport size to fit the sidebar
+		m.sourcesViewport.Width = max(0, m.layout().sidebarWidth-2) // Padding for borders
 		// Sources take ~65% of sidebar height (after stats section)
 		sidebarHeight := msg.Height - 6 // Account for header/footer
 		m.sourcesViewport.Height = (sidebarHeight * 65) / 100
 		// Update modal sizes
 		m.sourceModal.SetSize(msg.Width, msg.Height)
 		m.helpModal.SetSize(msg.Width, msg.Height)
+		m.messagesModal.SetSize(msg.Width, msg.Height)
+		m.errorsModal.SetSize(msg.Width, msg.Height)
+		m.digestModal.SetSize(msg.Width, msg.Height)
+		m.transcript.SetSize(msg.Width, msg.Height)
+		m.triageModal.SetSize(msg.Width, msg.Height)
+		m.dedupeModal.SetSize(msg.Width, msg.Height)
+		m.contextReview.SetSize(msg.Width, msg.Height)
+		m.contextEditor.SetSize(msg.Width, msg.Height)
+		m.chat.SetSize(msg.Width, msg.Height)
+		m.palette.SetSize(msg.Width, msg.Height)
 		m.commandMode.SetWidth(msg.Width)
+		if m.zenActive() {
+			m.updateReaderContent()
+		}
+
+	case syncProgressMsg:
+		// Sync events are handled before modals so the worker is always re-listened
+		if !m.syncing {
+			m.syncing = true
+			cmds = append(cmds, m.syncSpinner.Tick)
+		}
+		m.syncProgress = msg.progress
+		if msg.items != nil && len(m.itemsCache) == 0 {
+			// First sync: show the pages that have arrived
+			m.loading = false
+			m.listBase = applyFiltersClientSide(msg.items, m)
+			m.items = filterListQuery(m.listBase, m.listFilter.Query())
+		}
+		return m, tea.Batch(append(cmds, m.syncer.listen())...)
+
+	case syncDoneMsg:
+		m.syncing = false
+		m.syncProgress = api.SyncProgress{}
+		// The result goes through Update as its own message, so the
+		// post-update hooks run once for each
+		result := msg.result
+		return m, tea.Batch(func() tea.Msg { return result }, m.syncer.listen())
+
+	case spinner.TickMsg:
+		// Keep the header spinner turning only while a sync runs in view;
+		// focus coming back restarts it
+		if !m.syncing || m.blurred {
+			return m, nil
+		}
+		m.syncSpinner, cmd = m.syncSpinner.Update(msg)
+		return m, cmd
+
+	case uiStateSaveFailedMsg:
+		return m, m.notify(toastWarn, fmt.Sprintf("Layout not saved: %v", msg.err), 5*time.Second)
+
+	case toastExpiredMsg:
+		// Expire toasts even while a modal has focus
+		m.expireToast(msg.id)
+		return m, nil
+
+	case operationFailedMsg:
+		// Keep the failure for :errors, then handle the result as its own message
+		m.recordFailure(msg.failure)
+		result := msg.result
+		return m, func() tea.Msg { return result }
+
+	case failureRetryMsg:
+		return m, m.retryFailure(msg.id)
+
+	case failureDismissedMsg:
+		m.takeFailure(msg.id)
+		return m, nil
 
 	case initRefreshMsg:
-		// Set refresh interval and start timer
+		// Set refresh intervals and start timer
 		m.refreshInterval = msg.interval
-		return m, autoRefreshCmd(m.refreshInterval)
+		m.blurredInterval = msg.blurredInterval
+		return m, m.scheduleRefresh()
+
+	case tea.FocusMsg:
+		return m, m.setFocus(true)
+
+	case tea.BlurMsg:
+		return m, m.setFocus(false)
 	}
 
 	// Handle command mode updates first (highest priority)
@@ -228,6 +552,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	// The :sources rename preview takes y/n
+	if key, isKey := msg.(tea.KeyMsg); isKey && m.sourceRenames != nil {
+		return m.confirmSourceRenames(key)
+	}
+
 	// Handle source modal updates if it's visible
 	if m.sourceModal.IsVisible() {
 		m.sourceModal, cmd = m.sourceModal.Update(msg)
@@ -244,6 +573,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle messages modal updates if it's visible
+	if m.messagesModal.IsVisible() {
+		m.messagesModal, cmd = m.messagesModal.Update(msg)
+		return m, cmd
+	}
+
+	// Handle errors modal updates if it's visible
+	if m.errorsModal.IsVisible() {
+		m.errorsModal, cmd = m.errorsModal.Update(msg)
+		return m, cmd
+	}
+
+	// Handle digest modal updates if it's visible
+	if m.digestModal.IsVisible() {
+		m.digestModal, cmd = m.digestModal.Update(msg)
+		return m, cmd
+	}
+
+	// The transcript takes keys only; a briefing finishing behind it still lands
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.transcript.IsVisible() {
+		m.transcript, cmd = m.transcript.Update(msg)
+		return m, cmd
+	}
+
+	// Context review takes keys only; loads and results still reach the handlers below
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.contextReview.IsVisible() {
+		m.contextReview, cmd = m.contextReview.Update(msg)
+		return m, cmd
+	}
+
+	// Triage takes keys only; verdict results still reach the handlers below
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.triageModal.IsVisible() {
+		m.triageModal, cmd = m.triageModal.Update(msg)
+		return m, cmd
+	}
+
+	// Dedupe takes keys only; merge results still reach the handlers below
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.dedupeModal.IsVisible() {
+		m.dedupeModal, cmd = m.dedupeModal.Update(msg)
+		return m, cmd
+	}
+
+	// The context editor takes keys only so its save result reaches the handler below
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.contextEditor.IsVisible() {
+		m.contextEditor, cmd = m.contextEditor.Update(msg)
+		return m, cmd
+	}
+
+	// The chat takes keys only so streamed answers reach the handlers below
+	if _, isKey := msg.(tea.KeyMsg); isKey && m.chat.IsVisible() {
+		m.chat, cmd = m.chat.Update(msg)
+		return m, cmd
+	}
+
+	// Handle command palette updates if it's visible
+	if m.palette.IsVisible() {
+		m.palette, cmd = m.palette.Update(msg)
+		return m, cmd
+	}
+
 	// Handle view-specific updates - only update reader viewport when content pane is focused
 	if m.view == "reader" && m.focusedPane == "content" {
 		// Update viewport in reader view only when it has focus
@@ -254,6 +643,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case commands.RefreshMsg:
 		// Handle refresh command
+		if m.pendingWrites > 0 {
+			cmds = append(cmds, operations.ReplayPendingWrites())
+		}
 		if msg.PreserveCursor && m.view == "list" && !m.loading {
 			// Save current item ID to restore position if possible
 			var currentItemID string
@@ -262,25 +654,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			m.loading = true
+			m.newFetch()
+
+			// Remote mode: sync in the background, keeping the list on screen
+			if m.remoteURL != "" {
+				m.statusOp = operations.NewOp()
+				cmds = append(cmds, remoteFetch(syncJob{model: m, preserveCursor: true, targetItemID: currentItemID, op: m.statusOp}))
+				return m, tea.Batch(cmds...)
+			}
 
 			// Create refresh command that preserves position (same as old 'r' key)
-			refreshCmd := func() tea.Msg {
+			snapshot := m
+			refreshCmd := withSeq(m.fetchSeq, func() itemsLoadedMsg {
 				var result itemsLoadedMsg
+				m := snapshot
 
-				// Check if remote mode
-				if m.remoteURL != "" {
-					result = fetchItemsRemote(m)
+				// Fetch all content, filter client-side (unified with remote mode)
+				allItems, err := db.GetContentIn(m.archiveScope(), m.filterPredicates()...)
+				if err != nil {
+					result = itemsLoadedMsg{err: err}
 				} else {
-					// Fetch all content, filter client-side (unified with remote mode)
-					allItems, err := db.GetAllContent(m.showArchived)
-					if err != nil {
-						result = itemsLoadedMsg{err: err}
-					} else {
-						result = itemsLoadedMsg{
-							items:       applyFiltersClientSide(allItems, m),
-							hiddenCount: countHiddenUnprioritized(allItems, m),
-							err:         nil,
-						}
+					result = itemsLoadedMsg{
+						items:       applyFiltersClientSide(allItems, m),
+						hiddenCount: countHiddenUnprioritized(allItems, m),
+						err:         nil,
 					}
 				}
 
@@ -288,15 +685,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				result.preserveCursor = true
 				result.targetItemID = currentItemID
 				return result
-			}
+			})
 
-			return m, refreshCmd
+			cmds = append(cmds, refreshCmd)
+			return m, tea.Batch(cmds...)
 		} else {
 			// Simple refresh without cursor preservation
 			m.loading = true
-			return m, fetchItemsWithState(m, true)
+			cmds = append(cmds, m.fetchList(true))
+			return m, tea.Batch(cmds...)
 		}
 
+	case rowFormatInvalidMsg:
+		return m, m.notify(toastWarn, fmt.Sprintf("Ignoring [tui] row_format: %v", msg.err), 8*time.Second)
+
+	case keymapInvalidMsg:
+		return m, m.notify(toastWarn, msg.err.Error(), 8*time.Second)
+
+	case colorsInvalidMsg:
+		return m, m.notify(toastWarn, msg.err.Error(), 8*time.Second)
+
 	case commands.ErrorMsg:
 		// Show error in command line instead of status
 		cmd := m.commandMode.SetError(msg.Message)
@@ -304,25 +712,159 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case commands.HelpMsg:
 		// Show the help modal (same as pressing ?)
-		m.helpModal.Show()
+		m.helpModal.SetSize(m.width, m.height)
+		m.helpModal.Open(m.helpRows())
 		return m, nil
 
 	case commands.AddSourceMsg:
 		// Add source (refresh happens in response to success message)
-		return m, operations.AddSource(msg.URL, "")
+		return m, retryable("add source", msg.URL, operations.AddSource(msg.URL, "", ""))
 
 	case commands.RemoveSourceMsg:
 		// Remove source (refresh happens in response to success message)
 		return m, operations.RemoveSource(msg.Identifier)
 
+	case paletteSelectedMsg:
+		// Run the entry picked in the command palette
+		entry := msg.entry
+		switch entry.kind {
+		case "view":
+			if entry.category != "" {
+				return m.Update(commands.FilterMsg{Field: "category", Value: entry.category})
+			}
+			// Replay the view's hotkey so the palette and keys can't drift apart
+			return m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(entry.key)})
+		case "source":
+			return m.Update(commands.FilterMsg{Field: "source", Value: entry.sourceID})
+		case "item":
+			for _, item := range m.finderItems {
+				if item.ID != entry.itemID {
+					continue
+				}
+				m.finderItems = nil
+				m.view = "list"
+				// Already listed: just move there
+				for i, listed := range m.items {
+					if listed.ID == item.ID {
+						m.cursor = i
+						return m, nil
+					}
+				}
+				m.revealItem(item)
+				m.loading = true
+				cmd := m.jumpToItem(item.ID)
+				return m, cmd
+			}
+		case "command":
+			if entry.args {
+				m.commandMode.ShowWith(strings.Join(entry.line, " ") + " ")
+				return m, nil
+			}
+			if entry.unavailable != "" {
+				message := entry.unavailable
+				return m, func() tea.Msg { return commands.ErrorMsg{Message: message} }
+			}
+			return m, commands.NewRegistry().Execute(entry.line[0], entry.line[1:])
+		}
+
+	case commands.FindMsg:
+		// Load every item for the title finder
+		return m, loadFinderItems(m, msg.Query)
+
+	case finderItemsMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Find failed: %v", msg.err), 5*time.Second)
+		}
+		m.finderItems = msg.items
+		m.palette.SetSize(m.width, m.height)
+		m.palette.Open("Find an item by title", msg.query, finderEntries(msg.items))
+		return m, nil
+
+	case digestItemsMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Digest failed: %v", msg.err), 5*time.Second)
+		}
+		now := time.Now()
+		items := digestItems(msg.items, msg.includeMedium, now)
+		markdown := buildDigest(items, now)
+		if msg.export == "markdown" {
+			return m, exportDigest(markdown, now)
+		}
+		m.digestModal.SetSize(m.width, m.height)
+		m.digestModal.Open(markdown)
+		return m, nil
+
+	case digestExportedMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Digest export failed: %v", msg.err), 5*time.Second)
+		}
+		return m, m.notify(toastSuccess, "Digest saved to "+msg.path, 5*time.Second)
+
+	case commands.SearchMsg:
+		return m, m.handleSearch(msg)
+
+	case commands.SortMsg:
+		// Sort order for the current priority view, kept across restarts
+		spec := strings.TrimSpace(msg.Spec)
+		switch strings.ToLower(spec) {
+		case "":
+			return m, m.notify(toastInfo, fmt.Sprintf("sort (%s): %s", m.priority, formatSortSpec(m.sortOrder())), 3*time.Second)
+		case "default", "reset":
+			delete(m.viewSorts, m.priority)
+		default:
+			keys, err := parseSortSpec(spec)
+			if err != nil {
+				return m, m.notify(toastError, fmt.Sprintf("sort: %v", err), 3*time.Second)
+			}
+			if m.viewSorts == nil {
+				m.viewSorts = make(map[string]string)
+			}
+			m.viewSorts[m.priority] = formatSortSpec(keys)
+		}
+		sortItems(m.items, m.sortOrder())
+		m.cursor = 0
+		return m, tea.Batch(
+			saveUIState(m.savedLayout()),
+			m.notify(toastInfo, fmt.Sprintf("sort (%s): %s", m.priority, formatSortSpec(m.sortOrder())), 3*time.Second),
+		)
+
+	case commands.SetOptionMsg:
+		// Runtime options (see the table in options.go)
+		return m, m.setOption(msg)
+
+	case commands.ZenMsg:
+		m.toggleZen()
+		return m, nil
+
+	case commands.MessagesMsg:
+		// Review recent notifications
+		m.messagesModal.SetSize(m.width, m.height)
+		m.messagesModal.Open(m.toastHistory.list())
+		return m, nil
+
+	case commands.ErrorsMsg:
+		// Review failed operations and retry them
+		m.errorsModal.SetSize(m.width, m.height)
+		m.errorsModal.Open(m.failures)
+		return m, nil
+
 	case commands.ShowLogsMsg:
 		// Show logs (placeholder for now)
 		return m, operations.ShowLogs()
 
 	case commands.PruneMsg:
 		// Handle prune command with optional confirmation
+		if refuse := m.refuseMissing(api.FeaturePrune); refuse != nil {
+			return m, refuse
+		}
 		return m, operations.HandlePruneCommand(msg)
 
+	case operations.DaemonVersionMsg:
+		return m, m.handleDaemonVersion(msg)
+
+	case schemaCheckedMsg:
+		return m, m.handleSchema(msg)
+
 	case commands.PauseSourceMsg:
 		// Pause source (refresh happens in response to success message)
 		return m, operations.PauseSource(msg.URL)
@@ -335,41 +877,357 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Edit source name using the identifier lookup
 		return m, operations.EditSourceName(msg.Identifier, msg.NewName)
 
+	case commands.BackupMsg:
+		return m, m.startBackup(msg)
+
+	case backupDoneMsg:
+		if msg.err != nil {
+			m.statusMessage = ""
+			return m, m.notify(toastError, fmt.Sprintf("Backup failed: %v", msg.err), 6*time.Second)
+		}
+		m.statusMessage = "Backed up to " + describeBackup(msg.info)
+		return m, nil
+
+	case commands.RestoreMsg:
+		return m, m.startRestore(msg)
+
+	case restoreCheckedMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Cannot restore: %v", msg.err), 6*time.Second)
+		}
+		m.restoreConfirm = msg.info
+		m.statusMessage = fmt.Sprintf("Restore %s? It replaces the local database (y/n)", describeBackup(msg.info))
+		return m, nil
+
+	case restoreDoneMsg:
+		return m, m.restoreDone(msg)
+
+	case commands.DBMaintenanceMsg:
+		return m, m.startDBMaintenance(msg)
+
+	case commands.SyncMsg:
+		if msg.Action == "full" {
+			return m, m.startFullResync()
+		}
+		return m, m.showSyncReport()
+
+	case dbMaintenanceMsg:
+		return m, m.dbMaintenanceDone(msg)
+
+	case commands.ImportSourcesMsg:
+		return m, m.startImport(msg)
+
+	case operations.SubscriptionsLoadedMsg:
+		return m, m.subscriptionsLoaded(msg)
+
+	case operations.SourceImportedMsg:
+		return m, m.sourceImported(msg)
+
+	case commands.DedupeSourcesMsg:
+		// Step through duplicate sources, asking which to keep
+		if refuse := m.refuseMissing(api.FeatureMerge); refuse != nil {
+			return m, refuse
+		}
+		groups := duplicateSourceGroups(m.sources)
+		if len(groups) == 0 {
+			return m, m.notify(toastInfo, "No duplicate sources found", 3*time.Second)
+		}
+		m.dedupeModal.SetSize(m.width, m.height)
+		m.dedupeModal.Start(groups)
+		return m, nil
+
+	case dedupeMergeMsg:
+		ids := make([]string, len(msg.merge))
+		for i, source := range msg.merge {
+			ids[i] = source.ID
+		}
+		name := msg.keep.Name
+		if name == "" {
+			name = msg.keep.URL
+		}
+		return m, operations.MergeSources(msg.keep.ID, name, ids)
+
+	case operations.SourcesMergedMsg:
+		if msg.Error != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Merging into %s failed: %v", msg.KeepName, msg.Error), 5*time.Second)
+		}
+		// Items changed source, so the list reloads too
+		return m, tea.Batch(
+			fetchSources(m.remoteURL),
+			func() tea.Msg { return commands.RefreshMsg{PreserveCursor: true} },
+			m.notify(toastSuccess, fmt.Sprintf("Merged %d sources into %s (%d items moved)", msg.Merged, msg.KeepName, msg.Moved), 4*time.Second),
+		)
+
+	case commands.RenameSourcesMsg:
+		// Preview a bulk rename before anything is written
+		return m, m.startSourceRenames(msg)
+
+	case operations.SourcesRenamedMsg:
+		return m, m.sourcesRenamed(msg)
+
 	case commands.AudioMsg:
-		// Generate audio briefing from HIGH priority content
-		m.statusMessage = "Generating audio briefing..."
-		return m, operations.GenerateAudioBriefing()
+		// Generate audio briefing (HIGH priority, last 24h unless overridden)
+		if refuse := m.refuseMissing(api.FeatureAudio); refuse != nil {
+			return m, refuse
+		}
+		op := m.startStatusOp("Generating audio briefing...")
+		return m, retryable("audio briefing", cmp.Or(msg.MinPriority, "high")+" items", operations.GenerateAudioBriefing(op, api.AudioBriefingOptions{
+			MinPriority: msg.MinPriority,
+			Hours:       msg.Hours,
+			Voice:       msg.Voice,
+			MaxItems:    msg.MaxItems,
+		}))
+
+	case linkCheckTickMsg:
+		if !m.checkLinks || m.blurred {
+			m.linkTicking = false // Focus coming back restarts it
+			return m, nil
+		}
+		m.linkTicking = true
+		return m, tea.Batch(m.nextLinkCheck(), linkCheckTick())
+
+	case offlineSavedMsg:
+		m.offlineBusy = false
+		if msg.err != nil {
+			return m, m.notify(toastWarn, fmt.Sprintf("Offline cache failed: %v", msg.err), 5*time.Second)
+		}
+		return m, nil
+
+	case commands.TranscriptMsg:
+		if refuse := m.refuseMissing(api.FeatureAudio); refuse != nil {
+			return m, refuse
+		}
+		m.statusMessage = "Loading transcript..."
+		return m, operations.LoadBriefingTranscript(msg.Filename)
+
+	case operations.BriefingTranscriptMsg:
+		m.statusMessage = "" // Clear "Loading transcript..."
+		switch {
+		case errors.Is(msg.Error, api.ErrNotFound):
+			return m, m.notify(toastInfo, "No briefing transcript yet (:audio makes one)", 4*time.Second)
+		case operations.IsCancelled(msg.Error):
+			return m, m.notify(toastInfo, "Transcript cancelled", 3*time.Second)
+		case msg.Error != nil:
+			return m, m.notify(toastError, fmt.Sprintf("Transcript failed: %v", msg.Error), 5*time.Second)
+		}
+		m.transcript.SetSize(m.width, m.height)
+		m.transcript.Open(msg.Transcript)
+		return m, nil
+
+	case transcriptOpenMsg:
+		// A briefing source: open it directly when listed, else look it up
+		for i, item := range m.items {
+			if item.URL == msg.url {
+				m.cursor = i
+				m.view = "reader"
+				m.updateReaderContent()
+				return m, nil
+			}
+		}
+		return m, loadTranscriptItems(m, msg.url)
+
+	case transcriptItemsMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Open failed: %v", msg.err), 5*time.Second)
+		}
+		for _, item := range msg.items {
+			if item.URL != msg.url {
+				continue
+			}
+			m.revealItem(item)
+			m.view = "list"
+			m.loading = true
+			fetch := m.jumpToItem(item.ID)
+			return m, func() tea.Msg {
+				loaded := fetch()
+				if l, ok := loaded.(itemsLoadedMsg); ok {
+					l.openReader = true
+					return l
+				}
+				return loaded
+			}
+		}
+		return m, m.notify(toastWarn, "Not in your library: "+msg.url, 5*time.Second)
+
+	case commands.TriageMsg:
+		m.triageModal.SetSize(m.width, m.height)
+		m.triageModal.Start(m.items)
+		return m, nil
+
+	case triageVerdictMsg:
+		// Apply a triage verdict with the same operations as the list keys
+		switch msg.verdict {
+		case verdictRead:
+			return m, operations.MarkArticleRead(msg.item.ID)
+		case verdictFavorite:
+			read := operations.MarkArticleRead(msg.item.ID)
+			if msg.item.Favorited {
+				return m, read
+			}
+			return m, tea.Batch(operations.ToggleArticleFavorite(msg.item), read)
+		case verdictMute:
+			if msg.item.SourceID == "" {
+				return m, m.notify(toastWarn, "Can't mute: item has no source", 3*time.Second)
+			}
+			return m, operations.PauseSource(msg.item.SourceID)
+		}
+		return m, nil
+
+	case commands.DigestMsg:
+		if msg.Export == "audio" {
+			// The daemon narrates the briefing from the same items
+			opts := api.AudioBriefingOptions{}
+			if msg.IncludeMedium {
+				opts.MinPriority = "medium"
+			}
+			op := m.startStatusOp("Generating audio briefing...")
+			return m, retryable("audio briefing", "digest", operations.GenerateAudioBriefing(op, opts))
+		}
+		return m, loadDigestItems(m, msg.IncludeMedium, msg.Export)
+
+	case commands.HistoryMsg:
+		return m, loadHistory(msg.Days)
+
+	case historyLoadedMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("History failed: %v", msg.err), 5*time.Second)
+		}
+		m.digestModal.SetSize(m.width, m.height)
+		m.digestModal.OpenDocument("READING HISTORY", "", buildHistory(db.SummarizeHistory(msg.entries), msg.days))
+		return m, nil
+
+	case historyRecordedMsg:
+		return m, m.refreshGoal()
+
+	case goalStatusMsg:
+		if msg.err == nil {
+			m.goalStatus = msg
+		}
+		return m, nil
+
+	case historyFailedMsg:
+		return m, m.notify(toastWarn, fmt.Sprintf("Reading history not saved: %v", msg.err), 5*time.Second)
 
 	case commands.ExtractMsg:
 		// Trigger on-demand deep extraction for the current article
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
-			m.statusMessage = "Extracting..."
-			return m, operations.ExtractContent(item.ID)
+			return m, operations.ExtractContent(m.startStatusOp("Extracting..."), item.ID)
+		}
+
+	case commands.ListenMsg:
+		switch msg.Action {
+		case "pause":
+			if err := m.player.togglePause(); err != nil {
+				return m, m.notify(toastWarn, err.Error(), 3*time.Second)
+			}
+		case "stop":
+			m.player.stop()
+		default:
+			// Narrate the current article; the daemon reuses earlier narrations
+			if refuse := m.refuseMissing(api.FeatureAudio); refuse != nil {
+				return m, refuse
+			}
+			if len(m.items) > 0 && m.cursor < len(m.items) {
+				item := m.items[m.cursor]
+				op := m.startStatusOp("Narrating article...")
+				return m, retryable("narrate", item.Title, operations.GenerateArticleAudio(op, item.ID, item.Title))
+			}
+		}
+
+	case commands.SummarizeMsg:
+		// Summarize the current article if ingestion left it without one
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			item := m.items[m.cursor]
+			if item.ParsedAnalysis().ReadingSummary != "" {
+				return m, m.notify(toastInfo, "Already summarized", 3*time.Second)
+			}
+			return m, operations.SummarizeContent(m.startStatusOp("Summarizing..."), item.ID)
+		}
+
+	case commands.AskMsg:
+		// Ask about the current article, or reopen its conversation
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			item := m.items[m.cursor]
+			if m.chat.history == nil {
+				m.chat = NewChatModal()
+			}
+			m.chat.SetSize(m.width, m.height)
+			m.chat.Open(item.ID, item.Title)
+			return m, m.chat.Ask(msg.Question)
 		}
 
 	case commands.ExportSourcesMsg:
-		// Export sources to clipboard
-		return m, operations.ExportSources()
+		// Export sources to a file, or the clipboard without a path
+		return m, operations.ExportSources(msg.Path, msg.Format)
+
+	case commands.ExportHTMLMsg:
+		// Write the current list as a standalone HTML page
+		return m, exportHTML(m, msg.Path)
+
+	case htmlExportedMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("HTML export failed: %v", msg.err), 5*time.Second)
+		}
+		return m, m.notify(toastSuccess, fmt.Sprintf("Exported %d items to %s", msg.count, msg.path), 5*time.Second)
 
 	case commands.ContextReviewMsg:
-		// Review flagged items
+		if m.remoteURL != "" {
+			// The database is the daemon's; the cache has every loaded item
+			return m.Update(operations.ContextReviewedMsg{Items: operations.FlaggedItems(m.itemsCache)})
+		}
 		return m, operations.ReviewFlaggedItems()
 
+	case contextReviewOpenMsg:
+		if err := openInBrowser(msg.item.URL); err != nil {
+			return m, m.notify(toastError, "Failed to open browser", 3*time.Second)
+		}
+		return m, m.notify(toastInfo, "Opening in browser...", 2*time.Second)
+
+	case contextReviewApplyMsg:
+		m.statusMessage = "Updating context.md..."
+		object := fmt.Sprintf("%d topic%s", len(msg.topics), pluralize(len(msg.topics)))
+		return m, retryable("apply context review", object, operations.ApplyContextReview(msg.topics, msg.resolved))
+
 	case commands.ContextSuggestMsg:
 		// Get context suggestions from LLM
-		m.statusMessage = "Analyzing flagged items..."
-		return m, operations.GetContextSuggestions()
+		return m, operations.GetContextSuggestions(m.startStatusOp("Analyzing flagged items..."))
+
+	case commands.ContextAddMsg:
+		if msg.Topic != "" {
+			m.statusMessage = "Adding topic..."
+			return m, operations.AddContextTopic(msg.Topic, msg.Section)
+		}
+		// No topic given: pick one of the current article's entities
+		var entries []paletteEntry
+		if m.cursor < len(m.items) {
+			for _, entity := range m.items[m.cursor].ParsedAnalysis().Entities {
+				line := append([]string{"context", "add", msg.Section}, strings.Fields(entity)...)
+				entries = append(entries, paletteEntry{kind: "command", label: entity, hint: "add to " + msg.Section, line: line})
+			}
+		}
+		if len(entries) == 0 {
+			return m, m.notify(toastWarn, "No entities on this article; use :context add <topic>", 3*time.Second)
+		}
+		m.palette.SetSize(m.width, m.height)
+		m.palette.Open("Add which topic to context.md?", "", entries)
+		return m, nil
 
 	case commands.ContextEditMsg:
-		// Open context.md in $EDITOR
-		return m, operations.EditContextFile()
+		if msg.External {
+			// Open context.md in $EDITOR
+			return m, operations.EditContextFile()
+		}
+		return m, operations.LoadContextFile()
 
 	case commands.FabricMsg:
 		// Execute Fabric pattern on current item's full content
 		currentContent := ""
 		if len(m.items) > 0 && m.cursor < len(m.items) {
-			item := m.items[m.cursor]
+			item, err := m.currentItemFull()
+			if err != nil {
+				return m, m.notify(toastError, fmt.Sprintf("Failed to load item: %v", err), 3*time.Second)
+			}
 			// Only use full content - no fallback to summary
 			currentContent = item.Content
 		}
@@ -377,11 +1235,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case commands.ArchivedMsg:
 		// Toggle archived view (same as hotkey 5)
+		if refuse := m.refuseMissing(db.SchemaArchive); refuse != nil {
+			return m, refuse
+		}
 		if m.view == "list" {
 			m.showArchived = !m.showArchived
 			m.cursor = 0
 			m.loading = true
-			return m, fetchItemsWithState(m, false)
+			cmd := m.fetchList(false)
+			return m, cmd
+		}
+
+	case commands.FilterMsg:
+		// Set or clear a source filter (same effect as the 'f' hotkey for type)
+		if m.view == "list" {
+			switch msg.Field {
+			case "category":
+				m.filterCategory = msg.Value
+			case "source":
+				m.filterSource = ""
+				if msg.Value != "" {
+					source, ok := findSource(m.sources, msg.Value)
+					if !ok {
+						return m, m.notify(toastError, fmt.Sprintf("filter: no source matches '%s'", msg.Value), 3*time.Second)
+					}
+					m.filterSource = source.ID
+				}
+			case "tag":
+				m.filterTag = msg.Value
+			case "author":
+				m.filterAuthor = msg.Value
+			case "type":
+				m.filterType = msg.Value
+			case "since":
+				m.filterSince = msg.Time
+			case "until":
+				m.filterUntil = msg.Time
+			case "expr":
+				m.setFilterExpr(msg.Value)
+				cmds = append(cmds, saveUIState(m.savedLayout()))
+			default:
+				// Bare :filter clears everything, this view's expression included
+				if _, saved := m.viewExprs[m.priority]; saved {
+					m.setFilterExpr("")
+					cmds = append(cmds, saveUIState(m.savedLayout()))
+				}
+				m.filterCategory = ""
+				m.filterSource = ""
+				m.filterTag = ""
+				m.filterAuthor = ""
+				m.filterType = "all"
+				m.filterSince = time.Time{}
+				m.filterUntil = time.Time{}
+			}
+			m.updateSourcesViewport()
+			m.cursor = 0
+			m.loading = true
+			cmds = append(cmds, m.fetchList(false))
+			return m, tea.Batch(cmds...)
 		}
 
 	case commands.ThemeMsg:
@@ -395,11 +1306,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Move to next theme (wrap around)
 		nextIdx := (currentIdx + 1) % len(AvailableThemes)
-		m.theme = AvailableThemes[nextIdx]
-		m.statusMessage = fmt.Sprintf("Theme: %s", m.theme.Name)
+		m.useTheme(AvailableThemes[nextIdx].Name)
 		// Update sources viewport with new theme
 		m.updateSourcesViewport()
-		cmds = append(cmds, clearStatusAfterDelay(2*time.Second))
+		cmds = append(cmds, m.notify(toastInfo, fmt.Sprintf("Theme: %s", m.theme.Name), 2*time.Second))
 
 	// Reader command handlers
 	case commands.MarkMsg:
@@ -410,6 +1320,65 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, operations.ToggleArticleRead(item)
 		}
 
+	case commands.MarkAllMsg:
+		return m, m.startMarkAll(msg)
+
+	case markAllItemsMsg:
+		if msg.err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Failed to load items: %v", msg.err), 5*time.Second)
+		}
+		return m, m.confirmMarkAll(markAllTargets(msg.items, msg.scope, time.Now()))
+
+	case operations.ArticlesMarkedMsg:
+		return m, m.markAllDone(msg)
+
+	case commands.TagMsg:
+		// Add/remove user tags on the current article, or show them
+		if refuse := m.refuseMissing(db.SchemaTags); refuse != nil {
+			return m, refuse
+		}
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			item := m.items[m.cursor]
+			if len(msg.Add) == 0 && len(msg.Remove) == 0 {
+				if len(item.UserTags) == 0 {
+					return m, m.notify(toastInfo, "No tags (use :tag rust,career)", 3*time.Second)
+				}
+				return m, m.notify(toastInfo, "Tags: "+strings.Join(item.UserTags, ", "), 3*time.Second)
+			}
+			return m, operations.SetArticleTags(item.ID, editTags(item.UserTags, msg.Add, msg.Remove))
+		}
+
+	case commands.PinMsg:
+		// Pin or unpin the current article (works in both list and reader views)
+		if refuse := m.refuseMissing(db.SchemaPins); refuse != nil {
+			return m, refuse
+		}
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			return m, operations.ToggleArticlePin(m.items[m.cursor])
+		}
+
+	case operations.ArticlePinnedMsg:
+		return m, m.pinned(msg)
+
+	case commands.SnoozeMsg:
+		// Hide the current article until the chosen time
+		if refuse := m.refuseMissing(db.SchemaSnooze); refuse != nil {
+			return m, refuse
+		}
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			return m, operations.SnoozeArticle(m.items[m.cursor].ID, msg.Until)
+		}
+
+	case operations.ArticleSnoozedMsg:
+		return m, m.snoozed(msg)
+
+	case snoozeWakeMsg:
+		// Don't refresh the list out from under the reader; it catches up later
+		if m.view == "list" {
+			return m, func() tea.Msg { return commands.RefreshMsg{PreserveCursor: true} }
+		}
+		return m, nil
+
 	case commands.FavoriteMsg:
 		// Toggle favorite status (works in both list and reader views)
 		if len(m.items) > 0 && m.cursor < len(m.items) {
@@ -420,6 +1389,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case commands.UpvoteMsg:
 		// Upvote current item (works on ALL content, not just unprioritized)
+		if refuse := m.refuseMissing(db.SchemaFeedback); refuse != nil {
+			return m, refuse
+		}
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
 			return m, operations.UpvoteArticle(item)
@@ -427,6 +1399,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case commands.DownvoteMsg:
 		// Downvote current item (works on ALL content, not just unprioritized)
+		if refuse := m.refuseMissing(db.SchemaFeedback); refuse != nil {
+			return m, refuse
+		}
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
 			return m, operations.DownvoteArticle(item)
@@ -436,13 +1411,143 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Open URL in browser (works in both list and reader views)
 		if len(m.items) > 0 && m.cursor < len(m.items) {
 			item := m.items[m.cursor]
+			// Papers go to the configured PDF viewer when there is one
+			if isPaper(item) {
+				if cfg, err := config.LoadConfig(); err == nil && len(cfg.GetPDFHandler()) > 0 {
+					cacheDir, err := os.UserCacheDir()
+					if err != nil {
+						return m, m.notify(toastError, fmt.Sprintf("Open failed: %v", err), 5*time.Second)
+					}
+					path := filepath.Join(cacheDir, "prismis", "papers", paperFilename(item))
+					op := m.startStatusOp("Fetching PDF...")
+					return m, operations.FetchPaper(op, item.ID, paperPDFURL(item), path, cfg.GetPDFHandler())
+				}
+			}
 			err := openInBrowser(item.URL)
 			if err != nil {
-				m.statusMessage = "Failed to open browser"
+				cmds = append(cmds, m.notify(toastError, "Failed to open browser", 3*time.Second))
+			} else if status := m.linkStatus[item.URL]; status == http.StatusNotFound || status == http.StatusGone {
+				cmds = append(cmds, m.notify(toastWarn, deadLinkHint(status), 5*time.Second))
 			} else {
-				m.statusMessage = "Opening in browser..."
+				cmds = append(cmds, m.notify(toastInfo, "Opening in browser...", 2*time.Second))
+				if cmd := m.checkLink(item.URL); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			}
+		}
+
+	case commands.DownloadMsg:
+		// Save the current paper's PDF to the download directory
+		if len(m.items) == 0 || m.cursor >= len(m.items) {
+			break
+		}
+		item := m.items[m.cursor]
+		if !isPaper(item) {
+			return m, m.notify(toastWarn, "Not a paper: only arXiv and PDF items can be downloaded", 3*time.Second)
+		}
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Download failed: %v", err), 5*time.Second)
+		}
+		dir, err := cfg.GetDownloadDir()
+		if err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Download failed: %v", err), 5*time.Second)
+		}
+		op := m.startStatusOp("Downloading PDF...")
+		return m, operations.FetchPaper(op, item.ID, paperPDFURL(item), filepath.Join(dir, paperFilename(item)), nil)
+
+	case operations.PaperPDFMsg:
+		m.statusMessage = "" // Clear "Fetching PDF..." / "Downloading PDF..."
+		switch {
+		case operations.IsCancelled(msg.Error):
+			cmds = append(cmds, m.notify(toastInfo, "Download cancelled", 3*time.Second))
+		case msg.Error != nil:
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("PDF failed: %v", msg.Error), 5*time.Second))
+		case msg.Opened:
+			cmds = append(cmds, m.notify(toastInfo, "Opening "+filepath.Base(msg.Path)+"...", 2*time.Second))
+		default:
+			cmds = append(cmds, m.notify(toastSuccess, "Saved to "+msg.Path, 5*time.Second))
+		}
+
+	case commands.TaskMsg:
+		// Send the current article to the configured task backend
+		if len(m.items) == 0 || m.cursor >= len(m.items) {
+			break
+		}
+		item := m.items[m.cursor]
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Task failed: %v", err), 5*time.Second)
+		}
+		task, err := cfg.GetTask()
+		if err != nil {
+			return m, m.notify(toastError, err.Error(), 5*time.Second)
+		}
+		description := cmp.Or(msg.Description, item.Title)
+		return m, retryable("create task", description, operations.CreateTask(task, item, description))
+
+	case operations.TaskCreatedMsg:
+		if msg.Error != nil {
+			if operations.IsCancelled(msg.Error) {
+				return m, m.notify(toastInfo, "Task cancelled", 3*time.Second)
 			}
-			cmds = append(cmds, clearStatusAfterDelay(2*time.Second))
+			return m, m.notify(toastError, fmt.Sprintf("Task failed: %v", msg.Error), 5*time.Second)
+		}
+		cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("Task added (%s): %s", msg.Backend, msg.Description), 3*time.Second))
+		if msg.MarkRead {
+			cmds = append(cmds, operations.AutoMarkArticleRead(msg.ContentID))
+		}
+
+	case commands.RemindMsg:
+		// Put a reminder about the current article on the calendar
+		if len(m.items) == 0 || m.cursor >= len(m.items) {
+			break
+		}
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Reminder failed: %v", err), 5*time.Second)
+		}
+		dir, err := cfg.GetReminderDir()
+		if err != nil {
+			return m, m.notify(toastError, fmt.Sprintf("Reminder failed: %v", err), 5*time.Second)
+		}
+		return m, operations.CreateReminder(m.items[m.cursor], msg.At, msg.Note, dir, openInBrowser)
+
+	case operations.ReminderMsg:
+		when := msg.At.Format("Mon Jan 2 15:04")
+		switch {
+		case msg.Error != nil:
+			return m, m.notify(toastError, fmt.Sprintf("Reminder failed: %v", msg.Error), 5*time.Second)
+		case msg.Opened:
+			return m, m.notify(toastSuccess, "Reminder for "+when+" sent to your calendar app", 3*time.Second)
+		default:
+			return m, m.notify(toastSuccess, "Reminder for "+when+" saved to "+msg.Path, 5*time.Second)
+		}
+
+	case commands.MirrorMsg:
+		// Open an archived copy of the current article
+		if len(m.items) == 0 || m.cursor >= len(m.items) {
+			break
+		}
+		mirror := mirrorURL(m.items[m.cursor].URL, msg.Service)
+		if err := openInBrowser(mirror); err != nil {
+			return m, m.notify(toastError, "Failed to open browser", 3*time.Second)
+		}
+		return m, m.notify(toastInfo, "Opening archived copy...", 2*time.Second)
+
+	case operations.LinkCheckedMsg:
+		if m.linkStatus == nil {
+			m.linkStatus = make(map[string]int)
+		}
+		if msg.Error != nil {
+			// Unreachable right now: forget it so a later open checks again
+			delete(m.linkStatus, msg.URL)
+			break
+		}
+		m.linkStatus[msg.URL] = msg.Status
+		// Suggest the mirror while the dead article is still the one being read
+		if msg.Dead() && m.view == "reader" && len(m.items) > 0 && m.cursor < len(m.items) && m.items[m.cursor].URL == msg.URL {
+			cmds = append(cmds, m.notify(toastWarn, deadLinkHint(msg.Status), 5*time.Second))
 		}
 
 	case commands.YankMsg:
@@ -451,17 +1556,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			item := m.items[m.cursor]
 			err := clipboard.CopyToClipboard(item.URL)
 			if err != nil {
-				m.statusMessage = "Failed to copy URL"
+				cmds = append(cmds, m.notify(toastError, "Failed to copy URL", 3*time.Second))
 			} else {
-				m.statusMessage = "URL copied to clipboard"
+				cmds = append(cmds, m.notify(toastSuccess, "URL copied to clipboard", 2*time.Second))
 			}
-			cmds = append(cmds, clearStatusAfterDelay(2*time.Second))
 		}
 
 	case commands.CopyMsg:
 		// Copy content to clipboard (works in both list and reader views)
 		if len(m.items) > 0 && m.cursor < len(m.items) {
-			item := m.items[m.cursor]
+			item, err := m.currentItemFull()
+			if err != nil {
+				cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to load item: %v", err), 3*time.Second))
+				break
+			}
 			var contentToCopy string
 			var description string
 
@@ -476,23 +1584,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			default:
 				// Copy reading summary + deep synthesis prose (no quotes — keeps the
 				// clipboard payload clean; mirrors the reader minus the Quotes section)
-				metadata := parseMetadata(item.Analysis)
-				summary := extractReadingSummary(item.Analysis)
-				contentToCopy = strings.TrimSpace(appendSynthesisSection(summary, metadata.DeepExtraction))
+				analysis := item.ParsedAnalysis()
+				contentToCopy = strings.TrimSpace(appendSynthesisSection(analysis.ReadingSummary, analysis.DeepExtraction))
 				description = "Summary"
 			}
 
 			if contentToCopy == "" {
-				m.statusMessage = fmt.Sprintf("No %s available", strings.ToLower(description))
-				cmds = append(cmds, clearStatusAfterDelay(3*time.Second))
+				cmds = append(cmds, m.notify(toastWarn, fmt.Sprintf("No %s available", strings.ToLower(description)), 3*time.Second))
 			} else {
 				err := clipboard.CopyToClipboard(contentToCopy)
 				if err != nil {
-					m.statusMessage = fmt.Sprintf("Failed to copy %s", strings.ToLower(description))
+					cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to copy %s", strings.ToLower(description)), 3*time.Second))
 				} else {
-					m.statusMessage = fmt.Sprintf("%s copied to clipboard", description)
+					cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("%s copied to clipboard", description), 3*time.Second))
 				}
-				cmds = append(cmds, clearStatusAfterDelay(3*time.Second))
 			}
 		}
 
@@ -508,14 +1613,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "n", "N", "esc":
 				// Cancel the prune
 				m.pruneConfirm = pruneConfirmState{}
-				m.statusMessage = "Prune cancelled"
-				return m, nil
+				m.statusMessage = ""
+				return m, m.notify(toastInfo, "Prune cancelled", 3*time.Second)
 			default:
 				// Ignore other keys during confirmation
 				return m, nil
 			}
 		}
 
+		// Check if waiting for :markall confirmation
+		if m.markAllConfirm.active {
+			switch msg.String() {
+			case "y", "Y":
+				ids := m.markAllConfirm.ids
+				m.markAllConfirm = markAllConfirmState{}
+				m.statusMessage = fmt.Sprintf("Marking %d items read...", len(ids))
+				return m, operations.MarkArticlesRead(ids, true)
+			case "n", "N", "esc":
+				m.markAllConfirm = markAllConfirmState{}
+				m.statusMessage = ""
+				return m, m.notify(toastInfo, "Mark all cancelled", 3*time.Second)
+			default:
+				return m, nil
+			}
+		}
+
+		// Check if waiting for :restore confirmation
+		if m.restoreConfirm.Path != "" {
+			return m.confirmRestore(msg)
+		}
+
+		// Re-analysis offer after :context add
+		if m.reanalyzeConfirm {
+			m.reanalyzeConfirm = false
+			switch msg.String() {
+			case "y", "Y":
+				op := m.startStatusOp("Re-analyzing unprioritized items...")
+				return m, operations.ReanalyzeUnprioritized(op, reanalyzeDays)
+			default:
+				m.statusMessage = ""
+				return m, nil
+			}
+		}
+
 		// Check if command mode should be disabled for normal keys
 		if m.commandMode.IsActive() {
 			// Command mode is active, don't process normal navigation keys
@@ -524,12 +1664,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Type-to-filter bar takes all keys while open
+		if m.listFilter.IsActive() {
+			return m.updateListFilter(msg)
+		}
+
+		// The key after Ctrl-W is a window command
+		if m.windowPending {
+			return m.windowCommand(msg)
+		}
+
+		// [keys] bindings take over before the built-in keys
+		if updated, cmd, ok := m.applyKeymap(msg); ok {
+			return updated, cmd
+		}
+
 		switch msg.String() {
 		case ":":
 			// Activate command mode
 			m.commandMode.Show()
 			return m, nil
 
+		case "ctrl+p":
+			// Open the command palette
+			m.palette.SetSize(m.width, m.height)
+			m.palette.Open("Type a command, view, or source", "", m.paletteEntries())
+			return m, nil
+
+		case "/":
+			// Narrow the current list as you type
+			if m.view == "list" {
+				m.listFilter.Show()
+				return m, nil
+			}
+
+		case "ctrl+t":
+			// Open the title finder across all items
+			return m, loadFinderItems(m, "")
+
 		case "q":
 			if m.view == "reader" {
 				// In reader view, q goes back to list
@@ -537,10 +1709,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			// In list view, q quits
-			return m, tea.Quit
+			m.player.stop()
+			return m, m.quit()
 
 		case "ctrl+c":
-			return m, tea.Quit
+			m.player.stop()
+			return m, m.quit()
 
 		// Switch to reader view
 		case "enter":
@@ -548,36 +1722,80 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.view = "reader"
 				// Update viewport with current article content
 				m.updateReaderContent()
+				// Find out in the background whether the original is still up
+				if cmd := m.checkLink(m.items[m.cursor].URL); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 			}
 		case "esc":
 			if m.view == "reader" {
 				m.view = "list"
+			} else if m.listFilter.Query() != "" {
+				// Drop a sticky type-to-filter query
+				return m.updateListFilter(msg)
+			} else if m.statusOp.Cancel() {
+				// Abort the slow request the status line reports (a sync, an
+				// audio briefing, an extraction) instead of waiting it out.
+				// Background work the user didn't start here keeps running.
+				m.statusOp = nil
+				m.statusMessage = ""
+				cmds = append(cmds, m.notify(toastInfo, "Cancelled", 2*time.Second))
 			}
 
 		// Vim-style pane navigation
 		case "ctrl+w":
-			// Start vim window command mode - wait for next key
+			// Wait for the window command; the next key is handled by windowCommand
+			m.windowPending = true
 			m.statusMessage = "-- WINDOW --"
-			// This would need a small state machine, for now use direct shortcuts
 
-		case "ctrl+h", "ctrl+w h":
+		case "ctrl+h":
 			// Move to left pane (sources)
 			m.focusedPane = "sources"
 			m.statusMessage = ""
 
-		case "ctrl+l", "ctrl+w l":
+		case "ctrl+l":
 			// Move to right pane (content)
 			m.focusedPane = "content"
 			m.statusMessage = ""
 
-		case "ctrl+w w", "tab":
-			// Cycle through panes
-			if m.focusedPane == "sources" {
-				m.focusedPane = "content"
-			} else {
-				m.focusedPane = "sources"
+		case "Z":
+			// Distraction-free reading
+			m.toggleZen()
+
+		case "w":
+			// Expand or collapse why the item was prioritized
+			if m.view == "reader" {
+				m.showWhy = !m.showWhy
+				m.updateReaderContent()
 			}
-			m.statusMessage = ""
+
+		case "<", ">":
+			// Shrink or grow the sidebar; > also brings back a hidden one
+			delta := sidebarPercentStep
+			if msg.String() == "<" {
+				delta = -sidebarPercentStep
+			}
+			if !m.resizeSidebar(delta) {
+				return m, nil
+			}
+			return m, saveUIState(m.savedLayout())
+
+		case "ctrl+o":
+			// Jump back to the previous article or view
+			jumps, loc, ok := m.jumps.back(m.location())
+			m.jumps = jumps
+			if ok {
+				return m.goTo(loc)
+			}
+
+		case "tab":
+			// Terminals send Ctrl-I as Tab: jump forward after a Ctrl-O, else cycle panes
+			if m.jumps.canForward() {
+				jumps, loc, _ := m.jumps.forward()
+				m.jumps = jumps
+				return m.goTo(loc)
+			}
+			m.cyclePane()
 
 		// NOTE: Actions like mark, favorite, copy, yank, open are now :commands
 		// They can be executed with :m, :f, :c, :y, :o (or full names)
@@ -594,6 +1812,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if m.view == "reader" {
 					m.viewport.LineDown(1)
 				}
+			} else if m.focusedPane == "preview" {
+				m.preview.LineDown(1)
 			}
 		case "k", "up":
 			if m.focusedPane == "sources" {
@@ -606,17 +1826,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if m.view == "reader" {
 					m.viewport.LineUp(1)
 				}
+			} else if m.focusedPane == "preview" {
+				m.preview.LineUp(1)
 			}
 
+		// Skip to the next/previous unread or HIGH item, in the list or the reader
+		case "n":
+			return m, m.jumpToMatching(1, isUnread, "unread")
+		case "p":
+			return m, m.jumpToMatching(-1, isUnread, "unread")
+		case "]":
+			return m, m.jumpToMatching(1, isHigh, "HIGH")
+		case "[":
+			return m, m.jumpToMatching(-1, isHigh, "HIGH")
+
 		// Reader-specific navigation (only when content pane is focused)
 		case "h", "left":
-			if m.focusedPane == "content" && m.view == "reader" && m.cursor > 0 {
+			if msg.String() == "left" && m.view == "reader" && m.noWrap {
+				m.viewport.ScrollLeft(nowrapScrollStep)
+			} else if m.focusedPane == "content" && m.view == "reader" && m.cursor > 0 {
 				// Previous article
 				m.cursor--
 				m.updateReaderContent()
 			}
 		case "l", "right":
-			if m.focusedPane == "content" && m.view == "reader" && m.cursor < len(m.items)-1 {
+			if msg.String() == "right" && m.view == "reader" && m.noWrap {
+				m.viewport.ScrollRight(nowrapScrollStep)
+			} else if m.focusedPane == "content" && m.view == "reader" && m.cursor < len(m.items)-1 {
 				// Next article
 				m.cursor++
 				m.updateReaderContent()
@@ -655,43 +1891,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Note: showUnprioritized is always true for this view
 				m.showUnprioritized = true
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		case "1":
 			if m.view == "list" {
 				m.priority = "high"
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		case "2":
 			if m.view == "list" {
 				m.priority = "medium"
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		case "3":
 			if m.view == "list" {
 				m.priority = "low"
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		case "4", "*":
 			if m.view == "list" {
 				m.priority = "favorites"
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
+			}
+		case "6":
+			if m.view == "list" {
+				m.priority = "interesting"
+				m.cursor = 0
+				m.loading = true
+				return m, tea.Batch(m.warnUnsatisfied(), m.refetchSoon())
 			}
 		case "v":
 			// Toggle archived view
+			if refuse := m.refuseMissing(db.SchemaArchive); refuse != nil {
+				return m, refuse
+			}
 			if m.view == "list" {
 				m.showArchived = !m.showArchived
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		case "R":
 			// Reset all filters to defaults
@@ -701,10 +1947,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showArchived = false
 				m.showUnprioritized = false
 				m.filterType = "all"
+				m.filterSource = ""
+				m.filterTag = ""
+				m.filterAuthor = ""
+				m.filterSince = time.Time{}
+				m.filterUntil = time.Time{}
+				m.search = runningSearch{}
 				m.sortNewest = true
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				if _, saved := m.viewExprs[m.priority]; saved {
+					m.setFilterExpr("")
+					return m, tea.Batch(saveUIState(m.savedLayout()), m.refetchSoon())
+				}
+				return m, m.refetchSoon()
 			}
 		case "a":
 			if m.view == "list" {
@@ -713,7 +1969,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Note: showUnprioritized is false for 'all' to show only prioritized items
 				m.showUnprioritized = false
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		// Toggle interesting items view
 		case "i":
@@ -721,7 +1977,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showInteresting = !m.showInteresting
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, tea.Batch(m.warnUnsatisfied(), m.refetchSoon())
 			}
 		// Toggle unread/all view
 		case "u":
@@ -729,18 +1985,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showAll = !m.showAll
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		// Toggle date sort (newest/oldest)
 		case "d":
 			if m.view == "list" {
-				m.sortNewest = !m.sortNewest
+				m.toggleDateSort()
 				// Sort items in place without refetching
-				sortItemsByDate(m.items, m.sortNewest)
+				sortItems(m.items, m.sortOrder())
 				// Keep cursor in bounds
 				if m.cursor >= len(m.items) && len(m.items) > 0 {
 					m.cursor = len(m.items) - 1
 				}
+				if _, saved := m.viewSorts[m.priority]; saved {
+					return m, saveUIState(m.savedLayout())
+				}
 			}
 		// Cycle source type filter
 		case "s":
@@ -758,16 +2017,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.filterType = filterTypes[(currentIdx+1)%len(filterTypes)]
 				m.cursor = 0
 				m.loading = true
-				return m, fetchItemsWithState(m, false)
+				return m, m.refetchSoon()
 			}
 		// Upvote current item (+)
 		case "+", "=":
+			if refuse := m.refuseMissing(db.SchemaFeedback); refuse != nil {
+				return m, refuse
+			}
 			if len(m.items) > 0 && m.cursor < len(m.items) {
 				item := m.items[m.cursor]
 				return m, operations.UpvoteArticle(item)
 			}
 		// Downvote current item (-)
 		case "-", "_":
+			if refuse := m.refuseMissing(db.SchemaFeedback); refuse != nil {
+				return m, refuse
+			}
 			if len(m.items) > 0 && m.cursor < len(m.items) {
 				item := m.items[m.cursor]
 				return m, operations.DownvoteArticle(item)
@@ -777,16 +2042,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.view == "list" {
 				// Load fresh sources and show modal
 				m.sourceModal.SetSize(m.width, m.height)
+				m.sourceModal.SetRules(m.daemon != nil && m.daemon.Supports(api.FeatureSourceRules))
 				m.sourceModal.LoadSources(m.sources)
 				m.sourceModal.Show()
 				m.sourceModal.UpdateContent()
+				return m, fetchSourceActivity(m.remoteURL)
 			}
 		// Open help modal
 		case "?":
 			if m.view == "list" && !m.sourceModal.IsVisible() {
 				// Only open help from main view when no other modals are open
 				m.helpModal.SetSize(m.width, m.height)
-				m.helpModal.Show()
+				m.helpModal.Open(m.helpRows())
 			}
 		}
 
@@ -804,19 +2071,78 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.sourceModal.IsVisible() {
 				m.sourceModal.LoadSources(m.sources)
 			}
+			m.start.sourcesLoaded = true
+			cmds = append(cmds, m.applyStart())
+		} else if m.start.source != "" {
+			m.start.source = ""
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("--source: %v", msg.err), 5*time.Second))
 		}
 
+	case itemLoadedMsg:
+		return m, m.handleItemLoaded(msg)
+
+	case refetchDueMsg:
+		return m, m.refetchDue(msg)
+
+	case readerPrefetchedMsg:
+		m.handleReaderPrefetched(msg)
+		return m, nil
+
 	case itemsLoadedMsg:
+		if msg.seq != m.fetchSeq {
+			// A later fetch superseded this one; its own result is on the way
+			return m, m.supersededFetch(msg)
+		}
 		m.loading = false
+		m.jumping = false
+		if msg.isAutoRefresh {
+			// Schedule the next auto-refresh, whether or not this one worked
+			cmds = append(cmds, m.scheduleRefresh())
+		}
+		if operations.IsCancelled(msg.err) {
+			// User aborted the sync - keep showing what we have
+			break
+		}
 		m.err = msg.err
+		if !msg.syncedAt.IsZero() {
+			m.lastSyncAt = msg.syncedAt
+			m.latency = msg.latency
+			m.syncFailed = false
+			m.resolveFailure("sync", m.remoteURL)
+		} else if msg.syncFailed {
+			m.syncFailed = true
+			if msg.err != nil {
+				m.recordFailure(failure{op: "sync", object: m.remoteURL, err: msg.err, text: msg.err.Error(), retry: syncRetry})
+			}
+		}
 		if msg.err == nil {
+			cmds = append(cmds, m.refreshGoal())
+			var welcome tea.Cmd
+			if msg.isAutoRefresh {
+				// The first refresh after focus returns says what arrived meanwhile
+				welcome = m.welcomeBack(msg.items)
+				cmds = append(cmds, welcome)
+			}
 			previousCount := len(m.items)
-			m.items = msg.items
+			// Keep the type-to-filter query applied across reloads
+			m.listBase = msg.items
+			m.items = filterListQuery(msg.items, m.listFilter.Query())
 			m.hiddenCount = msg.hiddenCount
 
+			if msg.archived != nil {
+				m.archivedCache = msg.archived
+			}
+
 			// Update cache and lastSync for remote mode
 			if msg.updateCache && m.remoteURL != "" {
+				if msg.fullResync {
+					cmds = append(cmds, m.resyncDone(m.itemsCache, msg.allItems))
+					if msg.archived == nil {
+						m.archivedCache = nil // Refetched when an archived view next needs it
+					}
+				}
 				m.itemsCache = msg.allItems
+				cmds = append(cmds, m.recordConflicts(msg.conflicts))
 				// Only update lastSync if we got new items with timestamps
 				if !msg.newLastSync.IsZero() {
 					m.lastSync = msg.newLastSync
@@ -828,6 +2154,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.sources = calculateUnreadCounts(m.sources, m.itemsCache)
 					m.updateSourcesViewport()
 				}
+
+				if !msg.offlineAt.IsZero() {
+					text := fmt.Sprintf("Daemon unreachable: reading %d item(s) saved offline %s ago",
+						len(m.itemsCache), formatTime(time.Since(msg.offlineAt)))
+					cmds = append(cmds, m.notify(toastWarn, text, 5*time.Second))
+				} else if m.offline && !m.offlineBusy {
+					// Refresh the offline copy after every successful sync
+					m.offlineBusy = true
+					cmds = append(cmds, saveOfflineCache(m.remoteURL, m.itemsCache))
+				}
 			}
 
 			// Handle cursor position
@@ -848,34 +2184,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				// Show refresh completion message
+				var refreshed string
 				newCount := len(m.items)
 				if msg.isAutoRefresh {
 					// Auto-refresh messages
 					if newCount > previousCount {
 						diff := newCount - previousCount
-						m.statusMessage = fmt.Sprintf("✓ Auto-refreshed! %d new item(s)", diff)
+						refreshed = fmt.Sprintf("✓ Auto-refreshed! %d new item(s)", diff)
 					} else if newCount < previousCount {
-						m.statusMessage = "✓ Auto-refreshed (some items marked as read)"
+						refreshed = "✓ Auto-refreshed (some items marked as read)"
 					} else {
-						m.statusMessage = "✓ Auto-refreshed"
+						refreshed = "✓ Auto-refreshed"
 					}
 				} else {
 					// Manual refresh messages
 					if newCount > previousCount {
 						diff := newCount - previousCount
-						m.statusMessage = fmt.Sprintf("✓ Refreshed! %d new item(s)", diff)
+						refreshed = fmt.Sprintf("✓ Refreshed! %d new item(s)", diff)
 					} else if newCount < previousCount {
-						m.statusMessage = "✓ Refreshed (some items marked as read)"
+						refreshed = "✓ Refreshed (some items marked as read)"
 					} else {
-						m.statusMessage = "✓ Refreshed"
+						refreshed = "✓ Refreshed"
+					}
+				}
+				if welcome == nil && !msg.fullResync {
+					cmds = append(cmds, m.notify(toastSuccess, refreshed, 3*time.Second))
+				}
+			} else {
+				// Title finder: land on the chosen item
+				if msg.jumpToID != "" {
+					for i, item := range m.items {
+						if item.ID == msg.jumpToID {
+							m.cursor = i
+							if msg.openReader {
+								m.view = "reader"
+								m.updateReaderContent()
+							}
+							break
+						}
 					}
 				}
-				// Schedule next auto-refresh after this one completes
-				if msg.isAutoRefresh && m.refreshInterval > 0 {
-					cmds = append(cmds, autoRefreshCmd(m.refreshInterval))
-				}
-				cmds = append(cmds, clearStatusAfterDelay(3*time.Second))
-			} else {
 				// Normal cursor bounds check
 				if m.cursor >= len(m.items) {
 					m.cursor = 0
@@ -884,69 +2232,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			// Show error message if refresh failed
 			if msg.preserveCursor {
-				m.statusMessage = fmt.Sprintf("✗ Refresh failed: %v", msg.err)
-				cmds = append(cmds, clearStatusAfterDelay(3*time.Second))
+				cmds = append(cmds, m.notify(toastError, fmt.Sprintf("✗ Refresh failed: %v", msg.err), 5*time.Second))
 			}
 		}
-	case clearStatusMsg:
-		m.statusMessage = ""
+		cmds = append(cmds, m.applyStart())
+
+	case startReaderMsg:
+		return m, m.openStartReader(msg)
+
 	case clearFlashMsg:
 		m.flashItem = -1
 
 	case autoRefreshMsg:
-		// Handle automatic refresh - only if not already loading and in list view
-		if !m.loading && m.view == "list" && !m.sourceModal.IsVisible() {
-			// Save current item ID to restore position
-			var currentItemID string
-			if m.cursor < len(m.items) && m.cursor >= 0 {
-				currentItemID = m.items[m.cursor].ID
-			}
+		if msg.gen != m.refreshGen {
+			break // Replaced by a newer timer, or stopped by :set refresh
+		}
+		if m.refreshPaused() {
+			m.refreshHeld = true // resumeRefresh picks it up when the reader or modal closes
+			break
+		}
 
-			m.loading = true
+		// Retry queued offline writes on every tick until the daemon is back
+		if m.pendingWrites > 0 {
+			cmds = append(cmds, operations.ReplayPendingWrites())
+		}
 
-			// Create refresh command that preserves position
-			refreshCmd := func() tea.Msg {
-				var result itemsLoadedMsg
+		// A refresh already running: try again next interval
+		if m.loading {
+			cmds = append(cmds, m.scheduleRefresh())
+			break
+		}
 
-				// Check if remote mode
-				if m.remoteURL != "" {
-					result = fetchItemsRemote(m)
-				} else {
-					// Fetch all content, filter client-side (unified with remote mode)
-					allItems, err := db.GetAllContent(m.showArchived)
-					if err != nil {
-						result = itemsLoadedMsg{err: err}
-					} else {
-						result = itemsLoadedMsg{
-							items:       applyFiltersClientSide(allItems, m),
-							hiddenCount: countHiddenUnprioritized(allItems, m),
-							err:         nil,
-						}
-					}
-				}
+		// Save current item ID to restore position
+		var currentItemID string
+		if m.cursor < len(m.items) && m.cursor >= 0 {
+			currentItemID = m.items[m.cursor].ID
+		}
 
-				// Add cursor preservation and auto-refresh marker
-				result.preserveCursor = true
-				result.targetItemID = currentItemID
-				result.isAutoRefresh = true
-				return result
-			}
+		m.loading = true
+		m.newFetch()
 
-			// Trigger refresh (timer rescheduled after completion)
-			cmds = append(cmds, refreshCmd)
+		// Remote mode: sync in the background (timer rescheduled after completion)
+		if m.remoteURL != "" {
+			cmds = append(cmds, remoteFetch(syncJob{model: m, preserveCursor: true, targetItemID: currentItemID, isAutoRefresh: true}))
+			break
 		}
 
+		// Create refresh command that preserves position
+		snapshot := m
+		refreshCmd := withSeq(m.fetchSeq, func() itemsLoadedMsg {
+			var result itemsLoadedMsg
+			m := snapshot
+
+			// Fetch all content, filter client-side (unified with remote mode)
+			allItems, err := db.GetContentIn(m.archiveScope(), m.filterPredicates()...)
+			if err != nil {
+				result = itemsLoadedMsg{err: err}
+			} else {
+				result = itemsLoadedMsg{
+					items:       applyFiltersClientSide(allItems, m),
+					hiddenCount: countHiddenUnprioritized(allItems, m),
+					err:         nil,
+				}
+			}
+
+			// Add cursor preservation and auto-refresh marker
+			result.preserveCursor = true
+			result.targetItemID = currentItemID
+			result.isAutoRefresh = true
+			return result
+		})
+
+		// Trigger refresh (timer rescheduled after completion)
+		cmds = append(cmds, refreshCmd)
+
 	case operations.PruneCountMsg:
 		// Received count for prune confirmation or display
 		if msg.Count == 0 {
-			m.statusMessage = "No unprioritized items to prune"
+			cmds = append(cmds, m.notify(toastInfo, "No unprioritized items to prune", 3*time.Second))
 		} else if msg.ShowOnly {
 			// Just show the count (for :prune? or :prune count)
 			statusMsg := fmt.Sprintf("%d unprioritized items", msg.Count)
 			if msg.Days != nil {
 				statusMsg = fmt.Sprintf("%d unprioritized items older than %d days", msg.Count, *msg.Days)
 			}
-			m.statusMessage = statusMsg
+			cmds = append(cmds, m.notify(toastInfo, statusMsg, 5*time.Second))
 		} else {
 			// Store state for confirmation
 			m.pruneConfirm = pruneConfirmState{
@@ -966,13 +2336,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case operations.PruneResultMsg:
 		// Handle prune operation result
 		m.pruneConfirm = pruneConfirmState{} // Clear confirmation state
+		m.statusMessage = ""                 // Clear "Pruning..."
 
 		if msg.Error != nil {
-			m.statusMessage = fmt.Sprintf("Prune failed: %v", msg.Error)
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Prune failed: %v", msg.Error), 5*time.Second))
 		} else if msg.Deleted == 0 {
-			m.statusMessage = "No items were pruned"
+			cmds = append(cmds, m.notify(toastInfo, "No items were pruned", 3*time.Second))
 		} else {
-			m.statusMessage = fmt.Sprintf("Pruned %d unprioritized items", msg.Deleted)
+			cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("Pruned %d unprioritized items", msg.Deleted), 3*time.Second))
 			// Trigger refresh to update the UI
 			cmds = append(cmds, func() tea.Msg {
 				return commands.RefreshMsg{PreserveCursor: true}
@@ -981,7 +2352,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case operations.SourceOperationMsg:
 		// Handle source operation message from operations package
-		m.statusMessage = msg.Message
+		if msg.Success {
+			cmds = append(cmds, m.notify(toastSuccess, msg.Message, 3*time.Second))
+		} else {
+			cmds = append(cmds, m.notify(toastError, msg.Message, 5*time.Second))
+		}
 
 		// If operation was successful, trigger a refresh to show changes
 		if msg.Success {
@@ -996,45 +2371,158 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case operations.ContextReviewedMsg:
-		// Handle context review result
 		if msg.Error != nil {
-			m.statusMessage = fmt.Sprintf("Error: %v", msg.Error)
-		} else if msg.Count == 0 {
-			m.statusMessage = "No items flagged"
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Error: %v", msg.Error), 5*time.Second))
+		} else if len(msg.Items) == 0 {
+			cmds = append(cmds, m.notify(toastInfo, "No items flagged", 3*time.Second))
 		} else {
-			m.statusMessage = fmt.Sprintf("%d item%s flagged", msg.Count, pluralize(msg.Count))
+			m.contextReview.SetSize(m.width, m.height)
+			m.contextReview.Open(msg.Items)
+		}
+
+	case operations.ContextReviewAppliedMsg:
+		m.statusMessage = ""
+		// Unflag what was resolved, even if part of the session failed
+		resolved := map[string]bool{}
+		for _, id := range msg.Unflagged {
+			resolved[id] = true
+		}
+		for _, list := range [][]db.ContentItem{m.items, m.itemsCache} {
+			for i := range list {
+				if resolved[list[i].ID] {
+					list[i].UserFeedback = ""
+				}
+			}
+		}
+		switch {
+		case msg.Error != nil:
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Context review: %v", msg.Error), 5*time.Second))
+		case len(msg.Added) == 0:
+			cmds = append(cmds, m.notify(toastInfo, fmt.Sprintf("Unflagged %d item%s; no new topics", len(msg.Unflagged), pluralize(len(msg.Unflagged))), 3*time.Second))
+		default:
+			// Offer to apply the new topics to items they would have caught
+			where := "context.md"
+			if msg.Section != "" {
+				where = strings.ReplaceAll(msg.Section, "_", " ")
+			}
+			m.reanalyzeConfirm = true
+			m.statusMessage = fmt.Sprintf("Added %d topic%s to %s. Re-analyze unprioritized items from the last %d days? (y/n) ",
+				len(msg.Added), pluralize(len(msg.Added)), where, reanalyzeDays)
 		}
 
 	case operations.ContextSuggestionsMsg:
 		// Handle context suggestions result
-		if msg.Error != nil {
-			m.statusMessage = fmt.Sprintf("Error: %v", msg.Error)
+		m.statusMessage = "" // Clear "Analyzing flagged items..."
+		if operations.IsCancelled(msg.Error) {
+			cmds = append(cmds, m.notify(toastInfo, "Context suggestions cancelled", 3*time.Second))
+		} else if msg.Error != nil {
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Error: %v", msg.Error), 5*time.Second))
 		} else if msg.Count == 0 {
-			m.statusMessage = "No new topics suggested (copied to clipboard)"
+			cmds = append(cmds, m.notify(toastInfo, "No new topics suggested (copied to clipboard)", 5*time.Second))
 		} else {
-			m.statusMessage = fmt.Sprintf("%d topic%s suggested (copied to clipboard)", msg.Count, pluralize(msg.Count))
+			cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("%d topic%s suggested (copied to clipboard)", msg.Count, pluralize(msg.Count)), 5*time.Second))
 		}
-		cmds = append(cmds, clearStatusAfterDelay(5*time.Second))
 
 	case operations.ContextEditMsg:
 		// Handle context edit result
 		if msg.Error != nil {
-			m.statusMessage = fmt.Sprintf("Error: %v", msg.Error)
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Error: %v", msg.Error), 5*time.Second))
 		} else {
-			m.statusMessage = "Context file closed"
+			cmds = append(cmds, m.notify(toastInfo, "Context file closed", 3*time.Second))
+		}
+
+	case operations.ContextTopicAddedMsg:
+		m.statusMessage = ""
+		switch {
+		case msg.Error != nil:
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to add topic: %v", msg.Error), 5*time.Second))
+		case !msg.Added:
+			cmds = append(cmds, m.notify(toastInfo, fmt.Sprintf("%q is already in context.md", msg.Topic), 3*time.Second))
+		default:
+			// Offer to apply the new topic to items it would have caught
+			m.reanalyzeConfirm = true
+			m.statusMessage = fmt.Sprintf("Added %q to %s. Re-analyze unprioritized items from the last %d days? (y/n) ",
+				msg.Topic, strings.ReplaceAll(msg.Section, "_", " "), reanalyzeDays)
+		}
+
+	case operations.ReanalyzedMsg:
+		m.statusMessage = "" // Clear "Re-analyzing..."
+		switch {
+		case operations.IsCancelled(msg.Error):
+			cmds = append(cmds, m.notify(toastInfo, "Re-analysis cancelled", 3*time.Second))
+		case msg.Error != nil:
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Re-analysis failed: %v", msg.Error), 5*time.Second))
+		default:
+			cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("Re-analyzed %d item%s, %d now prioritized",
+				msg.Evaluated, pluralize(msg.Evaluated), msg.Prioritized), 5*time.Second))
+			if msg.Prioritized > 0 {
+				cmds = append(cmds, func() tea.Msg {
+					return commands.RefreshMsg{PreserveCursor: true}
+				})
+			}
+		}
+
+	case operations.ContextLoadedMsg:
+		if msg.Error != nil {
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Error: %v", msg.Error), 5*time.Second))
+			break
+		}
+		m.contextEditor.SetSize(m.width, m.height)
+		m.contextEditor.Open(msg.Path, msg.Content)
+
+	case operations.ContextSavedMsg:
+		if msg.Error != nil {
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Error: %v", msg.Error), 5*time.Second))
+			break
+		}
+		m.contextEditor.Saved()
+		cmds = append(cmds, m.notify(toastSuccess, "context.md saved", 3*time.Second))
+
+	case operations.AskDeltaMsg:
+		m.chat.AppendDelta(msg.ContentID, msg.Text)
+		cmds = append(cmds, msg.Next())
+
+	case operations.AskDoneMsg:
+		m.chat.Finish(msg.ContentID, msg.Error)
+		// The open conversation shows its own result; otherwise say where it went
+		if !m.chat.IsVisible() || m.chat.itemID != msg.ContentID {
+			switch {
+			case operations.IsCancelled(msg.Error):
+			case msg.Error != nil:
+				cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Ask failed: %v", msg.Error), 5*time.Second))
+			default:
+				cmds = append(cmds, m.notify(toastSuccess, "Answer ready (:ask to view)", 3*time.Second))
+			}
 		}
-		cmds = append(cmds, clearStatusAfterDelay(3*time.Second))
+
+	case operations.PendingSyncMsg:
+		m.pendingWrites = msg.Pending
+		if msg.Replayed > 0 {
+			cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("Synced %d offline change(s)", msg.Replayed), 3*time.Second))
+		}
+
+	case operations.RateLimitedMsg:
+		// A request is backing off after a 429; keep listening for the next one
+		text := fmt.Sprintf("Rate limited, retrying in %ds", int(msg.Wait.Round(time.Second).Seconds()))
+		cmds = append(cmds, m.notify(toastWarn, text, msg.Wait+time.Second), operations.ListenRateLimits())
 
 	case operations.AudioOperationMsg:
 		// Handle audio briefing generation message from operations package
-		m.statusMessage = msg.Message
-
-		// Clear status message after delay (success or error)
-		cmds = append(cmds, clearStatusAfterDelay(5*time.Second))
+		m.statusMessage = "" // Clear "Generating audio briefing..."
+		level := toastSuccess
+		if !msg.Success {
+			level = toastError
+		}
+		cmds = append(cmds, m.notify(level, msg.Message, 5*time.Second))
+		if msg.Success {
+			// Show what the briefing says so it can be skimmed while it plays
+			cmds = append(cmds, operations.LoadBriefingTranscript(msg.Filename))
+		}
 
 	case operations.ExtractOperationMsg:
 		// Handle deep extraction result. Locate the item by ID (not cursor index)
 		// so a cursor move during the LLM call doesn't patch the wrong item.
+		m.statusMessage = "" // Clear "Extracting..."
 		if msg.Success && msg.DeepExtraction != nil {
 			for i, item := range m.items {
 				if item.ID == msg.ContentID {
@@ -1044,39 +2532,95 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					analysis["deep_extraction"] = msg.DeepExtraction
 					if updated, err := json.Marshal(analysis); err == nil {
-						m.items[i].Analysis = string(updated)
+						m.items[i].SetAnalysis(string(updated))
 					}
 					break
 				}
 			}
-			m.statusMessage = "Deep synthesis ready"
 			m.updateReaderContent()
-			cmds = append(cmds, clearStatusAfterDelay(3*time.Second))
+			cmds = append(cmds, m.notify(toastSuccess, "Deep synthesis ready", 3*time.Second))
+		} else if operations.IsCancelled(msg.Error) {
+			cmds = append(cmds, m.notify(toastInfo, "Extraction cancelled", 3*time.Second))
 		} else {
-			m.statusMessage = fmt.Sprintf("Extraction failed: %v", msg.Error)
-			cmds = append(cmds, clearStatusAfterDelay(5*time.Second))
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Extraction failed: %v", msg.Error), 5*time.Second))
 		}
 
-	case operations.FabricOperationMsg:
-		// Handle Fabric operation results
-		if msg.Success {
-			if msg.Result != "" {
-				// Show result in reader view or modal (for now, just status)
-				m.statusMessage = msg.Message
-				// TODO: Display full result in a modal or reader view
-			} else {
-				// Show patterns list or success message
-				m.statusMessage = msg.Message
+	case operations.ArticleAudioMsg:
+		m.statusMessage = "" // Clear "Narrating article..."
+		if operations.IsCancelled(msg.Error) {
+			cmds = append(cmds, m.notify(toastInfo, "Narration cancelled", 3*time.Second))
+			break
+		}
+		if msg.Error != nil {
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Listen failed: %v", msg.Error), 5*time.Second))
+			break
+		}
+		if m.player == nil {
+			m.player = &audioPlayer{}
+		}
+		done, err := m.player.play(msg.Path, msg.Title)
+		if err != nil {
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Listen failed: %v (audio saved to %s)", err, msg.Path), 8*time.Second))
+			break
+		}
+		cmds = append(cmds, done, m.notify(toastSuccess, "Playing: "+msg.Title, 3*time.Second))
+
+	case playerDoneMsg:
+		// Stopped or replaced playbacks report too; only the current one counts
+		if m.player.finished(msg.gen) && msg.err != nil {
+			cmds = append(cmds, m.notify(toastWarn, fmt.Sprintf("Player exited: %v", msg.err), 5*time.Second))
+		}
+
+	case operations.SummarizedMsg:
+		// Patch the item by ID so a cursor move during the LLM call can't hit the wrong one
+		m.statusMessage = "" // Clear "Summarizing..."
+		if msg.Error == nil {
+			for i, item := range m.items {
+				if item.ID == msg.ContentID {
+					m.items[i].Summary = msg.Summary
+					m.items[i].SetAnalysis(msg.Analysis)
+					break
+				}
 			}
+			m.updateReaderContent()
+			cmds = append(cmds, m.notify(toastSuccess, "Summary ready", 3*time.Second))
+		} else if operations.IsCancelled(msg.Error) {
+			cmds = append(cmds, m.notify(toastInfo, "Summarization cancelled", 3*time.Second))
 		} else {
-			m.statusMessage = msg.Message
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Summarization failed: %v", msg.Error), 5*time.Second))
 		}
 
-		// Clear status message after delay
-		cmds = append(cmds, clearStatusAfterDelay(5*time.Second))
+	case operations.FabricOperationMsg:
+		// Handle Fabric operation results
+		// TODO: Display full result in a modal or reader view (for now, just a toast)
+		level := toastSuccess
+		if !msg.Success {
+			level = toastError
+		}
+		cmds = append(cmds, m.notify(level, msg.Message, 5*time.Second))
 
 	// Article operation messages from operations package
+	case markReadDueMsg:
+		return m, m.markReadDue(msg)
+
 	case operations.ArticleMarkedMsg:
+		if msg.Auto {
+			// Quietly, and without refreshing the list out from under the reader
+			if !msg.Success {
+				return m, m.notify(toastWarn, fmt.Sprintf("Failed to mark read: %v", msg.Error), 5*time.Second)
+			}
+			for i, item := range m.items {
+				if item.ID == msg.ID {
+					m.items[i].Read = true
+					break
+				}
+			}
+			m.setCachedState([]string{msg.ID}, func(item *db.ContentItem) { item.Read = true })
+			if msg.Queued {
+				return m, operations.CountPendingWrites()
+			}
+			return m, nil
+		}
 		if msg.Success {
 			// Update the item in our local state
 			for i, item := range m.items {
@@ -1085,11 +2629,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					break
 				}
 			}
+			m.setCachedState([]string{msg.ID}, func(item *db.ContentItem) { item.Read = msg.Read })
+			var text string
 			if msg.Read {
-				m.statusMessage = "Marked as read"
+				text = "Marked as read"
 			} else {
-				m.statusMessage = "Marked as unread"
+				text = "Marked as unread"
 			}
+			if msg.Queued {
+				text += " (offline, will sync)"
+				cmds = append(cmds, operations.CountPendingWrites())
+			}
+			cmds = append(cmds, m.notify(toastSuccess, text, 2*time.Second))
 
 			// If we're in unread-only mode and just marked as read, refresh to filter it out
 			// Or if we're showing all and just marked as unread, refresh to ensure proper display
@@ -1107,9 +2658,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, refreshCmd)
 			}
 		} else {
-			m.statusMessage = fmt.Sprintf("Failed to mark: %v", msg.Error)
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to mark: %v", msg.Error), 5*time.Second))
 		}
-		cmds = append(cmds, clearStatusAfterDelay(2*time.Second))
 
 	case operations.ArticleFavoritedMsg:
 		if msg.Success {
@@ -1120,15 +2670,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					break
 				}
 			}
+			m.setCachedState([]string{msg.ID}, func(item *db.ContentItem) { item.Favorited = msg.Favorited })
+			var text string
 			if msg.Favorited {
-				m.statusMessage = "★ Favorited"
+				text = "★ Favorited"
 			} else {
-				m.statusMessage = "☆ Unfavorited"
+				text = "☆ Unfavorited"
+			}
+			if msg.Queued {
+				text += " (offline, will sync)"
+				cmds = append(cmds, operations.CountPendingWrites())
+			}
+			cmds = append(cmds, m.notify(toastSuccess, text, 2*time.Second))
+		} else {
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to toggle favorite: %v", msg.Error), 5*time.Second))
+		}
+
+	case operations.ArticleTaggedMsg:
+		if msg.Success {
+			// Update the item in our local state
+			for i, item := range m.items {
+				if item.ID == msg.ID {
+					m.items[i].UserTags = msg.Tags
+					break
+				}
+			}
+			text := "Tags cleared"
+			if len(msg.Tags) > 0 {
+				text = "Tagged: " + strings.Join(msg.Tags, ", ")
+			}
+			if msg.Queued {
+				text += " (offline, will sync)"
+				cmds = append(cmds, operations.CountPendingWrites())
+			}
+			cmds = append(cmds, m.notify(toastSuccess, text, 2*time.Second))
+
+			// A tag filter may no longer match
+			if m.filterTag != "" {
+				cmds = append(cmds, func() tea.Msg {
+					return commands.RefreshMsg{PreserveCursor: true}
+				})
 			}
 		} else {
-			m.statusMessage = fmt.Sprintf("Failed to toggle favorite: %v", msg.Error)
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to tag: %v", msg.Error), 5*time.Second))
 		}
-		cmds = append(cmds, clearStatusAfterDelay(2*time.Second))
 
 	case operations.ArticleVotedMsg:
 		if msg.Success {
@@ -1139,18 +2724,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					break
 				}
 			}
+			var text string
 			switch msg.Vote {
 			case "up":
-				m.statusMessage = "👍 Upvoted"
+				text = "👍 Upvoted"
 			case "down":
-				m.statusMessage = "👎 Downvoted"
+				text = "👎 Downvoted"
 			default:
-				m.statusMessage = "Vote cleared"
+				text = "Vote cleared"
+			}
+			if msg.Queued {
+				text += " (offline, will sync)"
+				cmds = append(cmds, operations.CountPendingWrites())
 			}
+			cmds = append(cmds, m.notify(toastSuccess, text, 2*time.Second))
 		} else {
-			m.statusMessage = fmt.Sprintf("Failed to vote: %v", msg.Error)
+			cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Failed to vote: %v", msg.Error), 5*time.Second))
 		}
-		cmds = append(cmds, clearStatusAfterDelay(2*time.Second))
 	}
 
 	if len(cmds) > 0 {
@@ -1159,8 +2749,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// View renders the current model state
+// View renders the current model state, timed under the debug overlay
 func (m Model) View() string {
+	defer m.reportPanic("View")
+	if m.debug == nil {
+		return m.render()
+	}
+	began := time.Now()
+	view := m.render()
+	m.debug.traceRender(time.Since(began), view, m)
+	return overlayDebug(view, m, m.width)
+}
+
+// render draws the list or reader and any open modal
+func (m Model) render() string {
 	// RenderList now handles both list and reader views
 	baseView := RenderList(m)
 
@@ -1174,31 +2776,84 @@ func (m Model) View() string {
 		return m.helpModal.ViewWithOverlay(baseView, m.width, m.height, m.theme)
 	}
 
+	// Overlay command palette if visible (with dimming)
+	if m.palette.IsVisible() {
+		return m.palette.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay context editor if visible (with dimming)
+	if m.contextEditor.IsVisible() {
+		return m.contextEditor.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay chat if visible (with dimming)
+	if m.chat.IsVisible() {
+		return m.chat.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay triage modal if visible (with dimming)
+	if m.triageModal.IsVisible() {
+		return m.triageModal.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay dedupe modal if visible (with dimming)
+	if m.dedupeModal.IsVisible() {
+		return m.dedupeModal.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay digest modal if visible (with dimming)
+	if m.digestModal.IsVisible() {
+		return m.digestModal.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay briefing transcript if visible (with dimming)
+	if m.transcript.IsVisible() {
+		return m.transcript.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay messages modal if visible (with dimming)
+	if m.messagesModal.IsVisible() {
+		return m.messagesModal.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay errors modal if visible (with dimming)
+	if m.errorsModal.IsVisible() {
+		return m.errorsModal.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
+	// Overlay context review if visible (with dimming)
+	if m.contextReview.IsVisible() {
+		return m.contextReview.ViewWithOverlay(baseView, m.width, m.height, m.theme)
+	}
+
 	return baseView
 }
 
 // fetchItemsWithState returns a command that fetches content with all current state applied
 // If refreshData is false and in remote mode, just re-filters cached data without making API calls
 func fetchItemsWithState(m Model, refreshData bool) tea.Cmd {
-	return func() tea.Msg {
-		// Remote mode: check if we need to refresh or just re-filter
+	if m.remoteURL != "" && m.archiveScope() != db.ActiveContent && (refreshData || m.archivedCache == nil) {
+		// The sync leaves archived items out; fetch them for this scope
+		return fetchArchivedRemote(m)
+	}
+	if m.remoteURL != "" && (refreshData || (m.needsContent() && hasPartial(m.itemsCache))) {
+		// Actually fetch new data from API, in the background. An expression
+		// reading article text needs the content the list sync leaves out.
+		return remoteFetch(syncJob{model: m})
+	}
+	return withSeq(m.fetchSeq, func() itemsLoadedMsg {
+		// Remote mode: just re-filter cached data (instant)
 		if m.remoteURL != "" {
-			if refreshData {
-				// Actually fetch new data from API
-				return fetchItemsRemote(m)
-			} else {
-				// Just re-filter cached data (instant)
-				filtered := applyFiltersClientSide(m.itemsCache, m)
-				return itemsLoadedMsg{
-					items:       filtered,
-					hiddenCount: countHiddenUnprioritized(m.itemsCache, m),
-					err:         nil,
-				}
+			items := withArchived(m.itemsCache, m.archivedCache)
+			return itemsLoadedMsg{
+				items:       applyFiltersClientSide(items, m),
+				hiddenCount: countHiddenUnprioritized(items, m),
+				err:         nil,
 			}
 		}
 
 		// Local mode: fetch all content, filter client-side (unified with remote mode)
-		allItems, err := db.GetAllContent(m.showArchived)
+		allItems, err := db.GetContentIn(m.archiveScope(), m.filterPredicates()...)
 		if err != nil {
 			return itemsLoadedMsg{err: err}
 		}
@@ -1207,37 +2862,75 @@ func fetchItemsWithState(m Model, refreshData bool) tea.Cmd {
 			hiddenCount: countHiddenUnprioritized(allItems, m),
 			err:         nil,
 		}
-	}
+	})
 }
 
-// fetchItemsRemote fetches items via API and applies filters client-side
-func fetchItemsRemote(m Model) itemsLoadedMsg {
+// fetchItemsRemote fetches items via API and applies filters client-side.
+// op (nil for a background sync) lets Esc cancel it; progress (may be nil)
+// is called after each page of the sync.
+func fetchItemsRemote(m Model, op *operations.Op, progress func(api.SyncProgress)) itemsLoadedMsg {
 	// Create API client with remote URL
 	client, err := api.NewClientWithURL(m.remoteURL)
 	if err != nil {
 		return itemsLoadedMsg{err: err}
 	}
+	// Time to first byte of the quickest page stands in for network latency
+	var latency time.Duration
+	client.Use(api.MetricsMiddleware(func(_, _ string, status int, elapsed time.Duration) {
+		if status > 0 && (latency == 0 || elapsed < latency) {
+			latency = elapsed
+		}
+	}))
 
 	var apiItems []api.ContentItem
 	var allItems []db.ContentItem
 
+	// The list only needs list-level fields; the reader loads the rest on
+	// demand. An expression reading article text needs it for every item.
+	fetch, since := client.FetchEntryListPaged, m.lastSync
+	if m.needsContent() {
+		fetch = client.FetchEntriesPaged
+		if hasPartial(m.itemsCache) {
+			since = time.Time{}
+		}
+	}
+
+	// Full syncs over slow links can take a while; Esc cancels one the user started
+	ctx, release := op.Start()
+	defer release()
+
 	// Initial load vs incremental sync
 	if m.lastSync.IsZero() {
 		// Initial load: fetch everything
-		apiItems, err = client.FetchEntries()
+		apiItems, err = fetch(ctx, time.Time{}, progress)
 		if err != nil {
-			return itemsLoadedMsg{err: err}
+			// Daemon unreachable: fall back to what was saved for offline reading
+			if m.offline && len(m.itemsCache) == 0 && !operations.IsCancelled(err) {
+				if snapshot, loadErr := loadOfflineCache(); loadErr == nil && len(snapshot.Items) > 0 {
+					return itemsLoadedMsg{
+						items:       applyFiltersClientSide(snapshot.Items, m),
+						hiddenCount: countHiddenUnprioritized(snapshot.Items, m),
+						allItems:    snapshot.Items,
+						updateCache: true,
+						offlineAt:   snapshot.SavedAt,
+						syncFailed:  true,
+					}
+				}
+			}
+			return itemsLoadedMsg{err: err, syncFailed: !operations.IsCancelled(err)}
 		}
 		allItems = make([]db.ContentItem, 0, len(apiItems))
 	} else {
 		// Incremental sync: fetch only new/changed items
-		apiItems, err = client.FetchEntriesSince(m.lastSync)
+		apiItems, err = fetch(ctx, since, progress)
 		if err != nil {
 			// On error, show cached data
+			cached := withArchived(m.itemsCache, m.archivedCache)
 			return itemsLoadedMsg{
-				items:       applyFiltersClientSide(m.itemsCache, m),
-				hiddenCount: countHiddenUnprioritized(m.itemsCache, m),
+				items:       applyFiltersClientSide(cached, m),
+				hiddenCount: countHiddenUnprioritized(cached, m),
 				err:         err,
+				syncFailed:  !operations.IsCancelled(err),
 			}
 		}
 
@@ -1254,33 +2947,27 @@ func fetchItemsRemote(m Model) itemsLoadedMsg {
 		}
 	}
 
+	// Read and favorite state that differs from the cache changed elsewhere.
+	// The daemon's wins, except where a write queued here will replace it.
+	cached := make(map[string]db.ContentItem, len(m.itemsCache))
+	for _, item := range m.itemsCache {
+		cached[item.ID] = item
+	}
+	queued := operations.QueuedFields()
+	var conflicts []syncConflict
+	syncedAt := time.Now()
+
 	// Convert API items to DB format
 	for _, apiItem := range apiItems {
-		priority := ""
-		if apiItem.Priority != nil {
-			priority = *apiItem.Priority
-		}
-		analysis := ""
-		if len(apiItem.Analysis) > 0 && string(apiItem.Analysis) != "null" {
-			analysis = string(apiItem.Analysis)
-		}
-
-		newItem := db.ContentItem{
-			ID:                  apiItem.ID,
-			Title:               apiItem.Title,
-			URL:                 apiItem.URL,
-			Summary:             apiItem.Summary,
-			Priority:            priority,
-			Content:             apiItem.Content,
-			Analysis:            analysis,
-			Published:           apiItem.PublishedAt.Time,
-			Read:                apiItem.Read,
-			Favorited:           apiItem.Favorited,
-			InterestingOverride: apiItem.InterestingOverride,
-			UserFeedback:        apiItem.UserFeedback,
-			SourceType:          apiItem.SourceType,
-			SourceName:          apiItem.SourceName,
-			SourceID:            apiItem.SourceID,
+		newItem := contentFromAPI(apiItem)
+		if old, ok := cached[newItem.ID]; ok {
+			if queued[newItem.ID]["read"] {
+				newItem.Read = old.Read
+			}
+			if queued[newItem.ID]["favorited"] {
+				newItem.Favorited = old.Favorited
+			}
+			conflicts = append(conflicts, stateConflicts(old, newItem, syncedAt)...)
 		}
 
 		// Merge: replace existing item or append new
@@ -1298,7 +2985,7 @@ func fetchItemsRemote(m Model) itemsLoadedMsg {
 	}
 
 	// Apply filters client-side
-	filtered := applyFiltersClientSide(allItems, m)
+	filtered := applyFiltersClientSide(withArchived(allItems, m.archivedCache), m)
 
 	// Return both filtered items (for display) and all items (for caching)
 	return itemsLoadedMsg{
@@ -1307,6 +2994,9 @@ func fetchItemsRemote(m Model) itemsLoadedMsg {
 		allItems:    allItems,
 		updateCache: true,
 		newLastSync: newestFetchedAt,
+		syncedAt:    syncedAt,
+		latency:     latency,
+		conflicts:   conflicts,
 		err:         nil,
 	}
 }
@@ -1325,85 +3015,196 @@ func countHiddenUnprioritized(items []db.ContentItem, m Model) int {
 	return count
 }
 
+// contentFromAPI converts an item from the daemon's API to the form the list uses
+func contentFromAPI(apiItem api.ContentItem) db.ContentItem {
+	priority := ""
+	if apiItem.Priority != nil {
+		priority = *apiItem.Priority
+	}
+	analysis := ""
+	if len(apiItem.Analysis) > 0 && string(apiItem.Analysis) != "null" {
+		analysis = string(apiItem.Analysis)
+	}
+
+	item := db.ContentItem{
+		ID:                  apiItem.ID,
+		Title:               apiItem.Title,
+		URL:                 apiItem.URL,
+		Summary:             apiItem.Summary,
+		Priority:            priority,
+		Content:             apiItem.Content,
+		Published:           apiItem.PublishedAt.Time,
+		Fetched:             apiItem.FetchedAt.Time,
+		Author:              apiItem.Author,
+		Read:                apiItem.Read,
+		Favorited:           apiItem.Favorited,
+		InterestingOverride: apiItem.InterestingOverride,
+		UserFeedback:        apiItem.UserFeedback,
+		SourceType:          apiItem.SourceType,
+		SourceName:          apiItem.SourceName,
+		SourceID:            apiItem.SourceID,
+		UserTags:            apiItem.UserTags,
+		Archived:            apiItem.ArchivedAt != nil,
+	}
+	if apiItem.SnoozedUntil != nil {
+		item.SnoozedUntil = apiItem.SnoozedUntil.Time
+	}
+	if apiItem.ContentLength != nil {
+		// From the list sync: the reader loads the content on demand
+		item.Partial = true
+		item.ContentLength = *apiItem.ContentLength
+	}
+	item.Pinned = apiItem.Pinned
+	item.SetAnalysis(analysis)
+	return item
+}
+
 // applyFiltersClientSide applies TUI filters to items (for remote mode)
 func applyFiltersClientSide(items []db.ContentItem, m Model) []db.ContentItem {
 	filtered := make([]db.ContentItem, 0, len(items))
 
+	// Items don't carry a category, so resolve it through their source
+	categoryBySource := make(map[string]string)
+	if m.filterCategory != "" {
+		for _, source := range m.sources {
+			categoryBySource[source.ID] = source.Category
+		}
+	}
+
+	expr, search, scope := m.filterExpr(), m.searchExpr(), m.archiveScope()
+	now := time.Now()
 	for _, item := range items {
-		// Filter by priority
-		if m.priority == "high" && item.Priority != "high" {
+		// Snoozed items stay hidden in every view until they wake
+		if isSnoozed(item, now) {
 			continue
 		}
-		if m.priority == "medium" && item.Priority != "medium" {
+
+		// Pinned items show in every view; only the source filters narrow them
+		if !item.Pinned && !inView(item, m) {
 			continue
 		}
-		if m.priority == "low" && item.Priority != "low" {
+
+		// Filter by source type
+		if m.filterType != "all" && item.SourceType != m.filterType {
 			continue
 		}
-		if m.priority == "favorites" && !item.Favorited {
+
+		// Filter by single source
+		if m.filterSource != "" && item.SourceID != m.filterSource {
 			continue
 		}
-		if m.priority == "unprioritized" && item.Priority != "" {
+
+		// Filter by user tag
+		if m.filterTag != "" && !hasTag(item, m.filterTag) {
 			continue
 		}
-		// "all" shows all priorities
 
-		// Filter unprioritized items unless explicitly showing them (but not when showing interesting items)
-		if !m.showUnprioritized && !m.showInteresting && m.priority != "unprioritized" && item.Priority == "" {
+		// Filter by byline
+		if m.filterAuthor != "" && !strings.Contains(strings.ToLower(item.Author), strings.ToLower(m.filterAuthor)) {
 			continue
 		}
 
-		// Filter by read status (default: unread only)
-		// Exception: favorites always show regardless of read status
-		if !m.showAll && m.priority != "favorites" && item.Read {
+		// Filter by source category
+		if m.filterCategory != "" && !strings.EqualFold(categoryBySource[item.SourceID], m.filterCategory) {
 			continue
 		}
 
-		// Filter by upvoted flag (previously "interesting")
-		if m.showInteresting && item.UserFeedback != "up" {
+		// Filter by when the item arrived
+		if !arrivedBetween(item, m.filterSince, m.filterUntil) {
 			continue
 		}
 
-		// Filter by source type
-		if m.filterType != "all" && item.SourceType != m.filterType {
+		// Filter by the view's :filter expression and the running saved search
+		// (local mode ran both in SQL already)
+		if expr != nil && !expr.Match(item, now) {
+			continue
+		}
+		if search != nil && !search.Match(item, now) {
 			continue
 		}
 
-		// Note: archived filter is applied at query level (GetAllContent), not here
+		// Local mode applied the archive scope in SQL; the remote cache holds
+		// archived items only once something asked for them
+		if !scope.Includes(item.Archived) {
+			continue
+		}
 
 		filtered = append(filtered, item)
 	}
 
-	// Apply sort order
-	sortItemsByDate(filtered, m.sortNewest)
+	// Apply sort order, then bring pinned items and those back from a
+	// snooze to the top
+	sortItems(filtered, m.sortOrder())
+	floatToTop(filtered, now)
 
 	return filtered
 }
 
-// sortItemsByDate sorts items in place by published date
-func sortItemsByDate(items []db.ContentItem, newest bool) {
-	// Sort using Go's sort.Slice
-	for i := 0; i < len(items)-1; i++ {
-		for j := i + 1; j < len(items); j++ {
-			swap := false
-			if newest {
-				// Newest first: later dates come first
-				swap = items[j].Published.After(items[i].Published)
-			} else {
-				// Oldest first: earlier dates come first
-				swap = items[j].Published.Before(items[i].Published)
-			}
-			if swap {
-				items[i], items[j] = items[j], items[i]
-			}
-		}
+// arrivedBetween reports whether item arrived in [since, until). Arrival is
+// when it was fetched, or published for items without a fetch time; zero
+// bounds are open.
+func arrivedBetween(item db.ContentItem, since, until time.Time) bool {
+	arrived := item.Fetched
+	if arrived.IsZero() {
+		arrived = item.Published
 	}
+	if !since.IsZero() && arrived.Before(since) {
+		return false
+	}
+	if !until.IsZero() && !arrived.Before(until) {
+		return false
+	}
+	return true
+}
+
+// inView reports whether item belongs in the current view: its priority
+// tab or flag view, read status, and the upvoted toggle
+func inView(item db.ContentItem, m Model) bool {
+	// Filter by priority
+	if m.priority == "high" && item.Priority != "high" {
+		return false
+	}
+	if m.priority == "medium" && item.Priority != "medium" {
+		return false
+	}
+	if m.priority == "low" && item.Priority != "low" {
+		return false
+	}
+	if m.priority == "favorites" && !item.Favorited {
+		return false
+	}
+	if m.priority == "interesting" && item.UserFeedback != "up" {
+		return false
+	}
+	if m.priority == "unprioritized" && item.Priority != "" {
+		return false
+	}
+	// "all" shows all priorities
+
+	// Filter unprioritized items unless explicitly showing them (but not when showing flagged items)
+	if !m.showUnprioritized && !m.showInteresting && !db.FlagView(m.priority) && m.priority != "unprioritized" && item.Priority == "" {
+		return false
+	}
+
+	// Filter by read status (default: unread only)
+	// Exception: flag views (favorites, interesting) show read items too,
+	// as the local query does
+	if !m.showAll && !db.FlagView(m.priority) && item.Read {
+		return false
+	}
+
+	// Filter by upvoted flag (previously "interesting")
+	if m.showInteresting && item.UserFeedback != "up" {
+		return false
+	}
+
+	return true
 }
 
 // autoRefreshCmd returns a command that triggers auto-refresh after the specified interval
-func autoRefreshCmd(interval time.Duration) tea.Cmd {
+func autoRefreshCmd(interval time.Duration, gen int) tea.Cmd {
 	return tea.Tick(interval, func(t time.Time) tea.Msg {
-		return autoRefreshMsg{}
+		return autoRefreshMsg{gen: gen}
 	})
 }
 
@@ -1429,7 +3230,10 @@ func fetchSourcesRemote(remoteURL string) sourcesLoadedMsg {
 		return sourcesLoadedMsg{err: err}
 	}
 
-	apiSources, err := client.GetSources()
+	ctx, release := operations.Cancellable()
+	defer release()
+
+	apiSources, err := client.GetSources(ctx)
 	if err != nil {
 		return sourcesLoadedMsg{err: err}
 	}
@@ -1441,6 +3245,17 @@ func fetchSourcesRemote(remoteURL string) sourcesLoadedMsg {
 		if apiSource.Name != nil {
 			name = *apiSource.Name
 		}
+		category := ""
+		if apiSource.Category != nil {
+			category = *apiSource.Category
+		}
+		var floor, ceiling string
+		if apiSource.PriorityFloor != nil {
+			floor = *apiSource.PriorityFloor
+		}
+		if apiSource.PriorityCeiling != nil {
+			ceiling = *apiSource.PriorityCeiling
+		}
 		sources = append(sources, db.Source{
 			ID:          apiSource.ID,
 			URL:         apiSource.URL,
@@ -1450,6 +3265,10 @@ func fetchSourcesRemote(remoteURL string) sourcesLoadedMsg {
 			UnreadCount: 0, // API doesn't provide unread counts
 			LastFetched: apiSource.LastFetched,
 			ErrorCount:  apiSource.ErrorCount,
+			Category:    category,
+			// Rules only come from daemons with FeatureSourceRules
+			PriorityFloor:   floor,
+			PriorityCeiling: ceiling,
 		})
 	}
 
@@ -1476,13 +3295,6 @@ func calculateUnreadCounts(sources []db.Source, items []db.ContentItem) []db.Sou
 	return sources
 }
 
-// clearStatusAfterDelay returns a command that clears the status message after a delay
-func clearStatusAfterDelay(delay time.Duration) tea.Cmd {
-	return tea.Tick(delay, func(t time.Time) tea.Msg {
-		return clearStatusMsg{}
-	})
-}
-
 // flashItemCmd returns a command that flashes an item and then clears it
 func flashItemCmd() tea.Cmd {
 	return tea.Tick(200*time.Millisecond, func(t time.Time) tea.Msg {
@@ -1500,6 +3312,13 @@ func (m *Model) updateSourcesViewport() {
 func (m *Model) buildSourcesContent(theme StyleTheme) string {
 	ls := lipgloss.NewStyle()
 
+	// Once any source has a category, group by category instead of type
+	for _, source := range m.sources {
+		if source.Category != "" {
+			return m.buildSourcesContentByCategory(theme)
+		}
+	}
+
 	// Group sources by type for display
 	sourcesByType := make(map[string][]db.Source)
 	for _, source := range m.sources {
@@ -1550,6 +3369,64 @@ func (m *Model) buildSourcesContent(theme StyleTheme) string {
 	return strings.Join(lines, "\n")
 }
 
+// buildSourcesContentByCategory builds the source list grouped by category,
+// alphabetically, with uncategorized sources last
+func (m *Model) buildSourcesContentByCategory(theme StyleTheme) string {
+	ls := lipgloss.NewStyle()
+
+	sourcesByCategory := make(map[string][]db.Source)
+	var categories []string
+	for _, source := range m.sources {
+		if _, ok := sourcesByCategory[source.Category]; !ok && source.Category != "" {
+			categories = append(categories, source.Category)
+		}
+		sourcesByCategory[source.Category] = append(sourcesByCategory[source.Category], source)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return strings.ToLower(categories[i]) < strings.ToLower(categories[j])
+	})
+	if _, ok := sourcesByCategory[""]; ok {
+		categories = append(categories, "")
+	}
+
+	var lines []string
+	for i, category := range categories {
+		label := strings.ToUpper(category)
+		if category == "" {
+			label = "UNCATEGORIZED"
+		}
+		headerColor := theme.Cyan
+		if m.filterCategory != "" && strings.EqualFold(category, m.filterCategory) {
+			headerColor = theme.Orange // Highlight the active category filter
+		}
+		header := ls.Foreground(headerColor).Bold(true).Render(fmt.Sprintf("%s [%d]", label, len(sourcesByCategory[category])))
+		lines = append(lines, header)
+		for _, source := range sourcesByCategory[category] {
+			lines = append(lines, m.formatSourceLine(source, theme))
+		}
+		if i < len(categories)-1 {
+			lines = append(lines, "")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// findSource looks a source up by ID, then name, then URL (case-insensitive)
+func findSource(sources []db.Source, query string) (db.Source, bool) {
+	for _, source := range sources {
+		if source.ID == query {
+			return source, true
+		}
+	}
+	for _, source := range sources {
+		if strings.EqualFold(source.Name, query) || strings.EqualFold(source.URL, query) {
+			return source, true
+		}
+	}
+	return db.Source{}, false
+}
+
 // formatSourceLine formats a single source line with status indicator and count
 func (m *Model) formatSourceLine(source db.Source, theme StyleTheme) string {
 	ls := lipgloss.NewStyle()
@@ -1577,27 +3454,13 @@ func (m *Model) formatSourceLine(source db.Source, theme StyleTheme) string {
 
 	// Truncate name to fit viewport (like original code did with width-12)
 	// The -12 accounts for status icon, spaces, and count display
-	name := truncate(source.Name, m.sourcesViewport.Width-12)
-
-	return fmt.Sprintf("%s %s %s", status, name, count)
-}
-
-// extractReadingSummary extracts the reading_summary field from the Analysis JSON
-func extractReadingSummary(analysisJSON string) string {
-	if analysisJSON == "" {
-		return ""
-	}
-
-	var analysis map[string]interface{}
-	if err := json.Unmarshal([]byte(analysisJSON), &analysis); err != nil {
-		return ""
-	}
-
-	if readingSummary, ok := analysis["reading_summary"].(string); ok {
-		return readingSummary
+	rule := sourceRuleBadge(source)
+	if rule == "" {
+		name := truncate(source.Name, m.sourcesViewport.Width-12)
+		return fmt.Sprintf("%s %s %s", status, name, count)
 	}
-
-	return ""
+	name := truncate(source.Name, m.sourcesViewport.Width-13-lipgloss.Width(rule))
+	return fmt.Sprintf("%s %s %s %s", status, name, ls.Foreground(theme.Gray).Render(rule), count)
 }
 
 // openInBrowser opens the given URL in the default browser.
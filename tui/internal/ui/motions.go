@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// nextMatching returns the index of the first item after from (dir 1) or
+// before it (dir -1) that matches, or -1 when there is none
+func nextMatching(items []db.ContentItem, from, dir int, match func(db.ContentItem) bool) int {
+	for i := from + dir; i >= 0 && i < len(items); i += dir {
+		if match(items[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isUnread matches items not yet read (n/p)
+func isUnread(item db.ContentItem) bool {
+	return !item.Read
+}
+
+// isHigh matches HIGH priority items (]/[)
+func isHigh(item db.ContentItem) bool {
+	return item.Priority == "high"
+}
+
+// jumpToMatching moves the cursor to the next or previous matching item, in
+// the list or from article to article in the reader. label names what was
+// sought when nothing matches.
+func (m *Model) jumpToMatching(dir int, match func(db.ContentItem) bool, label string) tea.Cmd {
+	if m.view != "list" && m.view != "reader" {
+		return nil
+	}
+	i := nextMatching(m.items, m.cursor, dir, match)
+	if i < 0 {
+		where := "below"
+		if dir < 0 {
+			where = "above"
+		}
+		return m.notify(toastInfo, "No "+label+" items "+where, 2*time.Second)
+	}
+	m.cursor = i
+	if m.view == "reader" {
+		m.updateReaderContent()
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestMotions_SkipToUnreadAndHigh verifies n/p and ]/[ skip past items that don't match.
+// BREAKS: If the motions stop on read or low items, triage is back to scanning by hand.
+func TestMotions_SkipToUnreadAndHigh(t *testing.T) {
+	m := Model{view: "list", focusedPane: "content", items: []db.ContentItem{
+		{ID: "0", Priority: "high"},
+		{ID: "1", Priority: "low", Read: true},
+		{ID: "2", Priority: "medium", Read: true},
+		{ID: "3", Priority: "low"},
+		{ID: "4", Priority: "high", Read: true},
+	}}
+	press := func(key string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+
+	press("n")
+	if m.cursor != 3 {
+		t.Errorf("Expected n to skip read items to 3, got %d", m.cursor)
+	}
+	press("]")
+	if m.cursor != 4 {
+		t.Errorf("Expected ] to reach the next HIGH item, got %d", m.cursor)
+	}
+	press("[")
+	if m.cursor != 0 {
+		t.Errorf("Expected [ to go back to the previous HIGH item, got %d", m.cursor)
+	}
+
+	// Nothing further: the cursor stays and a toast says so
+	press("p")
+	if m.cursor != 0 || len(m.toasts) == 0 {
+		t.Errorf("Expected p at the top to stay put with a notice, got cursor %d, %d toasts", m.cursor, len(m.toasts))
+	}
+}
+
+// TestMotions_ReaderFollows verifies motions in the reader open the article they land on.
+// BREAKS: If the reader content isn't refreshed, the title changes but the old article stays on screen.
+func TestMotions_ReaderFollows(t *testing.T) {
+	m := Model{width: 100, height: 40, view: "reader", focusedPane: "content", items: []db.ContentItem{
+		{ID: "a", Title: "First", Content: "first body"},
+		{ID: "b", Title: "Second", Read: true, Content: "second body"},
+		{ID: "c", Title: "Third", Content: "third body"},
+	}}
+	m.updateReaderContent()
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	if m.cursor != 2 || m.readerItemID != "c" {
+		t.Errorf("Expected the reader on the third article, got cursor %d showing %q", m.cursor, m.readerItemID)
+	}
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+const (
+	maxOfflineImages    = 10      // Images kept per item
+	maxOfflineImageSize = 5 << 20 // Larger images are left online
+	offlineImageTimeout = 20 * time.Second
+)
+
+// Markdown and HTML image references in fetched content
+var (
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\((https?://[^)\s]+)`)
+	htmlImagePattern     = regexp.MustCompile(`<img[^>]+src=["'](https?://[^"']+)["']`)
+)
+
+// offlineSnapshot is what remote mode keeps on disk so the reader works
+// without the daemon
+type offlineSnapshot struct {
+	SavedAt time.Time        `json:"saved_at"`
+	Items   []db.ContentItem `json:"items"`
+}
+
+// offlineSavedMsg reports a finished offline cache write
+type offlineSavedMsg struct {
+	items  int
+	images int
+	err    error
+}
+
+// offlineDirFunc returns the offline cache directory (overridable for testing)
+var offlineDirFunc = defaultOfflineDir
+
+// defaultOfflineDir keeps the offline cache next to the local database
+func defaultOfflineDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "prismis", "offline"), nil
+}
+
+// offlineItems picks what is worth reading offline: unread HIGH and MEDIUM items
+func offlineItems(items []db.ContentItem) []db.ContentItem {
+	var picked []db.ContentItem
+	for _, item := range items {
+		if !item.Read && (item.Priority == "high" || item.Priority == "medium") {
+			picked = append(picked, item)
+		}
+	}
+	return picked
+}
+
+// saveOfflineCache writes the unread HIGH/MEDIUM items to disk in the
+// background, downloading the images their content references and pointing
+// the content at the local copies. Images no longer referenced are removed.
+// Items synced without their content fetch it from remoteURL first.
+func saveOfflineCache(remoteURL string, items []db.ContentItem) tea.Cmd {
+	picked := offlineItems(items)
+	return func() tea.Msg {
+		dir, err := offlineDirFunc()
+		if err != nil {
+			return offlineSavedMsg{err: err}
+		}
+		imageDir := filepath.Join(dir, "images")
+		if err := os.MkdirAll(imageDir, 0o755); err != nil {
+			return offlineSavedMsg{err: fmt.Errorf("failed to create offline directory: %w", err)}
+		}
+
+		client := &http.Client{Timeout: offlineImageTimeout}
+		kept := make(map[string]bool)
+		snapshot := offlineSnapshot{SavedAt: time.Now(), Items: make([]db.ContentItem, len(picked))}
+		for i, item := range picked {
+			if item.Partial {
+				if full, err := loadItem(context.Background(), remoteURL, item.ID); err == nil {
+					item = withBody(item, full)
+				}
+			}
+			item.Content = cacheImages(client, item.Content, imageDir, kept)
+			snapshot.Items[i] = item
+		}
+
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return offlineSavedMsg{err: fmt.Errorf("failed to encode offline cache: %w", err)}
+		}
+		// Write then rename so a crash never leaves a half-written snapshot
+		file := filepath.Join(dir, "items.json")
+		if err := os.WriteFile(file+".tmp", data, 0o644); err != nil {
+			return offlineSavedMsg{err: fmt.Errorf("failed to write offline cache: %w", err)}
+		}
+		if err := os.Rename(file+".tmp", file); err != nil {
+			return offlineSavedMsg{err: fmt.Errorf("failed to write offline cache: %w", err)}
+		}
+
+		if entries, err := os.ReadDir(imageDir); err == nil {
+			for _, entry := range entries {
+				if !kept[entry.Name()] {
+					os.Remove(filepath.Join(imageDir, entry.Name()))
+				}
+			}
+		}
+		return offlineSavedMsg{items: len(picked), images: len(kept)}
+	}
+}
+
+// cacheImages downloads the images content references into imageDir and
+// rewrites them to file:// URLs, recording kept filenames. Images that fail
+// to download keep their original URL.
+func cacheImages(client *http.Client, content, imageDir string, kept map[string]bool) string {
+	var urls []string
+	for _, pattern := range []*regexp.Regexp{markdownImagePattern, htmlImagePattern} {
+		for _, m := range pattern.FindAllStringSubmatch(content, -1) {
+			urls = append(urls, m[1])
+		}
+	}
+
+	for i, imageURL := range urls {
+		if i >= maxOfflineImages {
+			break
+		}
+		name := offlineImageName(imageURL)
+		local := filepath.Join(imageDir, name)
+		if _, err := os.Stat(local); err != nil {
+			if err := downloadImage(client, imageURL, local); err != nil {
+				continue
+			}
+		}
+		kept[name] = true
+		content = strings.ReplaceAll(content, imageURL, "file://"+local)
+	}
+	return content
+}
+
+// offlineImageName derives a stable filename from an image URL
+func offlineImageName(imageURL string) string {
+	sum := sha1.Sum([]byte(imageURL))
+	ext := ".img"
+	if u, err := url.Parse(imageURL); err == nil {
+		switch e := strings.ToLower(path.Ext(u.Path)); e {
+		case ".png", ".jpg", ".jpeg", ".gif", ".webp", ".svg":
+			ext = e
+		}
+	}
+	return hex.EncodeToString(sum[:8]) + ext
+}
+
+// downloadImage saves one image, refusing oversized or non-image responses
+func downloadImage(client *http.Client, imageURL, local string) error {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", imageURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "image/") {
+		return fmt.Errorf("not an image: %s", resp.Header.Get("Content-Type"))
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOfflineImageSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxOfflineImageSize {
+		return fmt.Errorf("image too large")
+	}
+	return os.WriteFile(local, data, 0o644)
+}
+
+// loadOfflineCache reads the saved snapshot; a missing cache is not an error
+// and returns no items
+func loadOfflineCache() (offlineSnapshot, error) {
+	dir, err := offlineDirFunc()
+	if err != nil {
+		return offlineSnapshot{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "items.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return offlineSnapshot{}, nil
+	}
+	if err != nil {
+		return offlineSnapshot{}, fmt.Errorf("failed to read offline cache: %w", err)
+	}
+	var snapshot offlineSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return offlineSnapshot{}, fmt.Errorf("failed to parse offline cache: %w", err)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// useOfflineDir points the offline cache at a temporary directory
+func useOfflineDir(t *testing.T) string {
+	dir := t.TempDir()
+	offlineDirFunc = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { offlineDirFunc = defaultOfflineDir })
+	return dir
+}
+
+// TestOfflineCache_SavesUnreadTopItemsWithImages verifies the snapshot keeps unread HIGH/MEDIUM items and localizes their images.
+// BREAKS: If images keep their remote URLs, diagrams in offline articles are dead links on the plane.
+func TestOfflineCache_SavesUnreadTopItemsWithImages(t *testing.T) {
+	dir := useOfflineDir(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chart.png" {
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>"))
+	}))
+	defer server.Close()
+
+	chart, page := server.URL+"/chart.png", server.URL+"/page.png"
+	items := []db.ContentItem{
+		{ID: "h", Priority: "high", Content: "See ![chart](" + chart + ") and ![x](" + page + ")"},
+		{ID: "m", Priority: "medium", Content: `<img src="` + chart + `">`},
+		{ID: "read", Priority: "high", Read: true},
+		{ID: "low", Priority: "low"},
+	}
+	msg := saveOfflineCache("", items)().(offlineSavedMsg)
+	if msg.err != nil || msg.items != 2 || msg.images != 1 {
+		t.Fatalf("Unexpected result: %+v", msg)
+	}
+
+	snapshot, err := loadOfflineCache()
+	if err != nil || len(snapshot.Items) != 2 || snapshot.SavedAt.IsZero() {
+		t.Fatalf("Expected two saved items, got %+v, %v", snapshot, err)
+	}
+	local := "file://" + filepath.Join(dir, "images", offlineImageName(chart))
+	if content := snapshot.Items[0].Content; !strings.Contains(content, local) || !strings.Contains(content, page) {
+		t.Errorf("Expected the image localized and the non-image left alone, got %q", content)
+	}
+	if !strings.Contains(snapshot.Items[1].Content, local) {
+		t.Errorf("Expected HTML images localized too, got %q", snapshot.Items[1].Content)
+	}
+
+	// Images no longer referenced are cleaned up on the next save
+	saveOfflineCache("", nil)()
+	if _, err := os.Stat(filepath.Join(dir, "images", offlineImageName(chart))); err == nil {
+		t.Error("Expected the unreferenced image to be removed")
+	}
+}
+
+// TestOfflineCache_FallbackWhenDaemonUnreachable verifies remote startup reads the snapshot when the daemon is down.
+// BREAKS: If the initial sync error wins, an offline laptop opens to an error screen despite the cache.
+func TestOfflineCache_FallbackWhenDaemonUnreachable(t *testing.T) {
+	useOfflineDir(t)
+	saveOfflineCache("", []db.ContentItem{{ID: "h", Title: "Saved", Priority: "high"}})()
+
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.Close() // Unreachable
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.priority = "all"
+	m.offline = true
+
+	result := fetchItemsRemote(m, nil, nil)
+	if result.err != nil || len(result.allItems) != 1 || result.offlineAt.IsZero() {
+		t.Fatalf("Expected the offline snapshot, got %+v", result)
+	}
+
+	m.offline = false
+	if result := fetchItemsRemote(m, nil, nil); result.err == nil {
+		t.Error("Expected the error without the offline option")
+	}
+}
@@ -1,18 +1,34 @@
 package operations
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nickpending/prismis/internal/db"
 	"github.com/nickpending/prismis/internal/service"
 )
 
-// Article operation result messages
+// Article operation result messages. Queued is set when the daemon was
+// unreachable and the change was saved for replay; Success is also true
+// then, since the change will not be lost.
 type ArticleMarkedMsg struct {
 	ID      string
 	Read    bool
+	Auto    bool // Marked by the reader's mark_read setting rather than :mark
 	Success bool
+	Queued  bool
+	Error   error
+}
+
+// ArticlesMarkedMsg reports a batch read/unread change from :markall
+type ArticlesMarkedMsg struct {
+	IDs     []string
+	Read    bool
+	Updated int // Items the daemon changed; already-read items don't count
+	Success bool
+	Queued  bool
 	Error   error
 }
 
@@ -20,6 +36,7 @@ type ArticleFavoritedMsg struct {
 	ID        string
 	Favorited bool
 	Success   bool
+	Queued    bool
 	Error     error
 }
 
@@ -38,10 +55,35 @@ type ArticleOpenedMsg struct {
 	Error   error
 }
 
+type ArticleTaggedMsg struct {
+	ID      string
+	Tags    []string
+	Success bool
+	Queued  bool
+	Error   error
+}
+
+type ArticlePinnedMsg struct {
+	ID      string
+	Pinned  bool
+	Success bool
+	Queued  bool
+	Error   error
+}
+
+type ArticleSnoozedMsg struct {
+	ID      string
+	Until   time.Time
+	Success bool
+	Queued  bool
+	Error   error
+}
+
 type ArticleVotedMsg struct {
 	ID      string
 	Vote    string // "up", "down", or "" (cleared)
 	Success bool
+	Queued  bool
 	Error   error
 }
 
@@ -52,12 +94,24 @@ func MarkArticleRead(id string) tea.Cmd {
 		return ArticleMarkedMsg{
 			ID:      id,
 			Read:    true,
-			Success: err == nil,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
 			Error:   err,
 		}
 	}
 }
 
+// AutoMarkArticleRead marks an article read on the reader's behalf, so the
+// list isn't refreshed out from under the user
+func AutoMarkArticleRead(id string) tea.Cmd {
+	mark := MarkArticleRead(id)
+	return func() tea.Msg {
+		msg := mark().(ArticleMarkedMsg)
+		msg.Auto = true
+		return msg
+	}
+}
+
 // MarkArticleUnread marks an article as unread
 func MarkArticleUnread(id string) tea.Cmd {
 	return func() tea.Msg {
@@ -65,12 +119,62 @@ func MarkArticleUnread(id string) tea.Cmd {
 		return ArticleMarkedMsg{
 			ID:      id,
 			Read:    false,
-			Success: err == nil,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
 			Error:   err,
 		}
 	}
 }
 
+// ToggleArticlePin pins an article to the top of every view, or unpins it
+func ToggleArticlePin(item db.ContentItem) tea.Cmd {
+	return func() tea.Msg {
+		pinned := !item.Pinned
+		err := service.SetPinned(item.ID, pinned)
+		return ArticlePinnedMsg{
+			ID:      item.ID,
+			Pinned:  pinned,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
+			Error:   err,
+		}
+	}
+}
+
+// SnoozeArticle hides an article until the given time
+func SnoozeArticle(id string, until time.Time) tea.Cmd {
+	return func() tea.Msg {
+		err := service.SnoozeUntil(id, until)
+		return ArticleSnoozedMsg{
+			ID:      id,
+			Until:   until,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
+			Error:   err,
+		}
+	}
+}
+
+// MarkArticlesRead marks many articles read or unread in one batch
+func MarkArticlesRead(ids []string, read bool) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := service.SetReadBatch(ids, read)
+		return ArticlesMarkedMsg{
+			IDs:     ids,
+			Read:    read,
+			Updated: updated,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
+			Error:   err,
+		}
+	}
+}
+
+// queued reports whether err means the change was saved for later sync
+func queued(err error) bool {
+	return errors.Is(err, service.ErrQueued)
+}
+
 // ToggleArticleRead toggles the read status of an article
 func ToggleArticleRead(item db.ContentItem) tea.Cmd {
 	if item.Read {
@@ -87,7 +191,8 @@ func ToggleArticleFavorite(item db.ContentItem) tea.Cmd {
 		return ArticleFavoritedMsg{
 			ID:        item.ID,
 			Favorited: newStatus,
-			Success:   err == nil,
+			Success:   err == nil || queued(err),
+			Queued:    queued(err),
 			Error:     err,
 		}
 	}
@@ -142,7 +247,8 @@ func SetArticleVote(item db.ContentItem, vote string) tea.Cmd {
 		return ArticleVotedMsg{
 			ID:      item.ID,
 			Vote:    vote,
-			Success: err == nil,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
 			Error:   err,
 		}
 	}
@@ -165,3 +271,17 @@ func DownvoteArticle(item db.ContentItem) tea.Cmd {
 	}
 	return SetArticleVote(item, newVote)
 }
+
+// SetArticleTags replaces the user tags on an article
+func SetArticleTags(id string, tags []string) tea.Cmd {
+	return func() tea.Msg {
+		err := service.SetUserTags(id, tags)
+		return ArticleTaggedMsg{
+			ID:      id,
+			Tags:    tags,
+			Success: err == nil || queued(err),
+			Queued:  queued(err),
+			Error:   err,
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// AskDeltaMsg carries the next piece of a streamed answer
+type AskDeltaMsg struct {
+	ContentID string
+	Text      string
+	stream    <-chan tea.Msg
+}
+
+// Next waits for the stream's following event; the model re-listens with it
+// after handling each delta
+func (m AskDeltaMsg) Next() tea.Cmd {
+	return listenAsk(m.stream)
+}
+
+// AskDoneMsg ends an answer stream
+type AskDoneMsg struct {
+	ContentID string
+	Error     error
+}
+
+// AskArticle asks the daemon a question about an article, with history as
+// the earlier turns. The answer streams back as AskDeltaMsgs followed by one
+// AskDoneMsg. Cancelling ctx stops it; unlike Cancellable operations,
+// CancelInFlight leaves it alone.
+func AskArticle(ctx context.Context, contentID, question string, history []api.ChatTurn) tea.Cmd {
+	history = slices.Clone(history) // The model keeps appending to its copy
+	return func() tea.Msg {
+		events := make(chan tea.Msg, 16)
+		go func() {
+			apiClient, err := api.NewClient()
+			if err != nil {
+				events <- AskDoneMsg{ContentID: contentID, Error: fmt.Errorf("failed to create API client: %w", err)}
+				return
+			}
+
+			err = apiClient.AskEntry(ctx, contentID, question, history, func(text string) {
+				events <- AskDeltaMsg{ContentID: contentID, Text: text, stream: events}
+			})
+			events <- AskDoneMsg{ContentID: contentID, Error: err}
+		}()
+		return <-events
+	}
+}
+
+// listenAsk waits for an answer stream's next event
+func listenAsk(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
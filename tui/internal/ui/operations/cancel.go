@@ -0,0 +1,98 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// In-flight cancellable operations, keyed by a monotonically increasing ID.
+// Bubble Tea runs commands in their own goroutines, so the UI can't hold the
+// contexts directly - it holds an Op, or calls CancelInFlight.
+var (
+	inFlightMu sync.Mutex
+	inFlight   = make(map[int]context.CancelFunc)
+	nextOpID   int
+)
+
+// Cancellable returns a context that CancelInFlight aborts, plus a release
+// func the caller must defer once the operation finishes.
+// Operations the UI reports in the status line take an Op and use op.Start.
+func Cancellable() (context.Context, func()) {
+	return cancellable(context.Background())
+}
+
+// cancellable registers a context derived from parent
+func cancellable(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+
+	inFlightMu.Lock()
+	nextOpID++
+	id := nextOpID
+	inFlight[id] = cancel
+	inFlightMu.Unlock()
+
+	return ctx, func() {
+		inFlightMu.Lock()
+		delete(inFlight, id)
+		inFlightMu.Unlock()
+		cancel()
+	}
+}
+
+// Op is a handle to one operation the UI started and shows progress for.
+// The UI makes it before the command runs and keeps it, so Esc can abort
+// that operation alone rather than everything in flight.
+type Op struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   atomic.Bool
+}
+
+// NewOp returns a handle for an operation about to start
+func NewOp() *Op {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Op{ctx: ctx, cancel: cancel}
+}
+
+// Start is Cancellable for the operation behind op: its context is also
+// aborted by op.Cancel. A nil op behaves like Cancellable.
+func (op *Op) Start() (context.Context, func()) {
+	if op == nil {
+		return Cancellable()
+	}
+	op.done.Store(false) // A retry runs the operation again
+	ctx, release := cancellable(op.ctx)
+	return ctx, func() {
+		release()
+		op.done.Store(true)
+	}
+}
+
+// Cancel aborts the operation unless it already finished, and reports whether it did
+func (op *Op) Cancel() bool {
+	if op == nil || op.done.Load() {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// CancelInFlight aborts every running cancellable operation and returns how many were cancelled
+func CancelInFlight() int {
+	inFlightMu.Lock()
+	defer inFlightMu.Unlock()
+
+	count := len(inFlight)
+	for id, cancel := range inFlight {
+		cancel()
+		delete(inFlight, id)
+	}
+	return count
+}
+
+// IsCancelled reports whether err came from an operation aborted via CancelInFlight or Op.Cancel
+func IsCancelled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,58 @@
+package operations
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCancelInFlight_CancelsRegisteredContexts verifies Esc-style cancellation reaches running operations.
+// BREAKS: If contexts aren't registered, Esc can't abort a slow audio briefing or sync.
+func TestCancelInFlight_CancelsRegisteredContexts(t *testing.T) {
+	ctx, release := Cancellable()
+	defer release()
+
+	if n := CancelInFlight(); n != 1 {
+		t.Errorf("Expected 1 cancelled operation, got %d", n)
+	}
+	if !IsCancelled(fmt.Errorf("network error: %w", ctx.Err())) {
+		t.Error("Expected wrapped context error to be reported as cancelled")
+	}
+}
+
+// TestCancellable_ReleaseUnregisters verifies finished operations aren't counted.
+// BREAKS: If release doesn't unregister, Esc reports "cancelled" when nothing was running.
+func TestCancellable_ReleaseUnregisters(t *testing.T) {
+	_, release := Cancellable()
+	release()
+
+	if n := CancelInFlight(); n != 0 {
+		t.Errorf("Expected nothing in flight after release, got %d", n)
+	}
+}
+
+// TestOp_CancelReachesOnlyItsOperation verifies a handle aborts its own operation until it finishes.
+// BREAKS: If Cancel reaches other contexts, or reports a finished operation as cancelled, Esc misleads.
+func TestOp_CancelReachesOnlyItsOperation(t *testing.T) {
+	other, releaseOther := Cancellable()
+	defer releaseOther()
+
+	op := NewOp()
+	ctx, release := op.Start()
+	if !op.Cancel() || ctx.Err() == nil {
+		t.Error("Expected the running operation to be cancelled")
+	}
+	if other.Err() != nil {
+		t.Error("Expected the other operation to keep running")
+	}
+	release()
+
+	finished := NewOp()
+	_, release = finished.Start()
+	release()
+	if finished.Cancel() {
+		t.Error("Expected nothing to cancel once the operation finished")
+	}
+	if (*Op)(nil).Cancel() {
+		t.Error("Expected a nil handle to cancel nothing")
+	}
+}
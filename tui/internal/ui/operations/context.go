@@ -1,6 +1,8 @@
 package operations
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,13 +13,21 @@ import (
 	"github.com/nickpending/prismis/internal/api"
 	"github.com/nickpending/prismis/internal/clipboard"
 	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/service"
 )
 
-// Context operation result messages
+// ContextReviewedMsg carries the items flagged interesting for :context review
 type ContextReviewedMsg struct {
-	Count   int
-	Success bool
-	Error   error
+	Items []db.ContentItem
+	Error error
+}
+
+// ContextReviewAppliedMsg reports what a :context review session changed
+type ContextReviewAppliedMsg struct {
+	Added     []string // Topics context.md didn't already list
+	Section   string   // Where Added went, when they all went to one section
+	Unflagged []string // Items accepted or dismissed, no longer flagged
+	Error     error
 }
 
 // ContextSuggestionsMsg contains LLM-generated topic suggestions
@@ -34,28 +44,112 @@ type ContextEditMsg struct {
 	Error   error
 }
 
-// ReviewFlaggedItems returns count of upvoted items
+// ContextTopicAddedMsg reports a topic added to context.md via the daemon
+type ContextTopicAddedMsg struct {
+	Topic   string
+	Section string
+	Added   bool // False when context.md already listed it
+	Error   error
+}
+
+// ReanalyzedMsg reports how many unprioritized items the new context promoted
+type ReanalyzedMsg struct {
+	Evaluated   int
+	Prioritized int
+	Error       error
+}
+
+// ContextLoadedMsg carries context.md for the built-in editor
+type ContextLoadedMsg struct {
+	Path    string
+	Content string
+	Error   error
+}
+
+// ContextSavedMsg reports the built-in editor's save
+type ContextSavedMsg struct {
+	Path  string
+	Error error
+}
+
+// ReviewFlaggedItems loads the items flagged interesting from the database
 func ReviewFlaggedItems() tea.Cmd {
 	return func() tea.Msg {
-		count, err := db.CountUpvotedItems()
+		items, err := db.GetAllContent(false)
 		if err != nil {
-			return ContextReviewedMsg{
-				Count:   0,
-				Success: false,
-				Error:   err,
+			return ContextReviewedMsg{Error: err}
+		}
+		return ContextReviewedMsg{Items: FlaggedItems(items)}
+	}
+}
+
+// FlaggedItems returns the items flagged interesting (upvoted)
+func FlaggedItems(items []db.ContentItem) []db.ContentItem {
+	var flagged []db.ContentItem
+	for _, item := range items {
+		if item.UserFeedback == "up" {
+			flagged = append(flagged, item)
+		}
+	}
+	return flagged
+}
+
+// ApplyContextReview adds the accepted topics to context.md in one request,
+// then clears the flag on the resolved items. Flags stay put if the topics
+// can't be added, so nothing is lost by retrying.
+func ApplyContextReview(topics []api.ContextTopic, resolved []string) tea.Cmd {
+	return func() tea.Msg {
+		var msg ContextReviewAppliedMsg
+		if len(topics) > 0 {
+			apiClient, err := api.NewClient()
+			if err != nil {
+				msg.Error = fmt.Errorf("failed to create API client: %w", err)
+				return msg
+			}
+			results, err := apiClient.AddContextTopics(context.Background(), topics)
+			if errors.Is(err, api.ErrUnsupported) {
+				// Older daemons take one topic per request
+				results, err = nil, nil
+				for _, topic := range topics {
+					result, addErr := apiClient.AddContextTopic(context.Background(), topic.Topic, topic.Section)
+					if addErr != nil {
+						err = addErr
+						break
+					}
+					results = append(results, *result)
+				}
+			}
+			sections := map[string]bool{}
+			for _, result := range results {
+				if result.Added {
+					msg.Added = append(msg.Added, result.Topic)
+					sections[result.Section] = true
+				}
+			}
+			if len(sections) == 1 {
+				for section := range sections {
+					msg.Section = section
+				}
+			}
+			if err != nil {
+				msg.Error = fmt.Errorf("failed to add topics: %w", err)
+				return msg
 			}
 		}
 
-		return ContextReviewedMsg{
-			Count:   count,
-			Success: true,
-			Error:   nil,
+		for _, id := range resolved {
+			if err := service.SetUserFeedback(id, ""); err != nil && !errors.Is(err, service.ErrQueued) {
+				msg.Error = err
+				return msg
+			}
+			msg.Unflagged = append(msg.Unflagged, id)
 		}
+		return msg
 	}
 }
 
 // GetContextSuggestions calls API to analyze flagged items and suggest topics
-func GetContextSuggestions() tea.Cmd {
+func GetContextSuggestions(op *Op) tea.Cmd {
 	return func() tea.Msg {
 		// Create API client
 		apiClient, err := api.NewClient()
@@ -66,8 +160,11 @@ func GetContextSuggestions() tea.Cmd {
 			}
 		}
 
-		// Call API to get suggestions (this will block for 3-10 seconds)
-		response, err := apiClient.GetContextSuggestions()
+		// Call API to get suggestions (this will block for 3-10 seconds; Esc cancels)
+		ctx, release := op.Start()
+		defer release()
+
+		response, err := apiClient.GetContextSuggestions(ctx)
 		if err != nil {
 			return ContextSuggestionsMsg{
 				Success: false,
@@ -161,23 +258,67 @@ func GetContextSuggestions() tea.Cmd {
 	}
 }
 
-// EditContextFile opens context.md in $EDITOR
-func EditContextFile() tea.Cmd {
-	// Get context.md path
+// contextFilePath returns where the daemon reads context.md from
+func contextFilePath() (string, error) {
 	configDir := os.Getenv("XDG_CONFIG_HOME")
 	if configDir == "" {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			return func() tea.Msg {
-				return ContextEditMsg{
-					Success: false,
-					Error:   fmt.Errorf("failed to get home directory: %w", err),
-				}
-			}
+			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
 		configDir = filepath.Join(homeDir, ".config")
 	}
-	contextPath := filepath.Join(configDir, "prismis", "context.md")
+	return filepath.Join(configDir, "prismis", "context.md"), nil
+}
+
+// LoadContextFile reads context.md for the built-in editor
+func LoadContextFile() tea.Cmd {
+	return func() tea.Msg {
+		contextPath, err := contextFilePath()
+		if err != nil {
+			return ContextLoadedMsg{Error: err}
+		}
+		data, err := os.ReadFile(contextPath)
+		if os.IsNotExist(err) {
+			return ContextLoadedMsg{Path: contextPath, Error: fmt.Errorf("context.md not found at %s", contextPath)}
+		}
+		if err != nil {
+			return ContextLoadedMsg{Path: contextPath, Error: fmt.Errorf("failed to read context.md: %w", err)}
+		}
+		return ContextLoadedMsg{Path: contextPath, Content: string(data)}
+	}
+}
+
+// SaveContextFile writes the edited context.md. It goes through a temp
+// file and rename so the daemon never reads a half-written context.
+func SaveContextFile(contextPath, content string) tea.Cmd {
+	return func() tea.Msg {
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		tmp := contextPath + ".tmp"
+		if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+			return ContextSavedMsg{Path: contextPath, Error: fmt.Errorf("failed to write context.md: %w", err)}
+		}
+		if err := os.Rename(tmp, contextPath); err != nil {
+			os.Remove(tmp)
+			return ContextSavedMsg{Path: contextPath, Error: fmt.Errorf("failed to write context.md: %w", err)}
+		}
+		return ContextSavedMsg{Path: contextPath}
+	}
+}
+
+// EditContextFile opens context.md in $EDITOR
+func EditContextFile() tea.Cmd {
+	contextPath, err := contextFilePath()
+	if err != nil {
+		return func() tea.Msg {
+			return ContextEditMsg{
+				Success: false,
+				Error:   err,
+			}
+		}
+	}
 
 	// Check if file exists
 	if _, err := os.Stat(contextPath); os.IsNotExist(err) {
@@ -210,3 +351,39 @@ func EditContextFile() tea.Cmd {
 		}
 	})
 }
+
+// AddContextTopic asks the daemon to add topic to a section of context.md
+func AddContextTopic(topic, section string) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return ContextTopicAddedMsg{Topic: topic, Section: section, Error: fmt.Errorf("failed to create API client: %w", err)}
+		}
+
+		result, err := apiClient.AddContextTopic(context.Background(), topic, section)
+		if err != nil {
+			return ContextTopicAddedMsg{Topic: topic, Section: section, Error: err}
+		}
+		return ContextTopicAddedMsg{Topic: result.Topic, Section: result.Section, Added: result.Added}
+	}
+}
+
+// ReanalyzeUnprioritized asks the daemon to re-evaluate recent unprioritized
+// items against the updated context.md (one LLM call each; Esc cancels)
+func ReanalyzeUnprioritized(op *Op, days int) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return ReanalyzedMsg{Error: fmt.Errorf("failed to create API client: %w", err)}
+		}
+
+		ctx, release := op.Start()
+		defer release()
+
+		result, err := apiClient.ReanalyzeUnprioritized(ctx, days)
+		if err != nil {
+			return ReanalyzedMsg{Error: err}
+		}
+		return ReanalyzedMsg{Evaluated: result.Evaluated, Prioritized: result.Prioritized}
+	}
+}
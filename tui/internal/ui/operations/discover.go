@@ -0,0 +1,70 @@
+package operations
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/feeds"
+)
+
+// FeedDiscoveryMsg contains the feeds advertised by a page the user tried to add
+type FeedDiscoveryMsg struct {
+	PageURL    string
+	Name       string // Display name the user entered (carried through to AddSource)
+	Candidates []feeds.Candidate
+	Error      error
+}
+
+// DiscoverFeeds probes a website URL for <link rel="alternate"> feeds
+func DiscoverFeeds(pageURL string, name string) tea.Cmd {
+	return func() tea.Msg {
+		candidates, err := feeds.Discover(pageURL)
+		return FeedDiscoveryMsg{
+			PageURL:    pageURL,
+			Name:       name,
+			Candidates: candidates,
+			Error:      err,
+		}
+	}
+}
+
+// NeedsFeedDiscovery reports whether an add-source URL should be probed for feeds
+// before being sent to the daemon. Only plain http(s) website URLs qualify:
+// reddit/youtube/file sources and URLs that already look like feeds go straight through.
+func NeedsFeedDiscovery(url string) bool {
+	if detectSourceType(url) != "rss" {
+		return false
+	}
+	lower := strings.ToLower(strings.TrimSpace(url))
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return false
+	}
+	return !feeds.LooksLikeFeedURL(lower)
+}
+
+// SourcePreviewMsg carries the result of a test fetch from the add form
+type SourcePreviewMsg struct {
+	URL        string // URL as entered, used to discard stale previews
+	SourceType string
+	Preview    *feeds.Preview // nil for source types that can't be previewed locally
+	Error      error
+}
+
+// PreviewSource performs a one-off dry-run fetch of a source without saving it.
+// Only feed URLs are fetched locally; Reddit/YouTube/file sources report their detected type.
+func PreviewSource(url string) tea.Cmd {
+	return func() tea.Msg {
+		sourceType := detectSourceType(url)
+		if sourceType != "rss" {
+			return SourcePreviewMsg{URL: url, SourceType: sourceType}
+		}
+
+		preview, err := feeds.FetchPreview(normalizeSourceURL(url, sourceType))
+		return SourcePreviewMsg{
+			URL:        url,
+			SourceType: sourceType,
+			Preview:    preview,
+			Error:      err,
+		}
+	}
+}
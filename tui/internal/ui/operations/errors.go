@@ -0,0 +1,29 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// apiErrorMessage turns an APIClient error into a status message.
+// action describes what failed, e.g. "remove source".
+func apiErrorMessage(action string, err error) string {
+	switch {
+	case IsCancelled(err):
+		return "Cancelled"
+	case errors.Is(err, api.ErrDaemonDown):
+		return "Cannot connect to daemon - is it running?"
+	case errors.Is(err, api.ErrAuth):
+		return "Authentication failed - check API key"
+	case errors.Is(err, api.ErrNotFound):
+		return fmt.Sprintf("Failed to %s: not found", action)
+	case errors.Is(err, api.ErrValidation):
+		return api.ErrorMessage(err)
+	case errors.Is(err, api.ErrRateLimited):
+		return fmt.Sprintf("Failed to %s: %s", action, api.ErrorMessage(err))
+	default:
+		return fmt.Sprintf("Failed to %s: %v", action, err)
+	}
+}
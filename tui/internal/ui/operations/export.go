@@ -0,0 +1,205 @@
+package operations
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// Source export formats for :export sources
+const (
+	ExportMarkdown = "markdown"
+	ExportOPML     = "opml"
+	ExportJSON     = "json"
+)
+
+// exportExtensions maps each export format to its file extension
+var exportExtensions = map[string]string{
+	ExportMarkdown: ".md",
+	ExportOPML:     ".opml",
+	ExportJSON:     ".json",
+}
+
+// exportFormatFor picks the format from a file name's extension, defaulting to markdown
+func exportFormatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".opml", ".xml":
+		return ExportOPML
+	case ".json":
+		return ExportJSON
+	default:
+		return ExportMarkdown
+	}
+}
+
+// sourceName returns a source's display name, falling back to its URL
+func sourceName(source api.Source) string {
+	if source.Name != nil && *source.Name != "" {
+		return *source.Name
+	}
+	return source.URL
+}
+
+// renderSources formats sources for export, sorted by name
+func renderSources(sources []api.Source, format string, now time.Time) ([]byte, error) {
+	sorted := append([]api.Source(nil), sources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sourceName(sorted[i])) < strings.ToLower(sourceName(sorted[j]))
+	})
+
+	switch format {
+	case ExportOPML:
+		return sourcesOPML(sorted, now)
+	case ExportJSON:
+		return sourcesJSON(sorted)
+	case ExportMarkdown:
+		return []byte(sourcesMarkdown(sorted, now)), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (markdown, opml, json)", format)
+	}
+}
+
+// sourcesMarkdown lists sources by type, noting paused ones
+func sourcesMarkdown(sources []api.Source, now time.Time) string {
+	var markdown strings.Builder
+	markdown.WriteString(fmt.Sprintf("# Prismis Sources (exported %s)\n\n", now.Format("2006-01-02")))
+
+	sections := []struct {
+		sourceType string
+		title      string
+	}{
+		{"rss", "RSS Feeds"},
+		{"youtube", "YouTube Channels"},
+		{"reddit", "Reddit Subreddits"},
+		{"file", "Files"},
+	}
+	for _, section := range sections {
+		var lines []string
+		for _, source := range sources {
+			if source.Type != section.sourceType {
+				continue
+			}
+			// Add paused indicator
+			status := ""
+			if !source.Active {
+				status = " (paused)"
+			}
+			lines = append(lines, fmt.Sprintf("- %s - %s%s\n", sourceName(source), source.URL, status))
+		}
+		if len(lines) > 0 {
+			markdown.WriteString("## " + section.title + "\n")
+			markdown.WriteString(strings.Join(lines, ""))
+			markdown.WriteString("\n")
+		}
+	}
+	return markdown.String()
+}
+
+// opmlOutline is a feed, or a category holding feeds
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlDocument is an OPML 2.0 subscription list
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    struct {
+		Title       string `xml:"title"`
+		DateCreated string `xml:"dateCreated"`
+	} `xml:"head"`
+	Body struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// sourcesOPML writes sources as OPML, nesting categorized ones under their
+// category the way feed readers import them
+func sourcesOPML(sources []api.Source, now time.Time) ([]byte, error) {
+	doc := opmlDocument{Version: "2.0"}
+	doc.Head.Title = "Prismis Sources"
+	doc.Head.DateCreated = now.Format(time.RFC1123Z)
+
+	categories := map[string]int{} // Category name → index in the body
+	for _, source := range sources {
+		feed := opmlOutline{Text: sourceName(source), Title: sourceName(source), Type: "rss", XMLURL: source.URL}
+		if source.Category == nil || *source.Category == "" {
+			doc.Body.Outlines = append(doc.Body.Outlines, feed)
+			continue
+		}
+		i, ok := categories[*source.Category]
+		if !ok {
+			i = len(doc.Body.Outlines)
+			categories[*source.Category] = i
+			doc.Body.Outlines = append(doc.Body.Outlines, opmlOutline{Text: *source.Category, Title: *source.Category})
+		}
+		doc.Body.Outlines[i].Outlines = append(doc.Body.Outlines[i].Outlines, feed)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// exportedSource is one source in a JSON export, in the shape the daemon accepts back
+type exportedSource struct {
+	URL      string `json:"url"`
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Category string `json:"category,omitempty"`
+	Active   bool   `json:"active"`
+}
+
+// sourcesJSON writes sources as a JSON array
+func sourcesJSON(sources []api.Source) ([]byte, error) {
+	exported := make([]exportedSource, len(sources))
+	for i, source := range sources {
+		exported[i] = exportedSource{URL: source.URL, Type: source.Type, Active: source.Active}
+		if source.Name != nil {
+			exported[i].Name = *source.Name
+		}
+		if source.Category != nil {
+			exported[i].Category = *source.Category
+		}
+	}
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// writeSourcesExport writes data to path, expanding ~ and naming the file
+// when path is a directory. Returns the path written.
+func writeSourcesExport(path, format string, data []byte, now time.Time) (string, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "prismis-sources-"+now.Format("2006-01-02")+exportExtensions[format])
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return path, nil
+}
@@ -18,7 +18,7 @@ type ExtractOperationMsg struct {
 // ExtractContent calls the API to trigger deep extraction for a content entry.
 // The returned tea.Cmd runs asynchronously; the API call may block 10-30s on
 // the first invocation, but is idempotent thereafter (cached result returned).
-func ExtractContent(contentID string) tea.Cmd {
+func ExtractContent(op *Op, contentID string) tea.Cmd {
 	return func() tea.Msg {
 		apiClient, err := api.NewClient()
 		if err != nil {
@@ -29,7 +29,11 @@ func ExtractContent(contentID string) tea.Cmd {
 			}
 		}
 
-		data, err := apiClient.ExtractEntry(contentID)
+		// Extraction can take a while on first run; Esc cancels
+		ctx, release := op.Start()
+		defer release()
+
+		data, err := apiClient.ExtractEntry(ctx, contentID)
 		if err != nil {
 			return ExtractOperationMsg{
 				ContentID: contentID,
@@ -0,0 +1,103 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/config"
+	"github.com/nickpending/prismis/internal/feeds"
+)
+
+// SubscriptionsLoadedMsg carries the feeds read from another reader for :import
+type SubscriptionsLoadedMsg struct {
+	From  string
+	Subs  []feeds.Subscription
+	Error error
+}
+
+// LoadSubscriptions reads the feeds to import: a newsboat urls file (path,
+// or newsboat's own when empty) or the Miniflux account in config.toml
+func LoadSubscriptions(from, path string) tea.Cmd {
+	return func() tea.Msg {
+		subs, err := loadSubscriptions(from, path)
+		return SubscriptionsLoadedMsg{From: from, Subs: subs, Error: err}
+	}
+}
+
+func loadSubscriptions(from, path string) ([]feeds.Subscription, error) {
+	if from == "miniflux" {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		baseURL, token, err := cfg.GetMiniflux()
+		if err != nil {
+			return nil, err
+		}
+		return feeds.FetchMinifluxFeeds(context.Background(), baseURL, token)
+	}
+
+	if path == "" {
+		var err error
+		if path, err = feeds.NewsboatURLsPath(); err != nil {
+			return nil, err
+		}
+	} else if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open newsboat urls: %w", err)
+	}
+	defer file.Close()
+	return feeds.ParseNewsboatURLs(file)
+}
+
+// SourceImportedMsg reports one feed of an :import
+type SourceImportedMsg struct {
+	Sub    feeds.Subscription
+	Exists bool // The daemon already had it
+	Error  error
+	Fatal  bool // The daemon can't be used at all, so the rest would fail too
+}
+
+// ImportSource adds one imported feed as a source
+func ImportSource(sub feeds.Subscription) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return SourceImportedMsg{Sub: sub, Error: err, Fatal: true}
+		}
+
+		request := api.SourceRequest{URL: sub.URL, Type: detectSourceType(sub.URL)}
+		if sub.Name != "" {
+			request.Name = &sub.Name
+		}
+		// Daemons without categories still take the feed, just uncategorized
+		if info, err := apiClient.Version(context.Background()); sub.Category != "" && (err != nil || info.Supports(api.FeatureCategories)) {
+			request.Category = &sub.Category
+		}
+		_, err = apiClient.AddSource(context.Background(), request)
+		if err == nil {
+			return SourceImportedMsg{Sub: sub}
+		}
+		if !errors.Is(err, api.ErrValidation) && strings.Contains(api.ErrorMessage(err), "already exists") {
+			return SourceImportedMsg{Sub: sub, Exists: true}
+		}
+		return SourceImportedMsg{
+			Sub:   sub,
+			Error: errors.New(apiErrorMessage("add source", err)),
+			Fatal: errors.Is(err, api.ErrDaemonDown) || errors.Is(err, api.ErrAuth),
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package operations
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkCheckTimeout bounds a link check; a slow site is not a dead one
+const linkCheckTimeout = 10 * time.Second
+
+// LinkCheckedMsg reports the HTTP status of an article's original URL
+type LinkCheckedMsg struct {
+	URL    string
+	Status int // 0 when the site couldn't be reached
+	Error  error
+}
+
+// Dead reports whether the link is gone for good (404 or 410)
+func (m LinkCheckedMsg) Dead() bool {
+	return m.Status == http.StatusNotFound || m.Status == http.StatusGone
+}
+
+// CheckLink sends a lightweight HEAD request to url in the background
+func CheckLink(url string) tea.Cmd {
+	return func() tea.Msg {
+		status, err := LinkStatus(context.Background(), url)
+		return LinkCheckedMsg{URL: url, Status: status, Error: err}
+	}
+}
+
+// LinkStatus returns the status url answers with after redirects. Servers
+// that refuse HEAD are asked for a single byte with GET instead.
+func LinkStatus(ctx context.Context, url string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, linkCheckTimeout)
+	defer cancel()
+
+	status, err := requestStatus(ctx, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestStatus(ctx, http.MethodGet, url)
+	}
+	return status, err
+}
+
+func requestStatus(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,32 @@
+package operations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestLinkStatus_HeadWithGetFallback verifies dead links are reported and HEAD-refusing servers are retried with GET.
+// BREAKS: If a 405 counts as the answer, every site that blocks HEAD looks broken.
+func TestLinkStatus_HeadWithGetFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/nohead" && r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	msg := CheckLink(server.URL + "/gone")().(LinkCheckedMsg)
+	if msg.Error != nil || !msg.Dead() {
+		t.Errorf("Expected a dead link, got %+v", msg)
+	}
+	if status, err := LinkStatus(context.Background(), server.URL+"/nohead"); err != nil || status != http.StatusOK {
+		t.Errorf("Expected the GET fallback to succeed, got %d, %v", status, err)
+	}
+}
@@ -0,0 +1,82 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// ArticleAudioMsg carries a narrated article's local audio file
+type ArticleAudioMsg struct {
+	ContentID string
+	Title     string
+	Path      string // Local file, ready to play
+	Cached    bool   // The daemon reused an earlier narration
+	Error     error
+}
+
+// GenerateArticleAudio narrates an article on the daemon and makes the file
+// available locally, downloading it when the daemon runs elsewhere.
+// TTS can take minutes for long pieces; Esc cancels.
+func GenerateArticleAudio(op *Op, contentID, title string) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return ArticleAudioMsg{ContentID: contentID, Error: fmt.Errorf("failed to create API client: %w", err)}
+		}
+
+		ctx, release := op.Start()
+		defer release()
+
+		audio, err := apiClient.GenerateArticleAudio(ctx, contentID)
+		if err != nil {
+			return ArticleAudioMsg{ContentID: contentID, Error: err}
+		}
+		msg := ArticleAudioMsg{ContentID: contentID, Title: title, Path: audio.FilePath, Cached: audio.Cached}
+
+		// Same machine: play the daemon's file directly
+		if _, err := os.Stat(audio.FilePath); err == nil {
+			return msg
+		}
+
+		dir, err := localAudioDir()
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		msg.Path = filepath.Join(dir, filepath.Base(audio.Filename))
+		if _, err := os.Stat(msg.Path); err == nil && audio.Cached {
+			return msg
+		}
+
+		data, err := apiClient.DownloadAudio(ctx, audio.Filename)
+		if err != nil {
+			msg.Error = fmt.Errorf("failed to download audio: %w", err)
+			return msg
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			msg.Error = fmt.Errorf("failed to create audio directory: %w", err)
+			return msg
+		}
+		if err := os.WriteFile(msg.Path, data, 0o644); err != nil {
+			msg.Error = fmt.Errorf("failed to save audio: %w", err)
+		}
+		return msg
+	}
+}
+
+// localAudioDir mirrors the daemon's audio directory on this machine
+func localAudioDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "prismis", "audio"), nil
+}
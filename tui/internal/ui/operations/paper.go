@@ -0,0 +1,101 @@
+package operations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxPaperSize caps a PDF download so a bad link can't fill the disk
+const maxPaperSize = 200 << 20
+
+// PaperPDFMsg reports a paper saved locally, and whether it was handed to
+// the PDF viewer
+type PaperPDFMsg struct {
+	ContentID string
+	Path      string
+	Opened    bool
+	Error     error
+}
+
+// FetchPaper saves the PDF at pdfURL to path, reusing an earlier download,
+// then opens it with handler (program and arguments) when one is given.
+// Large papers take a while on slow links; Esc cancels.
+func FetchPaper(op *Op, contentID, pdfURL, path string, handler []string) tea.Cmd {
+	return func() tea.Msg {
+		msg := PaperPDFMsg{ContentID: contentID, Path: path}
+
+		if _, err := os.Stat(path); err != nil {
+			ctx, release := op.Start()
+			defer release()
+			if err := downloadPDF(ctx, pdfURL, path); err != nil {
+				msg.Error = err
+				return msg
+			}
+		}
+
+		if len(handler) > 0 {
+			args := append(append([]string{}, handler[1:]...), path)
+			// Start, not Run: the viewer outlives the command
+			if err := exec.Command(handler[0], args...).Start(); err != nil {
+				msg.Error = fmt.Errorf("failed to start %s: %w", handler[0], err)
+				return msg
+			}
+			msg.Opened = true
+		}
+		return msg
+	}
+}
+
+// downloadPDF fetches pdfURL into path, refusing anything that isn't a PDF
+// (publishers often answer with an HTML login page instead)
+func downloadPDF(ctx context.Context, pdfURL, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pdfURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid PDF URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download PDF: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download PDF: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPaperSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to download PDF: %w", err)
+	}
+	if len(data) > maxPaperSize {
+		return fmt.Errorf("PDF is larger than %d MB", maxPaperSize>>20)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return fmt.Errorf("link did not return a PDF (paywalled?)")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create download directory: %w", err)
+	}
+	// Write then rename so an interrupted download never looks complete
+	tmp := path + ".part"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save PDF: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to save PDF: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package operations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFetchPaper_SavesPDFAndRejectsHTML verifies PDFs are saved once and login pages are refused.
+// BREAKS: If HTML is saved as .pdf, :download leaves a "paper" the viewer can't open.
+func TestFetchPaper_SavesPDFAndRejectsHTML(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path == "/paper.pdf" {
+			w.Write([]byte("%PDF-1.7 body"))
+			return
+		}
+		w.Write([]byte("<html>Sign in</html>"))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "papers", "paper.pdf")
+	msg := FetchPaper(nil, "a", server.URL+"/paper.pdf", path, nil)().(PaperPDFMsg)
+	if msg.Error != nil || msg.Opened || msg.Path != path {
+		t.Fatalf("Unexpected result: %+v", msg)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "%PDF-1.7 body" {
+		t.Errorf("Expected the PDF on disk, got %q", data)
+	}
+	FetchPaper(nil, "a", server.URL+"/paper.pdf", path, nil)()
+	if hits != 1 {
+		t.Errorf("Expected the saved PDF to be reused, got %d requests", hits)
+	}
+
+	login := filepath.Join(dir, "login.pdf")
+	if msg := FetchPaper(nil, "b", server.URL+"/login", login, nil)().(PaperPDFMsg); msg.Error == nil {
+		t.Error("Expected an HTML response to be refused")
+	}
+	if _, err := os.Stat(login); err == nil {
+		t.Error("Expected nothing saved for a refused download")
+	}
+}
@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -35,7 +36,7 @@ func GetPruneCount(days *int) tea.Cmd {
 		}
 
 		// Get the count
-		count, err := apiClient.PruneCount(days)
+		count, err := apiClient.PruneCount(context.Background(), days)
 		if err != nil {
 			return PruneResultMsg{
 				Error: fmt.Errorf("failed to get prune count: %w", err),
@@ -61,10 +62,10 @@ func ExecutePrune(days *int) tea.Cmd {
 		}
 
 		// Get count first (for the message)
-		count, _ := apiClient.PruneCount(days)
+		count, _ := apiClient.PruneCount(context.Background(), days)
 
 		// Execute the prune
-		deleted, err := apiClient.PruneUnprioritized(days)
+		deleted, err := apiClient.PruneUnprioritized(context.Background(), days)
 		if err != nil {
 			return PruneResultMsg{
 				Error: fmt.Errorf("failed to prune items: %w", err),
@@ -93,7 +94,7 @@ func HandlePruneCommand(msg commands.PruneMsg) tea.Cmd {
 			}
 
 			// Get the count
-			count, err := apiClient.PruneCount(msg.Days)
+			count, err := apiClient.PruneCount(context.Background(), msg.Days)
 			if err != nil {
 				return PruneResultMsg{
 					Error: fmt.Errorf("failed to get prune count: %w", err),
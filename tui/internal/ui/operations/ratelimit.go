@@ -0,0 +1,37 @@
+package operations
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// RateLimitedMsg reports that a request is backing off after a 429
+type RateLimitedMsg struct {
+	Wait time.Duration
+}
+
+// Backoff notices from api request goroutines. Buffered so a burst of 429s
+// never blocks a request; the UI only needs the latest.
+var (
+	rateLimitOnce    sync.Once
+	rateLimitNotices = make(chan time.Duration, 1)
+)
+
+// ListenRateLimits waits for the next rate-limit backoff and returns it as a
+// RateLimitedMsg. The model re-issues it after each message to keep listening.
+func ListenRateLimits() tea.Cmd {
+	rateLimitOnce.Do(func() {
+		api.SetRateLimitHandler(func(wait time.Duration) {
+			select {
+			case rateLimitNotices <- wait:
+			default:
+			}
+		})
+	})
+	return func() tea.Msg {
+		return RateLimitedMsg{Wait: <-rateLimitNotices}
+	}
+}
@@ -0,0 +1,144 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// reminderLength is how long a reminder's calendar event lasts
+const reminderLength = 30 * time.Minute
+
+// ReminderMsg reports a calendar reminder made by :remind
+type ReminderMsg struct {
+	ContentID string
+	At        time.Time
+	Path      string
+	Opened    bool // Handed to the calendar app rather than saved to [tui] reminder_dir
+	Error     error
+}
+
+// CreateReminder writes an .ics event at at for item. With a dir it's saved
+// there (a calendar sync folder, say); otherwise it goes to the cache and
+// open imports it into the default calendar app.
+func CreateReminder(item db.ContentItem, at time.Time, note, dir string, open func(path string) error) tea.Cmd {
+	return func() tea.Msg {
+		msg := ReminderMsg{ContentID: item.ID, At: at}
+		saveDir := dir
+		if saveDir == "" {
+			cacheDir, err := os.UserCacheDir()
+			if err != nil {
+				msg.Error = err
+				return msg
+			}
+			saveDir = filepath.Join(cacheDir, "prismis", "reminders")
+		}
+		if err := os.MkdirAll(saveDir, 0o755); err != nil {
+			msg.Error = fmt.Errorf("failed to create reminder directory: %w", err)
+			return msg
+		}
+
+		msg.Path = filepath.Join(saveDir, reminderFilename(item, at))
+		if err := os.WriteFile(msg.Path, []byte(reminderICS(item, at, note, time.Now())), 0o644); err != nil {
+			msg.Error = fmt.Errorf("failed to save reminder: %w", err)
+			return msg
+		}
+		if dir == "" {
+			if err := open(msg.Path); err != nil {
+				msg.Error = fmt.Errorf("saved %s but couldn't open it: %w", msg.Path, err)
+				return msg
+			}
+			msg.Opened = true
+		}
+		return msg
+	}
+}
+
+// reminderFilename names the .ics after the time and item, so reminding
+// twice about the same thing at the same time replaces the first
+func reminderFilename(item db.ContentItem, at time.Time) string {
+	id := item.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return "prismis-" + at.Format("20060102-1504") + "-" + id + ".ics"
+}
+
+// reminderICS builds a one-event iCalendar file (RFC 5545) with an alarm at
+// the start. The description holds the note, link, and summary.
+func reminderICS(item db.ContentItem, at time.Time, note string, now time.Time) string {
+	const stamp = "20060102T150405Z"
+	var description []string
+	for _, part := range []string{note, item.URL, item.Summary} {
+		if part = strings.TrimSpace(part); part != "" {
+			description = append(description, part)
+		}
+	}
+	summary := item.Title
+	if note != "" {
+		summary = note + ": " + item.Title
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//prismis//remind//EN",
+		"BEGIN:VEVENT",
+		"UID:" + item.ID + "-" + at.UTC().Format(stamp) + "@prismis",
+		"DTSTAMP:" + now.UTC().Format(stamp),
+		"DTSTART:" + at.UTC().Format(stamp),
+		"DTEND:" + at.Add(reminderLength).UTC().Format(stamp),
+		"SUMMARY:" + icsText(summary),
+		"DESCRIPTION:" + icsText(strings.Join(description, "\n\n")),
+	}
+	if item.URL != "" {
+		lines = append(lines, "URL:"+item.URL)
+	}
+	lines = append(lines,
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:"+icsText(summary),
+		"TRIGGER:PT0S",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// icsText escapes a TEXT value: backslashes, commas, semicolons, newlines
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine splits a content line into 75-octet pieces, each continuation
+// starting with a space, without cutting a UTF-8 character in two
+func foldICSLine(line string) string {
+	const limit = 75
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1 // The leading space counts
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package operations
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestReminderICS_EscapesAndFolds verifies the event carries the item, escapes TEXT values, and folds long lines.
+// BREAKS: An unescaped comma or a 200-character summary line makes calendar apps reject or truncate the import.
+func TestReminderICS_EscapesAndFolds(t *testing.T) {
+	item := db.ContentItem{
+		ID:      "abc123",
+		Title:   "RustConf 2026: CFP open; talks, workshops",
+		URL:     "https://rustconf.com/cfp",
+		Summary: strings.Repeat("Submissions close in June. ", 10),
+	}
+	at := time.Date(2026, 6, 1, 9, 0, 0, 0, time.FixedZone("PDT", -7*3600))
+	ics := reminderICS(item, at, "CFP closes", time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"DTSTART:20260601T160000Z\r\n",
+		"DTEND:20260601T163000Z\r\n",
+		`SUMMARY:CFP closes: RustConf 2026: CFP open\; talks\, workshops`,
+		"URL:https://rustconf.com/cfp\r\n",
+		"TRIGGER:PT0S\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("Expected %q in:\n%s", want, ics)
+		}
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected lines folded at 75 octets, got %d: %q", len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, `DESCRIPTION:CFP closes\n\nhttps://rustconf.com/cfp\n\nSubmissions close`) {
+		t.Errorf("Expected the note, link, and summary in the description:\n%s", unfolded)
+	}
+}
+
+// TestCreateReminder_SavesOrOpens verifies a reminder dir gets the file and no dir hands it to the calendar app.
+// BREAKS: If a configured dir still opens the app, every reminder synced to a calendar folder is imported twice.
+func TestCreateReminder_SavesOrOpens(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	item := db.ContentItem{ID: "abc123", Title: "Release", URL: "https://example.com"}
+	at := time.Now().Add(24 * time.Hour)
+	var opened []string
+	open := func(path string) error {
+		opened = append(opened, path)
+		return nil
+	}
+
+	dir := filepath.Join(t.TempDir(), "calendar")
+	msg := CreateReminder(item, at, "", dir, open)().(ReminderMsg)
+	if msg.Error != nil || msg.Opened || filepath.Dir(msg.Path) != dir || len(opened) != 0 {
+		t.Fatalf("Expected the reminder saved to the dir only, got %+v (opened %v)", msg, opened)
+	}
+	if data, err := os.ReadFile(msg.Path); err != nil || !strings.Contains(string(data), "SUMMARY:Release") {
+		t.Errorf("Expected the event on disk, got %q (%v)", data, err)
+	}
+
+	msg = CreateReminder(item, at, "", "", open)().(ReminderMsg)
+	if msg.Error != nil || !msg.Opened || len(opened) != 1 || opened[0] != msg.Path {
+		t.Errorf("Expected the reminder opened, got %+v (opened %v)", msg, opened)
+	}
+}
@@ -17,7 +17,7 @@ type AudioOperationMsg struct {
 }
 
 // GenerateAudioBriefing calls the API to generate an audio briefing
-func GenerateAudioBriefing() tea.Cmd {
+func GenerateAudioBriefing(op *Op, opts api.AudioBriefingOptions) tea.Cmd {
 	return func() tea.Msg {
 		// Create API client
 		apiClient, err := api.NewClient()
@@ -29,21 +29,54 @@ func GenerateAudioBriefing() tea.Cmd {
 			}
 		}
 
-		// Call the audio briefings API (this will block for 10-30 seconds)
-		audioData, err := apiClient.GenerateAudioBriefing()
+		// Call the audio briefings API (this will block for 10-30 seconds; Esc cancels)
+		ctx, release := op.Start()
+		defer release()
+
+		audioData, err := apiClient.GenerateAudioBriefing(ctx, opts)
+		if IsCancelled(err) {
+			return AudioOperationMsg{
+				Message: "Audio briefing cancelled",
+				Success: false,
+				Error:   err,
+			}
+		}
 		if err != nil {
 			return AudioOperationMsg{
-				Message: fmt.Sprintf("Failed to generate audio briefing: %v", err),
+				Message: apiErrorMessage("generate audio briefing", err),
 				Success: false,
 				Error:   err,
 			}
 		}
 
 		return AudioOperationMsg{
-			Message:  fmt.Sprintf("Briefing ready: %s", audioData.Filename),
+			Message:  fmt.Sprintf("Briefing ready: %s (%d items)", audioData.Filename, audioData.ItemCount),
 			Success:  true,
 			FilePath: audioData.FilePath,
 			Filename: audioData.Filename,
 		}
 	}
 }
+
+// BriefingTranscriptMsg carries a briefing's script and the items it covered
+type BriefingTranscriptMsg struct {
+	Transcript *api.BriefingTranscript
+	Error      error
+}
+
+// LoadBriefingTranscript fetches the transcript of the named briefing, or of
+// the most recent one when filename is empty
+func LoadBriefingTranscript(filename string) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return BriefingTranscriptMsg{Error: fmt.Errorf("failed to create API client: %w", err)}
+		}
+
+		ctx, release := Cancellable()
+		defer release()
+
+		transcript, err := apiClient.GetBriefingTranscript(ctx, filename)
+		return BriefingTranscriptMsg{Transcript: transcript, Error: err}
+	}
+}
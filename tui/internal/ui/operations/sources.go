@@ -1,14 +1,16 @@
 package operations
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nickpending/prismis/internal/api"
 	"github.com/nickpending/prismis/internal/clipboard"
+	"github.com/nickpending/prismis/internal/db"
 )
 
 // Source operation result messages
@@ -18,8 +20,8 @@ type SourceOperationMsg struct {
 	Error   error
 }
 
-// AddSource adds a new source
-func AddSource(url string, name string) tea.Cmd {
+// AddSource adds a new source, optionally assigning it to a category
+func AddSource(url string, name string, category string) tea.Cmd {
 	return func() tea.Msg {
 		// Create API client
 		apiClient, err := api.NewClient()
@@ -45,27 +47,18 @@ func AddSource(url string, name string) tea.Cmd {
 			request.Name = &name
 		}
 
+		// Add category if provided
+		if category != "" {
+			request.Category = &category
+		}
+
 		// Call API
-		resp, err := apiClient.AddSource(request)
+		resp, err := apiClient.AddSource(context.Background(), request)
 		if err != nil {
 			// Parse error for user-friendly message
-			errStr := err.Error()
-			var message string
-
-			if strings.Contains(errStr, "validation error") {
-				// Extract the actual validation error message
-				parts := strings.SplitN(errStr, ": ", 2)
-				if len(parts) >= 2 {
-					message = parts[1]
-				} else {
-					message = errStr
-				}
-			} else if strings.Contains(errStr, "network error") {
-				message = "Cannot connect to daemon - is it running?"
-			} else if strings.Contains(errStr, "already exists") {
+			message := apiErrorMessage("add source", err)
+			if !errors.Is(err, api.ErrValidation) && strings.Contains(api.ErrorMessage(err), "already exists") {
 				message = "Source already exists"
-			} else {
-				message = fmt.Sprintf("Failed to add source: %v", err)
 			}
 
 			return SourceOperationMsg{
@@ -91,8 +84,15 @@ func AddSource(url string, name string) tea.Cmd {
 	}
 }
 
-// RemoveSource removes a source by ID, URL, or name
+// RemoveSource removes a source by ID, URL, or name using the daemon's default content handling
 func RemoveSource(identifier string) tea.Cmd {
+	return RemoveSourceWithRetention(identifier, "")
+}
+
+// RemoveSourceWithRetention removes a source and deletes, keeps, or archives its items
+// (api.RetentionDelete/Keep/Archive). If the local daemon is down, the local database is
+// updated directly so the choice is still honored.
+func RemoveSourceWithRetention(identifier string, retention string) tea.Cmd {
 	return func() tea.Msg {
 		apiClient, err := api.NewClient()
 		if err != nil {
@@ -114,17 +114,31 @@ func RemoveSource(identifier string) tea.Cmd {
 		}
 
 		// Delete the source by ID
-		_, err = apiClient.DeleteSource(sourceID)
+		_, err = apiClient.DeleteSourceWithRetention(context.Background(), sourceID, retention)
+		if err != nil && retention != "" && !apiClient.IsRemote() && errors.Is(err, api.ErrDaemonDown) {
+			// Daemon not running - apply the deletion to the local database instead
+			_, err = db.DeleteSource(sourceID, retention)
+		}
 		if err != nil {
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to remove source: %v", err),
+				Message: apiErrorMessage("remove source", err),
 				Success: false,
 				Error:   err,
 			}
 		}
 
+		message := fmt.Sprintf("✓ Removed source: %s", sourceName)
+		switch retention {
+		case api.RetentionDelete:
+			message += " (items deleted)"
+		case api.RetentionKeep:
+			message += " (items kept)"
+		case api.RetentionArchive:
+			message += " (items archived)"
+		}
+
 		return SourceOperationMsg{
-			Message: fmt.Sprintf("✓ Removed source: %s", sourceName),
+			Message: message,
 			Success: true,
 			Error:   nil,
 		}
@@ -154,10 +168,10 @@ func PauseSource(identifier string) tea.Cmd {
 		}
 
 		// Pause the source
-		_, err = apiClient.PauseSource(sourceID)
+		_, err = apiClient.PauseSource(context.Background(), sourceID)
 		if err != nil {
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to pause source: %v", err),
+				Message: apiErrorMessage("pause source", err),
 				Success: false,
 				Error:   err,
 			}
@@ -194,10 +208,10 @@ func ResumeSource(identifier string) tea.Cmd {
 		}
 
 		// Resume the source
-		_, err = apiClient.ResumeSource(sourceID)
+		_, err = apiClient.ResumeSource(context.Background(), sourceID)
 		if err != nil {
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to resume source: %v", err),
+				Message: apiErrorMessage("resume source", err),
 				Success: false,
 				Error:   err,
 			}
@@ -234,10 +248,10 @@ func EditSourceName(identifier string, newName string) tea.Cmd {
 		}
 
 		// Get the current source data to preserve URL and Type
-		sourcesResp, err := apiClient.GetSources()
+		sourcesResp, err := apiClient.GetSources(context.Background())
 		if err != nil {
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to get source details: %v", err),
+				Message: apiErrorMessage("get source details", err),
 				Success: false,
 				Error:   err,
 			}
@@ -316,11 +330,24 @@ func UpdateSource(sourceID string, updates map[string]interface{}) tea.Cmd {
 			request.Name = &name
 		}
 
+		// Set category if present (empty string clears it)
+		if category, ok := updates["category"].(string); ok {
+			request.Category = &category
+		}
+
+		// Set the priority rule if present (empty strings clear it)
+		if floor, ok := updates["priority_floor"].(string); ok {
+			request.PriorityFloor = &floor
+		}
+		if ceiling, ok := updates["priority_ceiling"].(string); ok {
+			request.PriorityCeiling = &ceiling
+		}
+
 		// Call the update API
-		resp, err := apiClient.UpdateSource(sourceID, request)
+		resp, err := apiClient.UpdateSource(context.Background(), sourceID, request)
 		if err != nil {
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to update source: %v", err),
+				Message: apiErrorMessage("update source", err),
 				Success: false,
 				Error:   err,
 			}
@@ -342,6 +369,76 @@ func UpdateSource(sourceID string, updates map[string]interface{}) tea.Cmd {
 	}
 }
 
+// SourceRename is one source's planned name change in :sources rename
+type SourceRename struct {
+	ID      string
+	URL     string
+	Type    string
+	OldName string
+	NewName string
+}
+
+// SourcesRenamedMsg reports a :sources rename batch. On failure the renames
+// already applied were reverted; RollbackError says if any of those failed.
+type SourcesRenamedMsg struct {
+	Renamed       int
+	Failed        *SourceRename // The rename the daemon rejected
+	Error         error
+	RollbackError error
+}
+
+// RenameSources applies renames one by one. If one fails, the earlier ones
+// are renamed back so the batch lands whole or not at all.
+func RenameSources(renames []SourceRename) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return SourcesRenamedMsg{Error: err}
+		}
+		rename := func(r SourceRename, name string) error {
+			_, err := apiClient.UpdateSource(context.Background(), r.ID, api.SourceRequest{URL: r.URL, Type: r.Type, Name: &name})
+			return err
+		}
+
+		for i, r := range renames {
+			if err := rename(r, r.NewName); err != nil {
+				failed := r
+				var rollbackErrs []error
+				for _, done := range renames[:i] {
+					if err := rename(done, done.OldName); err != nil {
+						rollbackErrs = append(rollbackErrs, fmt.Errorf("%s: %w", done.NewName, err))
+					}
+				}
+				return SourcesRenamedMsg{Failed: &failed, Error: err, RollbackError: errors.Join(rollbackErrs...)}
+			}
+		}
+		return SourcesRenamedMsg{Renamed: len(renames)}
+	}
+}
+
+// SourcesMergedMsg reports a :sources dedupe merge
+type SourcesMergedMsg struct {
+	KeepName string
+	Merged   int // Sources folded into the kept one
+	Moved    int // Items reassigned to it
+	Error    error
+}
+
+// MergeSources folds the duplicates in sourceIDs into keepID on the daemon
+func MergeSources(keepID, keepName string, sourceIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return SourcesMergedMsg{KeepName: keepName, Error: err}
+		}
+		moved, err := apiClient.MergeSources(context.Background(), keepID, sourceIDs)
+		if err != nil {
+			return SourcesMergedMsg{KeepName: keepName, Error: err}
+		}
+		return SourcesMergedMsg{KeepName: keepName, Merged: len(sourceIDs), Moved: moved}
+	}
+}
+
 // RefreshSources triggers a manual refresh of all sources
 func RefreshSources() tea.Cmd {
 	return func() tea.Msg {
@@ -382,8 +479,9 @@ func ShowLogs() tea.Cmd {
 	}
 }
 
-// ExportSources exports all sources to clipboard in markdown format
-func ExportSources() tea.Cmd {
+// ExportSources exports all sources as markdown, OPML, or JSON: to path when
+// given (a directory gets a dated file name), otherwise to the clipboard
+func ExportSources(path string, format string) tea.Cmd {
 	return func() tea.Msg {
 		apiClient, err := api.NewClient()
 		if err != nil {
@@ -395,26 +493,10 @@ func ExportSources() tea.Cmd {
 		}
 
 		// Get all sources from API
-		sourcesResp, err := apiClient.GetSources()
+		sourcesResp, err := apiClient.GetSources(context.Background())
 		if err != nil {
-			// Check for specific error types
-			errStr := err.Error()
-			if strings.Contains(errStr, "network error") {
-				return SourceOperationMsg{
-					Message: "Cannot connect to daemon - is it running?",
-					Success: false,
-					Error:   err,
-				}
-			} else if strings.Contains(errStr, "authentication failed") {
-				return SourceOperationMsg{
-					Message: "Authentication failed - check API key",
-					Success: false,
-					Error:   err,
-				}
-			}
-
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to get sources: %v", err),
+				Message: apiErrorMessage("get sources", err),
 				Success: false,
 				Error:   err,
 			}
@@ -429,95 +511,45 @@ func ExportSources() tea.Cmd {
 			}
 		}
 
-		// Group sources by type
-		rssFeeds := []api.Source{}
-		redditSubs := []api.Source{}
-		youtubeChannels := []api.Source{}
-
-		for _, source := range sourcesResp.Sources {
-			switch source.Type {
-			case "rss":
-				rssFeeds = append(rssFeeds, source)
-			case "reddit":
-				redditSubs = append(redditSubs, source)
-			case "youtube":
-				youtubeChannels = append(youtubeChannels, source)
-			}
-		}
-
-		// Sort each group alphabetically by name/URL
-		sortSources := func(sources []api.Source) {
-			sort.Slice(sources, func(i, j int) bool {
-				nameI := sources[i].URL
-				if sources[i].Name != nil {
-					nameI = *sources[i].Name
-				}
-				nameJ := sources[j].URL
-				if sources[j].Name != nil {
-					nameJ = *sources[j].Name
-				}
-				return strings.ToLower(nameI) < strings.ToLower(nameJ)
-			})
+		now := time.Now()
+		if format == "" {
+			format = exportFormatFor(path)
 		}
-
-		sortSources(rssFeeds)
-		sortSources(redditSubs)
-		sortSources(youtubeChannels)
-
-		// Build markdown format
-		var markdown strings.Builder
-		markdown.WriteString(fmt.Sprintf("# Prismis Sources (exported %s)\n\n", time.Now().Format("2006-01-02")))
-
-		// Helper to format source list
-		formatSourceList := func(sources []api.Source) {
-			for _, source := range sources {
-				name := source.URL
-				if source.Name != nil && *source.Name != "" {
-					name = *source.Name
-				}
-
-				// Add paused indicator
-				status := ""
-				if !source.Active {
-					status = " (paused)"
-				}
-
-				markdown.WriteString(fmt.Sprintf("- %s - %s%s\n", name, source.URL, status))
+		data, err := renderSources(sourcesResp.Sources, format, now)
+		if err != nil {
+			return SourceOperationMsg{
+				Message: fmt.Sprintf("Failed to export sources: %v", err),
+				Success: false,
+				Error:   err,
 			}
 		}
 
-		// RSS Feeds section
-		if len(rssFeeds) > 0 {
-			markdown.WriteString("## RSS Feeds\n")
-			formatSourceList(rssFeeds)
-			markdown.WriteString("\n")
-		}
-
-		// YouTube Channels section
-		if len(youtubeChannels) > 0 {
-			markdown.WriteString("## YouTube Channels\n")
-			formatSourceList(youtubeChannels)
-			markdown.WriteString("\n")
-		}
-
-		// Reddit Subreddits section
-		if len(redditSubs) > 0 {
-			markdown.WriteString("## Reddit Subreddits\n")
-			formatSourceList(redditSubs)
-			markdown.WriteString("\n")
+		if path == "" {
+			// Copy to clipboard
+			if err := clipboard.CopyToClipboard(string(data)); err != nil {
+				return SourceOperationMsg{
+					Message: fmt.Sprintf("Failed to copy to clipboard: %v (try :export sources <path>)", err),
+					Success: false,
+					Error:   err,
+				}
+			}
+			return SourceOperationMsg{
+				Message: fmt.Sprintf("Exported %d sources to clipboard", len(sourcesResp.Sources)),
+				Success: true,
+				Error:   nil,
+			}
 		}
 
-		// Copy to clipboard
-		if err := clipboard.CopyToClipboard(markdown.String()); err != nil {
+		path, err = writeSourcesExport(path, format, data, now)
+		if err != nil {
 			return SourceOperationMsg{
-				Message: fmt.Sprintf("Failed to copy to clipboard: %v", err),
+				Message: fmt.Sprintf("Failed to export sources: %v", err),
 				Success: false,
 				Error:   err,
 			}
 		}
-
 		return SourceOperationMsg{
-			Message: fmt.Sprintf("Exported %d sources to clipboard", len(sourcesResp.Sources)),
+			Message: fmt.Sprintf("Exported %d sources to %s", len(sourcesResp.Sources), path),
 			Success: true,
 			Error:   nil,
 		}
@@ -613,7 +645,7 @@ func lookupSourceByIdentifier(identifier string, apiClient *api.APIClient) (stri
 	}
 
 	// Get all sources for lookup
-	sourcesResp, err := apiClient.GetSources()
+	sourcesResp, err := apiClient.GetSources(context.Background())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to get sources: %v", err)
 	}
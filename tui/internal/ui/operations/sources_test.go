@@ -0,0 +1,146 @@
+package operations
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/feeds"
+)
+
+// TestPauseSource_ByName verifies name lookup and the pause call reach the daemon.
+// BREAKS: If lookup by name stops matching, :pause <name> reports "source not found".
+func TestPauseSource_ByName(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddSource(apitest.Source{URL: "https://example.com/feed", Type: "rss", Name: "Example", Active: true})
+
+	msg := PauseSource("example")().(SourceOperationMsg)
+	if !msg.Success {
+		t.Fatalf("Expected success, got %q", msg.Message)
+	}
+	if daemon.Sources()[0].Active {
+		t.Error("Expected source to be paused on the daemon")
+	}
+}
+
+// TestRemoveSourceWithRetention_Archive verifies the retention choice reaches the daemon.
+// BREAKS: If the content param is dropped, "archive" silently deletes the source's items.
+func TestRemoveSourceWithRetention_Archive(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	src := daemon.AddSource(apitest.Source{URL: "https://example.com/feed", Type: "rss", Name: "Example", Active: true})
+	daemon.AddEntry(apitest.Entry{SourceID: src.ID, Title: "keep me"})
+
+	msg := RemoveSourceWithRetention("Example", api.RetentionArchive)().(SourceOperationMsg)
+	if !msg.Success || !strings.Contains(msg.Message, "archived") {
+		t.Fatalf("Unexpected result: %+v", msg)
+	}
+	entries := daemon.Entries()
+	if len(entries) != 1 || !entries[0].Archived {
+		t.Errorf("Expected the item to survive archived, got %+v", entries)
+	}
+}
+
+// TestAddSource_ValidationMessage verifies the daemon's reason is shown on rejection.
+// BREAKS: If the message is dropped, the user sees "validation error" with no hint.
+func TestAddSource_ValidationMessage(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.Fail("POST /api/sources", http.StatusUnprocessableEntity, "Subreddit r/nope does not exist")
+
+	msg := AddSource("reddit://nope", "", "")().(SourceOperationMsg)
+	if msg.Success || !strings.Contains(msg.Message, "r/nope does not exist") {
+		t.Errorf("Expected daemon message, got %q", msg.Message)
+	}
+}
+
+// TestRenameSources_RollsBackOnFailure verifies a rejected rename reverts the ones already made.
+// BREAKS: If the batch stops half-way, a failed bulk rename leaves sources half renamed.
+func TestRenameSources_RollsBackOnFailure(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	a := daemon.AddSource(apitest.Source{URL: "https://a.example/feed", Type: "rss", Name: "A – RSS", Active: true})
+	b := daemon.AddSource(apitest.Source{URL: "https://b.example/feed", Type: "rss", Name: "B – RSS", Active: true})
+	renames := []SourceRename{
+		{ID: a.ID, URL: a.URL, Type: a.Type, OldName: "A – RSS", NewName: "A"},
+		{ID: b.ID, URL: b.URL, Type: b.Type, OldName: "B – RSS", NewName: "B"},
+	}
+
+	daemon.Fail("PATCH /api/sources/"+b.ID, http.StatusInternalServerError, "database locked")
+	msg := RenameSources(renames)().(SourcesRenamedMsg)
+	if msg.Error == nil || msg.Failed == nil || msg.Failed.ID != b.ID || msg.RollbackError != nil {
+		t.Fatalf("Expected b's rename reported as failed, got %+v", msg)
+	}
+	for _, s := range daemon.Sources() {
+		if !strings.HasSuffix(s.Name, "– RSS") {
+			t.Errorf("Expected %s rolled back, got %q", s.ID, s.Name)
+		}
+	}
+
+	daemon.Recover()
+	if msg := RenameSources(renames)().(SourcesRenamedMsg); msg.Error != nil || msg.Renamed != 2 {
+		t.Fatalf("Expected both renamed, got %+v", msg)
+	}
+	if names := []string{daemon.Sources()[0].Name, daemon.Sources()[1].Name}; names[0] != "A" || names[1] != "B" {
+		t.Errorf("Expected A and B, got %v", names)
+	}
+}
+
+// TestImportSource_ExistingIsNotAnError verifies an imported feed carries its name and category, and a duplicate counts as existing.
+// BREAKS: If "already exists" is reported as a failure, re-running an import lists every feed as an error.
+func TestImportSource_ExistingIsNotAnError(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+
+	sub := feeds.Subscription{URL: "https://example.com/feed.xml", Name: "Example", Category: "tech"}
+	if msg := ImportSource(sub)().(SourceImportedMsg); msg.Error != nil || msg.Exists {
+		t.Fatalf("Expected the feed added, got %+v", msg)
+	}
+	if s := daemon.Sources()[0]; s.Name != "Example" || s.Category != "tech" || s.Type != "rss" {
+		t.Errorf("Expected name and category sent, got %+v", s)
+	}
+	if msg := ImportSource(sub)().(SourceImportedMsg); msg.Error != nil || !msg.Exists {
+		t.Errorf("Expected the second import to report an existing source, got %+v", msg)
+	}
+}
+
+// TestExportSources_WritesFormatByExtension verifies a path export writes to disk, choosing OPML from the extension.
+// BREAKS: If path exports still go through the clipboard, headless machines can't back up their sources.
+func TestExportSources_WritesFormatByExtension(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddSource(apitest.Source{URL: "https://example.com/feed.xml", Type: "rss", Name: "Example & Co", Category: "tech", Active: true})
+	daemon.AddSource(apitest.Source{URL: "https://example.org/rss", Type: "rss", Active: false})
+
+	dir := t.TempDir()
+	msg := ExportSources(filepath.Join(dir, "feeds.opml"), "")().(SourceOperationMsg)
+	if !msg.Success {
+		t.Fatalf("Expected success, got %q", msg.Message)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "feeds.opml"))
+	if err != nil {
+		t.Fatalf("Expected the file written: %v", err)
+	}
+	opml := string(data)
+	for _, want := range []string{`<opml version="2.0">`, `<outline text="tech"`, `text="Example &amp; Co"`, `xmlUrl="https://example.org/rss"`} {
+		if !strings.Contains(opml, want) {
+			t.Errorf("Expected %s in the OPML:\n%s", want, opml)
+		}
+	}
+
+	// A directory gets a dated file in the requested format
+	msg = ExportSources(dir, ExportJSON)().(SourceOperationMsg)
+	matches, _ := filepath.Glob(filepath.Join(dir, "prismis-sources-*.json"))
+	if !msg.Success || len(matches) != 1 {
+		t.Fatalf("Expected a JSON file in the directory, got %q", msg.Message)
+	}
+	data, _ = os.ReadFile(matches[0])
+	if !strings.Contains(string(data), `"active": false`) {
+		t.Errorf("Expected the paused source marked inactive:\n%s", data)
+	}
+}
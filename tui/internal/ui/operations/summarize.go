@@ -0,0 +1,36 @@
+package operations
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// SummarizedMsg carries an entry's new summary and analysis
+type SummarizedMsg struct {
+	ContentID string
+	Summary   string
+	Analysis  string // Full analysis JSON, replacing the item's
+	Error     error
+}
+
+// SummarizeContent asks the daemon to summarize an entry that has no reading
+// summary (one LLM call; Esc cancels)
+func SummarizeContent(op *Op, contentID string) tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return SummarizedMsg{ContentID: contentID, Error: fmt.Errorf("failed to create API client: %w", err)}
+		}
+
+		ctx, release := op.Start()
+		defer release()
+
+		result, err := apiClient.SummarizeEntry(ctx, contentID)
+		if err != nil {
+			return SummarizedMsg{ContentID: contentID, Error: err}
+		}
+		return SummarizedMsg{ContentID: contentID, Summary: result.Summary, Analysis: string(result.Analysis)}
+	}
+}
@@ -0,0 +1,47 @@
+package operations
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/service"
+)
+
+// PendingSyncMsg reports the offline write queue after a count or replay
+type PendingSyncMsg struct {
+	Replayed int // Writes applied to the daemon by this replay
+	Pending  int // Writes still waiting for the daemon
+	Error    error
+}
+
+// CountPendingWrites reports how many offline writes are waiting to sync
+func CountPendingWrites() tea.Cmd {
+	return func() tea.Msg {
+		return PendingSyncMsg{Pending: service.PendingCount()}
+	}
+}
+
+// QueuedFields returns the fields with writes waiting for the daemon, by
+// content ID then field ("read", "favorited", ...)
+func QueuedFields() map[string]map[string]bool {
+	writes, err := service.PendingWrites()
+	if err != nil {
+		return nil
+	}
+	queued := map[string]map[string]bool{}
+	for _, w := range writes {
+		if queued[w.ContentID] == nil {
+			queued[w.ContentID] = map[string]bool{}
+		}
+		queued[w.ContentID][w.Field] = true
+	}
+	return queued
+}
+
+// ReplayPendingWrites sends queued offline writes to the daemon
+func ReplayPendingWrites() tea.Cmd {
+	return func() tea.Msg {
+		replayed, pending, err := service.ReplayPending(context.Background())
+		return PendingSyncMsg{Replayed: replayed, Pending: pending, Error: err}
+	}
+}
@@ -0,0 +1,159 @@
+package operations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/config"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// taskCommand runs taskwarrior (overridable for testing)
+var taskCommand = func(args ...string) *exec.Cmd { return exec.Command("task", args...) }
+
+// TaskCreatedMsg reports a task made from an item by :task
+type TaskCreatedMsg struct {
+	ContentID   string
+	Description string
+	Backend     string
+	MarkRead    bool // [task] mark_read: the item should be marked read now
+	Error       error
+}
+
+// taskPayload is what the webhook backend POSTs
+type taskPayload struct {
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Summary     string `json:"summary,omitempty"`
+	Source      string `json:"source,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	Project     string `json:"project,omitempty"`
+	ContentID   string `json:"content_id"`
+}
+
+// CreateTask turns item into a task in the configured backend. description
+// is the task's text, usually the item's title.
+func CreateTask(task config.Task, item db.ContentItem, description string) tea.Cmd {
+	return func() tea.Msg {
+		msg := TaskCreatedMsg{ContentID: item.ID, Description: description, Backend: task.Backend, MarkRead: task.MarkRead}
+		switch task.Backend {
+		case "taskwarrior":
+			msg.Error = addTaskwarrior(task.Project, item, description)
+		case "todotxt":
+			msg.Error = appendTodoTxt(task.File, task.Project, item, description, time.Now())
+		case "webhook":
+			ctx, release := Cancellable()
+			defer release()
+			msg.Error = postTaskWebhook(ctx, task.URL, taskPayload{
+				Description: description,
+				URL:         item.URL,
+				Summary:     item.Summary,
+				Source:      item.SourceName,
+				Priority:    item.Priority,
+				Project:     task.Project,
+				ContentID:   item.ID,
+			})
+		default:
+			msg.Error = fmt.Errorf("unknown task backend '%s'", task.Backend)
+		}
+		return msg
+	}
+}
+
+// addTaskwarrior runs task add, then annotates the new task with the link
+// and summary so they show in task info
+func addTaskwarrior(project string, item db.ContentItem, description string) error {
+	args := []string{"rc.confirmation=off", "rc.verbose=nothing", "add", "+prismis"}
+	if project != "" {
+		args = append(args, "project:"+project)
+	}
+	// -- keeps a title like "depends: fix" from being read as an attribute
+	if err := runTask(append(args, "--", description)...); err != nil {
+		return err
+	}
+	for _, note := range []string{item.URL, oneLine(item.Summary)} {
+		if note == "" {
+			continue
+		}
+		if err := runTask("rc.confirmation=off", "rc.verbose=nothing", "+LATEST", "annotate", "--", note); err != nil {
+			return fmt.Errorf("task added, but annotating it failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runTask runs taskwarrior, putting its output in the error when it fails
+func runTask(args ...string) error {
+	out, err := taskCommand(args...).CombinedOutput()
+	if err != nil {
+		if detail := strings.TrimSpace(string(out)); detail != "" {
+			return fmt.Errorf("task: %s", detail)
+		}
+		return fmt.Errorf("task: %w", err)
+	}
+	return nil
+}
+
+// appendTodoTxt adds a todo.txt line: creation date, description, link, and
+// +project. todo.txt is one task per line, so the summary is left out.
+func appendTodoTxt(path, project string, item db.ContentItem, description string, now time.Time) error {
+	line := now.Format("2006-01-02") + " " + oneLine(description)
+	if item.URL != "" {
+		line += " " + item.URL
+	}
+	if project != "" {
+		line += " +" + strings.ReplaceAll(project, " ", "-")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create todo.txt directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open todo.txt: %w", err)
+	}
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write todo.txt: %w", err)
+	}
+	return f.Close()
+}
+
+// postTaskWebhook sends the task as JSON; any 2xx answer counts as created
+func postTaskWebhook(ctx context.Context, url string, payload taskPayload) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid task webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("task webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("task webhook failed: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// oneLine collapses whitespace, newlines included, to single spaces
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,94 @@
+package operations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/config"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestCreateTask_TodoTxtAppendsLine verifies a todo.txt task is one dated line with the link and project.
+// BREAKS: If the summary's newlines reach the file, one capture turns into several bogus tasks.
+func TestCreateTask_TodoTxtAppendsLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo", "todo.txt")
+	item := db.ContentItem{ID: "a", Title: "Rust 2024", URL: "https://example.com/rust", Summary: "line one\nline two"}
+	task := config.Task{Backend: "todotxt", File: path, Project: "reading list", MarkRead: true}
+
+	for _, description := range []string{"Rust 2024", "Try the\nnew edition"} {
+		if msg := CreateTask(task, item, description)().(TaskCreatedMsg); msg.Error != nil || !msg.MarkRead {
+			t.Fatalf("Unexpected result: %+v", msg)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := time.Now().Format("2006-01-02")
+	want := today + " Rust 2024 https://example.com/rust +reading-list\n" +
+		today + " Try the new edition https://example.com/rust +reading-list\n"
+	if string(data) != want {
+		t.Errorf("Expected %q, got %q", want, data)
+	}
+}
+
+// TestCreateTask_WebhookPostsItem verifies the webhook gets the item as JSON and a non-2xx answer is a failure.
+// BREAKS: If a 500 counts as created, the item is marked read and the task is silently lost.
+func TestCreateTask_WebhookPostsItem(t *testing.T) {
+	var got taskPayload
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	item := db.ContentItem{ID: "a", Title: "Rust 2024", URL: "https://example.com/rust", Summary: "New edition", Priority: "high"}
+	task := config.Task{Backend: "webhook", URL: server.URL}
+	if msg := CreateTask(task, item, "Rust 2024")().(TaskCreatedMsg); msg.Error != nil {
+		t.Fatalf("Unexpected error: %v", msg.Error)
+	}
+	if got.Description != "Rust 2024" || got.URL != item.URL || got.Summary != "New edition" || got.ContentID != "a" {
+		t.Errorf("Expected the item in the payload, got %+v", got)
+	}
+
+	status = http.StatusInternalServerError
+	if msg := CreateTask(task, item, "Rust 2024")().(TaskCreatedMsg); msg.Error == nil || !strings.Contains(msg.Error.Error(), "500") {
+		t.Errorf("Expected HTTP 500 reported, got %v", msg.Error)
+	}
+}
+
+// TestCreateTask_TaskwarriorAnnotates verifies task add gets the title after -- and the link and summary become annotations.
+// BREAKS: Without --, a title like "status: done" is parsed as a taskwarrior attribute.
+func TestCreateTask_TaskwarriorAnnotates(t *testing.T) {
+	var calls [][]string
+	original := taskCommand
+	defer func() { taskCommand = original }()
+	taskCommand = func(args ...string) *exec.Cmd {
+		calls = append(calls, args)
+		return exec.Command("true")
+	}
+
+	item := db.ContentItem{ID: "a", Title: "status: done", URL: "https://example.com/x", Summary: "Short\nsummary"}
+	task := config.Task{Backend: "taskwarrior", Project: "reading"}
+	if msg := CreateTask(task, item, item.Title)().(TaskCreatedMsg); msg.Error != nil {
+		t.Fatalf("Unexpected error: %v", msg.Error)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("Expected add and two annotations, got %q", calls)
+	}
+	add := strings.Join(calls[0], " ")
+	if !strings.Contains(add, "add +prismis project:reading -- status: done") {
+		t.Errorf("Unexpected add: %q", add)
+	}
+	if note := calls[2][len(calls[2])-1]; note != "Short summary" {
+		t.Errorf("Expected the summary on one line, got %q", note)
+	}
+}
@@ -0,0 +1,27 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+)
+
+// DaemonVersionMsg carries the daemon's version handshake
+type DaemonVersionMsg struct {
+	Info  *api.VersionInfo
+	Error error
+}
+
+// CheckDaemonVersion asks the daemon which optional features it supports
+func CheckDaemonVersion() tea.Cmd {
+	return func() tea.Msg {
+		apiClient, err := api.NewClient()
+		if err != nil {
+			return DaemonVersionMsg{Error: fmt.Errorf("failed to create API client: %w", err)}
+		}
+		info, err := apiClient.Version(context.Background())
+		return DaemonVersionMsg{Info: info, Error: err}
+	}
+}
@@ -0,0 +1,329 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/config"
+)
+
+// option is one :set option: how to show it, change it, and write it to
+// config.toml
+type option struct {
+	name string
+	get  func(m *Model) string
+	set  func(m *Model, value string) (tea.Cmd, error)
+	// save writes the current value to [tui] in config.toml; nil when the
+	// option has no config setting
+	save func(m *Model) error
+	// hidden options work by name but stay out of the bare :set list
+	hidden bool
+}
+
+// options is the :set table, in the order bare :set lists them
+var options = []option{
+	boolOption("preview", "", func(m *Model) *bool { return &m.showPreview }, func(m *Model) tea.Cmd {
+		m.previewItemID = "" // Re-render at the new size
+		return nil
+	}),
+	{
+		name: "sidebar",
+		get:  func(m *Model) string { return onOff(!m.hideSidebar) },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			on, err := parseOptionBool(value, !m.hideSidebar)
+			if err != nil {
+				return nil, err
+			}
+			m.setSidebar(on)
+			return saveUIState(m.savedLayout()), nil
+		},
+		save: rememberedOption,
+	},
+	{
+		name: "sidebarwidth",
+		get:  func(m *Model) string { return fmt.Sprintf("%d%%", m.sidebarRatio()) },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+			if err != nil || percent < minSidebarPercent || percent > maxSidebarPercent {
+				return nil, fmt.Errorf("expected %d-%d", minSidebarPercent, maxSidebarPercent)
+			}
+			m.sidebarPercent = percent
+			m.setSidebar(true)
+			return saveUIState(m.savedLayout()), nil
+		},
+		save: rememberedOption,
+	},
+	{
+		name: "density",
+		get:  func(m *Model) string { return m.density() },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			switch value {
+			case "compact":
+				m.compact = true
+			case "comfortable", "normal":
+				m.compact = false
+			case "toggle":
+				m.compact = !m.compact
+			default:
+				return nil, fmt.Errorf("expected compact or comfortable")
+			}
+			return saveUIState(m.savedLayout()), nil
+		},
+		save: rememberedOption,
+	},
+	{
+		name: "wrap",
+		get:  func(m *Model) string { return onOff(!m.noWrap) },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			on, err := parseOptionBool(value, !m.noWrap)
+			if err != nil {
+				return nil, err
+			}
+			m.noWrap = !on
+			if m.view == "reader" {
+				m.updateReaderContent()
+			}
+			return nil, nil
+		},
+		save: func(m *Model) error { return config.SetTUIOption("wrap", !m.noWrap) },
+	},
+	boolOption("showall", "show_all", func(m *Model) *bool { return &m.showAll }, func(m *Model) tea.Cmd {
+		m.cursor = 0
+		m.loading = true
+		return m.fetchList(false)
+	}),
+	{
+		name: "refresh",
+		get:  func(m *Model) string { return strconv.Itoa(int(m.refreshInterval.Seconds())) },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			if value == "false" {
+				value = "0" // :set norefresh
+			}
+			interval, err := parseRefreshInterval(value)
+			if err != nil {
+				return nil, err
+			}
+			wasOff := m.currentRefreshInterval() == 0
+			m.refreshInterval = interval
+			if m.currentRefreshInterval() == 0 || wasOff {
+				// Retire a timer that's still pending, or start one
+				return m.scheduleRefresh(), nil
+			}
+			return nil, nil // A running timer picks up the new interval when it next fires
+		},
+		save: func(m *Model) error {
+			return config.SetTUIOption("refresh_interval", int(m.refreshInterval.Seconds()))
+		},
+	},
+	boolOption("linkcheck", "check_links", func(m *Model) *bool { return &m.checkLinks }, func(m *Model) tea.Cmd {
+		if m.checkLinks && !m.linkTicking {
+			m.linkTicking = true
+			return linkCheckTick()
+		}
+		return nil
+	}),
+	boolOption("hyperlinks", "hyperlinks", func(m *Model) *bool { return &m.hyperlinks }, func(m *Model) tea.Cmd {
+		if m.view == "reader" {
+			m.updateReaderContent()
+		}
+		return nil
+	}),
+	{
+		name: "colors",
+		get:  func(m *Model) string { return m.colorDepthSetting() },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			if value == "true" || value == "toggle" {
+				return nil, fmt.Errorf("expected auto, truecolor, 256 or 16")
+			}
+			depth, err := parseColorDepth(value)
+			if err != nil {
+				return nil, err
+			}
+			m.colorDepth = depth
+			m.useTheme(m.theme.Name)
+			m.updateSourcesViewport()
+			return nil, nil
+		},
+		save: func(m *Model) error { return config.SetTUIOption("colors", cmp.Or(m.colorDepth, colorsAuto)) },
+	},
+	{
+		name: "background",
+		get:  func(m *Model) string { return m.backgroundSetting() },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			if value == "true" || value == "toggle" {
+				return nil, fmt.Errorf("expected light, dark or auto")
+			}
+			background, err := parseBackground(value)
+			if err != nil {
+				return nil, err
+			}
+			m.background = background
+			m.useTheme(themeForBackground(m.theme.Name, m.lightBackground()))
+			m.updateSourcesViewport()
+			return m.notify(toastInfo, fmt.Sprintf("Theme: %s", m.theme.Name), 2*time.Second), nil
+		},
+		save: func(m *Model) error { return config.SetTUIOption("background", cmp.Or(m.background, backgroundAuto)) },
+	},
+	{
+		name: "goal",
+		get:  func(m *Model) string { return m.goal.String() },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			if value == "true" || value == "toggle" {
+				return nil, fmt.Errorf("expected high, a daily article count like 10, or off")
+			}
+			goal, err := parseGoal(value)
+			if err != nil {
+				return nil, err
+			}
+			m.goal = goal
+			return m.refreshGoal(), nil
+		},
+		save: func(m *Model) error { return config.SetTUIOption("goal", m.goal.String()) },
+	},
+	{
+		name: "markread",
+		get:  func(m *Model) string { return m.markReadSetting() },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			if value == "true" || value == "toggle" {
+				return nil, fmt.Errorf("expected never, open, end, or a delay like 10s")
+			}
+			mode, delay, err := parseMarkRead(value, m.markReadDelay)
+			if err != nil {
+				return nil, err
+			}
+			m.markRead, m.markReadDelay = mode, delay
+			m.markReadItem = "" // Apply to the open article too
+			return m.notify(toastInfo, "markread="+m.markReadSetting(), 2*time.Second), nil
+		},
+		save: func(m *Model) error {
+			if err := config.SetTUIOption("mark_read", m.markRead); err != nil {
+				return err
+			}
+			return config.SetTUIOption("mark_read_delay", int(m.markReadDelay.Seconds()))
+		},
+	},
+	{
+		name: "debug",
+		get:  func(m *Model) string { return onOff(m.debug != nil) },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			on, err := parseOptionBool(value, m.debug != nil)
+			if err != nil {
+				return nil, err
+			}
+			m.setDebug(on)
+			return nil, nil
+		},
+		hidden: true,
+	},
+}
+
+// boolOption builds an on/off option backed by a Model field. changed runs
+// after every change; configKey is its [tui] setting, empty for none.
+func boolOption(name, configKey string, field func(m *Model) *bool, changed func(m *Model) tea.Cmd) option {
+	opt := option{
+		name: name,
+		get:  func(m *Model) string { return onOff(*field(m)) },
+		set: func(m *Model, value string) (tea.Cmd, error) {
+			on, err := parseOptionBool(value, *field(m))
+			if err != nil {
+				return nil, err
+			}
+			*field(m) = on
+			return changed(m), nil
+		},
+	}
+	if configKey != "" {
+		opt.save = func(m *Model) error { return config.SetTUIOption(configKey, *field(m)) }
+	}
+	return opt
+}
+
+// rememberedOption is the save for layout options, which ui_state.json
+// already keeps across restarts
+func rememberedOption(*Model) error { return nil }
+
+// findOption looks up a :set option by name
+func findOption(name string) *option {
+	for i := range options {
+		if options[i].name == name {
+			return &options[i]
+		}
+	}
+	return nil
+}
+
+// setOption applies a :set command: list every option, show one, or change
+// one and optionally write it to config
+func (m *Model) setOption(msg commands.SetOptionMsg) tea.Cmd {
+	if msg.Name == "" {
+		var values []string
+		for _, opt := range options {
+			if !opt.hidden {
+				values = append(values, opt.name+"="+opt.get(m))
+			}
+		}
+		return m.notify(toastInfo, strings.Join(values, " "), 8*time.Second)
+	}
+
+	opt := findOption(msg.Name)
+	if opt == nil {
+		return m.notify(toastError, fmt.Sprintf("set: unknown option '%s'", msg.Name), 3*time.Second)
+	}
+	if msg.Value == "" {
+		return m.notify(toastInfo, opt.name+"="+opt.get(m), 3*time.Second)
+	}
+	cmd, err := opt.set(m, msg.Value)
+	if err != nil {
+		return m.notify(toastError, fmt.Sprintf("set %s: %v", opt.name, err), 3*time.Second)
+	}
+	if !msg.Save {
+		return cmd
+	}
+
+	if opt.save == nil {
+		return tea.Batch(cmd, m.notify(toastError, fmt.Sprintf("set %s: not a config setting, changed for this session only", opt.name), 3*time.Second))
+	}
+	if err := opt.save(m); err != nil {
+		return tea.Batch(cmd, m.notify(toastError, fmt.Sprintf("set %s: %v", opt.name, err), 5*time.Second))
+	}
+	return tea.Batch(cmd, m.notify(toastSuccess, fmt.Sprintf("%s=%s saved", opt.name, opt.get(m)), 3*time.Second))
+}
+
+// parseRefreshInterval parses a :set refresh value: seconds, or a duration
+// like "2m"; 0 turns auto-refresh off
+func parseRefreshInterval(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 && (d == 0 || d >= time.Second) {
+		return d.Truncate(time.Second), nil
+	}
+	return 0, fmt.Errorf("expected seconds or a duration like 2m, got '%s'", value)
+}
+
+// parseOptionBool interprets a :set value for a boolean option; "toggle"
+// flips current
+func parseOptionBool(value string, current bool) (bool, error) {
+	switch value {
+	case "true", "on", "yes", "1":
+		return true, nil
+	case "false", "off", "no", "0":
+		return false, nil
+	case "toggle":
+		return !current, nil
+	}
+	return current, fmt.Errorf("expected on or off, got '%s'", value)
+}
+
+// onOff renders a boolean option value
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
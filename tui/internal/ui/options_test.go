@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// lastToast returns the newest notification's text
+func lastToast(m Model) string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+	return m.toasts[len(m.toasts)-1].text
+}
+
+// TestSetOption_ListsAndQueriesOptions verifies bare :set lists every option and "name?" shows one.
+// BREAKS: If the list is built by hand, new options silently go missing from :set.
+func TestSetOption_ListsAndQueriesOptions(t *testing.T) {
+	m := Model{refreshInterval: time.Minute, markRead: "never"}
+
+	updated, _ := m.Update(commands.SetOptionMsg{})
+	m = updated.(Model)
+	for _, opt := range options {
+		if strings.Contains(lastToast(m), opt.name+"=") == opt.hidden {
+			t.Errorf("Expected %s listed only if it isn't hidden, in %q", opt.name, lastToast(m))
+		}
+	}
+	if !strings.Contains(lastToast(m), "wrap=on") || !strings.Contains(lastToast(m), "refresh=60") {
+		t.Errorf("Expected current values, got %q", lastToast(m))
+	}
+
+	updated, _ = m.Update(commands.SetOptionMsg{Name: "showall"})
+	if got := lastToast(updated.(Model)); got != "showall=off" {
+		t.Errorf("Expected showall=off, got %q", got)
+	}
+}
+
+// TestSetOption_RefreshRetiresOldTimer verifies turning refresh off and on again leaves a single timer.
+// BREAKS: If the old tick still fires, every :set refresh adds another refresh loop.
+func TestSetOption_RefreshRetiresOldTimer(t *testing.T) {
+	m := Model{refreshInterval: time.Minute}
+	stale := autoRefreshMsg{gen: m.refreshGen}
+
+	updated, _ := m.Update(commands.SetOptionMsg{Name: "refresh", Value: "false"})
+	m = updated.(Model)
+	updated, cmd := m.Update(commands.SetOptionMsg{Name: "refresh", Value: "2m"})
+	m = updated.(Model)
+	if m.refreshInterval != 2*time.Minute || cmd == nil {
+		t.Fatalf("Expected a new 2m timer, got %v (cmd %v)", m.refreshInterval, cmd != nil)
+	}
+
+	m.view = "list"
+	updated, _ = m.Update(stale)
+	if updated.(Model).loading {
+		t.Error("Expected the retired timer's tick to be ignored")
+	}
+
+	updated, _ = m.Update(commands.SetOptionMsg{Name: "refresh", Value: "soon"})
+	if !strings.Contains(lastToast(updated.(Model)), "set refresh:") {
+		t.Errorf("Expected a parse error, got %q", lastToast(updated.(Model)))
+	}
+}
+
+// TestSetOption_SaveWritesConfig verifies --save writes the option's [tui] setting and rejects session-only options.
+// BREAKS: If --save reports success without writing, the setting is gone on the next launch.
+func TestSetOption_SaveWritesConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	m := Model{hyperlinks: true}
+
+	updated, _ := m.Update(commands.SetOptionMsg{Name: "hyperlinks", Value: "false", Save: true})
+	m = updated.(Model)
+	updated, _ = m.Update(commands.SetOptionMsg{Name: "refresh", Value: "90", Save: true})
+	m = updated.(Model)
+	data, err := os.ReadFile(filepath.Join(dir, "prismis", "config.toml"))
+	if err != nil || string(data) != "[tui]\nhyperlinks = false\nrefresh_interval = 90\n" {
+		t.Errorf("Expected both settings written, got %q (%v)", data, err)
+	}
+	if m.hyperlinks || lastToast(m) != "refresh=90 saved" {
+		t.Errorf("Expected the change applied and confirmed, got %v %q", m.hyperlinks, lastToast(m))
+	}
+
+	updated, _ = m.Update(commands.SetOptionMsg{Name: "preview", Value: "true", Save: true})
+	m = updated.(Model)
+	if !m.showPreview || !strings.Contains(lastToast(m), "session only") {
+		t.Errorf("Expected preview on for this session only, got %v %q", m.showPreview, lastToast(m))
+	}
+}
+
+// TestNowrap_KeepsLinesWholeAndScrollsSideways verifies :set nowrap leaves paragraphs unwrapped and →/← scroll them.
+// BREAKS: If nowrap still reflows, long code-like lines are split and the option does nothing.
+func TestNowrap_KeepsLinesWholeAndScrollsSideways(t *testing.T) {
+	long := strings.Repeat("word ", 40)
+	m := Model{view: "reader", focusedPane: "content", width: 100, height: 40, items: []db.ContentItem{
+		{ID: "a", Title: "One", Summary: long},
+		{ID: "b", Title: "Two"},
+	}}
+	m.updateReaderContent()
+	if m.viewport.TotalLineCount() < 3 {
+		t.Fatalf("Expected the paragraph wrapped by default, got %d lines", m.viewport.TotalLineCount())
+	}
+
+	m.noWrap = true
+	m.updateReaderContent()
+	if m.viewport.TotalLineCount() != 1 {
+		t.Errorf("Expected one unwrapped line, got %d", m.viewport.TotalLineCount())
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = updated.(Model)
+	if m.cursor != 0 || m.viewport.HorizontalScrollPercent() == 0 {
+		t.Errorf("Expected → to scroll sideways, not change article (cursor %d)", m.cursor)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if updated.(Model).cursor != 1 {
+		t.Error("Expected l to still move to the next article")
+	}
+}
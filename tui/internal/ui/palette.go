@@ -0,0 +1,387 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/commands"
+)
+
+// paletteEntry is one selectable row in the command palette
+type paletteEntry struct {
+	kind  string // "view", "command", "source", "item"
+	label string
+	hint  string
+	// What selecting the entry does, depending on kind
+	key         string   // view: hotkey to replay
+	category    string   // view: category filter to apply ("" with key set = hotkey view)
+	line        []string // command: registry name and args
+	args        bool     // command: needs more input, so open : mode pre-filled
+	unavailable string   // command: why the daemon can't run it (greyed out)
+	sourceID    string   // source: filter to this source
+	itemID      string   // item: jump to this item
+}
+
+// paletteSelectedMsg is sent when an entry is chosen with Enter
+type paletteSelectedMsg struct {
+	entry paletteEntry
+}
+
+// paletteCommands describes registry commands for the palette. Commands
+// marked args need input and open : mode pre-filled instead of running.
+var paletteCommands = []struct {
+	line string
+	hint string
+	args bool
+}{
+	{"refresh", "Fetch new content", false},
+	{"mark", "Toggle read on current item", false},
+	{"favorite", "Toggle star on current item", false},
+	{"pin", "Pin current item to the top of every view (again to unpin)", false},
+	{"up", "Upvote current item", false},
+	{"down", "Downvote current item", false},
+	{"open", "Open current item in browser", false},
+	{"download", "Save current paper's PDF", false},
+	{"mirror", "Open archived copy (Wayback Machine)", false},
+	{"mirror today", "Open archived copy (archive.today)", false},
+	{"yank", "Copy URL", false},
+	{"copy", "Copy summary", false},
+	{"copy content", "Copy full content", false},
+	{"task", "Create a task from current item ([task] backend)", false},
+	{"extract", "Deep synthesis of current item", false},
+	{"summarize", "Summarize current item if it has no summary", false},
+	{"ask", "Ask a question about the current item", true},
+	{"fabric", "Run a Fabric pattern", true},
+	{"audio", "Generate audio briefing", false},
+	{"audio medium", "Audio briefing of HIGH and MEDIUM items", false},
+	{"transcript", "Read the latest audio briefing", false},
+	{"listen", "Narrate current item and play it", false},
+	{"listen pause", "Pause or resume narration", false},
+	{"listen stop", "Stop narration", false},
+	{"triage", "Step through unread items with one-key verdicts", false},
+	{"digest", "Today's HIGH items as one document", false},
+	{"digest medium", "Today's HIGH and MEDIUM items", false},
+	{"digest export", "Save today's digest as Markdown", false},
+	{"history", "What you read this week, by day", false},
+	{"history 30", "What you read in the last 30 days", false},
+	{"add", "Add a source", true},
+	{"remove", "Remove a source", true},
+	{"pause", "Pause a source", true},
+	{"resume", "Resume a source", true},
+	{"edit", "Rename a source", true},
+	{"sources rename", "Regex rename across source names (/old/new/)", true},
+	{"sources dedupe", "Find sources pointing at the same feed and merge them", false},
+	{"export sources", "Copy sources to clipboard", false},
+	{"export sources --format=opml", "Write sources as OPML to a file", true},
+	{"export html", "Save the current list as an HTML page", false},
+	{"import newsboat", "Add the feeds of a newsboat urls file", false},
+	{"import miniflux", "Add the feeds of the Miniflux account in config.toml", false},
+	{"backup", "Back up the local database", false},
+	{"restore", "Restore the local database from a backup", true},
+	{"db vacuum", "Reclaim free space and refresh query statistics", false},
+	{"db check", "Check the local database's integrity", false},
+	{"db stats", "Database size by table and index", false},
+	{"filter", "Filter by field (since=2d) or expression (priority=high AND NOT read)", true},
+	{"archived", "Toggle archived view", false},
+	{"context review", "Accept or dismiss flagged items' entities as topics", false},
+	{"context suggest", "Suggest topics from flagged items", false},
+	{"context edit", "Edit context.md without leaving prismis", false},
+	{"context edit!", "Open context.md in $EDITOR", false},
+	{"context add", "Add an entity of this article to context.md", false},
+	{"unprioritized", "Count unprioritized items", false},
+	{"prune", "Delete unprioritized items", false},
+	{"markall", "Mark every unread item in the list read", false},
+	{"markall source", "Mark a source's unread items read", true},
+	{"markall older", "Mark unread items older than an age read", true},
+	{"markall undo", "Mark the last batch unread again", false},
+	{"theme", "Cycle color theme", false},
+	{"set preview!", "Toggle the preview pane", false},
+	{"set sidebar!", "Toggle the sources sidebar", false},
+	{"set linkcheck!", "Toggle dead-link and paywall icons", false},
+	{"set hyperlinks!", "Toggle clickable titles and URLs", false},
+	{"set density=compact", "One line per item", false},
+	{"set markread=", "Auto mark read: open, end, 10s, never", true},
+	{"set density=comfortable", "Two lines per item", false},
+	{"set showall!", "Toggle listing read items", false},
+	{"set wrap!", "Toggle reader line wrapping", false},
+	{"set refresh=", "Auto-refresh interval in seconds (0 turns it off)", true},
+	{"set", "Set an option (e.g. preview, sidebar); --save writes config", true},
+	{"sort", "Sort this view (e.g. priority,date desc)", true},
+	{"search", "Run a saved search (:search save <name> <expression> keeps one)", true},
+	{"sort default", "Sort this view by date again", false},
+	{"find", "Find any item by title", false},
+	{"zen", "Distraction-free reading", false},
+	{"tag", "Tag the current item (e.g. rust,career; -rust removes)", true},
+	{"snooze", "Hide the current item until later (3h, tomorrow, monday)", true},
+	{"remind", "Calendar reminder about the current item (2026-11-01, friday, 3d)", true},
+	{"messages", "Recent notifications", false},
+	{"errors", "Failed operations, with retry", false},
+	{"sync status", "Last remote sync and read/favorite conflicts", false},
+	{"sync full", "Refetch everything from the daemon and report what changed", false},
+	{"logs", "Daemon logs", false},
+	{"help", "Keyboard shortcuts", false},
+	{"quit", "Exit prismis", false},
+}
+
+// paletteViews are the built-in views, selected by replaying their hotkey
+var paletteViews = []struct {
+	label string
+	key   string
+}{
+	{"HIGH priority", "1"},
+	{"MEDIUM priority", "2"},
+	{"LOW priority", "3"},
+	{"Favorites", "4"},
+	{"Interesting (upvoted)", "6"},
+	{"Unprioritized", "0"},
+	{"All priorities", "a"},
+	{"Toggle unread / all", "u"},
+	{"Toggle archived", "v"},
+	{"Toggle upvoted", "i"},
+	{"Toggle date sort", "d"},
+	{"Cycle source type", "s"},
+	{"Reset filters", "R"},
+	{"Source manager", "S"},
+	{"Keyboard shortcuts", "?"},
+}
+
+// paletteEntries lists everything the palette can do for the current model:
+// views first, then commands, then sources
+func (m Model) paletteEntries() []paletteEntry {
+	var entries []paletteEntry
+	for _, v := range paletteViews {
+		entries = append(entries, paletteEntry{kind: "view", label: v.label, hint: v.key, key: v.key})
+	}
+
+	// Category views from the configured sources
+	seen := make(map[string]bool)
+	for _, source := range m.sources {
+		category := strings.ToLower(source.Category)
+		if category == "" || seen[category] {
+			continue
+		}
+		seen[category] = true
+		entries = append(entries, paletteEntry{kind: "view", label: "Category: " + source.Category, hint: ":filter", category: source.Category})
+	}
+
+	// Described commands, then any registry command without a description
+	described := make(map[string]bool)
+	for _, c := range paletteCommands {
+		line := strings.Fields(c.line)
+		described[line[0]] = true
+		entry := paletteEntry{kind: "command", label: ":" + c.line, hint: c.hint, line: line, args: c.args}
+		if err := m.missingFeature(commandFeature(line)); err != nil {
+			entry.hint = unavailableHint(err)
+			entry.unavailable = err.Error()
+		}
+		entries = append(entries, entry)
+	}
+	names := commands.NewRegistry().GetCommands()
+	sort.Strings(names)
+	for _, name := range names {
+		if !described[name] {
+			entries = append(entries, paletteEntry{kind: "command", label: ":" + name, line: []string{name}})
+		}
+	}
+
+	// Saved searches run straight from the palette
+	for _, name := range m.searchNames() {
+		entries = append(entries, paletteEntry{kind: "command", label: "Search: " + name, hint: m.savedSearches[name], line: []string{"search", name}})
+	}
+
+	for _, source := range m.sources {
+		label := source.Name
+		if label == "" {
+			label = source.URL
+		}
+		hint := source.Type
+		if source.UnreadCount > 0 {
+			hint = fmt.Sprintf("%s · %d unread", source.Type, source.UnreadCount)
+		}
+		entries = append(entries, paletteEntry{kind: "source", label: label, hint: hint, sourceID: source.ID})
+	}
+	return entries
+}
+
+// filterPalette returns the entries matching query: prefix matches first,
+// then substring, then fuzzy; ties keep their original order
+func filterPalette(entries []paletteEntry, query string) []paletteEntry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return entries
+	}
+
+	rank := func(label string) int {
+		label = strings.ToLower(strings.TrimPrefix(label, ":"))
+		switch {
+		case strings.HasPrefix(label, query):
+			return 0
+		case strings.Contains(label, query):
+			return 1
+		case fuzzyMatch(query, label):
+			return 2
+		default:
+			return -1
+		}
+	}
+
+	var buckets [3][]paletteEntry
+	for _, e := range entries {
+		if r := rank(e.label); r >= 0 {
+			buckets[r] = append(buckets[r], e)
+		}
+	}
+	return append(append(buckets[0], buckets[1]...), buckets[2]...)
+}
+
+// CommandPalette is the fuzzy picker behind Ctrl-P (views, commands, sources)
+// and Ctrl-T (item titles)
+type CommandPalette struct {
+	Modal   // Embed base modal
+	input   textinput.Model
+	entries []paletteEntry
+	matches []paletteEntry
+	cursor  int
+}
+
+// NewCommandPalette creates a new CommandPalette instance
+func NewCommandPalette() CommandPalette {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.CharLimit = 100
+
+	return CommandPalette{
+		Modal: NewModal("", 60, 16), // Will be sized dynamically
+		input: ti,
+	}
+}
+
+// SetSize updates the palette size based on terminal dimensions
+func (p *CommandPalette) SetSize(width, height int) {
+	paletteWidth := min(70, width-4)
+	paletteHeight := min(18, height-6)
+	p.Modal.width = max(30, paletteWidth)
+	p.Modal.height = max(6, paletteHeight)
+	p.input.Width = p.Modal.width - 6
+}
+
+// Open shows the palette over entries, starting from query
+func (p *CommandPalette) Open(placeholder, query string, entries []paletteEntry) {
+	p.entries = entries
+	p.matches = filterPalette(entries, query)
+	p.cursor = 0
+	p.input.Placeholder = placeholder
+	p.input.SetValue(query)
+	p.input.CursorEnd()
+	p.input.Focus()
+	p.Show()
+}
+
+// Close hides the palette
+func (p *CommandPalette) Close() {
+	p.input.Blur()
+	p.Hide()
+}
+
+// Update handles input for the palette
+func (p CommandPalette) Update(msg tea.Msg) (CommandPalette, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			p.Close()
+			return p, nil
+		case "enter":
+			p.Close()
+			if len(p.matches) == 0 {
+				return p, nil
+			}
+			entry := p.matches[p.cursor]
+			return p, func() tea.Msg { return paletteSelectedMsg{entry: entry} }
+		case "up", "ctrl+p", "ctrl+k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+			return p, nil
+		case "down", "ctrl+n", "ctrl+j":
+			if p.cursor < len(p.matches)-1 {
+				p.cursor++
+			}
+			return p, nil
+		}
+
+	case tea.WindowSizeMsg:
+		p.SetSize(msg.Width, msg.Height)
+		return p, nil
+	}
+
+	var cmd tea.Cmd
+	before := p.input.Value()
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != before {
+		p.matches = filterPalette(p.entries, p.input.Value())
+		p.cursor = 0
+	}
+	return p, cmd
+}
+
+// ViewWithOverlay renders the palette over the dimmed background
+func (p CommandPalette) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !p.visible {
+		return backgroundView
+	}
+
+	lineWidth := p.Modal.width - 4 // Inside padding
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	lineStyle := lipgloss.NewStyle().Width(lineWidth).MaxHeight(1)
+
+	var content strings.Builder
+	content.WriteString(lineStyle.Render(p.input.View()))
+	content.WriteString("\n\n")
+
+	// Input, blank line, and padding take five rows
+	rows := max(1, p.Modal.height-5)
+	start := 0
+	if p.cursor >= rows {
+		start = p.cursor - rows + 1
+	}
+	end := min(len(p.matches), start+rows)
+
+	if len(p.matches) == 0 {
+		content.WriteString(lineStyle.Foreground(theme.Gray).Render("No matches"))
+	}
+	kindStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Gray).Italic(true)
+	for i := start; i < end; i++ {
+		e := p.matches[i]
+		labelStyle := lipgloss.NewStyle().Foreground(theme.White)
+		marker := "  "
+		if i == p.cursor {
+			labelStyle = labelStyle.Foreground(theme.Cyan).Bold(true)
+			marker = lipgloss.NewStyle().Foreground(theme.Cyan).Render("▸ ")
+		}
+		if e.unavailable != "" {
+			labelStyle = labelStyle.Foreground(theme.Gray)
+		}
+		line := marker + kindStyle.Render(padRight(e.kind, 8)) + labelStyle.Render(e.label)
+		if e.hint != "" {
+			line += "  " + hintStyle.Render(e.hint)
+		}
+		content.WriteString(lineStyle.Render(line))
+		if i < end-1 {
+			content.WriteString("\n")
+		}
+	}
+
+	modal := p.Modal
+	modal.SetContent(content.String())
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestPaletteEntries_IncludesEveryCommand verifies all registry commands are reachable from the palette.
+// BREAKS: If a new command isn't listed, the palette stops being a complete index of what prismis can do.
+func TestPaletteEntries_IncludesEveryCommand(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, e := range (Model{}).paletteEntries() {
+		if e.kind == "command" {
+			listed[e.line[0]] = true
+		}
+	}
+	for _, name := range commands.NewRegistry().GetCommands() {
+		if !listed[name] {
+			t.Errorf("Command %q missing from palette", name)
+		}
+	}
+}
+
+// TestFilterPalette_RanksPrefixFirst verifies prefix matches sort ahead of fuzzy ones.
+// BREAKS: If ranking is lost, typing "ref" buries :refresh under unrelated fuzzy hits.
+func TestFilterPalette_RanksPrefixFirst(t *testing.T) {
+	entries := []paletteEntry{
+		{kind: "source", label: "Rust Evolution Feed"},
+		{kind: "command", label: ":refresh"},
+		{kind: "view", label: "HIGH priority"},
+	}
+
+	got := filterPalette(entries, "ref")
+	if len(got) != 2 || got[0].label != ":refresh" || got[1].label != "Rust Evolution Feed" {
+		t.Errorf("Expected :refresh then fuzzy source match, got %+v", got)
+	}
+}
+
+// TestPaletteSelected_SourceFiltersFeed verifies picking a source narrows the feed to it.
+// BREAKS: If the source ID isn't resolved, selecting a source silently does nothing.
+func TestPaletteSelected_SourceFiltersFeed(t *testing.T) {
+	m := Model{view: "list", sources: []db.Source{{ID: "src-1", Name: "Hacker News"}}}
+
+	updated, _ := m.Update(paletteSelectedMsg{entry: paletteEntry{kind: "source", sourceID: "src-1"}})
+	m = updated.(Model)
+	if m.filterSource != "src-1" {
+		t.Errorf("Expected source filter src-1, got %q", m.filterSource)
+	}
+
+	items := applyFiltersClientSide([]db.ContentItem{
+		{ID: "a", SourceID: "src-1", Priority: "high"},
+		{ID: "b", SourceID: "src-2", Priority: "high"},
+	}, m)
+	if len(items) != 1 || items[0].ID != "a" {
+		t.Errorf("Expected only src-1 items, got %+v", items)
+	}
+}
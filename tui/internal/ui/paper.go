@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+var (
+	// arXiv listings put authors and the abstract on labelled lines
+	paperAuthorsPattern  = regexp.MustCompile(`(?mi)^\s*Authors?:\s*(.+)$`)
+	paperAbstractPattern = regexp.MustCompile(`(?is)\bAbstract:\s*(.+?)(?:\n\s*\n|$)`)
+	// Old-style arXiv IDs carry an archive prefix (hep-th/9901001)
+	arxivIDPattern = regexp.MustCompile(`^/(?:abs|pdf)/(.+?)(?:\.pdf)?$`)
+)
+
+// isArxiv reports whether rawURL points at an arXiv paper page
+func isArxiv(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return host == "arxiv.org" || host == "export.arxiv.org"
+}
+
+// isPaper reports whether an item is a paper: an arXiv entry or a direct PDF link
+func isPaper(item db.ContentItem) bool {
+	if isArxiv(item.URL) {
+		return true
+	}
+	u, err := url.Parse(item.URL)
+	return err == nil && strings.HasSuffix(strings.ToLower(u.Path), ".pdf")
+}
+
+// paperPDFURL returns the URL of an item's PDF, turning arXiv abstract pages
+// into their /pdf/ counterpart
+func paperPDFURL(item db.ContentItem) string {
+	u, err := url.Parse(item.URL)
+	if err != nil || !isArxiv(item.URL) {
+		return item.URL
+	}
+	if m := arxivIDPattern.FindStringSubmatch(u.Path); m != nil {
+		u.Path = "/pdf/" + m[1]
+		u.RawQuery = ""
+		u.Fragment = ""
+	}
+	return u.String()
+}
+
+// paperFilename names a downloaded paper after its arXiv ID or the PDF's own name
+func paperFilename(item db.ContentItem) string {
+	u, err := url.Parse(item.URL)
+	if err != nil {
+		return "paper.pdf"
+	}
+	if isArxiv(item.URL) {
+		if m := arxivIDPattern.FindStringSubmatch(u.Path); m != nil {
+			return "arxiv-" + strings.ReplaceAll(m[1], "/", "-") + ".pdf"
+		}
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		return "paper.pdf"
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ".pdf") {
+		name += ".pdf"
+	}
+	return name
+}
+
+// paperMeta pulls the authors and abstract out of a paper's fetched text
+func paperMeta(item db.ContentItem) (authors, abstract string) {
+	for _, text := range []string{item.Content, item.Summary} {
+		if authors == "" {
+			if m := paperAuthorsPattern.FindStringSubmatch(text); m != nil {
+				authors = strings.TrimSpace(m[1])
+			}
+		}
+		if abstract == "" {
+			if m := paperAbstractPattern.FindStringSubmatch(text); m != nil {
+				abstract = strings.Join(strings.Fields(m[1]), " ")
+			}
+		}
+	}
+	return authors, abstract
+}
+
+// renderPaperSection shows a paper's authors and abstract above its summary
+func renderPaperSection(item db.ContentItem) string {
+	if !isPaper(item) {
+		return ""
+	}
+	authors, abstract := paperMeta(item)
+	if authors == "" && abstract == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("## Paper\n\n")
+	if authors != "" {
+		b.WriteString("Authors: " + authors + "\n\n")
+	}
+	if abstract != "" {
+		b.WriteString(abstract + "\n\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestPaper_DetectsArxivAndPDFLinks verifies which items count as papers and where their PDFs live.
+// BREAKS: If arXiv abstract pages aren't mapped to /pdf/, the PDF viewer is handed an HTML page.
+func TestPaper_DetectsArxivAndPDFLinks(t *testing.T) {
+	cases := []struct {
+		url, pdf, file string
+		paper          bool
+	}{
+		{"https://arxiv.org/abs/2401.01234v2", "https://arxiv.org/pdf/2401.01234v2", "arxiv-2401.01234v2.pdf", true},
+		{"http://export.arxiv.org/abs/hep-th/9901001", "http://export.arxiv.org/pdf/hep-th/9901001", "arxiv-hep-th-9901001.pdf", true},
+		{"https://example.com/papers/attention.PDF?dl=1", "https://example.com/papers/attention.PDF?dl=1", "attention.PDF", true},
+		{"https://example.com/blog/post", "https://example.com/blog/post", "", false},
+	}
+	for _, c := range cases {
+		item := db.ContentItem{URL: c.url}
+		if isPaper(item) != c.paper {
+			t.Errorf("%s: expected isPaper=%v", c.url, c.paper)
+			continue
+		}
+		if !c.paper {
+			continue
+		}
+		if got := paperPDFURL(item); got != c.pdf {
+			t.Errorf("%s: expected PDF URL %s, got %s", c.url, c.pdf, got)
+		}
+		if got := paperFilename(item); got != c.file {
+			t.Errorf("%s: expected filename %s, got %s", c.url, c.file, got)
+		}
+	}
+}
+
+// TestPaper_ReaderShowsAuthorsAndAbstract verifies the reader puts a paper's authors and abstract first.
+// BREAKS: If the labelled lines aren't parsed, arXiv items open on the LLM summary with no byline.
+func TestPaper_ReaderShowsAuthorsAndAbstract(t *testing.T) {
+	item := db.ContentItem{
+		URL:     "https://arxiv.org/abs/2401.01234",
+		Content: "arXiv:2401.01234v1 Announce Type: new\nAbstract: We study\nsparse attention.\n\nAuthors: Ada Lovelace, Alan Turing",
+	}
+	section := renderPaperSection(item)
+	for _, want := range []string{"## Paper", "Authors: Ada Lovelace, Alan Turing", "We study sparse attention."} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected %q in:\n%s", want, section)
+		}
+	}
+
+	item.URL = "https://example.com/post"
+	if renderPaperSection(item) != "" {
+		t.Error("Expected no paper section for ordinary articles")
+	}
+}
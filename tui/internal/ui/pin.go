@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// topRank orders the head of the list: pinned items first, then items
+// back from a snooze, then everything else
+func topRank(item db.ContentItem, now time.Time) int {
+	switch {
+	case item.Pinned:
+		return 0
+	case wokeFromSnooze(item, now):
+		return 1
+	}
+	return 2
+}
+
+// floatToTop moves pinned and woken items to the top, keeping the sort
+// order within each group
+func floatToTop(items []db.ContentItem, now time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return topRank(items[i], now) < topRank(items[j], now)
+	})
+}
+
+// pinned records a finished :pin on the item in both the list and the
+// remote cache, then refreshes so it moves to (or leaves) the top
+func (m *Model) pinned(msg operations.ArticlePinnedMsg) tea.Cmd {
+	if !msg.Success {
+		return m.notify(toastError, fmt.Sprintf("Failed to pin: %v", msg.Error), 5*time.Second)
+	}
+	for i := range m.items {
+		if m.items[i].ID == msg.ID {
+			m.items[i].Pinned = msg.Pinned
+		}
+	}
+	for i := range m.itemsCache {
+		if m.itemsCache[i].ID == msg.ID {
+			m.itemsCache[i].Pinned = msg.Pinned
+		}
+	}
+
+	text := "Unpinned"
+	if msg.Pinned {
+		text = "⚑ Pinned to top"
+	}
+	cmds := []tea.Cmd{func() tea.Msg { return commands.RefreshMsg{PreserveCursor: true} }}
+	if msg.Queued {
+		text += " (offline, will sync)"
+		cmds = append(cmds, operations.CountPendingWrites())
+	}
+	return tea.Batch(append(cmds, m.notify(toastSuccess, text, 2*time.Second))...)
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestPinFilter_KeepsPinnedAtTopOfEveryView verifies pinned items lead the list even outside their view.
+// BREAKS: If the priority or read filters apply, a pinned MEDIUM item vanishes from the HIGH tab once read.
+func TestPinFilter_KeepsPinnedAtTopOfEveryView(t *testing.T) {
+	now := time.Now()
+	items := []db.ContentItem{
+		{ID: "new", Priority: "high", Published: now.Add(-time.Hour)},
+		{ID: "woke", Priority: "high", Published: now.AddDate(0, 0, -2), SnoozedUntil: now.Add(-time.Minute)},
+		{ID: "pinned", Priority: "medium", Read: true, Published: now.AddDate(0, 0, -9), Pinned: true},
+		{ID: "medium", Priority: "medium", Published: now},
+	}
+	m := Model{priority: "high", filterType: "all", sortNewest: true}
+
+	var ids []string
+	for _, item := range applyFiltersClientSide(items, m) {
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "pinned,woke,new" {
+		t.Errorf("Expected [pinned woke new], got %v", ids)
+	}
+
+	// Source filters still narrow pinned items
+	m.filterType = "reddit"
+	if got := applyFiltersClientSide(items, m); len(got) != 0 {
+		t.Errorf("Expected the type filter to hide everything, got %v", got)
+	}
+}
+
+// TestArticlePinned_UpdatesItemAndMarker verifies a pin result lands on both copies and shows the marker.
+// BREAKS: If the cache isn't updated, remote mode re-sorts the stale copy and the pin appears to do nothing.
+func TestArticlePinned_UpdatesItemAndMarker(t *testing.T) {
+	m := Model{view: "list", items: []db.ContentItem{{ID: "a", Title: "Working notes"}}, itemsCache: []db.ContentItem{{ID: "a"}}}
+
+	updated, cmd := m.Update(operations.ArticlePinnedMsg{ID: "a", Pinned: true, Success: true})
+	m = updated.(Model)
+	if !m.items[0].Pinned || !m.itemsCache[0].Pinned || cmd == nil {
+		t.Fatalf("Expected the pin on both copies and a refresh, got %+v / %+v", m.items[0], m.itemsCache[0])
+	}
+
+	m.width, m.height, m.theme = 120, 30, CleanCyberTheme
+	if out := renderContentList(m, 100, 20, CleanCyberTheme); !strings.Contains(out, "⚑") {
+		t.Errorf("Expected the pin marker, got:\n%s", out)
+	}
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// audioPlayers are tried in order; the first one on PATH plays :listen audio
+var audioPlayers = []struct {
+	name string
+	args []string
+}{
+	{"mpv", []string{"--no-video", "--really-quiet"}},
+	{"ffplay", []string{"-nodisp", "-autoexit", "-loglevel", "quiet"}},
+	{"afplay", nil},
+	{"mpg123", []string{"-q"}},
+}
+
+// errNoAudioPlayer means none of audioPlayers is installed
+var errNoAudioPlayer = errors.New("no audio player found (install mpv, ffplay, afplay, or mpg123)")
+
+// playerDoneMsg reports that a playback process exited
+type playerDoneMsg struct {
+	gen int // Which playback ended; stale ones are ignored
+	err error
+}
+
+// audioPlayer plays one file at a time in a background player process, so
+// playback continues while the user keeps reading. The Model holds it by
+// pointer since it owns a running process.
+type audioPlayer struct {
+	cmd    *exec.Cmd
+	title  string
+	paused bool
+	gen    int // Bumped on every play/stop so old exits can be told apart
+}
+
+// play stops anything playing and starts path, returning the command that
+// reports when playback ends
+func (p *audioPlayer) play(path, title string) (tea.Cmd, error) {
+	p.stop()
+
+	for _, player := range audioPlayers {
+		bin, err := exec.LookPath(player.name)
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(bin, append(append([]string{}, player.args...), path)...)
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start %s: %w", player.name, err)
+		}
+		p.cmd = cmd
+		p.title = title
+		p.paused = false
+		gen := p.gen
+		return func() tea.Msg {
+			return playerDoneMsg{gen: gen, err: cmd.Wait()}
+		}, nil
+	}
+	return nil, errNoAudioPlayer
+}
+
+// togglePause pauses or resumes playback
+func (p *audioPlayer) togglePause() error {
+	if !p.playing() {
+		return errors.New("nothing is playing")
+	}
+	if err := pauseProcess(p.cmd.Process, !p.paused); err != nil {
+		return err
+	}
+	p.paused = !p.paused
+	return nil
+}
+
+// stop ends playback; safe to call on a nil or idle player
+func (p *audioPlayer) stop() {
+	if !p.playing() {
+		return
+	}
+	p.cmd.Process.Kill()
+	p.finished(p.gen)
+}
+
+// finished clears the player if gen is still the current playback
+func (p *audioPlayer) finished(gen int) bool {
+	if p == nil || gen != p.gen || p.cmd == nil {
+		return false
+	}
+	p.cmd = nil
+	p.paused = false
+	p.gen++
+	return true
+}
+
+// playing reports whether a playback process is running
+func (p *audioPlayer) playing() bool {
+	return p != nil && p.cmd != nil
+}
+
+// status is the status-bar indicator, e.g. "♪ Async Rust" or "⏸ Async Rust"
+func (p *audioPlayer) status() string {
+	if !p.playing() {
+		return ""
+	}
+	icon := "♪"
+	if p.paused {
+		icon = "⏸"
+	}
+	return icon + " " + truncate(strings.TrimSpace(p.title), 40)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestAudioPlayer_PlayPauseStop verifies the player runs in the background, pauses,
+// and that a stopped playback's exit is ignored.
+// BREAKS: :listen blocks the TUI, keeps playing after :listen stop, or an old
+// playback's exit clears the indicator of the one that replaced it.
+func TestAudioPlayer_PlayPauseStop(t *testing.T) {
+	saved := audioPlayers
+	defer func() { audioPlayers = saved }()
+	// sleep stands in for mpv: it runs until killed when given a long "file"
+	audioPlayers = []struct {
+		name string
+		args []string
+	}{{"sleep", nil}}
+
+	p := &audioPlayer{}
+	done, err := p.play("30", "Async Rust")
+	if err != nil {
+		t.Skipf("sleep unavailable: %v", err)
+	}
+	if !p.playing() || p.status() != "♪ Async Rust" {
+		t.Fatalf("Expected playback to start, status %q", p.status())
+	}
+
+	if err := p.togglePause(); err != nil {
+		t.Fatalf("togglePause: %v", err)
+	}
+	if !strings.HasPrefix(p.status(), "⏸") {
+		t.Errorf("Expected a paused indicator, got %q", p.status())
+	}
+
+	p.stop()
+	exited := done().(playerDoneMsg)
+	if p.playing() || p.finished(exited.gen) {
+		t.Error("Expected stop to end playback and its exit to be ignored")
+	}
+	if err := p.togglePause(); err == nil {
+		t.Error("Expected pausing with nothing playing to fail")
+	}
+}
+
+// TestAudioPlayer_NoPlayerInstalled verifies a clear error when no player binary exists.
+// BREAKS: :listen fails silently after paying for TTS.
+func TestAudioPlayer_NoPlayerInstalled(t *testing.T) {
+	saved := audioPlayers
+	defer func() { audioPlayers = saved }()
+	audioPlayers = []struct {
+		name string
+		args []string
+	}{{"prismis-no-such-player", nil}}
+
+	var p *audioPlayer
+	p.stop() // A nil player is idle, not a panic
+	if p.status() != "" {
+		t.Error("Expected no indicator without a player")
+	}
+	if _, err := (&audioPlayer{}).play("a.mp3", "x"); !errors.Is(err, errNoAudioPlayer) {
+		t.Errorf("Expected errNoAudioPlayer, got %v", err)
+	}
+}
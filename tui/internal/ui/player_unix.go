@@ -0,0 +1,16 @@
+//go:build !windows
+
+package ui
+
+import (
+	"os"
+	"syscall"
+)
+
+// pauseProcess suspends or resumes the player process with job-control signals
+func pauseProcess(proc *os.Process, pause bool) error {
+	if pause {
+		return proc.Signal(syscall.SIGSTOP)
+	}
+	return proc.Signal(syscall.SIGCONT)
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package ui
+
+import (
+	"errors"
+	"os"
+)
+
+// pauseProcess is unsupported on Windows, which has no job-control signals
+func pauseProcess(proc *os.Process, pause bool) error {
+	return errors.New("pausing is not supported on Windows; use :listen stop")
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// readerPrefetchedMsg carries the reader's neighbours rendered in the
+// background, with any that had to be loaded in full
+type readerPrefetchedMsg struct {
+	items   []db.ContentItem
+	renders readerRenderCache
+}
+
+// prefetchNeighbours loads and renders the articles either side of the
+// reader's in the background, so h/l shows them without rendering first.
+// It runs once per article and width.
+func (m *Model) prefetchNeighbours() tea.Cmd {
+	if m.view != "reader" || m.cursor < 0 || m.cursor >= len(m.items) {
+		return nil
+	}
+	width := m.layout().contentWidth - 4
+	at := fmt.Sprintf("%s@%d", m.items[m.cursor].ID, width)
+	if m.prefetchedAt == at {
+		return nil
+	}
+	m.prefetchedAt = at
+
+	var neighbours []db.ContentItem
+	for _, i := range []int{m.cursor + 1, m.cursor - 1} {
+		if i >= 0 && i < len(m.items) {
+			neighbours = append(neighbours, m.items[i])
+		}
+	}
+	if len(neighbours) == 0 {
+		return nil
+	}
+	snapshot := *m
+	return func() tea.Msg {
+		msg := readerPrefetchedMsg{renders: make(readerRenderCache)}
+		for _, item := range neighbours {
+			if item.Partial {
+				full, err := loadItem(context.Background(), snapshot.remoteURL, item.ID)
+				if err != nil {
+					// The reader loads it (and reports the error) if it's opened
+					continue
+				}
+				item = withBody(item, full)
+				msg.items = append(msg.items, item)
+			}
+			key, markdown := snapshot.readerKey(item, width)
+			msg.renders.put(key, markdown, renderReaderMarkdown(key, markdown))
+		}
+		return msg
+	}
+}
+
+// handleReaderPrefetched keeps the neighbours' renders and loaded bodies
+func (m *Model) handleReaderPrefetched(msg readerPrefetchedMsg) {
+	for _, item := range msg.items {
+		m.fillItem(item)
+	}
+	for key, render := range msg.renders {
+		m.readerRenders = m.readerRenders.put(key, render.markdown, render.rendered)
+	}
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestPrefetchNeighbours_RendersBothSides verifies the articles either side of the reader's are rendered ahead and used on h/l.
+// BREAKS: If the renders are keyed or looked up wrongly, every h/l re-renders and long articles lag.
+func TestPrefetchNeighbours_RendersBothSides(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{
+		{ID: "1", Title: "First", Content: "First body"},
+		{ID: "2", Title: "Second", Content: "Second body"},
+		{ID: "3", Title: "Third", Content: "Third body"},
+	})
+	m.view = "reader"
+	m.focusedPane = "content"
+	m.cursor = 1
+	m.updateReaderContent()
+
+	prefetch := m.prefetchNeighbours()
+	if prefetch == nil {
+		t.Fatal("Expected a prefetch for the neighbours")
+	}
+	if again := m.prefetchNeighbours(); again != nil {
+		t.Error("Expected no second prefetch for the same article")
+	}
+	updated, _ := m.Update(prefetch())
+	m = updated.(Model)
+	for _, i := range []int{0, 2} {
+		key, markdown := m.readerKey(m.items[i], m.viewport.Width)
+		if _, ok := m.readerRenders.get(key, markdown); !ok {
+			t.Fatalf("Expected article %d rendered ahead", i+1)
+		}
+	}
+
+	// Swap in a marker so the test can tell the cached render was used
+	key, markdown := m.readerKey(m.items[2], m.viewport.Width)
+	m.readerRenders.put(key, markdown, "prefetched render")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	if m.cursor != 2 || !strings.Contains(m.viewport.View(), "prefetched render") {
+		t.Errorf("Expected the prefetched render for the next article, got cursor %d: %q", m.cursor, m.viewport.View())
+	}
+}
@@ -1,95 +1,51 @@
 package ui
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
-)
-
-// ContentMetadata represents the metadata extracted from analysis JSON
-type ContentMetadata struct {
-	Entities       []string `json:"entities"`
-	Quotes         []string `json:"quotes"`
-	Tools          []string `json:"tools"`
-	URLs           []string `json:"urls"`
-	DeepExtraction *DeepExtraction
-}
 
-// DeepExtraction represents second-tier LLM synthesis for HIGH-priority items
-type DeepExtraction struct {
-	Synthesis   string   `json:"synthesis"`
-	Quotables   []string `json:"quotables"`
-	Model       string   `json:"model"`
-	ExtractedAt string   `json:"extracted_at"`
-}
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+)
 
-// parseMetadata extracts metadata from the Analysis JSON field
-func parseMetadata(analysisJSON string) ContentMetadata {
-	if analysisJSON == "" {
-		return ContentMetadata{}
+// renderWhySection explains what the LLM keyed on when prioritizing the item.
+// Collapsed it names the matched interests; expanded (w) it adds the reasoning.
+func renderWhySection(analysis *db.Analysis, expanded bool) string {
+	if len(analysis.MatchedInterests) == 0 && analysis.PriorityReasoning == "" {
+		return ""
 	}
 
-	var analysis map[string]interface{}
-	if err := json.Unmarshal([]byte(analysisJSON), &analysis); err != nil {
-		return ContentMetadata{}
+	var b strings.Builder
+	b.WriteString("## Why\n\n")
+	if len(analysis.MatchedInterests) > 0 {
+		b.WriteString("Matched: " + strings.Join(analysis.MatchedInterests, ", ") + "\n\n")
 	}
-
-	metadata := ContentMetadata{}
-
-	// Extract entities (these are the "topics")
-	if entities, ok := analysis["entities"].([]interface{}); ok {
-		for _, entity := range entities {
-			if str, ok := entity.(string); ok {
-				metadata.Entities = append(metadata.Entities, str)
-			}
+	if analysis.PriorityReasoning != "" {
+		if expanded {
+			b.WriteString(analysis.PriorityReasoning + "\n\n")
+		} else {
+			b.WriteString("Press w for the reasoning.\n\n")
 		}
 	}
+	return b.String()
+}
 
-	// Extract quotes
-	if quotes, ok := analysis["quotes"].([]interface{}); ok {
-		for _, quote := range quotes {
-			if str, ok := quote.(string); ok {
-				metadata.Quotes = append(metadata.Quotes, str)
-			}
-		}
+// whyHint is the one-line list hint for HIGH items: the matched interests,
+// or the start of the reasoning when the LLM named none
+func whyHint(analysis *db.Analysis) string {
+	if len(analysis.MatchedInterests) > 0 {
+		return "why: " + strings.Join(analysis.MatchedInterests, ", ")
 	}
-
-	// Extract tools
-	if tools, ok := analysis["tools"].([]interface{}); ok {
-		for _, tool := range tools {
-			if str, ok := tool.(string); ok {
-				metadata.Tools = append(metadata.Tools, str)
-			}
-		}
+	if analysis.PriorityReasoning != "" {
+		return "why: " + truncate(analysis.PriorityReasoning, 50)
 	}
-
-	// Extract URLs
-	if urls, ok := analysis["urls"].([]interface{}); ok {
-		for _, url := range urls {
-			if str, ok := url.(string); ok {
-				metadata.URLs = append(metadata.URLs, str)
-			}
-		}
-	}
-
-	// Extract deep_extraction (HIGH-priority synthesis from second-tier LLM call)
-	// Round-trip through json.Marshal/Unmarshal to convert map[string]interface{} into typed struct.
-	if deRaw, ok := analysis["deep_extraction"]; ok && deRaw != nil {
-		if deBytes, err := json.Marshal(deRaw); err == nil {
-			var de DeepExtraction
-			if err := json.Unmarshal(deBytes, &de); err == nil && de.Synthesis != "" {
-				metadata.DeepExtraction = &de
-			}
-		}
-	}
-
-	return metadata
+	return ""
 }
 
 // appendSynthesisSection appends only the "Deep Synthesis" prose. Quotables are no
 // longer emitted here — they're folded into the single unified Quotes section so the
 // reader doesn't show two separate quote blocks. Returns content unchanged when no synthesis.
-func appendSynthesisSection(content string, de *DeepExtraction) string {
+func appendSynthesisSection(content string, de *db.DeepExtraction) string {
 	if de == nil || de.Synthesis == "" {
 		return content
 	}
@@ -104,7 +60,7 @@ func appendSynthesisSection(content string, de *DeepExtraction) string {
 // combineQuotes merges light article quotes with deep-extraction quotables into one
 // deduplicated list (light first, order preserved). Dedup is by trimmed text so a line
 // surfaced by both the light and deep passes appears once.
-func combineQuotes(light []string, de *DeepExtraction) []string {
+func combineQuotes(light []string, de *db.DeepExtraction) []string {
 	all := append([]string{}, light...)
 	if de != nil {
 		all = append(all, de.Quotables...)
@@ -146,7 +102,7 @@ func appendQuotesSection(content string, quotes []string) string {
 }
 
 // renderMetadata formats metadata as markdown for proper styling
-func renderMetadata(metadata ContentMetadata, width int) string {
+func renderMetadata(metadata *db.Analysis, width int) string {
 	// Only show tools and URLs - quotes are now injected into the reading summary
 	if len(metadata.Tools) == 0 && len(metadata.URLs) == 0 {
 		return ""
@@ -179,6 +135,19 @@ func renderMetadata(metadata ContentMetadata, width int) string {
 	return strings.Join(sections, "\n")
 }
 
+// nowrapScrollStep is how many columns ←/→ scroll the reader with :set nowrap
+const nowrapScrollStep = 8
+
+// unwrappedWidth is the render width that keeps every line of markdown on
+// one line, leaving room for bullets and indents
+func unwrappedWidth(markdown string) int {
+	widest := 0
+	for _, line := range strings.Split(markdown, "\n") {
+		widest = max(widest, ansi.StringWidth(line))
+	}
+	return widest + 8
+}
+
 // updateReaderContent updates the viewport with article content (called from model.go)
 func (m *Model) updateReaderContent() {
 	if m.cursor >= len(m.items) || len(m.items) == 0 {
@@ -188,30 +157,50 @@ func (m *Model) updateReaderContent() {
 
 	item := m.items[m.cursor]
 
-	// Calculate content pane dimensions (same as in RenderList)
-	contentHeight := m.height - 5
-	sidebarWidth := m.width / 4
-	if sidebarWidth < 30 {
-		sidebarWidth = 30
-	}
-	contentWidth := m.width - sidebarWidth - 1
-
 	// Viewport dimensions - account for reader header and metadata
-	m.viewport.Width = contentWidth - 4   // Account for padding
-	m.viewport.Height = contentHeight - 9 // Account for position, title+metadata, tags, divider
+	l := m.layout()
+	m.viewport.Width = l.contentWidth - 4   // Account for padding
+	m.viewport.Height = l.contentHeight - 9 // Account for position, title+metadata, tags, divider
+
+	// Rendering is the slow part: reuse this article's last render, or the
+	// one prefetchNeighbours made, while its markdown is unchanged
+	key, markdown := m.readerKey(item, m.viewport.Width)
+	contentToShow, ok := m.readerRenders.get(key, markdown)
+	if !ok {
+		contentToShow = renderReaderMarkdown(key, markdown)
+		m.readerRenders = m.readerRenders.put(key, markdown, contentToShow)
+	}
+
+	// Set the viewport content, keeping the offset when re-rendering the same
+	// article and otherwise returning to where this one was last left
+	m.rememberScroll()
+	offset := m.scrolls.get(item.ID)
+	m.viewport.SetContent(contentToShow)
+	m.viewport.SetYOffset(offset)
+	if !m.noWrap || item.ID != m.readerItemID {
+		m.viewport.SetXOffset(0)
+	}
+	m.readerItemID = item.ID
+}
 
-	// Parse metadata once for use throughout
-	metadata := parseMetadata(item.Analysis)
+// readerKey assembles the markdown the reader shows for item at width
+// (summary or content, synthesis, quotes, and the metadata sections) and
+// the key its render is cached under
+func (m Model) readerKey(item db.ContentItem, width int) (readerRenderKey, string) {
+	metadata := item.ParsedAnalysis()
 
-	// Try to extract reading_summary from Analysis JSON (often has richer content)
+	// Prefer reading_summary from the analysis (often has richer content)
 	var contentToShow string
-	readingSummary := extractReadingSummary(item.Analysis)
+	readingSummary := metadata.ReadingSummary
 
 	if readingSummary != "" {
 		contentToShow = readingSummary
 	} else if item.Content != "" {
 		// Use the full article content
 		contentToShow = item.Content
+	} else if item.Partial && item.ContentLength > 0 {
+		// Still coming from the daemon (see loadOpenItem)
+		contentToShow = "Loading article…"
 	} else if item.Summary != "" {
 		// Fall back to summary
 		contentToShow = item.Summary
@@ -243,18 +232,20 @@ func (m *Model) updateReaderContent() {
 		}
 	}
 
+	// Why it was prioritized sits on top so it's seen before the summary,
+	// then a paper's authors and abstract
+	contentToShow = renderWhySection(metadata, m.showWhy) + renderPaperSection(item) + contentToShow
+
 	// Append remaining metadata (tools/links) BEFORE markdown rendering
-	metadataSection := renderMetadata(metadata, m.viewport.Width)
+	metadataSection := renderMetadata(metadata, width)
 	if metadataSection != "" {
 		contentToShow += metadataSection
 	}
 
 	// Render our simple markdown format ourselves for proper wrapping
-	contentToShow = renderSimpleMarkdown(contentToShow, m.viewport.Width)
-
-	// Set the viewport content
-	m.viewport.SetContent(contentToShow)
-
-	// Reset viewport to top
-	m.viewport.GotoTop()
+	if m.noWrap {
+		width = max(width, unwrappedWidth(contentToShow))
+	}
+	key := readerRenderKey{id: item.ID, width: width, theme: m.theme.Name, hyperlinks: m.hyperlinks}
+	return key, contentToShow
 }
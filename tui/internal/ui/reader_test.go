@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/nickpending/prismis/internal/commands"
 	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
 )
 
 // TestReaderView tests that reader view displays content correctly
@@ -68,57 +70,11 @@ func TestReaderEmptyContent(t *testing.T) {
 	}
 }
 
-// TestParseMetadata_DeepExtraction verifies parseMetadata correctly parses deep_extraction.
-// INV-D1: analysis["deep_extraction"] must be extracted into a typed *DeepExtraction with
-// non-empty Synthesis — if this breaks, deep extraction becomes invisible to every reader user.
-func TestParseMetadata_DeepExtraction(t *testing.T) {
-	analysisJSON := `{
-		"entities": ["Go", "LLM"],
-		"deep_extraction": {
-			"synthesis": "Counterintuitive finding: the obvious conclusion is wrong.",
-			"quotables": ["Revenue grew 22% but profitability fell.", "Key verbatim quote."],
-			"model": "gpt-5-mini",
-			"extracted_at": "2026-04-27T12:00:00+00:00"
-		}
-	}`
-
-	metadata := parseMetadata(analysisJSON)
-
-	if metadata.DeepExtraction == nil {
-		t.Fatal("DeepExtraction should not be nil when analysis contains deep_extraction")
-	}
-	if metadata.DeepExtraction.Synthesis == "" {
-		t.Error("Synthesis must not be empty")
-	}
-	if metadata.DeepExtraction.Synthesis != "Counterintuitive finding: the obvious conclusion is wrong." {
-		t.Errorf("Synthesis mismatch: got %q", metadata.DeepExtraction.Synthesis)
-	}
-	if len(metadata.DeepExtraction.Quotables) != 2 {
-		t.Errorf("Expected 2 quotables, got %d", len(metadata.DeepExtraction.Quotables))
-	}
-	if metadata.DeepExtraction.Model != "gpt-5-mini" {
-		t.Errorf("Model mismatch: got %q", metadata.DeepExtraction.Model)
-	}
-}
-
-// TestParseMetadata_DeepExtractionAbsent verifies DeepExtraction is nil when the key is absent.
-// INV-D1 nil guard: appendSynthesisSection must receive nil and omit the section entirely —
-// an empty synthesis string would produce an empty "## Deep Synthesis" header in the reader.
-func TestParseMetadata_DeepExtractionAbsent(t *testing.T) {
-	analysisJSON := `{"entities": ["Go"], "quotes": ["some quote"]}`
-
-	metadata := parseMetadata(analysisJSON)
-
-	if metadata.DeepExtraction != nil {
-		t.Errorf("DeepExtraction must be nil when deep_extraction key is absent, got %+v", metadata.DeepExtraction)
-	}
-}
-
 // TestAppendSynthesisSection verifies the synthesis prose renders WITHOUT quotables —
 // quotables now live in the unified Quotes section, not under Deep Synthesis.
 // BREAKS: if Notable Lines reappear here, the reader shows two quote blocks again.
 func TestAppendSynthesisSection(t *testing.T) {
-	de := &DeepExtraction{
+	de := &db.DeepExtraction{
 		Synthesis: "Dense synthesis paragraph.",
 		Quotables: []string{"First quotable.", "Second quotable."},
 		Model:     "gpt-5-mini",
@@ -151,7 +107,7 @@ func TestAppendSynthesisSection_NilGuard(t *testing.T) {
 	if result := appendSynthesisSection(base, nil); result != base {
 		t.Errorf("Nil guard: expected content unchanged, got %q", result)
 	}
-	empty := &DeepExtraction{Synthesis: "", Quotables: []string{"x"}}
+	empty := &db.DeepExtraction{Synthesis: "", Quotables: []string{"x"}}
 	if result := appendSynthesisSection(base, empty); result != base {
 		t.Errorf("Empty synthesis: expected content unchanged, got %q", result)
 	}
@@ -160,7 +116,7 @@ func TestAppendSynthesisSection_NilGuard(t *testing.T) {
 // TestUnifiedQuotes verifies light quotes + deep quotables merge into ONE deduped "## Quotes"
 // section. BREAKS: the old separate "Key Quotes" / "Notable Lines" headers returning.
 func TestUnifiedQuotes(t *testing.T) {
-	de := &DeepExtraction{
+	de := &db.DeepExtraction{
 		Synthesis: "S.",
 		Quotables: []string{"Deep line.", "Shared quote."},
 	}
@@ -223,3 +179,67 @@ func TestReaderViewWithMetadata(t *testing.T) {
 		t.Error("Reader should display article with metadata")
 	}
 }
+
+// TestRenderWhySection verifies the WHY section names matched interests and only
+// shows the reasoning when expanded.
+// BREAKS: users can't see what the LLM keyed on, so they can't tune context.md.
+func TestRenderWhySection(t *testing.T) {
+	analysis := db.ParseAnalysis(`{"matched_interests": ["rust", "llm tooling"], "priority_reasoning": "Deep dive on async runtimes."}`)
+
+	collapsed := renderWhySection(analysis, false)
+	if !strings.Contains(collapsed, "rust, llm tooling") {
+		t.Errorf("collapsed section should list matched interests, got %q", collapsed)
+	}
+	if strings.Contains(collapsed, "async runtimes") {
+		t.Error("collapsed section should not include the reasoning")
+	}
+
+	expanded := renderWhySection(analysis, true)
+	if !strings.Contains(expanded, "Deep dive on async runtimes.") {
+		t.Errorf("expanded section should include the reasoning, got %q", expanded)
+	}
+
+	if got := renderWhySection(db.ParseAnalysis(`{"entities": ["go"]}`), true); got != "" {
+		t.Errorf("no rationale should render no section, got %q", got)
+	}
+	if got := whyHint(analysis); got != "why: rust, llm tooling" {
+		t.Errorf("whyHint = %q", got)
+	}
+}
+
+// TestSummarize_UpdatesReaderInPlace verifies :summarize only calls the daemon for items
+// without a reading summary, and the result replaces the raw content in the open reader.
+// BREAKS: File-source items stay unreadable walls of text, or summarized items re-bill the LLM.
+func TestSummarize_UpdatesReaderInPlace(t *testing.T) {
+	m := Model{
+		items:    []db.ContentItem{{ID: "1", Title: "Notes", Content: "Raw file content."}},
+		view:     "reader",
+		width:    100,
+		height:   30,
+		viewport: viewport.New(100, 30),
+	}
+
+	updated, cmd := m.Update(commands.SummarizeMsg{})
+	m = updated.(Model)
+	if cmd == nil || m.statusMessage != "Summarizing..." {
+		t.Fatalf("Expected a summarize request, status %q", m.statusMessage)
+	}
+
+	updated, _ = m.Update(operations.SummarizedMsg{
+		ContentID: "1",
+		Summary:   "Short.",
+		Analysis:  `{"reading_summary": "The notes argue for smaller PRs.", "metrics": {"score": 3}}`,
+	})
+	m = updated.(Model)
+	if m.items[0].Summary != "Short." || m.items[0].ParsedAnalysis().Metrics.Score != 3 {
+		t.Errorf("Expected the item to take the new summary and analysis, got %+v", m.items[0])
+	}
+	if !strings.Contains(m.viewport.View(), "smaller PRs") {
+		t.Error("Expected the reader to show the new summary")
+	}
+
+	updated, _ = m.Update(commands.SummarizeMsg{})
+	if updated.(Model).statusMessage == "Summarizing..." {
+		t.Error("Expected an already-summarized item not to be sent again")
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// refetchDelay lets view toggles settle before the list is fetched, so
+// 1 2 3 pressed in quick succession queries once
+const refetchDelay = 100 * time.Millisecond
+
+// refetchDueMsg fires when the toggles have settled
+type refetchDueMsg struct {
+	seq int
+}
+
+// newFetch starts a list fetch: results are tagged with m.fetchSeq, so
+// bumping it makes Update drop whatever earlier fetches still bring back.
+// Call it before building the fetch from m.
+func (m *Model) newFetch() {
+	m.fetchSeq++
+}
+
+// fetchList starts a fetch of the list with the current state, superseding
+// any still running
+func (m *Model) fetchList(refreshData bool) tea.Cmd {
+	m.newFetch()
+	return fetchItemsWithState(*m, refreshData)
+}
+
+// refetchSoon fetches the list for the view once the toggles settle. Each
+// call supersedes the pending fetch, and any still running.
+func (m *Model) refetchSoon() tea.Cmd {
+	m.newFetch()
+	due := refetchDueMsg{seq: m.fetchSeq}
+	return tea.Tick(refetchDelay, func(time.Time) tea.Msg { return due })
+}
+
+// refetchDue runs the settled fetch if no toggle came since. m.fetchSeq is
+// still the one refetchSoon tagged it with.
+func (m Model) refetchDue(msg refetchDueMsg) tea.Cmd {
+	if msg.seq != m.fetchSeq {
+		return nil
+	}
+	return fetchItemsWithState(m, false)
+}
+
+// withSeq tags the itemsLoadedMsg fetch returns with seq
+func withSeq(seq int, fetch func() itemsLoadedMsg) tea.Cmd {
+	return func() tea.Msg {
+		loaded := fetch()
+		loaded.seq = seq
+		return loaded
+	}
+}
+
+// supersededFetch handles a result a later fetch has replaced. Its list is
+// dropped, but an auto-refresh still schedules the next one, and what a
+// remote sync downloaded is kept and re-filtered for the current view.
+func (m *Model) supersededFetch(msg itemsLoadedMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	if msg.isAutoRefresh {
+		cmds = append(cmds, m.scheduleRefresh())
+	}
+	if msg.err != nil || m.remoteURL == "" {
+		return tea.Batch(cmds...)
+	}
+	if msg.archived != nil {
+		m.archivedCache = msg.archived
+	}
+	if msg.updateCache {
+		m.itemsCache = msg.allItems
+		if !msg.newLastSync.IsZero() {
+			m.lastSync = msg.newLastSync
+		}
+		if !msg.syncedAt.IsZero() {
+			m.lastSyncAt = msg.syncedAt
+			m.latency = msg.latency
+			m.syncFailed = false
+		}
+		cmds = append(cmds, m.refetchSoon())
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestRefetchSoon_TogglesSettleIntoOneFetch verifies quick view toggles fetch once, for the last state, and drop superseded results.
+// BREAKS: If each key fetches, results race and the list can settle on an earlier view than the header shows.
+func TestRefetchSoon_TogglesSettleIntoOneFetch(t *testing.T) {
+	m := testModel()
+	m.remoteURL = "http://daemon.invalid" // Re-filtering the cache never calls it
+	m.showAll = true
+	m.filterType = "all"
+	m.itemsCache = []db.ContentItem{
+		{ID: "h", Title: "High", Priority: "high"},
+		{ID: "m", Title: "Medium", Priority: "medium"},
+		{ID: "l", Title: "Low", Priority: "low"},
+	}
+
+	var dues []tea.Msg
+	for _, key := range []string{"1", "2", "3"} {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+		dues = append(dues, cmd())
+	}
+	if m.priority != "low" || !m.loading {
+		t.Fatalf("Expected the low view loading, got %s (loading=%v)", m.priority, m.loading)
+	}
+
+	for _, due := range dues[:2] {
+		if _, cmd := m.Update(due); cmd != nil {
+			t.Error("Expected superseded toggles not to fetch")
+		}
+	}
+	_, fetch := m.Update(dues[2])
+	if fetch == nil {
+		t.Fatal("Expected the last toggle to fetch")
+	}
+	loaded := fetch().(itemsLoadedMsg)
+	if len(loaded.items) != 1 || loaded.items[0].ID != "l" {
+		t.Fatalf("Expected only the low item, got %+v", loaded.items)
+	}
+
+	// A toggle after the fetch started makes its result stale
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	m = updated.(Model)
+	updated, _ = m.Update(loaded)
+	m = updated.(Model)
+	if !m.loading || len(m.items) != 0 {
+		t.Errorf("Expected the stale result dropped, got %d items (loading=%v)", len(m.items), m.loading)
+	}
+}
+
+// TestFetchSeq_OlderRefreshDropped verifies an auto-refresh that finishes after a newer fetch can't replace its list.
+// BREAKS: If only toggles are tagged, a slow auto-refresh lands last and shows the view the user already left.
+func TestFetchSeq_OlderRefreshDropped(t *testing.T) {
+	m := testModel()
+	m.remoteURL = "http://daemon.invalid"
+	m.refreshInterval = time.Minute
+
+	m.newFetch()
+	older := itemsLoadedMsg{seq: m.fetchSeq, isAutoRefresh: true, items: []db.ContentItem{{ID: "old"}}}
+	m.loading = true
+	newer := m.fetchList(false)().(itemsLoadedMsg)
+	if newer.seq != m.fetchSeq || newer.seq == older.seq {
+		t.Fatalf("Expected each fetch tagged with its own generation, got %d and %d", older.seq, newer.seq)
+	}
+
+	updated, _ := m.Update(newer)
+	m = updated.(Model)
+	updated, cmd := m.Update(older)
+	m = updated.(Model)
+	if len(m.items) != 0 {
+		t.Errorf("Expected the older refresh dropped, got %+v", m.items)
+	}
+	if cmd == nil {
+		t.Error("Expected the dropped auto-refresh to schedule the next one")
+	}
+}
+
+// TestFetchSeq_SupersededSyncKeepsDownload verifies a remote sync overtaken by a newer fetch still updates the cache.
+// BREAKS: If the whole result is dropped, a view toggle during a sync throws away everything it downloaded.
+func TestFetchSeq_SupersededSyncKeepsDownload(t *testing.T) {
+	m := testModel()
+	m.remoteURL = "http://daemon.invalid"
+	m.showAll = true
+	m.filterType = "all"
+
+	m.newFetch()
+	synced := itemsLoadedMsg{
+		seq:         m.fetchSeq,
+		updateCache: true,
+		allItems:    []db.ContentItem{{ID: "a", Priority: "high"}},
+		items:       []db.ContentItem{{ID: "a", Priority: "high"}},
+		newLastSync: time.Now(),
+	}
+	m.newFetch()
+
+	updated, cmd := m.Update(synced)
+	m = updated.(Model)
+	if len(m.itemsCache) != 1 || m.lastSync.IsZero() {
+		t.Fatalf("Expected the download cached, got %d items", len(m.itemsCache))
+	}
+	if cmd == nil {
+		t.Fatal("Expected the view re-filtered from the new cache")
+	}
+	updated, fetch := m.Update(cmd())
+	m = updated.(Model)
+	updated, _ = m.Update(fetch())
+	m = updated.(Model)
+	if len(m.items) != 1 || m.items[0].ID != "a" {
+		t.Errorf("Expected the synced item listed, got %+v", m.items)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// currentRefreshInterval is the auto-refresh interval for the terminal's
+// focus: [refresh] blurred while another window has it
+func (m Model) currentRefreshInterval() time.Duration {
+	if m.blurred {
+		return m.blurredInterval
+	}
+	return m.refreshInterval
+}
+
+// scheduleRefresh starts the next auto-refresh timer, retiring any other,
+// so there's only ever one refresh loop however many paths reschedule
+func (m *Model) scheduleRefresh() tea.Cmd {
+	m.refreshGen++
+	m.refreshHeld = false
+	interval := m.currentRefreshInterval()
+	if interval <= 0 {
+		return nil
+	}
+	return autoRefreshCmd(interval, m.refreshGen)
+}
+
+// refreshNow runs an auto-refresh straight away, retiring the pending timer
+func (m *Model) refreshNow() tea.Cmd {
+	m.refreshGen++
+	m.refreshHeld = false
+	gen := m.refreshGen
+	return func() tea.Msg { return autoRefreshMsg{gen: gen} }
+}
+
+// refreshPaused reports whether auto-refresh should wait: the reader or a
+// modal is open, and replacing the list underneath would move what's shown
+func (m Model) refreshPaused() bool {
+	return m.view == "reader" || m.modalOpen()
+}
+
+// modalOpen reports whether any modal, the palette, or a session is showing
+func (m Model) modalOpen() bool {
+	return m.sourceModal.IsVisible() || m.helpModal.IsVisible() || m.palette.IsVisible() ||
+		m.contextEditor.IsVisible() || m.chat.IsVisible() || m.triageModal.IsVisible() ||
+		m.dedupeModal.IsVisible() || m.digestModal.IsVisible() || m.transcript.IsVisible() ||
+		m.messagesModal.IsVisible() || m.errorsModal.IsVisible() || m.contextReview.IsVisible()
+}
+
+// resumeRefresh runs the refresh held back while the reader or a modal was
+// open, once they've closed
+func (m *Model) resumeRefresh() tea.Cmd {
+	if !m.refreshHeld || m.refreshPaused() {
+		return nil
+	}
+	return m.refreshNow()
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestRefresh_FocusSwitchesInterval verifies blurring switches to [refresh] blurred and focus refreshes straight away.
+// BREAKS: A background window keeps syncing at the focused rate, or comes back showing stale items.
+func TestRefresh_FocusSwitchesInterval(t *testing.T) {
+	m := testModel()
+	m.refreshInterval, m.blurredInterval = time.Minute, 0
+	stale := autoRefreshMsg{gen: m.refreshGen}
+
+	updated, cmd := m.Update(tea.BlurMsg{})
+	m = updated.(Model)
+	if cmd != nil || !m.blurred {
+		t.Fatal("Expected blurring with blurred = 0 to stop the timer")
+	}
+	updated, _ = m.Update(stale)
+	if updated.(Model).loading {
+		t.Error("Expected the focused timer's tick to be ignored once blurred")
+	}
+
+	updated, cmd = m.Update(tea.FocusMsg{})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected focus to refresh straight away")
+	}
+	if tick, ok := cmd().(autoRefreshMsg); !ok || tick.gen != m.refreshGen {
+		t.Errorf("Expected a current auto-refresh tick, got %#v", tick)
+	}
+}
+
+// TestRefresh_HeldWhileReading verifies a tick in the reader waits, then refreshes once back at the list.
+// BREAKS: Refreshing under the reader moves the article being read; never resuming stops auto-refresh for good.
+func TestRefresh_HeldWhileReading(t *testing.T) {
+	m := testModel()
+	m.refreshInterval = time.Minute
+	m.view = "reader"
+
+	updated, cmd := m.Update(autoRefreshMsg{gen: m.refreshGen})
+	m = updated.(Model)
+	if m.loading || cmd != nil || !m.refreshHeld {
+		t.Fatal("Expected the tick held while the reader is open")
+	}
+
+	m.view = "list"
+	updated, cmd = m.Update(clearFlashMsg{})
+	m = updated.(Model)
+	if cmd == nil || m.refreshHeld {
+		t.Fatal("Expected the held refresh to run once the reader closed")
+	}
+	if tick, ok := cmd().(autoRefreshMsg); !ok || tick.gen != m.refreshGen {
+		t.Errorf("Expected a current auto-refresh tick, got %#v", tick)
+	}
+}
+
+// TestRefresh_TickWhileLoadingReschedules verifies a tick during a running refresh schedules the next one.
+// BREAKS: A tick landing on a manual refresh ends the auto-refresh loop.
+func TestRefresh_TickWhileLoadingReschedules(t *testing.T) {
+	m := testModel()
+	m.refreshInterval = time.Minute
+	m.loading = true
+	gen := m.refreshGen
+
+	updated, cmd := m.Update(autoRefreshMsg{gen: gen})
+	if cmd == nil || updated.(Model).refreshGen == gen {
+		t.Error("Expected a new timer replacing the one that fired")
+	}
+}
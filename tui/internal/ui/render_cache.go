@@ -0,0 +1,52 @@
+package ui
+
+// maxReaderRenders bounds the rendered articles kept. Past it the cache
+// starts over: revisits are mostly to recent articles, which re-render once.
+const maxReaderRenders = 64
+
+// readerRenderKey is how an article was rendered for the reader
+type readerRenderKey struct {
+	id         string
+	width      int
+	theme      string
+	hyperlinks bool
+}
+
+// readerRender is an article's rendered markdown, with the markdown it came
+// from so a changed article (loaded content, new analysis, :set why) misses
+type readerRender struct {
+	markdown string
+	rendered string
+}
+
+// readerRenderCache keeps rendered articles so navigating back to one, or
+// re-rendering it unchanged, skips the markdown renderer
+type readerRenderCache map[readerRenderKey]readerRender
+
+// get returns key's render if it was made from markdown
+func (c readerRenderCache) get(key readerRenderKey, markdown string) (string, bool) {
+	render, ok := c[key]
+	if !ok || render.markdown != markdown {
+		return "", false
+	}
+	return render.rendered, true
+}
+
+// put records a render, returning the cache to keep (a new one when c is
+// nil or full)
+func (c readerRenderCache) put(key readerRenderKey, markdown, rendered string) readerRenderCache {
+	if _, ok := c[key]; !ok && (c == nil || len(c) >= maxReaderRenders) {
+		c = make(readerRenderCache)
+	}
+	c[key] = readerRender{markdown: markdown, rendered: rendered}
+	return c
+}
+
+// renderReaderMarkdown renders an article's markdown for the reader
+func renderReaderMarkdown(key readerRenderKey, markdown string) string {
+	rendered := renderSimpleMarkdown(markdown, key.width)
+	if key.hyperlinks {
+		rendered = linkifyURLs(rendered)
+	}
+	return rendered
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestReaderRenderCache_ReusesUntilChanged verifies a revisited article reuses its render until its markdown, width, or theme changes.
+// BREAKS: If stale renders are served, loaded content or :set why never shows; if nothing is reused, navigation re-renders every time.
+func TestReaderRenderCache_ReusesUntilChanged(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "1", Title: "First", Content: "Original body"}})
+	m.view = "reader"
+	m.theme = CleanCyberTheme
+	m.updateReaderContent()
+
+	key, markdown := m.readerKey(m.items[0], m.viewport.Width)
+	if _, ok := m.readerRenders.get(key, markdown); !ok {
+		t.Fatal("Expected the render cached")
+	}
+	m.readerRenders.put(key, markdown, "cached render")
+	m.updateReaderContent()
+	if !strings.Contains(m.viewport.View(), "cached render") {
+		t.Errorf("Expected the cached render reused, got %q", m.viewport.View())
+	}
+
+	m.items[0].Content = "Edited body"
+	m.updateReaderContent()
+	if !strings.Contains(m.viewport.View(), "Edited body") {
+		t.Errorf("Expected changed content re-rendered, got %q", m.viewport.View())
+	}
+
+	m.theme.Name = "Other"
+	if next, _ := m.readerKey(m.items[0], m.viewport.Width); next == key {
+		t.Error("Expected another theme to render under another key")
+	}
+}
+
+// TestReaderRenderCache_Bounded verifies the cache starts over rather than growing without limit.
+// BREAKS: If it grows unbounded, a long reading session keeps every article's render in memory.
+func TestReaderRenderCache_Bounded(t *testing.T) {
+	var cache readerRenderCache
+	for i := 0; i <= maxReaderRenders; i++ {
+		cache = cache.put(readerRenderKey{id: string(rune('a' + i))}, "", "")
+	}
+	if len(cache) > maxReaderRenders {
+		t.Errorf("Expected at most %d renders, got %d", maxReaderRenders, len(cache))
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/filter"
+)
+
+// resultHeight is the lines per search result: title, snippet, metadata
+const resultHeight = 3
+
+// renderSearchResults lists the running search's hits: the title and a
+// snippet around the first match in the summary or content, with the query
+// highlighted in both. It replaces the feed rows (and any row_format) so
+// the reason each item matched is visible.
+func renderSearchResults(m Model, expr *filter.Expr, width, height int, theme StyleTheme) string {
+	maxVisible := max(1, height/resultHeight)
+	startIdx, endIdx := visibleRange(m.cursor, len(m.items), maxVisible)
+	match := lipgloss.NewStyle().Foreground(theme.Orange).Bold(true)
+
+	var lines []string
+	for i := startIdx; i < endIdx; i++ {
+		item := m.items[i]
+
+		selector := "  "
+		titleColor := theme.White
+		if i == m.cursor {
+			selector = lipgloss.NewStyle().Foreground(theme.Selection).Bold(true).Render("▸ ")
+			titleColor = theme.Selection
+		}
+		if item.Read {
+			titleColor = theme.Read
+		}
+
+		title := truncate(item.Title, width-20)
+		styled := highlightRanges(title, expr.Highlights(title), lipgloss.NewStyle().Foreground(titleColor), match)
+		line1 := fmt.Sprintf("%s%s %2d. %s", selector, itemIndicator(item, theme), i+1, m.titleLink(item, styled))
+
+		snippet, hits := searchSnippet(item, expr, width)
+		line2 := "        " + ansi.Truncate(highlightRanges(snippet, hits, lipgloss.NewStyle().Foreground(theme.Gray), match), width-12, "…")
+
+		meta := itemMetaParts(item, theme)
+		if item.Archived {
+			// A search with archived:yes mixes in items the feed no longer shows
+			meta = append([]string{lipgloss.NewStyle().Foreground(theme.Purple).Render("archived")}, meta...)
+		}
+		line3 := "        " + strings.Join(meta, " | ")
+		lines = append(lines, line1, line2, line3)
+	}
+
+	return withScrollbar(strings.Join(lines, "\n"), width-3, maxVisible*resultHeight, len(m.items)*resultHeight, startIdx*resultHeight, theme)
+}
+
+// searchSnippet is the summary or content, whitespace collapsed, from just
+// before the first hit, with the hits' ranges in it. An item that only
+// matched on its title (or a field) shows the start of its summary.
+func searchSnippet(item db.ContentItem, expr *filter.Expr, width int) (string, [][2]int) {
+	fallback := ""
+	for _, text := range []string{item.Summary, item.Content} {
+		flat := strings.Join(strings.Fields(text), " ")
+		if fallback == "" {
+			fallback = flat
+		}
+		hits := expr.Highlights(flat)
+		if len(hits) == 0 {
+			continue
+		}
+
+		// Lead in with a few words so the hit reads in context
+		begin := hits[0][0] - width/4
+		if begin <= 0 {
+			begin = 0
+		} else {
+			if space := strings.IndexByte(flat[begin:hits[0][0]], ' '); space >= 0 {
+				begin += space + 1
+			}
+			for begin < len(flat) && !utf8.RuneStart(flat[begin]) {
+				begin++
+			}
+		}
+		end := snippetEnd(flat, begin, width)
+
+		prefix := ""
+		if begin > 0 {
+			prefix = "…"
+		}
+		var shifted [][2]int
+		for _, hit := range hits {
+			if hit[0] >= end {
+				break
+			}
+			shifted = append(shifted, [2]int{hit[0] - begin + len(prefix), min(hit[1], end) - begin + len(prefix)})
+		}
+		return prefix + flat[begin:end], shifted
+	}
+	return fallback[:snippetEnd(fallback, 0, width)], nil
+}
+
+// snippetEnd bounds a snippet starting at begin: bytes past the widest line
+// can't show, so there's no need to style them
+func snippetEnd(s string, begin, width int) int {
+	end := min(len(s), begin+4*width)
+	for end < len(s) && !utf8.RuneStart(s[end]) {
+		end++
+	}
+	return end
+}
+
+// highlightRanges renders s with the byte ranges in match and the rest in base
+func highlightRanges(s string, ranges [][2]int, base, match lipgloss.Style) string {
+	var out strings.Builder
+	at := 0
+	for _, r := range ranges {
+		if r[0] < at || r[1] > len(s) {
+			continue
+		}
+		if r[0] > at {
+			out.WriteString(base.Render(s[at:r[0]]))
+		}
+		out.WriteString(match.Render(s[r[0]:r[1]]))
+		at = r[1]
+	}
+	if at < len(s) {
+		out.WriteString(base.Render(s[at:]))
+	}
+	return out.String()
+}
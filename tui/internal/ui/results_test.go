@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/filter"
+)
+
+// TestSearchSnippet_CentersOnFirstHit verifies the snippet starts shortly before the first match and carries its range.
+// BREAKS: If the snippet always starts at the top, a match deep in the content is invisible in the results.
+func TestSearchSnippet_CentersOnFirstHit(t *testing.T) {
+	expr, err := filter.ParseSearch("vulnerability")
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := db.ContentItem{
+		Summary: "A weekly roundup.",
+		Content: strings.Repeat("filler words here ", 40) + "a serious\n\nVulnerability in libfoo " + strings.Repeat("more ", 50),
+	}
+
+	snippet, hits := searchSnippet(item, expr, 80)
+	if !strings.HasPrefix(snippet, "…") || strings.Contains(snippet, "\n") {
+		t.Errorf("Expected a collapsed snippet from mid-content, got %q", snippet)
+	}
+	if len(hits) != 1 || snippet[hits[0][0]:hits[0][1]] != "Vulnerability" {
+		t.Fatalf("Expected the hit's range in the snippet, got %v in %q", hits, snippet)
+	}
+	if lead := snippet[:hits[0][0]]; !strings.Contains(lead, "a serious") || len(lead) > 40 {
+		t.Errorf("Expected a short lead-in before the hit, got %q", lead)
+	}
+
+	// A title-only match falls back to the summary
+	titleOnly := db.ContentItem{Title: "Vulnerability roundup", Summary: "This week's news."}
+	if snippet, hits := searchSnippet(titleOnly, expr, 80); snippet != "This week's news." || hits != nil {
+		t.Errorf("Expected the summary without hits, got %q %v", snippet, hits)
+	}
+}
+
+// TestRenderContentList_SearchResults verifies a running search renders three-line results with snippets instead of feed rows.
+// BREAKS: If searches reuse the feed rows, users can't see where each item matched.
+func TestRenderContentList_SearchResults(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{
+		{ID: "1", Title: "Weekly news", Priority: "high", SourceName: "LWN", Summary: "Kernel updates and a libfoo vulnerability disclosure."},
+		{ID: "2", Title: "Vulnerability report", Priority: "high", SourceName: "Blog", Summary: "Quarterly numbers."},
+	})
+	m.search = runningSearch{expr: "vulnerability"}
+
+	out := ansi.Strip(renderContentList(m, 100, 30, m.theme))
+	lines := strings.Split(out, "\n")
+	if len(lines) < 6 {
+		t.Fatalf("Expected three lines per result, got %d:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[0], "Weekly news") || !strings.Contains(lines[1], "libfoo vulnerability") || !strings.Contains(lines[2], "LWN") {
+		t.Errorf("Expected title, snippet, and metadata for the first result, got:\n%s", out)
+	}
+	if !strings.Contains(lines[3], "Vulnerability report") || !strings.Contains(lines[4], "Quarterly numbers") {
+		t.Errorf("Expected the second result to fall back to its summary, got:\n%s", out)
+	}
+
+	m.search = runningSearch{}
+	if out := ansi.Strip(renderContentList(m, 100, 30, m.theme)); strings.Contains(out, "libfoo") {
+		t.Errorf("Expected feed rows without snippets once the search is off, got:\n%s", out)
+	}
+}
@@ -0,0 +1,26 @@
+package ui
+
+// ring keeps the newest entries of a history without copying it on every
+// push. The buffer is shared between Model copies, like the caches; only the
+// latest Model pushes to it.
+type ring[T any] struct {
+	buf   []T
+	start int // Index of the oldest entry once buf is full
+}
+
+// push adds v, overwriting the oldest entry once the ring holds limit
+func (r *ring[T]) push(v T, limit int) {
+	if len(r.buf) < limit {
+		r.buf = append(r.buf, v)
+		return
+	}
+	r.buf[r.start] = v
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+// list copies the entries out, oldest first
+func (r ring[T]) list() []T {
+	out := make([]T, 0, len(r.buf))
+	out = append(out, r.buf[r.start:]...)
+	return append(out, r.buf[:r.start]...)
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// rowFields are the columns a [tui] row_format template can use
+var rowFields = map[string]bool{
+	"icon":     true, // ⚑ pinned, ♥ favorited, ✓ read, priority-colored ● unread (◷ back from :snooze)
+	"num":      true, // Position in the list, "12."
+	"title":    true,
+	"badge":    true, // Dead-link/paywall mark from :set linkcheck
+	"priority": true, // HIGH, MED, LOW
+	"source":   true,
+	"author":   true, // "by <author>" when the feed names one
+	"domain":   true,
+	"age":      true, // "3h", "2d"
+	"tags":     true, // Analysis entities and user tags
+	"metrics":  true, // Reddit score/comments, YouTube views/duration
+	"length":   true,
+	"why":      true, // What a HIGH item matched
+	"feedback": true, // 👍 / 👎
+	"meta":     true, // The default metadata line
+}
+
+// rowFormatInvalidMsg reports a row_format that failed to parse; the list
+// falls back to the built-in layout
+type rowFormatInvalidMsg struct {
+	err error
+}
+
+// rowSegment is literal text or a field of a row template
+type rowSegment struct {
+	text  string // Literal text, or the field name when field is set
+	field bool
+	width int  // Pad or truncate to this many columns; 0 keeps the natural width
+	right bool // Right-align within width
+}
+
+// rowFormat is a parsed row template, one segment list per line of the row
+type rowFormat [][]rowSegment
+
+// parseRowFormat parses a template like "{icon} {title:60} {source:15} {age:>5}".
+// Fields take an optional width, right-aligned with ">". A "\n" starts a
+// second line for the same item.
+func parseRowFormat(tmpl string) (rowFormat, error) {
+	tmpl = strings.ReplaceAll(tmpl, `\n`, "\n")
+	var format rowFormat
+	for _, line := range strings.Split(tmpl, "\n") {
+		var segments []rowSegment
+		for line != "" {
+			open := strings.IndexByte(line, '{')
+			if open < 0 {
+				segments = append(segments, rowSegment{text: line})
+				break
+			}
+			if open > 0 {
+				segments = append(segments, rowSegment{text: line[:open]})
+			}
+			end := strings.IndexByte(line[open:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '{' in row format")
+			}
+			segment, err := parseRowField(line[open+1 : open+end])
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+			line = line[open+end+1:]
+		}
+		format = append(format, segments)
+	}
+	return format, nil
+}
+
+// parseRowField parses the inside of a {name:width} placeholder
+func parseRowField(spec string) (rowSegment, error) {
+	name, width, hasWidth := strings.Cut(spec, ":")
+	segment := rowSegment{text: strings.TrimSpace(strings.ToLower(name)), field: true}
+	if !rowFields[segment.text] {
+		return rowSegment{}, fmt.Errorf("unknown row format field '%s'", segment.text)
+	}
+	if !hasWidth {
+		return segment, nil
+	}
+	switch {
+	case strings.HasPrefix(width, ">"):
+		segment.right = true
+		width = width[1:]
+	case strings.HasPrefix(width, "<"):
+		width = width[1:]
+	}
+	n, err := strconv.Atoi(width)
+	if err != nil || n <= 0 {
+		return rowSegment{}, fmt.Errorf("bad width '%s' for row format field '%s'", width, segment.text)
+	}
+	segment.width = n
+	return segment, nil
+}
+
+// render draws one item's lines, each fitted to width. The selector marks
+// the cursor row and titleColor follows the cursor and read state, as in
+// the default layout.
+func (f rowFormat) render(m Model, index int, selector string, titleColor lipgloss.Color, width int, theme StyleTheme) []string {
+	item := m.items[index]
+	lines := make([]string, len(f))
+	for i, segments := range f {
+		var line strings.Builder
+		for _, segment := range segments {
+			if !segment.field {
+				line.WriteString(segment.text)
+				continue
+			}
+			line.WriteString(fitColumn(rowFieldValue(m, index, item, segment.text, titleColor, theme), segment.width, segment.right))
+		}
+		prefix := selector
+		if i > 0 {
+			prefix = "  " // Later lines line up under the first
+		}
+		lines[i] = ansi.Truncate(prefix+line.String(), width, "…")
+	}
+	return lines
+}
+
+// rowFieldValue renders one field of a row
+func rowFieldValue(m Model, index int, item db.ContentItem, field string, titleColor lipgloss.Color, theme StyleTheme) string {
+	metaStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+	switch field {
+	case "icon":
+		return itemIndicator(item, theme)
+	case "num":
+		return fmt.Sprintf("%d.", index+1)
+	case "title":
+		return m.titleLink(item, lipgloss.NewStyle().Foreground(titleColor).Render(item.Title))
+	case "badge":
+		return m.linkBadge(item, theme)
+	case "priority":
+		label := map[string]string{"high": "HIGH", "medium": "MED", "low": "LOW"}[item.Priority]
+		if label == "" {
+			return ""
+		}
+		return lipgloss.NewStyle().Foreground(theme.PriorityColor(item.Priority)).Render(label)
+	case "source":
+		return metaStyle.Render(item.SourceName)
+	case "author":
+		return metaStyle.Render(itemByline(item))
+	case "domain":
+		return metaStyle.Render(extractDomain(item.URL))
+	case "age":
+		return metaStyle.Render(formatTime(time.Since(item.Published)))
+	case "tags":
+		var tags []string
+		if entities := formatEntities(item.ParsedAnalysis().Entities, 2); entities != "" {
+			tags = append(tags, entities)
+		}
+		if userTags := renderUserTags(item.UserTags, theme); userTags != "" {
+			tags = append(tags, userTags)
+		}
+		return strings.Join(tags, " ")
+	case "metrics":
+		return strings.Join(itemMetrics(item, item.ParsedAnalysis(), theme), " ")
+	case "length":
+		if n := itemLength(&item); n > 0 {
+			return metaStyle.Render(formatContentLength(n))
+		}
+		return ""
+	case "why":
+		if item.Priority != "high" {
+			return ""
+		}
+		return metaStyle.Italic(true).Render(whyHint(item.ParsedAnalysis()))
+	case "feedback":
+		return feedbackIcon(item.UserFeedback, theme)
+	case "meta":
+		return strings.Join(itemMetaParts(item, theme), " | ")
+	}
+	return ""
+}
+
+// fitColumn truncates or pads a styled value to width columns
+func fitColumn(value string, width int, right bool) string {
+	if width == 0 {
+		return value
+	}
+	value = ansi.Truncate(value, width, "…")
+	pad := strings.Repeat(" ", width-ansi.StringWidth(value))
+	if right {
+		return pad + value
+	}
+	return value + pad
+}
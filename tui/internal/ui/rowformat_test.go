@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestParseRowFormat verifies fields, widths, and alignment parse from the template.
+// BREAKS: If widths are dropped, columns stop lining up across rows.
+func TestParseRowFormat(t *testing.T) {
+	format, err := parseRowFormat(`{icon} {title:20} {age:>5}\n{source}`)
+	if err != nil {
+		t.Fatalf("parseRowFormat failed: %v", err)
+	}
+	if len(format) != 2 {
+		t.Fatalf("Expected two lines per row, got %d", len(format))
+	}
+	title := format[0][2]
+	if !title.field || title.text != "title" || title.width != 20 || title.right {
+		t.Errorf("Expected a 20-column title field, got %+v", title)
+	}
+	if age := format[0][4]; age.width != 5 || !age.right {
+		t.Errorf("Expected a right-aligned 5-column age field, got %+v", age)
+	}
+
+	for _, bad := range []string{"{byline}", "{title", "{title:wide}", "{age:>0}"} {
+		if _, err := parseRowFormat(bad); err == nil {
+			t.Errorf("parseRowFormat(%q) should fail", bad)
+		}
+	}
+}
+
+// TestRowFormat_RendersColumns verifies columns are padded and truncated to their widths.
+// BREAKS: If a long title isn't cut, the source column shifts on every row.
+func TestRowFormat_RendersColumns(t *testing.T) {
+	format, err := parseRowFormat("{num} {title:10}|{source:6}|{age:>4}")
+	if err != nil {
+		t.Fatalf("parseRowFormat failed: %v", err)
+	}
+	m := Model{items: []db.ContentItem{
+		{Title: "A rather long headline", SourceName: "HN", Published: time.Now().Add(-3 * time.Hour)},
+	}}
+
+	lines := format.render(m, 0, "  ", CleanCyberTheme.White, 80, CleanCyberTheme)
+	if len(lines) != 1 {
+		t.Fatalf("Expected one line, got %d", len(lines))
+	}
+	got := ansi.Strip(lines[0])
+	want := "  1. A rather …|HN    |  3h"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// The whole line is cut to the list width
+	lines = format.render(m, 0, "  ", CleanCyberTheme.White, 12, CleanCyberTheme)
+	if w := ansi.StringWidth(lines[0]); w > 12 || !strings.HasSuffix(ansi.Strip(lines[0]), "…") {
+		t.Errorf("Expected the line truncated to 12 columns, got %q (%d)", ansi.Strip(lines[0]), w)
+	}
+}
+
+// TestContentLength_ShownForEverySourceType verifies the length shows for Reddit and YouTube items as well as RSS, from the content when the analysis lacks it.
+// BREAKS: Only RSS rows say how long a read is, so long-form posts elsewhere can't be picked out.
+func TestContentLength_ShownForEverySourceType(t *testing.T) {
+	for _, sourceType := range []string{"rss", "reddit", "youtube", "file"} {
+		item := db.ContentItem{SourceType: sourceType, Partial: true, ContentLength: 4200}
+		if meta := ansi.Strip(strings.Join(itemMetaParts(item, CleanCyberTheme), " | ")); !strings.Contains(meta, "4.2k chars") {
+			t.Errorf("Expected the %s item's length in its metadata, got %q", sourceType, meta)
+		}
+	}
+	if row := ansi.Strip(rowFieldValue(Model{}, 0, db.ContentItem{Content: "short"}, "length", CleanCyberTheme.White, CleanCyberTheme)); row != "5 chars" {
+		t.Errorf("Expected the {length} field from the content, got %q", row)
+	}
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxScrollPositions caps how many articles keep a remembered offset
+const maxScrollPositions = 500
+
+// scrollPosition is one article's saved reader offset
+type scrollPosition struct {
+	ID     string `json:"id"`
+	Offset int    `json:"offset"`
+}
+
+// scrollPositions remembers where each article was left in the reader,
+// oldest first so the cap drops the least recently read
+type scrollPositions []scrollPosition
+
+// get returns the remembered offset for an item, 0 (the top) when there is none
+func (s scrollPositions) get(id string) int {
+	for _, pos := range s {
+		if pos.ID == id {
+			return pos.Offset
+		}
+	}
+	return 0
+}
+
+// set records an item's offset as the most recent, forgetting it at the top
+func (s scrollPositions) set(id string, offset int) scrollPositions {
+	next := make(scrollPositions, 0, len(s)+1)
+	for _, pos := range s {
+		if pos.ID != id {
+			next = append(next, pos)
+		}
+	}
+	if offset > 0 {
+		next = append(next, scrollPosition{ID: id, Offset: offset})
+	}
+	if len(next) > maxScrollPositions {
+		next = next[len(next)-maxScrollPositions:]
+	}
+	return next
+}
+
+// rememberScroll records the reader's offset for the article it shows
+func (m *Model) rememberScroll() {
+	if m.readerItemID != "" {
+		m.scrolls = m.scrolls.set(m.readerItemID, m.viewport.YOffset)
+	}
+}
+
+// scrollPathFunc returns the scroll position file (overridable for testing)
+var scrollPathFunc = defaultScrollPath
+
+// defaultScrollPath keeps scroll positions next to the local database
+func defaultScrollPath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "prismis", "scroll_positions.json"), nil
+}
+
+// loadScrollPositions reads saved offsets; a missing file means none
+func loadScrollPositions() (scrollPositions, error) {
+	path, err := scrollPathFunc()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scroll positions: %w", err)
+	}
+	var positions scrollPositions
+	if err := json.Unmarshal(data, &positions); err != nil {
+		return nil, fmt.Errorf("failed to parse scroll positions: %w", err)
+	}
+	return positions, nil
+}
+
+// saveScrollPositions writes offsets so the next session can restore them.
+// Runs before quitting; failures are dropped since nothing is left to show them.
+func saveScrollPositions(positions scrollPositions) tea.Cmd {
+	return func() tea.Msg {
+		path, err := scrollPathFunc()
+		if err != nil {
+			return nil
+		}
+		data, err := json.Marshal(positions)
+		if err != nil {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil
+		}
+		os.WriteFile(path, data, 0o644)
+		return nil
+	}
+}
+
+// quit saves the open reading session and, when they persist, scroll
+// positions, then exits
+func (m *Model) quit() tea.Cmd {
+	record := m.endReading(time.Now())
+	if !m.persistScrolls {
+		return tea.Sequence(record, tea.Quit)
+	}
+	m.rememberScroll()
+	return tea.Sequence(record, saveScrollPositions(m.scrolls), tea.Quit)
+}
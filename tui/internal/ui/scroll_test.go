@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestReaderScroll_RestoredPerArticle verifies returning to an article restores where it was left.
+// BREAKS: If offsets aren't tracked per item, every reopen jumps back to the top of a long article.
+func TestReaderScroll_RestoredPerArticle(t *testing.T) {
+	long := strings.Repeat("A paragraph of text.\n\n", 200)
+	m := Model{width: 100, height: 40, view: "reader", items: []db.ContentItem{
+		{ID: "a", Title: "First", Content: long},
+		{ID: "b", Title: "Second", Content: long},
+	}}
+	m.updateReaderContent()
+	m.viewport.SetYOffset(25)
+
+	m.cursor = 1
+	m.updateReaderContent()
+	if m.viewport.YOffset != 0 {
+		t.Errorf("Expected a new article to start at the top, got offset %d", m.viewport.YOffset)
+	}
+
+	m.cursor = 0
+	m.updateReaderContent()
+	if m.viewport.YOffset != 25 {
+		t.Errorf("Expected the first article restored to offset 25, got %d", m.viewport.YOffset)
+	}
+
+	// Re-rendering the same article (resize, WHY toggle) keeps the offset
+	m.viewport.SetYOffset(30)
+	m.updateReaderContent()
+	if m.viewport.YOffset != 30 {
+		t.Errorf("Expected a re-render to keep offset 30, got %d", m.viewport.YOffset)
+	}
+}
+
+// TestScrollPositions_PersistOnQuit verifies remember_scroll saves offsets on quit for the next session.
+// BREAKS: If the last article isn't recorded before quitting, its position is lost.
+func TestScrollPositions_PersistOnQuit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scroll_positions.json")
+	scrollPathFunc = func() (string, error) { return path, nil }
+	defer func() { scrollPathFunc = defaultScrollPath }()
+
+	m := Model{width: 100, height: 40, view: "list", persistScrolls: true, items: []db.ContentItem{
+		{ID: "a", Title: "First", Content: strings.Repeat("Line\n\n", 200)},
+	}}
+	m.updateReaderContent()
+	m.viewport.SetYOffset(12)
+
+	cmd := m.quit()
+	if cmd == nil {
+		t.Fatal("Expected a quit command")
+	}
+	saveScrollPositions(m.scrolls)()
+
+	positions, err := loadScrollPositions()
+	if err != nil || positions.get("a") != 12 {
+		t.Errorf("Expected offset 12 saved for the article, got %v (%v)", positions, err)
+	}
+}
+
+// TestScrollPositions_Capped verifies old offsets are dropped past the cap, least recent first.
+// BREAKS: If the list grows forever, the file grows with every article ever read.
+func TestScrollPositions_Capped(t *testing.T) {
+	var positions scrollPositions
+	for i := 0; i <= maxScrollPositions; i++ {
+		positions = positions.set(strings.Repeat("x", i+1), i+1)
+	}
+	if len(positions) != maxScrollPositions {
+		t.Fatalf("Expected %d positions, got %d", maxScrollPositions, len(positions))
+	}
+	if positions.get("x") != 0 {
+		t.Error("Expected the oldest position dropped")
+	}
+	if positions = positions.set("xx", 0); positions.get("xx") != 0 || len(positions) != maxScrollPositions-1 {
+		t.Error("Expected scrolling back to the top to forget the position")
+	}
+}
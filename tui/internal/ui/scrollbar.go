@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollbar renders a one-column track for a view of height rows showing
+// rows offset to offset+height of total. Empty when everything fits.
+func scrollbar(height, total, offset int, theme StyleTheme) string {
+	if height <= 0 || total <= height {
+		return ""
+	}
+	thumb := max(1, height*height/total)
+	// Pin the thumb to the bottom at the end so the last page reads as the end
+	maxOffset := total - height
+	pos := 0
+	if offset >= maxOffset {
+		pos = height - thumb
+	} else if offset > 0 {
+		pos = min(height-thumb, max(1, offset*(height-thumb)/maxOffset))
+	}
+
+	track := lipgloss.NewStyle().Foreground(theme.DarkGray).Render("│")
+	bar := lipgloss.NewStyle().Foreground(theme.Gray).Render("┃")
+	rows := make([]string, height)
+	for i := range rows {
+		rows[i] = track
+		if i >= pos && i < pos+thumb {
+			rows[i] = bar
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// withScrollbar fits view to width columns and puts a scrollbar on its
+// right edge when the content overflows
+func withScrollbar(view string, width, height, total, offset int, theme StyleTheme) string {
+	bar := scrollbar(height, total, offset, theme)
+	if bar == "" {
+		return view
+	}
+	fitted := lipgloss.NewStyle().Width(width).MaxWidth(width).Render(view)
+	return lipgloss.JoinHorizontal(lipgloss.Top, fitted, bar)
+}
+
+// viewportWithScrollbar renders a viewport with a scrollbar beside it
+func viewportWithScrollbar(v viewport.Model, theme StyleTheme) string {
+	return withScrollbar(v.View(), v.Width, v.Height, v.TotalLineCount(), v.YOffset, theme)
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestScrollbar_ThumbTracksOffset verifies the thumb moves from top to bottom as the view scrolls.
+// BREAKS: If the thumb never reaches the bottom, the last page looks like there's more to read.
+func TestScrollbar_ThumbTracksOffset(t *testing.T) {
+	if bar := scrollbar(10, 10, 0, CleanCyberTheme); bar != "" {
+		t.Errorf("Expected no scrollbar when everything fits, got %q", bar)
+	}
+
+	thumbRows := func(bar string) []int {
+		var rows []int
+		for i, row := range strings.Split(ansi.Strip(bar), "\n") {
+			if row == "┃" {
+				rows = append(rows, i)
+			}
+		}
+		return rows
+	}
+
+	top := thumbRows(scrollbar(10, 40, 0, CleanCyberTheme))
+	if len(top) != 2 || top[0] != 0 {
+		t.Errorf("Expected a 2-row thumb at the top, got rows %v", top)
+	}
+	middle := thumbRows(scrollbar(10, 40, 15, CleanCyberTheme))
+	if middle[0] == 0 || middle[len(middle)-1] == 9 {
+		t.Errorf("Expected the thumb mid-track, got rows %v", middle)
+	}
+	bottom := thumbRows(scrollbar(10, 40, 30, CleanCyberTheme))
+	if bottom[len(bottom)-1] != 9 {
+		t.Errorf("Expected the thumb at the bottom, got rows %v", bottom)
+	}
+}
+
+// TestContentList_ScrollbarWhenOverflowing verifies long lists get a scrollbar column and short ones don't.
+// BREAKS: If the bar is drawn past the pane, rows wrap and the list jumps.
+func TestContentList_ScrollbarWhenOverflowing(t *testing.T) {
+	m := Model{view: "list"}
+	for i := 0; i < 30; i++ {
+		m.items = append(m.items, db.ContentItem{Title: fmt.Sprintf("Item %d", i), SourceName: "Feed", Published: time.Now()})
+	}
+
+	lines := strings.Split(renderContentList(m, 50, 10, CleanCyberTheme), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("Expected 10 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w > 48 {
+			t.Errorf("Expected lines within the pane's 48 inner columns, got %d: %q", w, ansi.Strip(line))
+		}
+	}
+	if !strings.HasSuffix(ansi.Strip(lines[0]), "┃") {
+		t.Errorf("Expected the thumb on the first row, got %q", ansi.Strip(lines[0]))
+	}
+
+	m.items = m.items[:3]
+	if list := ansi.Strip(renderContentList(m, 50, 10, CleanCyberTheme)); strings.ContainsAny(list, "┃│") {
+		t.Errorf("Expected no scrollbar for a short list, got %q", list)
+	}
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/filter"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// runningSearch is the search narrowing the list: a saved one by name, or an
+// unsaved /regex/ (no name)
+type runningSearch struct {
+	name string
+	expr string
+}
+
+// searchExpr is the running search, or nil when none is
+func (m Model) searchExpr() *filter.Expr {
+	if m.search.expr == "" {
+		return nil
+	}
+	expr, err := filter.ParseSearch(m.search.expr)
+	if err != nil {
+		return nil
+	}
+	return expr
+}
+
+// archiveScope is what the list draws from: the running search's
+// archived:no|yes|only when it gives one, otherwise the archived view toggle
+func (m Model) archiveScope() db.ArchiveScope {
+	if search := m.searchExpr(); search != nil {
+		if scope, ok := search.Archived(); ok {
+			return scope
+		}
+	}
+	if m.showArchived {
+		return db.ArchivedContent
+	}
+	return db.ActiveContent
+}
+
+// withArchived adds archived items to the synced ones, dropping a synced copy
+// of any item archived since
+func withArchived(items, archived []db.ContentItem) []db.ContentItem {
+	if len(archived) == 0 {
+		return items
+	}
+	isArchived := make(map[string]bool, len(archived))
+	for _, item := range archived {
+		isArchived[item.ID] = true
+	}
+	merged := make([]db.ContentItem, 0, len(items)+len(archived))
+	for _, item := range items {
+		if !isArchived[item.ID] {
+			merged = append(merged, item)
+		}
+	}
+	return append(merged, archived...)
+}
+
+// fetchArchivedRemote fetches the daemon's archived items, which the sync
+// leaves out, and lists them with the synced ones for the current scope
+func fetchArchivedRemote(m Model) tea.Cmd {
+	return withSeq(m.fetchSeq, func() itemsLoadedMsg {
+		client, err := api.NewClientWithURL(m.remoteURL)
+		if err != nil {
+			return itemsLoadedMsg{err: err}
+		}
+		ctx, release := operations.Cancellable()
+		defer release()
+
+		apiItems, err := client.FetchArchivedEntries(ctx, nil)
+		if err != nil {
+			return itemsLoadedMsg{err: err}
+		}
+		// Older daemons send active entries along with the archived ones
+		archived := make([]db.ContentItem, 0)
+		for _, apiItem := range apiItems {
+			if apiItem.ArchivedAt != nil {
+				archived = append(archived, contentFromAPI(apiItem))
+			}
+		}
+
+		items := withArchived(m.itemsCache, archived)
+		return itemsLoadedMsg{
+			items:       applyFiltersClientSide(items, m),
+			hiddenCount: countHiddenUnprioritized(items, m),
+			archived:    archived,
+		}
+	})
+}
+
+// searchNames lists the saved searches alphabetically
+func (m Model) searchNames() []string {
+	names := make([]string, 0, len(m.savedSearches))
+	for name := range m.savedSearches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// handleSearch saves, deletes, runs, or lists saved searches. Running one
+// fetches fresh items, so a search bound to a key shows what's new each time.
+func (m *Model) handleSearch(msg commands.SearchMsg) tea.Cmd {
+	switch msg.Action {
+	case "save":
+		if m.savedSearches == nil {
+			m.savedSearches = make(map[string]string)
+		}
+		m.savedSearches[msg.Name] = msg.Expr
+		var refresh tea.Cmd
+		if m.search.name == msg.Name {
+			m.search.expr = msg.Expr
+			m.loading = true
+			refresh = m.fetchList(false)
+		}
+		return tea.Batch(
+			saveUIState(m.savedLayout()),
+			m.notify(toastSuccess, fmt.Sprintf("Saved search %s: run it with :search %s", msg.Name, msg.Name), 3*time.Second),
+			refresh,
+		)
+
+	case "delete":
+		if _, ok := m.savedSearches[msg.Name]; !ok {
+			return m.notify(toastError, fmt.Sprintf("search: no saved search '%s'", msg.Name), 3*time.Second)
+		}
+		delete(m.savedSearches, msg.Name)
+		var refresh tea.Cmd
+		if m.search.name == msg.Name {
+			m.search = runningSearch{}
+			m.loading = true
+			refresh = m.fetchList(false)
+		}
+		return tea.Batch(
+			saveUIState(m.savedLayout()),
+			m.notify(toastInfo, fmt.Sprintf("Deleted search %s", msg.Name), 2*time.Second),
+			refresh,
+		)
+
+	case "off":
+		if m.search.expr == "" {
+			return m.notify(toastInfo, "No search running", 2*time.Second)
+		}
+		m.search = runningSearch{}
+		m.cursor = 0
+		m.loading = true
+		return m.fetchList(false)
+
+	case "run":
+		search := runningSearch{expr: msg.Expr}
+		if msg.Name != "" {
+			expr, ok := m.savedSearches[msg.Name]
+			if !ok {
+				message := fmt.Sprintf("search: no saved search '%s'", msg.Name)
+				if names := m.searchNames(); len(names) > 0 {
+					message += " (saved: " + strings.Join(names, ", ") + ")"
+				}
+				return m.notify(toastError, message, 4*time.Second)
+			}
+			search = runningSearch{name: msg.Name, expr: expr}
+		}
+		m.search = search
+		m.view = "list"
+		m.cursor = 0
+		m.loading = true
+		return m.fetchList(true)
+	}
+
+	names := m.searchNames()
+	if len(names) == 0 {
+		return m.notify(toastInfo, "No saved searches: :search save <name> <expression>", 4*time.Second)
+	}
+	return m.notify(toastInfo, "Saved searches: "+strings.Join(names, ", ")+" (:search <name> runs one)", 4*time.Second)
+}
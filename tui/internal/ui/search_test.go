@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestSavedSearch_SaveRunAndList verifies a saved search survives a restart, narrows the list when run, and shows in the palette.
+// BREAKS: If running a search doesn't narrow the list, a key bound to ":search cves" just reloads everything.
+func TestSavedSearch_SaveRunAndList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui_state.json")
+	uiStatePathFunc = func() (string, error) { return path, nil }
+	defer func() { uiStatePathFunc = defaultUIStatePath }()
+
+	items := []db.ContentItem{
+		{ID: "cve", Priority: "high", Title: "CVE-2024-1234 in OpenSSL"},
+		{ID: "vuln", Priority: "low", Summary: "A vulnerability in the kernel"},
+		{ID: "other", Priority: "high", Title: "Rust 2024 edition"},
+	}
+	m := Model{view: "list", priority: "all", filterType: "all", showAll: true}
+
+	updated, cmd := m.Update(commands.SearchMsg{Action: "save", Name: "cves", Expr: "CVE OR vulnerability"})
+	m = updated.(Model)
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if msg != nil {
+			msg()
+		}
+	}
+	if saved, err := loadUIState(); err != nil || saved.Searches["cves"] != "CVE OR vulnerability" {
+		t.Fatalf("Expected the search saved, got %v (%v)", saved.Searches, err)
+	}
+	if got := applyFiltersClientSide(items, m); len(got) != 3 {
+		t.Errorf("Expected saving not to run the search, got %d items", len(got))
+	}
+
+	updated, cmd = m.Update(commands.SearchMsg{Action: "run", Name: "cves"})
+	m = updated.(Model)
+	if cmd == nil || !m.loading {
+		t.Error("Expected running the search to fetch fresh items")
+	}
+	got := applyFiltersClientSide(items, m)
+	if len(got) != 2 || got[0].ID == "other" || got[1].ID == "other" {
+		t.Errorf("Expected the two matches, got %v", got)
+	}
+	if state := buildViewStateString(m); !strings.Contains(state, "Search: cves") {
+		t.Errorf("Expected the search in the view state, got %q", state)
+	}
+
+	found := false
+	for _, e := range m.paletteEntries() {
+		if e.label == "Search: cves" && e.hint == "CVE OR vulnerability" && strings.Join(e.line, " ") == "search cves" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected the saved search in the palette")
+	}
+
+	updated, _ = m.Update(commands.SearchMsg{Action: "off"})
+	if m = updated.(Model); len(applyFiltersClientSide(items, m)) != 3 {
+		t.Error("Expected :search off to show everything again")
+	}
+
+	updated, _ = m.Update(commands.SearchMsg{Action: "run", Name: "nope"})
+	if m = updated.(Model); !strings.Contains(lastToast(m), "saved: cves") {
+		t.Errorf("Expected an unknown search to list the saved ones, got %q", lastToast(m))
+	}
+}
+
+// TestRegexSearch_RunsUnsaved verifies :search /pattern/ narrows the list without saving, labelled by its pattern.
+// BREAKS: If an unsaved search needs a name, regex searches can't run or show in the view state.
+func TestRegexSearch_RunsUnsaved(t *testing.T) {
+	items := []db.ContentItem{
+		{ID: "cve", Priority: "high", Title: "CVE-2024-1234 in OpenSSL"},
+		{ID: "other", Priority: "high", Title: "CVE roundup"},
+	}
+	m := Model{view: "reader", priority: "all", filterType: "all", showAll: true}
+
+	updated, _ := m.Update(commands.SearchMsg{Action: "run", Expr: `/cve-\d+/i`})
+	m = updated.(Model)
+	if m.view != "list" {
+		t.Errorf("Expected the list shown, got %s", m.view)
+	}
+	if got := applyFiltersClientSide(items, m); len(got) != 1 || got[0].ID != "cve" {
+		t.Errorf("Expected only the numbered CVE, got %v", got)
+	}
+	if state := buildViewStateString(m); !strings.Contains(state, `Search: /cve-\d+/i`) {
+		t.Errorf("Expected the pattern in the view state, got %q", state)
+	}
+	if len(m.savedSearches) != 0 {
+		t.Errorf("Expected nothing saved, got %v", m.savedSearches)
+	}
+}
+
+// TestParseCommandWithQuotes_KeepsRegexBackslashes verifies a backslash only escapes quotes, spaces, and itself.
+// BREAKS: If every backslash is eaten, :search /cve-\d+/ searches for "cve-d+".
+func TestParseCommandWithQuotes_KeepsRegexBackslashes(t *testing.T) {
+	got := parseCommandWithQuotes(`search /cve-\d+\ x/ "a \"b\"" c\\d`)
+	want := []string{"search", `/cve-\d+ x/`, `a "b"`, `c\d`}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestArchivedSearch_LocalScope verifies archived:yes and archived:only reach archived rows without flipping the archived view.
+// BREAKS: If the search's scope isn't passed to the query, old research stays hidden until the whole list is toggled to archived.
+func TestArchivedSearch_LocalScope(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	dbPath := filepath.Join(dataHome, "prismis", "prismis.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE sources (id TEXT PRIMARY KEY, url TEXT, type TEXT, name TEXT)`,
+		`CREATE TABLE content (id TEXT PRIMARY KEY, source_id TEXT, title TEXT, url TEXT, summary TEXT, content TEXT,
+			analysis TEXT, priority TEXT, published_at TIMESTAMP, read BOOLEAN DEFAULT 0, favorited BOOLEAN DEFAULT 0,
+			archived_at TIMESTAMP)`,
+		`INSERT INTO sources VALUES ('s1', 'https://example.com/feed', 'rss', 'Example')`,
+		`INSERT INTO content (id, source_id, title, url, priority) VALUES ('new', 's1', 'OpenSSL 3.4 released', 'https://example.com/new', 'high')`,
+		`INSERT INTO content (id, source_id, title, url, priority, archived_at) VALUES ('old', 's1', 'OpenSSL heartbleed retrospective', 'https://example.com/old', 'high', '2024-01-01T00:00:00Z')`,
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	conn.Close()
+	db.CloseDB()
+	defer db.CloseDB()
+
+	m := Model{view: "list", priority: "all", filterType: "all", showAll: true}
+	for expr, want := range map[string]string{
+		"openssl":               "new",
+		"openssl archived:yes":  "new,old",
+		"openssl archived:only": "old",
+	} {
+		updated, cmd := m.Update(commands.SearchMsg{Action: "run", Expr: expr})
+		if updated.(Model).showArchived {
+			t.Errorf("%q: expected the archived view left alone", expr)
+		}
+		msg := cmd().(itemsLoadedMsg)
+		if msg.err != nil {
+			t.Fatalf("%q: %v", expr, msg.err)
+		}
+		var ids []string
+		for _, item := range msg.items {
+			if item.Archived != (item.ID == "old") {
+				t.Errorf("%q: expected %s archived=%v", expr, item.ID, item.ID == "old")
+			}
+			ids = append(ids, item.ID)
+		}
+		sort.Strings(ids)
+		if got := strings.Join(ids, ","); got != want {
+			t.Errorf("%q: expected %s, got %s", expr, want, got)
+		}
+	}
+}
+
+// TestArchivedView_Remote verifies v lists just the daemon's archived items in remote mode, as it does locally.
+// BREAKS: The archived view shows the synced feed, or nothing, against a remote daemon.
+func TestArchivedView_Remote(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{ID: "live", Title: "Current", Priority: "high"})
+	daemon.AddEntry(apitest.Entry{ID: "old", Title: "Archived", Priority: "high", Archived: true})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.itemsCache = fetchItemsRemote(m, nil, nil).allItems
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = updated.(Model)
+	updated, _ = m.Update(fetchItemsWithState(m, false)())
+	m = updated.(Model)
+	if cmd == nil || len(m.items) != 1 || m.items[0].ID != "old" {
+		t.Fatalf("Expected only the archived item, got %+v", m.items)
+	}
+}
+
+// TestArchivedSearch_RemoteScope verifies remote mode fetches archived items, which the sync leaves out, for archived:yes.
+// BREAKS: If the cache is only re-filtered, archived:yes finds nothing archived against a remote daemon.
+func TestArchivedSearch_RemoteScope(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{Title: "OpenSSL 3.4 released", Priority: "high"})
+	daemon.AddEntry(apitest.Entry{Title: "OpenSSL heartbleed retrospective", Priority: "high", Archived: true})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	synced := fetchItemsRemote(m, nil, nil)
+	if synced.err != nil || len(synced.allItems) != 1 {
+		t.Fatalf("Expected the sync to bring only the active item, got %d (%v)", len(synced.allItems), synced.err)
+	}
+	m.itemsCache = synced.allItems
+
+	updated, cmd := m.Update(commands.SearchMsg{Action: "run", Expr: "openssl archived:yes"})
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if len(m.items) != 2 || len(m.archivedCache) != 1 || !m.archivedCache[0].Archived {
+		t.Fatalf("Expected both items with the archived one cached, got %d items, %d archived", len(m.items), len(m.archivedCache))
+	}
+	if state := buildViewStateString(m); !strings.Contains(state, "archived:yes") {
+		t.Errorf("Expected the scope in the view state, got %q", state)
+	}
+
+	// Later re-filters reuse the fetched archive
+	updated, _ = m.Update(commands.SearchMsg{Action: "off"})
+	m = updated.(Model)
+	if got := fetchItemsWithState(m, false)().(itemsLoadedMsg); len(got.items) != 1 || got.items[0].Archived {
+		t.Errorf("Expected only the active item once the search is off, got %+v", got.items)
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestSinceFilter_NarrowsByArrival verifies since/until keep items fetched in the window, falling back to published for items without a fetch time.
+// BREAKS: If the window uses published dates only, a backfilled feed's old posts vanish from "what arrived while I was away".
+func TestSinceFilter_NarrowsByArrival(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	items := []db.ContentItem{
+		{ID: "old-post-new-fetch", Priority: "high", Published: now.AddDate(-1, 0, 0), Fetched: now.Add(-time.Hour)},
+		{ID: "last-week", Priority: "high", Published: now.AddDate(0, 0, -7), Fetched: now.AddDate(0, 0, -7)},
+		{ID: "no-fetch-time", Priority: "high", Published: now.Add(-2 * time.Hour)},
+	}
+	ids := func(got []db.ContentItem) string {
+		var names []string
+		for _, item := range got {
+			names = append(names, item.ID)
+		}
+		return strings.Join(names, ",")
+	}
+
+	m := Model{priority: "all", filterType: "all", filterSince: now.AddDate(0, 0, -2)}
+	if got := ids(applyFiltersClientSide(items, m)); got != "old-post-new-fetch,no-fetch-time" {
+		t.Errorf("Expected the two recent arrivals, got %s", got)
+	}
+	m = Model{priority: "all", filterType: "all", filterUntil: now.AddDate(0, 0, -2)}
+	if got := ids(applyFiltersClientSide(items, m)); got != "last-week" {
+		t.Errorf("Expected only last week's item, got %s", got)
+	}
+}
+
+// TestSinceFilter_SetShownAndCleared verifies :filter since= shows in the view state and bare :filter clears it.
+// BREAKS: If the window isn't shown or cleared, the list looks mysteriously short after coming back.
+func TestSinceFilter_SetShownAndCleared(t *testing.T) {
+	m := testModel()
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	updated, _ := m.Update(commands.FilterMsg{Field: "since", Value: "2024-01-01", Time: since})
+	m = updated.(Model)
+	if !m.filterSince.Equal(since) {
+		t.Fatalf("Expected since set, got %v", m.filterSince)
+	}
+	if state := buildViewStateString(m); !strings.Contains(state, "Since: 2024-01-01") {
+		t.Errorf("Expected the window in the view state, got %q", state)
+	}
+
+	updated, _ = m.Update(commands.FilterMsg{})
+	if m = updated.(Model); !m.filterSince.IsZero() {
+		t.Errorf("Expected bare :filter to clear since, got %v", m.filterSince)
+	}
+}
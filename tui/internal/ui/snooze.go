@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// snoozeWakeMsg fires when a snooze set this session runs out, so the item
+// reappears without waiting for the next refresh
+type snoozeWakeMsg struct{}
+
+// isSnoozed reports whether item is hidden by :snooze at now
+func isSnoozed(item db.ContentItem, now time.Time) bool {
+	return item.SnoozedUntil.After(now)
+}
+
+// wokeFromSnooze reports whether item's snooze has run out and it hasn't
+// been read since; these float to the top with a snooze icon
+func wokeFromSnooze(item db.ContentItem, now time.Time) bool {
+	return !item.Read && !item.SnoozedUntil.IsZero() && !item.SnoozedUntil.After(now)
+}
+
+// formatSnoozeUntil describes a wake time for the toast: "15:04" today,
+// otherwise the weekday and time
+func formatSnoozeUntil(until, now time.Time) string {
+	until = until.In(now.Location())
+	if y, m, d := until.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		return until.Format("15:04")
+	}
+	if until.Sub(now) < 7*24*time.Hour {
+		return until.Format("Mon 15:04")
+	}
+	return until.Format("Jan 2 15:04")
+}
+
+// snoozed records a finished :snooze on the item in both the list and the
+// remote cache, then refreshes so it drops out of the list
+func (m *Model) snoozed(msg operations.ArticleSnoozedMsg) tea.Cmd {
+	if !msg.Success {
+		return m.notify(toastError, fmt.Sprintf("Failed to snooze: %v", msg.Error), 5*time.Second)
+	}
+	for i := range m.items {
+		if m.items[i].ID == msg.ID {
+			m.items[i].SnoozedUntil = msg.Until
+		}
+	}
+	for i := range m.itemsCache {
+		if m.itemsCache[i].ID == msg.ID {
+			m.itemsCache[i].SnoozedUntil = msg.Until
+		}
+	}
+	if m.view == "reader" {
+		m.view = "list"
+	}
+
+	text := "Snoozed until " + formatSnoozeUntil(msg.Until, time.Now())
+	cmds := []tea.Cmd{
+		func() tea.Msg { return commands.RefreshMsg{PreserveCursor: true} },
+		tea.Tick(time.Until(msg.Until), func(time.Time) tea.Msg { return snoozeWakeMsg{} }),
+	}
+	if msg.Queued {
+		text += " (offline, will sync)"
+		cmds = append(cmds, operations.CountPendingWrites())
+	}
+	return tea.Batch(append(cmds, m.notify(toastSuccess, text, 3*time.Second))...)
+}
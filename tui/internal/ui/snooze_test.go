@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestSnoozeFilter_HidesThenFloatsWokenItems verifies snoozed items are hidden and woken ones lead the list.
+// BREAKS: If woken items keep their date slot, a snoozed article resurfaces somewhere deep in the backlog.
+func TestSnoozeFilter_HidesThenFloatsWokenItems(t *testing.T) {
+	now := time.Now()
+	items := []db.ContentItem{
+		{ID: "new", Title: "Newest", Priority: "high", Published: now.Add(-time.Hour)},
+		{ID: "later", Title: "Still snoozed", Priority: "high", Published: now.Add(-2 * time.Hour), SnoozedUntil: now.Add(time.Hour)},
+		{ID: "woke", Title: "Back from snooze", Priority: "high", Published: now.AddDate(0, 0, -5), SnoozedUntil: now.Add(-time.Minute)},
+		{ID: "old", Title: "Oldest", Priority: "high", Published: now.AddDate(0, 0, -3)},
+	}
+	m := Model{priority: "all", filterType: "all", sortNewest: true}
+
+	got := applyFiltersClientSide(items, m)
+	var ids []string
+	for _, item := range got {
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "woke,new,old" {
+		t.Fatalf("Expected [woke new old], got %v", ids)
+	}
+
+	m = Model{width: 120, height: 30, view: "list", theme: CleanCyberTheme, items: got}
+	if out := renderContentList(m, 100, 20, CleanCyberTheme); strings.Count(out, "◷") != 1 {
+		t.Errorf("Expected one snooze icon, got:\n%s", out)
+	}
+
+	// Reading a woken item drops it back into its date slot
+	got[0].Read = true
+	m = Model{priority: "all", filterType: "all", sortNewest: true, showAll: true}
+	if resorted := applyFiltersClientSide(got, m); resorted[len(resorted)-1].ID != "woke" {
+		t.Errorf("Expected the read item back in date order, got %v", resorted)
+	}
+}
+
+// TestArticleSnoozed_UpdatesItemAndCache verifies a snooze result lands on the list item and the remote cache.
+// BREAKS: If the cache isn't updated, remote mode re-filters the stale copy and the item never hides.
+func TestArticleSnoozed_UpdatesItemAndCache(t *testing.T) {
+	until := time.Now().Add(3 * time.Hour)
+	m := Model{view: "reader", items: []db.ContentItem{{ID: "a"}}, itemsCache: []db.ContentItem{{ID: "b"}, {ID: "a"}}}
+
+	updated, cmd := m.Update(operations.ArticleSnoozedMsg{ID: "a", Until: until, Success: true})
+	m = updated.(Model)
+	if !m.items[0].SnoozedUntil.Equal(until) || !m.itemsCache[1].SnoozedUntil.Equal(until) {
+		t.Errorf("Expected the snooze on both copies, got %v / %v", m.items[0].SnoozedUntil, m.itemsCache[1].SnoozedUntil)
+	}
+	if m.view != "list" || cmd == nil {
+		t.Errorf("Expected a return to the list and a refresh, got view %q", m.view)
+	}
+}
+
+// TestFormatSnoozeUntil_DescribesWakeTime verifies the toast names the wake time compactly.
+// BREAKS: If today's snoozes show a date, "Snoozed until" reads as if it were days away.
+func TestFormatSnoozeUntil_DescribesWakeTime(t *testing.T) {
+	now := time.Date(2026, 5, 18, 14, 30, 0, 0, time.UTC)
+	cases := map[time.Time]string{
+		now.Add(3 * time.Hour):                       "17:30",
+		time.Date(2026, 5, 19, 9, 0, 0, 0, time.UTC): "Tue 09:00",
+		time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC):  "Jun 1 09:00",
+	}
+	for until, want := range cases {
+		if got := formatSnoozeUntil(until, now); got != want {
+			t.Errorf("For %v expected %q, got %q", until, want, got)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// sortKey is one field of a composed sort order
+type sortKey struct {
+	field string // "priority", "date", "source", "length"
+	desc  bool
+}
+
+// sortFieldAliases maps accepted spellings to sort fields
+var sortFieldAliases = map[string]string{
+	"priority":  "priority",
+	"rank":      "priority",
+	"date":      "date",
+	"published": "date",
+	"source":    "source",
+	"length":    "length",
+	"len":       "length",
+}
+
+// sortDefaultDesc is each field's direction when none is given: high
+// priority, newest and longest first, sources A-Z
+var sortDefaultDesc = map[string]bool{
+	"priority": true,
+	"date":     true,
+	"source":   false,
+	"length":   true,
+}
+
+// parseSortSpec parses a sort order like "priority,date desc": comma-separated
+// fields, each optionally followed by asc or desc
+func parseSortSpec(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		words := strings.Fields(strings.ToLower(part))
+		if len(words) == 0 {
+			continue
+		}
+		field, ok := sortFieldAliases[words[0]]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort key '%s' (available: priority, date, source, length)", words[0])
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("sort key '%s' given twice", field)
+		}
+		seen[field] = true
+
+		key := sortKey{field: field, desc: sortDefaultDesc[field]}
+		switch {
+		case len(words) == 1:
+		case len(words) == 2 && words[1] == "asc":
+			key.desc = false
+		case len(words) == 2 && words[1] == "desc":
+			key.desc = true
+		default:
+			return nil, fmt.Errorf("expected asc or desc after '%s'", words[0])
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no sort keys given")
+	}
+	return keys, nil
+}
+
+// formatSortSpec renders keys back into the :sort syntax
+func formatSortSpec(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		dir := "asc"
+		if key.desc {
+			dir = "desc"
+		}
+		parts[i] = key.field + " " + dir
+	}
+	return strings.Join(parts, ",")
+}
+
+// priorityRank orders priorities from unprioritized (0) to high (3)
+func priorityRank(priority string) int {
+	switch priority {
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	case "low":
+		return 1
+	}
+	return 0
+}
+
+// itemLength is the article length the analysis recorded, falling back to
+// the fetched content
+func itemLength(item *db.ContentItem) int {
+	if a := item.ParsedAnalysis(); a != nil && a.Metadata.ContentLength > 0 {
+		return a.Metadata.ContentLength
+	}
+	return item.Length()
+}
+
+// compareItems orders two items by a single key, ascending
+func compareItems(a, b *db.ContentItem, field string) int {
+	switch field {
+	case "priority":
+		return priorityRank(a.Priority) - priorityRank(b.Priority)
+	case "date":
+		return a.Published.Compare(b.Published)
+	case "source":
+		return strings.Compare(strings.ToLower(a.SourceName), strings.ToLower(b.SourceName))
+	case "length":
+		return itemLength(a) - itemLength(b)
+	}
+	return 0
+}
+
+// sortItems sorts items in place by each key in turn. The sort is stable, so
+// items equal on every key keep their fetched order.
+func sortItems(items []db.ContentItem, keys []sortKey) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, key := range keys {
+			c := compareItems(&items[i], &items[j], key.field)
+			if c == 0 {
+				continue
+			}
+			if key.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+// sortItemsByDate sorts items in place by published date
+func sortItemsByDate(items []db.ContentItem, newest bool) {
+	sortItems(items, []sortKey{{field: "date", desc: newest}})
+}
+
+// sortOrder is the order the current view sorts by: the one saved for it
+// with :sort, otherwise date in the direction d toggles
+func (m Model) sortOrder() []sortKey {
+	if spec, ok := m.viewSorts[m.priority]; ok {
+		if keys, err := parseSortSpec(spec); err == nil {
+			return keys
+		}
+	}
+	return []sortKey{{field: "date", desc: m.sortNewest}}
+}
+
+// toggleDateSort flips the date direction of the current order. A saved
+// order without a date key gains one as its last tiebreaker.
+func (m *Model) toggleDateSort() {
+	if _, ok := m.viewSorts[m.priority]; !ok {
+		m.sortNewest = !m.sortNewest
+		return
+	}
+	keys := m.sortOrder()
+	found := false
+	for i := range keys {
+		if keys[i].field == "date" {
+			keys[i].desc = !keys[i].desc
+			found = true
+		}
+	}
+	if !found {
+		keys = append(keys, sortKey{field: "date", desc: !m.sortNewest})
+	}
+	m.viewSorts[m.priority] = formatSortSpec(keys)
+}
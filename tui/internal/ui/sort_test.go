@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestParseSortSpec verifies keys compose with per-key directions and defaults.
+// BREAKS: If "priority,date desc" misparses, :sort silently orders the wrong way.
+func TestParseSortSpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"priority,date desc", "priority desc,date desc"},
+		{"source, published asc", "source asc,date asc"},
+		{"LEN,rank asc", "length desc,priority asc"},
+	}
+	for _, tt := range tests {
+		keys, err := parseSortSpec(tt.spec)
+		if err != nil {
+			t.Errorf("parseSortSpec(%q) failed: %v", tt.spec, err)
+			continue
+		}
+		if got := formatSortSpec(keys); got != tt.want {
+			t.Errorf("parseSortSpec(%q) = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "title", "date sideways", "date,published"} {
+		if _, err := parseSortSpec(bad); err == nil {
+			t.Errorf("parseSortSpec(%q) should fail", bad)
+		}
+	}
+}
+
+// TestSortItems_MultiKey verifies later keys break ties left by earlier ones.
+// BREAKS: If ties aren't broken, HIGH items land in arbitrary date order.
+func TestSortItems_MultiKey(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	items := []db.ContentItem{
+		{ID: "low-new", Priority: "low", Published: day(20), SourceName: "b"},
+		{ID: "high-old", Priority: "high", Published: day(10), SourceName: "a", Content: "long article text"},
+		{ID: "high-new", Priority: "high", Published: day(15), SourceName: "c", Content: "short"},
+		{ID: "none", Published: day(25), SourceName: "B"},
+	}
+
+	keys, _ := parseSortSpec("priority,date desc")
+	sortItems(items, keys)
+	want := []string{"high-new", "high-old", "low-new", "none"}
+	for i, id := range want {
+		if items[i].ID != id {
+			t.Fatalf("Expected order %v, got %s at %d", want, items[i].ID, i)
+		}
+	}
+
+	keys, _ = parseSortSpec("length,source")
+	sortItems(items, keys)
+	if items[0].ID != "high-old" || items[1].ID != "high-new" {
+		t.Errorf("Expected longest content first, got %s, %s", items[0].ID, items[1].ID)
+	}
+	// Equal lengths fall back to case-insensitive source name, stable otherwise
+	if items[2].ID != "low-new" || items[3].ID != "none" {
+		t.Errorf("Expected source ties kept in order, got %s, %s", items[2].ID, items[3].ID)
+	}
+}
+
+// TestSortCommand_PersistsPerView verifies :sort applies to the current view only and survives a restart.
+// BREAKS: If the order isn't keyed by view, sorting HIGH by source reorders every other view too.
+func TestSortCommand_PersistsPerView(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui_state.json")
+	uiStatePathFunc = func() (string, error) { return path, nil }
+	defer func() { uiStatePathFunc = defaultUIStatePath }()
+
+	m := Model{view: "list", priority: "high", sortNewest: true, items: []db.ContentItem{
+		{ID: "1", SourceName: "zed", Published: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{ID: "2", SourceName: "alpha", Published: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	updated, cmd := m.Update(commands.SortMsg{Spec: "source,date"})
+	m = updated.(Model)
+	if m.items[0].ID != "2" {
+		t.Errorf("Expected items sorted by source, got %s first", m.items[0].ID)
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		msg()
+	}
+
+	state, err := loadUIState()
+	if err != nil || state.Sorts["high"] != "source asc,date desc" {
+		t.Fatalf("Expected the HIGH order saved, got %v (%v)", state.Sorts, err)
+	}
+
+	// Other views keep date order; d flips the saved order's date key
+	m.priority = "all"
+	if got := formatSortSpec(m.sortOrder()); got != "date desc" {
+		t.Errorf("Expected the ALL view to keep date order, got %s", got)
+	}
+	m.priority = "high"
+	m.toggleDateSort()
+	if got := m.viewSorts["high"]; got != "source asc,date asc" {
+		t.Errorf("Expected d to flip the saved date key, got %s", got)
+	}
+	if !m.sortNewest {
+		t.Error("Expected d on a saved order to leave the default direction alone")
+	}
+
+	updated, _ = m.Update(commands.SortMsg{Spec: "default"})
+	m = updated.(Model)
+	if _, ok := m.viewSorts["high"]; ok {
+		t.Error("Expected :sort default to drop the saved order")
+	}
+}
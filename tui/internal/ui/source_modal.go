@@ -9,7 +9,9 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/api"
 	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/feeds"
 	"github.com/nickpending/prismis/internal/ui/operations"
 )
 
@@ -45,17 +47,33 @@ func detectSourceType(url string) string {
 type SourceModal struct {
 	Modal      // Embed base modal
 	sources    []db.Source
+	matches    []db.Source // Sources matching filterInput (cursor indexes this)
 	cursor     int
-	mode       string // "list", "add", "edit", "confirm_remove"
+	mode       string // "list", "add", "discover", "edit", "confirm_remove"
 	editBuffer string // Deprecated - not used anymore
 	errorMsg   string
 
 	// Form fields for add/edit modes - now using textinput.Model
 	urlInput       textinput.Model // URL input field
 	nameInput      textinput.Model // Name input field
-	activeField    string          // Which field is currently being edited
+	categoryInput  textinput.Model // Category input field
+	floorInput     textinput.Model // Priority floor in edit form (high/medium/low)
+	ceilingInput   textinput.Model // Priority ceiling in edit form
+	activeField    string          // Which field is currently being edited: "url", "name", "category", "floor", "ceiling"
 	sourceToDelete string          // ID of source being deleted
 
+	// List filter (fuzzy match on name/URL)
+	filterInput textinput.Model
+	filtering   bool // True while the filter input has focus
+
+	// Feed discovery state (add form -> "discover" mode)
+	discovered     []feeds.Candidate // Feeds found on the page the user entered
+	discoverCursor int               // Selected candidate
+	discoverName   string            // Name entered in the add form, applied to the chosen feed
+
+	// Test-fetch preview state for the add form
+	preview *operations.SourcePreviewMsg // Last preview result (nil if none)
+
 	// Status message for temporary feedback (like main/reader modal)
 	statusMessage string // Temporary status message to display
 
@@ -65,6 +83,12 @@ type SourceModal struct {
 
 	// Remote mode support
 	remoteURL string // If non-empty, use API instead of local DB
+
+	// The daemon keeps per-source priority rules, so the edit form offers them
+	rules bool
+
+	// Daily item counts per source ID for the sparklines (nil until loaded)
+	activity map[string][]int
 }
 
 // NewSourceModal creates a new SourceModal instance
@@ -83,14 +107,41 @@ func NewSourceModal() SourceModal {
 	nameInput.Width = 36
 	nameInput.CharLimit = 100
 
+	// Create category input
+	categoryInput := textinput.New()
+	categoryInput.Placeholder = "Optional category (e.g. work)"
+	categoryInput.Width = 36
+	categoryInput.CharLimit = 50
+
+	// Create priority rule inputs
+	floorInput := textinput.New()
+	floorInput.Placeholder = "high, medium, low, or empty for none"
+	floorInput.Width = 36
+	floorInput.CharLimit = 6
+	ceilingInput := textinput.New()
+	ceilingInput.Placeholder = "high, medium, low, or empty for none"
+	ceilingInput.Width = 36
+	ceilingInput.CharLimit = 6
+
+	// Create list filter input
+	filterInput := textinput.New()
+	filterInput.Prompt = "/ "
+	filterInput.Placeholder = "filter by name or URL"
+	filterInput.Width = 36
+	filterInput.CharLimit = 100
+
 	return SourceModal{
-		Modal:       NewModal("SOURCES", 45, 12),
-		mode:        "list",
-		urlInput:    urlInput,
-		nameInput:   nameInput,
-		activeField: "url", // Default to URL field
-		viewport:    vp,
-		ready:       false,
+		Modal:         NewModal("SOURCES", 45, 12),
+		mode:          "list",
+		urlInput:      urlInput,
+		nameInput:     nameInput,
+		categoryInput: categoryInput,
+		floorInput:    floorInput,
+		ceilingInput:  ceilingInput,
+		filterInput:   filterInput,
+		activeField:   "url", // Default to URL field
+		viewport:      vp,
+		ready:         false,
 	}
 }
 
@@ -99,11 +150,23 @@ func (m *SourceModal) SetRemoteURL(url string) {
 	m.remoteURL = url
 }
 
+// SetRules says whether the daemon takes per-source priority rules
+func (m *SourceModal) SetRules(supported bool) {
+	m.rules = supported
+}
+
 // SetSize updates the modal size based on terminal dimensions
 func (m *SourceModal) SetSize(width, height int) {
-	// Small fixed size for source modal
+	// Fixed width (wider when there's room for the activity sparklines);
+	// height grows with the terminal so long source lists can scroll
 	modalWidth := 45
-	modalHeight := 12
+	if width >= 74 {
+		modalWidth = 68
+	}
+	modalHeight := height * 2 / 3
+	if modalHeight < 12 {
+		modalHeight = 12
+	}
 
 	// Only adjust if terminal is really small
 	if width < 50 {
@@ -119,8 +182,8 @@ func (m *SourceModal) SetSize(width, height int) {
 	m.Modal.height = modalHeight
 
 	// Update viewport size to fill the modal
-	// Account for: border (2) + padding (2) + header (2) + status bar (1) = 7
-	vpHeight := modalHeight - 7
+	// Account for: border (2) + padding (2) + header (2) + filter line (1) + status bar (1) = 8
+	vpHeight := modalHeight - 8
 	if vpHeight < 3 {
 		vpHeight = 3
 	}
@@ -132,17 +195,14 @@ func (m *SourceModal) SetSize(width, height int) {
 // LoadSources updates the modal with fresh source data
 func (m *SourceModal) LoadSources(sources []db.Source) {
 	m.sources = sources
-	// Reset cursor if it's out of bounds
-	if m.cursor >= len(m.sources) && len(m.sources) > 0 {
-		m.cursor = len(m.sources) - 1
-	}
+	m.applyFilter()
 	// Update the modal content to reflect the new sources if visible and in list mode
 	if m.visible && m.mode == "list" {
 		m.UpdateContent()
 	}
 	// Ensure viewport is initialized if not ready
 	if !m.ready && m.height > 0 {
-		vpHeight := m.height - 7
+		vpHeight := m.height - 8
 		if vpHeight < 3 {
 			vpHeight = 3
 		}
@@ -153,6 +213,130 @@ func (m *SourceModal) LoadSources(sources []db.Source) {
 	}
 }
 
+// applyFilter recomputes the matching sources and keeps the cursor in bounds
+func (m *SourceModal) applyFilter() {
+	query := strings.TrimSpace(m.filterInput.Value())
+	if query == "" {
+		m.matches = m.sources
+	} else {
+		m.matches = make([]db.Source, 0, len(m.sources))
+		for _, source := range m.sources {
+			if fuzzyMatch(query, source.Name) || fuzzyMatch(query, source.URL) {
+				m.matches = append(m.matches, source)
+			}
+		}
+	}
+
+	if m.cursor >= len(m.matches) {
+		m.cursor = max(0, len(m.matches)-1)
+	}
+}
+
+// clearFilter empties the list filter and shows all sources again
+func (m *SourceModal) clearFilter() {
+	m.filtering = false
+	m.filterInput.SetValue("")
+	m.filterInput.Blur()
+	m.applyFilter()
+}
+
+// moveCursor moves the list cursor by delta, clamped to the matches
+func (m *SourceModal) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.matches) {
+		m.cursor = len(m.matches) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// scrollToCursor keeps the selected row inside the viewport (one line per source)
+func (m *SourceModal) scrollToCursor() {
+	if m.viewport.Height <= 0 {
+		return
+	}
+	if m.cursor < m.viewport.YOffset {
+		m.viewport.SetYOffset(m.cursor)
+	} else if m.cursor >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(m.cursor - m.viewport.Height + 1)
+	}
+}
+
+// focusField moves input focus to the named form field ("url", "name",
+// "category", "floor", "ceiling")
+func (m *SourceModal) focusField(field string) {
+	m.activeField = field
+	m.urlInput.Blur()
+	m.nameInput.Blur()
+	m.categoryInput.Blur()
+	m.floorInput.Blur()
+	m.ceilingInput.Blur()
+	switch field {
+	case "name":
+		m.nameInput.Focus()
+	case "category":
+		m.categoryInput.Focus()
+	case "floor":
+		m.floorInput.Focus()
+	case "ceiling":
+		m.ceilingInput.Focus()
+	default:
+		m.urlInput.Focus()
+	}
+}
+
+// nextField returns the form field after the active one, wrapping around
+func (m SourceModal) nextField() string {
+	switch m.activeField {
+	case "url":
+		return "name"
+	case "name":
+		return "category"
+	case "category":
+		if m.mode == "edit" && m.rules {
+			return "floor"
+		}
+		return "url"
+	case "floor":
+		return "ceiling"
+	default:
+		return "url"
+	}
+}
+
+// resetForm clears and blurs all add/edit form fields
+func (m *SourceModal) resetForm() {
+	m.urlInput.SetValue("")
+	m.nameInput.SetValue("")
+	m.categoryInput.SetValue("")
+	m.floorInput.SetValue("")
+	m.ceilingInput.SetValue("")
+	m.urlInput.Blur()
+	m.nameInput.Blur()
+	m.categoryInput.Blur()
+	m.floorInput.Blur()
+	m.ceilingInput.Blur()
+}
+
+// updateActiveInput lets the focused textinput handle a key (including paste!)
+func (m *SourceModal) updateActiveInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch m.activeField {
+	case "name":
+		m.nameInput, cmd = m.nameInput.Update(msg)
+	case "category":
+		m.categoryInput, cmd = m.categoryInput.Update(msg)
+	case "floor":
+		m.floorInput, cmd = m.floorInput.Update(msg)
+	case "ceiling":
+		m.ceilingInput, cmd = m.ceilingInput.Update(msg)
+	default:
+		m.urlInput, cmd = m.urlInput.Update(msg)
+	}
+	return cmd
+}
+
 // Update handles input for the source modal
 func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 	if !m.visible {
@@ -163,41 +347,71 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 	case tea.KeyMsg:
 		switch m.mode {
 		case "list":
+			// While typing a filter, keys go to the filter input
+			if m.filtering {
+				switch msg.String() {
+				case "esc":
+					m.clearFilter()
+				case "enter":
+					// Keep the filter, return keys to the list
+					m.filtering = false
+					m.filterInput.Blur()
+				case "down", "ctrl+n":
+					m.moveCursor(1)
+				case "up", "ctrl+p":
+					m.moveCursor(-1)
+				default:
+					var cmd tea.Cmd
+					m.filterInput, cmd = m.filterInput.Update(msg)
+					m.cursor = 0
+					m.applyFilter()
+					m.UpdateContent()
+					return m, cmd
+				}
+				break
+			}
+
 			switch msg.String() {
 			case "j", "down":
-				if m.cursor < len(m.sources)-1 {
-					m.cursor++
-				}
+				m.moveCursor(1)
 			case "k", "up":
-				if m.cursor > 0 {
-					m.cursor--
-				}
+				m.moveCursor(-1)
+			case "ctrl+d", "pgdown":
+				m.moveCursor(max(1, m.viewport.Height))
+			case "ctrl+u", "pgup":
+				m.moveCursor(-max(1, m.viewport.Height))
+			case "g", "home":
+				m.cursor = 0
+			case "G", "end":
+				m.moveCursor(len(m.matches))
+			case "/":
+				m.filtering = true
+				m.filterInput.Focus()
+				m.errorMsg = ""
 			case "a":
 				m.mode = "add"
 				// Reset textinput fields
-				m.urlInput.SetValue("")
-				m.nameInput.SetValue("")
-				m.activeField = "url"
-				m.urlInput.Focus()
-				m.nameInput.Blur()
+				m.resetForm()
+				m.focusField("url")
 				m.errorMsg = ""
 			case "enter":
 				// Enter edits the selected source
-				if len(m.sources) > 0 {
+				if len(m.matches) > 0 {
 					m.mode = "edit"
-					source := m.sources[m.cursor]
+					source := m.matches[m.cursor]
 					// Set textinput values
 					m.urlInput.SetValue(source.URL)
 					m.nameInput.SetValue(source.Name)
-					m.activeField = "url" // Start with URL field for consistency
-					m.urlInput.Focus()
-					m.nameInput.Blur()
+					m.categoryInput.SetValue(source.Category)
+					m.floorInput.SetValue(source.PriorityFloor)
+					m.ceilingInput.SetValue(source.PriorityCeiling)
+					m.focusField("url") // Start with URL field for consistency
 					m.errorMsg = ""
 				}
 			case "p":
 				// Toggle pause/resume for selected source
-				if len(m.sources) > 0 && m.cursor < len(m.sources) {
-					source := m.sources[m.cursor]
+				if len(m.matches) > 0 && m.cursor < len(m.matches) {
+					source := m.matches[m.cursor]
 					if source.Active {
 						return m, operations.PauseSource(source.ID)
 					} else {
@@ -205,13 +419,19 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 					}
 				}
 			case "d":
-				if len(m.sources) > 0 && m.cursor < len(m.sources) {
+				if len(m.matches) > 0 && m.cursor < len(m.matches) {
 					m.mode = "confirm_remove"
-					m.sourceToDelete = m.sources[m.cursor].ID
+					m.sourceToDelete = m.matches[m.cursor].ID
 					m.errorMsg = ""
 				}
 			case "esc", "q":
+				// First esc clears an active filter; the next one closes
+				if msg.String() == "esc" && m.filterInput.Value() != "" {
+					m.clearFilter()
+					break
+				}
 				m.Hide()
+				m.clearFilter()
 				m.mode = "list"
 				m.errorMsg = ""
 			}
@@ -220,15 +440,7 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 			switch msg.String() {
 			case "tab":
 				// Switch between URL and name fields
-				if m.activeField == "url" {
-					m.activeField = "name"
-					m.urlInput.Blur()
-					m.nameInput.Focus()
-				} else {
-					m.activeField = "url"
-					m.nameInput.Blur()
-					m.urlInput.Focus()
-				}
+				m.focusField(m.nextField())
 			case "enter":
 				// Add source using textinput values
 				url := strings.TrimSpace(m.urlInput.Value())
@@ -238,57 +450,89 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 				}
 
 				name := strings.TrimSpace(m.nameInput.Value())
-				return m, operations.AddSource(url, name)
+				category := strings.TrimSpace(m.categoryInput.Value())
+
+				// Plain website URLs are probed for <link rel="alternate"> feeds first
+				if operations.NeedsFeedDiscovery(url) {
+					m.statusMessage = "Looking for feeds..."
+					m.errorMsg = ""
+					return m, operations.DiscoverFeeds(url, name)
+				}
+				return m, retryable("add source", url, operations.AddSource(url, name, category))
+			case "ctrl+t":
+				// Dry-run fetch so the user can sanity-check the source before saving
+				url := strings.TrimSpace(m.urlInput.Value())
+				if url == "" {
+					m.errorMsg = "URL is required"
+					return m, nil
+				}
+				m.preview = nil
+				m.errorMsg = ""
+				m.statusMessage = "Testing source..."
+				m.UpdateContent()
+				return m, operations.PreviewSource(url)
 			case "esc":
 				m.mode = "list"
-				m.urlInput.SetValue("")
-				m.nameInput.SetValue("")
-				m.urlInput.Blur()
-				m.nameInput.Blur()
+				m.resetForm()
 				m.errorMsg = ""
+				m.preview = nil
 			default:
 				// Let textinput handle all other keys (including paste!)
-				var cmd tea.Cmd
-				if m.activeField == "url" {
-					m.urlInput, cmd = m.urlInput.Update(msg)
-				} else {
-					m.nameInput, cmd = m.nameInput.Update(msg)
+				return m, m.updateActiveInput(msg)
+			}
+
+		case "discover":
+			switch msg.String() {
+			case "j", "down":
+				if m.discoverCursor < len(m.discovered)-1 {
+					m.discoverCursor++
 				}
-				return m, cmd
+			case "k", "up":
+				if m.discoverCursor > 0 {
+					m.discoverCursor--
+				}
+			case "enter":
+				if m.discoverCursor < len(m.discovered) {
+					url := m.discovered[m.discoverCursor].URL
+					return m, retryable("add source", url, operations.AddSource(url, m.discoverName, strings.TrimSpace(m.categoryInput.Value())))
+				}
+			case "esc":
+				// Back to the add form with the original input intact
+				m.mode = "add"
+				m.discovered = nil
+				m.discoverCursor = 0
+				m.errorMsg = ""
 			}
 
 		case "edit":
 			switch msg.String() {
 			case "tab":
 				// Switch between URL and name fields (consistent with add)
-				if m.activeField == "url" {
-					m.activeField = "name"
-					m.urlInput.Blur()
-					m.nameInput.Focus()
-				} else {
-					m.activeField = "url"
-					m.nameInput.Blur()
-					m.urlInput.Focus()
-				}
+				m.focusField(m.nextField())
 			case "enter":
 				// Prepare to update source
-				if m.cursor >= len(m.sources) {
+				if m.cursor >= len(m.matches) {
 					m.errorMsg = "No source selected"
 					return m, nil
 				}
 
-				source := m.sources[m.cursor]
+				source := m.matches[m.cursor]
 				url := strings.TrimSpace(m.urlInput.Value())
 				name := strings.TrimSpace(m.nameInput.Value())
+				category := strings.TrimSpace(m.categoryInput.Value())
+				floor := strings.ToLower(strings.TrimSpace(m.floorInput.Value()))
+				ceiling := strings.ToLower(strings.TrimSpace(m.ceilingInput.Value()))
+				if err := checkSourceRule(floor, ceiling); err != nil {
+					m.errorMsg = err.Error()
+					return m, nil
+				}
 
 				// Check if anything actually changed
-				if url == source.URL && name == source.Name {
+				if url == source.URL && name == source.Name && category == source.Category &&
+					floor == source.PriorityFloor && ceiling == source.PriorityCeiling {
 					// No changes made, just go back to list
 					m.mode = "list"
-					m.urlInput.SetValue("")
-					m.nameInput.SetValue("")
-					m.urlInput.Blur()
-					m.nameInput.Blur()
+					m.resetForm()
 					m.errorMsg = ""
 					return m, nil
 				}
@@ -301,14 +545,20 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 				if name != "" {
 					updates["name"] = name
 				}
+				if category != source.Category {
+					updates["category"] = category // Empty clears the category
+				}
+				if floor != source.PriorityFloor {
+					updates["priority_floor"] = floor // Empty clears the rule
+				}
+				if ceiling != source.PriorityCeiling {
+					updates["priority_ceiling"] = ceiling
+				}
 
 				// Clear form and go back to list
 				// The actual update will happen via the command
 				m.mode = "list"
-				m.urlInput.SetValue("")
-				m.nameInput.SetValue("")
-				m.urlInput.Blur()
-				m.nameInput.Blur()
+				m.resetForm()
 				m.errorMsg = ""
 
 				// Update content before returning
@@ -318,26 +568,25 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 				return m, operations.UpdateSource(source.ID, updates)
 			case "esc":
 				m.mode = "list"
-				m.urlInput.SetValue("")
-				m.nameInput.SetValue("")
-				m.urlInput.Blur()
-				m.nameInput.Blur()
+				m.resetForm()
 				m.errorMsg = ""
 			default:
 				// Let textinput handle all other keys (including paste!)
-				var cmd tea.Cmd
-				if m.activeField == "url" {
-					m.urlInput, cmd = m.urlInput.Update(msg)
-				} else {
-					m.nameInput, cmd = m.nameInput.Update(msg)
-				}
-				return m, cmd
+				return m, m.updateActiveInput(msg)
 			}
 
 		case "confirm_remove":
+			// Choice of what happens to the source's items
+			var retention string
 			switch msg.String() {
-			case "y":
-				// Delete source
+			case "y", "d":
+				retention = api.RetentionDelete
+			case "k":
+				retention = api.RetentionKeep
+			case "a":
+				retention = api.RetentionArchive
+			}
+			if retention != "" {
 				if m.sourceToDelete == "" {
 					m.errorMsg = "No source selected for deletion"
 					m.mode = "list"
@@ -345,8 +594,10 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 				}
 
 				// Use shared removal function
-				return m, operations.RemoveSource(m.sourceToDelete)
+				return m, operations.RemoveSourceWithRetention(m.sourceToDelete, retention)
+			}
 
+			switch msg.String() {
 			case "n", "esc":
 				m.mode = "list"
 				m.sourceToDelete = ""
@@ -354,14 +605,39 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 			}
 		}
 
+	case operations.SourcePreviewMsg:
+		m.statusMessage = ""
+		// Ignore results for a URL the user has since changed or left
+		if m.mode != "add" || msg.URL != strings.TrimSpace(m.urlInput.Value()) {
+			return m, nil
+		}
+		m.preview = &msg
+		m.UpdateContent()
+		return m, nil
+
+	case operations.FeedDiscoveryMsg:
+		m.statusMessage = ""
+		if msg.Error != nil || len(msg.Candidates) == 0 {
+			// Nothing advertised (or page unreachable) - let the daemon validate the URL as given
+			return m, retryable("add source", msg.PageURL, operations.AddSource(msg.PageURL, msg.Name, strings.TrimSpace(m.categoryInput.Value())))
+		}
+		m.mode = "discover"
+		m.discovered = msg.Candidates
+		m.discoverCursor = 0
+		m.discoverName = msg.Name
+		m.errorMsg = ""
+		m.UpdateContent()
+		return m, nil
+
 	case operations.SourceOperationMsg:
 		if msg.Success {
 			// Success: return to list mode with status message
 			m.statusMessage = msg.Message
 			m.mode = "list"
-			m.urlInput.SetValue("")
-			m.nameInput.SetValue("")
+			m.resetForm()
 			m.sourceToDelete = "" // Clear deletion state
+			m.discovered = nil
+			m.preview = nil
 			m.errorMsg = ""
 			m.UpdateContent()
 			return m, tea.Batch(
@@ -379,6 +655,14 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 			return m, nil
 		}
 
+	case sourceActivityMsg:
+		// Without activity (e.g. an older daemon) the list just omits the sparklines
+		if msg.err == nil {
+			m.activity = msg.counts
+			m.UpdateContent()
+		}
+		return m, nil
+
 	case clearStatusMsg:
 		m.statusMessage = ""
 		// Update content to refresh status bar
@@ -389,11 +673,9 @@ func (m SourceModal) Update(msg tea.Msg) (SourceModal, tea.Cmd) {
 	// Update the modal content based on current mode
 	m.UpdateContent()
 
-	// Also update viewport if in list mode
+	// Keep the cursor row visible in list mode
 	if m.mode == "list" {
-		var cmd tea.Cmd
-		m.viewport, cmd = m.viewport.Update(msg)
-		return m, cmd
+		m.scrollToCursor()
 	}
 
 	return m, nil
@@ -408,6 +690,8 @@ func (m *SourceModal) UpdateContent() {
 		m.SetContent(m.renderList())
 	case "add":
 		m.SetContent(m.renderAddForm())
+	case "discover":
+		m.SetContent(m.renderDiscoverContentOnly())
 	case "edit":
 		m.SetContent(m.renderEditForm())
 	case "confirm_remove":
@@ -437,8 +721,10 @@ func (m SourceModal) renderList() string {
 		noSourcesStyle := theme.MutedStyle().Italic(true)
 		lines = append(lines, "", noSourcesStyle.Render("No sources configured"))
 		lines = append(lines, "", theme.MutedStyle().Render("Press [a] to add your first source"))
+	} else if len(m.matches) == 0 {
+		lines = append(lines, theme.MutedStyle().Italic(true).Render("No sources match"))
 	} else {
-		for i, source := range m.sources {
+		for i, source := range m.matches {
 			// Status indicator
 			var status string
 			if !source.Active {
@@ -520,13 +806,18 @@ func (m SourceModal) renderAddForm() string {
 	lines = append(lines, m.nameInput.View())
 	lines = append(lines, "")
 
+	// Category field
+	lines = append(lines, labelStyle.Render("Category (optional):"))
+	lines = append(lines, m.categoryInput.View())
+	lines = append(lines, "")
+
 	// Help text
 	lines = append(lines, theme.MutedStyle().Render("Supported: RSS/Atom feeds, Reddit URLs, YouTube channels, .md/.txt files"))
 	lines = append(lines, "")
 
 	// Commands
 	commandStyle := theme.MutedStyle()
-	lines = append(lines, commandStyle.Render("[tab] switch [\u21b5] save [esc] cancel"))
+	lines = append(lines, commandStyle.Render("[tab] switch [^t] test [\u21b5] save [esc] cancel"))
 
 	// Error message if any
 	if m.errorMsg != "" {
@@ -540,7 +831,7 @@ func (m SourceModal) renderAddForm() string {
 // renderEditForm renders the edit source form
 func (m SourceModal) renderEditForm() string {
 	theme := CleanCyberTheme
-	if m.cursor >= len(m.sources) {
+	if m.cursor >= len(m.matches) {
 		return "Invalid source selection"
 	}
 
@@ -562,6 +853,17 @@ func (m SourceModal) renderEditForm() string {
 	lines = append(lines, m.nameInput.View())
 	lines = append(lines, "")
 
+	// Category field
+	lines = append(lines, labelStyle.Render("Category (optional):"))
+	lines = append(lines, m.categoryInput.View())
+	lines = append(lines, "")
+
+	// Priority rule, when the daemon keeps them
+	if m.rules {
+		lines = append(lines, m.renderRuleFields(labelStyle)...)
+		lines = append(lines, "")
+	}
+
 	// Commands
 	commandStyle := theme.MutedStyle()
 	lines = append(lines, commandStyle.Render("[tab] switch [\u21b5] save [esc] cancel"))
@@ -591,6 +893,8 @@ func (m SourceModal) View(theme StyleTheme) string {
 	switch m.mode {
 	case "add":
 		modeStr = "ADD SOURCE"
+	case "discover":
+		modeStr = "SELECT FEED"
 	case "edit":
 		modeStr = "EDIT SOURCE"
 	case "confirm_remove":
@@ -604,6 +908,9 @@ func (m SourceModal) View(theme StyleTheme) string {
 	// Count on right (for list mode)
 	if m.mode == "list" && len(m.sources) > 0 {
 		countText := fmt.Sprintf("%d sources", len(m.sources))
+		if m.filterInput.Value() != "" {
+			countText = fmt.Sprintf("%d/%d sources", len(m.matches), len(m.sources))
+		}
 		countStyle := lipgloss.NewStyle().Foreground(theme.Gray)
 		countRendered := countStyle.Render(countText)
 
@@ -625,7 +932,13 @@ func (m SourceModal) View(theme StyleTheme) string {
 	var mainContentStr string
 
 	if m.mode == "list" {
-		// For list mode, use viewport
+		// For list mode, filter line above the scrolling viewport
+		if m.filtering || m.filterInput.Value() != "" {
+			content.WriteString(m.filterInput.View())
+		} else {
+			content.WriteString(lipgloss.NewStyle().Foreground(theme.Gray).Render("[/] filter"))
+		}
+		content.WriteString("\n")
 		content.WriteString(m.viewport.View())
 		mainContentStr = content.String()
 	} else {
@@ -634,6 +947,8 @@ func (m SourceModal) View(theme StyleTheme) string {
 		switch m.mode {
 		case "add":
 			mainContent = m.renderAddContentOnly()
+		case "discover":
+			mainContent = m.renderDiscoverContentOnly()
 		case "edit":
 			mainContent = m.renderEditContentOnly()
 		case "confirm_remove":
@@ -652,11 +967,19 @@ func (m SourceModal) View(theme StyleTheme) string {
 		// Show commands when no status message
 		switch m.mode {
 		case "list":
-			statusContent = "[a]dd [↵] edit [d]elete [esc] close"
-		case "add", "edit":
+			if m.filtering {
+				statusContent = "[↑/↓] move [↵] done [esc] clear"
+			} else {
+				statusContent = "[/] filter [a]dd [↵] edit [d]elete [esc] close"
+			}
+		case "add":
+			statusContent = "[tab] switch [^t] test [↵] save [esc] cancel"
+		case "edit":
 			statusContent = "[tab] switch [↵] save [esc] cancel"
+		case "discover":
+			statusContent = "[j/k] select [↵] add [esc] back"
 		case "confirm_remove":
-			statusContent = "[y] delete [n] cancel"
+			statusContent = "[d]elete [k]eep [a]rchive [esc] cancel"
 		}
 	}
 
@@ -698,8 +1021,10 @@ func (m SourceModal) renderListContentOnly() string {
 		noSourcesStyle := theme.MutedStyle().Italic(true)
 		lines = append(lines, "", noSourcesStyle.Render("No sources configured"))
 		lines = append(lines, "", theme.MutedStyle().Render("Press [a] to add your first source"))
+	} else if len(m.matches) == 0 {
+		lines = append(lines, theme.MutedStyle().Italic(true).Render("No sources match"))
 	} else {
-		for i, source := range m.sources {
+		for i, source := range m.matches {
 			// Status indicator
 			var status string
 			if !source.Active {
@@ -771,15 +1096,19 @@ func (m SourceModal) renderListContentOnly() string {
 			}
 
 			// Build columnar line
-			line := fmt.Sprintf("%s%s %s%s %s%s %s",
+			line := fmt.Sprintf("%s%s %s%s %s%s%s %s",
 				selector,
 				status,
 				nameStr,
 				strings.Repeat(" ", titlePadding),
+				m.renderActivity(source.ID),
 				typeStr,
 				strings.Repeat(" ", typePadding),
 				countStr,
 			)
+			if rule := sourceRuleBadge(source); rule != "" {
+				line += " " + theme.MutedStyle().Render(rule)
+			}
 
 			lines = append(lines, line)
 		}
@@ -794,6 +1123,26 @@ func (m SourceModal) renderListContentOnly() string {
 	return strings.Join(lines, "\n")
 }
 
+// renderActivity renders a source's sparkline and item total for the
+// activity column, or nothing when the counts aren't loaded or don't fit
+func (m SourceModal) renderActivity(sourceID string) string {
+	if m.activity == nil || m.viewport.Width < 60 {
+		return ""
+	}
+	theme := CleanCyberTheme
+	counts := m.activity[sourceID]
+	if counts == nil {
+		// Nothing published in the window: a flat, muted line flags a dead feed
+		return theme.MutedStyle().Render(strings.Repeat(string(sparkBlocks[0]), activityDays)+fmt.Sprintf(" %4d", 0)) + " "
+	}
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return lipgloss.NewStyle().Foreground(theme.Purple).Render(sparkline(counts)) +
+		theme.MutedStyle().Render(fmt.Sprintf(" %4d", total)) + " "
+}
+
 // renderAddContentOnly renders just the add form content
 func (m SourceModal) renderAddContentOnly() string {
 	theme := CleanCyberTheme
@@ -810,9 +1159,81 @@ func (m SourceModal) renderAddContentOnly() string {
 	lines = append(lines, m.nameInput.View())
 	lines = append(lines, "")
 
+	// Category field
+	lines = append(lines, labelStyle.Render("Category (optional):"))
+	lines = append(lines, m.categoryInput.View())
+	lines = append(lines, "")
+
 	// Help text
 	lines = append(lines, theme.MutedStyle().Render("Supported: RSS/Atom feeds, Reddit URLs, YouTube channels"))
 
+	// Test-fetch result, only while the URL still matches what was tested
+	if m.preview != nil && m.preview.URL == strings.TrimSpace(m.urlInput.Value()) {
+		lines = append(lines, "")
+		lines = append(lines, m.renderPreview()...)
+	}
+
+	// Error message if any
+	if m.errorMsg != "" {
+		lines = append(lines, "")
+		lines = append(lines, theme.ErrorStyle().Render("⚠ "+m.errorMsg))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderPreview renders the result of a test fetch as form lines
+func (m SourceModal) renderPreview() []string {
+	theme := CleanCyberTheme
+	p := m.preview
+
+	if p.Error != nil {
+		return []string{theme.ErrorStyle().Render("✗ Test failed: " + p.Error.Error())}
+	}
+	if p.Preview == nil {
+		// Non-feed sources are fetched by the daemon only
+		return []string{theme.MutedStyle().Render(fmt.Sprintf("Detected type: %s (fetched by daemon, no local preview)", p.SourceType))}
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(theme.Green).Render(
+			fmt.Sprintf("✓ %s feed · %d item%s", p.Preview.Format, p.Preview.ItemCount, pluralize(p.Preview.ItemCount))),
+	}
+	if p.Preview.LatestTitle != "" {
+		lines = append(lines, theme.TextStyle().Render("Latest: "+sourceModalTruncate(p.Preview.LatestTitle, m.width-16)))
+	}
+	return lines
+}
+
+// renderDiscoverContentOnly renders the list of feeds found on the entered page
+func (m SourceModal) renderDiscoverContentOnly() string {
+	theme := CleanCyberTheme
+	var lines []string
+
+	lines = append(lines, theme.MutedStyle().Render(fmt.Sprintf("Found %d feed%s:", len(m.discovered), pluralize(len(m.discovered)))))
+
+	for i, candidate := range m.discovered {
+		selector := "  "
+		label := candidate.Title
+		if label == "" {
+			label = candidate.URL
+		}
+		label = sourceModalTruncate(label, m.width-10)
+
+		if i == m.discoverCursor {
+			selector = lipgloss.NewStyle().Foreground(theme.Cyan).Render("▸ ")
+			label = lipgloss.NewStyle().Foreground(theme.White).Bold(true).Render(label)
+		} else {
+			label = theme.TextStyle().Render(label)
+		}
+		lines = append(lines, selector+label)
+
+		// Show the URL under titled entries so the choice is unambiguous
+		if candidate.Title != "" && i == m.discoverCursor {
+			lines = append(lines, "    "+theme.MutedStyle().Render(sourceModalTruncate(candidate.URL, m.width-10)))
+		}
+	}
+
 	// Error message if any
 	if m.errorMsg != "" {
 		lines = append(lines, "")
@@ -825,7 +1246,7 @@ func (m SourceModal) renderAddContentOnly() string {
 // renderEditContentOnly renders just the edit form content
 func (m SourceModal) renderEditContentOnly() string {
 	theme := CleanCyberTheme
-	if m.cursor >= len(m.sources) {
+	if m.cursor >= len(m.matches) {
 		return "Invalid source selection"
 	}
 
@@ -841,6 +1262,17 @@ func (m SourceModal) renderEditContentOnly() string {
 	// Name field (second - consistent with add form)
 	lines = append(lines, labelStyle.Render("Name:"))
 	lines = append(lines, m.nameInput.View())
+	lines = append(lines, "")
+
+	// Category field
+	lines = append(lines, labelStyle.Render("Category (optional):"))
+	lines = append(lines, m.categoryInput.View())
+
+	// Priority rule, when the daemon keeps them
+	if m.rules {
+		lines = append(lines, "")
+		lines = append(lines, m.renderRuleFields(labelStyle)...)
+	}
 
 	// Error message if any
 	if m.errorMsg != "" {
@@ -851,21 +1283,38 @@ func (m SourceModal) renderEditContentOnly() string {
 	return strings.Join(lines, "\n")
 }
 
+// renderRuleFields renders the priority floor and ceiling inputs
+func (m SourceModal) renderRuleFields(labelStyle lipgloss.Style) []string {
+	return []string{
+		labelStyle.Render("Priority at least (optional):"),
+		m.floorInput.View(),
+		"",
+		labelStyle.Render("Priority at most (optional):"),
+		m.ceilingInput.View(),
+	}
+}
+
 // renderConfirmContentOnly renders just the confirmation content
 func (m SourceModal) renderConfirmContentOnly() string {
 	theme := CleanCyberTheme
-	if m.cursor >= len(m.sources) {
+	if m.cursor >= len(m.matches) {
 		return "Invalid source selection"
 	}
 
-	source := m.sources[m.cursor]
+	source := m.matches[m.cursor]
 	var lines []string
 
 	nameStyle := lipgloss.NewStyle().Foreground(theme.White).Bold(true)
 
 	lines = append(lines, fmt.Sprintf("Delete source: %s", nameStyle.Render(source.Name)))
 	lines = append(lines, "")
-	lines = append(lines, theme.MutedStyle().Render("This cannot be undone."))
+	lines = append(lines, theme.TextStyle().Render("What should happen to its items?"))
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	lines = append(lines, keyStyle.Render("[d]")+" Delete items too "+theme.MutedStyle().Render("(favorites kept)"))
+	lines = append(lines, keyStyle.Render("[k]")+" Keep items, detached")
+	lines = append(lines, keyStyle.Render("[a]")+" Archive items")
+	lines = append(lines, "")
+	lines = append(lines, theme.MutedStyle().Render("The source itself cannot be restored."))
 
 	return strings.Join(lines, "\n")
 }
@@ -929,6 +1378,37 @@ func (m SourceModal) ViewWithOverlay(backgroundView string, termWidth, termHeigh
 	return strings.Join(result, "\n")
 }
 
+// ruleLabels are the short priority names in rule badges
+var ruleLabels = map[string]string{"high": "HIGH", "medium": "MED", "low": "LOW"}
+
+// sourceRuleBadge shows a source's priority rule in brief, e.g. "≤LOW" for
+// a feed that's always at most LOW or "≥MED" for one never below MEDIUM;
+// empty when it has none
+func sourceRuleBadge(source db.Source) string {
+	var badge string
+	if label := ruleLabels[source.PriorityFloor]; label != "" {
+		badge += "≥" + label
+	}
+	if label := ruleLabels[source.PriorityCeiling]; label != "" {
+		badge += "≤" + label
+	}
+	return badge
+}
+
+// checkSourceRule validates a priority floor and ceiling from the edit form:
+// each empty or a priority, and the floor no higher than the ceiling
+func checkSourceRule(floor, ceiling string) error {
+	for _, priority := range []string{floor, ceiling} {
+		if priority != "" && ruleLabels[priority] == "" {
+			return fmt.Errorf("unknown priority '%s' (available: high, medium, low)", priority)
+		}
+	}
+	if floor != "" && ceiling != "" && priorityRank(floor) > priorityRank(ceiling) {
+		return fmt.Errorf("priority at least %s is above at most %s", floor, ceiling)
+	}
+	return nil
+}
+
 // sourceModalTruncate truncates a string to the specified length with ellipsis
 func sourceModalTruncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api/apitest"
 	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/feeds"
+	"github.com/nickpending/prismis/internal/ui/operations"
 )
 
 func TestSourceModal_LoadSources_UpdatesContent(t *testing.T) {
@@ -111,3 +117,253 @@ func TestSourceModal_ErrorMessageDisplay(t *testing.T) {
 		t.Errorf("Expected error message in content, got: %s", modal.content)
 	}
 }
+
+// TestSourceModal_FeedDiscovery_ShowsCandidates verifies discovered feeds are offered for selection.
+// BREAKS: If FeedDiscoveryMsg is not handled, adding a website URL skips straight to the daemon and fails.
+func TestSourceModal_FeedDiscovery_ShowsCandidates(t *testing.T) {
+	modal := NewSourceModal()
+	modal.visible = true
+	modal.mode = "add"
+
+	updated, cmd := modal.Update(operations.FeedDiscoveryMsg{
+		PageURL: "https://example.com",
+		Name:    "Example",
+		Candidates: []feeds.Candidate{
+			{URL: "https://example.com/feed.xml", Title: "Main Feed"},
+			{URL: "https://example.com/comments.xml", Title: "Comments"},
+		},
+	})
+
+	if cmd != nil {
+		t.Error("Expected no command while the user picks a feed")
+	}
+	if updated.mode != "discover" {
+		t.Fatalf("Expected mode 'discover', got %q", updated.mode)
+	}
+	if !strings.Contains(updated.content, "Main Feed") || !strings.Contains(updated.content, "Comments") {
+		t.Errorf("Expected both candidates in content, got: %s", updated.content)
+	}
+	if updated.discoverName != "Example" {
+		t.Errorf("Expected name to carry over, got %q", updated.discoverName)
+	}
+}
+
+// TestSourceModal_Preview_DiscardsStaleResult verifies a test-fetch result is only shown for the URL that was tested.
+// BREAKS: If stale previews render, the user sees item counts for a URL they already replaced.
+func TestSourceModal_Preview_DiscardsStaleResult(t *testing.T) {
+	modal := NewSourceModal()
+	modal.visible = true
+	modal.mode = "add"
+	modal.urlInput.SetValue("https://example.com/feed.xml")
+
+	modal, _ = modal.Update(operations.SourcePreviewMsg{
+		URL:        "https://example.com/feed.xml",
+		SourceType: "rss",
+		Preview:    &feeds.Preview{Format: "RSS", ItemCount: 3, LatestTitle: "Hello"},
+	})
+	if out := modal.renderAddContentOnly(); !strings.Contains(out, "3 items") || !strings.Contains(out, "Hello") {
+		t.Errorf("Expected preview in add form, got: %s", out)
+	}
+
+	modal.urlInput.SetValue("https://other.example.com/rss")
+	if out := modal.renderAddContentOnly(); strings.Contains(out, "Hello") {
+		t.Errorf("Preview for old URL should be hidden, got: %s", out)
+	}
+}
+
+// TestApplyFilters_Category verifies :filter category= keeps only items from sources in that category.
+// BREAKS: If category lookup by source ID fails, the filtered feed is always empty.
+func TestApplyFilters_Category(t *testing.T) {
+	m := testModel()
+	m.showAll = true
+	m.filterType = "all"
+	m.filterCategory = "work"
+	m.sources = []db.Source{
+		{ID: "s1", Name: "Work Feed", Category: "Work"},
+		{ID: "s2", Name: "Hobby Feed", Category: "hobby"},
+	}
+	items := []db.ContentItem{
+		{ID: "1", Title: "Work item", Priority: "high", SourceID: "s1"},
+		{ID: "2", Title: "Hobby item", Priority: "high", SourceID: "s2"},
+	}
+
+	filtered := applyFiltersClientSide(items, m)
+	if len(filtered) != 1 || filtered[0].ID != "1" {
+		t.Errorf("Expected only the work item, got %+v", filtered)
+	}
+}
+
+// TestBuildSourcesContent_GroupsByCategory verifies the sidebar switches to category headers.
+// BREAKS: If grouping stays by type, categories set in the source modal are invisible.
+func TestBuildSourcesContent_GroupsByCategory(t *testing.T) {
+	m := testModel()
+	m.theme = CleanCyberTheme
+	m.sourcesViewport = viewport.New(30, 20)
+	m.sources = []db.Source{
+		{ID: "s1", Name: "Work Feed", Type: "rss", Active: true, Category: "work"},
+		{ID: "s2", Name: "Loose Feed", Type: "rss", Active: true},
+	}
+
+	content := m.buildSourcesContent(m.theme)
+	workIdx := strings.Index(content, "WORK [1]")
+	uncatIdx := strings.Index(content, "UNCATEGORIZED [1]")
+	if workIdx == -1 || uncatIdx == -1 || workIdx > uncatIdx {
+		t.Errorf("Expected WORK group before UNCATEGORIZED, got: %s", content)
+	}
+}
+
+// TestSourceModal_Filter_NarrowsAndActsOnMatches verifies typing a filter narrows the list
+// and actions apply to the selected match, not the unfiltered index.
+// BREAKS: If the cursor still indexes all sources, [d] on a filtered row deletes the wrong feed.
+func TestSourceModal_Filter_NarrowsAndActsOnMatches(t *testing.T) {
+	modal := NewSourceModal()
+	modal.visible = true
+	modal.SetSize(100, 40)
+	modal.LoadSources([]db.Source{
+		{ID: "1", Name: "Hacker News", URL: "https://news.ycombinator.com/rss", Type: "rss", Active: true},
+		{ID: "2", Name: "Lobsters", URL: "https://lobste.rs/rss", Type: "rss", Active: true},
+		{ID: "3", Name: "Rust", URL: "https://reddit.com/r/rust", Type: "reddit", Active: true},
+	})
+
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "lbst" {
+		modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	if len(modal.matches) != 1 || modal.matches[0].ID != "2" {
+		t.Fatalf("Expected only Lobsters to match, got %+v", modal.matches)
+	}
+	if !strings.Contains(modal.View(CleanCyberTheme), "1/3 sources") {
+		t.Error("Expected match count in title")
+	}
+
+	// Leave filter input, then delete the selected match
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if modal.sourceToDelete != "2" {
+		t.Errorf("Expected to delete source 2, got %q", modal.sourceToDelete)
+	}
+}
+
+// TestSourceModal_CursorFollowsViewport verifies the list scrolls to keep the cursor visible.
+// BREAKS: If the viewport doesn't follow, the cursor walks off-screen with 100+ sources.
+func TestSourceModal_CursorFollowsViewport(t *testing.T) {
+	modal := NewSourceModal()
+	modal.visible = true
+	modal.SetSize(100, 24)
+
+	var sources []db.Source
+	for i := 0; i < 50; i++ {
+		sources = append(sources, db.Source{ID: fmt.Sprintf("%d", i), Name: fmt.Sprintf("Source %d", i), Type: "rss", Active: true})
+	}
+	modal.LoadSources(sources)
+
+	for i := 0; i < 30; i++ {
+		modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+
+	if modal.cursor != 30 {
+		t.Fatalf("Expected cursor 30, got %d", modal.cursor)
+	}
+	if modal.cursor < modal.viewport.YOffset || modal.cursor >= modal.viewport.YOffset+modal.viewport.Height {
+		t.Errorf("Cursor %d outside viewport [%d,%d)", modal.cursor, modal.viewport.YOffset, modal.viewport.YOffset+modal.viewport.Height)
+	}
+}
+
+// TestSparkline_ScalesToBusiestDay verifies empty days sit on the baseline and any item lifts a day off it.
+// BREAKS: If one item rounds down to the baseline, a trickling feed looks dead.
+func TestSparkline_ScalesToBusiestDay(t *testing.T) {
+	if got := sparkline([]int{0, 1, 50, 100}); got != "▁▂▅█" {
+		t.Errorf("Expected ▁▂▅█, got %s", got)
+	}
+	if got := sparkline([]int{0, 0, 0}); got != "▁▁▁" {
+		t.Errorf("Expected a flat line for no items, got %s", got)
+	}
+}
+
+// TestSourceModal_ActivityColumn verifies loaded counts render as a sparkline and total per source.
+// BREAKS: If activity is keyed or matched wrongly, a busy feed shows as dead or vice versa.
+func TestSourceModal_ActivityColumn(t *testing.T) {
+	modal := NewSourceModal()
+	modal.SetSize(100, 40)
+	modal.Show()
+	modal.LoadSources([]db.Source{
+		{ID: "busy", Name: "Busy", Type: "rss", Active: true},
+		{ID: "dead", Name: "Dead", Type: "rss", Active: true},
+	})
+	if strings.Contains(modal.renderListContentOnly(), "▁") {
+		t.Fatal("Expected no sparklines before activity loads")
+	}
+
+	counts := make([]int, activityDays)
+	counts[activityDays-1] = 9
+	modal, _ = modal.Update(sourceActivityMsg{counts: map[string][]int{"busy": counts}})
+
+	rows := map[string]string{}
+	for _, line := range strings.Split(modal.renderListContentOnly(), "\n") {
+		for _, name := range []string{"Busy", "Dead"} {
+			if strings.Contains(line, name) {
+				rows[name] = line
+			}
+		}
+	}
+	if !strings.Contains(rows["Busy"], strings.Repeat("▁", activityDays-1)+"█") || !strings.Contains(rows["Busy"], "   9") {
+		t.Errorf("Expected busy's sparkline and total, got %q", rows["Busy"])
+	}
+	if !strings.Contains(rows["Dead"], strings.Repeat("▁", activityDays)) || !strings.Contains(rows["Dead"], "   0") {
+		t.Errorf("Expected a flat line for the dead feed, got %q", rows["Dead"])
+	}
+}
+
+// TestSourceModal_EditsPriorityRule verifies the edit form sends a source's priority floor and ceiling to a daemon that keeps them, and the sidebar badges the rule.
+// BREAKS: Rules can't be set from the TUI, or a capped feed looks like any other in the sidebar.
+func TestSourceModal_EditsPriorityRule(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	source := daemon.AddSource(apitest.Source{URL: "https://noisy.example/feed", Type: "rss", Name: "Noisy", Active: true})
+
+	loaded := fetchSourcesRemote(daemon.URL)
+	modal := NewSourceModal()
+	modal.SetSize(100, 40)
+	modal.SetRules(true)
+	modal.LoadSources(loaded.sources)
+	modal.Show()
+
+	press := func(keys ...string) tea.Cmd {
+		var cmd tea.Cmd
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			switch key {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "tab":
+				msg = tea.KeyMsg{Type: tea.KeyTab}
+			}
+			modal, cmd = modal.Update(msg)
+		}
+		return cmd
+	}
+	press("enter", "tab", "tab", "tab", "high", "tab", "low")
+	if cmd := press("enter"); cmd != nil || !strings.Contains(modal.errorMsg, "above") {
+		t.Fatalf("Expected a floor above the ceiling refused, got %q", modal.errorMsg)
+	}
+	modal.floorInput.SetValue("")
+	cmd := press("enter")
+	if cmd == nil {
+		t.Fatal("Expected an update command")
+	}
+	if msg := cmd().(operations.SourceOperationMsg); !msg.Success {
+		t.Fatalf("Expected the update to succeed, got %q", msg.Message)
+	}
+
+	m := testModel()
+	m.theme = CleanCyberTheme
+	m.sourcesViewport = viewport.New(30, 20)
+	m.sources = fetchSourcesRemote(daemon.URL).sources
+	if len(m.sources) != 1 || m.sources[0].ID != source.ID || m.sources[0].PriorityCeiling != "low" || m.sources[0].PriorityFloor != "" {
+		t.Fatalf("Expected the ceiling saved alone, got %+v", m.sources)
+	}
+	if content := m.buildSourcesContent(m.theme); !strings.Contains(content, "≤LOW") {
+		t.Errorf("Expected the rule badge in the sidebar, got %q", content)
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// planSourceRenames applies :sources rename to every named source, keeping
+// the ones whose name changes to something non-empty
+func planSourceRenames(sources []db.Source, msg commands.RenameSourcesMsg) ([]operations.SourceRename, error) {
+	re, err := regexp.Compile(msg.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	var renames []operations.SourceRename
+	for _, source := range sources {
+		if source.Name == "" {
+			continue
+		}
+		name := strings.TrimSpace(re.ReplaceAllString(source.Name, msg.Replacement))
+		if name == "" || name == strings.TrimSpace(source.Name) {
+			continue
+		}
+		renames = append(renames, operations.SourceRename{
+			ID:      source.ID,
+			URL:     source.URL,
+			Type:    source.Type,
+			OldName: source.Name,
+			NewName: name,
+		})
+	}
+	return renames, nil
+}
+
+// buildRenamePreview lists the planned renames as Markdown
+func buildRenamePreview(renames []operations.SourceRename) string {
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "# Rename %d sources\n\n", len(renames))
+	for _, r := range renames {
+		fmt.Fprintf(&doc, "- %s → %s\n", r.OldName, r.NewName)
+	}
+	return doc.String()
+}
+
+// startSourceRenames previews a :sources rename and waits for y/n
+func (m *Model) startSourceRenames(msg commands.RenameSourcesMsg) tea.Cmd {
+	renames, err := planSourceRenames(m.sources, msg)
+	if err != nil {
+		return m.notify(toastError, fmt.Sprintf("Invalid pattern: %v", err), 5*time.Second)
+	}
+	if len(renames) == 0 {
+		return m.notify(toastInfo, "No source names would change", 3*time.Second)
+	}
+	m.sourceRenames = renames
+	m.digestModal.SetSize(m.width, m.height)
+	m.digestModal.OpenDocument("RENAME SOURCES", "y rename · n cancel", buildRenamePreview(renames))
+	m.statusMessage = fmt.Sprintf("Rename %d sources? (y/n)", len(renames))
+	return nil
+}
+
+// confirmSourceRenames handles keys while the rename preview is open: y
+// applies it, n or Esc cancels, and the rest scroll the preview
+func (m Model) confirmSourceRenames(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		renames := m.sourceRenames
+		m.sourceRenames = nil
+		m.digestModal.Hide()
+		m.statusMessage = fmt.Sprintf("Renaming %d sources...", len(renames))
+		return m, operations.RenameSources(renames)
+	case "n", "N", "esc", "q":
+		m.sourceRenames = nil
+		m.digestModal.Hide()
+		m.statusMessage = ""
+		return m, m.notify(toastInfo, "Rename cancelled", 3*time.Second)
+	}
+	var cmd tea.Cmd
+	m.digestModal, cmd = m.digestModal.Update(msg)
+	return m, cmd
+}
+
+// sourcesRenamed reports a finished rename batch and reloads the sources
+func (m *Model) sourcesRenamed(msg operations.SourcesRenamedMsg) tea.Cmd {
+	m.statusMessage = ""
+	reload := fetchSources(m.remoteURL)
+	if msg.Error == nil {
+		return tea.Batch(reload, m.notify(toastSuccess, fmt.Sprintf("Renamed %d sources", msg.Renamed), 4*time.Second))
+	}
+
+	text := fmt.Sprintf("Rename failed, nothing changed: %v", msg.Error)
+	if msg.Failed != nil {
+		text = fmt.Sprintf("Renaming %s failed, nothing changed: %v", msg.Failed.OldName, msg.Error)
+	}
+	if msg.RollbackError != nil {
+		text = fmt.Sprintf("Rename failed and some sources kept their new names: %v", msg.RollbackError)
+	}
+	return tea.Batch(reload, m.notify(toastError, text, 8*time.Second))
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestSourceRenames_PreviewThenConfirm verifies only changed names are planned and nothing runs before y.
+// BREAKS: If the preview is skipped or unchanged sources are sent, a typo in the pattern renames everything.
+func TestSourceRenames_PreviewThenConfirm(t *testing.T) {
+	m := testModel()
+	m.digestModal = NewDigestModal()
+	m.sources = []db.Source{
+		{ID: "1", Name: "Lobsters – RSS", URL: "https://lobste.rs/rss", Type: "rss"},
+		{ID: "2", Name: "Hacker News", URL: "https://hn/rss", Type: "rss"},
+		{ID: "3", Name: " – RSS", URL: "https://blank/rss", Type: "rss"}, // Would end up empty
+	}
+
+	updated, cmd := m.Update(commands.RenameSourcesMsg{Pattern: " – RSS$", Replacement: ""})
+	m = updated.(Model)
+	if cmd != nil || len(m.sourceRenames) != 1 || m.sourceRenames[0].NewName != "Lobsters" {
+		t.Fatalf("Expected one planned rename awaiting confirmation, got %+v", m.sourceRenames)
+	}
+	if !m.digestModal.IsVisible() {
+		t.Error("Expected the preview open")
+	}
+
+	// Scrolling keys stay in the preview; y runs the batch
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m = updated.(Model); m.sourceRenames == nil {
+		t.Fatal("Expected j to leave the rename pending")
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m = updated.(Model); cmd == nil || m.sourceRenames != nil || m.digestModal.IsVisible() {
+		t.Error("Expected y to close the preview and start the rename")
+	}
+
+	updated, _ = m.Update(commands.RenameSourcesMsg{Pattern: "^Hacker", Replacement: "HN"})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.sourceRenames != nil || m.statusMessage != "" {
+		t.Error("Expected Esc to cancel the rename")
+	}
+
+	if renames, _ := planSourceRenames(m.sources, commands.RenameSourcesMsg{Pattern: "nomatch"}); len(renames) != 0 {
+		t.Errorf("Expected nothing planned, got %+v", renames)
+	}
+}
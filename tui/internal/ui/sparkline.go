@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// activityDays is how many days of history the source sparklines cover
+const activityDays = 14
+
+// sparkBlocks are the sparkline levels; the lowest is reserved for empty days
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sourceActivityMsg carries each source's daily item counts, oldest day first
+type sourceActivityMsg struct {
+	counts map[string][]int
+	err    error
+}
+
+// sparkline renders counts as block characters scaled to the busiest day.
+// Any day with items is at least one step above an empty one, so a single
+// post still shows against a flat line.
+func sparkline(counts []int) string {
+	peak := 0
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	var b strings.Builder
+	for _, c := range counts {
+		level := 0
+		if c > 0 {
+			steps := len(sparkBlocks) - 1
+			level = 1 + (c*steps-1)/peak // Ceiling of c*steps/peak, minus one
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// fetchSourceActivity loads the per-source counts behind the sparklines
+func fetchSourceActivity(remoteURL string) tea.Cmd {
+	return func() tea.Msg {
+		if remoteURL == "" {
+			counts, err := db.GetSourceDailyCounts(activityDays)
+			return sourceActivityMsg{counts: counts, err: err}
+		}
+
+		client, err := api.NewClientWithURL(remoteURL)
+		if err != nil {
+			return sourceActivityMsg{err: err}
+		}
+		ctx, release := operations.Cancellable()
+		defer release()
+		counts, err := client.GetSourceDailyCounts(ctx, activityDays)
+		return sourceActivityMsg{counts: counts, err: err}
+	}
+}
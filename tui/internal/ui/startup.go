@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// StartOptions pick the first screen from command-line flags
+type StartOptions struct {
+	Priority string // high, medium, low, favorites, interesting, unprioritized, or all
+	Source   string // Source name, URL, or ID, as for :filter source
+	Reader   string // Item ID to open in the reader
+	View     string // list (default) or digest
+	Debug    bool   // Start with the debug overlay and trace on
+}
+
+// startPriorities are the views --priority accepts, as the 0-4, 6, and a keys pick
+var startPriorities = []string{"all", "high", "medium", "low", "favorites", "interesting", "unprioritized"}
+
+// startState holds start options that wait on the first load: sources to
+// resolve --source, items to find --reader or build the digest
+type startState struct {
+	source        string
+	reader        string
+	digest        bool
+	sourcesLoaded bool // The first sourcesLoadedMsg arrived
+}
+
+// startReaderMsg carries every item so --reader can find its item even
+// outside the starting view
+type startReaderMsg struct {
+	id    string
+	items []db.ContentItem
+	err   error
+}
+
+// WithStart applies command-line start options to a new model. The
+// priority applies at once; the rest wait until the first load finishes.
+func (m Model) WithStart(opts StartOptions) (Model, error) {
+	if opts.Priority != "" {
+		priority := strings.ToLower(opts.Priority)
+		if !slices.Contains(startPriorities, priority) {
+			return m, fmt.Errorf("--priority: expected one of %s, got '%s'", strings.Join(startPriorities, ", "), opts.Priority)
+		}
+		m.priority = priority
+		m.showUnprioritized = priority == "unprioritized"
+	}
+
+	switch strings.ToLower(opts.View) {
+	case "", "list":
+	case "digest":
+		m.start.digest = true
+	default:
+		return m, fmt.Errorf("--view: expected list or digest, got '%s'", opts.View)
+	}
+
+	m.start.source = strings.TrimSpace(opts.Source)
+	m.start.reader = strings.TrimSpace(opts.Reader)
+	m.setDebug(opts.Debug)
+	return m, nil
+}
+
+// applyStart runs the next waiting start option once the initial load has
+// finished and sources are in. Each step's reload triggers the next one.
+func (m *Model) applyStart() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+
+	switch {
+	case m.start.source != "":
+		if !m.start.sourcesLoaded {
+			return nil // sourcesLoadedMsg calls back
+		}
+		query := m.start.source
+		m.start.source = ""
+		source, ok := findSource(m.sources, query)
+		if !ok {
+			return tea.Batch(m.notify(toastError, fmt.Sprintf("--source: no source matches '%s'", query), 5*time.Second), m.applyStart())
+		}
+		m.filterSource = source.ID
+		m.updateSourcesViewport()
+		m.cursor = 0
+		m.loading = true
+		return m.fetchList(false)
+
+	case m.start.reader != "":
+		id := m.start.reader
+		m.start.reader = ""
+		if m.remoteURL != "" {
+			items := m.itemsCache
+			return func() tea.Msg { return startReaderMsg{id: id, items: items} }
+		}
+		showArchived := m.showArchived
+		return func() tea.Msg {
+			items, err := db.GetAllContent(showArchived)
+			return startReaderMsg{id: id, items: items, err: err}
+		}
+
+	case m.start.digest:
+		m.start.digest = false
+		return func() tea.Msg { return commands.DigestMsg{} }
+	}
+	return nil
+}
+
+// openStartReader shows the --reader item, relaxing filters if it's
+// outside the starting view
+func (m *Model) openStartReader(msg startReaderMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notify(toastError, fmt.Sprintf("--reader: %v", msg.err), 5*time.Second)
+	}
+	for _, item := range msg.items {
+		if item.ID != msg.id {
+			continue
+		}
+		m.revealItem(item)
+		m.loading = true
+		fetch := m.jumpToItem(item.ID)
+		return func() tea.Msg {
+			loaded := fetch()
+			if l, ok := loaded.(itemsLoadedMsg); ok {
+				l.openReader = true
+				return l
+			}
+			return loaded
+		}
+	}
+	return m.notify(toastError, fmt.Sprintf("--reader: no item with ID '%s'", msg.id), 5*time.Second)
+}
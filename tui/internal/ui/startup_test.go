@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// settle feeds the load messages cmd produces back into the model until
+// nothing more happens, skipping timers (toasts, ticks) that never fire in time
+func settle(m Model, cmd tea.Cmd) (Model, []tea.Msg) {
+	var others []tea.Msg
+	queue := []tea.Cmd{cmd}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+		done := make(chan tea.Msg, 1)
+		go func() { done <- next() }()
+		var msg tea.Msg
+		select {
+		case msg = <-done:
+		case <-time.After(100 * time.Millisecond):
+			continue
+		}
+		switch msg := msg.(type) {
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		case itemsLoadedMsg, startReaderMsg, sourcesLoadedMsg:
+			updated, more := m.Update(msg)
+			m = updated.(Model)
+			queue = append(queue, more)
+		default:
+			others = append(others, msg)
+		}
+	}
+	return m, others
+}
+
+// TestWithStart_RejectsUnknownValues verifies bad flag values are reported before the TUI starts.
+// BREAKS: If "--priority hgih" is accepted, the TUI opens on an empty view with no hint why.
+func TestWithStart_RejectsUnknownValues(t *testing.T) {
+	m := Model{priority: "all"}
+	if _, err := m.WithStart(StartOptions{Priority: "hgih"}); err == nil {
+		t.Error("Expected an error for an unknown priority")
+	}
+	if _, err := m.WithStart(StartOptions{View: "calendar"}); err == nil {
+		t.Error("Expected an error for an unknown view")
+	}
+	started, err := m.WithStart(StartOptions{Priority: "Unprioritized", View: "digest"})
+	if err != nil || started.priority != "unprioritized" || !started.showUnprioritized || !started.start.digest {
+		t.Errorf("Expected the unprioritized view and a pending digest, got %+v (%v)", started, err)
+	}
+}
+
+// TestApplyStart_SourceThenReader verifies --source waits for sources and --reader opens an item outside the view.
+// BREAKS: If --source runs before sources load it never matches; if --reader ignores filters, read items can't be opened.
+func TestApplyStart_SourceThenReader(t *testing.T) {
+	now := time.Now()
+	cache := []db.ContentItem{
+		{ID: "rust-1", SourceID: "s1", SourceName: "r/rust", Priority: "high", Published: now},
+		{ID: "go-1", SourceID: "s2", SourceName: "Go Blog", Priority: "high", Published: now},
+		{ID: "old", SourceID: "s2", SourceName: "Go Blog", Priority: "low", Read: true, Published: now.Add(-time.Hour)},
+	}
+	m := Model{remoteURL: "http://daemon", loading: true, priority: "all", filterType: "all", view: "list", sortNewest: true, itemsCache: cache}
+	m, err := m.WithStart(StartOptions{Priority: "high", Source: "r/rust", Reader: "old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sources arrive while the first load is still running: nothing yet
+	m, _ = settle(m, func() tea.Msg {
+		return sourcesLoadedMsg{sources: []db.Source{{ID: "s1", Name: "r/rust"}, {ID: "s2", Name: "Go Blog"}}}
+	})
+	if m.filterSource != "" || m.start.source != "r/rust" {
+		t.Fatalf("Expected --source to wait for the first load, got %q", m.filterSource)
+	}
+
+	// The first load lands: the source filter applies, then the reader opens
+	m, _ = settle(m, fetchItemsWithState(m, false))
+	if m.view != "reader" || m.cursor >= len(m.items) || m.items[m.cursor].ID != "old" {
+		t.Fatalf("Expected the reader on 'old', got view %s items %v", m.view, m.items)
+	}
+	if !m.showAll || m.priority != "all" || m.filterSource != "" {
+		t.Errorf("Expected filters relaxed to reveal the read LOW item, got showAll=%v priority=%s source=%q", m.showAll, m.priority, m.filterSource)
+	}
+}
+
+// TestApplyStart_Digest verifies --view digest opens the digest once items are loaded.
+// BREAKS: If the digest opens before the first remote sync, it's built from an empty cache.
+func TestApplyStart_Digest(t *testing.T) {
+	m := Model{remoteURL: "http://daemon", loading: true, priority: "all", filterType: "all", view: "list"}
+	m, _ = m.WithStart(StartOptions{View: "digest"})
+	if cmd := m.applyStart(); cmd != nil {
+		t.Fatal("Expected nothing while loading")
+	}
+
+	m, others := settle(m, fetchItemsWithState(m, false))
+	found := false
+	for _, msg := range others {
+		if _, ok := msg.(commands.DigestMsg); ok {
+			found = true
+		}
+	}
+	if !found || m.start.digest {
+		t.Errorf("Expected a DigestMsg after the first load, got %v", others)
+	}
+}
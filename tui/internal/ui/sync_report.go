@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// maxSyncConflicts bounds the :sync status conflict list; the oldest drops off first
+const maxSyncConflicts = 100
+
+// syncConflict is an item whose read or favorite state at a sync differed
+// between the local cache and the daemon: changed on another device, or a
+// change here still queued. The daemon's state is kept.
+type syncConflict struct {
+	id     string
+	title  string
+	field  string // "read" or "favorited"
+	local  bool
+	server bool
+	at     time.Time
+}
+
+// stateConflicts compares an item from the daemon with its cached copy
+func stateConflicts(cached, fresh db.ContentItem, at time.Time) []syncConflict {
+	var conflicts []syncConflict
+	if cached.Read != fresh.Read {
+		conflicts = append(conflicts, syncConflict{id: fresh.ID, title: fresh.Title, field: "read", local: cached.Read, server: fresh.Read, at: at})
+	}
+	if cached.Favorited != fresh.Favorited {
+		conflicts = append(conflicts, syncConflict{id: fresh.ID, title: fresh.Title, field: "favorited", local: cached.Favorited, server: fresh.Favorited, at: at})
+	}
+	return conflicts
+}
+
+// recordConflicts adds a sync's conflicts to the report, keeping the newest
+func (m *Model) recordConflicts(conflicts []syncConflict) tea.Cmd {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	m.syncConflicts = append(m.syncConflicts, conflicts...)
+	if over := len(m.syncConflicts) - maxSyncConflicts; over > 0 {
+		m.syncConflicts = m.syncConflicts[over:]
+	}
+	items := map[string]bool{}
+	for _, c := range conflicts {
+		items[c.id] = true
+	}
+	return m.notify(toastInfo, fmt.Sprintf("%d item%s changed elsewhere; kept the daemon's state (:sync status)",
+		len(items), pluralize(len(items))), 4*time.Second)
+}
+
+// setCachedState applies a read or favorite change made here to the remote
+// cache, so the next sync only flags what changed elsewhere
+func (m *Model) setCachedState(ids []string, apply func(item *db.ContentItem)) {
+	changed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		changed[id] = true
+	}
+	for i := range m.itemsCache {
+		if changed[m.itemsCache[i].ID] {
+			apply(&m.itemsCache[i])
+		}
+	}
+}
+
+// conflictState describes one side of a conflict, e.g. "unread"
+func conflictState(field string, on bool) string {
+	switch {
+	case field == "read" && on:
+		return "read"
+	case field == "read":
+		return "unread"
+	case on:
+		return "favorited"
+	}
+	return "not favorited"
+}
+
+// buildSyncReport describes the last remote sync and lists the conflicts
+// seen this session, newest first
+func buildSyncReport(m Model, now time.Time) string {
+	var doc strings.Builder
+	doc.WriteString("# Sync\n\n")
+	fmt.Fprintf(&doc, "- Daemon: %s\n", m.remoteURL)
+	switch {
+	case m.lastSyncAt.IsZero():
+		doc.WriteString("- Last sync: none yet\n")
+	default:
+		fmt.Fprintf(&doc, "- Last sync: %s (%s ago), %s round trip\n",
+			m.lastSyncAt.Format("15:04:05"), formatTime(now.Sub(m.lastSyncAt)), m.latency.Round(time.Millisecond))
+	}
+	if m.syncFailed {
+		doc.WriteString("- The latest sync failed; :errors has the details\n")
+	}
+	fmt.Fprintf(&doc, "- Cached items: %d\n", len(m.itemsCache))
+	if m.pendingWrites > 0 {
+		fmt.Fprintf(&doc, "- Queued changes: %d, sent when the daemon is reachable\n", m.pendingWrites)
+	}
+
+	doc.WriteString("\n## Conflicts\n\n")
+	if len(m.syncConflicts) == 0 {
+		doc.WriteString("No read or favorite state has differed from the daemon's this session.\n")
+		return doc.String()
+	}
+	doc.WriteString("Read or favorite state that differed from the daemon's at a sync. The daemon's was kept.\n\n")
+	for i := len(m.syncConflicts) - 1; i >= 0; i-- {
+		c := m.syncConflicts[i]
+		fmt.Fprintf(&doc, "- %s **%s**: %s here, %s on the daemon\n",
+			c.at.Format("15:04"), c.title, conflictState(c.field, c.local), conflictState(c.field, c.server))
+	}
+	return doc.String()
+}
+
+// startFullResync refetches everything for :sync full, as on first launch.
+// The job starts without lastSync or a cache; the live cache stays on screen
+// until the result replaces it, so a failed resync loses nothing.
+func (m *Model) startFullResync() tea.Cmd {
+	if m.remoteURL == "" {
+		return m.notify(toastInfo, "Local mode reads the database directly; there's no sync", 3*time.Second)
+	}
+	var currentItemID string
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		currentItemID = m.items[m.cursor].ID
+	}
+	m.newFetch()
+	snapshot := *m
+	snapshot.lastSync = time.Time{}
+	snapshot.itemsCache = nil
+	snapshot.archivedCache = nil
+	snapshot.offline = false // A failed resync reports the error rather than loading the offline copy
+	m.loading = true
+	m.statusOp = operations.NewOp()
+	return remoteFetch(syncJob{model: snapshot, preserveCursor: true, targetItemID: currentItemID, fullResync: true, op: m.statusOp})
+}
+
+// resyncDone reports how a full resync's items differ from the cache it
+// replaces: items the incremental syncs missed, ones gone from the daemon,
+// and ones whose read, favorite, or priority changed
+func (m *Model) resyncDone(before, after []db.ContentItem) tea.Cmd {
+	old := make(map[string]db.ContentItem, len(before))
+	for _, item := range before {
+		old[item.ID] = item
+	}
+	var added, changed int
+	for _, item := range after {
+		prev, ok := old[item.ID]
+		switch {
+		case !ok:
+			added++
+		case prev.Read != item.Read || prev.Favorited != item.Favorited || prev.Priority != item.Priority:
+			changed++
+		}
+		delete(old, item.ID)
+	}
+
+	text := fmt.Sprintf("Full resync: %d items", len(after))
+	if added == 0 && len(old) == 0 && changed == 0 {
+		return m.notify(toastSuccess, text+", the cache was up to date", 5*time.Second)
+	}
+	return m.notify(toastSuccess, fmt.Sprintf("%s (%d new, %d gone, %d changed)", text, added, len(old), changed), 8*time.Second)
+}
+
+// showSyncReport opens :sync status
+func (m *Model) showSyncReport() tea.Cmd {
+	if m.remoteURL == "" {
+		return m.notify(toastInfo, "Local mode reads the database directly; there's no sync", 3*time.Second)
+	}
+	m.digestModal.SetSize(m.width, m.height)
+	m.digestModal.OpenDocument("SYNC", "", buildSyncReport(*m, time.Now()))
+	return nil
+}
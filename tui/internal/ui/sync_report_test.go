@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestSyncConflicts_ServerWinsAndReported verifies a sync keeps the daemon's read state over a stale cache, flags it for :sync status, and leaves queued writes alone.
+// BREAKS: An item read on another device silently flips back, or a change queued here is reported as someone else's.
+func TestSyncConflicts_ServerWinsAndReported(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	queue := filepath.Join(dataHome, "prismis", "pending_writes.json")
+	if err := os.MkdirAll(filepath.Dir(queue), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(queue, []byte(`[{"content_id": "b", "field": "read"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{ID: "a", Title: "Read elsewhere", Priority: "high", Read: true})
+	daemon.AddEntry(apitest.Entry{ID: "b", Title: "Read here, queued", Priority: "high"})
+	daemon.AddEntry(apitest.Entry{ID: "c", Title: "Unchanged", Priority: "high", Favorited: true})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.showAll = true
+	m.itemsCache = []db.ContentItem{
+		{ID: "a", Title: "Read elsewhere", Priority: "high"},
+		{ID: "b", Title: "Read here, queued", Priority: "high", Read: true},
+		{ID: "c", Title: "Unchanged", Priority: "high", Favorited: true},
+	}
+
+	result := fetchItemsRemote(m, nil, nil)
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+	if len(result.conflicts) != 1 || result.conflicts[0].id != "a" || result.conflicts[0].field != "read" {
+		t.Fatalf("Expected one read conflict on a, got %+v", result.conflicts)
+	}
+	for _, item := range result.allItems {
+		if !item.Read && item.ID != "c" {
+			t.Errorf("Expected %s read: the daemon's state for a, the queued write for b", item.ID)
+		}
+	}
+
+	updated, _ := m.Update(result)
+	m = updated.(Model)
+	if !strings.Contains(lastToast(m), "1 item changed elsewhere") {
+		t.Errorf("Expected a conflict toast, got %q", lastToast(m))
+	}
+	updated, _ = m.Update(commands.SyncMsg{Action: "status"})
+	m = updated.(Model)
+	if !m.digestModal.IsVisible() {
+		t.Fatal("Expected :sync status to open the report")
+	}
+	report := buildSyncReport(m, time.Now())
+	if !strings.Contains(report, "**Read elsewhere**: unread here, read on the daemon") {
+		t.Errorf("Expected the conflict in the report:\n%s", report)
+	}
+}
+
+// TestSetCachedState_LocalChangesArentConflicts verifies marking an item read here updates the remote cache.
+// BREAKS: The next sync would report your own change as made on another device.
+func TestSetCachedState_LocalChangesArentConflicts(t *testing.T) {
+	m := testModel()
+	m.itemsCache = []db.ContentItem{{ID: "a"}, {ID: "b"}}
+	m.setCachedState([]string{"b"}, func(item *db.ContentItem) { item.Read = true })
+	if m.itemsCache[0].Read || !m.itemsCache[1].Read {
+		t.Errorf("Expected only b marked read in the cache, got %+v", m.itemsCache)
+	}
+	if conflicts := stateConflicts(m.itemsCache[1], db.ContentItem{ID: "b", Read: true}, time.Now()); len(conflicts) != 0 {
+		t.Errorf("Expected no conflict once the daemon agrees, got %+v", conflicts)
+	}
+}
+
+// TestFullResync_ReplacesCacheAndReportsDelta verifies :sync full fetches without the cache and reports new, gone, and changed items.
+// BREAKS: A resync that only fetches since lastSync misses what incremental syncs dropped, or hides what it fixed.
+func TestFullResync_ReplacesCacheAndReportsDelta(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{ID: "a", Title: "Same", Priority: "high"})
+	daemon.AddEntry(apitest.Entry{ID: "b", Title: "Read elsewhere", Priority: "high", Read: true})
+	daemon.AddEntry(apitest.Entry{ID: "c", Title: "Missed", Priority: "high"})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.showAll = true
+	m.lastSync = time.Now()
+	m.itemsCache = []db.ContentItem{
+		{ID: "a", Title: "Same", Priority: "high"},
+		{ID: "b", Title: "Read elsewhere", Priority: "high"},
+		{ID: "d", Title: "Deleted", Priority: "high"},
+	}
+
+	updated, cmd := m.Update(commands.SyncMsg{Action: "full"})
+	m = updated.(Model)
+	if cmd == nil || !m.loading {
+		t.Fatal("Expected :sync full to start a fetch")
+	}
+	result, ok := cmd().(itemsLoadedMsg)
+	if !ok || result.err != nil || !result.fullResync {
+		t.Fatalf("Expected a full resync result, got %#v", result)
+	}
+	if len(result.conflicts) != 0 {
+		t.Errorf("Expected no conflicts from a fetch without a cache, got %+v", result.conflicts)
+	}
+
+	updated, _ = m.Update(result)
+	m = updated.(Model)
+	if len(m.itemsCache) != 3 {
+		t.Errorf("Expected the cache replaced with the daemon's 3 items, got %d", len(m.itemsCache))
+	}
+	if got := lastToast(m); got != "Full resync: 3 items (1 new, 1 gone, 1 changed)" {
+		t.Errorf("Expected the delta toast, got %q", got)
+	}
+}
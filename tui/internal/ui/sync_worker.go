@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// syncJob is one remote fetch request, carrying a snapshot of the model
+// (filters, cache, lastSync) and the cursor handling for the result
+type syncJob struct {
+	model          Model
+	preserveCursor bool
+	targetItemID   string
+	isAutoRefresh  bool
+	fullResync     bool
+	op             *operations.Op // Set when the user started the sync, so Esc can cancel it
+}
+
+// syncProgressMsg reports a running remote sync; Page 0 means it just started
+type syncProgressMsg struct {
+	progress api.SyncProgress
+	items    []db.ContentItem // A first sync's items so far, unfiltered (nil otherwise)
+}
+
+// syncDoneMsg carries the finished sync's result back to Update
+type syncDoneMsg struct {
+	result itemsLoadedMsg
+}
+
+// syncWorker runs remote fetches on one long-lived goroutine so they never
+// block Update, streaming progress back through events. Jobs queue in order;
+// the model listens with listen() and re-listens after each event.
+type syncWorker struct {
+	jobs   chan *syncJob // Pointers: a Model snapshot is too big for a channel element
+	events chan tea.Msg
+}
+
+// newSyncWorker starts the worker goroutine
+func newSyncWorker() *syncWorker {
+	w := &syncWorker{
+		jobs:   make(chan *syncJob, 4),
+		events: make(chan tea.Msg, 16),
+	}
+	go w.run()
+	return w
+}
+
+func (w *syncWorker) run() {
+	for job := range w.jobs {
+		w.events <- syncProgressMsg{}
+
+		first := len(job.model.itemsCache) == 0
+		result := fetchItemsRemote(job.model, job.op, func(p api.SyncProgress) {
+			msg := syncProgressMsg{progress: p}
+			if first {
+				// Nothing to show yet: list what has arrived while the rest downloads
+				msg.items = receivedItems(p.Received)
+			}
+			msg.progress.Received = nil
+			// Drop progress rather than stall the fetch if the UI is behind;
+			// a later report carries everything a dropped one had
+			select {
+			case w.events <- msg:
+			default:
+			}
+		})
+		result.preserveCursor = job.preserveCursor
+		result.targetItemID = job.targetItemID
+		result.isAutoRefresh = job.isAutoRefresh
+		result.fullResync = job.fullResync
+		result.seq = job.model.fetchSeq
+
+		w.events <- syncDoneMsg{result: result}
+	}
+}
+
+// receivedItems converts a sync's items so far. Update filters them with the
+// filters current when they arrive.
+func receivedItems(received []api.ContentItem) []db.ContentItem {
+	items := make([]db.ContentItem, len(received))
+	for i, apiItem := range received {
+		items[i] = contentFromAPI(apiItem)
+	}
+	return items
+}
+
+// submit queues job; the result arrives later as a syncDoneMsg
+func (w *syncWorker) submit(job syncJob) tea.Cmd {
+	return func() tea.Msg {
+		w.jobs <- &job
+		return nil
+	}
+}
+
+// listen waits for the worker's next event
+func (w *syncWorker) listen() tea.Cmd {
+	return func() tea.Msg {
+		return <-w.events
+	}
+}
+
+// remoteFetch runs job on the sync worker, or inline when the model has none
+// (models built directly in tests)
+func remoteFetch(job syncJob) tea.Cmd {
+	if job.model.syncer != nil {
+		return job.model.syncer.submit(job)
+	}
+	return func() tea.Msg {
+		result := fetchItemsRemote(job.model, job.op, nil)
+		result.preserveCursor = job.preserveCursor
+		result.targetItemID = job.targetItemID
+		result.isAutoRefresh = job.isAutoRefresh
+		result.fullResync = job.fullResync
+		result.seq = job.model.fetchSeq
+		return result
+	}
+}
+
+// syncStatus describes a running sync for the header, e.g. "⠙ Syncing 2/5 · 1.2 MB"
+func syncStatus(frame string, p api.SyncProgress) string {
+	switch {
+	case p.Page == 0:
+		return frame + " Syncing"
+	case p.Pages > 0:
+		return fmt.Sprintf("%s Syncing %d/%d · %s", frame, p.Page, p.Pages, formatBytes(p.Bytes))
+	default:
+		return fmt.Sprintf("%s Syncing page %d · %s", frame, p.Page, formatBytes(p.Bytes))
+	}
+}
+
+// formatBytes renders n as B, KB, MB, or GB
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%d KB", n/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/api/apitest"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestSyncWorker_StreamsProgressThenResult verifies the worker reports start, pages, and the result in order.
+// BREAKS: If the result is sent before progress, the header spinner sticks after the sync ends.
+func TestSyncWorker_StreamsProgressThenResult(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{Title: "remote item", Priority: "high"})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.priority = "all"
+	m.showAll = true
+
+	w := newSyncWorker()
+	w.submit(syncJob{model: m, targetItemID: "x"})()
+
+	var events []interface{}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-w.events:
+			events = append(events, ev)
+		case <-timeout:
+			t.Fatalf("Timed out after events %+v", events)
+		}
+		if _, done := events[len(events)-1].(syncDoneMsg); done {
+			break
+		}
+	}
+
+	if start, ok := events[0].(syncProgressMsg); !ok || start.progress.Page != 0 {
+		t.Errorf("Expected start event first, got %+v", events[0])
+	}
+	done := events[len(events)-1].(syncDoneMsg)
+	if done.result.err != nil || len(done.result.allItems) != 1 || done.result.targetItemID != "x" {
+		t.Errorf("Unexpected result: %+v", done.result)
+	}
+}
+
+// TestSyncWorker_FirstSyncShowsPagesEarly verifies a first sync's pages reach the list before the sync finishes.
+// BREAKS: If the list waits for the whole payload, a large first sync shows "Loading" until every page is in.
+func TestSyncWorker_FirstSyncShowsPagesEarly(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	for i := 0; i < 600; i++ {
+		daemon.AddEntry(apitest.Entry{Title: "remote item", Priority: "high"})
+	}
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	m.showAll = true
+	m.loading = true
+
+	w := newSyncWorker()
+	m.syncer = w
+	w.submit(syncJob{model: m})()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		var ev interface{}
+		select {
+		case ev = <-w.events:
+		case <-timeout:
+			t.Fatal("Timed out waiting for the first page")
+		}
+		progress, ok := ev.(syncProgressMsg)
+		if !ok {
+			t.Fatalf("Expected a page before the result, got %T", ev)
+		}
+		if progress.progress.Received != nil {
+			t.Error("Expected the API items dropped from the event")
+		}
+		if progress.progress.Page != 1 {
+			continue
+		}
+		updated, _ := m.Update(progress)
+		m = updated.(Model)
+		if m.loading || len(m.items) != 500 {
+			t.Errorf("Expected the first 500 items listed, got %d (loading=%v)", len(m.items), m.loading)
+		}
+		break
+	}
+}
+
+// TestSyncStatus verifies the header text for each progress stage.
+// BREAKS: If an unknown page count renders as "1/0", the header looks broken on old daemons.
+func TestSyncStatus(t *testing.T) {
+	if got := syncStatus("*", api.SyncProgress{}); got != "* Syncing" {
+		t.Errorf("Start: got %q", got)
+	}
+	p := api.SyncProgress{Page: 2, Pages: 5, Bytes: 3 << 20}
+	if got := syncStatus("*", p); got != "* Syncing 2/5 · 3.0 MB" {
+		t.Errorf("Known pages: got %q", got)
+	}
+	p.Pages = 0
+	if got := syncStatus("*", p); got != "* Syncing page 2 · 3.0 MB" {
+		t.Errorf("Unknown pages: got %q", got)
+	}
+}
+
+// TestSyncHealth_MeasuredAndShownInSidebar verifies a sync records latency and time, and a failed one is flagged.
+// BREAKS: If failures don't reach the sidebar, it keeps claiming "Online" while nothing syncs.
+func TestSyncHealth_MeasuredAndShownInSidebar(t *testing.T) {
+	daemon := apitest.New(t)
+	daemon.Install(t)
+	daemon.AddEntry(apitest.Entry{Title: "remote item", Priority: "high"})
+
+	m := testModel()
+	m.remoteURL = daemon.URL
+	result := fetchItemsRemote(m, nil, nil)
+	if result.err != nil || result.syncedAt.IsZero() || result.latency <= 0 {
+		t.Fatalf("Expected a timed sync, got err=%v syncedAt=%v latency=%v", result.err, result.syncedAt, result.latency)
+	}
+
+	updated, _ := m.Update(result)
+	m = updated.(Model)
+	m.pendingWrites = 2
+	stats := strings.Join(syncStats(m, m.theme), "\n")
+	for _, want := range []string{"Online", "ms", "just now", "2 queued"} {
+		if !strings.Contains(stats, want) {
+			t.Errorf("Expected %q in sidebar stats:\n%s", want, stats)
+		}
+	}
+
+	daemon.Fail("GET /api/entries", 500, "boom")
+	updated, _ = m.Update(fetchItemsRemote(m, nil, nil))
+	m = updated.(Model)
+	if stats := strings.Join(syncStats(m, m.theme), "\n"); !strings.Contains(stats, "Sync failing") || !strings.Contains(stats, "just now") {
+		t.Errorf("Expected a failing sync that keeps the last success, got:\n%s", stats)
+	}
+}
+
+// TestSyncProgress_FirstSyncPreviewIsFiltered verifies the pages shown during a first sync go through the view's filters.
+// BREAKS: If raw pages are listed, a first sync flashes items the view hides until the sync finishes.
+func TestSyncProgress_FirstSyncPreviewIsFiltered(t *testing.T) {
+	m := testModel()
+	m.showAll = true
+	m.filterType = "rss"
+	m.loading = true
+
+	updated, _ := m.Update(syncProgressMsg{items: []db.ContentItem{
+		{ID: "a", Title: "Feed post", Priority: "high", SourceType: "rss"},
+		{ID: "b", Title: "Thread", Priority: "high", SourceType: "reddit"},
+	}})
+	m = updated.(Model)
+	if len(m.items) != 1 || m.items[0].ID != "a" || m.loading {
+		t.Errorf("Expected only the rss item listed, got %+v (loading=%v)", m.items, m.loading)
+	}
+}
+
+// TestSyncDone_ResultIsItsOwnMessage verifies a finished sync hands its result back as a follow-up message.
+// BREAKS: If the result is handled by a nested Update, the post-update hooks run twice for one sync.
+func TestSyncDone_ResultIsItsOwnMessage(t *testing.T) {
+	m := testModel()
+	m.syncing = true
+
+	updated, cmd := m.Update(syncDoneMsg{result: itemsLoadedMsg{targetItemID: "x"}})
+	m = updated.(Model)
+	if m.syncing || cmd == nil {
+		t.Fatalf("Expected the sync ended with a follow-up, got syncing=%v", m.syncing)
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected the result batched with the next listen")
+	}
+	if result, ok := batch[0]().(itemsLoadedMsg); !ok || result.targetItemID != "x" {
+		t.Errorf("Expected the sync's result first, got %T", batch[0]())
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// editTags applies :tag additions and removals, keeping order and
+// dropping duplicates. Returns nil when nothing is left.
+func editTags(current, add, remove []string) []string {
+	var tags []string
+	for _, tag := range append(slices.Clone(current), add...) {
+		if !slices.Contains(remove, tag) && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// hasTag reports whether item carries the user tag (case-insensitive)
+func hasTag(item db.ContentItem, tag string) bool {
+	return slices.ContainsFunc(item.UserTags, func(t string) bool {
+		return strings.EqualFold(t, tag)
+	})
+}
+
+// renderUserTags renders user tags as "#rust #career", styled apart from
+// the LLM's entities
+func renderUserTags(tags []string, theme StyleTheme) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(theme.VibrantPurple).Render("#" + strings.Join(tags, " #"))
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestEditTags_AddsRemovesAndDedupes verifies :tag edits keep order and never duplicate.
+// BREAKS: If tags duplicate, every :tag rust appends another #rust to the metadata line.
+func TestEditTags_AddsRemovesAndDedupes(t *testing.T) {
+	got := editTags([]string{"rust", "ai"}, []string{"career", "rust"}, []string{"ai"})
+	if strings.Join(got, ",") != "rust,career" {
+		t.Errorf("Expected [rust career], got %v", got)
+	}
+	if got := editTags([]string{"rust"}, nil, []string{"rust"}); got != nil {
+		t.Errorf("Expected removing the last tag to clear, got %v", got)
+	}
+}
+
+// TestTagFilter_NarrowsListAndShowsTags verifies :filter tag= keeps only tagged items and tags render in rows.
+// BREAKS: If the filter ignores user tags, :filter tag=rust shows the whole feed.
+func TestTagFilter_NarrowsListAndShowsTags(t *testing.T) {
+	items := []db.ContentItem{
+		{ID: "a", Title: "Tagged", Priority: "high", UserTags: []string{"rust", "career"}},
+		{ID: "b", Title: "Plain", Priority: "high"},
+	}
+	m := Model{priority: "all", filterType: "all", filterTag: "rust"}
+	if got := applyFiltersClientSide(items, m); len(got) != 1 || got[0].ID != "a" {
+		t.Errorf("Expected only the tagged item, got %v", got)
+	}
+
+	m = Model{width: 120, height: 30, view: "list", theme: CleanCyberTheme, items: items}
+	if out := renderContentList(m, 100, 20, CleanCyberTheme); !strings.Contains(out, "#rust #career") {
+		t.Errorf("Expected user tags in the metadata line, got:\n%s", out)
+	}
+}
+
+// TestArticleTagged_UpdatesItem verifies a tag result lands on the item in place.
+// BREAKS: If the item isn't updated, the new tags only appear after a full refresh.
+func TestArticleTagged_UpdatesItem(t *testing.T) {
+	m := Model{view: "list", items: []db.ContentItem{{ID: "a"}}}
+	updated, _ := m.Update(operations.ArticleTaggedMsg{ID: "a", Tags: []string{"rust"}, Success: true})
+	m = updated.(Model)
+	if strings.Join(m.items[0].UserTags, ",") != "rust" {
+		t.Errorf("Expected tags [rust], got %v", m.items[0].UserTags)
+	}
+
+	// Bare :tag just reports, without touching the daemon
+	updated, cmd := m.Update(commands.TagMsg{})
+	m = updated.(Model)
+	if cmd == nil || !strings.Contains(m.toasts[len(m.toasts)-1].text, "rust") {
+		t.Error("Expected bare :tag to show the current tags")
+	}
+}
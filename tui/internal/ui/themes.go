@@ -13,6 +13,7 @@ import (
 // StyleTheme defines a clean cyberpunk color scheme for the TUI
 type StyleTheme struct {
 	Name          string
+	Light         bool           // Made for a light terminal background
 	Cyan          lipgloss.Color // Primary UI accent #00D9FF
 	Purple        lipgloss.Color // Tags and metadata #E6CCFF
 	VibrantPurple lipgloss.Color // Errors and gradient accent #9F4DFF
@@ -22,6 +23,15 @@ type StyleTheme struct {
 	Gray          lipgloss.Color // Muted text/low priority #666666
 	DarkGray      lipgloss.Color // Borders and backgrounds #333333
 	White         lipgloss.Color // Main text #EEEEEE
+
+	// Item states, drawn from the palette above; [colors] overrides them
+	High          lipgloss.Color // High priority dot and badge
+	Medium        lipgloss.Color // Medium priority dot and badge
+	Low           lipgloss.Color // Low priority dot and badge
+	Unprioritized lipgloss.Color // Dot for items without a priority
+	Read          lipgloss.Color // Read items' titles and check mark
+	Favorite      lipgloss.Color // Favorite heart
+	Selection     lipgloss.Color // Cursor row and its marker
 }
 
 // CleanCyberTheme provides the exact colors used in clean_cyber.go
@@ -36,6 +46,13 @@ var CleanCyberTheme = StyleTheme{
 	Gray:          lipgloss.Color("#666666"),
 	DarkGray:      lipgloss.Color("#333333"),
 	White:         lipgloss.Color("#EEEEEE"),
+	High:          lipgloss.Color("#FF0066"),
+	Medium:        lipgloss.Color("#FF8800"),
+	Low:           lipgloss.Color("#00D9FF"),
+	Unprioritized: lipgloss.Color("#666666"),
+	Read:          lipgloss.Color("#666666"),
+	Favorite:      lipgloss.Color("#9F4DFF"),
+	Selection:     lipgloss.Color("#00D9FF"),
 }
 
 // MonokaiProTheme provides warm dark colors inspired by Monokai Pro
@@ -50,6 +67,13 @@ var MonokaiProTheme = StyleTheme{
 	Gray:          lipgloss.Color("#727072"),
 	DarkGray:      lipgloss.Color("#403E41"),
 	White:         lipgloss.Color("#FCFCFA"),
+	High:          lipgloss.Color("#FF6188"),
+	Medium:        lipgloss.Color("#FC9867"),
+	Low:           lipgloss.Color("#78DCE8"),
+	Unprioritized: lipgloss.Color("#727072"),
+	Read:          lipgloss.Color("#727072"),
+	Favorite:      lipgloss.Color("#FF6188"),
+	Selection:     lipgloss.Color("#78DCE8"),
 }
 
 // LightTheme provides a warm, natural color scheme distinct from cyber aesthetic
@@ -65,6 +89,36 @@ var LightTheme = StyleTheme{
 	Gray:          lipgloss.Color("#64748B"), // Slate gray (vs neutral gray)
 	DarkGray:      lipgloss.Color("#475569"), // Dark slate (vs charcoal)
 	White:         lipgloss.Color("#F1F5F9"), // Slate white (vs stark white)
+	High:          lipgloss.Color("#F43F5E"),
+	Medium:        lipgloss.Color("#FB923C"),
+	Low:           lipgloss.Color("#06B6D4"),
+	Unprioritized: lipgloss.Color("#64748B"),
+	Read:          lipgloss.Color("#64748B"),
+	Favorite:      lipgloss.Color("#EC4899"),
+	Selection:     lipgloss.Color("#06B6D4"),
+}
+
+// DaylightTheme is for light terminal backgrounds: dark text and deeper
+// accents that keep their contrast on white
+var DaylightTheme = StyleTheme{
+	Name:          "daylight",
+	Light:         true,
+	Cyan:          lipgloss.Color("#0369A1"), // Deep sky blue
+	Purple:        lipgloss.Color("#7C3AED"), // Violet
+	VibrantPurple: lipgloss.Color("#BE185D"), // Raspberry
+	Green:         lipgloss.Color("#15803D"), // Forest green
+	Red:           lipgloss.Color("#DC2626"), // Signal red
+	Orange:        lipgloss.Color("#C2410C"), // Burnt orange
+	Gray:          lipgloss.Color("#6B7280"), // Mid gray, still legible on white
+	DarkGray:      lipgloss.Color("#D1D5DB"), // Light borders and header band
+	White:         lipgloss.Color("#1F2937"), // Main text, near black
+	High:          lipgloss.Color("#DC2626"),
+	Medium:        lipgloss.Color("#C2410C"),
+	Low:           lipgloss.Color("#0369A1"),
+	Unprioritized: lipgloss.Color("#6B7280"),
+	Read:          lipgloss.Color("#9CA3AF"),
+	Favorite:      lipgloss.Color("#BE185D"),
+	Selection:     lipgloss.Color("#0369A1"),
 }
 
 // AvailableThemes is a list of all available themes for cycling
@@ -72,6 +126,7 @@ var AvailableThemes = []StyleTheme{
 	CleanCyberTheme,
 	MonokaiProTheme,
 	LightTheme,
+	DaylightTheme,
 }
 
 // Package-level variables for backward compatibility
@@ -97,19 +152,19 @@ func (t StyleTheme) HeaderStyle() lipgloss.Style {
 
 func (t StyleTheme) HighPriorityStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(t.Red).
+		Foreground(t.High).
 		Bold(true)
 }
 
 func (t StyleTheme) MediumPriorityStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(t.Orange).
+		Foreground(t.Medium).
 		Bold(true)
 }
 
 func (t StyleTheme) LowPriorityStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(t.Gray)
+		Foreground(t.Low)
 }
 
 func (t StyleTheme) TagStyle() lipgloss.Style {
@@ -146,10 +201,23 @@ func (t StyleTheme) DimmedStyle() lipgloss.Style {
 
 func (t StyleTheme) SelectedStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(t.Cyan).
+		Foreground(t.Selection).
 		Bold(true)
 }
 
+// PriorityColor is the dot and badge color for an item's priority
+func (t StyleTheme) PriorityColor(priority string) lipgloss.Color {
+	switch priority {
+	case "high":
+		return t.High
+	case "medium":
+		return t.Medium
+	case "low":
+		return t.Low
+	}
+	return t.Unprioritized
+}
+
 // ToGlamourStyle converts our theme to a glamour style config for markdown rendering
 func (t StyleTheme) ToGlamourStyle() ansi.StyleConfig {
 	// Start with a base dark style
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// toastLevel is the severity of a toast notification
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastSuccess
+	toastWarn
+	toastError
+)
+
+const (
+	maxVisibleToasts = 3   // Toasts stacked above the status bar at once
+	maxToastHistory  = 200 // Recent toasts kept for :messages
+)
+
+// toast is one notification; it stays visible until its TTL expires
+type toast struct {
+	id    int
+	level toastLevel
+	text  string
+	at    time.Time
+}
+
+// toastExpiredMsg removes the toast with id once its TTL has passed
+type toastExpiredMsg struct {
+	id int
+}
+
+// notify pushes a toast onto the visible stack and the history, returning
+// the command that expires it after ttl. Unlike statusMessage, concurrent
+// notifications don't clobber each other - each expires on its own.
+func (m *Model) notify(level toastLevel, text string, ttl time.Duration) tea.Cmd {
+	m.toastSeq++
+	t := toast{id: m.toastSeq, level: level, text: text, at: time.Now()}
+
+	// Copy before appending so earlier Model values don't share the backing array
+	m.toasts = appendCapped(m.toasts, t, maxVisibleToasts)
+	m.toastHistory.push(t, maxToastHistory)
+
+	id := t.id
+	return tea.Tick(ttl, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// startStatusOp shows status as the progress of a new operation and returns
+// its handle. Esc cancels that operation and nothing else.
+func (m *Model) startStatusOp(status string) *operations.Op {
+	m.statusMessage = status
+	m.statusOp = operations.NewOp()
+	return m.statusOp
+}
+
+// expireToast drops the toast with id from the visible stack
+func (m *Model) expireToast(id int) {
+	visible := make([]toast, 0, len(m.toasts))
+	for _, t := range m.toasts {
+		if t.id != id {
+			visible = append(visible, t)
+		}
+	}
+	m.toasts = visible
+}
+
+// appendCapped returns a copy of list with t appended, keeping the newest limit entries
+func appendCapped(list []toast, t toast, limit int) []toast {
+	out := make([]toast, 0, limit)
+	if len(list) >= limit {
+		list = list[len(list)-limit+1:]
+	}
+	out = append(out, list...)
+	return append(out, t)
+}
+
+// color returns the theme color for a toast level
+func (l toastLevel) color(theme StyleTheme) lipgloss.Color {
+	switch l {
+	case toastSuccess:
+		return theme.Green
+	case toastWarn:
+		return theme.Orange
+	case toastError:
+		return theme.VibrantPurple // Noticeable but not harsh
+	default:
+		return theme.Cyan
+	}
+}
+
+// label returns the short severity tag shown in :messages
+func (l toastLevel) label() string {
+	switch l {
+	case toastSuccess:
+		return "OK"
+	case toastWarn:
+		return "WARN"
+	case toastError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+// renderToast renders t as one bottom-area line of the given width
+func renderToast(t toast, width int, theme StyleTheme) string {
+	return lipgloss.NewStyle().
+		Foreground(t.level.color(theme)).
+		Width(width).
+		MaxHeight(1).
+		Padding(0, 1).
+		Render(t.text)
+}
+
+// overlayToasts draws toasts over the lines just above the status bar, oldest on top
+func overlayToasts(view string, toasts []toast, width int, theme StyleTheme) string {
+	if len(toasts) == 0 {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	// Last two lines are the status bar and the bottom line
+	start := len(lines) - 2 - len(toasts)
+	if start < 1 {
+		return view
+	}
+	for i, t := range toasts {
+		lines[start+i] = renderToast(t, width, theme)
+	}
+	return strings.Join(lines, "\n")
+}
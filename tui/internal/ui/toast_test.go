@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestNotify_ConcurrentToastsDontClobber verifies overlapping notifications each stay until their own TTL.
+// BREAKS: If toasts share one slot, auto-refresh overwrites "Exported sources" before it's read.
+func TestNotify_ConcurrentToastsDontClobber(t *testing.T) {
+	m := Model{}
+	m.notify(toastSuccess, "Exported sources", 5*time.Second)
+	m.notify(toastSuccess, "✓ Auto-refreshed", 3*time.Second)
+
+	if len(m.toasts) != 2 {
+		t.Fatalf("Expected 2 visible toasts, got %d", len(m.toasts))
+	}
+
+	// The auto-refresh toast expires first; the export toast survives
+	updated, _ := m.Update(toastExpiredMsg{id: m.toasts[1].id})
+	m = updated.(Model)
+	if len(m.toasts) != 1 || m.toasts[0].text != "Exported sources" {
+		t.Errorf("Expected only the export toast left, got %+v", m.toasts)
+	}
+	if history := m.toastHistory.list(); len(history) != 2 {
+		t.Errorf("Expected expired toast kept in history, got %d entries", len(history))
+	}
+}
+
+// TestNotify_CapsStackAndHistory verifies the visible stack and history stay bounded.
+// BREAKS: If unbounded, a burst of errors pushes the feed off screen and grows memory forever.
+func TestNotify_CapsStackAndHistory(t *testing.T) {
+	m := Model{}
+	for i := 0; i < maxToastHistory+10; i++ {
+		m.notify(toastInfo, fmt.Sprintf("toast %d", i), time.Second)
+	}
+
+	if len(m.toasts) != maxVisibleToasts {
+		t.Errorf("Expected %d visible toasts, got %d", maxVisibleToasts, len(m.toasts))
+	}
+	history := m.toastHistory.list()
+	if len(history) != maxToastHistory {
+		t.Errorf("Expected %d history entries, got %d", maxToastHistory, len(history))
+	}
+	if first := history[0].text; first != "toast 10" {
+		t.Errorf("Expected the oldest kept toast first in history, got %q", first)
+	}
+	if last := history[len(history)-1].text; last != fmt.Sprintf("toast %d", maxToastHistory+9) {
+		t.Errorf("Expected newest toast last in history, got %q", last)
+	}
+}
+
+// TestMessagesMsg_OpensModal verifies :messages shows the toast history.
+// BREAKS: If not wired, expired notifications can't be reviewed.
+func TestMessagesMsg_OpensModal(t *testing.T) {
+	m := Model{}
+	m.notify(toastError, "Prune failed: boom", time.Second)
+
+	updated, _ := m.Update(commands.MessagesMsg{})
+	m = updated.(Model)
+	if !m.messagesModal.IsVisible() || len(m.messagesModal.entries) != 1 {
+		t.Errorf("Expected messages modal with 1 entry, visible=%v entries=%d", m.messagesModal.IsVisible(), len(m.messagesModal.entries))
+	}
+}
+
+// TestMessagesModal_Scrolls verifies the history opens on the newest messages and scrolls back to the oldest.
+// BREAKS: If the modal only shows what fits, older failures in the 200-entry history can't be read.
+func TestMessagesModal_Scrolls(t *testing.T) {
+	m := Model{}
+	for i := 0; i < 30; i++ {
+		m.notify(toastInfo, fmt.Sprintf("message %02d", i), time.Second)
+	}
+	modal := NewMessagesModal()
+	modal.SetSize(100, 20)
+	modal.Open(m.toastHistory.list())
+
+	view := modal.ViewWithOverlay("", 100, 20, CleanCyberTheme)
+	if !strings.Contains(view, "message 29") || strings.Contains(view, "message 00") {
+		t.Errorf("Expected the newest messages first, got %q", view)
+	}
+
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	view = modal.ViewWithOverlay("", 100, 20, CleanCyberTheme)
+	if !strings.Contains(view, "message 00") || strings.Contains(view, "message 29") {
+		t.Errorf("Expected g to scroll to the oldest, got %q", view)
+	}
+	if !strings.Contains(view, "1-6 of 30") {
+		t.Errorf("Expected the window position shown, got %q", view)
+	}
+
+	modal, _ = modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if modal.scroll != 0 {
+		t.Errorf("Expected G back at the newest, scroll=%d", modal.scroll)
+	}
+}
+
+// TestEsc_CancelsOnlyTheStatusOperation verifies Esc in the list aborts the operation the status line reports and nothing else.
+// BREAKS: If Esc cancels everything in flight, leaving a view kills a background sync or a briefing started elsewhere.
+func TestEsc_CancelsOnlyTheStatusOperation(t *testing.T) {
+	background, release := operations.Cancellable() // e.g. an auto-refresh sync
+	defer release()
+
+	m := Model{view: "list", listFilter: NewListFilter()}
+	summarize, done := m.startStatusOp("Summarizing...").Start()
+	defer done()
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if summarize.Err() == nil {
+		t.Error("Expected Esc to cancel the operation the status line shows")
+	}
+	if background.Err() != nil {
+		t.Error("Expected the unrelated operation to keep running")
+	}
+	if m.statusMessage != "" || len(m.toasts) != 1 || m.toasts[0].text != "Cancelled" {
+		t.Errorf("Expected the status cleared and one Cancelled toast, got %q and %+v", m.statusMessage, m.toasts)
+	}
+
+	// Nothing left to cancel: a second Esc is just Esc
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); len(m.toasts) != 1 || background.Err() != nil {
+		t.Errorf("Expected the second Esc to do nothing, got %+v", m.toasts)
+	}
+}
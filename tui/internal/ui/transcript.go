@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// transcriptOpenMsg asks the model to open a briefing source in the reader
+type transcriptOpenMsg struct {
+	url string
+}
+
+// transcriptItemsMsg carries every item so a briefing source outside the
+// current view can still be found by URL
+type transcriptItemsMsg struct {
+	url   string
+	items []db.ContentItem
+	err   error
+}
+
+// loadTranscriptItems fetches all items in the current archive scope. Remote
+// mode already holds them in itemsCache; local mode reads them from the database.
+func loadTranscriptItems(m Model, url string) tea.Cmd {
+	if m.remoteURL != "" {
+		items := m.itemsCache
+		return func() tea.Msg {
+			return transcriptItemsMsg{url: url, items: items}
+		}
+	}
+	showArchived := m.showArchived
+	return func() tea.Msg {
+		items, err := db.GetAllContent(showArchived)
+		return transcriptItemsMsg{url: url, items: items, err: err}
+	}
+}
+
+// buildTranscript renders a briefing's script followed by its numbered sources
+func buildTranscript(t *api.BriefingTranscript) string {
+	var doc strings.Builder
+	heading := "Audio Briefing"
+	if generated, err := time.Parse(time.RFC3339, t.GeneratedAt); err == nil {
+		heading += ": " + generated.Local().Format("Monday, January 2, 15:04")
+	}
+	fmt.Fprintf(&doc, "# %s\n\n", heading)
+
+	script := strings.TrimSpace(t.Script)
+	if script == "" {
+		script = "The briefing has no script."
+	}
+	doc.WriteString(script)
+	doc.WriteString("\n\n")
+
+	if len(t.Items) == 0 {
+		return doc.String()
+	}
+	doc.WriteString("## Sources\n\n")
+	for i, item := range t.Items {
+		fmt.Fprintf(&doc, "### %d. %s\n\n", i+1, item.Title)
+		meta := []string{}
+		if item.SourceName != "" {
+			meta = append(meta, item.SourceName)
+		}
+		if item.Priority != "" {
+			meta = append(meta, strings.ToUpper(item.Priority))
+		}
+		if item.URL != "" {
+			meta = append(meta, item.URL)
+		}
+		if len(meta) > 0 {
+			fmt.Fprintf(&doc, "*%s*\n\n", strings.Join(meta, " · "))
+		}
+	}
+	return doc.String()
+}
+
+// TranscriptModal shows what an audio briefing said and links its sources,
+// for skimming instead of listening (:transcript)
+type TranscriptModal struct {
+	Modal      // Embed base modal
+	viewport   viewport.Model
+	transcript *api.BriefingTranscript
+	markdown   string
+}
+
+// NewTranscriptModal creates a new TranscriptModal instance
+func NewTranscriptModal() TranscriptModal {
+	return TranscriptModal{
+		Modal:    NewModal("BRIEFING TRANSCRIPT", 80, 20), // Will be sized dynamically
+		viewport: viewport.New(76, 14),
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *TranscriptModal) SetSize(width, height int) {
+	modalWidth := min(max(60, width*3/4), width-4)
+	modalHeight := max(10, height-6)
+	m.Modal.width = modalWidth
+	m.Modal.height = modalHeight
+	// Title, its margin, the blank line, and the footer hint take four rows
+	m.viewport.Width = modalWidth - 4
+	m.viewport.Height = max(1, modalHeight-6)
+	if m.markdown != "" {
+		m.viewport.SetContent(renderSimpleMarkdown(m.markdown, m.viewport.Width))
+	}
+}
+
+// Open shows the modal with the rendered transcript, scrolled to the top
+func (m *TranscriptModal) Open(transcript *api.BriefingTranscript) {
+	m.transcript = transcript
+	m.markdown = buildTranscript(transcript)
+	m.viewport.SetContent(renderSimpleMarkdown(m.markdown, m.viewport.Width))
+	m.viewport.GotoTop()
+	m.Show()
+}
+
+// Update handles input for the transcript modal. Digits open the numbered
+// source in the reader.
+func (m TranscriptModal) Update(msg tea.Msg) (TranscriptModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch key := msg.String(); key {
+		case "esc", "q":
+			m.Hide()
+		case "j", "down":
+			m.viewport.ScrollDown(1)
+		case "k", "up":
+			m.viewport.ScrollUp(1)
+		case " ", "pgdown", "ctrl+d":
+			m.viewport.HalfPageDown()
+		case "b", "pgup", "ctrl+u":
+			m.viewport.HalfPageUp()
+		case "g", "home":
+			m.viewport.GotoTop()
+		case "G", "end":
+			m.viewport.GotoBottom()
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			n := int(key[0] - '0')
+			if m.transcript == nil || n > len(m.transcript.Items) {
+				return m, nil
+			}
+			url := m.transcript.Items[n-1].URL
+			m.Hide()
+			return m, func() tea.Msg { return transcriptOpenMsg{url: url} }
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// ViewWithOverlay renders the transcript over the background
+func (m TranscriptModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	body := lipgloss.NewStyle().Width(m.viewport.Width).Align(lipgloss.Left).Render(m.viewport.View())
+	hint := fmt.Sprintf("j/k scroll · Space page · %d%% · ESC close", int(m.viewport.ScrollPercent()*100))
+	if m.transcript != nil && len(m.transcript.Items) > 0 {
+		hint = fmt.Sprintf("1-%d open source · ", min(9, len(m.transcript.Items))) + hint
+	}
+
+	modal := m.Modal
+	modal.SetContent(body + "\n\n" + lipgloss.NewStyle().Foreground(theme.Gray).Italic(true).Render(hint))
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
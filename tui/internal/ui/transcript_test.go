@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nickpending/prismis/internal/api"
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/ui/operations"
+)
+
+// TestTranscript_ListsNumberedSources verifies the transcript shows the script followed by its numbered sources.
+// BREAKS: If sources are dropped, a skimmed briefing can't lead back to the articles it mentions.
+func TestTranscript_ListsNumberedSources(t *testing.T) {
+	doc := buildTranscript(&api.BriefingTranscript{
+		GeneratedAt: "2026-03-02T08:00:00Z",
+		Script:      "Good morning. Two stories today.",
+		Items: []api.TranscriptItem{
+			{Title: "Go 1.26", URL: "https://go.dev/blog", SourceName: "Go Blog", Priority: "high"},
+			{Title: "Rust 2027", URL: "https://blog.rust-lang.org"},
+		},
+	})
+	for _, want := range []string{"# Audio Briefing: ", "Good morning. Two stories today.", "## Sources", "### 1. Go 1.26", "*Go Blog · HIGH · https://go.dev/blog*", "### 2. Rust 2027"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected transcript to contain %q, got:\n%s", want, doc)
+		}
+	}
+}
+
+// TestTranscript_DigitOpensSourceInReader verifies a number key opens that source's article in the reader.
+// BREAKS: If digits fall through to the list, 2 changes the view instead of opening the second story.
+func TestTranscript_DigitOpensSourceInReader(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{
+		{ID: "a", Title: "Other", URL: "https://example.com"},
+		{ID: "b", Title: "Rust 2027", URL: "https://blog.rust-lang.org"},
+	})
+	m.width, m.height = 120, 40
+	m.transcript = NewTranscriptModal()
+
+	updated, _ := m.Update(operations.BriefingTranscriptMsg{Transcript: &api.BriefingTranscript{
+		Script: "Briefing.",
+		Items: []api.TranscriptItem{
+			{Title: "Go 1.26", URL: "https://go.dev/blog"},
+			{Title: "Rust 2027", URL: "https://blog.rust-lang.org"},
+		},
+	}})
+	m = updated.(Model)
+	if !m.transcript.IsVisible() || !strings.Contains(m.transcript.viewport.View(), "Briefing.") {
+		t.Fatalf("Expected the transcript modal, got %q", m.transcript.viewport.View())
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = updated.(Model)
+	if cmd == nil || m.transcript.IsVisible() {
+		t.Fatal("Expected 2 to close the transcript and open its second source")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.view != "reader" || m.cursor != 1 {
+		t.Errorf("Expected the reader on the Rust item, got view=%s cursor=%d", m.view, m.cursor)
+	}
+}
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// Triage verdicts, in the order the summary lists them
+const (
+	verdictRead     = "read"
+	verdictLater    = "later"
+	verdictFavorite = "favorite"
+	verdictMute     = "mute"
+	verdictSkip     = "skip"
+)
+
+var triageVerdicts = []string{verdictRead, verdictLater, verdictFavorite, verdictMute, verdictSkip}
+
+// triageKeys maps single keys to verdicts
+var triageKeys = map[string]string{
+	"r": verdictRead,
+	"l": verdictLater,
+	"f": verdictFavorite,
+	"m": verdictMute,
+	"s": verdictSkip,
+	" ": verdictSkip,
+}
+
+// triageVerdictMsg asks the model to apply a verdict to an item
+type triageVerdictMsg struct {
+	item    db.ContentItem
+	verdict string
+}
+
+// TriageModal steps through unread items one at a time (:triage)
+type TriageModal struct {
+	Modal // Embed base modal
+	queue []db.ContentItem
+	pos   int
+	tally map[string]int
+	muted int  // Items dropped because their source was muted
+	done  bool // Showing the end-of-session summary
+}
+
+// NewTriageModal creates a new TriageModal instance
+func NewTriageModal() TriageModal {
+	return TriageModal{
+		Modal: NewModal("TRIAGE", 80, 20), // Will be sized dynamically
+	}
+}
+
+// SetSize updates the modal size based on terminal dimensions
+func (m *TriageModal) SetSize(width, height int) {
+	m.Modal.width = min(max(60, width*2/3), width-4)
+	m.Modal.height = min(max(14, height*2/3), height-4)
+}
+
+// Start opens a session over the unread items in items
+func (m *TriageModal) Start(items []db.ContentItem) {
+	m.queue = nil
+	for _, item := range items {
+		if !item.Read {
+			m.queue = append(m.queue, item)
+		}
+	}
+	m.pos = 0
+	m.tally = map[string]int{}
+	m.muted = 0
+	m.done = len(m.queue) == 0
+	m.Show()
+}
+
+// current returns the item being triaged
+func (m TriageModal) current() (db.ContentItem, bool) {
+	if m.done || m.pos >= len(m.queue) {
+		return db.ContentItem{}, false
+	}
+	return m.queue[m.pos], true
+}
+
+// decide records verdict for the current item and advances. Muting drops
+// the rest of that source's items from the session.
+func (m *TriageModal) decide(verdict string) {
+	item := m.queue[m.pos]
+	m.tally[verdict]++
+	if verdict == verdictMute && item.SourceID != "" {
+		rest := m.queue[:m.pos+1]
+		for _, next := range m.queue[m.pos+1:] {
+			if next.SourceID == item.SourceID {
+				m.muted++
+				continue
+			}
+			rest = append(rest, next)
+		}
+		m.queue = rest
+	}
+	m.pos++
+	if m.pos >= len(m.queue) {
+		m.done = true
+	}
+}
+
+// Update handles input for the triage modal
+func (m TriageModal) Update(msg tea.Msg) (TriageModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.done {
+			// Any key dismisses the summary
+			m.Hide()
+			return m, nil
+		}
+		key := msg.String()
+		if key == "esc" || key == "q" {
+			m.done = true
+			return m, nil
+		}
+		verdict, ok := triageKeys[key]
+		if !ok {
+			return m, nil
+		}
+		item := m.queue[m.pos]
+		m.decide(verdict)
+		return m, func() tea.Msg {
+			return triageVerdictMsg{item: item, verdict: verdict}
+		}
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	}
+	return m, nil
+}
+
+// triageProgressBar renders "████░░░░ 12/87"
+func triageProgressBar(done, total, width int, theme StyleTheme) string {
+	label := fmt.Sprintf(" %d/%d", done, total)
+	barWidth := max(10, width-len(label))
+	filled := 0
+	if total > 0 {
+		filled = barWidth * done / total
+	}
+	return lipgloss.NewStyle().Foreground(theme.Cyan).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(theme.DarkGray).Render(strings.Repeat("░", barWidth-filled)) +
+		lipgloss.NewStyle().Foreground(theme.Gray).Render(label)
+}
+
+// ViewWithOverlay renders the current card, or the summary, over the background
+func (m TriageModal) ViewWithOverlay(backgroundView string, width, height int, theme StyleTheme) string {
+	if !m.visible {
+		return backgroundView
+	}
+
+	lineWidth := m.Modal.width - 4 // Inside padding
+	// Lines are padded to full width so the base modal's centering leaves them left-aligned
+	lineStyle := lipgloss.NewStyle().Width(lineWidth)
+	grayStyle := lipgloss.NewStyle().Foreground(theme.Gray)
+	hintStyle := grayStyle.Italic(true)
+
+	var content strings.Builder
+	content.WriteString(triageProgressBar(m.pos, len(m.queue), lineWidth, theme))
+	content.WriteString("\n\n")
+
+	item, ok := m.current()
+	if !ok {
+		content.WriteString(lineStyle.Render(m.renderSummary(theme)))
+		content.WriteString("\n\n")
+		content.WriteString(hintStyle.Render("Press any key to close"))
+	} else {
+		priority := strings.ToUpper(item.Priority)
+		if priority == "" {
+			priority = "UNPRIORITIZED"
+		}
+		content.WriteString(lineStyle.Render(
+			lipgloss.NewStyle().Foreground(theme.White).Bold(true).Render(item.Title)))
+		content.WriteString("\n")
+		content.WriteString(lineStyle.Render(grayStyle.Render(priority + " · " + item.SourceName)))
+		content.WriteString("\n\n")
+
+		summary := item.ParsedAnalysis().ReadingSummary
+		if summary == "" {
+			summary = item.Summary
+		}
+		// Title, meta, progress, hints, and margins take about eleven rows
+		bodyHeight := max(1, m.Modal.height-11)
+		body := lipgloss.NewStyle().Width(lineWidth).Height(bodyHeight).MaxHeight(bodyHeight).
+			Render(wrapText(summary, lineWidth))
+		content.WriteString(body)
+		content.WriteString("\n\n")
+		content.WriteString(hintStyle.Render("r read · l later · f favorite · m mute source · s skip · q finish"))
+	}
+
+	modal := m.Modal
+	modal.SetContent(content.String())
+	return modal.ViewWithOverlay(backgroundView, width, height, theme)
+}
+
+// renderSummary lists the session's verdict counts
+func (m TriageModal) renderSummary(theme StyleTheme) string {
+	var lines []string
+	lines = append(lines, lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true).
+		Render(fmt.Sprintf("Triaged %d of %d", m.pos, len(m.queue)+m.muted)))
+	for _, verdict := range triageVerdicts {
+		if n := m.tally[verdict]; n > 0 {
+			lines = append(lines, fmt.Sprintf("  %-9s %d", verdict, n))
+		}
+	}
+	if m.muted > 0 {
+		lines = append(lines, fmt.Sprintf("  %-9s %d (from muted sources)", "dropped", m.muted))
+	}
+	if left := len(m.queue) - m.pos; left > 0 {
+		lines = append(lines, fmt.Sprintf("  %-9s %d", "untouched", left))
+	}
+	return strings.Join(lines, "\n")
+}
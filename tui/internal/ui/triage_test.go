@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestTriage_StepsThroughUnreadWithVerdicts verifies single-key verdicts advance the session and are tallied.
+// BREAKS: If a verdict doesn't advance or reach the model, the backlog never shrinks.
+func TestTriage_StepsThroughUnreadWithVerdicts(t *testing.T) {
+	m := Model{width: 120, height: 40, view: "list", triageModal: NewTriageModal(), items: []db.ContentItem{
+		{ID: "a", Title: "Alpha", SourceID: "s1"},
+		{ID: "done", Title: "Already read", Read: true},
+		{ID: "b", Title: "Beta", SourceID: "s2"},
+		{ID: "c", Title: "Gamma", SourceID: "s2"},
+		{ID: "d", Title: "Delta", SourceID: "s3"},
+	}}
+
+	updated, _ := m.Update(commands.TriageMsg{})
+	m = updated.(Model)
+	if !m.triageModal.IsVisible() || len(m.triageModal.queue) != 4 {
+		t.Fatalf("Expected a session over the 4 unread items, got %d", len(m.triageModal.queue))
+	}
+	if view := m.View(); !strings.Contains(view, "Alpha") || !strings.Contains(view, "0/4") {
+		t.Error("Expected the first card with 0/4 progress")
+	}
+
+	press := func(key string) tea.Msg {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+		if cmd == nil {
+			return nil
+		}
+		return cmd()
+	}
+
+	if msg, ok := press("r").(triageVerdictMsg); !ok || msg.item.ID != "a" || msg.verdict != verdictRead {
+		t.Errorf("Expected a read verdict for Alpha, got %+v", msg)
+	}
+	// Muting Beta's source drops Gamma too
+	if msg, ok := press("m").(triageVerdictMsg); !ok || msg.item.ID != "b" || msg.verdict != verdictMute {
+		t.Errorf("Expected a mute verdict for Beta, got %+v", msg)
+	}
+	if item, _ := m.triageModal.current(); item.ID != "d" {
+		t.Errorf("Expected the muted source's other items to be skipped, got %s", item.ID)
+	}
+	press("l")
+
+	if !m.triageModal.done {
+		t.Fatal("Expected the summary after the last item")
+	}
+	summary := m.triageModal.renderSummary(CleanCyberTheme)
+	for _, want := range []string{"Triaged 3 of 4", "read", "mute", "later", "dropped"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected summary to mention %q, got:\n%s", want, summary)
+		}
+	}
+
+	press("x")
+	if m.triageModal.IsVisible() {
+		t.Error("Expected any key to close the summary")
+	}
+}
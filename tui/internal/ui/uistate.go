@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// uiState is the layout that survives restarts. Zero values mean defaults
+// so a missing or old file loads cleanly.
+type uiState struct {
+	SidebarHidden  bool              `json:"sidebar_hidden"`
+	SidebarPercent int               `json:"sidebar_percent"`
+	Density        string            `json:"density,omitempty"`  // "compact" or "comfortable"; empty is comfortable
+	Sorts          map[string]string `json:"sorts,omitempty"`    // :sort order by priority view
+	Filters        map[string]string `json:"filters,omitempty"`  // Last :filter expression by priority view
+	Searches       map[string]string `json:"searches,omitempty"` // Saved :search expressions by name
+}
+
+// uiStateSaveFailedMsg reports that the layout couldn't be persisted
+type uiStateSaveFailedMsg struct {
+	err error
+}
+
+// uiStatePathFunc returns the state file location (overridable for testing)
+var uiStatePathFunc = defaultUIStatePath
+
+// defaultUIStatePath keeps UI state next to the local database
+func defaultUIStatePath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "prismis", "ui_state.json"), nil
+}
+
+// loadUIState reads the saved layout, returning defaults when there is none
+func loadUIState() (uiState, error) {
+	path, err := uiStatePathFunc()
+	if err != nil {
+		return uiState{}, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return uiState{}, nil
+	}
+	if err != nil {
+		return uiState{}, fmt.Errorf("failed to read UI state: %w", err)
+	}
+	var state uiState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return uiState{}, fmt.Errorf("failed to parse UI state: %w", err)
+	}
+	return state, nil
+}
+
+// saveUIState writes the layout in the background
+func saveUIState(state uiState) tea.Cmd {
+	return func() tea.Msg {
+		path, err := uiStatePathFunc()
+		if err != nil {
+			return uiStateSaveFailedMsg{err: err}
+		}
+		data, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return uiStateSaveFailedMsg{err: fmt.Errorf("failed to encode UI state: %w", err)}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return uiStateSaveFailedMsg{err: fmt.Errorf("failed to create state directory: %w", err)}
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return uiStateSaveFailedMsg{err: fmt.Errorf("failed to write UI state: %w", err)}
+		}
+		return nil
+	}
+}
+
+// savedLayout captures the persisted parts of the layout
+func (m Model) savedLayout() uiState {
+	return uiState{SidebarHidden: m.hideSidebar, SidebarPercent: m.sidebarPercent, Density: m.density(), Sorts: m.viewSorts, Filters: m.viewExprs, Searches: m.savedSearches}
+}
+
+// density names the list row density for :set and the state file
+func (m Model) density() string {
+	if m.compact {
+		return "compact"
+	}
+	return "comfortable"
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"github.com/nickpending/prismis/internal/db"
+	"github.com/nickpending/prismis/internal/filter"
+)
+
+// filterExpr is the :filter expression the current priority view keeps, or
+// nil when it has none (or the saved one no longer parses)
+func (m Model) filterExpr() *filter.Expr {
+	text, ok := m.viewExprs[m.priority]
+	if !ok {
+		return nil
+	}
+	expr, err := filter.Parse(text)
+	if err != nil {
+		return nil
+	}
+	return expr
+}
+
+// setFilterExpr keeps expr as the current view's expression; empty clears it
+func (m *Model) setFilterExpr(expr string) {
+	if expr == "" {
+		delete(m.viewExprs, m.priority)
+		return
+	}
+	if m.viewExprs == nil {
+		m.viewExprs = make(map[string]string)
+	}
+	m.viewExprs[m.priority] = expr
+}
+
+// filterPredicates narrows a local query to the view's expression and the
+// running saved search, so the database skips what they would hide
+func (m Model) filterPredicates() []db.Predicate {
+	var predicates []db.Predicate
+	if expr := m.filterExpr(); expr != nil {
+		predicates = append(predicates, expr)
+	}
+	if search := m.searchExpr(); search != nil {
+		predicates = append(predicates, search)
+	}
+	return predicates
+}
+
+// needsContent reports whether the view's expression or the running search
+// reads article text, which list queries otherwise leave out
+func (m Model) needsContent() bool {
+	for _, expr := range []*filter.Expr{m.filterExpr(), m.searchExpr()} {
+		if expr != nil && expr.NeedsContent() {
+			return true
+		}
+	}
+	return false
+}
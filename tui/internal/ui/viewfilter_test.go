@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestFilterExpr_PersistsPerView verifies a :filter expression narrows only the view it was set in, survives a restart, and clears with bare :filter.
+// BREAKS: If expressions aren't keyed by view, filtering HIGH to unread Rust posts empties every other view too.
+func TestFilterExpr_PersistsPerView(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ui_state.json")
+	uiStatePathFunc = func() (string, error) { return path, nil }
+	defer func() { uiStatePathFunc = defaultUIStatePath }()
+
+	items := []db.ContentItem{
+		{ID: "rust", Priority: "high", SourceName: "This Week in Rust", Content: strings.Repeat("x", 3000)},
+		{ID: "rust-read", Priority: "high", SourceName: "This Week in Rust", Content: strings.Repeat("x", 3000), Read: true},
+		{ID: "go", Priority: "high", SourceName: "r/golang", Content: strings.Repeat("x", 3000)},
+	}
+	// Remote mode so the refetch reads the cache rather than a database
+	m := Model{view: "list", priority: "high", filterType: "all", showAll: true, remoteURL: "http://localhost:8989", itemsCache: items}
+
+	updated, cmd := m.Update(commands.FilterMsg{Field: "expr", Value: "source~rust AND length>2000 AND NOT read"})
+	m = updated.(Model)
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if msg != nil {
+			msg()
+		}
+	}
+	if got := applyFiltersClientSide(items, m); len(got) != 1 || got[0].ID != "rust" {
+		t.Errorf("Expected only the unread Rust item, got %v", got)
+	}
+	if state := buildViewStateString(m); !strings.Contains(state, "Where: source~rust AND length>2000 AND NOT read") {
+		t.Errorf("Expected the expression in the view state, got %q", state)
+	}
+
+	saved, err := loadUIState()
+	if err != nil || saved.Filters["high"] != "source~rust AND length>2000 AND NOT read" {
+		t.Fatalf("Expected the HIGH expression saved, got %v (%v)", saved.Filters, err)
+	}
+
+	// Other views aren't narrowed
+	m.priority = "all"
+	if got := applyFiltersClientSide(items, m); len(got) != 3 {
+		t.Errorf("Expected ALL unfiltered, got %d items", len(got))
+	}
+
+	// Bare :filter in HIGH drops its expression for good
+	m.priority = "high"
+	updated, cmd = m.Update(commands.FilterMsg{})
+	m = updated.(Model)
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if msg != nil {
+			msg()
+		}
+	}
+	if m.filterExpr() != nil {
+		t.Errorf("Expected bare :filter to clear the expression, got %v", m.filterExpr())
+	}
+	if saved, _ := loadUIState(); len(saved.Filters) != 0 {
+		t.Errorf("Expected no saved expressions, got %v", saved.Filters)
+	}
+}
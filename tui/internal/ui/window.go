@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// windowCommands are the keys accepted after Ctrl-W, in popup order
+var windowCommands = []struct {
+	key  string
+	desc string
+}{
+	{"h", "Focus sources"},
+	{"l", "Focus content"},
+	{"j", "Focus preview"},
+	{"k", "Focus list"},
+	{"w", "Next pane"},
+	{"=", "Reset sidebar width"},
+	{"s", "Toggle preview split"},
+}
+
+// windowCommand runs the key pressed after Ctrl-W. Vim's Ctrl-W Ctrl-H style
+// works too; any other key cancels.
+func (m Model) windowCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.windowPending = false
+	m.statusMessage = ""
+
+	switch strings.TrimPrefix(msg.String(), "ctrl+") {
+	case "h":
+		if !m.hideSidebar {
+			m.focusedPane = "sources"
+		}
+	case "l", "k":
+		m.focusedPane = "content"
+	case "j":
+		if m.previewActive() {
+			m.focusedPane = "preview"
+		}
+	case "w":
+		m.cyclePane()
+	case "=":
+		m.sidebarPercent = 0
+		m.relayout()
+		return m, saveUIState(m.savedLayout())
+	case "s":
+		m.showPreview = !m.showPreview
+		m.previewItemID = "" // Re-render at the new size
+		m.syncPreview()
+	}
+	return m, nil
+}
+
+// overlayWindowHints draws the which-key popup of Ctrl-W commands in the
+// bottom-right corner, just above the status bar
+func overlayWindowHints(view string, width int, theme StyleTheme) string {
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Purple).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(theme.White)
+	rows := make([]string, len(windowCommands))
+	for i, c := range windowCommands {
+		rows[i] = keyStyle.Render(c.key) + "  " + descStyle.Render(c.desc)
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Cyan).
+		Padding(0, 1).
+		Render(strings.Join(rows, "\n"))
+
+	lines := strings.Split(view, "\n")
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	// Last two lines are the status bar and the bottom line
+	start := len(lines) - 2 - len(boxLines)
+	if start < 1 || boxWidth > width {
+		return view
+	}
+	for i, boxLine := range boxLines {
+		left := ansi.Truncate(lines[start+i], width-boxWidth-1, "")
+		lines[start+i] = left + strings.Repeat(" ", max(0, width-boxWidth-1-lipgloss.Width(left))) + boxLine
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// ctrlW presses Ctrl-W, then keys
+func ctrlW(m Model, keys ...tea.KeyMsg) Model {
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	m = updated.(Model)
+	for _, key := range keys {
+		updated, _ = m.Update(key)
+		m = updated.(Model)
+	}
+	return m
+}
+
+// TestWindowCommand_WaitsForSecondKey verifies Ctrl-W shows its options and the next key picks the command.
+// BREAKS: If Ctrl-W relies on compound key strings again, none of the window commands are reachable.
+func TestWindowCommand_WaitsForSecondKey(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "a", Title: "A"}})
+	m.focusedPane = "content"
+
+	m = ctrlW(m)
+	if !m.windowPending || !strings.Contains(m.View(), "Reset sidebar width") {
+		t.Fatal("Expected Ctrl-W to wait with the which-key popup showing")
+	}
+
+	m = ctrlW(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if m.windowPending || m.focusedPane != "sources" {
+		t.Errorf("Expected Ctrl-W h to focus sources, got %q", m.focusedPane)
+	}
+	if strings.Contains(m.View(), "Reset sidebar width") {
+		t.Error("Expected the popup gone after the command")
+	}
+
+	// Ctrl-W Ctrl-L works like Ctrl-W l
+	if m = ctrlW(m, tea.KeyMsg{Type: tea.KeyCtrlL}); m.focusedPane != "content" {
+		t.Errorf("Expected Ctrl-W Ctrl-L to focus content, got %q", m.focusedPane)
+	}
+
+	m = ctrlW(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if !m.showPreview {
+		t.Fatal("Expected Ctrl-W s to split the list with the preview")
+	}
+	if m = ctrlW(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}); m.focusedPane != "preview" {
+		t.Errorf("Expected Ctrl-W j to focus the preview, got %q", m.focusedPane)
+	}
+
+	m.sidebarPercent = 40
+	if m = ctrlW(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("=")}); m.sidebarRatio() != defaultSidebarPercent {
+		t.Errorf("Expected Ctrl-W = to reset the sidebar, got %d%%", m.sidebarRatio())
+	}
+}
+
+// TestWindowCommand_OtherKeyCancels verifies an unknown key after Ctrl-W cancels without running as a normal key.
+// BREAKS: If the pending state leaks, Ctrl-W followed by q quits the app.
+func TestWindowCommand_OtherKeyCancels(t *testing.T) {
+	m := testModelWithItems([]db.ContentItem{{ID: "a"}})
+	m.focusedPane = "content"
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	updated, cmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = updated.(Model)
+	if m.windowPending || m.statusMessage != "" || cmd != nil {
+		t.Errorf("Expected q to just cancel the window command, pending=%v status=%q", m.windowPending, m.statusMessage)
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// zenReadingWidth is the article column width in zen mode, about 80
+// characters of text once padding is taken off
+const zenReadingWidth = 84
+
+// zenActive reports whether zen mode is showing the reader full screen
+func (m Model) zenActive() bool {
+	return m.zen && m.view == "reader"
+}
+
+// toggleZen enters or leaves zen mode. Entering from the list opens the
+// item under the cursor.
+func (m *Model) toggleZen() {
+	if m.zenActive() {
+		m.zen = false
+		m.relayout()
+		return
+	}
+	if len(m.items) == 0 || m.cursor >= len(m.items) {
+		return
+	}
+	m.zen = true
+	m.view = "reader"
+	m.focusedPane = "content"
+	m.relayout()
+}
+
+// renderZen renders the reader alone, centered below a blank top margin
+// and above the command line
+func renderZen(m Model, width int, bottomLine string, theme StyleTheme) string {
+	l := m.layout()
+	article := lipgloss.NewStyle().
+		Width(l.contentWidth).
+		Height(l.contentHeight).
+		MaxHeight(l.contentHeight).
+		Padding(0, 1).
+		Render(renderReaderContent(m, l.contentWidth, l.contentHeight, theme))
+	page := lipgloss.Place(width, l.contentHeight, lipgloss.Center, lipgloss.Top, article)
+	return lipgloss.JoinVertical(lipgloss.Left, "", page, bottomLine)
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nickpending/prismis/internal/commands"
+	"github.com/nickpending/prismis/internal/db"
+)
+
+// TestZen_HidesChromeAndRestoresOnExit verifies Z opens a centered full-screen reader and Esc restores the layout.
+// BREAKS: If zen outlives the reader, the list renders without its sidebar and header.
+func TestZen_HidesChromeAndRestoresOnExit(t *testing.T) {
+	m := Model{width: 160, height: 40, view: "list", focusedPane: "sources", theme: CleanCyberTheme,
+		items: []db.ContentItem{{ID: "a", Title: "Calm Article", Summary: "quiet words"}}}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	m = updated.(Model)
+	if !m.zenActive() || m.view != "reader" {
+		t.Fatalf("Expected Z to open the reader in zen mode, got view=%s zen=%v", m.view, m.zen)
+	}
+	l := m.layout()
+	if l.sidebarWidth != 0 || l.contentWidth != zenReadingWidth || l.contentHeight != m.height-2 {
+		t.Errorf("Expected a %d-column full-height column, got %+v", zenReadingWidth, l)
+	}
+
+	view := m.View()
+	if strings.Contains(view, "PRISMIS") || strings.Contains(view, "Press ? for help") {
+		t.Error("Expected zen to hide the header and status bar")
+	}
+	if !strings.Contains(view, "Calm Article") {
+		t.Error("Expected zen to show the article")
+	}
+	line := strings.Split(view, "\n")[3]
+	if !strings.HasPrefix(line, strings.Repeat(" ", (m.width-zenReadingWidth)/2)) {
+		t.Errorf("Expected the article column to be centered, got %q", line)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.zen || m.layout().sidebarWidth == 0 {
+		t.Errorf("Expected leaving the reader to restore the normal layout, got zen=%v %+v", m.zen, m.layout())
+	}
+
+	updated, _ = m.Update(commands.ZenMsg{})
+	m = updated.(Model)
+	updated, _ = m.Update(commands.ZenMsg{})
+	m = updated.(Model)
+	if m.zen || m.view != "reader" {
+		t.Errorf("Expected :zen twice to leave zen but stay reading, got view=%s zen=%v", m.view, m.zen)
+	}
+}